@@ -0,0 +1,22 @@
+package client
+
+import (
+	"context"
+	"net/http"
+)
+
+// Login exchanges a username and password for a JWT, which the client sends with every later request.
+func (c *Client) Login(ctx context.Context, username, password string) (string, error) {
+	body := struct {
+		Username string `json:"username"`
+		Password string `json:"password"`
+	}{username, password}
+	var resp struct {
+		Token string `json:"token"`
+	}
+	if err := c.do(ctx, http.MethodPost, "/login", body, &resp); err != nil {
+		return "", err
+	}
+	c.token = resp.Token
+	return resp.Token, nil
+}
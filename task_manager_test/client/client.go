@@ -0,0 +1,129 @@
+// Package client is a Go client for the task manager REST API. It speaks /api/v2 and reports failures as *Error,
+// which carries the RFC 7807 problem returned by the server.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Config holds the settings of a Client.
+type Config struct {
+	// BaseURL is the server's root URL, e.g. "https://tasks.example.com".
+	BaseURL string
+	// Token is a JWT from Login or a personal access token. Login sets it when empty.
+	Token string
+	// HTTPClient sends the requests; http.DefaultClient is used when nil.
+	HTTPClient *http.Client
+}
+
+// Client calls the task manager API. It is safe for concurrent use once logged in.
+type Client struct {
+	baseURL    *url.URL
+	token      string
+	httpClient *http.Client
+}
+
+// New creates a Client for the server at cfg.BaseURL.
+func New(cfg Config) (*Client, error) {
+	base, err := url.Parse(strings.TrimSuffix(cfg.BaseURL, "/"))
+	if err != nil {
+		return nil, fmt.Errorf("parse base URL: %w", err)
+	}
+	if base.Scheme != "http" && base.Scheme != "https" {
+		return nil, fmt.Errorf("base URL %q must start with http:// or https://", cfg.BaseURL)
+	}
+	httpClient := cfg.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &Client{baseURL: base, token: cfg.Token, httpClient: httpClient}, nil
+}
+
+// Token returns the bearer token sent with requests.
+func (c *Client) Token() string {
+	return c.token
+}
+
+// Error is a failed request, decoded from the problem the server returned.
+type Error struct {
+	// Status is the HTTP status code.
+	Status int `json:"status"`
+	// Code is the stable error code, e.g. "not_found".
+	Code      string       `json:"code"`
+	Title     string       `json:"title"`
+	Detail    string       `json:"detail,omitempty"`
+	RequestID string       `json:"request_id,omitempty"`
+	Errors    []FieldError `json:"errors,omitempty"`
+}
+
+// FieldError describes one invalid field of a request body.
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// Error renders the detail, or the title when there is none, with the invalid fields.
+func (e *Error) Error() string {
+	msg := e.Title
+	if e.Detail != "" {
+		msg = e.Detail
+	}
+	for _, f := range e.Errors {
+		msg += "; " + f.Field + " " + f.Message
+	}
+	return fmt.Sprintf("%s (%d %s)", msg, e.Status, e.Code)
+}
+
+// do sends a request with body encoded as JSON, unless nil, and decodes the response into out, unless nil.
+// Responses other than 2xx are returned as *Error.
+func (c *Client) do(ctx context.Context, method, path string, body, out any) error {
+	var reqBody io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("encode request: %w", err)
+		}
+		reqBody = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL.String()+path, reqBody)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		apiErr := &Error{Status: resp.StatusCode}
+		// Bodies that are not problems, e.g. from a proxy, still yield an error with the status.
+		if json.NewDecoder(resp.Body).Decode(apiErr) != nil || apiErr.Title == "" {
+			apiErr.Title = http.StatusText(resp.StatusCode)
+		}
+		apiErr.Status = resp.StatusCode
+		return apiErr
+	}
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("decode response: %w", err)
+	}
+	return nil
+}
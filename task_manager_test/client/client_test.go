@@ -0,0 +1,147 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// ClientTestSuite runs the client against a fake server that records the last request.
+type ClientTestSuite struct {
+	suite.Suite
+	server   *httptest.Server
+	client   *Client
+	handler  http.HandlerFunc
+	lastReq  *http.Request
+	lastBody string
+}
+
+// SetupTest starts the fake server; each test sets handler to shape its responses.
+func (s *ClientTestSuite) SetupTest() {
+	s.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		s.lastReq, s.lastBody = r, string(body)
+		s.handler(w, r)
+	}))
+	c, err := New(Config{BaseURL: s.server.URL + "/"})
+	s.Require().NoError(err)
+	s.client = c
+}
+
+// TearDownTest stops the fake server.
+func (s *ClientTestSuite) TearDownTest() {
+	s.server.Close()
+}
+
+// TestClient runs the entire test suite.
+func TestClient(t *testing.T) {
+	suite.Run(t, new(ClientTestSuite))
+}
+
+// respond makes the fake server answer with status and body.
+func (s *ClientTestSuite) respond(status int, contentType, body string) {
+	s.handler = func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", contentType)
+		w.WriteHeader(status)
+		_, _ = io.WriteString(w, body)
+	}
+}
+
+// TestNew_RejectsBadURL tests that base URLs must be HTTP(S).
+func (s *ClientTestSuite) TestNew_RejectsBadURL() {
+	_, err := New(Config{BaseURL: "localhost:8080"})
+	s.ErrorContains(err, "must start with http:// or https://")
+}
+
+// TestLogin tests that the token is returned and sent with later requests.
+func (s *ClientTestSuite) TestLogin() {
+	s.respond(http.StatusOK, "application/json", `{"token":"jwt-1"}`)
+
+	token, err := s.client.Login(context.Background(), "alice", "pw")
+
+	s.Require().NoError(err)
+	s.Equal("jwt-1", token)
+	s.Equal("/login", s.lastReq.URL.Path)
+	s.JSONEq(`{"username":"alice","password":"pw"}`, s.lastBody)
+
+	s.respond(http.StatusOK, "application/json", `[]`)
+	_, err = s.client.ListTasks(context.Background())
+	s.Require().NoError(err)
+	s.Equal("Bearer jwt-1", s.lastReq.Header.Get("Authorization"))
+}
+
+// TestGetTask tests that a v2 task is decoded.
+func (s *ClientTestSuite) TestGetTask() {
+	s.respond(http.StatusOK, "application/json", `{
+		"id": "task 1", "title": "Write docs", "description": "", "due_date": "2025-06-01T10:00:00Z",
+		"status": "pending", "owner": {"id": "u1", "username": "alice"}, "attachments": [],
+		"links": {"self": "/api/v2/tasks/task%201", "comments": "", "attachments": "", "shares": ""}
+	}`)
+
+	task, err := s.client.GetTask(context.Background(), "task 1")
+
+	s.Require().NoError(err)
+	s.Equal("/api/v2/tasks/task 1", s.lastReq.URL.Path, "IDs are escaped in the path")
+	s.Equal("Write docs", task.Title)
+	s.Equal(time.Date(2025, 6, 1, 10, 0, 0, 0, time.UTC), task.DueDate)
+	s.Equal(&UserRef{ID: "u1", Username: "alice"}, task.Owner)
+	s.Nil(task.ArchivedAt)
+}
+
+// TestCreateTask_InProject tests that tasks with a project are created through the project.
+func (s *ClientTestSuite) TestCreateTask_InProject() {
+	s.respond(http.StatusCreated, "application/json", `{"id":"t1","project_id":"p1"}`)
+	due := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	task, err := s.client.CreateTask(context.Background(), TaskInput{Title: "T", DueDate: due, Status: "pending", ProjectID: "p1"})
+
+	s.Require().NoError(err)
+	s.Equal("t1", task.ID)
+	s.Equal(http.MethodPost, s.lastReq.Method)
+	s.Equal("/api/v2/projects/p1/tasks", s.lastReq.URL.Path)
+	s.JSONEq(`{"title":"T","description":"","due_date":"2025-06-01T00:00:00Z","status":"pending"}`, s.lastBody)
+}
+
+// TestDeleteTask tests that an empty success response is accepted.
+func (s *ClientTestSuite) TestDeleteTask() {
+	s.respond(http.StatusNoContent, "", "")
+
+	s.NoError(s.client.DeleteTask(context.Background(), "t1"))
+	s.Equal(http.MethodDelete, s.lastReq.Method)
+}
+
+// TestProblem tests that problems are returned as *Error with their fields.
+func (s *ClientTestSuite) TestProblem() {
+	problem, _ := json.Marshal(map[string]any{
+		"type": "/problems/invalid_request", "title": "invalid request", "status": 400, "code": "invalid_request",
+		"detail": "request body failed validation", "errors": []map[string]string{{"field": "title", "message": "is required"}},
+	})
+	s.respond(http.StatusBadRequest, "application/problem+json", string(problem))
+
+	_, err := s.client.CreateTask(context.Background(), TaskInput{})
+
+	var apiErr *Error
+	s.Require().True(errors.As(err, &apiErr))
+	s.Equal(http.StatusBadRequest, apiErr.Status)
+	s.Equal("invalid_request", apiErr.Code)
+	s.Equal("request body failed validation; title is required (400 invalid_request)", err.Error())
+}
+
+// TestNonProblemError tests that error bodies that are not problems still report the status.
+func (s *ClientTestSuite) TestNonProblemError() {
+	s.respond(http.StatusBadGateway, "text/html", "<h1>Bad Gateway</h1>")
+
+	_, err := s.client.ListTasks(context.Background())
+
+	var apiErr *Error
+	s.Require().True(errors.As(err, &apiErr))
+	s.Equal(http.StatusBadGateway, apiErr.Status)
+	s.Equal("Bad Gateway", apiErr.Title)
+}
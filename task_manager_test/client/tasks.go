@@ -0,0 +1,123 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// Task is a task as served by /api/v2.
+type Task struct {
+	ID          string    `json:"id"`
+	Title       string    `json:"title"`
+	Description string    `json:"description"`
+	DueDate     time.Time `json:"due_date"`
+	Status      string    `json:"status"`
+	// Owner is nil for tasks created before ownership existed, or whose owner was deleted.
+	Owner       *UserRef     `json:"owner"`
+	ProjectID   string       `json:"project_id,omitempty"`
+	ArchivedAt  *time.Time   `json:"archived_at,omitempty"`
+	Attachments []Attachment `json:"attachments"`
+	// Ownership and Access are only set by ListTasks.
+	Ownership string    `json:"ownership,omitempty"`
+	Access    string    `json:"access,omitempty"`
+	Links     TaskLinks `json:"links"`
+}
+
+// UserRef identifies a user embedded in another resource.
+type UserRef struct {
+	ID       string `json:"id"`
+	Username string `json:"username"`
+}
+
+// Attachment describes a file attached to a task.
+type Attachment struct {
+	ID          string    `json:"id"`
+	Filename    string    `json:"filename"`
+	ContentType string    `json:"content_type"`
+	Size        int64     `json:"size"`
+	SHA256      string    `json:"sha256"`
+	UploadedBy  string    `json:"uploaded_by"`
+	UploadedAt  time.Time `json:"uploaded_at"`
+}
+
+// TaskLinks holds the URLs of a task and its sub-resources.
+type TaskLinks struct {
+	Self        string `json:"self"`
+	Comments    string `json:"comments"`
+	Attachments string `json:"attachments"`
+	Shares      string `json:"shares"`
+	Project     string `json:"project,omitempty"`
+}
+
+// TaskInput is the content of a task being created or replaced. Title, DueDate and Status are required.
+type TaskInput struct {
+	Title       string    `json:"title"`
+	Description string    `json:"description"`
+	DueDate     time.Time `json:"due_date"`
+	Status      string    `json:"status"`
+	// ProjectID creates the task in a project; it is ignored by UpdateTask.
+	ProjectID string `json:"-"`
+}
+
+// Input returns the content of t, for replacing it with some fields changed.
+func (t Task) Input() TaskInput {
+	return TaskInput{Title: t.Title, Description: t.Description, DueDate: t.DueDate, Status: t.Status}
+}
+
+// tasksPath is the path of the task collection.
+const tasksPath = "/api/v2/tasks"
+
+// taskPath returns the path of the task with the given ID.
+func taskPath(id string) string {
+	return tasksPath + "/" + url.PathEscape(id)
+}
+
+// ListTasks returns the personal tasks visible to the caller.
+func (c *Client) ListTasks(ctx context.Context) ([]Task, error) {
+	var tasks []Task
+	if err := c.do(ctx, http.MethodGet, tasksPath, nil, &tasks); err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+// ListProjectTasks returns the tasks of a project the caller is a member of.
+func (c *Client) ListProjectTasks(ctx context.Context, projectID string) ([]Task, error) {
+	var tasks []Task
+	if err := c.do(ctx, http.MethodGet, "/api/v2/projects/"+url.PathEscape(projectID)+"/tasks", nil, &tasks); err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+// GetTask returns the task with the given ID.
+func (c *Client) GetTask(ctx context.Context, id string) (Task, error) {
+	var task Task
+	err := c.do(ctx, http.MethodGet, taskPath(id), nil, &task)
+	return task, err
+}
+
+// CreateTask creates a personal task, or a task in in.ProjectID when it is set.
+func (c *Client) CreateTask(ctx context.Context, in TaskInput) (Task, error) {
+	path := tasksPath
+	if in.ProjectID != "" {
+		path = "/api/v2/projects/" + url.PathEscape(in.ProjectID) + "/tasks"
+	}
+	var task Task
+	err := c.do(ctx, http.MethodPost, path, in, &task)
+	return task, err
+}
+
+// UpdateTask replaces the content of the task with the given ID.
+func (c *Client) UpdateTask(ctx context.Context, id string, in TaskInput) (Task, error) {
+	var task Task
+	err := c.do(ctx, http.MethodPut, taskPath(id), in, &task)
+	return task, err
+}
+
+// DeleteTask deletes the task with the given ID.
+func (c *Client) DeleteTask(ctx context.Context, id string) error {
+	return c.do(ctx, http.MethodDelete, taskPath(id), nil, nil)
+}
@@ -0,0 +1,329 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"task_manager_test/internal/domain"
+	"task_manager_test/internal/mocks"
+	"task_manager_test/internal/repository"
+	"task_manager_test/internal/usecase"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+// fakeBackup records the calls made by the export and import commands.
+type fakeBackup struct {
+	exported string
+	imported string
+	replace  bool
+	stats    repository.BackupStats
+}
+
+// Export writes the canned backup.
+func (f *fakeBackup) Export(_ context.Context, w io.Writer) (repository.BackupStats, error) {
+	_, err := io.WriteString(w, f.exported)
+	return f.stats, err
+}
+
+// Import records the backup it was given.
+func (f *fakeBackup) Import(_ context.Context, r io.Reader, replace bool) (repository.BackupStats, error) {
+	data, err := io.ReadAll(r)
+	f.imported, f.replace = string(data), replace
+	return f.stats, err
+}
+
+// fakeMigrator reports two migrations, the first already applied, and applies the second.
+type fakeMigrator struct {
+	statuses []repository.MigrationStatus
+}
+
+// Status returns the canned statuses.
+func (f *fakeMigrator) Status(context.Context) ([]repository.MigrationStatus, error) {
+	return f.statuses, nil
+}
+
+// Migrate marks the pending migrations applied and returns them.
+func (f *fakeMigrator) Migrate(context.Context) ([]repository.MigrationStatus, error) {
+	var applied []repository.MigrationStatus
+	for i := range f.statuses {
+		if f.statuses[i].Pending() {
+			f.statuses[i].AppliedAt = time.Now()
+			applied = append(applied, f.statuses[i])
+		}
+	}
+	return applied, nil
+}
+
+// AdminTestSuite runs admin commands against mocked services.
+type AdminTestSuite struct {
+	suite.Suite
+	users     *mocks.UserUsecase
+	orgs      *mocks.OrganizationUsecase
+	backup    *fakeBackup
+	migrator  *fakeMigrator
+	env       map[string]string
+	connected bool
+	stdout    *bytes.Buffer
+	stderr    *bytes.Buffer
+}
+
+// SetupTest creates fresh mocks for each test.
+func (s *AdminTestSuite) SetupTest() {
+	s.users = mocks.NewUserUsecase(s.T())
+	s.orgs = mocks.NewOrganizationUsecase(s.T())
+	s.backup = &fakeBackup{
+		exported: "backup\n",
+		stats:    repository.BackupStats{Documents: map[string]int{"users": 2, "tasks": 5}, Blobs: 1},
+	}
+	s.migrator = &fakeMigrator{statuses: []repository.MigrationStatus{
+		{Version: 1, Name: "create_initial_indexes", AppliedAt: time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)},
+		{Version: 2, Name: "backfill_member_role"},
+	}}
+	s.env = map[string]string{}
+	s.connected = false
+}
+
+// TestAdmin runs the entire test suite.
+func TestAdmin(t *testing.T) {
+	suite.Run(t, new(AdminTestSuite))
+}
+
+// admin runs the admin command with args, feeding stdin, and returns the exit code.
+func (s *AdminTestSuite) admin(stdin string, args ...string) int {
+	s.stdout, s.stderr = new(bytes.Buffer), new(bytes.Buffer)
+	a := newApp(strings.NewReader(stdin), s.stdout, s.stderr, func(key string) (string, bool) {
+		v, ok := s.env[key]
+		return v, ok
+	})
+	a.connect = func(context.Context, string) (*services, error) {
+		s.connected = true
+		return &services{
+			users:      s.users,
+			orgs:       s.orgs,
+			backup:     s.backup,
+			migrations: s.migrator,
+			close:      func(context.Context) error { return nil },
+		}, nil
+	}
+	return a.run(context.Background(), args)
+}
+
+// isOperator matches contexts carrying the operator actor and spanning every organization.
+func isOperator(ctx context.Context) bool {
+	actor, ok := usecase.ActorFromContext(ctx)
+	tenant, _ := usecase.TenantFromContext(ctx)
+	return ok && actor == operator && tenant.All
+}
+
+// inOrg matches contexts carrying the operator actor and confined to the organization.
+func inOrg(orgID string) any {
+	return mock.MatchedBy(func(ctx context.Context) bool {
+		actor, ok := usecase.ActorFromContext(ctx)
+		tenant, _ := usecase.TenantFromContext(ctx)
+		return ok && actor == operator && tenant == usecase.TenantScope{OrgID: orgID}
+	})
+}
+
+// TestCreateAdmin_PasswordFromStdin tests that the admin is registered in the default organization as the operator
+// with the piped password.
+func (s *AdminTestSuite) TestCreateAdmin_PasswordFromStdin() {
+	s.orgs.On("FindBySlug", mock.MatchedBy(isOperator), "default").Return(domain.Organization{ID: "org-1", Slug: "default"}, nil)
+	s.users.On("Register", inOrg("org-1"), domain.User{Username: "root", Password: "s3cret-pass", Role: domain.RoleAdmin}).Return(nil)
+
+	s.Equal(exitOK, s.admin("s3cret-pass\n", "create-admin", "-username", "root"), s.stderr.String())
+	s.Equal("Created admin root in organization default\n", s.stdout.String())
+}
+
+// TestCreateAdmin_SuperAdmin tests that -super creates a super admin in the named organization.
+func (s *AdminTestSuite) TestCreateAdmin_SuperAdmin() {
+	s.env[passwordEnv] = "s3cret-pass"
+	s.orgs.On("FindBySlug", mock.Anything, "acme").Return(domain.Organization{ID: "org-2", Slug: "acme"}, nil)
+	s.users.On("Register", inOrg("org-2"), domain.User{Username: "root", Password: "s3cret-pass", Role: domain.RoleSuperAdmin}).Return(nil)
+
+	s.Equal(exitOK, s.admin("", "create-admin", "-username", "root", "-org", "acme", "-super"), s.stderr.String())
+	s.Equal("Created super admin root in organization acme\n", s.stdout.String())
+}
+
+// TestCreateAdmin_Fails_When_OrganizationIsUnknown tests that the organization is named in the error.
+func (s *AdminTestSuite) TestCreateAdmin_Fails_When_OrganizationIsUnknown() {
+	s.env[passwordEnv] = "s3cret-pass"
+	s.orgs.On("FindBySlug", mock.Anything, "nope").Return(domain.Organization{}, usecase.ErrOrganizationNotFound)
+
+	s.Equal(exitError, s.admin("", "create-admin", "-username", "root", "-org", "nope"))
+	s.Contains(s.stderr.String(), "admin create-admin: organization nope: organization not found")
+}
+
+// TestCreateOrg tests that the organization is created with its admin as the operator.
+func (s *AdminTestSuite) TestCreateOrg() {
+	s.env[passwordEnv] = "s3cret-pass"
+	s.orgs.On("Create", mock.MatchedBy(isOperator),
+		domain.Organization{Slug: "acme", Name: "Acme Inc"},
+		domain.User{Username: "wile", Password: "s3cret-pass"},
+	).Return(domain.Organization{ID: "org-2", Slug: "acme", Name: "Acme Inc"}, nil)
+
+	s.Equal(exitOK, s.admin("", "create-org", "-slug", "acme", "-name", "Acme Inc", "-admin", "wile"), s.stderr.String())
+	s.Equal("Created organization acme (org-2) with admin wile\n", s.stdout.String())
+
+	s.Equal(exitUsage, s.admin("", "create-org", "-slug", "acme"))
+	s.Contains(s.stderr.String(), "-slug, -name and -admin are required")
+}
+
+// TestCreateAdmin_Fails_When_PolicyRejectsPassword tests that use case errors are reported with exit code 1.
+func (s *AdminTestSuite) TestCreateAdmin_Fails_When_PolicyRejectsPassword() {
+	s.env[passwordEnv] = "short"
+	s.orgs.On("FindBySlug", mock.Anything, "default").Return(domain.Organization{ID: "org-1", Slug: "default"}, nil)
+	s.users.On("Register", mock.Anything, mock.Anything).Return(&usecase.PasswordPolicyError{Reason: "must be at least 8 characters long"})
+
+	s.Equal(exitError, s.admin("", "create-admin", "-username", "root"))
+	s.Contains(s.stderr.String(), "admin create-admin: password does not meet the password policy: must be at least 8 characters long")
+}
+
+// TestCreateAdmin_RequiresInput tests that missing input is a usage error found before connecting.
+func (s *AdminTestSuite) TestCreateAdmin_RequiresInput() {
+	s.Equal(exitUsage, s.admin("pw\n", "create-admin"))
+	s.Contains(s.stderr.String(), "-username is required")
+
+	s.Equal(exitUsage, s.admin("", "create-admin", "-username", "root"))
+	s.Contains(s.stderr.String(), "set ADMIN_PASSWORD or write it to stdin")
+	s.False(s.connected)
+}
+
+// TestResetPassword_PasswordFromEnv tests the non-interactive password reset.
+func (s *AdminTestSuite) TestResetPassword_PasswordFromEnv() {
+	s.env[passwordEnv] = "new-pass-123"
+	s.users.On("SetPassword", mock.MatchedBy(isOperator), "alice", "new-pass-123").Return(nil)
+
+	s.Equal(exitOK, s.admin("", "reset-password", "alice"), s.stderr.String())
+	s.Equal("Reset the password of alice\n", s.stdout.String())
+}
+
+// TestListUsers tests the table and JSON output and the -disabled filter.
+func (s *AdminTestSuite) TestListUsers() {
+	locked := time.Now().Add(time.Hour).Truncate(time.Second)
+	s.users.On("ListUsers", mock.MatchedBy(isOperator)).Return([]domain.User{
+		{ID: "u1", Username: "alice", Role: domain.RoleAdmin, OrgID: "org-1"},
+		{ID: "u2", Username: "bob", Role: domain.RoleMember, OrgID: "org-2", Disabled: true},
+		{ID: "u3", Username: "carol", Role: domain.RoleViewer, OrgID: "org-1", LockedUntil: locked},
+		{ID: "u4", Username: "dave", Role: domain.RoleMember, OrgID: "org-1", LockedUntil: time.Now().Add(-time.Hour)},
+	}, nil)
+	s.orgs.On("List", mock.MatchedBy(isOperator)).Return([]domain.Organization{
+		{ID: "org-1", Slug: "default"}, {ID: "org-2", Slug: "acme"},
+	}, nil)
+
+	s.Require().Equal(exitOK, s.admin("", "list-users"))
+	lines := strings.Split(strings.TrimSpace(s.stdout.String()), "\n")
+	s.Require().Len(lines, 5)
+	s.Regexp(`^ID\s+USERNAME\s+ORG\s+ROLE\s+STATE$`, lines[0])
+	s.Regexp(`^u2\s+bob\s+acme\s+member\s+disabled$`, lines[2])
+	s.Regexp(`^u3\s+carol\s+default\s+viewer\s+locked until `, lines[3])
+	s.Regexp(`^u4\s+dave\s+default\s+member\s+active$`, lines[4], "Expired lockouts are not shown")
+
+	s.Require().Equal(exitOK, s.admin("", "-output", "json", "list-users", "-disabled"))
+	var infos []userInfo
+	s.Require().NoError(json.Unmarshal(s.stdout.Bytes(), &infos))
+	s.Equal([]userInfo{{ID: "u2", Username: "bob", Role: domain.RoleMember, Org: "acme", Disabled: true}}, infos)
+	s.NotContains(s.stdout.String(), "password")
+}
+
+// TestDisableUser_NeedsConfirmation tests that disabling asks first, and that -yes skips the prompt.
+func (s *AdminTestSuite) TestDisableUser_NeedsConfirmation() {
+	s.Equal(exitUsage, s.admin("", "disable-user", "bob"))
+	s.Contains(s.stderr.String(), "pass -yes")
+	s.False(s.connected)
+
+	s.users.On("SetDisabled", mock.MatchedBy(isOperator), "bob", true).Return(nil).Twice()
+	s.Equal(exitOK, s.admin("yes\n", "disable-user", "bob"))
+	s.Equal(exitOK, s.admin("", "disable-user", "bob", "-yes"))
+	s.Equal("Disabled bob\n", s.stdout.String())
+}
+
+// TestEnableUser_Fails_When_UserIsUnknown tests that a missing user is reported.
+func (s *AdminTestSuite) TestEnableUser_Fails_When_UserIsUnknown() {
+	s.users.On("SetDisabled", mock.Anything, "ghost", false).Return(usecase.ErrNotFound)
+
+	s.Equal(exitError, s.admin("", "enable-user", "ghost"))
+	s.Contains(s.stderr.String(), "admin enable-user: ")
+}
+
+// TestMigrate_DryRun tests that -dry-run lists the migrations without applying them.
+func (s *AdminTestSuite) TestMigrate_DryRun() {
+	s.Require().Equal(exitOK, s.admin("", "migrate", "-dry-run"))
+	lines := strings.Split(strings.TrimSpace(s.stdout.String()), "\n")
+	s.Require().Len(lines, 3)
+	s.Regexp(`^VERSION\s+NAME\s+STATUS$`, lines[0])
+	s.Regexp(`^1\s+create_initial_indexes\s+applied `, lines[1])
+	s.Regexp(`^2\s+backfill_member_role\s+pending$`, lines[2])
+	s.True(s.migrator.statuses[1].Pending())
+
+	s.Require().Equal(exitOK, s.admin("", "-output", "json", "migrate", "-dry-run"))
+	s.Contains(s.stdout.String(), `"applied_at": "2025-06-01T12:00:00Z"`)
+}
+
+// TestMigrate tests that pending migrations are applied and listed, and that a second run has nothing to do.
+func (s *AdminTestSuite) TestMigrate() {
+	s.Equal(exitOK, s.admin("", "migrate"))
+	s.Equal("Applied migration 2 backfill_member_role\n", s.stdout.String())
+
+	s.Equal(exitOK, s.admin("", "migrate"))
+	s.Equal("The database is up to date\n", s.stdout.String())
+}
+
+// TestExport_ToFile tests that backups are written to a new private file, with the counts on stderr.
+func (s *AdminTestSuite) TestExport_ToFile() {
+	path := filepath.Join(s.T().TempDir(), "backup.ndjson")
+
+	s.Require().Equal(exitOK, s.admin("", "export", "-file", path), s.stderr.String())
+
+	data, err := os.ReadFile(path)
+	s.Require().NoError(err)
+	s.Equal("backup\n", string(data))
+	info, _ := os.Stat(path)
+	s.Equal(os.FileMode(0o600), info.Mode().Perm())
+	s.Empty(s.stdout.String())
+	s.Contains(s.stderr.String(), "Exported 0 organizations, 2 users, 0 access_tokens")
+	s.Contains(s.stderr.String(), "5 tasks")
+
+	s.Equal(exitError, s.admin("", "export", "-file", path), "Existing backups are never overwritten")
+}
+
+// TestImport tests importing from stdin, and that replacing data from stdin requires -yes.
+func (s *AdminTestSuite) TestImport() {
+	s.Require().Equal(exitOK, s.admin("backup\n", "import"))
+	s.Equal("backup\n", s.backup.imported)
+	s.False(s.backup.replace)
+
+	s.Equal(exitUsage, s.admin("backup\n", "import", "-replace"))
+	s.Contains(s.stderr.String(), "needs -yes")
+
+	s.Require().Equal(exitOK, s.admin("backup\n", "import", "-replace", "-yes"))
+	s.True(s.backup.replace)
+}
+
+// TestConnectError tests that configuration and connection errors are reported.
+func (s *AdminTestSuite) TestConnectError() {
+	s.stdout, s.stderr = new(bytes.Buffer), new(bytes.Buffer)
+	a := newApp(strings.NewReader(""), s.stdout, s.stderr, func(string) (string, bool) { return "", false })
+	a.connect = func(context.Context, string) (*services, error) { return nil, errors.New("mongo.uri is required") }
+
+	s.Equal(exitError, a.run(context.Background(), []string{"migrate"}))
+	s.Contains(s.stderr.String(), "admin migrate: mongo.uri is required")
+}
+
+// TestUsageErrors tests the top-level usage errors.
+func (s *AdminTestSuite) TestUsageErrors() {
+	s.Equal(exitUsage, s.admin("", "frobnicate"))
+	s.Contains(s.stderr.String(), `unknown command "frobnicate"`)
+
+	s.Equal(exitUsage, s.admin("", "-output", "yaml", "list-users"))
+	s.Equal(exitUsage, s.admin("", "reset-password"))
+	s.Contains(s.stderr.String(), "expected <username>")
+}
@@ -0,0 +1,410 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"task_manager_test/internal/domain"
+	"task_manager_test/internal/repository"
+	"task_manager_test/internal/usecase"
+	"text/tabwriter"
+	"time"
+)
+
+// Output formats accepted by -output.
+const (
+	formatTable = "table"
+	formatJSON  = "json"
+)
+
+// command is an admin subcommand. parse checks the arguments and asks for any input before the database is
+// touched, and returns the function that does the work.
+type command struct {
+	name    string
+	summary string
+	parse   func(a *app, fs *flag.FlagSet, args []string) (func(ctx context.Context, svc *services) error, error)
+}
+
+// commands lists the subcommands in the order shown by -h.
+var commands = []command{
+	{"create-org", "create an organization together with its admin", parseCreateOrg},
+	{"create-admin", "create an organization admin, or a super admin with -super", parseCreateAdmin},
+	{"reset-password", "set a new password for a user, ending their sessions", parseResetPassword},
+	{"list-users", "list every user with their role and state", parseListUsers},
+	{"disable-user", "stop a user from logging in, ending their sessions", parseDisableUser},
+	{"enable-user", "allow a disabled user to log in again", parseEnableUser},
+	{"migrate", "apply pending schema migrations, or list them with -dry-run", parseMigrate},
+	{"export", "write a full backup of the database and attachments", parseExport},
+	{"import", "restore a full backup into an empty database", parseImport},
+}
+
+// errFlagParse reports command flags the flag package has already complained about.
+var errFlagParse = errors.New("invalid flags")
+
+// parseArgs parses the flags of a command, which may come before or after its positional arguments, and checks that
+// exactly len(names) positional arguments were given. It returns them in order.
+func parseArgs(fs *flag.FlagSet, args []string, usage string, names ...string) ([]string, error) {
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: %s %s\n", fs.Name(), usage)
+		fs.PrintDefaults()
+	}
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			if errors.Is(err, flag.ErrHelp) {
+				return nil, err
+			}
+			return nil, errFlagParse
+		}
+		if fs.NArg() == 0 {
+			break
+		}
+		positional = append(positional, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+	if len(positional) != len(names) {
+		if len(names) == 0 {
+			return nil, usagef("unexpected argument %q", positional[0])
+		}
+		return nil, usagef("expected %s", strings.Join(names, " "))
+	}
+	return positional, nil
+}
+
+// password returns the password from ADMIN_PASSWORD, or else reads it as the first line of stdin.
+func (a *app) password(username string) (string, error) {
+	if password, ok := a.lookupEnv(passwordEnv); ok {
+		return password, nil
+	}
+	fmt.Fprintf(a.stderr, "New password for %s: ", username)
+	line, err := bufio.NewReader(a.stdin).ReadString('\n')
+	password := strings.TrimRight(line, "\r\n")
+	if password == "" {
+		if err != nil && !errors.Is(err, io.EOF) {
+			return "", fmt.Errorf("read password: %w", err)
+		}
+		return "", usagef("no password given; set %s or write it to stdin", passwordEnv)
+	}
+	return password, nil
+}
+
+// confirm asks the operator to type yes unless assumeYes is set. Anything else, including end of input, declines.
+func (a *app) confirm(assumeYes bool, format string, args ...any) error {
+	if assumeYes {
+		return nil
+	}
+	fmt.Fprintf(a.stderr, format+" Type yes to continue: ", args...)
+	line, _ := bufio.NewReader(a.stdin).ReadString('\n')
+	if strings.TrimSpace(line) != "yes" {
+		return usagef("not confirmed; pass -yes to skip the prompt")
+	}
+	return nil
+}
+
+// parseCreateOrg creates an organization and its first admin.
+func parseCreateOrg(a *app, fs *flag.FlagSet, args []string) (func(context.Context, *services) error, error) {
+	slug := fs.String("slug", "", "URL-safe identifier of the organization (required)")
+	name := fs.String("name", "", "display name of the organization (required)")
+	admin := fs.String("admin", "", "username of the organization's admin (required)")
+	if _, err := parseArgs(fs, args, "-slug <slug> -name <name> -admin <username>"); err != nil {
+		return nil, err
+	}
+	if *slug == "" || *name == "" || *admin == "" {
+		return nil, usagef("-slug, -name and -admin are required")
+	}
+	password, err := a.password(*admin)
+	if err != nil {
+		return nil, err
+	}
+	return func(ctx context.Context, svc *services) error {
+		org, err := svc.orgs.Create(ctx,
+			domain.Organization{Slug: *slug, Name: *name},
+			domain.User{Username: *admin, Password: password},
+		)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(a.stdout, "Created organization %s (%s) with admin %s\n", org.Slug, org.ID, *admin)
+		return nil
+	}, nil
+}
+
+// parseCreateAdmin creates an administrator of an organization, or a super admin, the only way to get one on a
+// fresh deployment.
+func parseCreateAdmin(a *app, fs *flag.FlagSet, args []string) (func(context.Context, *services) error, error) {
+	username := fs.String("username", "", "username of the new admin (required)")
+	orgSlug := fs.String("org", domain.DefaultOrganizationSlug, "slug of the organization the admin belongs to")
+	super := fs.Bool("super", false, "create a super admin, who administers every organization")
+	if _, err := parseArgs(fs, args, "-username <name> [-org slug] [-super]"); err != nil {
+		return nil, err
+	}
+	if *username == "" {
+		return nil, usagef("-username is required")
+	}
+	password, err := a.password(*username)
+	if err != nil {
+		return nil, err
+	}
+	role, kind := domain.RoleAdmin, "admin"
+	if *super {
+		role, kind = domain.RoleSuperAdmin, "super admin"
+	}
+	return func(ctx context.Context, svc *services) error {
+		org, err := svc.orgs.FindBySlug(ctx, *orgSlug)
+		if err != nil {
+			return fmt.Errorf("organization %s: %w", *orgSlug, err)
+		}
+		user := domain.User{Username: *username, Password: password, Role: role}
+		if err := svc.users.Register(usecase.WithTenant(ctx, org.ID), user); err != nil {
+			return err
+		}
+		fmt.Fprintf(a.stdout, "Created %s %s in organization %s\n", kind, *username, org.Slug)
+		return nil
+	}, nil
+}
+
+// parseResetPassword sets a user's password, which also clears any lockout and ends their sessions.
+func parseResetPassword(a *app, fs *flag.FlagSet, args []string) (func(context.Context, *services) error, error) {
+	positional, err := parseArgs(fs, args, "<username>", "<username>")
+	if err != nil {
+		return nil, err
+	}
+	username := positional[0]
+	password, err := a.password(username)
+	if err != nil {
+		return nil, err
+	}
+	return func(ctx context.Context, svc *services) error {
+		if err := svc.users.SetPassword(ctx, username, password); err != nil {
+			return err
+		}
+		fmt.Fprintf(a.stdout, "Reset the password of %s\n", username)
+		return nil
+	}, nil
+}
+
+// userInfo is a user as listed by list-users. Password hashes are never printed.
+type userInfo struct {
+	ID          string      `json:"id"`
+	Username    string      `json:"username"`
+	Role        domain.Role `json:"role"`
+	Org         string      `json:"org"`
+	Disabled    bool        `json:"disabled"`
+	LockedUntil *time.Time  `json:"locked_until,omitempty"`
+}
+
+// parseListUsers lists every user of every organization, optionally only the disabled ones.
+func parseListUsers(a *app, fs *flag.FlagSet, args []string) (func(context.Context, *services) error, error) {
+	onlyDisabled := fs.Bool("disabled", false, "only list disabled users")
+	if _, err := parseArgs(fs, args, "[-disabled]"); err != nil {
+		return nil, err
+	}
+	return func(ctx context.Context, svc *services) error {
+		users, err := svc.users.ListUsers(ctx)
+		if err != nil {
+			return err
+		}
+		orgs, err := svc.orgs.List(ctx)
+		if err != nil {
+			return err
+		}
+		slugs := make(map[string]string, len(orgs))
+		for _, org := range orgs {
+			slugs[org.ID] = org.Slug
+		}
+		now := time.Now()
+		infos := make([]userInfo, 0, len(users))
+		for _, u := range users {
+			if *onlyDisabled && !u.Disabled {
+				continue
+			}
+			info := userInfo{ID: u.ID, Username: u.Username, Role: u.Role, Org: slugs[u.OrgID], Disabled: u.Disabled}
+			if info.Org == "" {
+				info.Org = u.OrgID // an organization deleted under its users, shown by ID
+			}
+			if u.LockedUntil.After(now) {
+				info.LockedUntil = &u.LockedUntil
+			}
+			infos = append(infos, info)
+		}
+		if a.output == formatJSON {
+			enc := json.NewEncoder(a.stdout)
+			enc.SetIndent("", "  ")
+			return enc.Encode(infos)
+		}
+		tw := tabwriter.NewWriter(a.stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "ID\tUSERNAME\tORG\tROLE\tSTATE")
+		for _, info := range infos {
+			state := "active"
+			switch {
+			case info.Disabled:
+				state = "disabled"
+			case info.LockedUntil != nil:
+				state = "locked until " + info.LockedUntil.Local().Format(time.DateTime)
+			}
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", info.ID, info.Username, info.Org, info.Role, state)
+		}
+		return tw.Flush()
+	}, nil
+}
+
+// parseDisableUser disables an account after confirmation.
+func parseDisableUser(a *app, fs *flag.FlagSet, args []string) (func(context.Context, *services) error, error) {
+	yes := fs.Bool("yes", false, "do not ask for confirmation")
+	positional, err := parseArgs(fs, args, "[-yes] <username>", "<username>")
+	if err != nil {
+		return nil, err
+	}
+	username := positional[0]
+	if err := a.confirm(*yes, "Disable %s and end their sessions?", username); err != nil {
+		return nil, err
+	}
+	return func(ctx context.Context, svc *services) error {
+		if err := svc.users.SetDisabled(ctx, username, true); err != nil {
+			return err
+		}
+		fmt.Fprintf(a.stdout, "Disabled %s\n", username)
+		return nil
+	}, nil
+}
+
+// parseEnableUser re-enables an account.
+func parseEnableUser(a *app, fs *flag.FlagSet, args []string) (func(context.Context, *services) error, error) {
+	positional, err := parseArgs(fs, args, "<username>", "<username>")
+	if err != nil {
+		return nil, err
+	}
+	username := positional[0]
+	return func(ctx context.Context, svc *services) error {
+		if err := svc.users.SetDisabled(ctx, username, false); err != nil {
+			return err
+		}
+		fmt.Fprintf(a.stdout, "Enabled %s\n", username)
+		return nil
+	}, nil
+}
+
+// parseMigrate applies the pending migrations, or with -dry-run lists every migration without applying any.
+func parseMigrate(a *app, fs *flag.FlagSet, args []string) (func(context.Context, *services) error, error) {
+	dryRun := fs.Bool("dry-run", false, "list the migrations and whether they are applied, without applying any")
+	if _, err := parseArgs(fs, args, "[-dry-run]"); err != nil {
+		return nil, err
+	}
+	return func(ctx context.Context, svc *services) error {
+		if *dryRun {
+			statuses, err := svc.migrations.Status(ctx)
+			if err != nil {
+				return err
+			}
+			return a.printMigrations(statuses)
+		}
+		applied, err := svc.migrations.Migrate(ctx)
+		for _, m := range applied {
+			fmt.Fprintf(a.stdout, "Applied migration %d %s\n", m.Version, m.Name)
+		}
+		if err == nil && len(applied) == 0 {
+			fmt.Fprintln(a.stdout, "The database is up to date")
+		}
+		return err
+	}, nil
+}
+
+// printMigrations writes the migrations as a table or as JSON.
+func (a *app) printMigrations(statuses []repository.MigrationStatus) error {
+	if a.output == formatJSON {
+		enc := json.NewEncoder(a.stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(statuses)
+	}
+	tw := tabwriter.NewWriter(a.stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "VERSION\tNAME\tSTATUS")
+	for _, m := range statuses {
+		status := "pending"
+		if !m.Pending() {
+			status = "applied " + m.AppliedAt.Local().Format(time.DateTime)
+		}
+		fmt.Fprintf(tw, "%d\t%s\t%s\n", m.Version, m.Name, status)
+	}
+	return tw.Flush()
+}
+
+// parseExport writes a backup to a file, or to stdout.
+func parseExport(a *app, fs *flag.FlagSet, args []string) (func(context.Context, *services) error, error) {
+	file := fs.String("file", "", "write the backup to this file instead of stdout")
+	if _, err := parseArgs(fs, args, "[-file path]"); err != nil {
+		return nil, err
+	}
+	return func(ctx context.Context, svc *services) error {
+		w, closeFile := a.stdout, func() error { return nil }
+		if *file != "" {
+			// Backups hold password hashes, so the file is private and never overwrites an older backup.
+			f, err := os.OpenFile(*file, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+			if err != nil {
+				return err
+			}
+			w, closeFile = f, f.Close
+		}
+		stats, err := svc.backup.Export(ctx, w)
+		if closeErr := closeFile(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return err
+		}
+		a.reportBackup("Exported", stats)
+		for _, digest := range stats.MissingBlobs {
+			fmt.Fprintf(a.stderr, "warning: attachment content %s is missing from the blob store\n", digest)
+		}
+		return nil
+	}, nil
+}
+
+// parseImport restores a backup from a file, or from stdin. Replacing existing data needs confirmation.
+func parseImport(a *app, fs *flag.FlagSet, args []string) (func(context.Context, *services) error, error) {
+	file := fs.String("file", "", "read the backup from this file instead of stdin")
+	replace := fs.Bool("replace", false, "delete the existing data first instead of refusing to import")
+	yes := fs.Bool("yes", false, "do not ask for confirmation before replacing data")
+	if _, err := parseArgs(fs, args, "[-file path] [-replace [-yes]]"); err != nil {
+		return nil, err
+	}
+	if *replace {
+		if *file == "" && !*yes {
+			return nil, usagef("-replace with a backup on stdin needs -yes, since stdin cannot also answer the prompt")
+		}
+		if err := a.confirm(*yes, "Delete all existing data before importing?"); err != nil {
+			return nil, err
+		}
+	}
+	return func(ctx context.Context, svc *services) error {
+		r := a.stdin
+		if *file != "" {
+			f, err := os.Open(*file)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			r = f
+		}
+		stats, err := svc.backup.Import(ctx, r, *replace)
+		if err != nil {
+			return err
+		}
+		a.reportBackup("Imported", stats)
+		return nil
+	}, nil
+}
+
+// reportBackup writes the document and blob counts to stderr, keeping stdout free for the backup itself.
+func (a *app) reportBackup(verb string, stats repository.BackupStats) {
+	var parts []string
+	for _, coll := range repository.Collections {
+		parts = append(parts, fmt.Sprintf("%d %s", stats.Documents[coll], coll))
+	}
+	fmt.Fprintf(a.stderr, "%s %s and %d attachment blobs\n", verb, strings.Join(parts, ", "), stats.Blobs)
+}
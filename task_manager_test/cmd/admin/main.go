@@ -0,0 +1,239 @@
+// Command admin performs maintenance on a task manager deployment: bootstrapping administrators, managing accounts,
+// applying schema migrations and taking or restoring backups. It reads the same configuration as the server and
+// talks to its database directly, so it needs no running server and no credentials beyond the database's.
+//
+// Usage:
+//
+//	admin [-config file] [-output table|json] <command> [flags] [args]
+//
+// Run admin -h for the list of commands. Every prompt can be answered up front for scripting: passwords with the
+// ADMIN_PASSWORD environment variable or the first line of stdin, and confirmations with -yes.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+	"task_manager_test/internal/config"
+	"task_manager_test/internal/domain"
+	"task_manager_test/internal/repository"
+	"task_manager_test/internal/service"
+	"task_manager_test/internal/usecase"
+
+	"github.com/joho/godotenv"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// passwordEnv is the environment variable holding the password for create-admin and reset-password.
+const passwordEnv = "ADMIN_PASSWORD"
+
+// Exit codes.
+const (
+	exitOK    = 0
+	exitError = 1
+	exitUsage = 2
+)
+
+// operator is the actor the commands run as. It holds the super-admin role, so the use cases' permission checks pass
+// in every organization.
+var operator = usecase.Actor{Username: "admin-cli", Role: domain.RoleSuperAdmin}
+
+func main() {
+	_ = godotenv.Load()
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	os.Exit(newApp(os.Stdin, os.Stdout, os.Stderr, os.LookupEnv).run(ctx, os.Args[1:]))
+}
+
+// backupService exports and imports full backups; it is implemented by *repository.Backup.
+type backupService interface {
+	Export(ctx context.Context, w io.Writer) (repository.BackupStats, error)
+	Import(ctx context.Context, r io.Reader, replace bool) (repository.BackupStats, error)
+}
+
+// migrator lists and applies schema migrations; it is implemented by *repository.Migrator.
+type migrator interface {
+	Status(ctx context.Context) ([]repository.MigrationStatus, error)
+	Migrate(ctx context.Context) ([]repository.MigrationStatus, error)
+}
+
+// services are the parts of the server the commands use.
+type services struct {
+	users      usecase.UserUsecase
+	orgs       usecase.OrganizationUsecase
+	backup     backupService
+	migrations migrator
+	close      func(ctx context.Context) error
+}
+
+// app holds the streams and settings shared by the commands of one invocation.
+type app struct {
+	stdin          io.Reader
+	stdout, stderr io.Writer
+	lookupEnv      func(string) (string, bool)
+	// connect builds the services from the configuration; tests replace it.
+	connect func(ctx context.Context, configPath string) (*services, error)
+
+	configPath string
+	output     string
+}
+
+// newApp creates an app reading and writing the given streams.
+func newApp(stdin io.Reader, stdout, stderr io.Writer, lookupEnv func(string) (string, bool)) *app {
+	a := &app{stdin: stdin, stdout: stdout, stderr: stderr, lookupEnv: lookupEnv}
+	a.connect = a.connectMongo
+	return a
+}
+
+// connectMongo loads the server configuration and builds the services on its database.
+func (a *app) connectMongo(ctx context.Context, configPath string) (*services, error) {
+	var args []string
+	if configPath != "" {
+		args = []string{"-config", configPath}
+	}
+	cfg, err := config.Load(args, a.lookupEnv)
+	if err != nil {
+		return nil, err
+	}
+	connectCtx, cancel := context.WithTimeout(ctx, cfg.Mongo.ConnectTimeout)
+	defer cancel()
+	client, err := mongo.Connect(connectCtx, options.Client().ApplyURI(cfg.Mongo.URI))
+	if err != nil {
+		return nil, err
+	}
+	db := client.Database(cfg.Mongo.Database)
+
+	blobStore, err := service.NewLocalBlobStore(cfg.Attachments.Dir)
+	if err != nil {
+		_ = client.Disconnect(ctx)
+		return nil, err
+	}
+	accessPolicy := usecase.DefaultAccessPolicy()
+	if cfg.Auth.PolicyFile != "" {
+		if accessPolicy, err = service.LoadAccessPolicy(cfg.Auth.PolicyFile); err != nil {
+			_ = client.Disconnect(ctx)
+			return nil, err
+		}
+	}
+	var denylist []string
+	if cfg.Auth.PasswordDenylistFile != "" {
+		if denylist, err = service.LoadPasswordDenylist(cfg.Auth.PasswordDenylistFile); err != nil {
+			_ = client.Disconnect(ctx)
+			return nil, err
+		}
+	}
+	// The commands never issue tokens, so the HMAC service stands in even when the server signs with a key pair.
+	jwtSvc := service.NewJWTService(cfg.Auth.JWTSecret, cfg.Auth.TokenTTL, cfg.Auth.Issuer, cfg.Auth.Audience)
+	orgRepo := repository.NewMongoOrganizationRepository(db)
+	userUC := usecase.NewUserUsecase(
+		repository.NewMongoUserRepository(db),
+		orgRepo,
+		service.NewPasswordHasher(cfg.Auth.BcryptCost),
+		jwtSvc,
+		usecase.NewPasswordPolicy(cfg.Auth.PasswordMinLength, denylist),
+		usecase.LockoutPolicy{MaxAttempts: cfg.Auth.LockoutThreshold, Duration: cfg.Auth.LockoutDuration},
+		accessPolicy,
+	)
+	return &services{
+		users:      userUC,
+		orgs:       usecase.NewOrganizationUsecase(orgRepo, userUC),
+		backup:     repository.NewBackup(db, blobStore),
+		migrations: repository.NewMigrator(db),
+		close:      client.Disconnect,
+	}, nil
+}
+
+// usageError is a mistake in the command line, reported with exit code 2.
+type usageError struct {
+	msg string
+}
+
+// Error implements the error interface.
+func (e *usageError) Error() string {
+	return e.msg
+}
+
+// usagef formats a usageError.
+func usagef(format string, args ...any) error {
+	return &usageError{msg: fmt.Sprintf(format, args...)}
+}
+
+// run parses the global flags, runs the command and returns the exit code.
+func (a *app) run(ctx context.Context, args []string) int {
+	fs := flag.NewFlagSet("admin", flag.ContinueOnError)
+	fs.SetOutput(a.stderr)
+	fs.StringVar(&a.configPath, "config", "", "path to the server's YAML or TOML config file (env CONFIG_FILE)")
+	fs.StringVar(&a.output, "output", formatTable, "output format of list-users and migrate -dry-run: table or json")
+	fs.Usage = func() {
+		fmt.Fprintln(a.stderr, "Usage: admin [flags] <command> [command flags] [args]")
+		fmt.Fprintln(a.stderr, "\nCommands:")
+		for _, c := range commands {
+			fmt.Fprintf(a.stderr, "  %-15s %s\n", c.name, c.summary)
+		}
+		fmt.Fprintln(a.stderr, "\nFlags:")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return exitOK
+		}
+		return exitUsage
+	}
+	if a.output != formatTable && a.output != formatJSON {
+		fmt.Fprintf(a.stderr, "admin: unknown output format %q (want table or json)\n", a.output)
+		return exitUsage
+	}
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return exitUsage
+	}
+
+	name, cmdArgs := fs.Arg(0), fs.Args()[1:]
+	for _, c := range commands {
+		if c.name != name {
+			continue
+		}
+		cmdFlags := flag.NewFlagSet("admin "+c.name, flag.ContinueOnError)
+		cmdFlags.SetOutput(a.stderr)
+		err := a.runCommand(ctx, c, cmdFlags, cmdArgs)
+		var usageErr *usageError
+		switch {
+		case err == nil, errors.Is(err, flag.ErrHelp):
+			return exitOK
+		case errors.Is(err, errFlagParse):
+			return exitUsage
+		case errors.As(err, &usageErr):
+			fmt.Fprintf(a.stderr, "admin %s: %v\n", c.name, err)
+			return exitUsage
+		default:
+			fmt.Fprintf(a.stderr, "admin %s: %v\n", c.name, err)
+			return exitError
+		}
+	}
+	fmt.Fprintf(a.stderr, "admin: unknown command %q; run admin -h for a list\n", name)
+	return exitUsage
+}
+
+// runCommand parses the command's arguments, connects, and runs it as the operator, across every organization.
+func (a *app) runCommand(ctx context.Context, c command, fs *flag.FlagSet, args []string) error {
+	exec, err := c.parse(a, fs, args)
+	if err != nil {
+		return err
+	}
+	svc, err := a.connect(ctx, a.configPath)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := svc.close(context.WithoutCancel(ctx)); err != nil {
+			fmt.Fprintf(a.stderr, "admin: disconnect: %v\n", err)
+		}
+	}()
+	return exec(usecase.WithAllTenants(usecase.WithActor(ctx, operator)), svc)
+}
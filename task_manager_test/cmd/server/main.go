@@ -2,18 +2,29 @@ package main
 
 import (
 	"context"
+	"errors"
+	"flag"
 	"log"
+	"net"
 	"os"
+	"os/signal"
+	"syscall"
+	"task_manager_test/internal/config"
 	"task_manager_test/internal/delivery/controller"
+	"task_manager_test/internal/delivery/middleware"
 	"task_manager_test/internal/delivery/router"
+	"task_manager_test/internal/delivery/rpc"
+	"task_manager_test/internal/delivery/server"
 	"task_manager_test/internal/repository"
 	"task_manager_test/internal/service"
 	"task_manager_test/internal/usecase"
-	"time"
+	// Embed the time zone database so users' zones resolve on hosts without one.
+	_ "time/tzdata"
 
 	"github.com/joho/godotenv"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
 )
 
 func main() {
@@ -22,63 +33,264 @@ func main() {
 		log.Println("No .env file found, using system environment variables")
 	}
 
-	// Read MongoDB connection URI from environment variables.
-	uri := os.Getenv("MONGODB_URI")
-	if uri == "" {
-		log.Fatal("MONGODB_URI environment variable not set")
+	// Load the typed configuration from defaults, an optional config file, the environment and flags.
+	cfg, err := config.Load(os.Args[1:], os.LookupEnv)
+	if errors.Is(err, flag.ErrHelp) {
+		return
 	}
-
-	// Read JWT secret key for authentication from environment variables.
-	jwtSecret := os.Getenv("JWT_SECRET")
-	if jwtSecret == "" {
-		log.Fatal("JWT_SECRET not set")
+	if err != nil {
+		log.Fatal(err)
 	}
+	log.Printf("Loaded configuration:\n%s", cfg)
+
+	// Cancel the root context on SIGINT/SIGTERM so the server can shut down gracefully.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
 
 	// Create a context with a timeout for MongoDB connection.
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	connectCtx, cancel := context.WithTimeout(ctx, cfg.Mongo.ConnectTimeout)
 	defer cancel()
 
 	// Connect to MongoDB using the provided URI.
-	client, err := mongo.Connect(ctx, options.Client().ApplyURI(uri))
+	client, err := mongo.Connect(connectCtx, options.Client().ApplyURI(cfg.Mongo.URI))
 	if err != nil {
 		log.Fatal(err)
 	}
 
-	// Ensure the MongoDB client disconnects when the application stops.
-	defer client.Disconnect(ctx)
-
 	// Get a handle to the database.
-	db := client.Database("taskdb")
+	db := client.Database(cfg.Mongo.Database)
+
+	// Bring the schema up to date, or warn when migrations are left to the admin CLI.
+	if err := migrate(ctx, repository.NewMigrator(db), cfg.Mongo.MigrateOnStartup); err != nil {
+		log.Fatal(err)
+	}
+
+	// Initialize repositories with the database handle. Every call is bounded by a timeout, retried on transient
+	// failures, and refused with 503 while the circuit breaker considers the database unhealthy.
+	resilience := repository.NewResilience(repository.ResilienceOptions{
+		Timeout:          cfg.Mongo.OperationTimeout,
+		MaxRetries:       cfg.Mongo.MaxRetries,
+		RetryBackoff:     cfg.Mongo.RetryBackoff,
+		FailureThreshold: cfg.Mongo.BreakerThreshold,
+		Cooldown:         cfg.Mongo.BreakerCooldown,
+		Logger:           log.Default(),
+	})
+	taskRepo := repository.NewResilientTaskRepository(repository.NewMongoTaskRepository(db), resilience)
+	userRepo := repository.NewResilientUserRepository(repository.NewMongoUserRepository(db), resilience)
+	resetRepo := repository.NewResilientPasswordResetRepository(repository.NewMongoPasswordResetRepository(db), resilience)
+	accessTokenRepo := repository.NewResilientAccessTokenRepository(repository.NewMongoAccessTokenRepository(db), resilience)
+	shareRepo := repository.NewResilientTaskShareRepository(repository.NewMongoTaskShareRepository(db), resilience)
+	projectRepo := repository.NewResilientProjectRepository(repository.NewMongoProjectRepository(db), resilience)
+	commentRepo := repository.NewResilientCommentRepository(repository.NewMongoCommentRepository(db), resilience)
+	orgRepo := repository.NewResilientOrganizationRepository(repository.NewMongoOrganizationRepository(db), resilience)
+	idempotencyRepo := repository.NewResilientIdempotencyRepository(repository.NewMongoIdempotencyRepository(db), resilience)
+	timeRepo := repository.NewResilientTimeEntryRepository(repository.NewMongoTimeEntryRepository(db), resilience)
+	auditRepo := repository.NewResilientAuditRepository(repository.NewMongoAuditRepository(db), resilience)
+	accountRepo := repository.NewResilientAccountRepository(repository.NewMongoAccountRepository(db), resilience)
 
-	// Initialize repositories with the database handle.
-	taskRepo := repository.NewMongoTaskRepository(db)
-	userRepo := repository.NewMongoUserRepository(db)
+	// Optionally serve tasks read by ID from memory, reporting the cache's statistics to admins.
+	caches := map[string]usecase.ICacheStatsReporter{}
+	if cfg.Cache.Enabled {
+		cached := repository.NewCachedTaskRepository(taskRepo, cfg.Cache.Size, cfg.Cache.TTL)
+		taskRepo, caches["tasks"] = cached, cached
+		accountRepo = cached.Accounts(accountRepo)
+	}
 
 	// Initialize services with the correct types.
-	pwdSvc := service.NewPasswordHasher()
-	jwtSvc := service.NewJWTService(jwtSecret)
+	pwdSvc := service.NewPasswordHasher(cfg.Auth.BcryptCost)
+	jwtSvc, err := newJWTService(cfg.Auth)
+	if err != nil {
+		log.Fatal(err)
+	}
+	resetSender := service.NewLogResetSender(log.Default())
+
+	// Build the password policy, optionally rejecting passwords from a breached-password list.
+	var denylist []string
+	if cfg.Auth.PasswordDenylistFile != "" {
+		if denylist, err = service.LoadPasswordDenylist(cfg.Auth.PasswordDenylistFile); err != nil {
+			log.Fatal(err)
+		}
+	}
+	pwdPolicy := usecase.NewPasswordPolicy(cfg.Auth.PasswordMinLength, denylist)
+
+	// Attachment contents live on local disk, named by their digest.
+	blobStore, err := service.NewLocalBlobStore(cfg.Attachments.Dir)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Load the role-to-permission policy shared by the router and the use cases.
+	accessPolicy := usecase.DefaultAccessPolicy()
+	if cfg.Auth.PolicyFile != "" {
+		if accessPolicy, err = service.LoadAccessPolicy(cfg.Auth.PolicyFile); err != nil {
+			log.Fatal(err)
+		}
+	}
 
 	// Initialize usecases (business logic) for users and tasks.
-	userUC := usecase.NewUserUsecase(userRepo, pwdSvc, jwtSvc)
-	taskUC := usecase.NewTaskUsecase(taskRepo)
+	lockout := usecase.LockoutPolicy{
+		MaxAttempts: cfg.Auth.LockoutThreshold,
+		Duration:    cfg.Auth.LockoutDuration,
+	}
+	userUC := usecase.NewUserUsecase(userRepo, orgRepo, pwdSvc, jwtSvc, pwdPolicy, lockout, accessPolicy)
+	passwordUC := usecase.NewPasswordUsecase(userRepo, resetRepo, pwdSvc, jwtSvc, resetSender, pwdPolicy, lockout, cfg.Auth.ResetTokenTTL)
+	orgUC := usecase.NewOrganizationUsecase(orgRepo, userUC)
+	accessTokenUC := usecase.NewAccessTokenUsecase(accessTokenRepo, userRepo, accessPolicy)
+	taskUC := usecase.NewTaskUsecase(taskRepo, shareRepo, projectRepo, commentRepo, timeRepo, blobStore, userRepo, accessPolicy)
+	projectUC := usecase.NewProjectUsecase(projectRepo, taskRepo, commentRepo, timeRepo, blobStore, userRepo, accessPolicy)
+	commentUC := usecase.NewCommentUsecase(commentRepo, taskUC, userRepo, accessPolicy, cfg.Comments.EditWindow)
+	attachmentUC := usecase.NewAttachmentUsecase(taskRepo, taskUC, userRepo, blobStore, cfg.Attachments.MaxSize, cfg.Attachments.UserQuota)
+	accountUC := usecase.NewAccountUsecase(userRepo, taskRepo, commentRepo, projectRepo, accountRepo, auditRepo, blobStore, pwdSvc, lockout, accessPolicy)
+	timeUC := usecase.NewTimeUsecase(timeRepo, taskUC, userRepo, accessPolicy)
 
 	// Initialize each controller individually.
 	userCont := controller.NewUserController(userUC)
 	taskCont := controller.NewTaskController(taskUC)
+	taskV2Cont := controller.NewTaskControllerV2(taskUC, userUC)
+	shareCont := controller.NewTaskShareController(taskUC)
+	projectCont := controller.NewProjectController(projectUC, taskUC)
+	orgCont := controller.NewOrganizationController(orgUC)
+	commentCont := controller.NewCommentController(commentUC)
+	attachmentCont := controller.NewAttachmentController(attachmentUC)
+	passwordCont := controller.NewPasswordController(passwordUC)
+	tokenCont := controller.NewAccessTokenController(accessTokenUC)
+	accountCont := controller.NewAccountController(accountUC)
+	timeCont := controller.NewTimeController(timeUC)
+	jwksCont := controller.NewJWKSController(jwtSvc)
+	cacheCont := controller.NewCacheController(caches)
+	healthCont := controller.NewHealthController(controller.HealthCheck{
+		Name: "mongodb",
+		Check: func(ctx context.Context) error {
+			return client.Ping(ctx, readpref.Primary())
+		},
+	})
 
 	// Populate the RouterConfig struct
 	routerCfg := &router.RouterConfig{
-		UserCont: userCont,
-		TaskCont: taskCont,
-		JwtSvc:   jwtSvc,
+		UserCont:       userCont,
+		TaskCont:       taskCont,
+		TaskV2Cont:     taskV2Cont,
+		ShareCont:      shareCont,
+		ProjectCont:    projectCont,
+		OrgCont:        orgCont,
+		CommentCont:    commentCont,
+		AttachmentCont: attachmentCont,
+		PasswordCont:   passwordCont,
+		HealthCont:     healthCont,
+		JWKSCont:       jwksCont,
+		CacheCont:      cacheCont,
+		AccountCont:    accountCont,
+		TimeCont:       timeCont,
+		JwtSvc:         jwtSvc,
+		Sessions:       userUC,
+		AccessTokens:   accessTokenUC,
+		TokenCont:      tokenCont,
+		Policy:         accessPolicy,
+		TrustedProxies: cfg.Server.TrustedProxies,
+
+		DocsUI:           cfg.API.DocsUI,
+		ValidateRequests: cfg.API.ValidateRequests,
+	}
+	if deprecatedAt, sunset := cfg.API.V1Dates(); !deprecatedAt.IsZero() {
+		routerCfg.V1Deprecation = &middleware.Deprecation{Since: deprecatedAt, Sunset: sunset, Successor: "/api/v2"}
+	}
+	if cfg.RateLimit.Enabled {
+		routerCfg.AuthIPLimiter = middleware.NewRateLimiter(cfg.RateLimit.AuthIPLimit, cfg.RateLimit.AuthWindow)
+		routerCfg.AuthUsernameLimiter = middleware.NewRateLimiter(cfg.RateLimit.AuthUsernameLimit, cfg.RateLimit.AuthWindow)
+		routerCfg.APIUserLimiter = middleware.NewRateLimiter(cfg.RateLimit.APIUserLimit, cfg.RateLimit.APIWindow)
+	}
+	if cfg.Idempotency.Enabled {
+		routerCfg.IdempotencyKeys = middleware.NewIdempotencyKeys(idempotencyRepo, cfg.Idempotency.TTL)
 	}
 
 	// Set up the HTTP router with the config struct.
 	router := router.SetupRouter(routerCfg)
 
-	// Start the HTTP server on port 8080.
-	log.Println("Starting server on :8080")
-	if err := router.Run(":8080"); err != nil {
-		log.Fatal("Failed to start server:", err)
+	// Wrap the router in an http.Server with explicit timeouts.
+	srv := server.New(server.Config{
+		Addr:            cfg.Server.Addr,
+		ReadTimeout:     cfg.Server.ReadTimeout,
+		WriteTimeout:    cfg.Server.WriteTimeout,
+		IdleTimeout:     cfg.Server.IdleTimeout,
+		ShutdownTimeout: cfg.Server.ShutdownTimeout,
+	}, router)
+
+	// Stop advertising readiness as soon as shutdown starts, then release resources in dependency order.
+	srv.OnDrain(healthCont.MarkShuttingDown)
+	if cfg.GRPC.Enabled {
+		srv.OnShutdown("grpc", startGRPC(cfg.GRPC, rpc.Config{
+			TaskUC:        taskUC,
+			UserUC:        userUC,
+			JwtSvc:        jwtSvc,
+			Sessions:      userUC,
+			WatchInterval: cfg.GRPC.WatchInterval,
+		}))
+	}
+	srv.OnShutdown("password resets", passwordUC.Wait)
+	srv.OnShutdown("mongodb", client.Disconnect)
+
+	// Start the HTTP server and block until it has fully shut down.
+	log.Printf("Starting server on %s", cfg.Server.Addr)
+	if err := srv.Run(ctx); err != nil {
+		log.Fatal("Server shutdown with error:", err)
+	}
+	log.Println("Server stopped")
+}
+
+// startGRPC serves the gRPC API in the background and returns the function that stops it.
+func startGRPC(cfg config.GRPCConfig, rpcCfg rpc.Config) func(ctx context.Context) error {
+	ln, err := net.Listen("tcp", cfg.Addr)
+	if err != nil {
+		log.Fatal(err)
+	}
+	grpcSrv := rpc.New(rpcCfg)
+	go func() {
+		if err := grpcSrv.Serve(ln); err != nil {
+			log.Printf("gRPC server stopped with error: %v", err)
+		}
+	}()
+	log.Printf("Starting gRPC server on %s", cfg.Addr)
+	return grpcSrv.Shutdown
+}
+
+// migrate applies pending migrations when apply is set, and otherwise logs the ones still pending.
+func migrate(ctx context.Context, migrator *repository.Migrator, apply bool) error {
+	if apply {
+		applied, err := migrator.Migrate(ctx)
+		for _, m := range applied {
+			log.Printf("Applied migration %d %s", m.Version, m.Name)
+		}
+		return err
+	}
+	statuses, err := migrator.Status(ctx)
+	if err != nil {
+		return err
+	}
+	for _, m := range statuses {
+		if m.Pending() {
+			log.Printf("Migration %d %s is pending; run admin migrate", m.Version, m.Name)
+		}
+	}
+	return nil
+}
+
+// newJWTService signs with the configured private key when one is set, and with the HMAC secret otherwise.
+func newJWTService(cfg config.AuthConfig) (usecase.IJWTService, error) {
+	if cfg.SigningKeyFile == "" {
+		return service.NewJWTService(cfg.JWTSecret, cfg.TokenTTL, cfg.Issuer, cfg.Audience), nil
+	}
+	signing, err := service.LoadSigningKey(cfg.SigningKeyFile)
+	if err != nil {
+		return nil, err
+	}
+	var verification []service.SigningKey
+	for _, path := range cfg.VerificationKeyFiles {
+		key, err := service.LoadVerificationKey(path)
+		if err != nil {
+			return nil, err
+		}
+		verification = append(verification, key)
 	}
+	log.Printf("Signing JWTs with %s key %s", signing.Method.Alg(), signing.ID)
+	return service.NewAsymmetricJWTService(signing, verification, cfg.TokenTTL, cfg.Issuer, cfg.Audience)
 }
@@ -0,0 +1,498 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"strings"
+	"task_manager_test/client"
+	"time"
+)
+
+// statusCompleted is the status set by the done command.
+const statusCompleted = "completed"
+
+// defaultProfile is the profile used when none is named and none is current.
+const defaultProfile = "default"
+
+// command is a taskctl subcommand.
+type command struct {
+	name    string
+	summary string
+	run     func(ctx context.Context, a *app, fs *flag.FlagSet, args []string) error
+}
+
+// commands lists the subcommands in the order shown by -h.
+var commands = []command{
+	{"login", "log in to a server and cache the token in the profile", runLogin},
+	{"logout", "forget the token cached in the profile", runLogout},
+	{"profiles", "list the server profiles", runProfiles},
+	{"use", "make a profile the current one", runUse},
+	{"list", "list tasks, optionally filtered", runList},
+	{"show", "show a task", runShow},
+	{"create", "create a task", runCreate},
+	{"edit", "change fields of a task", runEdit},
+	{"done", "mark a task completed", runDone},
+	{"delete", "delete a task", runDelete},
+}
+
+// errFlagParse reports command flags the flag package has already complained about.
+var errFlagParse = errors.New("invalid flags")
+
+// parseArgs parses the flags of a command, which may come before or after its positional arguments, and checks that
+// exactly len(names) positional arguments were given. It returns them in order.
+func parseArgs(fs *flag.FlagSet, args []string, usage string, names ...string) ([]string, error) {
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: taskctl %s %s\n", strings.TrimPrefix(fs.Name(), "taskctl "), usage)
+		fs.PrintDefaults()
+	}
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			if errors.Is(err, flag.ErrHelp) {
+				return nil, err
+			}
+			return nil, errFlagParse
+		}
+		if fs.NArg() == 0 {
+			break
+		}
+		positional = append(positional, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+	if len(positional) != len(names) {
+		if len(names) == 0 {
+			return nil, usagef("unexpected argument %q", positional[0])
+		}
+		return nil, usagef("expected %s", strings.Join(names, " "))
+	}
+	return positional, nil
+}
+
+// parseDue parses a due date given as YYYY-MM-DD, meaning local midnight, or as an RFC 3339 time.
+func parseDue(s string) (time.Time, error) {
+	if t, err := time.ParseInLocation(time.DateOnly, s, time.Local); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	return time.Time{}, usagef("%q is not a date (YYYY-MM-DD) or an RFC 3339 time", s)
+}
+
+// config loads the config file.
+func (a *app) config() (*configFile, error) {
+	if a.configPath == "" {
+		path, err := defaultConfigPath()
+		if err != nil {
+			return nil, fmt.Errorf("locate config directory: %w; set %s", err, configEnv)
+		}
+		a.configPath = path
+	}
+	return loadConfig(a.configPath)
+}
+
+// selectedProfile returns the name of the profile chosen with -profile, or else the current one.
+func (a *app) selectedProfile(cfg *configFile) string {
+	switch {
+	case a.profileName != "":
+		return a.profileName
+	case cfg.Current != "":
+		return cfg.Current
+	default:
+		return defaultProfile
+	}
+}
+
+// client returns an API client for the selected profile, which must be logged in.
+func (a *app) client() (*client.Client, error) {
+	cfg, err := a.config()
+	if err != nil {
+		return nil, err
+	}
+	name := a.selectedProfile(cfg)
+	p, ok := cfg.Profiles[name]
+	if !ok || p.Token == "" {
+		return nil, fmt.Errorf("not logged in with profile %q; run taskctl login", name)
+	}
+	return client.New(client.Config{BaseURL: p.Server, Token: p.Token})
+}
+
+// runLogin logs in and caches the token, creating the profile if needed.
+func runLogin(ctx context.Context, a *app, fs *flag.FlagSet, args []string) error {
+	server := fs.String("server", "", "server URL, e.g. http://localhost:8080 (required for a new profile)")
+	username := fs.String("username", "", "username (default: the profile's last username)")
+	if _, err := parseArgs(fs, args, "[-server url] [-username name]"); err != nil {
+		return err
+	}
+	cfg, err := a.config()
+	if err != nil {
+		return err
+	}
+	name := a.selectedProfile(cfg)
+	p := cfg.Profiles[name]
+	if p == nil {
+		p = &profile{}
+	}
+	if *server != "" {
+		p.Server = *server
+	}
+	if *username != "" {
+		p.Username = *username
+	}
+	if p.Server == "" {
+		return usagef("profile %q has no server; pass -server", name)
+	}
+	if p.Username == "" {
+		return usagef("pass -username")
+	}
+	password, err := a.password()
+	if err != nil {
+		return err
+	}
+
+	c, err := client.New(client.Config{BaseURL: p.Server})
+	if err != nil {
+		return err
+	}
+	if p.Token, err = c.Login(ctx, p.Username, password); err != nil {
+		return err
+	}
+	cfg.Profiles[name] = p
+	if cfg.Current == "" {
+		cfg.Current = name
+	}
+	if err := cfg.save(a.configPath); err != nil {
+		return fmt.Errorf("save config: %w", err)
+	}
+	fmt.Fprintf(a.stdout, "Logged in to %s as %s (profile %s)\n", p.Server, p.Username, name)
+	return nil
+}
+
+// password returns the password from TASKCTL_PASSWORD, or else reads it as the first line of stdin.
+func (a *app) password() (string, error) {
+	if password, ok := a.lookupEnv(passwordEnv); ok {
+		return password, nil
+	}
+	fmt.Fprint(a.stderr, "Password: ")
+	line, err := bufio.NewReader(a.stdin).ReadString('\n')
+	password := strings.TrimRight(line, "\r\n")
+	if password == "" {
+		if err != nil {
+			return "", fmt.Errorf("read password: %w", err)
+		}
+		return "", usagef("password must not be empty")
+	}
+	return password, nil
+}
+
+// runLogout removes the cached token of the selected profile, keeping its server and username.
+func runLogout(_ context.Context, a *app, fs *flag.FlagSet, args []string) error {
+	if _, err := parseArgs(fs, args, ""); err != nil {
+		return err
+	}
+	cfg, err := a.config()
+	if err != nil {
+		return err
+	}
+	name := a.selectedProfile(cfg)
+	p, ok := cfg.Profiles[name]
+	if !ok {
+		return usagef("no profile named %q", name)
+	}
+	p.Token = ""
+	if err := cfg.save(a.configPath); err != nil {
+		return fmt.Errorf("save config: %w", err)
+	}
+	fmt.Fprintf(a.stdout, "Logged out of %s (profile %s)\n", p.Server, name)
+	return nil
+}
+
+// profileInfo is a profile as listed by the profiles command. Tokens are never printed.
+type profileInfo struct {
+	Name     string `json:"name"`
+	Current  bool   `json:"current"`
+	Server   string `json:"server"`
+	Username string `json:"username"`
+	LoggedIn bool   `json:"logged_in"`
+}
+
+// runProfiles lists the profiles.
+func runProfiles(_ context.Context, a *app, fs *flag.FlagSet, args []string) error {
+	if _, err := parseArgs(fs, args, ""); err != nil {
+		return err
+	}
+	cfg, err := a.config()
+	if err != nil {
+		return err
+	}
+	current := a.selectedProfile(cfg)
+	infos := make([]profileInfo, 0, len(cfg.Profiles))
+	for _, name := range cfg.names() {
+		p := cfg.Profiles[name]
+		infos = append(infos, profileInfo{
+			Name: name, Current: name == current, Server: p.Server, Username: p.Username, LoggedIn: p.Token != "",
+		})
+	}
+	if a.out.format != formatTable {
+		return a.out.structured(infos)
+	}
+	rows := make([][]string, len(infos))
+	for i, info := range infos {
+		marker := ""
+		if info.Current {
+			marker = "*"
+		}
+		loggedIn := "no"
+		if info.LoggedIn {
+			loggedIn = "yes"
+		}
+		rows[i] = []string{marker, info.Name, info.Server, info.Username, loggedIn}
+	}
+	return a.out.table([]string{"CURRENT", "NAME", "SERVER", "USERNAME", "LOGGED IN"}, rows)
+}
+
+// runUse makes a profile the current one.
+func runUse(_ context.Context, a *app, fs *flag.FlagSet, args []string) error {
+	positional, err := parseArgs(fs, args, "<profile>", "<profile>")
+	if err != nil {
+		return err
+	}
+	cfg, err := a.config()
+	if err != nil {
+		return err
+	}
+	name := positional[0]
+	if _, ok := cfg.Profiles[name]; !ok {
+		return usagef("no profile named %q; create it with taskctl -profile %s login -server <url>", name, name)
+	}
+	cfg.Current = name
+	if err := cfg.save(a.configPath); err != nil {
+		return fmt.Errorf("save config: %w", err)
+	}
+	fmt.Fprintf(a.stdout, "Using profile %s\n", name)
+	return nil
+}
+
+// taskFilter selects the tasks printed by the list command. The API returns every visible task, so the filters
+// are applied by taskctl.
+type taskFilter struct {
+	status    string
+	ownership string
+	search    string
+	dueBefore string
+	dueAfter  string
+	overdue   bool
+}
+
+// matcher returns a function reporting whether a task passes the filter, or an error for malformed dates.
+func (f taskFilter) matcher(now time.Time) (func(client.Task) bool, error) {
+	var before, after time.Time
+	var err error
+	if f.dueBefore != "" {
+		if before, err = parseDue(f.dueBefore); err != nil {
+			return nil, err
+		}
+	}
+	if f.dueAfter != "" {
+		if after, err = parseDue(f.dueAfter); err != nil {
+			return nil, err
+		}
+	}
+	search := strings.ToLower(f.search)
+	return func(t client.Task) bool {
+		switch {
+		case f.status != "" && !strings.EqualFold(t.Status, f.status):
+			return false
+		case f.ownership != "" && t.Ownership != f.ownership:
+			return false
+		case search != "" && !strings.Contains(strings.ToLower(t.Title+"\n"+t.Description), search):
+			return false
+		case !before.IsZero() && !t.DueDate.Before(before):
+			return false
+		case !after.IsZero() && t.DueDate.Before(after):
+			return false
+		case f.overdue && (!t.DueDate.Before(now) || t.Status == statusCompleted):
+			return false
+		}
+		return true
+	}, nil
+}
+
+// runList lists personal tasks, or the tasks of a project.
+func runList(ctx context.Context, a *app, fs *flag.FlagSet, args []string) error {
+	var f taskFilter
+	project := fs.String("project", "", "list the tasks of this project instead of personal tasks")
+	fs.StringVar(&f.status, "status", "", "only tasks with this status")
+	fs.StringVar(&f.ownership, "ownership", "", "only tasks you own (owner), that were shared with you (shared), or that your role lets you see (other)")
+	fs.StringVar(&f.search, "search", "", "only tasks whose title or description contains this text")
+	fs.StringVar(&f.dueBefore, "due-before", "", "only tasks due before this date")
+	fs.StringVar(&f.dueAfter, "due-after", "", "only tasks due on or after this date")
+	fs.BoolVar(&f.overdue, "overdue", false, "only tasks past their due date that are not completed")
+	if _, err := parseArgs(fs, args, "[filters]"); err != nil {
+		return err
+	}
+	match, err := f.matcher(a.now())
+	if err != nil {
+		return err
+	}
+	c, err := a.client()
+	if err != nil {
+		return err
+	}
+	var tasks []client.Task
+	if *project != "" {
+		tasks, err = c.ListProjectTasks(ctx, *project)
+	} else {
+		tasks, err = c.ListTasks(ctx)
+	}
+	if err != nil {
+		return err
+	}
+	matched := make([]client.Task, 0, len(tasks))
+	for _, t := range tasks {
+		if match(t) {
+			matched = append(matched, t)
+		}
+	}
+	return a.out.tasks(matched)
+}
+
+// runShow prints a task.
+func runShow(ctx context.Context, a *app, fs *flag.FlagSet, args []string) error {
+	positional, err := parseArgs(fs, args, "<task-id>", "<task-id>")
+	if err != nil {
+		return err
+	}
+	c, err := a.client()
+	if err != nil {
+		return err
+	}
+	task, err := c.GetTask(ctx, positional[0])
+	if err != nil {
+		return err
+	}
+	return a.out.task(task)
+}
+
+// runCreate creates a task and prints it.
+func runCreate(ctx context.Context, a *app, fs *flag.FlagSet, args []string) error {
+	title := fs.String("title", "", "title (required)")
+	description := fs.String("description", "", "description")
+	due := fs.String("due", "", "due date, YYYY-MM-DD or RFC 3339 (required)")
+	status := fs.String("status", "pending", "status")
+	project := fs.String("project", "", "create the task in this project")
+	if _, err := parseArgs(fs, args, "-title <title> -due <date> [flags]"); err != nil {
+		return err
+	}
+	if *title == "" || *due == "" {
+		return usagef("-title and -due are required")
+	}
+	dueDate, err := parseDue(*due)
+	if err != nil {
+		return err
+	}
+	c, err := a.client()
+	if err != nil {
+		return err
+	}
+	task, err := c.CreateTask(ctx, client.TaskInput{
+		Title: *title, Description: *description, DueDate: dueDate, Status: *status, ProjectID: *project,
+	})
+	if err != nil {
+		return err
+	}
+	return a.out.task(task)
+}
+
+// runEdit changes the fields given as flags and keeps the others.
+func runEdit(ctx context.Context, a *app, fs *flag.FlagSet, args []string) error {
+	title := fs.String("title", "", "new title")
+	description := fs.String("description", "", "new description")
+	due := fs.String("due", "", "new due date, YYYY-MM-DD or RFC 3339")
+	status := fs.String("status", "", "new status")
+	positional, err := parseArgs(fs, args, "<task-id> [-title t] [-description d] [-due date] [-status s]", "<task-id>")
+	if err != nil {
+		return err
+	}
+	changed := map[string]bool{}
+	fs.Visit(func(f *flag.Flag) { changed[f.Name] = true })
+	if len(changed) == 0 {
+		return usagef("nothing to change; pass at least one of -title, -description, -due or -status")
+	}
+	var dueDate time.Time
+	if changed["due"] {
+		if dueDate, err = parseDue(*due); err != nil {
+			return err
+		}
+	}
+
+	c, err := a.client()
+	if err != nil {
+		return err
+	}
+	task, err := c.GetTask(ctx, positional[0])
+	if err != nil {
+		return err
+	}
+	in := task.Input()
+	if changed["title"] {
+		in.Title = *title
+	}
+	if changed["description"] {
+		in.Description = *description
+	}
+	if changed["due"] {
+		in.DueDate = dueDate
+	}
+	if changed["status"] {
+		in.Status = *status
+	}
+	if task, err = c.UpdateTask(ctx, task.ID, in); err != nil {
+		return err
+	}
+	return a.out.task(task)
+}
+
+// runDone marks a task completed.
+func runDone(ctx context.Context, a *app, fs *flag.FlagSet, args []string) error {
+	positional, err := parseArgs(fs, args, "<task-id>", "<task-id>")
+	if err != nil {
+		return err
+	}
+	c, err := a.client()
+	if err != nil {
+		return err
+	}
+	task, err := c.GetTask(ctx, positional[0])
+	if err != nil {
+		return err
+	}
+	if task.Status != statusCompleted {
+		in := task.Input()
+		in.Status = statusCompleted
+		if task, err = c.UpdateTask(ctx, task.ID, in); err != nil {
+			return err
+		}
+	}
+	return a.out.task(task)
+}
+
+// runDelete deletes a task.
+func runDelete(ctx context.Context, a *app, fs *flag.FlagSet, args []string) error {
+	positional, err := parseArgs(fs, args, "<task-id>", "<task-id>")
+	if err != nil {
+		return err
+	}
+	c, err := a.client()
+	if err != nil {
+		return err
+	}
+	if err := c.DeleteTask(ctx, positional[0]); err != nil {
+		return err
+	}
+	fmt.Fprintf(a.stdout, "Deleted task %s\n", positional[0])
+	return nil
+}
@@ -0,0 +1,144 @@
+// Command taskctl is a command-line client for the task manager API.
+//
+// Usage:
+//
+//	taskctl [-profile name] [-output table|json|yaml] <command> [flags] [args]
+//
+// Run taskctl -h for the list of commands. Logging in caches a JWT per profile in $XDG_CONFIG_HOME/taskctl/config.yaml
+// (or the path in TASKCTL_CONFIG), so later commands need no credentials.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/signal"
+	"task_manager_test/client"
+	"time"
+)
+
+// Environment variables read by taskctl.
+const (
+	configEnv   = "TASKCTL_CONFIG"
+	profileEnv  = "TASKCTL_PROFILE"
+	passwordEnv = "TASKCTL_PASSWORD"
+)
+
+// Exit codes.
+const (
+	exitOK    = 0
+	exitError = 1
+	exitUsage = 2
+)
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	os.Exit(newApp(os.Stdin, os.Stdout, os.Stderr, os.LookupEnv).run(ctx, os.Args[1:]))
+}
+
+// app holds the streams and settings shared by the commands of one invocation.
+type app struct {
+	stdin          io.Reader
+	stdout, stderr io.Writer
+	lookupEnv      func(string) (string, bool)
+	now            func() time.Time
+
+	configPath  string
+	profileName string
+	out         printer
+}
+
+// newApp creates an app reading and writing the given streams.
+func newApp(stdin io.Reader, stdout, stderr io.Writer, lookupEnv func(string) (string, bool)) *app {
+	return &app{stdin: stdin, stdout: stdout, stderr: stderr, lookupEnv: lookupEnv, now: time.Now}
+}
+
+// usageError is a mistake in the command line, reported with exit code 2.
+type usageError struct {
+	msg string
+}
+
+// Error implements the error interface.
+func (e *usageError) Error() string {
+	return e.msg
+}
+
+// usagef formats a usageError.
+func usagef(format string, args ...any) error {
+	return &usageError{msg: fmt.Sprintf(format, args...)}
+}
+
+// run parses the global flags, runs the command and returns the exit code.
+func (a *app) run(ctx context.Context, args []string) int {
+	fs := flag.NewFlagSet("taskctl", flag.ContinueOnError)
+	fs.SetOutput(a.stderr)
+	fs.StringVar(&a.configPath, "config", "", "path to the config file (env "+configEnv+")")
+	fs.StringVar(&a.profileName, "profile", "", "server profile to use (env "+profileEnv+"; default: the current profile)")
+	format := fs.String("output", formatTable, "output format: table, json or yaml")
+	fs.Usage = func() {
+		fmt.Fprintln(a.stderr, "Usage: taskctl [flags] <command> [command flags] [args]")
+		fmt.Fprintln(a.stderr, "\nCommands:")
+		for _, c := range commands {
+			fmt.Fprintf(a.stderr, "  %-9s %s\n", c.name, c.summary)
+		}
+		fmt.Fprintln(a.stderr, "\nFlags:")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return exitOK
+		}
+		return exitUsage
+	}
+	if !validFormat(*format) {
+		fmt.Fprintf(a.stderr, "taskctl: unknown output format %q (want table, json or yaml)\n", *format)
+		return exitUsage
+	}
+	a.out = printer{w: a.stdout, format: *format}
+	if a.configPath == "" {
+		a.configPath, _ = a.lookupEnv(configEnv)
+	}
+	if a.profileName == "" {
+		a.profileName, _ = a.lookupEnv(profileEnv)
+	}
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return exitUsage
+	}
+
+	name, cmdArgs := fs.Arg(0), fs.Args()[1:]
+	for _, c := range commands {
+		if c.name != name {
+			continue
+		}
+		cmdFlags := flag.NewFlagSet("taskctl "+c.name, flag.ContinueOnError)
+		cmdFlags.SetOutput(a.stderr)
+		err := c.run(ctx, a, cmdFlags, cmdArgs)
+		var usageErr *usageError
+		switch {
+		case err == nil:
+			return exitOK
+		case errors.Is(err, flag.ErrHelp):
+			return exitOK
+		case errors.Is(err, errFlagParse):
+			return exitUsage
+		case errors.As(err, &usageErr):
+			fmt.Fprintf(a.stderr, "taskctl %s: %v\n", c.name, err)
+			return exitUsage
+		default:
+			fmt.Fprintf(a.stderr, "taskctl %s: %v\n", c.name, err)
+			var apiErr *client.Error
+			if errors.As(err, &apiErr) && apiErr.Status == http.StatusUnauthorized && c.name != "login" {
+				fmt.Fprintln(a.stderr, "Your session may have expired; run taskctl login.")
+			}
+			return exitError
+		}
+	}
+	fmt.Fprintf(a.stderr, "taskctl: unknown command %q; run taskctl -h for a list\n", name)
+	return exitUsage
+}
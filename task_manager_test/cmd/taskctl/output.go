@@ -0,0 +1,132 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"task_manager_test/client"
+	"text/tabwriter"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Output formats accepted by -output.
+const (
+	formatTable = "table"
+	formatJSON  = "json"
+	formatYAML  = "yaml"
+)
+
+// printer writes command results in the format chosen with -output.
+type printer struct {
+	w      io.Writer
+	format string
+}
+
+// validFormat reports whether format is a supported -output value.
+func validFormat(format string) bool {
+	return format == formatTable || format == formatJSON || format == formatYAML
+}
+
+// structured writes v as JSON or YAML. YAML output has the same keys, in the same order, as the JSON output.
+func (p printer) structured(v any) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	if p.format == formatJSON {
+		_, err = fmt.Fprintf(p.w, "%s\n", data)
+		return err
+	}
+	// JSON is valid YAML, so decoding it into a node keeps the key order; clearing the styles turns its flow
+	// collections and quoted strings into block YAML.
+	var node yaml.Node
+	if err := yaml.Unmarshal(data, &node); err != nil {
+		return err
+	}
+	clearStyle(&node)
+	enc := yaml.NewEncoder(p.w)
+	enc.SetIndent(2)
+	if err := enc.Encode(&node); err != nil {
+		return err
+	}
+	return enc.Close()
+}
+
+// clearStyle resets the style of node and its descendants to the encoder's default.
+func clearStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		clearStyle(child)
+	}
+}
+
+// table writes rows under a header, in aligned columns.
+func (p printer) table(header []string, rows [][]string) error {
+	tw := tabwriter.NewWriter(p.w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, strings.Join(header, "\t"))
+	for _, row := range rows {
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	return tw.Flush()
+}
+
+// tasks writes a list of tasks, one per row in table format.
+func (p printer) tasks(tasks []client.Task) error {
+	if p.format != formatTable {
+		return p.structured(tasks)
+	}
+	rows := make([][]string, len(tasks))
+	for i, t := range tasks {
+		rows[i] = []string{t.ID, t.Title, t.Status, formatDue(t.DueDate), ownerName(t)}
+	}
+	return p.table([]string{"ID", "TITLE", "STATUS", "DUE", "OWNER"}, rows)
+}
+
+// task writes a single task, one field per row in table format.
+func (p printer) task(t client.Task) error {
+	if p.format != formatTable {
+		return p.structured(t)
+	}
+	rows := [][]string{
+		{"ID:", t.ID},
+		{"Title:", t.Title},
+		{"Description:", t.Description},
+		{"Status:", t.Status},
+		{"Due:", formatDue(t.DueDate)},
+		{"Owner:", ownerName(t)},
+	}
+	if t.ProjectID != "" {
+		rows = append(rows, []string{"Project:", t.ProjectID})
+	}
+	if t.ArchivedAt != nil {
+		rows = append(rows, []string{"Archived:", t.ArchivedAt.Format(time.RFC3339)})
+	}
+	for _, a := range t.Attachments {
+		rows = append(rows, []string{"Attachment:", fmt.Sprintf("%s (%d bytes)", a.Filename, a.Size)})
+	}
+	tw := tabwriter.NewWriter(p.w, 0, 0, 1, ' ', 0)
+	for _, row := range rows {
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	return tw.Flush()
+}
+
+// formatDue renders a due date in the local time zone, omitting the time at midnight.
+func formatDue(t time.Time) string {
+	local := t.Local()
+	if local.Hour() == 0 && local.Minute() == 0 && local.Second() == 0 {
+		return local.Format(time.DateOnly)
+	}
+	return local.Format("2006-01-02 15:04")
+}
+
+// ownerName returns the owner's username, or "-" for tasks without one.
+func ownerName(t client.Task) string {
+	if t.Owner == nil {
+		return "-"
+	}
+	return t.Owner.Username
+}
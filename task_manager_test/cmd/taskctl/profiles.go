@@ -0,0 +1,76 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"gopkg.in/yaml.v3"
+)
+
+// configFile is the taskctl configuration: the servers it knows, and the token cached for each after login.
+type configFile struct {
+	// Current names the profile used when -profile is not given.
+	Current  string              `yaml:"current,omitempty"`
+	Profiles map[string]*profile `yaml:"profiles,omitempty"`
+}
+
+// profile is one server and the session cached for it.
+type profile struct {
+	Server   string `yaml:"server"`
+	Username string `yaml:"username,omitempty"`
+	Token    string `yaml:"token,omitempty"`
+}
+
+// defaultConfigPath returns the config file path inside the user's config directory.
+func defaultConfigPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "taskctl", "config.yaml"), nil
+}
+
+// loadConfig reads the config file at path. A missing file is an empty configuration.
+func loadConfig(path string) (*configFile, error) {
+	cfg := &configFile{Profiles: map[string]*profile{}}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return cfg, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := yaml.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	if cfg.Profiles == nil {
+		cfg.Profiles = map[string]*profile{}
+	}
+	return cfg, nil
+}
+
+// save writes the configuration to path. The file holds tokens, so it is readable by its owner only.
+func (c *configFile) save(path string) error {
+	data, err := yaml.Marshal(c)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return err
+	}
+	// WriteFile keeps the mode of an existing file, so tighten files created by hand.
+	return os.Chmod(path, 0o600)
+}
+
+// names returns the profile names in order.
+func (c *configFile) names() []string {
+	return slices.Sorted(maps.Keys(c.Profiles))
+}
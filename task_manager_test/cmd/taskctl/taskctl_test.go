@@ -0,0 +1,247 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"task_manager_test/client"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// TaskctlTestSuite runs taskctl commands against a fake API holding a few tasks.
+type TaskctlTestSuite struct {
+	suite.Suite
+	server     *httptest.Server
+	configPath string
+	env        map[string]string
+	tasks      map[string]client.Task
+	updates    []client.TaskInput
+	stdout     *bytes.Buffer
+	stderr     *bytes.Buffer
+}
+
+// SetupTest starts the fake API with three tasks and points taskctl at a temporary config file.
+func (s *TaskctlTestSuite) SetupTest() {
+	s.tasks = map[string]client.Task{
+		"t1": {ID: "t1", Title: "Write docs", Status: "pending", DueDate: time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC), Ownership: "owner", Owner: &client.UserRef{ID: "u1", Username: "alice"}},
+		"t2": {ID: "t2", Title: "Review PR", Status: "completed", DueDate: time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC), Ownership: "owner"},
+		"t3": {ID: "t3", Title: "Plan sprint", Description: "with the docs team", Status: "pending", DueDate: time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC), Ownership: "shared"},
+	}
+	s.updates = nil
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /login", func(w http.ResponseWriter, r *http.Request) {
+		var body struct{ Username, Password string }
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		if body.Password != "secret" {
+			w.Header().Set("Content-Type", "application/problem+json")
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"title":"invalid credentials","status":401,"code":"invalid_credentials","detail":"invalid username or password"}`))
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]string{"token": "jwt-" + body.Username})
+	})
+	authed := func(h http.HandlerFunc) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Authorization") != "Bearer jwt-alice" {
+				w.WriteHeader(http.StatusUnauthorized)
+				_, _ = w.Write([]byte(`{"title":"authentication required","status":401,"code":"unauthenticated","detail":"invalid or expired token"}`))
+				return
+			}
+			h(w, r)
+		}
+	}
+	mux.HandleFunc("GET /api/v2/tasks", authed(func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode([]client.Task{s.tasks["t1"], s.tasks["t2"], s.tasks["t3"]})
+	}))
+	mux.HandleFunc("GET /api/v2/tasks/{id}", authed(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(s.tasks[r.PathValue("id")])
+	}))
+	mux.HandleFunc("PUT /api/v2/tasks/{id}", authed(func(w http.ResponseWriter, r *http.Request) {
+		var in client.TaskInput
+		_ = json.NewDecoder(r.Body).Decode(&in)
+		s.updates = append(s.updates, in)
+		task := s.tasks[r.PathValue("id")]
+		task.Title, task.Description, task.DueDate, task.Status = in.Title, in.Description, in.DueDate, in.Status
+		_ = json.NewEncoder(w).Encode(task)
+	}))
+	mux.HandleFunc("DELETE /api/v2/tasks/{id}", authed(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	s.server = httptest.NewServer(mux)
+	s.configPath = filepath.Join(s.T().TempDir(), "taskctl", "config.yaml")
+	s.env = map[string]string{configEnv: s.configPath}
+}
+
+// TearDownTest stops the fake API.
+func (s *TaskctlTestSuite) TearDownTest() {
+	s.server.Close()
+}
+
+// TestTaskctl runs the entire test suite.
+func TestTaskctl(t *testing.T) {
+	suite.Run(t, new(TaskctlTestSuite))
+}
+
+// taskctl runs taskctl with args, feeding stdin, and returns the exit code.
+func (s *TaskctlTestSuite) taskctl(stdin string, args ...string) int {
+	s.stdout, s.stderr = new(bytes.Buffer), new(bytes.Buffer)
+	a := newApp(strings.NewReader(stdin), s.stdout, s.stderr, func(key string) (string, bool) {
+		v, ok := s.env[key]
+		return v, ok
+	})
+	a.now = func() time.Time { return time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC) }
+	return a.run(context.Background(), args)
+}
+
+// login logs in as alice with the default profile.
+func (s *TaskctlTestSuite) login() {
+	s.Require().Equal(exitOK, s.taskctl("secret\n", "login", "-server", s.server.URL, "-username", "alice"), s.stderr.String())
+}
+
+// TestLogin_CachesToken tests that the token is saved in a private config file and made the current profile.
+func (s *TaskctlTestSuite) TestLogin_CachesToken() {
+	s.login()
+
+	s.Contains(s.stdout.String(), "Logged in to "+s.server.URL+" as alice (profile default)")
+	info, err := os.Stat(s.configPath)
+	s.Require().NoError(err)
+	s.Equal(os.FileMode(0o600), info.Mode().Perm())
+	cfg, err := loadConfig(s.configPath)
+	s.Require().NoError(err)
+	s.Equal("default", cfg.Current)
+	s.Equal(&profile{Server: s.server.URL, Username: "alice", Token: "jwt-alice"}, cfg.Profiles["default"])
+}
+
+// TestLogin_PasswordFromEnv tests non-interactive logins, and that failures do not touch the config.
+func (s *TaskctlTestSuite) TestLogin_PasswordFromEnv() {
+	s.env[passwordEnv] = "wrong"
+	s.Equal(exitError, s.taskctl("", "login", "-server", s.server.URL, "-username", "alice"))
+	s.Contains(s.stderr.String(), "invalid username or password (401 invalid_credentials)")
+	s.NotContains(s.stderr.String(), "run taskctl login", "The hint is pointless after a failed login")
+	s.NoFileExists(s.configPath)
+}
+
+// TestNotLoggedIn tests that commands other than login require a cached token.
+func (s *TaskctlTestSuite) TestNotLoggedIn() {
+	s.Equal(exitError, s.taskctl("", "list"))
+	s.Contains(s.stderr.String(), `not logged in with profile "default"; run taskctl login`)
+}
+
+// TestExpiredToken tests that a rejected token suggests logging in again.
+func (s *TaskctlTestSuite) TestExpiredToken() {
+	s.login()
+	cfg, _ := loadConfig(s.configPath)
+	cfg.Profiles["default"].Token = "expired"
+	s.Require().NoError(cfg.save(s.configPath))
+
+	s.Equal(exitError, s.taskctl("", "show", "t1"))
+	s.Contains(s.stderr.String(), "run taskctl login")
+}
+
+// TestProfiles tests that profiles are listed without tokens and switched with use.
+func (s *TaskctlTestSuite) TestProfiles() {
+	s.login()
+	s.env[passwordEnv] = "secret"
+	s.Require().Equal(exitOK, s.taskctl("", "-profile", "local", "login", "-server", s.server.URL, "-username", "alice"))
+	s.Require().Equal(exitOK, s.taskctl("", "use", "local"))
+
+	s.Require().Equal(exitOK, s.taskctl("", "-output", "json", "profiles"))
+	s.JSONEq(`[
+		{"name": "default", "current": false, "server": "`+s.server.URL+`", "username": "alice", "logged_in": true},
+		{"name": "local", "current": true, "server": "`+s.server.URL+`", "username": "alice", "logged_in": true}
+	]`, s.stdout.String())
+	s.NotContains(s.stdout.String(), "jwt-")
+
+	s.Equal(exitUsage, s.taskctl("", "use", "missing"))
+}
+
+// TestList_Filters tests the client-side filters and the table output.
+func (s *TaskctlTestSuite) TestList_Filters() {
+	s.login()
+
+	s.Require().Equal(exitOK, s.taskctl("", "list", "-status", "pending", "-search", "DOCS"))
+	lines := strings.Split(strings.TrimSpace(s.stdout.String()), "\n")
+	s.Require().Len(lines, 3)
+	s.Regexp(`^ID\s+TITLE\s+STATUS\s+DUE\s+OWNER$`, lines[0])
+	s.Regexp(`^t1\s+Write docs\s+pending\s+\S+\s+alice$`, lines[1])
+	s.Regexp(`^t3\s+Plan sprint\s+pending\s+\S+\s+-$`, lines[2])
+
+	s.Require().Equal(exitOK, s.taskctl("", "-output", "json", "list", "-overdue"))
+	var tasks []client.Task
+	s.Require().NoError(json.Unmarshal(s.stdout.Bytes(), &tasks))
+	s.Require().Len(tasks, 1, "Completed tasks are never overdue")
+	s.Equal("t1", tasks[0].ID)
+
+	s.Require().Equal(exitOK, s.taskctl("", "-output", "json", "list", "-ownership", "shared", "-due-after", "2025-06-15T00:00:00Z"))
+	s.Require().NoError(json.Unmarshal(s.stdout.Bytes(), &tasks))
+	s.Require().Len(tasks, 1)
+	s.Equal("t3", tasks[0].ID)
+
+	s.Equal(exitUsage, s.taskctl("", "list", "-due-before", "next week"))
+}
+
+// TestShow_YAML tests that YAML output uses the same keys, in the same order, as JSON.
+func (s *TaskctlTestSuite) TestShow_YAML() {
+	s.login()
+
+	s.Require().Equal(exitOK, s.taskctl("", "-output", "yaml", "show", "t1"))
+
+	s.True(strings.HasPrefix(s.stdout.String(), "id: t1\ntitle: Write docs\ndescription: \"\"\ndue_date: \"2025-06-01T00:00:00Z\"\n"), s.stdout.String())
+	s.Contains(s.stdout.String(), "owner:\n  id: u1\n  username: alice\n")
+}
+
+// TestEdit_KeepsOtherFields tests that edit replaces only the fields given as flags, which may follow the ID.
+func (s *TaskctlTestSuite) TestEdit_KeepsOtherFields() {
+	s.login()
+
+	s.Require().Equal(exitOK, s.taskctl("", "edit", "t3", "-title", "Plan the sprint"), s.stderr.String())
+
+	s.Require().Len(s.updates, 1)
+	s.Equal(client.TaskInput{
+		Title: "Plan the sprint", Description: "with the docs team", DueDate: s.tasks["t3"].DueDate, Status: "pending",
+	}, s.updates[0])
+	s.Contains(s.stdout.String(), "Title:       Plan the sprint")
+
+	s.Equal(exitUsage, s.taskctl("", "edit", "t3"))
+	s.Contains(s.stderr.String(), "nothing to change")
+}
+
+// TestDone tests that done completes a task, and leaves completed tasks alone.
+func (s *TaskctlTestSuite) TestDone() {
+	s.login()
+
+	s.Require().Equal(exitOK, s.taskctl("", "done", "t1"))
+	s.Require().Len(s.updates, 1)
+	s.Equal("completed", s.updates[0].Status)
+
+	s.Require().Equal(exitOK, s.taskctl("", "done", "t2"))
+	s.Len(s.updates, 1)
+}
+
+// TestDelete tests deleting a task and argument checking.
+func (s *TaskctlTestSuite) TestDelete() {
+	s.login()
+
+	s.Require().Equal(exitOK, s.taskctl("", "delete", "t2"))
+	s.Equal("Deleted task t2\n", s.stdout.String())
+
+	s.Equal(exitUsage, s.taskctl("", "delete"))
+	s.Contains(s.stderr.String(), "expected <task-id>")
+}
+
+// TestUnknownCommandAndFormat tests the top-level usage errors.
+func (s *TaskctlTestSuite) TestUnknownCommandAndFormat() {
+	s.Equal(exitUsage, s.taskctl("", "frobnicate"))
+	s.Contains(s.stderr.String(), `unknown command "frobnicate"`)
+
+	s.Equal(exitUsage, s.taskctl("", "-output", "xml", "list"))
+	s.Contains(s.stderr.String(), `unknown output format "xml"`)
+}
@@ -0,0 +1,410 @@
+package config
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"reflect"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Config is the complete, typed runtime configuration of the server.
+//
+// Every leaf field is tagged with the key used in config files, the environment variable that overrides it,
+// and a usage string for the matching command-line flag. Fields tagged `secret:"true"` are redacted when printed,
+// and fields tagged `secret:"url"` have only the password component of the URL redacted.
+type Config struct {
+	Server      ServerConfig      `key:"server"`
+	Mongo       MongoConfig       `key:"mongo"`
+	Auth        AuthConfig        `key:"auth"`
+	RateLimit   RateLimitConfig   `key:"rate_limit"`
+	Comments    CommentsConfig    `key:"comments"`
+	Attachments AttachmentsConfig `key:"attachments"`
+	API         APIConfig         `key:"api"`
+	GRPC        GRPCConfig        `key:"grpc"`
+	Cache       CacheConfig       `key:"cache"`
+	Idempotency IdempotencyConfig `key:"idempotency"`
+}
+
+// ServerConfig holds the HTTP listener settings.
+type ServerConfig struct {
+	Addr            string        `key:"addr" env:"SERVER_ADDR" usage:"HTTP listen address"`
+	ReadTimeout     time.Duration `key:"read_timeout" env:"SERVER_READ_TIMEOUT" usage:"maximum duration for reading a request"`
+	WriteTimeout    time.Duration `key:"write_timeout" env:"SERVER_WRITE_TIMEOUT" usage:"maximum duration for writing a response"`
+	IdleTimeout     time.Duration `key:"idle_timeout" env:"SERVER_IDLE_TIMEOUT" usage:"keep-alive idle timeout"`
+	ShutdownTimeout time.Duration `key:"shutdown_timeout" env:"SERVER_SHUTDOWN_TIMEOUT" usage:"deadline for draining in-flight requests on shutdown"`
+	// TrustedProxies lists the reverse proxies whose X-Forwarded-For header is believed when determining the client IP
+	// used by rate limits. Without any, the address of the connection is used.
+	TrustedProxies []string `key:"trusted_proxies" env:"SERVER_TRUSTED_PROXIES" usage:"comma-separated IPs or CIDR ranges of reverse proxies whose X-Forwarded-For header is trusted"`
+}
+
+// MongoConfig holds the MongoDB connection settings.
+type MongoConfig struct {
+	URI            string        `key:"uri" env:"MONGODB_URI" secret:"url" usage:"MongoDB connection URI"`
+	Database       string        `key:"database" env:"MONGODB_DATABASE" usage:"MongoDB database name"`
+	ConnectTimeout time.Duration `key:"connect_timeout" env:"MONGODB_CONNECT_TIMEOUT" usage:"timeout for the initial MongoDB connection"`
+	// MigrateOnStartup applies pending schema migrations before the server starts listening.
+	MigrateOnStartup bool `key:"migrate_on_startup" env:"MONGODB_MIGRATE_ON_STARTUP" usage:"apply pending schema migrations at startup"`
+
+	// Every repository call is bounded by OperationTimeout and retried on transient failures. After BreakerThreshold
+	// consecutive failures, calls are refused with 503 for BreakerCooldown before a probe is let through.
+	OperationTimeout time.Duration `key:"operation_timeout" env:"MONGODB_OPERATION_TIMEOUT" usage:"deadline of each attempt of a database operation"`
+	MaxRetries       int           `key:"max_retries" env:"MONGODB_MAX_RETRIES" usage:"retries of a database operation after a transient failure (0 disables retries)"`
+	RetryBackoff     time.Duration `key:"retry_backoff" env:"MONGODB_RETRY_BACKOFF" usage:"upper bound of the random delay before the first retry, doubled for each further retry"`
+	BreakerThreshold int           `key:"breaker_threshold" env:"MONGODB_BREAKER_THRESHOLD" usage:"consecutive failed database operations that make the server fail fast (0 disables the circuit breaker)"`
+	BreakerCooldown  time.Duration `key:"breaker_cooldown" env:"MONGODB_BREAKER_COOLDOWN" usage:"how long the server fails fast before trying the database again"`
+}
+
+// AuthConfig holds the token and password hashing settings.
+type AuthConfig struct {
+	JWTSecret  string        `key:"jwt_secret" env:"JWT_SECRET" secret:"true" usage:"HMAC secret used to sign JWTs when no signing key file is set"`
+	TokenTTL   time.Duration `key:"token_ttl" env:"JWT_TOKEN_TTL" usage:"lifetime of issued JWTs"`
+	BcryptCost int           `key:"bcrypt_cost" env:"BCRYPT_COST" usage:"bcrypt work factor for password hashing"`
+
+	Issuer               string   `key:"issuer" env:"JWT_ISSUER" usage:"issuer (iss) written to and required in JWTs"`
+	Audience             string   `key:"audience" env:"JWT_AUDIENCE" usage:"audience (aud) written to and required in JWTs"`
+	SigningKeyFile       string   `key:"signing_key_file" env:"JWT_SIGNING_KEY_FILE" usage:"PEM private key (RSA, P-256 or Ed25519) used to sign JWTs instead of the HMAC secret"`
+	VerificationKeyFiles []string `key:"verification_key_files" env:"JWT_VERIFICATION_KEY_FILES" usage:"comma-separated PEM public keys still accepted for verification during key rotation"`
+
+	LockoutThreshold int           `key:"lockout_threshold" env:"AUTH_LOCKOUT_THRESHOLD" usage:"consecutive failed logins before an account is locked (0 disables locking)"`
+	LockoutDuration  time.Duration `key:"lockout_duration" env:"AUTH_LOCKOUT_DURATION" usage:"how long an account stays locked"`
+
+	PasswordMinLength    int           `key:"password_min_length" env:"AUTH_PASSWORD_MIN_LENGTH" usage:"minimum length of new passwords"`
+	PasswordDenylistFile string        `key:"password_denylist_file" env:"AUTH_PASSWORD_DENYLIST_FILE" usage:"file of breached passwords to reject, one per line"`
+	ResetTokenTTL        time.Duration `key:"reset_token_ttl" env:"AUTH_RESET_TOKEN_TTL" usage:"lifetime of password reset tokens"`
+
+	PolicyFile string `key:"policy_file" env:"AUTH_POLICY_FILE" usage:"YAML or TOML file mapping roles to permissions (built-in policy when unset)"`
+}
+
+// RateLimitConfig holds the token-bucket limits applied to the public auth endpoints and the authenticated API.
+// Each limit is the number of requests allowed per window, which is also the burst size.
+type RateLimitConfig struct {
+	Enabled           bool          `key:"enabled" env:"RATE_LIMIT_ENABLED" usage:"enable request rate limiting"`
+	AuthIPLimit       int           `key:"auth_ip_limit" env:"RATE_LIMIT_AUTH_IP" usage:"requests per auth window to /login and /register from one client IP"`
+	AuthUsernameLimit int           `key:"auth_username_limit" env:"RATE_LIMIT_AUTH_USERNAME" usage:"requests per auth window to /login and /register for one username"`
+	AuthWindow        time.Duration `key:"auth_window" env:"RATE_LIMIT_AUTH_WINDOW" usage:"refill window for the auth endpoint limits"`
+	APIUserLimit      int           `key:"api_user_limit" env:"RATE_LIMIT_API_USER" usage:"requests per API window to /api for one user"`
+	APIWindow         time.Duration `key:"api_window" env:"RATE_LIMIT_API_WINDOW" usage:"refill window for the /api limit"`
+}
+
+// CommentsConfig holds the task comment settings.
+type CommentsConfig struct {
+	EditWindow time.Duration `key:"edit_window" env:"COMMENTS_EDIT_WINDOW" usage:"how long authors may edit a comment after posting it"`
+}
+
+// AttachmentsConfig holds the task attachment storage settings. Sizes are in bytes.
+type AttachmentsConfig struct {
+	Dir       string `key:"dir" env:"ATTACHMENTS_DIR" usage:"directory where attachment contents are stored"`
+	MaxSize   int64  `key:"max_size" env:"ATTACHMENTS_MAX_SIZE" usage:"maximum size of one attachment in bytes"`
+	UserQuota int64  `key:"user_quota" env:"ATTACHMENTS_USER_QUOTA" usage:"maximum total size of the attachments one user may upload in bytes"`
+}
+
+// APIConfig holds the settings of the OpenAPI document served at /openapi.json.
+type APIConfig struct {
+	DocsUI           bool `key:"docs_ui" env:"API_DOCS_UI" usage:"serve browsable API documentation at /docs"`
+	ValidateRequests bool `key:"validate_requests" env:"API_VALIDATE_REQUESTS" usage:"reject requests that do not match the OpenAPI document before they reach a handler"`
+
+	// Dates are YYYY-MM-DD, in UTC.
+	V1DeprecatedAt string `key:"v1_deprecated_at" env:"API_V1_DEPRECATED_AT" usage:"date /api/v1 was deprecated, sent in its Deprecation header (empty, the default, to send none)"`
+	V1Sunset       string `key:"v1_sunset" env:"API_V1_SUNSET" usage:"date /api/v1 will be removed, sent in its Sunset header (empty to send none)"`
+}
+
+// GRPCConfig holds the settings of the gRPC listener, which serves tasks and logins to internal services.
+type GRPCConfig struct {
+	Enabled       bool          `key:"enabled" env:"GRPC_ENABLED" usage:"serve the gRPC API next to the HTTP API"`
+	Addr          string        `key:"addr" env:"GRPC_ADDR" usage:"gRPC listen address"`
+	WatchInterval time.Duration `key:"watch_interval" env:"GRPC_WATCH_INTERVAL" usage:"how often WatchTasks streams look for changed tasks"`
+}
+
+// CacheConfig holds the settings of the in-process cache of tasks read by ID.
+type CacheConfig struct {
+	Enabled bool          `key:"enabled" env:"CACHE_ENABLED" usage:"cache tasks read by ID in memory"`
+	Size    int           `key:"size" env:"CACHE_SIZE" usage:"maximum number of cached tasks"`
+	TTL     time.Duration `key:"ttl" env:"CACHE_TTL" usage:"how long a cached task is served, bounding staleness when several instances share a database"`
+}
+
+// IdempotencyConfig holds the settings of Idempotency-Key support on requests that create resources.
+type IdempotencyConfig struct {
+	Enabled bool          `key:"enabled" env:"IDEMPOTENCY_ENABLED" usage:"replay the first response to requests retried with the same Idempotency-Key"`
+	TTL     time.Duration `key:"ttl" env:"IDEMPOTENCY_TTL" usage:"how long the response to a request with an Idempotency-Key is kept for retries"`
+}
+
+// V1Dates returns the parsed v1 deprecation and sunset dates, zero when unset. Validate reports malformed dates.
+func (c APIConfig) V1Dates() (deprecatedAt, sunset time.Time) {
+	deprecatedAt, _ = parseDate(c.V1DeprecatedAt)
+	sunset, _ = parseDate(c.V1Sunset)
+	return deprecatedAt, sunset
+}
+
+// parseDate parses a YYYY-MM-DD date as midnight UTC; the empty string is the zero time.
+func parseDate(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse(time.DateOnly, s)
+}
+
+// Default returns the configuration used when no other source overrides a value.
+func Default() Config {
+	return Config{
+		Server: ServerConfig{
+			Addr:            ":8080",
+			ReadTimeout:     15 * time.Second,
+			WriteTimeout:    15 * time.Second,
+			IdleTimeout:     60 * time.Second,
+			ShutdownTimeout: 20 * time.Second,
+		},
+		Mongo: MongoConfig{
+			Database:         "taskdb",
+			ConnectTimeout:   10 * time.Second,
+			MigrateOnStartup: true,
+			OperationTimeout: 5 * time.Second,
+			MaxRetries:       2,
+			RetryBackoff:     100 * time.Millisecond,
+			BreakerThreshold: 5,
+			BreakerCooldown:  10 * time.Second,
+		},
+		Auth: AuthConfig{
+			TokenTTL:   24 * time.Hour,
+			BcryptCost: bcrypt.DefaultCost,
+			Issuer:     "task-manager",
+			Audience:   "task-manager-api",
+
+			LockoutThreshold: 5,
+			LockoutDuration:  15 * time.Minute,
+
+			PasswordMinLength: 8,
+			ResetTokenTTL:     30 * time.Minute,
+		},
+		RateLimit: RateLimitConfig{
+			Enabled:           true,
+			AuthIPLimit:       20,
+			AuthUsernameLimit: 5,
+			AuthWindow:        time.Minute,
+			APIUserLimit:      300,
+			APIWindow:         time.Minute,
+		},
+		Comments: CommentsConfig{
+			EditWindow: 15 * time.Minute,
+		},
+		Attachments: AttachmentsConfig{
+			Dir:       "data/attachments",
+			MaxSize:   10 << 20,
+			UserQuota: 100 << 20,
+		},
+		GRPC: GRPCConfig{
+			Addr:          ":9090",
+			WatchInterval: 2 * time.Second,
+		},
+		Cache: CacheConfig{
+			Size: 10000,
+			TTL:  30 * time.Second,
+		},
+		Idempotency: IdempotencyConfig{
+			Enabled: true,
+			TTL:     24 * time.Hour,
+		},
+	}
+}
+
+// ValidationError collects every problem found in a configuration so they can be reported together.
+type ValidationError struct {
+	Problems []string
+}
+
+// Error renders one problem per line.
+func (e *ValidationError) Error() string {
+	return "invalid configuration:\n  - " + strings.Join(e.Problems, "\n  - ")
+}
+
+// Validate checks the configuration for missing or out-of-range values.
+func (c Config) Validate() error {
+	var problems []string
+	add := func(format string, args ...any) {
+		problems = append(problems, fmt.Sprintf(format, args...))
+	}
+	positive := func(key string, d time.Duration) {
+		if d <= 0 {
+			add("%s must be a positive duration (got %s)", key, d)
+		}
+	}
+
+	if strings.TrimSpace(c.Server.Addr) == "" {
+		add("server.addr must not be empty")
+	}
+	positive("server.read_timeout", c.Server.ReadTimeout)
+	positive("server.write_timeout", c.Server.WriteTimeout)
+	positive("server.idle_timeout", c.Server.IdleTimeout)
+	positive("server.shutdown_timeout", c.Server.ShutdownTimeout)
+	for _, proxy := range c.Server.TrustedProxies {
+		if net.ParseIP(proxy) == nil {
+			if _, _, err := net.ParseCIDR(proxy); err != nil {
+				add("server.trusted_proxies must contain IP addresses or CIDR ranges (got %q)", proxy)
+			}
+		}
+	}
+
+	if c.Mongo.URI == "" {
+		add("mongo.uri is required (set MONGODB_URI)")
+	} else if !strings.HasPrefix(c.Mongo.URI, "mongodb://") && !strings.HasPrefix(c.Mongo.URI, "mongodb+srv://") {
+		add("mongo.uri must start with mongodb:// or mongodb+srv://")
+	}
+	if strings.TrimSpace(c.Mongo.Database) == "" {
+		add("mongo.database must not be empty")
+	}
+	positive("mongo.connect_timeout", c.Mongo.ConnectTimeout)
+	positive("mongo.operation_timeout", c.Mongo.OperationTimeout)
+	if c.Mongo.MaxRetries < 0 || c.Mongo.MaxRetries > 10 {
+		add("mongo.max_retries must be between 0 and 10 (got %d)", c.Mongo.MaxRetries)
+	}
+	if c.Mongo.MaxRetries > 0 {
+		positive("mongo.retry_backoff", c.Mongo.RetryBackoff)
+	}
+	if c.Mongo.BreakerThreshold < 0 {
+		add("mongo.breaker_threshold must not be negative (got %d)", c.Mongo.BreakerThreshold)
+	}
+	if c.Mongo.BreakerThreshold > 0 {
+		positive("mongo.breaker_cooldown", c.Mongo.BreakerCooldown)
+	}
+
+	if c.Auth.JWTSecret == "" && c.Auth.SigningKeyFile == "" {
+		add("auth.jwt_secret is required (set JWT_SECRET) unless auth.signing_key_file is set")
+	}
+	if len(c.Auth.VerificationKeyFiles) > 0 && c.Auth.SigningKeyFile == "" {
+		add("auth.verification_key_files requires auth.signing_key_file")
+	}
+	positive("auth.token_ttl", c.Auth.TokenTTL)
+	if c.Auth.BcryptCost < bcrypt.MinCost || c.Auth.BcryptCost > bcrypt.MaxCost {
+		add("auth.bcrypt_cost must be between %d and %d (got %d)", bcrypt.MinCost, bcrypt.MaxCost, c.Auth.BcryptCost)
+	}
+	if c.Auth.LockoutThreshold < 0 {
+		add("auth.lockout_threshold must not be negative (got %d)", c.Auth.LockoutThreshold)
+	}
+	if c.Auth.LockoutThreshold > 0 {
+		positive("auth.lockout_duration", c.Auth.LockoutDuration)
+	}
+	if c.Auth.PasswordMinLength < 1 || c.Auth.PasswordMinLength > 72 {
+		add("auth.password_min_length must be between 1 and 72 (got %d)", c.Auth.PasswordMinLength)
+	}
+	positive("auth.reset_token_ttl", c.Auth.ResetTokenTTL)
+
+	if c.RateLimit.Enabled {
+		atLeastOne := func(key string, n int) {
+			if n < 1 {
+				add("%s must be at least 1 when rate limiting is enabled (got %d)", key, n)
+			}
+		}
+		atLeastOne("rate_limit.auth_ip_limit", c.RateLimit.AuthIPLimit)
+		atLeastOne("rate_limit.auth_username_limit", c.RateLimit.AuthUsernameLimit)
+		atLeastOne("rate_limit.api_user_limit", c.RateLimit.APIUserLimit)
+		positive("rate_limit.auth_window", c.RateLimit.AuthWindow)
+		positive("rate_limit.api_window", c.RateLimit.APIWindow)
+	}
+
+	positive("comments.edit_window", c.Comments.EditWindow)
+
+	if strings.TrimSpace(c.Attachments.Dir) == "" {
+		add("attachments.dir must not be empty")
+	}
+	if c.Attachments.MaxSize < 1 {
+		add("attachments.max_size must be at least 1 (got %d)", c.Attachments.MaxSize)
+	}
+	if c.Attachments.UserQuota < 1 {
+		add("attachments.user_quota must be at least 1 (got %d)", c.Attachments.UserQuota)
+	}
+
+	deprecatedAt, err := parseDate(c.API.V1DeprecatedAt)
+	if err != nil {
+		add("api.v1_deprecated_at must be a YYYY-MM-DD date (got %q)", c.API.V1DeprecatedAt)
+	}
+	sunset, err := parseDate(c.API.V1Sunset)
+	switch {
+	case err != nil:
+		add("api.v1_sunset must be a YYYY-MM-DD date (got %q)", c.API.V1Sunset)
+	case sunset.IsZero():
+	case c.API.V1DeprecatedAt == "":
+		add("api.v1_sunset requires api.v1_deprecated_at")
+	case !sunset.After(deprecatedAt):
+		add("api.v1_sunset must be after api.v1_deprecated_at")
+	}
+
+	if c.GRPC.Enabled {
+		if strings.TrimSpace(c.GRPC.Addr) == "" {
+			add("grpc.addr must not be empty when gRPC is enabled")
+		} else if c.GRPC.Addr == c.Server.Addr {
+			add("grpc.addr must differ from server.addr")
+		}
+		positive("grpc.watch_interval", c.GRPC.WatchInterval)
+	}
+
+	if c.Cache.Enabled {
+		if c.Cache.Size < 1 {
+			add("cache.size must be at least 1 when the cache is enabled (got %d)", c.Cache.Size)
+		}
+		positive("cache.ttl", c.Cache.TTL)
+	}
+
+	if c.Idempotency.Enabled {
+		positive("idempotency.ttl", c.Idempotency.TTL)
+	}
+
+	if len(problems) > 0 {
+		return &ValidationError{Problems: problems}
+	}
+	return nil
+}
+
+// String renders the configuration as key=value lines with secrets redacted, suitable for startup logs.
+func (c Config) String() string {
+	var b strings.Builder
+	for _, f := range fields(&c) {
+		fmt.Fprintf(&b, "%s=%s\n", f.key, f.display())
+	}
+	return b.String()
+}
+
+// redacted is printed in place of secret values.
+const redacted = "[REDACTED]"
+
+// display formats a field's current value, redacting it according to its secret tag.
+func (f field) display() string {
+	s := formatValue(f.value)
+	switch f.secret {
+	case "true":
+		if s != "" {
+			return redacted
+		}
+	case "url":
+		return redactURL(s)
+	}
+	return s
+}
+
+// redactURL hides the password component of a URL while leaving the host visible for debugging.
+func redactURL(raw string) string {
+	if raw == "" {
+		return ""
+	}
+	u, err := url.Parse(raw)
+	if err != nil {
+		return redacted
+	}
+	return u.Redacted()
+}
+
+// formatValue renders a supported field value in the same syntax accepted by the loaders.
+func formatValue(v reflect.Value) string {
+	switch v.Kind() {
+	case reflect.Slice:
+		parts := make([]string, v.Len())
+		for i := range parts {
+			parts[i] = fmt.Sprint(v.Index(i).Interface())
+		}
+		return strings.Join(parts, ",")
+	default:
+		return fmt.Sprint(v.Interface())
+	}
+}
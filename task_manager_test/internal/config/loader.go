@@ -0,0 +1,275 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// configFileEnv names the environment variable that points at a config file when -config is not given.
+const configFileEnv = "CONFIG_FILE"
+
+// field describes a single leaf setting of Config discovered through its struct tags.
+type field struct {
+	key    string // dotted file key, e.g. "server.addr"
+	env    string
+	usage  string
+	secret string
+	value  reflect.Value
+}
+
+// flagName converts a dotted key into a command-line flag name, e.g. "server.read_timeout" -> "server-read-timeout".
+func (f field) flagName() string {
+	return strings.NewReplacer(".", "-", "_", "-").Replace(f.key)
+}
+
+// fields walks cfg and returns every tagged leaf setting in declaration order.
+func fields(cfg *Config) []field {
+	var out []field
+	var walk func(v reflect.Value, prefix string)
+	walk = func(v reflect.Value, prefix string) {
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			sf := t.Field(i)
+			key := sf.Tag.Get("key")
+			if key == "" {
+				continue
+			}
+			if prefix != "" {
+				key = prefix + "." + key
+			}
+			fv := v.Field(i)
+			if sf.Type.Kind() == reflect.Struct {
+				walk(fv, key)
+				continue
+			}
+			out = append(out, field{
+				key:    key,
+				env:    sf.Tag.Get("env"),
+				usage:  sf.Tag.Get("usage"),
+				secret: sf.Tag.Get("secret"),
+				value:  fv,
+			})
+		}
+	}
+	walk(reflect.ValueOf(cfg).Elem(), "")
+	return out
+}
+
+// set parses raw according to the field's Go type and stores it.
+func (f field) set(raw string) error {
+	raw = strings.TrimSpace(raw)
+	switch f.value.Interface().(type) {
+	case time.Duration:
+		d, err := time.ParseDuration(raw)
+		if err != nil {
+			return fmt.Errorf("%q is not a duration such as 15s or 24h", raw)
+		}
+		f.value.SetInt(int64(d))
+		return nil
+	}
+	switch f.value.Kind() {
+	case reflect.String:
+		f.value.SetString(raw)
+	case reflect.Int, reflect.Int64:
+		n, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return fmt.Errorf("%q is not an integer", raw)
+		}
+		f.value.SetInt(n)
+	case reflect.Float64:
+		n, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return fmt.Errorf("%q is not a number", raw)
+		}
+		f.value.SetFloat(n)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return fmt.Errorf("%q is not a boolean", raw)
+		}
+		f.value.SetBool(b)
+	case reflect.Slice:
+		var items []string
+		for _, item := range strings.Split(raw, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		f.value.Set(reflect.ValueOf(items))
+	default:
+		return fmt.Errorf("unsupported config field type %s", f.value.Type())
+	}
+	return nil
+}
+
+// Load builds the configuration from, in increasing order of precedence: built-in defaults, an optional YAML or
+// TOML file, environment variables and command-line flags. The file is taken from the -config flag or the
+// CONFIG_FILE environment variable. The result is validated before it is returned.
+func Load(args []string, lookupEnv func(string) (string, bool)) (Config, error) {
+	cfg := Default()
+	fs := newFlagSet(&cfg)
+	if err := fs.Parse(args); err != nil {
+		return Config{}, err
+	}
+
+	path := fs.Lookup("config").Value.String()
+	if path == "" {
+		path, _ = lookupEnv(configFileEnv)
+	}
+	if path != "" {
+		if err := applyFile(&cfg, path); err != nil {
+			return Config{}, err
+		}
+	}
+	if err := applyEnv(&cfg, lookupEnv); err != nil {
+		return Config{}, err
+	}
+	if err := applyFlags(&cfg, fs); err != nil {
+		return Config{}, err
+	}
+	if err := cfg.Validate(); err != nil {
+		return Config{}, err
+	}
+	return cfg, nil
+}
+
+// newFlagSet declares one string flag per setting. Values are parsed later by applyFlags so that flags share
+// the same parsing and error reporting as the other sources.
+func newFlagSet(cfg *Config) *flag.FlagSet {
+	fs := flag.NewFlagSet("server", flag.ContinueOnError)
+	fs.String("config", "", "path to a YAML or TOML config file (env "+configFileEnv+")")
+	for _, f := range fields(cfg) {
+		usage := f.usage
+		if f.env != "" {
+			usage += " (env " + f.env + ")"
+		}
+		fs.String(f.flagName(), f.display(), usage)
+	}
+	return fs
+}
+
+// applyFile reads a YAML or TOML file, chosen by extension, and applies every key it contains.
+// Unknown keys are rejected so that typos do not silently fall back to defaults.
+func applyFile(cfg *Config, path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("read config file: %w", err)
+	}
+
+	raw := map[string]any{}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		dec := yaml.NewDecoder(bytes.NewReader(data))
+		if err := dec.Decode(&raw); err != nil && !errors.Is(err, io.EOF) {
+			return fmt.Errorf("parse %s: %w", path, err)
+		}
+	case ".toml":
+		if err := toml.Unmarshal(data, &raw); err != nil {
+			return fmt.Errorf("parse %s: %w", path, err)
+		}
+	default:
+		return fmt.Errorf("config file %s must have a .yaml, .yml or .toml extension", path)
+	}
+
+	values := map[string]string{}
+	flatten("", raw, values)
+
+	byKey := map[string]field{}
+	for _, f := range fields(cfg) {
+		byKey[f.key] = f
+	}
+	keys := make([]string, 0, len(values))
+	for k := range values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var problems []string
+	for _, k := range keys {
+		f, ok := byKey[k]
+		if !ok {
+			problems = append(problems, fmt.Sprintf("%s: unknown key %q", path, k))
+			continue
+		}
+		if err := f.set(values[k]); err != nil {
+			problems = append(problems, fmt.Sprintf("%s: %s: %v", path, k, err))
+		}
+	}
+	if len(problems) > 0 {
+		return &ValidationError{Problems: problems}
+	}
+	return nil
+}
+
+// flatten turns nested maps into dotted keys with string values; lists are joined with commas.
+func flatten(prefix string, in map[string]any, out map[string]string) {
+	for k, v := range in {
+		key := k
+		if prefix != "" {
+			key = prefix + "." + k
+		}
+		switch val := v.(type) {
+		case map[string]any:
+			flatten(key, val, out)
+		case []any:
+			parts := make([]string, len(val))
+			for i, item := range val {
+				parts[i] = fmt.Sprint(item)
+			}
+			out[key] = strings.Join(parts, ",")
+		default:
+			out[key] = fmt.Sprint(val)
+		}
+	}
+}
+
+// applyEnv overrides settings from their environment variables, if set.
+func applyEnv(cfg *Config, lookupEnv func(string) (string, bool)) error {
+	var problems []string
+	for _, f := range fields(cfg) {
+		if f.env == "" {
+			continue
+		}
+		if v, ok := lookupEnv(f.env); ok && v != "" {
+			if err := f.set(v); err != nil {
+				problems = append(problems, fmt.Sprintf("%s: %v", f.env, err))
+			}
+		}
+	}
+	if len(problems) > 0 {
+		return &ValidationError{Problems: problems}
+	}
+	return nil
+}
+
+// applyFlags overrides settings from flags that were explicitly set on the command line.
+func applyFlags(cfg *Config, fs *flag.FlagSet) error {
+	set := map[string]string{}
+	fs.Visit(func(fl *flag.Flag) { set[fl.Name] = fl.Value.String() })
+
+	var problems []string
+	for _, f := range fields(cfg) {
+		if v, ok := set[f.flagName()]; ok {
+			if err := f.set(v); err != nil {
+				problems = append(problems, fmt.Sprintf("-%s: %v", f.flagName(), err))
+			}
+		}
+	}
+	if len(problems) > 0 {
+		return &ValidationError{Problems: problems}
+	}
+	return nil
+}
@@ -0,0 +1,170 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// LoaderTestSuite defines the test suite for loading configuration from files, environment and flags.
+type LoaderTestSuite struct {
+	suite.Suite
+	env map[string]string
+}
+
+// SetupTest provides the minimum environment required for a valid configuration.
+func (s *LoaderTestSuite) SetupTest() {
+	s.env = map[string]string{
+		"MONGODB_URI": "mongodb://localhost:27017",
+		"JWT_SECRET":  "env-secret",
+	}
+}
+
+// TestLoader runs the entire test suite.
+func TestLoader(t *testing.T) {
+	suite.Run(t, new(LoaderTestSuite))
+}
+
+// lookupEnv is an injectable replacement for os.LookupEnv backed by the suite's map.
+func (s *LoaderTestSuite) lookupEnv(key string) (string, bool) {
+	v, ok := s.env[key]
+	return v, ok
+}
+
+// writeFile creates a temporary config file with the given name and contents.
+func (s *LoaderTestSuite) writeFile(name, contents string) string {
+	path := filepath.Join(s.T().TempDir(), name)
+	s.Require().NoError(os.WriteFile(path, []byte(contents), 0o600), "Setup: failed to write config file")
+	return path
+}
+
+// TestLoad_DefaultsAndEnvironment verifies that the environment alone produces a valid configuration.
+func (s *LoaderTestSuite) TestLoad_DefaultsAndEnvironment() {
+	cfg, err := Load(nil, s.lookupEnv)
+
+	s.Require().NoError(err)
+	s.Equal("mongodb://localhost:27017", cfg.Mongo.URI)
+	s.Equal("env-secret", cfg.Auth.JWTSecret)
+	s.Equal(":8080", cfg.Server.Addr, "Unset values should keep their defaults")
+}
+
+// TestLoad_YAMLFile verifies that a YAML file is read and its values applied.
+func (s *LoaderTestSuite) TestLoad_YAMLFile() {
+	// ARRANGE
+	path := s.writeFile("config.yaml", `
+server:
+  addr: ":9090"
+  read_timeout: 5s
+mongo:
+  database: tasks_prod
+auth:
+  token_ttl: 1h
+  bcrypt_cost: 12
+`)
+
+	// ACT
+	cfg, err := Load([]string{"-config", path}, s.lookupEnv)
+
+	// ASSERT
+	s.Require().NoError(err)
+	s.Equal(":9090", cfg.Server.Addr)
+	s.Equal(5*time.Second, cfg.Server.ReadTimeout)
+	s.Equal("tasks_prod", cfg.Mongo.Database)
+	s.Equal(time.Hour, cfg.Auth.TokenTTL)
+	s.Equal(12, cfg.Auth.BcryptCost)
+}
+
+// TestLoad_TOMLFileFromEnvironment verifies that a TOML file can be selected through CONFIG_FILE.
+func (s *LoaderTestSuite) TestLoad_TOMLFileFromEnvironment() {
+	// ARRANGE
+	s.env["CONFIG_FILE"] = s.writeFile("config.toml", `
+[server]
+addr = ":7070"
+
+[mongo]
+connect_timeout = "3s"
+`)
+
+	// ACT
+	cfg, err := Load(nil, s.lookupEnv)
+
+	// ASSERT
+	s.Require().NoError(err)
+	s.Equal(":7070", cfg.Server.Addr)
+	s.Equal(3*time.Second, cfg.Mongo.ConnectTimeout)
+}
+
+// TestLoad_Precedence verifies that flags override the environment, which overrides the file, which overrides defaults.
+func (s *LoaderTestSuite) TestLoad_Precedence() {
+	// ARRANGE
+	path := s.writeFile("config.yaml", `
+server:
+  addr: ":1111"
+  idle_timeout: 90s
+mongo:
+  database: from_file
+auth:
+  token_ttl: 2h
+`)
+	s.env["MONGODB_DATABASE"] = "from_env"
+	s.env["JWT_TOKEN_TTL"] = "3h"
+
+	// ACT
+	cfg, err := Load([]string{"-config", path, "-auth-token-ttl", "4h"}, s.lookupEnv)
+
+	// ASSERT
+	s.Require().NoError(err)
+	s.Equal(":1111", cfg.Server.Addr, "File should override defaults")
+	s.Equal(90*time.Second, cfg.Server.IdleTimeout, "File should override defaults")
+	s.Equal("from_env", cfg.Mongo.Database, "Environment should override the file")
+	s.Equal(4*time.Hour, cfg.Auth.TokenTTL, "Flags should override the environment")
+	s.Equal(15*time.Second, cfg.Server.ReadTimeout, "Untouched values should keep their defaults")
+}
+
+// TestLoad_Fails_When_FileHasUnknownKey verifies that typos in config files are reported instead of ignored.
+func (s *LoaderTestSuite) TestLoad_Fails_When_FileHasUnknownKey() {
+	path := s.writeFile("config.yaml", "server:\n  adr: \":9090\"\n")
+
+	_, err := Load([]string{"-config", path}, s.lookupEnv)
+
+	s.ErrorContains(err, `unknown key "server.adr"`)
+}
+
+// TestLoad_Fails_When_ValueIsMalformed verifies that parse errors name the offending source.
+func (s *LoaderTestSuite) TestLoad_Fails_When_ValueIsMalformed() {
+	s.env["SERVER_READ_TIMEOUT"] = "fifteen"
+
+	_, err := Load(nil, s.lookupEnv)
+
+	s.ErrorContains(err, `SERVER_READ_TIMEOUT: "fifteen" is not a duration such as 15s or 24h`)
+}
+
+// TestLoad_Fails_When_FlagIsMalformed verifies that flag values go through the same parsing as other sources.
+func (s *LoaderTestSuite) TestLoad_Fails_When_FlagIsMalformed() {
+	_, err := Load([]string{"-auth-bcrypt-cost", "high"}, s.lookupEnv)
+
+	s.ErrorContains(err, `-auth-bcrypt-cost: "high" is not an integer`)
+}
+
+// TestLoad_Fails_When_ExtensionIsUnsupported verifies that only YAML and TOML files are accepted.
+func (s *LoaderTestSuite) TestLoad_Fails_When_ExtensionIsUnsupported() {
+	path := s.writeFile("config.json", "{}")
+
+	_, err := Load([]string{"-config", path}, s.lookupEnv)
+
+	s.ErrorContains(err, "must have a .yaml, .yml or .toml extension")
+}
+
+// TestLoad_Fails_When_ResultIsInvalid verifies that the merged result is validated.
+func (s *LoaderTestSuite) TestLoad_Fails_When_ResultIsInvalid() {
+	delete(s.env, "JWT_SECRET")
+
+	_, err := Load(nil, s.lookupEnv)
+
+	var verr *ValidationError
+	s.ErrorAs(err, &verr)
+	s.Contains(verr.Problems, "auth.jwt_secret is required (set JWT_SECRET) unless auth.signing_key_file is set")
+}
@@ -0,0 +1,104 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"task_manager_test/internal/domain"
+	"task_manager_test/internal/usecase"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AccessTokenController wraps use case interfaces for managing personal access tokens.
+type AccessTokenController struct {
+	tokenUC usecase.AccessTokenUsecase
+}
+
+// NewAccessTokenController creates a new Handler given AccessToken use cases.
+func NewAccessTokenController(t usecase.AccessTokenUsecase) *AccessTokenController {
+	return &AccessTokenController{tokenUC: t}
+}
+
+// AccessTokenRequest is the body accepted when creating a personal access token. A zero ExpiresAt creates a
+// token that does not expire.
+type AccessTokenRequest struct {
+	Name      string    `json:"name" binding:"required"`
+	Scopes    []string  `json:"scopes" binding:"required"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// AccessTokenResponse defines the JSON structure for token metadata returned in API responses.
+// The token value itself is only included in the response to CreateToken.
+type AccessTokenResponse struct {
+	ID         string     `json:"id"`
+	Name       string     `json:"name"`
+	Scopes     []string   `json:"scopes"`
+	Token      string     `json:"token,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	ExpiresAt  *time.Time `json:"expires_at"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	Revoked    bool       `json:"revoked"`
+}
+
+// mapToAccessTokenResponse converts a domain.AccessToken into an AccessTokenResponse, rendering unset times as null.
+func mapToAccessTokenResponse(t domain.AccessToken) AccessTokenResponse {
+	optional := func(ts time.Time) *time.Time {
+		if ts.IsZero() {
+			return nil
+		}
+		return &ts
+	}
+	return AccessTokenResponse{
+		ID:         t.ID,
+		Name:       t.Name,
+		Scopes:     t.Scopes,
+		CreatedAt:  t.CreatedAt,
+		ExpiresAt:  optional(t.ExpiresAt),
+		LastUsedAt: optional(t.LastUsedAt),
+		Revoked:    !t.RevokedAt.IsZero(),
+	}
+}
+
+// CreateToken issues a personal access token for the signed-in user. The token value is returned only once.
+func (ac *AccessTokenController) CreateToken(c *gin.Context) {
+	var body AccessTokenRequest
+	if !bindJSON(c, &body) {
+		return
+	}
+	created, token, err := ac.tokenUC.Create(c.Request.Context(), c.GetString("username"), body.Name, body.Scopes, body.ExpiresAt)
+	if err != nil {
+		if errors.Is(err, usecase.ErrForbidden) {
+			err = usecase.WithDetail(err, "only admins can create tokens with the admin scope")
+		}
+		fail(c, signedInUserGone(err))
+		return
+	}
+	resp := mapToAccessTokenResponse(created)
+	resp.Token = token
+	c.JSON(http.StatusCreated, resp)
+}
+
+// ListTokens returns the metadata of every token belonging to the signed-in user.
+func (ac *AccessTokenController) ListTokens(c *gin.Context) {
+	tokens, err := ac.tokenUC.List(c.Request.Context(), c.GetString("username"))
+	if err != nil {
+		fail(c, err)
+		return
+	}
+	responses := make([]AccessTokenResponse, len(tokens))
+	for i, t := range tokens {
+		responses[i] = mapToAccessTokenResponse(t)
+	}
+	c.JSON(http.StatusOK, responses)
+}
+
+// RevokeToken permanently disables one of the signed-in user's tokens.
+func (ac *AccessTokenController) RevokeToken(c *gin.Context) {
+	err := ac.tokenUC.Revoke(c.Request.Context(), c.GetString("username"), c.Param("id"))
+	if err != nil {
+		fail(c, notFound(err, "access token"))
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
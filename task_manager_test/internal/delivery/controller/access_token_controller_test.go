@@ -0,0 +1,140 @@
+package controller
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"task_manager_test/internal/delivery/middleware"
+	"task_manager_test/internal/domain"
+	"task_manager_test/internal/mocks"
+	"task_manager_test/internal/usecase"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+// AccessTokenControllerTestSuite defines the test suite for the AccessTokenController.
+type AccessTokenControllerTestSuite struct {
+	suite.Suite
+	router      *gin.Engine
+	mockUsecase *mocks.AccessTokenUsecase
+}
+
+// SetupTest runs before each test in the suite, ensuring a clean state.
+func (s *AccessTokenControllerTestSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+	s.mockUsecase = new(mocks.AccessTokenUsecase)
+	ac := NewAccessTokenController(s.mockUsecase)
+
+	s.router = gin.New()
+	s.router.Use(middleware.ErrorHandler())
+	me := s.router.Group("/me", func(c *gin.Context) {
+		c.Set("username", "alice")
+		c.Next()
+	})
+	me.POST("/tokens", ac.CreateToken)
+	me.GET("/tokens", ac.ListTokens)
+	me.DELETE("/tokens/:id", ac.RevokeToken)
+}
+
+// TestAccessTokenController runs the entire test suite.
+func TestAccessTokenController(t *testing.T) {
+	suite.Run(t, new(AccessTokenControllerTestSuite))
+}
+
+// send performs a request with an optional JSON body against the suite router.
+func (s *AccessTokenControllerTestSuite) send(method, path string, body any) *httptest.ResponseRecorder {
+	var buf bytes.Buffer
+	if body != nil {
+		_ = json.NewEncoder(&buf).Encode(body)
+	}
+	req, _ := http.NewRequest(method, path, &buf)
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	return w
+}
+
+//--- CreateToken Endpoint Tests ---//
+
+// TestCreateToken_Success tests that the token value is returned together with its metadata.
+func (s *AccessTokenControllerTestSuite) TestCreateToken_Success() {
+	// Arrange
+	created := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	s.mockUsecase.On("Create", mock.Anything, "alice", "ci", []string{"tasks:read"}, time.Time{}).Return(
+		domain.AccessToken{ID: "tok-1", Name: "ci", Scopes: []string{"tasks:read"}, CreatedAt: created},
+		"tmpat_secret",
+		nil,
+	).Once()
+
+	// Act
+	w := s.send(http.MethodPost, "/me/tokens", gin.H{"name": "ci", "scopes": []string{"tasks:read"}})
+
+	// Assert
+	s.Equal(http.StatusCreated, w.Code)
+	s.JSONEq(`{
+		"id": "tok-1", "name": "ci", "scopes": ["tasks:read"], "token": "tmpat_secret",
+		"created_at": "2025-01-01T12:00:00Z", "expires_at": null, "last_used_at": null, "revoked": false
+	}`, w.Body.String())
+	s.mockUsecase.AssertExpectations(s.T())
+}
+
+// TestCreateToken_ErrorMapping tests how usecase errors are translated into HTTP responses.
+func (s *AccessTokenControllerTestSuite) TestCreateToken_ErrorMapping() {
+	cases := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantCode   string
+		wantDetail string
+	}{
+		{"invalid request", &usecase.TokenRequestError{Reason: `unknown scope "x"`}, http.StatusBadRequest, "invalid_token_request", "unknown scope \"x\""},
+		{"admin scope", usecase.ErrForbidden, http.StatusForbidden, "forbidden", "only admins can create tokens with the admin scope"},
+	}
+	for _, tc := range cases {
+		s.Run(tc.name, func() {
+			s.SetupTest()
+			s.mockUsecase.On("Create", mock.Anything, "alice", "ci", []string{"x"}, time.Time{}).Return(domain.AccessToken{}, "", tc.err).Once()
+
+			w := s.send(http.MethodPost, "/me/tokens", gin.H{"name": "ci", "scopes": []string{"x"}})
+
+			assertProblem(s.T(), w, tc.wantStatus, tc.wantCode, tc.wantDetail)
+		})
+	}
+}
+
+//--- ListTokens Endpoint Tests ---//
+
+// TestListTokens_NeverIncludesTokenValue tests that listed tokens expose metadata only.
+func (s *AccessTokenControllerTestSuite) TestListTokens_NeverIncludesTokenValue() {
+	used := time.Date(2025, 1, 2, 8, 0, 0, 0, time.UTC)
+	s.mockUsecase.On("List", mock.Anything, "alice").Return([]domain.AccessToken{
+		{ID: "tok-1", Name: "ci", Scopes: []string{"tasks:read"}, LastUsedAt: used, RevokedAt: used},
+	}, nil).Once()
+
+	w := s.send(http.MethodGet, "/me/tokens", nil)
+
+	s.Equal(http.StatusOK, w.Code)
+	s.JSONEq(`[{
+		"id": "tok-1", "name": "ci", "scopes": ["tasks:read"], "created_at": "0001-01-01T00:00:00Z",
+		"expires_at": null, "last_used_at": "2025-01-02T08:00:00Z", "revoked": true
+	}]`, w.Body.String())
+}
+
+//--- RevokeToken Endpoint Tests ---//
+
+// TestRevokeToken tests successful revocation and the not-found case.
+func (s *AccessTokenControllerTestSuite) TestRevokeToken() {
+	s.mockUsecase.On("Revoke", mock.Anything, "alice", "tok-1").Return(nil).Once()
+	s.mockUsecase.On("Revoke", mock.Anything, "alice", "tok-2").Return(usecase.ErrNotFound).Once()
+
+	ok := s.send(http.MethodDelete, "/me/tokens/tok-1", nil)
+	missing := s.send(http.MethodDelete, "/me/tokens/tok-2", nil)
+
+	s.Equal(http.StatusNoContent, ok.Code)
+	assertProblem(s.T(), missing, http.StatusNotFound, "not_found", "access token not found")
+}
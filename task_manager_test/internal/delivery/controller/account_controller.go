@@ -0,0 +1,126 @@
+package controller
+
+import (
+	"mime"
+	"net/http"
+	"task_manager_test/internal/domain"
+	"task_manager_test/internal/usecase"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AccountController wraps use case interfaces for exporting and deleting accounts.
+type AccountController struct {
+	accountUC usecase.AccountUsecase
+}
+
+// NewAccountController creates a new Handler given Account use cases.
+func NewAccountController(a usecase.AccountUsecase) *AccountController {
+	return &AccountController{accountUC: a}
+}
+
+// DeleteAccountRequest is the body accepted when deleting the caller's own account.
+type DeleteAccountRequest struct {
+	Password string `json:"password" binding:"required"`
+}
+
+// AuditEntryResponse describes an operation recorded in the audit log.
+type AuditEntryResponse struct {
+	ID             string    `json:"id"`
+	Action         string    `json:"action"`
+	ActorID        string    `json:"actor_id"`
+	ActorUsername  string    `json:"actor_username"`
+	TargetUserID   string    `json:"target_user_id"`
+	TargetUsername string    `json:"target_username"`
+	At             time.Time `json:"at"`
+}
+
+// AccountExportResponse is the archive of the data held about a user.
+type AccountExportResponse struct {
+	Profile    MeResponse           `json:"profile"`
+	Tasks      []TaskResponse       `json:"tasks"`
+	Comments   []CommentResponse    `json:"comments"`
+	AuditLog   []AuditEntryResponse `json:"audit_log"`
+	ExportedAt time.Time            `json:"exported_at"`
+}
+
+// mapToAccountExportResponse converts a domain.AccountExport into an AccountExportResponse. Empty lists are kept
+// as empty arrays rather than null.
+func mapToAccountExportResponse(e domain.AccountExport) AccountExportResponse {
+	resp := AccountExportResponse{
+		Profile:    mapToMeResponse(e.User),
+		Tasks:      make([]TaskResponse, len(e.Tasks)),
+		Comments:   make([]CommentResponse, len(e.Comments)),
+		AuditLog:   make([]AuditEntryResponse, len(e.AuditLog)),
+		ExportedAt: e.ExportedAt,
+	}
+	for i, t := range e.Tasks {
+		resp.Tasks[i] = mapToTaskResponse(t)
+	}
+	for i, c := range e.Comments {
+		resp.Comments[i] = mapToCommentResponse(c)
+	}
+	for i, a := range e.AuditLog {
+		resp.AuditLog[i] = AuditEntryResponse{
+			ID:             a.ID,
+			Action:         string(a.Action),
+			ActorID:        a.ActorID,
+			ActorUsername:  a.ActorUsername,
+			TargetUserID:   a.TargetUserID,
+			TargetUsername: a.TargetUsername,
+			At:             a.At,
+		}
+	}
+	return resp
+}
+
+// ExportMe returns the caller's data as a JSON download.
+func (ac *AccountController) ExportMe(c *gin.Context) {
+	export, err := ac.accountUC.Export(c.Request.Context())
+	if err != nil {
+		fail(c, signedInUserGone(err))
+		return
+	}
+	download(c, export)
+}
+
+// DeleteMe deletes the caller's account after checking the password. The caller's tokens stop working with it.
+func (ac *AccountController) DeleteMe(c *gin.Context) {
+	var body DeleteAccountRequest
+	if !bindJSON(c, &body) {
+		return
+	}
+	if err := ac.accountUC.Delete(c.Request.Context(), body.Password); err != nil {
+		fail(c, signedInUserGone(err))
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// ExportUser returns the data of the user named in the URL as a JSON download.
+func (ac *AccountController) ExportUser(c *gin.Context) {
+	export, err := ac.accountUC.ExportUser(c.Request.Context(), c.Param("username"))
+	if err != nil {
+		fail(c, notFound(err, "user"))
+		return
+	}
+	download(c, export)
+}
+
+// DeleteUser deletes the account of the user named in the URL.
+func (ac *AccountController) DeleteUser(c *gin.Context) {
+	if err := ac.accountUC.DeleteUser(c.Request.Context(), c.Param("username")); err != nil {
+		fail(c, notFound(err, "user"))
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// download writes the export as a JSON file named after the user and the day of the export.
+func download(c *gin.Context, export domain.AccountExport) {
+	name := "account-" + export.User.Username + "-" + export.ExportedAt.UTC().Format("2006-01-02") + ".json"
+	c.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": name}))
+	c.Header("Cache-Control", "no-store")
+	c.JSON(http.StatusOK, mapToAccountExportResponse(export))
+}
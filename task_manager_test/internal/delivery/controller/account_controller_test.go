@@ -0,0 +1,147 @@
+package controller
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"task_manager_test/internal/delivery/middleware"
+	"task_manager_test/internal/domain"
+	"task_manager_test/internal/mocks"
+	"task_manager_test/internal/usecase"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+// AccountControllerTestSuite defines the test suite for the AccountController.
+type AccountControllerTestSuite struct {
+	suite.Suite
+	router      *gin.Engine
+	mockUsecase *mocks.AccountUsecase
+}
+
+// SetupTest runs before each test in the suite, ensuring a clean state.
+func (s *AccountControllerTestSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+	s.mockUsecase = new(mocks.AccountUsecase)
+	ac := NewAccountController(s.mockUsecase)
+
+	s.router = gin.New()
+	s.router.Use(middleware.ErrorHandler())
+	s.router.GET("/me/export", ac.ExportMe)
+	s.router.DELETE("/me", ac.DeleteMe)
+	s.router.GET("/admin/users/:username/export", ac.ExportUser)
+	s.router.DELETE("/admin/users/:username", ac.DeleteUser)
+}
+
+// TestAccountController runs the entire test suite.
+func TestAccountController(t *testing.T) {
+	suite.Run(t, new(AccountControllerTestSuite))
+}
+
+// send performs a request against the suite router, with a JSON body unless body is nil.
+func (s *AccountControllerTestSuite) send(method, path string, body gin.H) *httptest.ResponseRecorder {
+	var buf bytes.Buffer
+	if body != nil {
+		_ = json.NewEncoder(&buf).Encode(body)
+	}
+	req, _ := http.NewRequest(method, path, &buf)
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	return w
+}
+
+// TestExportMe_Success tests that the export is served as a JSON download with empty lists kept as arrays.
+func (s *AccountControllerTestSuite) TestExportMe_Success() {
+	// Arrange
+	at := time.Date(2025, 3, 4, 10, 0, 0, 0, time.UTC)
+	s.mockUsecase.On("Export", mock.Anything).Return(domain.AccountExport{
+		User: domain.User{ID: "user-1", Username: "alice", Role: domain.RoleMember, OrgID: "org-1"},
+		AuditLog: []domain.AuditEntry{{
+			ID: "a1", ActorID: "user-1", ActorUsername: "alice", Action: domain.AuditAccountExported,
+			TargetUserID: "user-1", TargetUsername: "alice", At: at,
+		}},
+		ExportedAt: at,
+	}, nil).Once()
+
+	// Act
+	w := s.send(http.MethodGet, "/me/export", nil)
+
+	// Assert
+	s.Equal(http.StatusOK, w.Code)
+	s.Equal(`attachment; filename=account-alice-2025-03-04.json`, w.Header().Get("Content-Disposition"))
+	s.Equal("no-store", w.Header().Get("Cache-Control"))
+	s.JSONEq(`{
+		"profile": {"id": "user-1", "username": "alice", "role": "member", "org_id": "org-1", "time_zone": ""},
+		"tasks": [],
+		"comments": [],
+		"audit_log": [{"id": "a1", "action": "account.exported", "actor_id": "user-1", "actor_username": "alice",
+			"target_user_id": "user-1", "target_username": "alice", "at": "2025-03-04T10:00:00Z"}],
+		"exported_at": "2025-03-04T10:00:00Z"
+	}`, w.Body.String())
+}
+
+// TestDeleteMe_Success tests that the password is passed on and the deletion answers 204.
+func (s *AccountControllerTestSuite) TestDeleteMe_Success() {
+	s.mockUsecase.On("Delete", mock.Anything, "secret").Return(nil).Once()
+
+	w := s.send(http.MethodDelete, "/me", gin.H{"password": "secret"})
+
+	s.Equal(http.StatusNoContent, w.Code)
+	s.mockUsecase.AssertExpectations(s.T())
+}
+
+// TestDeleteMe_ErrorMapping tests how usecase errors are translated into HTTP responses.
+func (s *AccountControllerTestSuite) TestDeleteMe_ErrorMapping() {
+	cases := []struct {
+		name       string
+		body       gin.H
+		err        error
+		wantStatus int
+		wantCode   string
+		wantDetail string
+	}{
+		{"missing password", gin.H{}, nil, http.StatusBadRequest, "invalid_request", "request body failed validation"},
+		{"wrong password", gin.H{"password": "guess"}, usecase.ErrIncorrectPassword, http.StatusForbidden, "incorrect_password", ""},
+		{"last project owner", gin.H{"password": "guess"}, usecase.WithDetail(usecase.ErrAccountOwnsProjects, "hand over ownership of Launch first"), http.StatusConflict, "account_owns_projects", "hand over ownership of Launch first"},
+		{"user deleted", gin.H{"password": "guess"}, usecase.ErrNotFound, http.StatusUnauthorized, "unauthenticated", "invalid or expired token"},
+	}
+	for _, tc := range cases {
+		s.Run(tc.name, func() {
+			s.SetupTest()
+			if tc.err != nil {
+				s.mockUsecase.On("Delete", mock.Anything, "guess").Return(tc.err).Once()
+			}
+
+			w := s.send(http.MethodDelete, "/me", tc.body)
+
+			assertProblem(s.T(), w, tc.wantStatus, tc.wantCode, tc.wantDetail)
+		})
+	}
+}
+
+// TestExportUser_Success tests that admins download the export of the user named in the URL.
+func (s *AccountControllerTestSuite) TestExportUser_Success() {
+	s.mockUsecase.On("ExportUser", mock.Anything, "bob").Return(domain.AccountExport{
+		User: domain.User{ID: "user-2", Username: "bob"}, ExportedAt: time.Date(2025, 3, 4, 0, 0, 0, 0, time.UTC),
+	}, nil).Once()
+
+	w := s.send(http.MethodGet, "/admin/users/bob/export", nil)
+
+	s.Equal(http.StatusOK, w.Code)
+	s.Equal(`attachment; filename=account-bob-2025-03-04.json`, w.Header().Get("Content-Disposition"))
+}
+
+// TestDeleteUser tests the admin deletion and the naming of a missing user.
+func (s *AccountControllerTestSuite) TestDeleteUser() {
+	s.mockUsecase.On("DeleteUser", mock.Anything, "bob").Return(nil).Once()
+	s.mockUsecase.On("DeleteUser", mock.Anything, "ghost").Return(usecase.ErrNotFound).Once()
+
+	s.Equal(http.StatusNoContent, s.send(http.MethodDelete, "/admin/users/bob", nil).Code)
+	assertProblem(s.T(), s.send(http.MethodDelete, "/admin/users/ghost", nil), http.StatusNotFound, "not_found", "user not found")
+}
@@ -0,0 +1,127 @@
+package controller
+
+import (
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"task_manager_test/internal/domain"
+	"task_manager_test/internal/usecase"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// attachmentField is the multipart form field that carries the uploaded file.
+const attachmentField = "file"
+
+// AttachmentController wraps use case interfaces for files attached to tasks.
+type AttachmentController struct {
+	attachmentUC usecase.AttachmentUsecase
+}
+
+// NewAttachmentController creates a new Handler given Attachment use cases.
+func NewAttachmentController(a usecase.AttachmentUsecase) *AttachmentController {
+	return &AttachmentController{attachmentUC: a}
+}
+
+// AttachmentResponse defines the JSON structure for attachment metadata returned in API responses.
+type AttachmentResponse struct {
+	ID          string    `json:"id"`
+	Filename    string    `json:"filename"`
+	ContentType string    `json:"content_type"`
+	Size        int64     `json:"size"`
+	SHA256      string    `json:"sha256"`
+	UploadedBy  string    `json:"uploaded_by"`
+	UploadedAt  time.Time `json:"uploaded_at"`
+}
+
+// mapToAttachmentResponse converts a domain.Attachment into an AttachmentResponse for API output.
+func mapToAttachmentResponse(a domain.Attachment) AttachmentResponse {
+	return AttachmentResponse{
+		ID:          a.ID,
+		Filename:    a.Filename,
+		ContentType: a.ContentType,
+		Size:        a.Size,
+		SHA256:      a.Digest,
+		UploadedBy:  a.UploadedBy,
+		UploadedAt:  a.UploadedAt,
+	}
+}
+
+// respondAttachmentError records errors shared by every attachment endpoint. Attachments of tasks the caller
+// cannot see are reported as a missing task.
+func respondAttachmentError(c *gin.Context, err error) {
+	fail(c, notFound(err, "task"))
+}
+
+// UploadAttachment attaches the file sent in the "file" field of a multipart/form-data request. The file is
+// streamed to storage rather than buffered in memory.
+func (ac *AttachmentController) UploadAttachment(c *gin.Context) {
+	reader, err := c.Request.MultipartReader()
+	if err != nil {
+		fail(c, invalidRequest("request must be multipart/form-data"))
+		return
+	}
+	for {
+		part, err := reader.NextPart()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			fail(c, invalidRequest("malformed multipart body"))
+			return
+		}
+		if part.FormName() != attachmentField {
+			part.Close()
+			continue
+		}
+		attachment, err := ac.attachmentUC.Upload(c.Request.Context(), c.Param("id"), part.FileName(), part)
+		part.Close()
+		if err != nil {
+			respondAttachmentError(c, err)
+			return
+		}
+		c.JSON(http.StatusCreated, mapToAttachmentResponse(attachment))
+		return
+	}
+	fail(c, invalidRequest("file is required"))
+}
+
+// ListAttachments returns the metadata of the task's attachments.
+func (ac *AttachmentController) ListAttachments(c *gin.Context) {
+	attachments, err := ac.attachmentUC.List(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		respondAttachmentError(c, err)
+		return
+	}
+	resp := make([]AttachmentResponse, len(attachments))
+	for i, a := range attachments {
+		resp[i] = mapToAttachmentResponse(a)
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// DownloadAttachment streams an attachment's content. It is always served as a download with the sniffed content
+// type, so browsers never render uploaded HTML in the API's origin.
+func (ac *AttachmentController) DownloadAttachment(c *gin.Context) {
+	attachment, content, err := ac.attachmentUC.Open(c.Request.Context(), c.Param("id"), c.Param("aid"))
+	if err != nil {
+		respondAttachmentError(c, err)
+		return
+	}
+	defer content.Close()
+	c.DataFromReader(http.StatusOK, attachment.Size, attachment.ContentType, content, map[string]string{
+		"Content-Disposition":    mime.FormatMediaType("attachment", map[string]string{"filename": attachment.Filename}),
+		"X-Content-Type-Options": "nosniff",
+	})
+}
+
+// DeleteAttachment removes an attachment from the task.
+func (ac *AttachmentController) DeleteAttachment(c *gin.Context) {
+	if err := ac.attachmentUC.Delete(c.Request.Context(), c.Param("id"), c.Param("aid")); err != nil {
+		respondAttachmentError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,182 @@
+package controller
+
+import (
+	"bytes"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"task_manager_test/internal/delivery/middleware"
+	"task_manager_test/internal/domain"
+	"task_manager_test/internal/mocks"
+	"task_manager_test/internal/usecase"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+// AttachmentControllerTestSuite defines the test suite for the AttachmentController.
+type AttachmentControllerTestSuite struct {
+	suite.Suite
+	router      *gin.Engine
+	mockUsecase *mocks.AttachmentUsecase
+}
+
+// SetupTest runs before each test in the suite, ensuring a clean state.
+func (s *AttachmentControllerTestSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+	s.mockUsecase = new(mocks.AttachmentUsecase)
+	ac := NewAttachmentController(s.mockUsecase)
+
+	s.router = gin.New()
+	s.router.Use(middleware.ErrorHandler())
+	s.router.GET("/tasks/:id/attachments", ac.ListAttachments)
+	s.router.POST("/tasks/:id/attachments", ac.UploadAttachment)
+	s.router.GET("/tasks/:id/attachments/:aid", ac.DownloadAttachment)
+	s.router.DELETE("/tasks/:id/attachments/:aid", ac.DeleteAttachment)
+}
+
+// TestAttachmentController runs the entire test suite.
+func TestAttachmentController(t *testing.T) {
+	suite.Run(t, new(AttachmentControllerTestSuite))
+}
+
+// upload sends a multipart request with the content in the given form field.
+func (s *AttachmentControllerTestSuite) upload(field, filename, content string) *httptest.ResponseRecorder {
+	var buf bytes.Buffer
+	mw := multipart.NewWriter(&buf)
+	_ = mw.WriteField("comment", "ignored")
+	fw, _ := mw.CreateFormFile(field, filename)
+	_, _ = fw.Write([]byte(content))
+	_ = mw.Close()
+	req, _ := http.NewRequest(http.MethodPost, "/tasks/task-123/attachments", &buf)
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	return w
+}
+
+//--- UploadAttachment Endpoint Tests ---//
+
+// TestUploadAttachment_Success tests that the file part is streamed to the use case.
+func (s *AttachmentControllerTestSuite) TestUploadAttachment_Success() {
+	// Arrange
+	at := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	att := domain.Attachment{
+		ID: "att-1", Filename: "notes.txt", ContentType: "text/plain; charset=utf-8", Size: 5,
+		Digest: "abc", UploadedBy: "user-1", UploadedAt: at,
+	}
+	var received string
+	s.mockUsecase.On("Upload", mock.Anything, "task-123", "notes.txt", mock.Anything).Run(func(args mock.Arguments) {
+		b, _ := io.ReadAll(args.Get(3).(io.Reader))
+		received = string(b)
+	}).Return(att, nil).Once()
+
+	// Act
+	w := s.upload("file", "notes.txt", "hello")
+
+	// Assert
+	s.Equal(http.StatusCreated, w.Code)
+	s.Equal("hello", received)
+	s.JSONEq(`{"id": "att-1", "filename": "notes.txt", "content_type": "text/plain; charset=utf-8", "size": 5,
+		"sha256": "abc", "uploaded_by": "user-1", "uploaded_at": "2025-01-01T12:00:00Z"}`, w.Body.String())
+}
+
+// TestUploadAttachment_BadRequest tests requests without a file part or not encoded as multipart.
+func (s *AttachmentControllerTestSuite) TestUploadAttachment_BadRequest() {
+	w := s.upload("document", "notes.txt", "hello")
+	assertProblem(s.T(), w, http.StatusBadRequest, "invalid_request", "file is required")
+
+	req, _ := http.NewRequest(http.MethodPost, "/tasks/task-123/attachments", strings.NewReader(`{}`))
+	req.Header.Set("Content-Type", "application/json")
+	w = httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	assertProblem(s.T(), w, http.StatusBadRequest, "invalid_request", "request must be multipart/form-data")
+
+	s.mockUsecase.AssertNotCalled(s.T(), "Upload", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+// TestAttachmentErrorMapping tests how usecase errors are translated into HTTP responses.
+func (s *AttachmentControllerTestSuite) TestAttachmentErrorMapping() {
+	cases := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantCode   string
+		wantDetail string
+	}{
+		{"hidden task", usecase.ErrNotFound, http.StatusNotFound, "not_found", "task not found"},
+		{"read-only task", usecase.ErrForbidden, http.StatusForbidden, "forbidden", ""},
+		{"too large", usecase.ErrAttachmentTooLarge, http.StatusRequestEntityTooLarge, "attachment_too_large", ""},
+		{"over quota", usecase.ErrAttachmentQuotaExceeded, http.StatusRequestEntityTooLarge, "attachment_quota_exceeded", ""},
+		{"empty file", &usecase.AttachmentRequestError{Reason: "file is empty"}, http.StatusBadRequest, "invalid_attachment_request", "file is empty"},
+	}
+	for _, tc := range cases {
+		s.Run(tc.name, func() {
+			s.SetupTest()
+			s.mockUsecase.On("Upload", mock.Anything, "task-123", "a.txt", mock.Anything).Return(domain.Attachment{}, tc.err).Once()
+
+			w := s.upload("file", "a.txt", "data")
+
+			assertProblem(s.T(), w, tc.wantStatus, tc.wantCode, tc.wantDetail)
+		})
+	}
+}
+
+//--- DownloadAttachment Endpoint Tests ---//
+
+// TestDownloadAttachment_Success tests that content is served as a download with the sniffed content type.
+func (s *AttachmentControllerTestSuite) TestDownloadAttachment_Success() {
+	att := domain.Attachment{ID: "att-1", Filename: "report 1.pdf", ContentType: "application/pdf", Size: 4}
+	s.mockUsecase.On("Open", mock.Anything, "task-123", "att-1").Return(att, io.NopCloser(strings.NewReader("%PDF")), nil).Once()
+
+	req, _ := http.NewRequest(http.MethodGet, "/tasks/task-123/attachments/att-1", nil)
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+
+	s.Equal(http.StatusOK, w.Code)
+	s.Equal("%PDF", w.Body.String())
+	s.Equal("application/pdf", w.Header().Get("Content-Type"))
+	s.Equal(`attachment; filename="report 1.pdf"`, w.Header().Get("Content-Disposition"))
+	s.Equal("nosniff", w.Header().Get("X-Content-Type-Options"))
+}
+
+// TestDownloadAttachment_NotFound tests unknown attachments.
+func (s *AttachmentControllerTestSuite) TestDownloadAttachment_NotFound() {
+	s.mockUsecase.On("Open", mock.Anything, "task-123", "att-9").Return(domain.Attachment{}, nil, usecase.ErrAttachmentNotFound).Once()
+
+	req, _ := http.NewRequest(http.MethodGet, "/tasks/task-123/attachments/att-9", nil)
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+
+	assertProblem(s.T(), w, http.StatusNotFound, "attachment_not_found", "")
+}
+
+//--- ListAttachments and DeleteAttachment Endpoint Tests ---//
+
+// TestListAttachments_Success tests that an empty list is rendered as an array.
+func (s *AttachmentControllerTestSuite) TestListAttachments_Success() {
+	s.mockUsecase.On("List", mock.Anything, "task-123").Return(nil, nil).Once()
+
+	req, _ := http.NewRequest(http.MethodGet, "/tasks/task-123/attachments", nil)
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+
+	s.Equal(http.StatusOK, w.Code)
+	s.JSONEq(`[]`, w.Body.String())
+}
+
+// TestDeleteAttachment_Success tests deleting an attachment.
+func (s *AttachmentControllerTestSuite) TestDeleteAttachment_Success() {
+	s.mockUsecase.On("Delete", mock.Anything, "task-123", "att-1").Return(nil).Once()
+
+	req, _ := http.NewRequest(http.MethodDelete, "/tasks/task-123/attachments/att-1", nil)
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+
+	s.Equal(http.StatusNoContent, w.Code)
+}
@@ -0,0 +1,53 @@
+package controller
+
+import (
+	"net/http"
+	"task_manager_test/internal/usecase"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CacheController reports the statistics of the in-process caches.
+type CacheController struct {
+	caches map[string]usecase.ICacheStatsReporter
+}
+
+// NewCacheController creates a new Handler reporting on the given caches, keyed by the name they are reported under.
+func NewCacheController(caches map[string]usecase.ICacheStatsReporter) *CacheController {
+	return &CacheController{caches: caches}
+}
+
+// CacheStatsResponse defines the JSON structure reported for each cache.
+type CacheStatsResponse struct {
+	Hits      uint64  `json:"hits"`
+	Misses    uint64  `json:"misses"`
+	HitRatio  float64 `json:"hit_ratio"`
+	Evictions uint64  `json:"evictions"`
+	Entries   int     `json:"entries"`
+	Capacity  int     `json:"capacity"`
+}
+
+// toCacheStatsResponse maps cache statistics to their response representation.
+func toCacheStatsResponse(s usecase.CacheStats) CacheStatsResponse {
+	var ratio float64
+	if lookups := s.Hits + s.Misses; lookups > 0 {
+		ratio = float64(s.Hits) / float64(lookups)
+	}
+	return CacheStatsResponse{
+		Hits:      s.Hits,
+		Misses:    s.Misses,
+		HitRatio:  ratio,
+		Evictions: s.Evictions,
+		Entries:   s.Entries,
+		Capacity:  s.Capacity,
+	}
+}
+
+// Stats reports every cache's statistics; the map is empty when caching is disabled.
+func (cc *CacheController) Stats(c *gin.Context) {
+	out := make(map[string]CacheStatsResponse, len(cc.caches))
+	for name, cache := range cc.caches {
+		out[name] = toCacheStatsResponse(cache.Stats())
+	}
+	c.JSON(http.StatusOK, gin.H{"caches": out})
+}
@@ -0,0 +1,45 @@
+package controller
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"task_manager_test/internal/usecase"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// fixedCacheStats reports the same statistics on every call.
+type fixedCacheStats usecase.CacheStats
+
+// Stats implements usecase.ICacheStatsReporter.
+func (f fixedCacheStats) Stats() usecase.CacheStats { return usecase.CacheStats(f) }
+
+// serveCacheStats performs a GET request against a CacheController reporting on caches.
+func serveCacheStats(caches map[string]usecase.ICacheStatsReporter) *httptest.ResponseRecorder {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/admin/cache", NewCacheController(caches).Stats)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin/cache", nil))
+	return w
+}
+
+// TestCacheStats verifies that every cache is reported under its name with its hit ratio.
+func TestCacheStats(t *testing.T) {
+	w := serveCacheStats(map[string]usecase.ICacheStatsReporter{
+		"tasks": fixedCacheStats{Hits: 3, Misses: 1, Evictions: 2, Entries: 10, Capacity: 100},
+	})
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"caches": {"tasks": {"hits": 3, "misses": 1, "hit_ratio": 0.75, "evictions": 2, "entries": 10, "capacity": 100}}}`, w.Body.String())
+}
+
+// TestCacheStats_Disabled verifies that an empty map is reported when no cache is enabled.
+func TestCacheStats_Disabled(t *testing.T) {
+	w := serveCacheStats(nil)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"caches": {}}`, w.Body.String())
+}
@@ -0,0 +1,155 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+	"task_manager_test/internal/domain"
+	"task_manager_test/internal/usecase"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CommentController wraps use case interfaces for discussing tasks.
+type CommentController struct {
+	commentUC usecase.CommentUsecase
+}
+
+// NewCommentController creates a new Handler given Comment use cases.
+func NewCommentController(c usecase.CommentUsecase) *CommentController {
+	return &CommentController{commentUC: c}
+}
+
+// CommentMentionResponse defines the JSON structure for a user mentioned in a comment.
+type CommentMentionResponse struct {
+	UserID   string `json:"user_id"`
+	Username string `json:"username"`
+}
+
+// CommentResponse defines the JSON structure for comment data returned in API responses.
+type CommentResponse struct {
+	ID        string                   `json:"id"`
+	ParentID  string                   `json:"parent_id,omitempty"`
+	Author    string                   `json:"author"`
+	Body      string                   `json:"body"`
+	Mentions  []CommentMentionResponse `json:"mentions"`
+	CreatedAt time.Time                `json:"created_at"`
+	// EditedAt is only set on comments that have been edited.
+	EditedAt *time.Time `json:"edited_at,omitempty"`
+	// Replies is only set on top-level comments when listing.
+	Replies []CommentResponse `json:"replies,omitempty"`
+}
+
+// CommentPageResponse defines the JSON structure for a page of comment threads.
+type CommentPageResponse struct {
+	Comments []CommentResponse `json:"comments"`
+	Page     int               `json:"page"`
+	PerPage  int               `json:"per_page"`
+	Total    int64             `json:"total"`
+}
+
+// mapToCommentResponse converts a domain.Comment into a CommentResponse for API output.
+func mapToCommentResponse(c domain.Comment) CommentResponse {
+	mentions := make([]CommentMentionResponse, len(c.Mentions))
+	for i, m := range c.Mentions {
+		mentions[i] = CommentMentionResponse{UserID: m.UserID, Username: m.Username}
+	}
+	resp := CommentResponse{
+		ID:        c.ID,
+		ParentID:  c.ParentID,
+		Author:    c.AuthorUsername,
+		Body:      c.Body,
+		Mentions:  mentions,
+		CreatedAt: c.CreatedAt,
+	}
+	if !c.EditedAt.IsZero() {
+		resp.EditedAt = &c.EditedAt
+	}
+	return resp
+}
+
+// CommentRequest is the body accepted when posting a comment. ParentID makes the comment a reply.
+type CommentRequest struct {
+	Body     string `json:"body" binding:"required"`
+	ParentID string `json:"parent_id"`
+}
+
+// CommentUpdateRequest is the body accepted when editing a comment.
+type CommentUpdateRequest struct {
+	Body string `json:"body" binding:"required"`
+}
+
+// respondCommentError records errors shared by every comment endpoint. Comments on tasks the caller cannot see are
+// reported as a missing task.
+func respondCommentError(c *gin.Context, err error) {
+	fail(c, notFound(err, "task"))
+}
+
+// CreateComment posts a comment on the task, or a reply when parent_id is given.
+func (cc *CommentController) CreateComment(c *gin.Context) {
+	var body CommentRequest
+	if !bindJSON(c, &body) {
+		return
+	}
+	comment, err := cc.commentUC.Create(c.Request.Context(), c.Param("id"), body.ParentID, body.Body)
+	if err != nil {
+		respondCommentError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, mapToCommentResponse(comment))
+}
+
+// ListComments returns a page of the task's comment threads, selected with the page and per_page query parameters.
+func (cc *CommentController) ListComments(c *gin.Context) {
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil {
+		fail(c, invalidRequest("page must be a number"))
+		return
+	}
+	perPage, err := strconv.Atoi(c.DefaultQuery("per_page", "0"))
+	if err != nil {
+		fail(c, invalidRequest("per_page must be a number"))
+		return
+	}
+	result, err := cc.commentUC.List(c.Request.Context(), c.Param("id"), page, perPage)
+	if err != nil {
+		respondCommentError(c, err)
+		return
+	}
+	resp := CommentPageResponse{
+		Comments: make([]CommentResponse, len(result.Threads)),
+		Page:     result.Page,
+		PerPage:  result.PerPage,
+		Total:    result.Total,
+	}
+	for i, thread := range result.Threads {
+		resp.Comments[i] = mapToCommentResponse(thread.Comment)
+		for _, reply := range thread.Replies {
+			resp.Comments[i].Replies = append(resp.Comments[i].Replies, mapToCommentResponse(reply))
+		}
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// UpdateComment changes the body of the caller's own comment.
+func (cc *CommentController) UpdateComment(c *gin.Context) {
+	var body CommentUpdateRequest
+	if !bindJSON(c, &body) {
+		return
+	}
+	comment, err := cc.commentUC.Update(c.Request.Context(), c.Param("id"), c.Param("cid"), body.Body)
+	if err != nil {
+		respondCommentError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, mapToCommentResponse(comment))
+}
+
+// DeleteComment removes a comment together with its replies.
+func (cc *CommentController) DeleteComment(c *gin.Context) {
+	if err := cc.commentUC.Delete(c.Request.Context(), c.Param("id"), c.Param("cid")); err != nil {
+		respondCommentError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,152 @@
+package controller
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"task_manager_test/internal/delivery/middleware"
+	"task_manager_test/internal/domain"
+	"task_manager_test/internal/mocks"
+	"task_manager_test/internal/usecase"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+// CommentControllerTestSuite defines the test suite for the CommentController.
+type CommentControllerTestSuite struct {
+	suite.Suite
+	router      *gin.Engine
+	mockUsecase *mocks.CommentUsecase
+}
+
+// SetupTest runs before each test in the suite, ensuring a clean state.
+func (s *CommentControllerTestSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+	s.mockUsecase = new(mocks.CommentUsecase)
+	cc := NewCommentController(s.mockUsecase)
+
+	s.router = gin.New()
+	s.router.Use(middleware.ErrorHandler())
+	s.router.GET("/tasks/:id/comments", cc.ListComments)
+	s.router.POST("/tasks/:id/comments", cc.CreateComment)
+	s.router.PUT("/tasks/:id/comments/:cid", cc.UpdateComment)
+	s.router.DELETE("/tasks/:id/comments/:cid", cc.DeleteComment)
+}
+
+// TestCommentController runs the entire test suite.
+func TestCommentController(t *testing.T) {
+	suite.Run(t, new(CommentControllerTestSuite))
+}
+
+// send performs a JSON request against the suite router.
+func (s *CommentControllerTestSuite) send(method, path string, body gin.H) *httptest.ResponseRecorder {
+	var buf bytes.Buffer
+	if body != nil {
+		_ = json.NewEncoder(&buf).Encode(body)
+	}
+	req, _ := http.NewRequest(method, path, &buf)
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	return w
+}
+
+//--- CreateComment Endpoint Tests ---//
+
+// TestCreateComment_Success tests posting a reply and rendering its mentions.
+func (s *CommentControllerTestSuite) TestCreateComment_Success() {
+	// Arrange
+	createdAt := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	comment := domain.Comment{
+		ID: "c2", TaskID: "task-123", ParentID: "c1", AuthorID: "user-1", AuthorUsername: "alice",
+		Body: "thanks @bob", Mentions: []domain.CommentMention{{UserID: "user-2", Username: "bob"}}, CreatedAt: createdAt,
+	}
+	s.mockUsecase.On("Create", mock.Anything, "task-123", "c1", "thanks @bob").Return(comment, nil).Once()
+
+	// Act
+	w := s.send(http.MethodPost, "/tasks/task-123/comments", gin.H{"body": "thanks @bob", "parent_id": "c1"})
+
+	// Assert
+	s.Equal(http.StatusCreated, w.Code)
+	s.JSONEq(`{"id": "c2", "parent_id": "c1", "author": "alice", "body": "thanks @bob",
+		"mentions": [{"user_id": "user-2", "username": "bob"}], "created_at": "2025-01-01T12:00:00Z"}`, w.Body.String())
+	s.mockUsecase.AssertExpectations(s.T())
+}
+
+// TestCommentErrorMapping tests how usecase errors are translated into HTTP responses.
+func (s *CommentControllerTestSuite) TestCommentErrorMapping() {
+	cases := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantCode   string
+		wantDetail string
+	}{
+		{"hidden task", usecase.ErrNotFound, http.StatusNotFound, "not_found", "task not found"},
+		{"unknown comment", usecase.ErrCommentNotFound, http.StatusNotFound, "comment_not_found", ""},
+		{"not the author", usecase.ErrForbidden, http.StatusForbidden, "forbidden", ""},
+		{"window closed", usecase.ErrCommentEditWindowClosed, http.StatusForbidden, "comment_edit_window_closed", ""},
+		{"invalid body", &usecase.CommentRequestError{Reason: "body is required"}, http.StatusBadRequest, "invalid_comment_request", "body is required"},
+	}
+	for _, tc := range cases {
+		s.Run(tc.name, func() {
+			s.SetupTest()
+			s.mockUsecase.On("Update", mock.Anything, "task-123", "c1", "edited").Return(domain.Comment{}, tc.err).Once()
+
+			w := s.send(http.MethodPut, "/tasks/task-123/comments/c1", gin.H{"body": "edited"})
+
+			assertProblem(s.T(), w, tc.wantStatus, tc.wantCode, tc.wantDetail)
+		})
+	}
+}
+
+//--- ListComments Endpoint Tests ---//
+
+// TestListComments_Success tests that threads are rendered with nested replies and paging metadata.
+func (s *CommentControllerTestSuite) TestListComments_Success() {
+	// Arrange
+	at := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	page := domain.CommentPage{
+		Threads: []domain.CommentThread{{
+			Comment: domain.Comment{ID: "c1", AuthorUsername: "alice", Body: "first", CreatedAt: at, EditedAt: at.Add(time.Minute)},
+			Replies: []domain.Comment{{ID: "c2", ParentID: "c1", AuthorUsername: "bob", Body: "reply", CreatedAt: at}},
+		}},
+		Page: 2, PerPage: 10, Total: 11,
+	}
+	s.mockUsecase.On("List", mock.Anything, "task-123", 2, 10).Return(page, nil).Once()
+
+	// Act
+	w := s.send(http.MethodGet, "/tasks/task-123/comments?page=2&per_page=10", nil)
+
+	// Assert
+	s.Equal(http.StatusOK, w.Code)
+	s.JSONEq(`{"page": 2, "per_page": 10, "total": 11, "comments": [{
+		"id": "c1", "author": "alice", "body": "first", "mentions": [],
+		"created_at": "2025-01-01T12:00:00Z", "edited_at": "2025-01-01T12:01:00Z",
+		"replies": [{"id": "c2", "parent_id": "c1", "author": "bob", "body": "reply", "mentions": [], "created_at": "2025-01-01T12:00:00Z"}]
+	}]}`, w.Body.String())
+}
+
+// TestListComments_BadQuery tests that non-numeric paging parameters are rejected.
+func (s *CommentControllerTestSuite) TestListComments_BadQuery() {
+	w := s.send(http.MethodGet, "/tasks/task-123/comments?page=two", nil)
+
+	assertProblem(s.T(), w, http.StatusBadRequest, "invalid_request", "page must be a number")
+	s.mockUsecase.AssertNotCalled(s.T(), "List", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+//--- DeleteComment Endpoint Tests ---//
+
+// TestDeleteComment_Success tests deleting a comment.
+func (s *CommentControllerTestSuite) TestDeleteComment_Success() {
+	s.mockUsecase.On("Delete", mock.Anything, "task-123", "c1").Return(nil).Once()
+
+	w := s.send(http.MethodDelete, "/tasks/task-123/comments/c1", nil)
+
+	s.Equal(http.StatusNoContent, w.Code)
+}
@@ -0,0 +1,35 @@
+package controller
+
+import (
+	_ "embed"
+	"net/http"
+	"task_manager_test/internal/delivery/openapi"
+
+	"github.com/gin-gonic/gin"
+)
+
+// docsPage renders /openapi.json with Swagger UI, which the browser loads from a CDN.
+//
+//go:embed docs_ui.html
+var docsPage []byte
+
+// DocsController publishes the OpenAPI document describing the API.
+type DocsController struct {
+	spec *openapi.Document
+}
+
+// NewDocsController creates a new Handler serving spec. The document is read on every request, so it may be
+// completed after the controller is created.
+func NewDocsController(spec *openapi.Document) *DocsController {
+	return &DocsController{spec: spec}
+}
+
+// OpenAPI returns the OpenAPI document.
+func (dc *DocsController) OpenAPI(c *gin.Context) {
+	c.JSON(http.StatusOK, dc.spec)
+}
+
+// UI returns an HTML page for browsing and trying out the API.
+func (dc *DocsController) UI(c *gin.Context) {
+	c.Data(http.StatusOK, "text/html; charset=utf-8", docsPage)
+}
@@ -0,0 +1,40 @@
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"task_manager_test/internal/delivery/openapi"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestDocsController tests that the document is served as JSON and the UI page points at it.
+func TestDocsController(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	spec := openapi.New(openapi.Info{Title: "Task Manager API", Version: "1.0.0"})
+	dc := NewDocsController(spec)
+	r := gin.New()
+	r.GET("/openapi.json", dc.OpenAPI)
+	r.GET("/docs", dc.UI)
+	spec.Add(http.MethodGet, "/tasks/:id", &openapi.Operation{Summary: "Get a task"})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var got openapi.Document
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &got))
+	assert.Equal(t, "3.1.0", got.OpenAPI)
+	assert.Equal(t, "Get a task", got.Operation(http.MethodGet, "/tasks/{id}").Summary, "Operations added later are served")
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/docs", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Header().Get("Content-Type"), "text/html")
+	assert.Contains(t, w.Body.String(), `url: "/openapi.json"`)
+}
@@ -0,0 +1,47 @@
+package controller
+
+import (
+	"errors"
+	"task_manager_test/internal/usecase"
+
+	"github.com/gin-gonic/gin"
+)
+
+// fail records err for middleware.ErrorHandler, which renders it as an RFC 7807 problem. Handlers return right
+// after calling it.
+func fail(c *gin.Context, err error) {
+	_ = c.Error(err)
+}
+
+// bindJSON decodes and validates the request body into obj. Failures are recorded as bind errors, which
+// middleware.ErrorHandler reports field by field, and bindJSON returns false.
+func bindJSON(c *gin.Context, obj any) bool {
+	if err := c.ShouldBindJSON(obj); err != nil {
+		_ = c.Error(err).SetType(gin.ErrorTypeBind)
+		return false
+	}
+	return true
+}
+
+// notFound names the missing resource in a generic usecase.ErrNotFound, e.g. "task not found", so clients can tell
+// which part of the request did not resolve.
+func notFound(err error, resource string) error {
+	if errors.Is(err, usecase.ErrNotFound) {
+		return usecase.WithDetail(err, resource+" not found")
+	}
+	return err
+}
+
+// invalidRequest reports a malformed request that is not a JSON body, such as a bad query parameter.
+func invalidRequest(detail string) error {
+	return usecase.WithDetail(usecase.ErrInvalidRequest, detail)
+}
+
+// signedInUserGone reports the caller's own account not being found, which happens when it was deleted after the
+// token was issued, as an invalid token rather than a missing resource.
+func signedInUserGone(err error) error {
+	if errors.Is(err, usecase.ErrNotFound) {
+		return usecase.WithDetail(usecase.ErrUnauthenticated, "invalid or expired token")
+	}
+	return err
+}
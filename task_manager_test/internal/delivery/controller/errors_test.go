@@ -0,0 +1,24 @@
+package controller
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"task_manager_test/internal/delivery/middleware"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// assertProblem checks that w holds a problem with the given status, code and detail, as rendered by
+// middleware.ErrorHandler from the error a handler recorded.
+func assertProblem(t *testing.T, w *httptest.ResponseRecorder, status int, code, detail string) middleware.Problem {
+	t.Helper()
+	assert.Equal(t, status, w.Code)
+	assert.Equal(t, middleware.ProblemContentType, w.Header().Get("Content-Type"))
+	var p middleware.Problem
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &p), "body should be a problem: %s", w.Body.String())
+	assert.Equal(t, code, p.Code)
+	assert.Equal(t, detail, p.Detail)
+	return p
+}
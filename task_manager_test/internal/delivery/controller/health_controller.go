@@ -0,0 +1,89 @@
+package controller
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// readinessTimeout bounds how long a single readiness probe may wait on its dependencies.
+const readinessTimeout = 2 * time.Second
+
+// HealthCheck is a named probe for a single external dependency, such as the database.
+type HealthCheck struct {
+	Name  string
+	Check func(ctx context.Context) error
+}
+
+// HealthController serves the liveness and readiness probes.
+type HealthController struct {
+	checks       []HealthCheck
+	shuttingDown atomic.Bool
+}
+
+// NewHealthController creates a new Handler that reports readiness based on the given dependency checks.
+func NewHealthController(checks ...HealthCheck) *HealthController {
+	return &HealthController{checks: checks}
+}
+
+// DependencyStatus defines the JSON structure reported for each dependency by the readiness probe. Failures are only
+// logged, since the probe is unauthenticated and errors can reveal internal addresses.
+type DependencyStatus struct {
+	Status string `json:"status"`
+}
+
+// MarkShuttingDown makes the readiness probe fail so that no new traffic is routed to this instance.
+func (hc *HealthController) MarkShuttingDown() {
+	hc.shuttingDown.Store(true)
+}
+
+// Liveness reports that the process is up and able to serve HTTP requests.
+func (hc *HealthController) Liveness(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
+
+// Readiness runs every dependency check concurrently and reports each one's status.
+func (hc *HealthController) Readiness(c *gin.Context) {
+	if hc.shuttingDown.Load() {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"status": "shutting_down"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), readinessTimeout)
+	defer cancel()
+
+	results := make(map[string]DependencyStatus, len(hc.checks))
+	var (
+		mu sync.Mutex
+		wg sync.WaitGroup
+	)
+	for _, hcheck := range hc.checks {
+		wg.Add(1)
+		go func(check HealthCheck) {
+			defer wg.Done()
+			status := DependencyStatus{Status: "up"}
+			if err := check.Check(ctx); err != nil {
+				log.Printf("Readiness check %q failed: %v", check.Name, err)
+				status = DependencyStatus{Status: "down"}
+			}
+			mu.Lock()
+			results[check.Name] = status
+			mu.Unlock()
+		}(hcheck)
+	}
+	wg.Wait()
+
+	code, overall := http.StatusOK, "ok"
+	for _, r := range results {
+		if r.Status != "up" {
+			code, overall = http.StatusServiceUnavailable, "unavailable"
+			break
+		}
+	}
+	c.JSON(code, gin.H{"status": overall, "checks": results})
+}
@@ -0,0 +1,89 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+)
+
+// HealthControllerTestSuite defines the test suite for the HealthController.
+type HealthControllerTestSuite struct {
+	suite.Suite
+}
+
+// TestHealthController runs the entire test suite.
+func TestHealthController(t *testing.T) {
+	suite.Run(t, new(HealthControllerTestSuite))
+}
+
+// serve builds a router around hc and performs a GET request against path.
+func (s *HealthControllerTestSuite) serve(hc *HealthController, path string) *httptest.ResponseRecorder {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/healthz", hc.Liveness)
+	r.GET("/readyz", hc.Readiness)
+	req, _ := http.NewRequest(http.MethodGet, path, nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+// TestLiveness_AlwaysOK verifies that liveness does not depend on any external dependency.
+func (s *HealthControllerTestSuite) TestLiveness_AlwaysOK() {
+	hc := NewHealthController(HealthCheck{Name: "mongodb", Check: func(ctx context.Context) error {
+		return errors.New("connection refused")
+	}})
+
+	w := s.serve(hc, "/healthz")
+
+	s.Equal(http.StatusOK, w.Code)
+	s.JSONEq(`{"status": "ok"}`, w.Body.String())
+}
+
+// TestReadiness_AllDependenciesUp verifies that a healthy dependency is reported as up.
+func (s *HealthControllerTestSuite) TestReadiness_AllDependenciesUp() {
+	hc := NewHealthController(HealthCheck{Name: "mongodb", Check: func(ctx context.Context) error { return nil }})
+
+	w := s.serve(hc, "/readyz")
+
+	s.Equal(http.StatusOK, w.Code)
+	s.JSONEq(`{"status": "ok", "checks": {"mongodb": {"status": "up"}}}`, w.Body.String())
+}
+
+// TestReadiness_DependencyDown verifies that a failing dependency makes the instance unready and is reported individually.
+func (s *HealthControllerTestSuite) TestReadiness_DependencyDown() {
+	hc := NewHealthController(
+		HealthCheck{Name: "mongodb", Check: func(ctx context.Context) error { return errors.New("server selection timeout") }},
+		HealthCheck{Name: "cache", Check: func(ctx context.Context) error { return nil }},
+	)
+
+	w := s.serve(hc, "/readyz")
+
+	s.Equal(http.StatusServiceUnavailable, w.Code)
+	s.JSONEq(`{
+		"status": "unavailable",
+		"checks": {
+			"mongodb": {"status": "down"},
+			"cache": {"status": "up"}
+		}
+	}`, w.Body.String())
+}
+
+// TestReadiness_ShuttingDown verifies that readiness fails once shutdown has begun, without probing dependencies.
+func (s *HealthControllerTestSuite) TestReadiness_ShuttingDown() {
+	hc := NewHealthController(HealthCheck{Name: "mongodb", Check: func(ctx context.Context) error {
+		s.Fail("Dependency check should not run while shutting down")
+		return nil
+	}})
+	hc.MarkShuttingDown()
+
+	w := s.serve(hc, "/readyz")
+
+	s.Equal(http.StatusServiceUnavailable, w.Code)
+	s.JSONEq(`{"status": "shutting_down"}`, w.Body.String())
+}
@@ -0,0 +1,61 @@
+package controller
+
+import (
+	"net/http"
+	"task_manager_test/internal/domain"
+	"task_manager_test/internal/usecase"
+
+	"github.com/gin-gonic/gin"
+)
+
+// jwksCacheControl lets verifiers cache the key set briefly while still picking up rotated keys quickly.
+const jwksCacheControl = "public, max-age=300"
+
+// JWKSController publishes the public keys that verify issued tokens.
+type JWKSController struct {
+	jwtService usecase.IJWTService
+}
+
+// NewJWKSController creates a new Handler backed by the given JWT service.
+func NewJWKSController(j usecase.IJWTService) *JWKSController {
+	return &JWKSController{jwtService: j}
+}
+
+// JSONWebKey defines the JSON structure of a single key in the published key set.
+type JSONWebKey struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	N         string `json:"n,omitempty"`
+	E         string `json:"e,omitempty"`
+	Curve     string `json:"crv,omitempty"`
+	X         string `json:"x,omitempty"`
+	Y         string `json:"y,omitempty"`
+}
+
+// toJSONWebKey maps a domain key to its response representation.
+func toJSONWebKey(k domain.JSONWebKey) JSONWebKey {
+	return JSONWebKey{
+		KeyType:   k.KeyType,
+		KeyID:     k.KeyID,
+		Use:       k.Use,
+		Algorithm: k.Algorithm,
+		N:         k.N,
+		E:         k.E,
+		Curve:     k.Curve,
+		X:         k.X,
+		Y:         k.Y,
+	}
+}
+
+// JWKS serves the key set as described in RFC 7517. In HMAC mode the set is empty.
+func (jc *JWKSController) JWKS(c *gin.Context) {
+	keys := jc.jwtService.PublicKeys()
+	out := make([]JSONWebKey, 0, len(keys))
+	for _, k := range keys {
+		out = append(out, toJSONWebKey(k))
+	}
+	c.Header("Cache-Control", jwksCacheControl)
+	c.JSON(http.StatusOK, gin.H{"keys": out})
+}
@@ -0,0 +1,70 @@
+package controller
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"task_manager_test/internal/domain"
+	"task_manager_test/internal/mocks"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+)
+
+// JWKSControllerTestSuite defines the test suite for the JWKSController.
+type JWKSControllerTestSuite struct {
+	suite.Suite
+	router     *gin.Engine
+	mockJwtSvc *mocks.IJWTService
+}
+
+// SetupTest runs before each test in the suite, ensuring a clean state.
+func (s *JWKSControllerTestSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+	s.mockJwtSvc = new(mocks.IJWTService)
+	s.router = gin.New()
+	s.router.GET("/.well-known/jwks.json", NewJWKSController(s.mockJwtSvc).JWKS)
+}
+
+// TestJWKSController runs the entire test suite.
+func TestJWKSController(t *testing.T) {
+	suite.Run(t, new(JWKSControllerTestSuite))
+}
+
+// get requests the key set.
+func (s *JWKSControllerTestSuite) get() *httptest.ResponseRecorder {
+	req, _ := http.NewRequest(http.MethodGet, "/.well-known/jwks.json", nil)
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	return w
+}
+
+// TestJWKS_PublishesKeys tests that keys are rendered with RFC 7517 member names and only the members they use.
+func (s *JWKSControllerTestSuite) TestJWKS_PublishesKeys() {
+	// Arrange
+	s.mockJwtSvc.On("PublicKeys").Return([]domain.JSONWebKey{
+		{KeyType: "OKP", KeyID: "new", Use: "sig", Algorithm: "EdDSA", Curve: "Ed25519", X: "eA"},
+		{KeyType: "RSA", KeyID: "old", Use: "sig", Algorithm: "RS256", N: "bg", E: "AQAB"},
+	}).Once()
+
+	// Act
+	w := s.get()
+
+	// Assert
+	s.Equal(http.StatusOK, w.Code)
+	s.Equal("public, max-age=300", w.Header().Get("Cache-Control"))
+	s.JSONEq(`{"keys": [
+		{"kty": "OKP", "kid": "new", "use": "sig", "alg": "EdDSA", "crv": "Ed25519", "x": "eA"},
+		{"kty": "RSA", "kid": "old", "use": "sig", "alg": "RS256", "n": "bg", "e": "AQAB"}
+	]}`, w.Body.String())
+}
+
+// TestJWKS_EmptyInHMACMode tests that an empty key set is still a valid JWKS document.
+func (s *JWKSControllerTestSuite) TestJWKS_EmptyInHMACMode() {
+	s.mockJwtSvc.On("PublicKeys").Return([]domain.JSONWebKey{}).Once()
+
+	w := s.get()
+
+	s.Equal(http.StatusOK, w.Code)
+	s.JSONEq(`{"keys": []}`, w.Body.String())
+}
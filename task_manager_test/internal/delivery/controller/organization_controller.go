@@ -0,0 +1,96 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"task_manager_test/internal/domain"
+	"task_manager_test/internal/usecase"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// OrganizationController wraps use case interfaces for organizations.
+type OrganizationController struct {
+	orgUC usecase.OrganizationUsecase
+}
+
+// NewOrganizationController creates a new Handler given the Organization use cases.
+func NewOrganizationController(o usecase.OrganizationUsecase) *OrganizationController {
+	return &OrganizationController{orgUC: o}
+}
+
+// OrganizationResponse defines the JSON structure for organization data returned in API responses.
+type OrganizationResponse struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	Slug      string    `json:"slug"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// mapToOrganizationResponse converts a domain.Organization into an OrganizationResponse for API output.
+func mapToOrganizationResponse(o domain.Organization) OrganizationResponse {
+	return OrganizationResponse{ID: o.ID, Name: o.Name, Slug: o.Slug, CreatedAt: o.CreatedAt}
+}
+
+// OrganizationAdminRequest names the first administrator of a new organization.
+type OrganizationAdminRequest struct {
+	Username string `json:"username" binding:"required"`
+	Password string `json:"password" binding:"required"`
+}
+
+// OrganizationRequest is the body accepted when creating an organization together with its first administrator.
+type OrganizationRequest struct {
+	Name  string                   `json:"name" binding:"required"`
+	Slug  string                   `json:"slug" binding:"required"`
+	Admin OrganizationAdminRequest `json:"admin" binding:"required"`
+}
+
+// superAdminOnly explains a forbidden organization request.
+func superAdminOnly(err error) error {
+	if errors.Is(err, usecase.ErrForbidden) {
+		return usecase.WithDetail(err, "managing organizations requires the super_admin role")
+	}
+	return err
+}
+
+// CreateOrganization creates an organization and its administrator.
+func (oc *OrganizationController) CreateOrganization(c *gin.Context) {
+	var body OrganizationRequest
+	if !bindJSON(c, &body) {
+		return
+	}
+	org, err := oc.orgUC.Create(c.Request.Context(),
+		domain.Organization{Name: body.Name, Slug: body.Slug},
+		domain.User{Username: body.Admin.Username, Password: body.Admin.Password},
+	)
+	if err != nil {
+		fail(c, superAdminOnly(err))
+		return
+	}
+	c.JSON(http.StatusCreated, mapToOrganizationResponse(org))
+}
+
+// ListOrganizations returns every organization.
+func (oc *OrganizationController) ListOrganizations(c *gin.Context) {
+	orgs, err := oc.orgUC.List(c.Request.Context())
+	if err != nil {
+		fail(c, superAdminOnly(err))
+		return
+	}
+	responses := make([]OrganizationResponse, len(orgs))
+	for i, o := range orgs {
+		responses[i] = mapToOrganizationResponse(o)
+	}
+	c.JSON(http.StatusOK, responses)
+}
+
+// CurrentOrganization returns the caller's organization.
+func (oc *OrganizationController) CurrentOrganization(c *gin.Context) {
+	org, err := oc.orgUC.Current(c.Request.Context())
+	if err != nil {
+		fail(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, mapToOrganizationResponse(org))
+}
@@ -0,0 +1,126 @@
+package controller
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"task_manager_test/internal/delivery/middleware"
+	"task_manager_test/internal/domain"
+	"task_manager_test/internal/mocks"
+	"task_manager_test/internal/usecase"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+// OrganizationControllerTestSuite defines the test suite for the OrganizationController.
+type OrganizationControllerTestSuite struct {
+	suite.Suite
+	router      *gin.Engine
+	mockUsecase *mocks.OrganizationUsecase
+}
+
+// SetupTest runs before each test in the suite, ensuring a clean state.
+func (s *OrganizationControllerTestSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+	s.mockUsecase = mocks.NewOrganizationUsecase(s.T())
+	ctrl := NewOrganizationController(s.mockUsecase)
+
+	s.router = gin.New()
+	s.router.Use(middleware.ErrorHandler())
+	s.router.GET("/org", ctrl.CurrentOrganization)
+	s.router.GET("/orgs", ctrl.ListOrganizations)
+	s.router.POST("/orgs", ctrl.CreateOrganization)
+}
+
+// TestOrganizationController runs the entire test suite.
+func TestOrganizationController(t *testing.T) {
+	suite.Run(t, new(OrganizationControllerTestSuite))
+}
+
+// serve sends a request with an optional JSON body to the router.
+func (s *OrganizationControllerTestSuite) serve(method, path string, body any) *httptest.ResponseRecorder {
+	var buf bytes.Buffer
+	if body != nil {
+		_ = json.NewEncoder(&buf).Encode(body)
+	}
+	req, _ := http.NewRequest(method, path, &buf)
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	return w
+}
+
+// TestCreateOrganization_Success tests that the organization and its administrator are passed to the use case.
+func (s *OrganizationControllerTestSuite) TestCreateOrganization_Success() {
+	created := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	s.mockUsecase.On("Create", mock.Anything,
+		domain.Organization{Name: "Acme", Slug: "acme"},
+		domain.User{Username: "wile", Password: "a-long-passphrase"},
+	).Return(domain.Organization{ID: "org-2", Name: "Acme", Slug: "acme", CreatedAt: created}, nil)
+
+	w := s.serve(http.MethodPost, "/orgs", gin.H{
+		"name": "Acme", "slug": "acme", "admin": gin.H{"username": "wile", "password": "a-long-passphrase"},
+	})
+
+	s.Equal(http.StatusCreated, w.Code)
+	s.JSONEq(`{"id":"org-2","name":"Acme","slug":"acme","created_at":"2025-01-01T12:00:00Z"}`, w.Body.String())
+}
+
+// TestCreateOrganization_ErrorMapping tests how usecase errors are translated into HTTP responses.
+func (s *OrganizationControllerTestSuite) TestCreateOrganization_ErrorMapping() {
+	cases := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantCode   string
+		wantDetail string
+	}{
+		{"not a super admin", usecase.ErrForbidden, http.StatusForbidden, "forbidden", "managing organizations requires the super_admin role"},
+		{"slug taken", usecase.ErrOrganizationExists, http.StatusConflict, "organization_exists", ""},
+		{"bad slug", &usecase.OrganizationRequestError{Reason: "slug must be 1-63 lowercase letters, digits or inner hyphens"},
+			http.StatusBadRequest, "invalid_organization_request", "slug must be 1-63 lowercase letters, digits or inner hyphens"},
+		{"weak admin password", usecase.ErrWeakPassword, http.StatusBadRequest, "weak_password", ""},
+	}
+	for _, tc := range cases {
+		s.Run(tc.name, func() {
+			s.SetupTest()
+			s.mockUsecase.On("Create", mock.Anything, mock.Anything, mock.Anything).Return(domain.Organization{}, tc.err).Once()
+
+			w := s.serve(http.MethodPost, "/orgs", gin.H{"name": "Acme", "slug": "acme", "admin": gin.H{"username": "wile", "password": "pw"}})
+
+			assertProblem(s.T(), w, tc.wantStatus, tc.wantCode, tc.wantDetail)
+		})
+	}
+}
+
+// TestCreateOrganization_BadRequest tests that an organization without an administrator is rejected.
+func (s *OrganizationControllerTestSuite) TestCreateOrganization_BadRequest() {
+	w := s.serve(http.MethodPost, "/orgs", gin.H{"name": "Acme", "slug": "acme"})
+
+	s.Equal(http.StatusBadRequest, w.Code)
+}
+
+// TestListOrganizations tests listing every organization.
+func (s *OrganizationControllerTestSuite) TestListOrganizations() {
+	s.mockUsecase.On("List", mock.Anything).Return([]domain.Organization{{ID: "org-1", Name: "Default", Slug: "default"}}, nil)
+
+	w := s.serve(http.MethodGet, "/orgs", nil)
+
+	s.Equal(http.StatusOK, w.Code)
+	s.JSONEq(`[{"id":"org-1","name":"Default","slug":"default","created_at":"0001-01-01T00:00:00Z"}]`, w.Body.String())
+}
+
+// TestCurrentOrganization tests returning the caller's organization.
+func (s *OrganizationControllerTestSuite) TestCurrentOrganization() {
+	s.mockUsecase.On("Current", mock.Anything).Return(domain.Organization{ID: "org-1", Name: "Default", Slug: "default"}, nil)
+
+	w := s.serve(http.MethodGet, "/org", nil)
+
+	s.Equal(http.StatusOK, w.Code)
+	s.Contains(w.Body.String(), `"slug":"default"`)
+}
@@ -0,0 +1,76 @@
+package controller
+
+import (
+	"net/http"
+	"task_manager_test/internal/usecase"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PasswordController wraps use case interfaces for password change and reset operations.
+type PasswordController struct {
+	passwordUC usecase.PasswordUsecase
+}
+
+// NewPasswordController creates a new Handler given Password use cases.
+func NewPasswordController(p usecase.PasswordUsecase) *PasswordController {
+	return &PasswordController{passwordUC: p}
+}
+
+// ChangePasswordRequest is the body accepted when changing the signed-in user's password.
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password" binding:"required"`
+	NewPassword     string `json:"new_password" binding:"required"`
+}
+
+// ForgotPasswordRequest is the body accepted when requesting a password reset.
+type ForgotPasswordRequest struct {
+	Username string `json:"username" binding:"required"`
+}
+
+// ResetPasswordRequest is the body accepted when resetting a password with a reset token.
+type ResetPasswordRequest struct {
+	Token       string `json:"token" binding:"required"`
+	NewPassword string `json:"new_password" binding:"required"`
+}
+
+// ChangePassword lets an authenticated user replace their password. Every other session is revoked,
+// and a fresh token is returned for the current client.
+func (pc *PasswordController) ChangePassword(c *gin.Context) {
+	var body ChangePasswordRequest
+	if !bindJSON(c, &body) {
+		return
+	}
+	token, err := pc.passwordUC.ChangePassword(c.Request.Context(), c.GetString("username"), body.CurrentPassword, body.NewPassword)
+	if err != nil {
+		fail(c, signedInUserGone(err))
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"token": token})
+}
+
+// ForgotPassword starts the reset flow. It always answers 202 so the response does not reveal whether the account exists.
+func (pc *PasswordController) ForgotPassword(c *gin.Context) {
+	var body ForgotPasswordRequest
+	if !bindJSON(c, &body) {
+		return
+	}
+	if err := pc.passwordUC.RequestReset(c.Request.Context(), body.Username); err != nil {
+		fail(c, err)
+		return
+	}
+	c.JSON(http.StatusAccepted, gin.H{"message": "If the account exists, password reset instructions have been sent"})
+}
+
+// ResetPassword completes the reset flow with a token from ForgotPassword.
+func (pc *PasswordController) ResetPassword(c *gin.Context) {
+	var body ResetPasswordRequest
+	if !bindJSON(c, &body) {
+		return
+	}
+	if err := pc.passwordUC.ResetPassword(c.Request.Context(), body.Token, body.NewPassword); err != nil {
+		fail(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Password has been reset"})
+}
@@ -0,0 +1,147 @@
+package controller
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"task_manager_test/internal/delivery/middleware"
+	"task_manager_test/internal/mocks"
+	"task_manager_test/internal/usecase"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+// PasswordControllerTestSuite defines the test suite for the PasswordController.
+type PasswordControllerTestSuite struct {
+	suite.Suite
+	router      *gin.Engine
+	mockUsecase *mocks.PasswordUsecase
+}
+
+// SetupTest runs before each test in the suite, ensuring a clean state.
+func (s *PasswordControllerTestSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+	s.mockUsecase = new(mocks.PasswordUsecase)
+	pc := NewPasswordController(s.mockUsecase)
+
+	s.router = gin.New()
+	s.router.Use(middleware.ErrorHandler())
+	s.router.POST("/password/forgot", pc.ForgotPassword)
+	s.router.POST("/password/reset", pc.ResetPassword)
+	s.router.PUT("/me/password", func(c *gin.Context) {
+		c.Set("username", "testuser")
+		c.Next()
+	}, pc.ChangePassword)
+}
+
+// TestPasswordController runs the entire test suite.
+func TestPasswordController(t *testing.T) {
+	suite.Run(t, new(PasswordControllerTestSuite))
+}
+
+// send performs a JSON request against the suite router.
+func (s *PasswordControllerTestSuite) send(method, path string, body gin.H) *httptest.ResponseRecorder {
+	data, _ := json.Marshal(body)
+	req, _ := http.NewRequest(method, path, bytes.NewBuffer(data))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	return w
+}
+
+//--- ChangePassword Endpoint Tests ---//
+
+// TestChangePassword_Success tests that a fresh token is returned after a password change.
+func (s *PasswordControllerTestSuite) TestChangePassword_Success() {
+	// Arrange
+	s.mockUsecase.On("ChangePassword", mock.Anything, "testuser", "old-password", "new-password").Return("fresh-token", nil).Once()
+
+	// Act
+	w := s.send(http.MethodPut, "/me/password", gin.H{"current_password": "old-password", "new_password": "new-password"})
+
+	// Assert
+	s.Equal(http.StatusOK, w.Code)
+	s.JSONEq(`{"token": "fresh-token"}`, w.Body.String())
+	s.mockUsecase.AssertExpectations(s.T())
+}
+
+// TestChangePassword_ErrorMapping tests how usecase errors are translated into HTTP responses.
+func (s *PasswordControllerTestSuite) TestChangePassword_ErrorMapping() {
+	cases := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantCode   string
+		wantDetail string
+	}{
+		{"wrong current password", usecase.ErrIncorrectPassword, http.StatusForbidden, "incorrect_password", ""},
+		{"weak new password", &usecase.PasswordPolicyError{Reason: "must be at least 8 characters"}, http.StatusBadRequest, "weak_password", "password must be at least 8 characters"},
+		{"user deleted", usecase.ErrNotFound, http.StatusUnauthorized, "unauthenticated", "invalid or expired token"},
+	}
+	for _, tc := range cases {
+		s.Run(tc.name, func() {
+			s.SetupTest()
+			s.mockUsecase.On("ChangePassword", mock.Anything, "testuser", "old", "new").Return("", tc.err).Once()
+
+			w := s.send(http.MethodPut, "/me/password", gin.H{"current_password": "old", "new_password": "new"})
+
+			assertProblem(s.T(), w, tc.wantStatus, tc.wantCode, tc.wantDetail)
+		})
+	}
+}
+
+// TestChangePassword_TooManyRequests_AccountLocked tests that a locked account is reported with 429 and a
+// Retry-After header, as the caller has already proven who they are.
+func (s *PasswordControllerTestSuite) TestChangePassword_TooManyRequests_AccountLocked() {
+	locked := &usecase.AccountLockedError{Until: time.Now().Add(90 * time.Second)}
+	s.mockUsecase.On("ChangePassword", mock.Anything, "testuser", "old", "new").Return("", locked).Once()
+
+	w := s.send(http.MethodPut, "/me/password", gin.H{"current_password": "old", "new_password": "new"})
+
+	assertProblem(s.T(), w, http.StatusTooManyRequests, "account_locked", "account temporarily locked due to too many failed password attempts")
+	retryAfter, err := strconv.Atoi(w.Header().Get("Retry-After"))
+	s.NoError(err, "Retry-After should be a number of seconds")
+	s.InDelta(90, retryAfter, 1)
+}
+
+//--- ForgotPassword Endpoint Tests ---//
+
+// TestForgotPassword_AlwaysAccepted tests that the response does not reveal whether the account exists.
+func (s *PasswordControllerTestSuite) TestForgotPassword_AlwaysAccepted() {
+	// Arrange
+	s.mockUsecase.On("RequestReset", mock.Anything, "ghost").Return(nil).Once()
+
+	// Act
+	w := s.send(http.MethodPost, "/password/forgot", gin.H{"username": "ghost"})
+
+	// Assert
+	s.Equal(http.StatusAccepted, w.Code)
+	s.JSONEq(`{"message": "If the account exists, password reset instructions have been sent"}`, w.Body.String())
+}
+
+//--- ResetPassword Endpoint Tests ---//
+
+// TestResetPassword_Success tests a successful reset.
+func (s *PasswordControllerTestSuite) TestResetPassword_Success() {
+	s.mockUsecase.On("ResetPassword", mock.Anything, "reset-token", "new-password").Return(nil).Once()
+
+	w := s.send(http.MethodPost, "/password/reset", gin.H{"token": "reset-token", "new_password": "new-password"})
+
+	s.Equal(http.StatusOK, w.Code)
+	s.JSONEq(`{"message": "Password has been reset"}`, w.Body.String())
+}
+
+// TestResetPassword_InvalidToken tests that expired or reused tokens are rejected.
+func (s *PasswordControllerTestSuite) TestResetPassword_InvalidToken() {
+	s.mockUsecase.On("ResetPassword", mock.Anything, "used-token", "new-password").Return(usecase.ErrInvalidResetToken).Once()
+
+	w := s.send(http.MethodPost, "/password/reset", gin.H{"token": "used-token", "new_password": "new-password"})
+
+	assertProblem(s.T(), w, http.StatusBadRequest, "invalid_reset_token", "")
+}
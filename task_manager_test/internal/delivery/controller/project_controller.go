@@ -0,0 +1,196 @@
+package controller
+
+import (
+	"net/http"
+	"task_manager_test/internal/domain"
+	"task_manager_test/internal/usecase"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ProjectController wraps use case interfaces for projects, their members and their tasks.
+type ProjectController struct {
+	projectUC usecase.ProjectUsecase
+	taskUC    usecase.TaskUsecase
+}
+
+// NewProjectController creates a new Handler given Project and Task use cases.
+func NewProjectController(p usecase.ProjectUsecase, t usecase.TaskUsecase) *ProjectController {
+	return &ProjectController{projectUC: p, taskUC: t}
+}
+
+// ProjectMemberResponse defines the JSON structure for a project member returned in API responses.
+type ProjectMemberResponse struct {
+	Username string `json:"username"`
+	Role     string `json:"role"`
+}
+
+// ProjectResponse defines the JSON structure for project data returned in API responses.
+type ProjectResponse struct {
+	ID          string                  `json:"id"`
+	Name        string                  `json:"name"`
+	Description string                  `json:"description"`
+	Members     []ProjectMemberResponse `json:"members"`
+	CreatedBy   string                  `json:"created_by"`
+	CreatedAt   time.Time               `json:"created_at"`
+}
+
+// mapToProjectResponse converts a domain.Project into a ProjectResponse for API output.
+func mapToProjectResponse(p domain.Project) ProjectResponse {
+	members := make([]ProjectMemberResponse, len(p.Members))
+	for i, m := range p.Members {
+		members[i] = ProjectMemberResponse{Username: m.Username, Role: string(m.Role)}
+	}
+	return ProjectResponse{
+		ID:          p.ID,
+		Name:        p.Name,
+		Description: p.Description,
+		Members:     members,
+		CreatedBy:   p.CreatedBy,
+		CreatedAt:   p.CreatedAt,
+	}
+}
+
+// respondProjectError records errors shared by every project endpoint. Projects the caller is not a member of are
+// reported as not found, while projects the caller may see but not change are forbidden. The only other lookup
+// that can fail is that of a member.
+func respondProjectError(c *gin.Context, err error) {
+	fail(c, notFound(err, "member"))
+}
+
+// ProjectRequest is the body accepted when creating or updating a project.
+type ProjectRequest struct {
+	Name        string `json:"name" binding:"required"`
+	Description string `json:"description"`
+}
+
+// ProjectMemberRequest is the body accepted when adding a member or changing their role.
+type ProjectMemberRequest struct {
+	Role string `json:"role" binding:"required"`
+}
+
+// CreateProject creates a project with the caller as its owner.
+func (pc *ProjectController) CreateProject(c *gin.Context) {
+	var body ProjectRequest
+	if !bindJSON(c, &body) {
+		return
+	}
+	created, err := pc.projectUC.Create(c.Request.Context(), domain.Project{Name: body.Name, Description: body.Description})
+	if err != nil {
+		respondProjectError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, mapToProjectResponse(created))
+}
+
+// ListProjects returns the projects the caller is a member of.
+func (pc *ProjectController) ListProjects(c *gin.Context) {
+	projects, err := pc.projectUC.List(c.Request.Context())
+	if err != nil {
+		respondProjectError(c, err)
+		return
+	}
+	responses := make([]ProjectResponse, len(projects))
+	for i, p := range projects {
+		responses[i] = mapToProjectResponse(p)
+	}
+	c.JSON(http.StatusOK, responses)
+}
+
+// GetProject retrieves a single project by ID.
+func (pc *ProjectController) GetProject(c *gin.Context) {
+	p, err := pc.projectUC.Get(c.Request.Context(), c.Param("pid"))
+	if err != nil {
+		respondProjectError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, mapToProjectResponse(p))
+}
+
+// UpdateProject changes the name and description of a project.
+func (pc *ProjectController) UpdateProject(c *gin.Context) {
+	var body ProjectRequest
+	if !bindJSON(c, &body) {
+		return
+	}
+	updated, err := pc.projectUC.Update(c.Request.Context(), domain.Project{
+		ID:          c.Param("pid"),
+		Name:        body.Name,
+		Description: body.Description,
+	})
+	if err != nil {
+		respondProjectError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, mapToProjectResponse(updated))
+}
+
+// DeleteProject deletes a project. The tasks query parameter must say whether the project's tasks are deleted
+// with it or kept as archived personal tasks.
+func (pc *ProjectController) DeleteProject(c *gin.Context) {
+	tasks := domain.TaskDisposition(c.Query("tasks"))
+	if err := pc.projectUC.Delete(c.Request.Context(), c.Param("pid"), tasks); err != nil {
+		respondProjectError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// SetMember adds a user to the project or changes the role of an existing member.
+func (pc *ProjectController) SetMember(c *gin.Context) {
+	var body ProjectMemberRequest
+	if !bindJSON(c, &body) {
+		return
+	}
+	p, err := pc.projectUC.SetMember(c.Request.Context(), c.Param("pid"), c.Param("username"), domain.ProjectRole(body.Role))
+	if err != nil {
+		respondProjectError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, mapToProjectResponse(p))
+}
+
+// RemoveMember removes a user from the project.
+func (pc *ProjectController) RemoveMember(c *gin.Context) {
+	if err := pc.projectUC.RemoveMember(c.Request.Context(), c.Param("pid"), c.Param("username")); err != nil {
+		respondProjectError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// ListProjectTasks returns the tasks of a project.
+func (pc *ProjectController) ListProjectTasks(c *gin.Context) {
+	tasks, err := pc.taskUC.ListByProject(c.Request.Context(), c.Param("pid"))
+	if err != nil {
+		respondProjectError(c, err)
+		return
+	}
+	responses := make([]TaskResponse, len(tasks))
+	for i, t := range tasks {
+		responses[i] = mapToTaskResponse(t)
+	}
+	c.JSON(http.StatusOK, responses)
+}
+
+// CreateProjectTask creates a task in a project. The caller must be an editor or owner of the project.
+func (pc *ProjectController) CreateProjectTask(c *gin.Context) {
+	var body TaskRequest
+	if !bindJSON(c, &body) {
+		return
+	}
+	task := domain.Task{
+		Title:       body.Title,
+		Description: body.Description,
+		DueDate:     *body.DueDate,
+		Status:      body.Status,
+		ProjectID:   c.Param("pid"),
+	}
+	created, err := pc.taskUC.Create(c.Request.Context(), task)
+	if err != nil {
+		fail(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, mapToTaskResponse(created))
+}
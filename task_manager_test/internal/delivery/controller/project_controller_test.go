@@ -0,0 +1,155 @@
+package controller
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"task_manager_test/internal/delivery/middleware"
+	"task_manager_test/internal/domain"
+	"task_manager_test/internal/mocks"
+	"task_manager_test/internal/usecase"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+// ProjectControllerTestSuite defines the test suite for the ProjectController.
+type ProjectControllerTestSuite struct {
+	suite.Suite
+	router          *gin.Engine
+	mockProjectUC   *mocks.ProjectUsecase
+	mockTaskUsecase *mocks.TaskUsecase
+}
+
+// SetupTest runs before each test in the suite, ensuring a clean state.
+func (s *ProjectControllerTestSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+	s.mockProjectUC = new(mocks.ProjectUsecase)
+	s.mockTaskUsecase = new(mocks.TaskUsecase)
+	pc := NewProjectController(s.mockProjectUC, s.mockTaskUsecase)
+
+	s.router = gin.New()
+	s.router.Use(middleware.ErrorHandler())
+	s.router.POST("/projects", pc.CreateProject)
+	s.router.GET("/projects/:pid", pc.GetProject)
+	s.router.DELETE("/projects/:pid", pc.DeleteProject)
+	s.router.PUT("/projects/:pid/members/:username", pc.SetMember)
+	s.router.GET("/projects/:pid/tasks", pc.ListProjectTasks)
+	s.router.POST("/projects/:pid/tasks", pc.CreateProjectTask)
+}
+
+// TestProjectController runs the entire test suite.
+func TestProjectController(t *testing.T) {
+	suite.Run(t, new(ProjectControllerTestSuite))
+}
+
+// send performs a JSON request against the suite router.
+func (s *ProjectControllerTestSuite) send(method, path string, body gin.H) *httptest.ResponseRecorder {
+	var buf bytes.Buffer
+	if body != nil {
+		_ = json.NewEncoder(&buf).Encode(body)
+	}
+	req, _ := http.NewRequest(method, path, &buf)
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	return w
+}
+
+//--- CreateProject Endpoint Tests ---//
+
+// TestCreateProject_Success tests creating a project and rendering its members.
+func (s *ProjectControllerTestSuite) TestCreateProject_Success() {
+	// Arrange
+	createdAt := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	created := domain.Project{
+		ID: "proj-1", Name: "Launch", CreatedBy: "user-1", CreatedAt: createdAt,
+		Members: []domain.ProjectMember{{UserID: "user-1", Username: "alice", Role: domain.ProjectOwner}},
+	}
+	s.mockProjectUC.On("Create", mock.Anything, domain.Project{Name: "Launch"}).Return(created, nil).Once()
+
+	// Act
+	w := s.send(http.MethodPost, "/projects", gin.H{"name": "Launch"})
+
+	// Assert
+	s.Equal(http.StatusCreated, w.Code)
+	s.JSONEq(`{"id": "proj-1", "name": "Launch", "description": "", "created_by": "user-1", "created_at": "2025-01-01T12:00:00Z",
+		"members": [{"username": "alice", "role": "owner"}]}`, w.Body.String())
+	s.mockProjectUC.AssertExpectations(s.T())
+}
+
+// TestProjectErrorMapping tests how usecase errors are translated into HTTP responses.
+func (s *ProjectControllerTestSuite) TestProjectErrorMapping() {
+	cases := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantCode   string
+		wantDetail string
+	}{
+		{"not a member", usecase.ErrProjectNotFound, http.StatusNotFound, "project_not_found", ""},
+		{"not an owner", usecase.ErrForbidden, http.StatusForbidden, "forbidden", ""},
+		{"invalid request", &usecase.ProjectRequestError{Reason: "unknown user ghost"}, http.StatusBadRequest, "invalid_project_request", "unknown user ghost"},
+		{"repository failure", errors.New("db down"), http.StatusInternalServerError, "internal", ""},
+	}
+	for _, tc := range cases {
+		s.Run(tc.name, func() {
+			s.SetupTest()
+			s.mockProjectUC.On("SetMember", mock.Anything, "proj-1", "ghost", domain.ProjectViewer).Return(domain.Project{}, tc.err).Once()
+
+			w := s.send(http.MethodPut, "/projects/proj-1/members/ghost", gin.H{"role": "viewer"})
+
+			assertProblem(s.T(), w, tc.wantStatus, tc.wantCode, tc.wantDetail)
+		})
+	}
+}
+
+//--- DeleteProject Endpoint Tests ---//
+
+// TestDeleteProject_PassesTaskDisposition tests that the tasks query parameter reaches the usecase.
+func (s *ProjectControllerTestSuite) TestDeleteProject_PassesTaskDisposition() {
+	s.mockProjectUC.On("Delete", mock.Anything, "proj-1", domain.DisposeArchive).Return(nil).Once()
+	s.mockProjectUC.On("Delete", mock.Anything, "proj-1", domain.TaskDisposition("")).
+		Return(&usecase.ProjectRequestError{Reason: "tasks must be delete or archive"}).Once()
+
+	w := s.send(http.MethodDelete, "/projects/proj-1?tasks=archive", nil)
+	s.Equal(http.StatusNoContent, w.Code)
+
+	w = s.send(http.MethodDelete, "/projects/proj-1", nil)
+	assertProblem(s.T(), w, http.StatusBadRequest, "invalid_project_request", "tasks must be delete or archive")
+}
+
+//--- Project Task Endpoint Tests ---//
+
+// TestListProjectTasks_Success tests listing the tasks of a project.
+func (s *ProjectControllerTestSuite) TestListProjectTasks_Success() {
+	due := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+	tasks := []domain.Task{{ID: "t1", Title: "Plan", DueDate: due, Status: "Pending", OwnerID: "user-1", ProjectID: "proj-1"}}
+	s.mockTaskUsecase.On("ListByProject", mock.Anything, "proj-1").Return(tasks, nil).Once()
+
+	w := s.send(http.MethodGet, "/projects/proj-1/tasks", nil)
+
+	s.Equal(http.StatusOK, w.Code)
+	s.JSONEq(`[{"id": "t1", "title": "Plan", "description": "", "duedate": "2025-02-01T00:00:00Z", "status": "Pending",
+		"owner_id": "user-1", "project_id": "proj-1"}]`, w.Body.String())
+}
+
+// TestCreateProjectTask tests that tasks are created in the project from the URL and viewers are refused.
+func (s *ProjectControllerTestSuite) TestCreateProjectTask() {
+	due := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+	task := domain.Task{Title: "Plan", DueDate: due, Status: "Pending", ProjectID: "proj-1"}
+	s.mockTaskUsecase.On("Create", mock.Anything, task).Return(task, nil).Once()
+	s.mockTaskUsecase.On("Create", mock.Anything, task).Return(domain.Task{}, usecase.ErrForbidden).Once()
+	body := gin.H{"title": "Plan", "duedate": due, "status": "Pending"}
+
+	w := s.send(http.MethodPost, "/projects/proj-1/tasks", body)
+	s.Equal(http.StatusCreated, w.Code)
+
+	w = s.send(http.MethodPost, "/projects/proj-1/tasks", body)
+	assertProblem(s.T(), w, http.StatusForbidden, "forbidden", "")
+}
@@ -1,7 +1,6 @@
 package controller
 
 import (
-	"errors"
 	"net/http"
 	"task_manager_test/internal/domain"
 	"task_manager_test/internal/usecase"
@@ -20,6 +19,14 @@ func NewTaskController(t usecase.TaskUsecase) *TaskController {
 	return &TaskController{taskUC: t}
 }
 
+// TaskRequest is the body accepted when creating or replacing a task.
+type TaskRequest struct {
+	Title       string     `json:"title" binding:"required"`
+	Description string     `json:"description"`
+	DueDate     *time.Time `json:"duedate" binding:"required"`
+	Status      string     `json:"status" binding:"required"`
+}
+
 // TaskResponse defines the JSON structure for task data returned in API responses.
 type TaskResponse struct {
 	ID          string    `json:"id"`
@@ -27,49 +34,103 @@ type TaskResponse struct {
 	Description string    `json:"description"`
 	DueDate     time.Time `json:"duedate"`
 	Status      string    `json:"status"`
+	OwnerID     string    `json:"owner_id,omitempty"`
+	ProjectID   string    `json:"project_id,omitempty"`
+	// ArchivedAt is set on tasks kept after their project was deleted.
+	ArchivedAt  *time.Time           `json:"archived_at,omitempty"`
+	Attachments []AttachmentResponse `json:"attachments,omitempty"`
+	// Ownership and Access are only set when listing tasks; see domain.TaskListItem.
+	Ownership string `json:"ownership,omitempty"`
+	Access    string `json:"access,omitempty"`
 }
 
 // mapToTaskResponse converts a domain.Task into a TaskResponse for API output.
 func mapToTaskResponse(t domain.Task) TaskResponse {
-	return TaskResponse{
+	resp := TaskResponse{
 		ID:          t.ID,
 		Title:       t.Title,
 		Description: t.Description,
 		DueDate:     t.DueDate,
 		Status:      t.Status,
+		OwnerID:     t.OwnerID,
+		ProjectID:   t.ProjectID,
+	}
+	if !t.ArchivedAt.IsZero() {
+		resp.ArchivedAt = &t.ArchivedAt
+	}
+	for _, a := range t.Attachments {
+		resp.Attachments = append(resp.Attachments, mapToAttachmentResponse(a))
 	}
+	return resp
 }
 
-// GetTasks retrieves all tasks via taskUC.List and returns them as JSON.
+// GetTasks retrieves the tasks visible to the caller via taskUC.List and returns them as JSON, marking each as
+// owned by the caller, shared with the caller, or visible through the caller's role.
 func (tc *TaskController) GetTasks(c *gin.Context) {
 	tasks, err := tc.taskUC.List(c.Request.Context())
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "could not retrieve tasks"})
+		fail(c, err)
 		return
 	}
 
 	// Map tasks to TaskResponse
 	responses := make([]TaskResponse, len(tasks))
-	for i, t := range tasks {
-		responses[i] = mapToTaskResponse(t)
+	for i, item := range tasks {
+		responses[i] = mapToTaskResponse(item.Task)
+		responses[i].Ownership = string(item.Ownership)
+		responses[i].Access = string(item.Access)
 	}
 
 	c.JSON(http.StatusOK, responses)
 }
 
+// AgendaResponse lists the caller's open tasks due within a range of days. Due dates are given in the caller's
+// time zone.
+type AgendaResponse struct {
+	Range    string `json:"range"`
+	TimeZone string `json:"time_zone"`
+	// From is omitted for the overdue range, which has no lower bound.
+	From  *time.Time     `json:"from,omitempty"`
+	To    time.Time      `json:"to"`
+	Tasks []TaskResponse `json:"tasks"`
+}
+
+// agendaRange reads the range query parameter, which defaults to today.
+func agendaRange(c *gin.Context) domain.AgendaRange {
+	return domain.AgendaRange(c.DefaultQuery("range", string(domain.AgendaToday)))
+}
+
+// GetAgenda lists the caller's open tasks due today, tomorrow, this week, or before today.
+func (tc *TaskController) GetAgenda(c *gin.Context) {
+	agenda, err := tc.taskUC.Agenda(c.Request.Context(), agendaRange(c))
+	if err != nil {
+		fail(c, err)
+		return
+	}
+	resp := AgendaResponse{
+		Range:    string(agenda.Range),
+		TimeZone: agenda.Location.String(),
+		To:       agenda.To,
+		Tasks:    make([]TaskResponse, len(agenda.Tasks)),
+	}
+	if !agenda.From.IsZero() {
+		resp.From = &agenda.From
+	}
+	for i, item := range agenda.Tasks {
+		resp.Tasks[i] = mapToTaskResponse(item.Task)
+		resp.Tasks[i].DueDate = item.Task.DueDate.In(agenda.Location)
+		resp.Tasks[i].Ownership = string(item.Ownership)
+		resp.Tasks[i].Access = string(item.Access)
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
 // GetTask retrieves a single task by ID via taskUC.Get.
 func (tc *TaskController) GetTask(c *gin.Context) {
 	id := c.Param("id")
 	task, err := tc.taskUC.Get(c.Request.Context(), id)
 	if err != nil {
-		switch {
-		case errors.Is(err, usecase.ErrNotFound):
-			c.JSON(http.StatusNotFound, gin.H{"error": "task not found"})
-		case errors.Is(err, usecase.ErrInvalidID):
-			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid task ID format"})
-		default:
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "could not retrieve task"})
-		}
+		fail(c, notFound(err, "task"))
 		return
 	}
 	c.JSON(http.StatusOK, mapToTaskResponse(task))
@@ -77,14 +138,8 @@ func (tc *TaskController) GetTask(c *gin.Context) {
 
 // CreateTask handles the creation of a new task.
 func (tc *TaskController) CreateTask(c *gin.Context) {
-	var body struct {
-		Title       string     `json:"title" binding:"required"`
-		Description string     `json:"description"`
-		DueDate     *time.Time `json:"duedate" binding:"required"`
-		Status      string     `json:"status" binding:"required"`
-	}
-	if err := c.ShouldBindJSON(&body); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	var body TaskRequest
+	if !bindJSON(c, &body) {
 		return
 	}
 	task := domain.Task{
@@ -95,12 +150,7 @@ func (tc *TaskController) CreateTask(c *gin.Context) {
 	}
 	created, err := tc.taskUC.Create(c.Request.Context(), task)
 	if err != nil {
-		switch {
-		case errors.Is(err, usecase.ErrTaskAlreadyExists):
-			c.JSON(http.StatusConflict, gin.H{"error": "a task with these details already exists"})
-		default:
-			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-		}
+		fail(c, err)
 		return
 	}
 	c.JSON(http.StatusCreated, mapToTaskResponse(created))
@@ -109,14 +159,8 @@ func (tc *TaskController) CreateTask(c *gin.Context) {
 // UpdateTask updates an existing task identified by URL param ID.
 func (tc *TaskController) UpdateTask(c *gin.Context) {
 	id := c.Param("id")
-	var body struct {
-		Title       string     `json:"title" binding:"required"`
-		Description string     `json:"description"`
-		DueDate     *time.Time `json:"duedate" binding:"required"`
-		Status      string     `json:"status" binding:"required"`
-	}
-	if err := c.ShouldBindJSON(&body); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	var body TaskRequest
+	if !bindJSON(c, &body) {
 		return
 	}
 	task := domain.Task{
@@ -128,14 +172,7 @@ func (tc *TaskController) UpdateTask(c *gin.Context) {
 	}
 	updated, err := tc.taskUC.Update(c.Request.Context(), task)
 	if err != nil {
-		switch {
-		case errors.Is(err, usecase.ErrNotFound):
-			c.JSON(http.StatusNotFound, gin.H{"error": "task not found"})
-		case errors.Is(err, usecase.ErrInvalidID):
-			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid task ID format"})
-		default:
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "could not update task"})
-		}
+		fail(c, notFound(err, "task"))
 		return
 	}
 	c.JSON(http.StatusOK, mapToTaskResponse(updated))
@@ -145,20 +182,13 @@ func (tc *TaskController) UpdateTask(c *gin.Context) {
 func (tc *TaskController) DeleteTask(c *gin.Context) {
 	id := c.Param("id")
 	if err := tc.taskUC.Delete(c.Request.Context(), id); err != nil {
-		switch {
-		case errors.Is(err, usecase.ErrNotFound):
-			c.JSON(http.StatusNotFound, gin.H{"error": "task not found"})
-		case errors.Is(err, usecase.ErrInvalidID):
-			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid task ID format"})
-		default:
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "could not delete task"})
-		}
+		fail(c, notFound(err, "task"))
 		return
 	}
 	c.Status(http.StatusNoContent)
 }
 
-// AdminDashboard handles admin-only access.
+// AdminDashboard serves the dashboard to users holding the admin.dashboard permission.
 func (tc *TaskController) AdminDashboard(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"message": "Welcome Admin"})
 }
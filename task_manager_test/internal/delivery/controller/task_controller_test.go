@@ -6,6 +6,7 @@ import (
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"task_manager_test/internal/delivery/middleware"
 	"task_manager_test/internal/domain"
 	"task_manager_test/internal/mocks"
 	"task_manager_test/internal/usecase"
@@ -33,9 +34,11 @@ func (s *TaskControllerTestSuite) SetupTest() {
 	s.mockUsecase = new(mocks.TaskUsecase)
 	s.taskController = NewTaskController(s.mockUsecase)
 	s.router = gin.Default()
+	s.router.Use(middleware.ErrorHandler())
 	taskRoutes := s.router.Group("/tasks")
 	{
 		taskRoutes.GET("", s.taskController.GetTasks)
+		taskRoutes.GET("/agenda", s.taskController.GetAgenda)
 		taskRoutes.POST("", s.taskController.CreateTask)
 		taskRoutes.GET("/:id", s.taskController.GetTask)
 		taskRoutes.PUT("/:id", s.taskController.UpdateTask)
@@ -60,13 +63,23 @@ func TestTaskController(t *testing.T) {
 
 // --- GetTasks ---//
 func (s *TaskControllerTestSuite) TestGetTasks_Success() {
-	s.mockUsecase.On("List", mock.Anything).Return([]domain.Task{s.sampleTask}, nil).Once()
+	shared := s.sampleTask
+	shared.ID = "task-456"
+	s.mockUsecase.On("List", mock.Anything).Return([]domain.TaskListItem{
+		{Task: s.sampleTask, Ownership: domain.OwnershipOwner},
+		{Task: shared, Ownership: domain.OwnershipShared, Access: domain.ShareEdit},
+	}, nil).Once()
 	req, _ := http.NewRequest(http.MethodGet, "/tasks", nil)
 	w := httptest.NewRecorder()
 	s.router.ServeHTTP(w, req)
 
 	s.Equal(http.StatusOK, w.Code)
-	expectedBody, _ := json.Marshal([]TaskResponse{mapToTaskResponse(s.sampleTask)})
+	own := mapToTaskResponse(s.sampleTask)
+	own.Ownership = "owner"
+	sharedResp := mapToTaskResponse(shared)
+	sharedResp.Ownership = "shared"
+	sharedResp.Access = "edit"
+	expectedBody, _ := json.Marshal([]TaskResponse{own, sharedResp})
 	s.JSONEq(string(expectedBody), w.Body.String())
 	s.mockUsecase.AssertExpectations(s.T())
 }
@@ -77,11 +90,50 @@ func (s *TaskControllerTestSuite) TestGetTasks_Error() {
 	w := httptest.NewRecorder()
 	s.router.ServeHTTP(w, req)
 
-	s.Equal(http.StatusInternalServerError, w.Code)
-	s.JSONEq(`{"error": "could not retrieve tasks"}`, w.Body.String())
+	assertProblem(s.T(), w, http.StatusInternalServerError, "internal", "")
 	s.mockUsecase.AssertExpectations(s.T())
 }
 
+// --- GetAgenda ---//
+func (s *TaskControllerTestSuite) TestGetAgenda_Success() {
+	addis, _ := time.LoadLocation("Africa/Addis_Ababa")
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, addis)
+	s.mockUsecase.On("Agenda", mock.Anything, domain.AgendaToday).Return(domain.Agenda{
+		Range: domain.AgendaToday, Location: addis, From: from, To: from.AddDate(0, 0, 1),
+		Tasks: []domain.TaskListItem{{Task: s.sampleTask, Ownership: domain.OwnershipOwner}},
+	}, nil).Once()
+	req, _ := http.NewRequest(http.MethodGet, "/tasks/agenda", nil)
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+
+	s.Equal(http.StatusOK, w.Code)
+	s.JSONEq(`{"range":"today","time_zone":"Africa/Addis_Ababa","from":"2025-01-01T00:00:00+03:00","to":"2025-01-02T00:00:00+03:00",
+		"tasks":[{"id":"task-123","title":"Sample Task","description":"A description for the sample task.",
+		"duedate":"2025-01-01T18:04:05+03:00","status":"Pending","ownership":"owner"}]}`, w.Body.String())
+}
+
+func (s *TaskControllerTestSuite) TestGetAgenda_Overdue() {
+	s.mockUsecase.On("Agenda", mock.Anything, domain.AgendaOverdue).Return(domain.Agenda{
+		Range: domain.AgendaOverdue, Location: time.UTC, To: s.sampleTime, Tasks: []domain.TaskListItem{},
+	}, nil).Once()
+	req, _ := http.NewRequest(http.MethodGet, "/tasks/agenda?range=overdue", nil)
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+
+	s.Equal(http.StatusOK, w.Code)
+	s.JSONEq(`{"range":"overdue","time_zone":"UTC","to":"2025-01-01T15:04:05Z","tasks":[]}`, w.Body.String())
+}
+
+func (s *TaskControllerTestSuite) TestGetAgenda_InvalidRange() {
+	s.mockUsecase.On("Agenda", mock.Anything, domain.AgendaRange("month")).
+		Return(domain.Agenda{}, &usecase.TaskRequestError{Reason: "range must be today, tomorrow, week or overdue"}).Once()
+	req, _ := http.NewRequest(http.MethodGet, "/tasks/agenda?range=month", nil)
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+
+	assertProblem(s.T(), w, http.StatusBadRequest, "invalid_task_request", "range must be today, tomorrow, week or overdue")
+}
+
 // --- GetTask ---//
 func (s *TaskControllerTestSuite) TestGetTask_Success() {
 	s.mockUsecase.On("Get", mock.Anything, "task-123").Return(s.sampleTask, nil).Once()
@@ -101,8 +153,7 @@ func (s *TaskControllerTestSuite) TestGetTask_NotFound() {
 	w := httptest.NewRecorder()
 	s.router.ServeHTTP(w, req)
 
-	s.Equal(http.StatusNotFound, w.Code)
-	s.JSONEq(`{"error": "task not found"}`, w.Body.String())
+	assertProblem(s.T(), w, http.StatusNotFound, "not_found", "task not found")
 	s.mockUsecase.AssertExpectations(s.T())
 }
 
@@ -116,8 +167,7 @@ func (s *TaskControllerTestSuite) TestGetTask_InvalidID() {
 	s.router.ServeHTTP(w, req)
 
 	// Assert
-	s.Equal(http.StatusBadRequest, w.Code)
-	s.JSONEq(`{"error": "invalid task ID format"}`, w.Body.String())
+	assertProblem(s.T(), w, http.StatusBadRequest, "invalid_id", "")
 	s.mockUsecase.AssertExpectations(s.T())
 }
 
@@ -161,15 +211,14 @@ func (s *TaskControllerTestSuite) TestCreateTask_Conflict() {
 	s.router.ServeHTTP(w, req)
 
 	// Assert
-	s.Equal(http.StatusConflict, w.Code)
-	s.JSONEq(`{"error": "a task with these details already exists"}`, w.Body.String())
+	assertProblem(s.T(), w, http.StatusConflict, "task_exists", "")
 	s.mockUsecase.AssertExpectations(s.T())
 }
 
 func (s *TaskControllerTestSuite) TestCreateTask_UsecaseValidationError() {
-	// Arrange: Mock the use case to return a generic validation error.
+	// Arrange: Mock the use case to reject the task.
 	taskToCreate := domain.Task{Title: "title", Description: "", DueDate: s.sampleTime, Status: "invalid"}
-	validationError := errors.New("status must be one of 'pending', 'in-progress', or 'done'")
+	validationError := &usecase.TaskRequestError{Reason: "status must be one of 'pending', 'in-progress', or 'done'"}
 	s.mockUsecase.On("Create", mock.Anything, taskToCreate).Return(domain.Task{}, validationError).Once()
 
 	// Act
@@ -179,9 +228,8 @@ func (s *TaskControllerTestSuite) TestCreateTask_UsecaseValidationError() {
 	w := httptest.NewRecorder()
 	s.router.ServeHTTP(w, req)
 
-	// Assert: This hits the default case which returns the raw error.
-	s.Equal(http.StatusBadRequest, w.Code)
-	s.JSONEq(`{"error": "status must be one of 'pending', 'in-progress', or 'done'"}`, w.Body.String())
+	// Assert: The reason is reported as the problem detail.
+	assertProblem(s.T(), w, http.StatusBadRequest, "invalid_task_request", "status must be one of 'pending', 'in-progress', or 'done'")
 	s.mockUsecase.AssertExpectations(s.T())
 }
 
@@ -222,8 +270,7 @@ func (s *TaskControllerTestSuite) TestUpdateTask_NotFound() {
 	w := httptest.NewRecorder()
 	s.router.ServeHTTP(w, req)
 
-	s.Equal(http.StatusNotFound, w.Code)
-	s.JSONEq(`{"error": "task not found"}`, w.Body.String())
+	assertProblem(s.T(), w, http.StatusNotFound, "not_found", "task not found")
 	s.mockUsecase.AssertExpectations(s.T())
 }
 
@@ -238,8 +285,7 @@ func (s *TaskControllerTestSuite) TestUpdateTask_InvalidID() {
 	s.router.ServeHTTP(w, req)
 
 	// Assert
-	s.Equal(http.StatusBadRequest, w.Code)
-	s.JSONEq(`{"error": "invalid task ID format"}`, w.Body.String())
+	assertProblem(s.T(), w, http.StatusBadRequest, "invalid_id", "")
 	s.mockUsecase.AssertExpectations(s.T())
 }
 
@@ -261,8 +307,7 @@ func (s *TaskControllerTestSuite) TestDeleteTask_NotFound() {
 	w := httptest.NewRecorder()
 	s.router.ServeHTTP(w, req)
 
-	s.Equal(http.StatusNotFound, w.Code)
-	s.JSONEq(`{"error": "task not found"}`, w.Body.String())
+	assertProblem(s.T(), w, http.StatusNotFound, "not_found", "task not found")
 	s.mockUsecase.AssertExpectations(s.T())
 }
 
@@ -276,8 +321,7 @@ func (s *TaskControllerTestSuite) TestDeleteTask_InvalidID() {
 	s.router.ServeHTTP(w, req)
 
 	// Assert
-	s.Equal(http.StatusBadRequest, w.Code)
-	s.JSONEq(`{"error": "invalid task ID format"}`, w.Body.String())
+	assertProblem(s.T(), w, http.StatusBadRequest, "invalid_id", "")
 	s.mockUsecase.AssertExpectations(s.T())
 }
 
@@ -287,8 +331,29 @@ func (s *TaskControllerTestSuite) TestDeleteTask_InternalError() {
 	w := httptest.NewRecorder()
 	s.router.ServeHTTP(w, req)
 
-	s.Equal(http.StatusInternalServerError, w.Code)
-	s.JSONEq(`{"error": "could not delete task"}`, w.Body.String())
+	assertProblem(s.T(), w, http.StatusInternalServerError, "internal", "")
+	s.mockUsecase.AssertExpectations(s.T())
+}
+
+// TestTaskEndpoints_Forbidden tests that permission failures from the use case are reported as 403.
+func (s *TaskControllerTestSuite) TestTaskEndpoints_Forbidden() {
+	s.mockUsecase.On("List", mock.Anything).Return(nil, usecase.ErrForbidden).Once()
+	s.mockUsecase.On("Update", mock.Anything, s.sampleTask).Return(domain.Task{}, usecase.ErrForbidden).Once()
+	s.mockUsecase.On("Delete", mock.Anything, "task-123").Return(usecase.ErrForbidden).Once()
+	body, _ := json.Marshal(gin.H{"title": s.sampleTask.Title, "description": s.sampleTask.Description, "duedate": s.sampleTask.DueDate, "status": s.sampleTask.Status})
+
+	requests := []*http.Request{
+		httptest.NewRequest(http.MethodGet, "/tasks", nil),
+		httptest.NewRequest(http.MethodPut, "/tasks/task-123", bytes.NewBuffer(body)),
+		httptest.NewRequest(http.MethodDelete, "/tasks/task-123", nil),
+	}
+	for _, req := range requests {
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		s.router.ServeHTTP(w, req)
+
+		assertProblem(s.T(), w, http.StatusForbidden, "forbidden", "")
+	}
 	s.mockUsecase.AssertExpectations(s.T())
 }
 
@@ -0,0 +1,273 @@
+package controller
+
+import (
+	"net/http"
+	"task_manager_test/internal/domain"
+	"task_manager_test/internal/usecase"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TaskControllerV2 serves tasks in the /api/v2 representation. It shares the task use cases with TaskController
+// and differs only in its request and response bodies.
+type TaskControllerV2 struct {
+	taskUC usecase.TaskUsecase
+	userUC usecase.UserUsecase
+}
+
+// NewTaskControllerV2 creates a TaskControllerV2; userUC resolves the owners embedded in responses.
+func NewTaskControllerV2(t usecase.TaskUsecase, u usecase.UserUsecase) *TaskControllerV2 {
+	return &TaskControllerV2{taskUC: t, userUC: u}
+}
+
+// apiV2 is the path prefix of the links in v2 responses.
+const apiV2 = "/api/v2"
+
+// TaskRequestV2 is the body accepted when creating or replacing a task through /api/v2.
+type TaskRequestV2 struct {
+	Title       string     `json:"title" binding:"required"`
+	Description string     `json:"description"`
+	DueDate     *time.Time `json:"due_date" binding:"required"`
+	Status      string     `json:"status" binding:"required"`
+}
+
+// TaskResponseV2 is the /api/v2 representation of a task. Times are RFC 3339 strings in UTC.
+type TaskResponseV2 struct {
+	ID          string `json:"id"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	DueDate     string `json:"due_date" format:"date-time"`
+	Status      string `json:"status"`
+	// Owner is null for tasks created before ownership existed, or whose owner was deleted.
+	Owner       *UserRef             `json:"owner"`
+	ProjectID   string               `json:"project_id,omitempty"`
+	ArchivedAt  string               `json:"archived_at,omitempty" format:"date-time"`
+	Attachments []AttachmentResponse `json:"attachments"`
+	// Ownership and Access are only set when listing tasks; see domain.TaskListItem.
+	Ownership string    `json:"ownership,omitempty"`
+	Access    string    `json:"access,omitempty"`
+	Links     TaskLinks `json:"links"`
+}
+
+// UserRef identifies a user embedded in another resource.
+type UserRef struct {
+	ID       string `json:"id"`
+	Username string `json:"username"`
+}
+
+// TaskLinks holds the URLs of a task and its sub-resources.
+type TaskLinks struct {
+	Self        string `json:"self"`
+	Comments    string `json:"comments"`
+	Attachments string `json:"attachments"`
+	Shares      string `json:"shares"`
+	Project     string `json:"project,omitempty"`
+}
+
+// mapToTaskResponseV2 converts a domain.Task into a TaskResponseV2, embedding the owner's username from usernames.
+func mapToTaskResponseV2(t domain.Task, usernames map[string]string) TaskResponseV2 {
+	self := apiV2 + "/tasks/" + t.ID
+	resp := TaskResponseV2{
+		ID:          t.ID,
+		Title:       t.Title,
+		Description: t.Description,
+		DueDate:     formatTime(t.DueDate),
+		Status:      t.Status,
+		ProjectID:   t.ProjectID,
+		Attachments: make([]AttachmentResponse, 0, len(t.Attachments)),
+		Links: TaskLinks{
+			Self:        self,
+			Comments:    self + "/comments",
+			Attachments: self + "/attachments",
+			Shares:      self + "/shares",
+		},
+	}
+	if username, ok := usernames[t.OwnerID]; ok {
+		resp.Owner = &UserRef{ID: t.OwnerID, Username: username}
+	}
+	if !t.ArchivedAt.IsZero() {
+		resp.ArchivedAt = formatTime(t.ArchivedAt)
+	}
+	for _, a := range t.Attachments {
+		resp.Attachments = append(resp.Attachments, mapToAttachmentResponse(a))
+	}
+	if t.ProjectID != "" {
+		resp.Links.Project = apiV2 + "/projects/" + t.ProjectID
+	}
+	return resp
+}
+
+// formatTime renders t as an RFC 3339 string in UTC.
+func formatTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
+
+// respond maps tasks to v2 responses, resolving all of their owners in one call.
+func (tc *TaskControllerV2) respond(c *gin.Context, tasks ...domain.Task) ([]TaskResponseV2, bool) {
+	owners := make([]string, len(tasks))
+	for i, t := range tasks {
+		owners[i] = t.OwnerID
+	}
+	usernames, err := tc.userUC.Usernames(c.Request.Context(), owners)
+	if err != nil {
+		fail(c, err)
+		return nil, false
+	}
+	responses := make([]TaskResponseV2, len(tasks))
+	for i, t := range tasks {
+		responses[i] = mapToTaskResponseV2(t, usernames)
+	}
+	return responses, true
+}
+
+// toTask converts a v2 request body into a domain.Task.
+func (body TaskRequestV2) toTask() domain.Task {
+	return domain.Task{
+		Title:       body.Title,
+		Description: body.Description,
+		DueDate:     *body.DueDate,
+		Status:      body.Status,
+	}
+}
+
+// GetTasks lists the tasks visible to the caller, like TaskController.GetTasks.
+func (tc *TaskControllerV2) GetTasks(c *gin.Context) {
+	items, err := tc.taskUC.List(c.Request.Context())
+	if err != nil {
+		fail(c, err)
+		return
+	}
+	tasks := make([]domain.Task, len(items))
+	for i, item := range items {
+		tasks[i] = item.Task
+	}
+	responses, ok := tc.respond(c, tasks...)
+	if !ok {
+		return
+	}
+	for i, item := range items {
+		responses[i].Ownership = string(item.Ownership)
+		responses[i].Access = string(item.Access)
+	}
+	c.JSON(http.StatusOK, responses)
+}
+
+// AgendaResponseV2 is the /api/v2 representation of an agenda. Unlike elsewhere in v2, times are given in the
+// caller's time zone.
+type AgendaResponseV2 struct {
+	Range    string `json:"range"`
+	TimeZone string `json:"time_zone"`
+	// From is omitted for the overdue range, which has no lower bound.
+	From  string           `json:"from,omitempty" format:"date-time"`
+	To    string           `json:"to" format:"date-time"`
+	Tasks []TaskResponseV2 `json:"tasks"`
+}
+
+// GetAgenda lists the caller's open tasks due within a range of days, like TaskController.GetAgenda.
+func (tc *TaskControllerV2) GetAgenda(c *gin.Context) {
+	agenda, err := tc.taskUC.Agenda(c.Request.Context(), agendaRange(c))
+	if err != nil {
+		fail(c, err)
+		return
+	}
+	tasks := make([]domain.Task, len(agenda.Tasks))
+	for i, item := range agenda.Tasks {
+		tasks[i] = item.Task
+	}
+	responses, ok := tc.respond(c, tasks...)
+	if !ok {
+		return
+	}
+	for i, item := range agenda.Tasks {
+		responses[i].DueDate = item.Task.DueDate.In(agenda.Location).Format(time.RFC3339)
+		responses[i].Ownership = string(item.Ownership)
+		responses[i].Access = string(item.Access)
+	}
+	resp := AgendaResponseV2{
+		Range:    string(agenda.Range),
+		TimeZone: agenda.Location.String(),
+		To:       agenda.To.Format(time.RFC3339),
+		Tasks:    responses,
+	}
+	if !agenda.From.IsZero() {
+		resp.From = agenda.From.Format(time.RFC3339)
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// GetTask retrieves a single task by ID.
+func (tc *TaskControllerV2) GetTask(c *gin.Context) {
+	task, err := tc.taskUC.Get(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		fail(c, notFound(err, "task"))
+		return
+	}
+	if responses, ok := tc.respond(c, task); ok {
+		c.JSON(http.StatusOK, responses[0])
+	}
+}
+
+// CreateTask creates a personal task.
+func (tc *TaskControllerV2) CreateTask(c *gin.Context) {
+	var body TaskRequestV2
+	if !bindJSON(c, &body) {
+		return
+	}
+	created, err := tc.taskUC.Create(c.Request.Context(), body.toTask())
+	if err != nil {
+		fail(c, err)
+		return
+	}
+	if responses, ok := tc.respond(c, created); ok {
+		c.JSON(http.StatusCreated, responses[0])
+	}
+}
+
+// UpdateTask replaces the task identified by URL param ID.
+func (tc *TaskControllerV2) UpdateTask(c *gin.Context) {
+	var body TaskRequestV2
+	if !bindJSON(c, &body) {
+		return
+	}
+	task := body.toTask()
+	task.ID = c.Param("id")
+	updated, err := tc.taskUC.Update(c.Request.Context(), task)
+	if err != nil {
+		fail(c, notFound(err, "task"))
+		return
+	}
+	if responses, ok := tc.respond(c, updated); ok {
+		c.JSON(http.StatusOK, responses[0])
+	}
+}
+
+// ListProjectTasks lists the tasks of a project, like ProjectController.ListProjectTasks.
+func (tc *TaskControllerV2) ListProjectTasks(c *gin.Context) {
+	tasks, err := tc.taskUC.ListByProject(c.Request.Context(), c.Param("pid"))
+	if err != nil {
+		respondProjectError(c, err)
+		return
+	}
+	if responses, ok := tc.respond(c, tasks...); ok {
+		c.JSON(http.StatusOK, responses)
+	}
+}
+
+// CreateProjectTask creates a task in a project, like ProjectController.CreateProjectTask.
+func (tc *TaskControllerV2) CreateProjectTask(c *gin.Context) {
+	var body TaskRequestV2
+	if !bindJSON(c, &body) {
+		return
+	}
+	task := body.toTask()
+	task.ProjectID = c.Param("pid")
+	created, err := tc.taskUC.Create(c.Request.Context(), task)
+	if err != nil {
+		fail(c, err)
+		return
+	}
+	if responses, ok := tc.respond(c, created); ok {
+		c.JSON(http.StatusCreated, responses[0])
+	}
+}
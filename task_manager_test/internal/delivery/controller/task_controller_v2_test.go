@@ -0,0 +1,188 @@
+package controller
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"task_manager_test/internal/delivery/middleware"
+	"task_manager_test/internal/domain"
+	"task_manager_test/internal/mocks"
+	"task_manager_test/internal/usecase"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+// TaskControllerV2TestSuite defines the test suite for the TaskControllerV2.
+type TaskControllerV2TestSuite struct {
+	suite.Suite
+	router     *gin.Engine
+	mockTasks  *mocks.TaskUsecase
+	mockUsers  *mocks.UserUsecase
+	sampleTask domain.Task
+}
+
+// SetupTest runs before each test in the suite.
+func (s *TaskControllerV2TestSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+	s.mockTasks = new(mocks.TaskUsecase)
+	s.mockUsers = new(mocks.UserUsecase)
+	controller := NewTaskControllerV2(s.mockTasks, s.mockUsers)
+	s.router = gin.New()
+	s.router.Use(middleware.ErrorHandler())
+	s.router.GET("/tasks", controller.GetTasks)
+	s.router.GET("/tasks/agenda", controller.GetAgenda)
+	s.router.POST("/tasks", controller.CreateTask)
+	s.router.GET("/tasks/:id", controller.GetTask)
+	s.router.PUT("/tasks/:id", controller.UpdateTask)
+	s.router.GET("/projects/:pid/tasks", controller.ListProjectTasks)
+	s.router.POST("/projects/:pid/tasks", controller.CreateProjectTask)
+
+	s.sampleTask = domain.Task{
+		ID:          "task-123",
+		Title:       "Sample Task",
+		Description: "A description for the sample task.",
+		DueDate:     time.Date(2025, 1, 1, 17, 4, 5, 0, time.FixedZone("EET", 2*60*60)),
+		Status:      "Pending",
+		OwnerID:     "user-1",
+	}
+}
+
+// TestTaskControllerV2 runs the entire test suite.
+func TestTaskControllerV2(t *testing.T) {
+	suite.Run(t, new(TaskControllerV2TestSuite))
+}
+
+// send performs a request against the suite router.
+func (s *TaskControllerV2TestSuite) send(method, path, body string) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest(method, path, bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	return w
+}
+
+// TestGetTask tests the v2 representation: snake_case UTC times, the embedded owner and links.
+func (s *TaskControllerV2TestSuite) TestGetTask() {
+	s.mockTasks.On("Get", mock.Anything, "task-123").Return(s.sampleTask, nil).Once()
+	s.mockUsers.On("Usernames", mock.Anything, []string{"user-1"}).Return(map[string]string{"user-1": "alice"}, nil).Once()
+
+	w := s.send(http.MethodGet, "/tasks/task-123", "")
+
+	s.Equal(http.StatusOK, w.Code)
+	s.JSONEq(`{
+		"id": "task-123",
+		"title": "Sample Task",
+		"description": "A description for the sample task.",
+		"due_date": "2025-01-01T15:04:05Z",
+		"status": "Pending",
+		"owner": {"id": "user-1", "username": "alice"},
+		"attachments": [],
+		"links": {
+			"self": "/api/v2/tasks/task-123",
+			"comments": "/api/v2/tasks/task-123/comments",
+			"attachments": "/api/v2/tasks/task-123/attachments",
+			"shares": "/api/v2/tasks/task-123/shares"
+		}
+	}`, w.Body.String())
+	s.mockTasks.AssertExpectations(s.T())
+}
+
+// TestGetTasks tests that listing resolves every owner in one call and marks how each task is visible.
+func (s *TaskControllerV2TestSuite) TestGetTasks() {
+	legacy := s.sampleTask
+	legacy.ID = "task-456"
+	legacy.OwnerID = ""
+	archived := s.sampleTask
+	archived.ID = "task-789"
+	archived.ProjectID = "proj-1"
+	archived.ArchivedAt = time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+	s.mockTasks.On("List", mock.Anything).Return([]domain.TaskListItem{
+		{Task: s.sampleTask, Ownership: domain.OwnershipOwner},
+		{Task: legacy, Ownership: domain.OwnershipShared, Access: domain.ShareRead},
+		{Task: archived, Ownership: domain.OwnershipOwner},
+	}, nil).Once()
+	s.mockUsers.On("Usernames", mock.Anything, []string{"user-1", "", "user-1"}).Return(map[string]string{"user-1": "alice"}, nil).Once()
+
+	w := s.send(http.MethodGet, "/tasks", "")
+
+	s.Equal(http.StatusOK, w.Code)
+	var got []TaskResponseV2
+	s.NoError(json.Unmarshal(w.Body.Bytes(), &got))
+	s.Len(got, 3)
+	s.Equal(&UserRef{ID: "user-1", Username: "alice"}, got[0].Owner)
+	s.Equal("owner", got[0].Ownership)
+	s.Nil(got[1].Owner, "tasks without an owner embed null")
+	s.Equal("shared", got[1].Ownership)
+	s.Equal("read", got[1].Access)
+	s.Equal("2025-02-01T00:00:00Z", got[2].ArchivedAt)
+	s.Equal("/api/v2/projects/proj-1", got[2].Links.Project)
+}
+
+// TestCreateTask tests that v2 bodies use due_date and project tasks are created in their project.
+func (s *TaskControllerV2TestSuite) TestCreateTask() {
+	due := time.Date(2025, 1, 1, 15, 4, 5, 0, time.UTC)
+	want := domain.Task{Title: "New", DueDate: due, Status: "Pending"}
+	s.mockTasks.On("Create", mock.Anything, want).Return(s.sampleTask, nil).Once()
+	inProject := want
+	inProject.ProjectID = "proj-1"
+	s.mockTasks.On("Create", mock.Anything, inProject).Return(s.sampleTask, nil).Once()
+	s.mockUsers.On("Usernames", mock.Anything, []string{"user-1"}).Return(map[string]string{"user-1": "alice"}, nil)
+
+	body := `{"title": "New", "due_date": "2025-01-01T15:04:05Z", "status": "Pending"}`
+	s.Equal(http.StatusCreated, s.send(http.MethodPost, "/tasks", body).Code)
+	s.Equal(http.StatusCreated, s.send(http.MethodPost, "/projects/proj-1/tasks", body).Code)
+
+	w := s.send(http.MethodPost, "/tasks", `{"title": "New", "duedate": "2025-01-01T15:04:05Z", "status": "Pending"}`)
+	p := assertProblem(s.T(), w, http.StatusBadRequest, "invalid_request", "request body failed validation")
+	s.Equal([]middleware.FieldError{{Field: "due_date", Message: "is required"}}, p.Errors)
+	s.mockTasks.AssertExpectations(s.T())
+}
+
+// TestUpdateTask tests that errors are reported as in v1.
+func (s *TaskControllerV2TestSuite) TestUpdateTask() {
+	s.mockTasks.On("Update", mock.Anything, mock.Anything).Return(domain.Task{}, usecase.ErrNotFound).Once()
+
+	w := s.send(http.MethodPut, "/tasks/missing", `{"title": "x", "due_date": "2025-01-01T15:04:05Z", "status": "Done"}`)
+
+	assertProblem(s.T(), w, http.StatusNotFound, "not_found", "task not found")
+}
+
+// TestListProjectTasks tests project task listing and that owner lookup failures are reported.
+func (s *TaskControllerV2TestSuite) TestListProjectTasks() {
+	s.mockTasks.On("ListByProject", mock.Anything, "proj-1").Return([]domain.Task{s.sampleTask}, nil).Once()
+	s.mockUsers.On("Usernames", mock.Anything, []string{"user-1"}).Return(nil, errors.New("database error")).Once()
+
+	w := s.send(http.MethodGet, "/projects/proj-1/tasks", "")
+
+	assertProblem(s.T(), w, http.StatusInternalServerError, "internal", "")
+}
+
+// TestGetAgenda tests that agenda due dates are rendered in the caller's time zone.
+func (s *TaskControllerV2TestSuite) TestGetAgenda() {
+	sf, _ := time.LoadLocation("America/Los_Angeles")
+	monday := time.Date(2024, 12, 30, 0, 0, 0, 0, sf)
+	s.mockTasks.On("Agenda", mock.Anything, domain.AgendaWeek).Return(domain.Agenda{
+		Range: domain.AgendaWeek, Location: sf, From: monday, To: monday.AddDate(0, 0, 7),
+		Tasks: []domain.TaskListItem{{Task: s.sampleTask, Ownership: domain.OwnershipOwner}},
+	}, nil).Once()
+	s.mockUsers.On("Usernames", mock.Anything, []string{"user-1"}).Return(map[string]string{"user-1": "alice"}, nil).Once()
+
+	w := s.send(http.MethodGet, "/tasks/agenda?range=week", "")
+
+	s.Equal(http.StatusOK, w.Code)
+	var got AgendaResponseV2
+	s.Require().NoError(json.Unmarshal(w.Body.Bytes(), &got))
+	s.Equal("America/Los_Angeles", got.TimeZone)
+	s.Equal("2024-12-30T00:00:00-08:00", got.From)
+	s.Equal("2025-01-06T00:00:00-08:00", got.To)
+	s.Require().Len(got.Tasks, 1)
+	s.Equal("2025-01-01T07:04:05-08:00", got.Tasks[0].DueDate)
+	s.Equal("owner", got.Tasks[0].Ownership)
+	s.Equal(&UserRef{ID: "user-1", Username: "alice"}, got.Tasks[0].Owner)
+}
@@ -0,0 +1,87 @@
+package controller
+
+import (
+	"net/http"
+	"task_manager_test/internal/domain"
+	"task_manager_test/internal/usecase"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TaskShareController wraps use case interfaces for sharing tasks with other users.
+type TaskShareController struct {
+	taskUC usecase.TaskUsecase
+}
+
+// NewTaskShareController creates a new Handler given Task use cases.
+func NewTaskShareController(t usecase.TaskUsecase) *TaskShareController {
+	return &TaskShareController{taskUC: t}
+}
+
+// ShareRequest is the body accepted when sharing a task.
+type ShareRequest struct {
+	Username string `json:"username" binding:"required"`
+	Access   string `json:"access" binding:"required"`
+}
+
+// TaskShareResponse defines the JSON structure for a task share returned in API responses.
+type TaskShareResponse struct {
+	Username  string    `json:"username"`
+	Access    string    `json:"access"`
+	GrantedBy string    `json:"granted_by"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// mapToTaskShareResponse converts a domain.TaskShare into a TaskShareResponse for API output.
+func mapToTaskShareResponse(s domain.TaskShare) TaskShareResponse {
+	return TaskShareResponse{
+		Username:  s.Username,
+		Access:    string(s.Access),
+		GrantedBy: s.GrantedBy,
+		CreatedAt: s.CreatedAt,
+	}
+}
+
+// respondShareError records errors shared by every share endpoint. Tasks the caller cannot see are reported as
+// not found, while visible tasks whose shares the caller may not manage are forbidden.
+func respondShareError(c *gin.Context, err error) {
+	fail(c, notFound(err, "task"))
+}
+
+// ShareTask grants a user read or edit access to the task, or changes the access of an existing share.
+func (sc *TaskShareController) ShareTask(c *gin.Context) {
+	var body ShareRequest
+	if !bindJSON(c, &body) {
+		return
+	}
+	share, err := sc.taskUC.Share(c.Request.Context(), c.Param("id"), body.Username, domain.ShareAccess(body.Access))
+	if err != nil {
+		respondShareError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, mapToTaskShareResponse(share))
+}
+
+// ListShares returns the users the task is shared with.
+func (sc *TaskShareController) ListShares(c *gin.Context) {
+	shares, err := sc.taskUC.ListShares(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		respondShareError(c, err)
+		return
+	}
+	responses := make([]TaskShareResponse, len(shares))
+	for i, s := range shares {
+		responses[i] = mapToTaskShareResponse(s)
+	}
+	c.JSON(http.StatusOK, responses)
+}
+
+// RevokeShare removes a user's access to the task.
+func (sc *TaskShareController) RevokeShare(c *gin.Context) {
+	if err := sc.taskUC.Unshare(c.Request.Context(), c.Param("id"), c.Param("username")); err != nil {
+		respondShareError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
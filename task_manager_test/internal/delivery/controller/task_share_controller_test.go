@@ -0,0 +1,140 @@
+package controller
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"task_manager_test/internal/delivery/middleware"
+	"task_manager_test/internal/domain"
+	"task_manager_test/internal/mocks"
+	"task_manager_test/internal/usecase"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+// TaskShareControllerTestSuite defines the test suite for the TaskShareController.
+type TaskShareControllerTestSuite struct {
+	suite.Suite
+	router      *gin.Engine
+	mockUsecase *mocks.TaskUsecase
+}
+
+// SetupTest runs before each test in the suite, ensuring a clean state.
+func (s *TaskShareControllerTestSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+	s.mockUsecase = new(mocks.TaskUsecase)
+	sc := NewTaskShareController(s.mockUsecase)
+
+	s.router = gin.New()
+	s.router.Use(middleware.ErrorHandler())
+	s.router.POST("/tasks/:id/shares", sc.ShareTask)
+	s.router.GET("/tasks/:id/shares", sc.ListShares)
+	s.router.DELETE("/tasks/:id/shares/:username", sc.RevokeShare)
+}
+
+// TestTaskShareController runs the entire test suite.
+func TestTaskShareController(t *testing.T) {
+	suite.Run(t, new(TaskShareControllerTestSuite))
+}
+
+// send performs a JSON request against the suite router.
+func (s *TaskShareControllerTestSuite) send(method, path string, body gin.H) *httptest.ResponseRecorder {
+	var buf bytes.Buffer
+	if body != nil {
+		_ = json.NewEncoder(&buf).Encode(body)
+	}
+	req, _ := http.NewRequest(method, path, &buf)
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	return w
+}
+
+//--- ShareTask Endpoint Tests ---//
+
+// TestShareTask_Success tests granting access to a task.
+func (s *TaskShareControllerTestSuite) TestShareTask_Success() {
+	// Arrange
+	createdAt := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	share := domain.TaskShare{TaskID: "task-123", UserID: "user-2", Username: "bob", Access: domain.ShareRead, GrantedBy: "user-1", CreatedAt: createdAt}
+	s.mockUsecase.On("Share", mock.Anything, "task-123", "bob", domain.ShareRead).Return(share, nil).Once()
+
+	// Act
+	w := s.send(http.MethodPost, "/tasks/task-123/shares", gin.H{"username": "bob", "access": "read"})
+
+	// Assert
+	s.Equal(http.StatusCreated, w.Code)
+	s.JSONEq(`{"username": "bob", "access": "read", "granted_by": "user-1", "created_at": "2025-01-01T12:00:00Z"}`, w.Body.String())
+	s.mockUsecase.AssertExpectations(s.T())
+}
+
+// TestShareTask_BadRequestBinding tests that both fields are required.
+func (s *TaskShareControllerTestSuite) TestShareTask_BadRequestBinding() {
+	w := s.send(http.MethodPost, "/tasks/task-123/shares", gin.H{"username": "bob"})
+
+	s.Equal(http.StatusBadRequest, w.Code)
+	s.mockUsecase.AssertNotCalled(s.T(), "Share", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+// TestShareTask_ErrorMapping tests how usecase errors are translated into HTTP responses.
+func (s *TaskShareControllerTestSuite) TestShareTask_ErrorMapping() {
+	cases := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantCode   string
+		wantDetail string
+	}{
+		{"invalid request", &usecase.ShareRequestError{Reason: "unknown user bob"}, http.StatusBadRequest, "invalid_share_request", "unknown user bob"},
+		{"hidden task", usecase.ErrNotFound, http.StatusNotFound, "not_found", "task not found"},
+		{"not the owner", usecase.ErrForbidden, http.StatusForbidden, "forbidden", ""},
+		{"invalid id", usecase.ErrInvalidID, http.StatusBadRequest, "invalid_id", ""},
+		{"database down", errors.New("database down"), http.StatusInternalServerError, "internal", ""},
+	}
+	for _, tc := range cases {
+		s.Run(tc.name, func() {
+			s.SetupTest()
+			s.mockUsecase.On("Share", mock.Anything, "task-123", "bob", domain.ShareEdit).Return(domain.TaskShare{}, tc.err).Once()
+
+			w := s.send(http.MethodPost, "/tasks/task-123/shares", gin.H{"username": "bob", "access": "edit"})
+
+			assertProblem(s.T(), w, tc.wantStatus, tc.wantCode, tc.wantDetail)
+		})
+	}
+}
+
+//--- ListShares Endpoint Tests ---//
+
+// TestListShares_Success tests listing the users a task is shared with.
+func (s *TaskShareControllerTestSuite) TestListShares_Success() {
+	createdAt := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	s.mockUsecase.On("ListShares", mock.Anything, "task-123").Return([]domain.TaskShare{
+		{Username: "bob", Access: domain.ShareEdit, GrantedBy: "user-1", CreatedAt: createdAt},
+	}, nil).Once()
+
+	w := s.send(http.MethodGet, "/tasks/task-123/shares", nil)
+
+	s.Equal(http.StatusOK, w.Code)
+	s.JSONEq(`[{"username": "bob", "access": "edit", "granted_by": "user-1", "created_at": "2025-01-01T12:00:00Z"}]`, w.Body.String())
+}
+
+//--- RevokeShare Endpoint Tests ---//
+
+// TestRevokeShare tests revoking a share and the response for a share that does not exist.
+func (s *TaskShareControllerTestSuite) TestRevokeShare() {
+	s.mockUsecase.On("Unshare", mock.Anything, "task-123", "bob").Return(nil).Once()
+	s.mockUsecase.On("Unshare", mock.Anything, "task-123", "ghost").Return(usecase.ErrShareNotFound).Once()
+
+	w := s.send(http.MethodDelete, "/tasks/task-123/shares/bob", nil)
+	s.Equal(http.StatusNoContent, w.Code)
+	s.Empty(w.Body.String())
+
+	w = s.send(http.MethodDelete, "/tasks/task-123/shares/ghost", nil)
+	assertProblem(s.T(), w, http.StatusNotFound, "share_not_found", "")
+}
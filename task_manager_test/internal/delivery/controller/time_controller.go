@@ -0,0 +1,227 @@
+package controller
+
+import (
+	"encoding/csv"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"task_manager_test/internal/domain"
+	"task_manager_test/internal/usecase"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TimeController wraps use case interfaces for tracking the time spent on tasks.
+type TimeController struct {
+	timeUC usecase.TimeUsecase
+}
+
+// NewTimeController creates a new Handler given Time use cases.
+func NewTimeController(t usecase.TimeUsecase) *TimeController {
+	return &TimeController{timeUC: t}
+}
+
+// StartTimerRequest is the optional body accepted when starting a timer.
+type StartTimerRequest struct {
+	Note string `json:"note"`
+}
+
+// LogTimeRequest is the body accepted when logging time without a timer.
+type LogTimeRequest struct {
+	Start *time.Time `json:"start" binding:"required"`
+	End   *time.Time `json:"end" binding:"required"`
+	Note  string     `json:"note"`
+}
+
+// TimeEntryResponse defines the JSON structure for a time entry returned in API responses.
+type TimeEntryResponse struct {
+	ID     string    `json:"id"`
+	TaskID string    `json:"task_id"`
+	User   string    `json:"user"`
+	Start  time.Time `json:"start"`
+	// End and DurationSeconds are omitted while the timer is running.
+	End             *time.Time `json:"end,omitempty"`
+	DurationSeconds *int64     `json:"duration_seconds,omitempty"`
+	Running         bool       `json:"running"`
+	Manual          bool       `json:"manual"`
+	Note            string     `json:"note,omitempty"`
+	CreatedAt       time.Time  `json:"created_at"`
+}
+
+// TaskTimeResponse lists the time logged against a task. The total counts running timers up to the request.
+type TaskTimeResponse struct {
+	Entries      []TimeEntryResponse `json:"entries"`
+	TotalSeconds int64               `json:"total_seconds"`
+}
+
+// TimesheetTaskResponse is the time spent on a task during one day of a timesheet.
+type TimesheetTaskResponse struct {
+	TaskID string `json:"task_id"`
+	// Title is empty for tasks that no longer exist.
+	Title   string `json:"title"`
+	Seconds int64  `json:"seconds"`
+}
+
+// TimesheetDayResponse is the time logged during one day of a timesheet, by task.
+type TimesheetDayResponse struct {
+	Date         string                  `json:"date"`
+	Tasks        []TimesheetTaskResponse `json:"tasks"`
+	TotalSeconds int64                   `json:"total_seconds"`
+}
+
+// TimesheetResponse is the time the caller logged over a range of days in the caller's time zone. Days without
+// logged time are left out.
+type TimesheetResponse struct {
+	TimeZone     string                 `json:"time_zone"`
+	From         string                 `json:"from"`
+	To           string                 `json:"to"`
+	Days         []TimesheetDayResponse `json:"days"`
+	TotalSeconds int64                  `json:"total_seconds"`
+}
+
+// mapToTimeEntryResponse converts a domain.TimeEntry into a TimeEntryResponse for API output.
+func mapToTimeEntryResponse(e domain.TimeEntry) TimeEntryResponse {
+	resp := TimeEntryResponse{
+		ID:        e.ID,
+		TaskID:    e.TaskID,
+		User:      e.Username,
+		Start:     e.Start,
+		Running:   e.Running(),
+		Manual:    e.Manual,
+		Note:      e.Note,
+		CreatedAt: e.CreatedAt,
+	}
+	if !e.Running() {
+		seconds := int64(e.End.Sub(e.Start).Seconds())
+		resp.End = &e.End
+		resp.DurationSeconds = &seconds
+	}
+	return resp
+}
+
+// mapToTimesheetResponse converts a domain.Timesheet into a TimesheetResponse for API output.
+func mapToTimesheetResponse(t domain.Timesheet) TimesheetResponse {
+	resp := TimesheetResponse{
+		TimeZone:     t.Location.String(),
+		From:         t.From.Format(time.DateOnly),
+		To:           t.To.Format(time.DateOnly),
+		Days:         make([]TimesheetDayResponse, len(t.Days)),
+		TotalSeconds: int64(t.Total.Seconds()),
+	}
+	for i, d := range t.Days {
+		day := TimesheetDayResponse{
+			Date:         d.Date.Format(time.DateOnly),
+			Tasks:        make([]TimesheetTaskResponse, len(d.Tasks)),
+			TotalSeconds: int64(d.Total.Seconds()),
+		}
+		for j, task := range d.Tasks {
+			day.Tasks[j] = TimesheetTaskResponse{TaskID: task.TaskID, Title: task.Title, Seconds: int64(task.Duration.Seconds())}
+		}
+		resp.Days[i] = day
+	}
+	return resp
+}
+
+// respondTimeError records errors shared by the endpoints of a task's time. Time on tasks the caller cannot see is
+// reported as a missing task.
+func respondTimeError(c *gin.Context, err error) {
+	fail(c, notFound(err, "task"))
+}
+
+// StartTimer starts the caller's timer on the task. The body is optional.
+func (tc *TimeController) StartTimer(c *gin.Context) {
+	var body StartTimerRequest
+	if c.Request.ContentLength != 0 && !bindJSON(c, &body) {
+		return
+	}
+	entry, err := tc.timeUC.StartTimer(c.Request.Context(), c.Param("id"), body.Note)
+	if err != nil {
+		respondTimeError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, mapToTimeEntryResponse(entry))
+}
+
+// StopTimer stops the caller's timer on the task.
+func (tc *TimeController) StopTimer(c *gin.Context) {
+	entry, err := tc.timeUC.StopTimer(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		respondTimeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, mapToTimeEntryResponse(entry))
+}
+
+// LogTime records time the caller spent on the task without a timer.
+func (tc *TimeController) LogTime(c *gin.Context) {
+	var body LogTimeRequest
+	if !bindJSON(c, &body) {
+		return
+	}
+	entry, err := tc.timeUC.LogTime(c.Request.Context(), c.Param("id"), *body.Start, *body.End, body.Note)
+	if err != nil {
+		respondTimeError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, mapToTimeEntryResponse(entry))
+}
+
+// GetTaskTime lists the time every user logged against the task.
+func (tc *TimeController) GetTaskTime(c *gin.Context) {
+	result, err := tc.timeUC.TaskTime(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		respondTimeError(c, err)
+		return
+	}
+	resp := TaskTimeResponse{Entries: make([]TimeEntryResponse, len(result.Entries)), TotalSeconds: int64(result.Total.Seconds())}
+	for i, e := range result.Entries {
+		resp.Entries[i] = mapToTimeEntryResponse(e)
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// GetTimesheet reports the time the caller logged between the from and to query parameters, as JSON or, with
+// format=csv, as a CSV download with one row per day and task.
+func (tc *TimeController) GetTimesheet(c *gin.Context) {
+	format := c.DefaultQuery("format", "json")
+	if format != "json" && format != "csv" {
+		fail(c, invalidRequest("format must be json or csv"))
+		return
+	}
+	sheet, err := tc.timeUC.Timesheet(c.Request.Context(), c.Query("from"), c.Query("to"))
+	if err != nil {
+		fail(c, signedInUserGone(err))
+		return
+	}
+	if format == "json" {
+		c.JSON(http.StatusOK, mapToTimesheetResponse(sheet))
+		return
+	}
+
+	name := "timesheet-" + sheet.From.Format(time.DateOnly) + "-" + sheet.To.Format(time.DateOnly) + ".csv"
+	c.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": name}))
+	c.Header("Cache-Control", "no-store")
+	c.Status(http.StatusOK)
+	c.Header("Content-Type", "text/csv; charset=utf-8")
+	w := csv.NewWriter(c.Writer)
+	_ = w.Write([]string{"date", "task_id", "task", "hours"})
+	for _, d := range sheet.Days {
+		for _, t := range d.Tasks {
+			_ = w.Write([]string{
+				d.Date.Format(time.DateOnly), t.TaskID, csvText(t.Title), strconv.FormatFloat(t.Duration.Hours(), 'f', 2, 64),
+			})
+		}
+	}
+	w.Flush()
+}
+
+// csvText keeps a user-supplied cell from being evaluated as a formula by spreadsheet applications, by prefixing
+// the characters that start one with a quote.
+func csvText(s string) string {
+	if s != "" && strings.ContainsRune("=+-@\t\r", rune(s[0])) {
+		return "'" + s
+	}
+	return s
+}
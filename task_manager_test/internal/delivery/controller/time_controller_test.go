@@ -0,0 +1,193 @@
+package controller
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"task_manager_test/internal/delivery/middleware"
+	"task_manager_test/internal/domain"
+	"task_manager_test/internal/mocks"
+	"task_manager_test/internal/usecase"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+// TimeControllerTestSuite defines the test suite for the TimeController.
+type TimeControllerTestSuite struct {
+	suite.Suite
+	router      *gin.Engine
+	mockUsecase *mocks.TimeUsecase
+	at          time.Time
+}
+
+// SetupTest runs before each test in the suite, ensuring a clean state.
+func (s *TimeControllerTestSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+	s.mockUsecase = new(mocks.TimeUsecase)
+	tc := NewTimeController(s.mockUsecase)
+	s.at = time.Date(2025, 3, 4, 9, 0, 0, 0, time.UTC)
+
+	s.router = gin.New()
+	s.router.Use(middleware.ErrorHandler())
+	s.router.GET("/tasks/:id/time", tc.GetTaskTime)
+	s.router.POST("/tasks/:id/time", tc.LogTime)
+	s.router.POST("/tasks/:id/time/start", tc.StartTimer)
+	s.router.POST("/tasks/:id/time/stop", tc.StopTimer)
+	s.router.GET("/timesheet", tc.GetTimesheet)
+}
+
+// TestTimeController runs the entire test suite.
+func TestTimeController(t *testing.T) {
+	suite.Run(t, new(TimeControllerTestSuite))
+}
+
+// send performs a request against the suite router, with a JSON body unless body is nil.
+func (s *TimeControllerTestSuite) send(method, path string, body gin.H) *httptest.ResponseRecorder {
+	var buf bytes.Buffer
+	if body != nil {
+		_ = json.NewEncoder(&buf).Encode(body)
+	}
+	req, _ := http.NewRequest(method, path, &buf)
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	return w
+}
+
+// TestStartTimer tests that the body is optional and that a running entry is returned without an end.
+func (s *TimeControllerTestSuite) TestStartTimer() {
+	running := domain.TimeEntry{ID: "e1", TaskID: "task-1", Username: "alice", Start: s.at, CreatedAt: s.at}
+	s.mockUsecase.On("StartTimer", mock.Anything, "task-1", "").Return(running, nil).Once()
+	s.mockUsecase.On("StartTimer", mock.Anything, "task-1", "review").Return(running, nil).Once()
+
+	w := s.send(http.MethodPost, "/tasks/task-1/time/start", nil)
+	s.Equal(http.StatusCreated, w.Code)
+	s.JSONEq(`{"id": "e1", "task_id": "task-1", "user": "alice", "start": "2025-03-04T09:00:00Z", "running": true,
+		"manual": false, "created_at": "2025-03-04T09:00:00Z"}`, w.Body.String())
+
+	s.Equal(http.StatusCreated, s.send(http.MethodPost, "/tasks/task-1/time/start", gin.H{"note": "review"}).Code)
+	s.mockUsecase.AssertExpectations(s.T())
+}
+
+// TestStopTimer tests that a stopped entry carries its end and duration.
+func (s *TimeControllerTestSuite) TestStopTimer() {
+	s.mockUsecase.On("StopTimer", mock.Anything, "task-1").Return(domain.TimeEntry{
+		ID: "e1", TaskID: "task-1", Username: "alice", Start: s.at, End: s.at.Add(90 * time.Minute), CreatedAt: s.at,
+	}, nil).Once()
+
+	w := s.send(http.MethodPost, "/tasks/task-1/time/stop", nil)
+
+	s.Equal(http.StatusOK, w.Code)
+	s.JSONEq(`{"id": "e1", "task_id": "task-1", "user": "alice", "start": "2025-03-04T09:00:00Z",
+		"end": "2025-03-04T10:30:00Z", "duration_seconds": 5400, "running": false, "manual": false,
+		"created_at": "2025-03-04T09:00:00Z"}`, w.Body.String())
+}
+
+// TestTimeController_ErrorMapping tests how usecase errors are translated into HTTP responses.
+func (s *TimeControllerTestSuite) TestTimeController_ErrorMapping() {
+	cases := []struct {
+		name       string
+		path       string
+		err        error
+		wantStatus int
+		wantCode   string
+		wantDetail string
+	}{
+		{"timer running", "/tasks/task-1/time/start", usecase.WithDetail(usecase.ErrTimerRunning, "a timer is already running on task task-9; stop it first"), http.StatusConflict, "timer_running", "a timer is already running on task task-9; stop it first"},
+		{"timer not running", "/tasks/task-1/time/stop", usecase.ErrTimerNotRunning, http.StatusConflict, "timer_not_running", ""},
+		{"hidden task", "/tasks/task-1/time/stop", usecase.ErrNotFound, http.StatusNotFound, "not_found", "task not found"},
+		{"read-only task", "/tasks/task-1/time/start", usecase.ErrForbidden, http.StatusForbidden, "forbidden", ""},
+	}
+	for _, tc := range cases {
+		s.Run(tc.name, func() {
+			s.SetupTest()
+			s.mockUsecase.On("StartTimer", mock.Anything, "task-1", "").Return(domain.TimeEntry{}, tc.err).Maybe()
+			s.mockUsecase.On("StopTimer", mock.Anything, "task-1").Return(domain.TimeEntry{}, tc.err).Maybe()
+
+			w := s.send(http.MethodPost, tc.path, nil)
+
+			assertProblem(s.T(), w, tc.wantStatus, tc.wantCode, tc.wantDetail)
+		})
+	}
+}
+
+// TestLogTime tests that the times and note are passed on, and that start and end are required.
+func (s *TimeControllerTestSuite) TestLogTime() {
+	end := s.at.Add(time.Hour)
+	s.mockUsecase.On("LogTime", mock.Anything, "task-1", s.at, end, "call").Return(domain.TimeEntry{
+		ID: "e1", TaskID: "task-1", Start: s.at, End: end, Manual: true, Note: "call",
+	}, nil).Once()
+
+	w := s.send(http.MethodPost, "/tasks/task-1/time", gin.H{"start": s.at, "end": end, "note": "call"})
+	s.Equal(http.StatusCreated, w.Code)
+	s.Contains(w.Body.String(), `"manual":true`)
+
+	w = s.send(http.MethodPost, "/tasks/task-1/time", gin.H{"start": s.at})
+	assertProblem(s.T(), w, http.StatusBadRequest, "invalid_request", "request body failed validation")
+}
+
+// TestGetTaskTime tests that entries are listed with the total in seconds, and an empty list as an array.
+func (s *TimeControllerTestSuite) TestGetTaskTime() {
+	s.mockUsecase.On("TaskTime", mock.Anything, "task-1").Return(domain.TaskTime{Total: 2 * time.Hour}, nil).Once()
+
+	w := s.send(http.MethodGet, "/tasks/task-1/time", nil)
+
+	s.Equal(http.StatusOK, w.Code)
+	s.JSONEq(`{"entries": [], "total_seconds": 7200}`, w.Body.String())
+}
+
+// timesheet returns a one-day timesheet in Addis Ababa with two tasks.
+func (s *TimeControllerTestSuite) timesheet() domain.Timesheet {
+	loc, _ := time.LoadLocation("Africa/Addis_Ababa")
+	day := time.Date(2025, 3, 4, 0, 0, 0, 0, loc)
+	return domain.Timesheet{
+		Location: loc, From: day, To: day.AddDate(0, 0, 1), Total: 150 * time.Minute,
+		Days: []domain.TimesheetDay{{Date: day, Total: 150 * time.Minute, Tasks: []domain.TimesheetTask{
+			{TaskID: "task-1", Title: "=SUM(A1)", Duration: 90 * time.Minute},
+			{TaskID: "task-2", Title: "Write, review", Duration: time.Hour},
+		}}},
+	}
+}
+
+// TestGetTimesheet_JSON tests the JSON report, with days as dates in the caller's time zone.
+func (s *TimeControllerTestSuite) TestGetTimesheet_JSON() {
+	s.mockUsecase.On("Timesheet", mock.Anything, "2025-03-04", "2025-03-05").Return(s.timesheet(), nil).Once()
+
+	w := s.send(http.MethodGet, "/timesheet?from=2025-03-04&to=2025-03-05", nil)
+
+	s.Equal(http.StatusOK, w.Code)
+	s.JSONEq(`{"time_zone": "Africa/Addis_Ababa", "from": "2025-03-04", "to": "2025-03-05", "total_seconds": 9000,
+		"days": [{"date": "2025-03-04", "total_seconds": 9000, "tasks": [
+			{"task_id": "task-1", "title": "=SUM(A1)", "seconds": 5400},
+			{"task_id": "task-2", "title": "Write, review", "seconds": 3600}
+		]}]}`, w.Body.String())
+}
+
+// TestGetTimesheet_CSV tests the CSV download, whose titles cannot be evaluated as formulas.
+func (s *TimeControllerTestSuite) TestGetTimesheet_CSV() {
+	s.mockUsecase.On("Timesheet", mock.Anything, "2025-03-04", "2025-03-05").Return(s.timesheet(), nil).Once()
+
+	w := s.send(http.MethodGet, "/timesheet?from=2025-03-04&to=2025-03-05&format=csv", nil)
+
+	s.Equal(http.StatusOK, w.Code)
+	s.Equal("text/csv; charset=utf-8", w.Header().Get("Content-Type"))
+	s.Equal("attachment; filename=timesheet-2025-03-04-2025-03-05.csv", w.Header().Get("Content-Disposition"))
+	s.Equal("date,task_id,task,hours\n"+
+		"2025-03-04,task-1,'=SUM(A1),1.50\n"+
+		"2025-03-04,task-2,\"Write, review\",1.00\n", w.Body.String())
+}
+
+// TestGetTimesheet_Errors tests the rejection of unknown formats and invalid ranges.
+func (s *TimeControllerTestSuite) TestGetTimesheet_Errors() {
+	assertProblem(s.T(), s.send(http.MethodGet, "/timesheet?format=xml", nil), http.StatusBadRequest, "invalid_request", "format must be json or csv")
+
+	s.mockUsecase.On("Timesheet", mock.Anything, "2025-03-05", "2025-03-04").
+		Return(domain.Timesheet{}, &usecase.TimeEntryRequestError{Reason: "to must not be before from"}).Once()
+	w := s.send(http.MethodGet, "/timesheet?from=2025-03-05&to=2025-03-04", nil)
+	assertProblem(s.T(), w, http.StatusBadRequest, "invalid_time_entry_request", "to must not be before from")
+}
@@ -19,29 +19,61 @@ func NewUserController(u usecase.UserUsecase) *UserController {
 	return &UserController{userUC: u}
 }
 
+// RegisterRequest is the body accepted when registering. Role defaults to member.
+type RegisterRequest struct {
+	Username string `json:"username" binding:"required"`
+	Password string `json:"password" binding:"required"`
+	Role     string `json:"role"`
+}
+
+// LoginRequest is the body accepted when logging in.
+type LoginRequest struct {
+	Username string `json:"username" binding:"required"`
+	Password string `json:"password" binding:"required"`
+}
+
+// RoleRequest is the body accepted when changing a user's role.
+type RoleRequest struct {
+	Role string `json:"role" binding:"required"`
+}
+
+// MeResponse describes the caller's own account.
+type MeResponse struct {
+	ID       string `json:"id"`
+	Username string `json:"username"`
+	Role     string `json:"role"`
+	OrgID    string `json:"org_id"`
+	// TimeZone is the IANA name of the caller's time zone; empty means UTC.
+	TimeZone string `json:"time_zone"`
+}
+
+// mapToMeResponse converts a domain.User into a MeResponse.
+func mapToMeResponse(u domain.User) MeResponse {
+	return MeResponse{ID: u.ID, Username: u.Username, Role: string(u.Role), OrgID: u.OrgID, TimeZone: u.TimeZone}
+}
+
+// MeRequest is the body accepted when changing the caller's settings.
+type MeRequest struct {
+	// TimeZone is an IANA time zone name such as "Africa/Addis_Ababa"; the empty string resets it to UTC.
+	TimeZone *string `json:"time_zone" binding:"required"`
+}
+
 // Register handles new user registration requests.
 func (uc *UserController) Register(c *gin.Context) {
-	var body struct {
-		Username string `json:"username" binding:"required"`
-		Password string `json:"password" binding:"required"`
-		Role     string `json:"role" binding:"required"`
-	}
-	if err := c.ShouldBindJSON(&body); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	var body RegisterRequest
+	if !bindJSON(c, &body) {
 		return
 	}
 	user := domain.User{
 		Username: body.Username,
 		Password: body.Password,
-		Role:     body.Role,
+		Role:     domain.Role(body.Role),
 	}
 	if err := uc.userUC.Register(c.Request.Context(), user); err != nil {
-		switch {
-		case errors.Is(err, usecase.ErrUserAlreadyExists):
-			c.JSON(http.StatusConflict, gin.H{"error": "a user with this username already exists"})
-		default:
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "could not register user"})
+		if errors.Is(err, usecase.ErrForbidden) {
+			err = usecase.WithDetail(err, "registering with this role requires the user.manage permission")
 		}
+		fail(c, err)
 		return
 	}
 	c.JSON(http.StatusCreated, gin.H{"message": "User Registered successfully"})
@@ -49,23 +81,76 @@ func (uc *UserController) Register(c *gin.Context) {
 
 // Login handles user authentication.
 func (uc *UserController) Login(c *gin.Context) {
-	var body struct {
-		Username string `json:"username" binding:"required"`
-		Password string `json:"password" binding:"required"`
-	}
-	if err := c.ShouldBindJSON(&body); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	var body LoginRequest
+	if !bindJSON(c, &body) {
 		return
 	}
 	token, err := uc.userUC.Login(c.Request.Context(), body.Username, body.Password)
 	if err != nil {
-		switch {
-		case errors.Is(err, usecase.ErrNotFound), errors.Is(err, usecase.ErrInvalidCredentials):
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid username or password"})
-		default:
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "an internal server error occurred"})
+		// Unknown usernames are reported like wrong passwords so that logins cannot probe which accounts exist.
+		if errors.Is(err, usecase.ErrNotFound) || errors.Is(err, usecase.ErrInvalidCredentials) {
+			err = usecase.WithDetail(usecase.ErrInvalidCredentials, "invalid username or password")
 		}
+		fail(c, err)
 		return
 	}
 	c.JSON(http.StatusOK, gin.H{"token": token})
 }
+
+// CreateUser registers a user in the caller's organization, with any role the caller may grant.
+func (uc *UserController) CreateUser(c *gin.Context) {
+	var body RegisterRequest
+	if !bindJSON(c, &body) {
+		return
+	}
+	user := domain.User{
+		Username: body.Username,
+		Password: body.Password,
+		Role:     domain.Role(body.Role),
+	}
+	if err := uc.userUC.Register(c.Request.Context(), user); err != nil {
+		if errors.Is(err, usecase.ErrForbidden) {
+			err = usecase.WithDetail(err, "only a super admin can create super admins")
+		}
+		fail(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{"message": "User created"})
+}
+
+// ChangeRole assigns a new role to the user named in the URL.
+func (uc *UserController) ChangeRole(c *gin.Context) {
+	var body RoleRequest
+	if !bindJSON(c, &body) {
+		return
+	}
+	if err := uc.userUC.ChangeRole(c.Request.Context(), c.Param("username"), domain.Role(body.Role)); err != nil {
+		fail(c, notFound(err, "user"))
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Role updated"})
+}
+
+// GetMe returns the caller's account.
+func (uc *UserController) GetMe(c *gin.Context) {
+	usr, err := uc.userUC.Me(c.Request.Context())
+	if err != nil {
+		fail(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, mapToMeResponse(usr))
+}
+
+// UpdateMe changes the caller's settings.
+func (uc *UserController) UpdateMe(c *gin.Context) {
+	var body MeRequest
+	if !bindJSON(c, &body) {
+		return
+	}
+	usr, err := uc.userUC.SetTimeZone(c.Request.Context(), *body.TimeZone)
+	if err != nil {
+		fail(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, mapToMeResponse(usr))
+}
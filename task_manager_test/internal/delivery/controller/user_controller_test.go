@@ -6,10 +6,13 @@ import (
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
+	"task_manager_test/internal/delivery/middleware"
 	"task_manager_test/internal/domain"
 	"task_manager_test/internal/mocks"
 	"task_manager_test/internal/usecase"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/mock"
@@ -34,10 +37,15 @@ func (s *UserControllerTestSuite) SetupTest() {
 
 	// Set up the router and define user routes
 	s.router = gin.Default()
+	s.router.Use(middleware.ErrorHandler())
 	userRoutes := s.router.Group("/users")
 	{
 		userRoutes.POST("/register", s.userController.Register)
 		userRoutes.POST("/login", s.userController.Login)
+		userRoutes.PUT("/:username/role", s.userController.ChangeRole)
+		userRoutes.POST("", s.userController.CreateUser)
+		userRoutes.GET("/me", s.userController.GetMe)
+		userRoutes.PATCH("/me", s.userController.UpdateMe)
 	}
 }
 
@@ -98,8 +106,7 @@ func (s *UserControllerTestSuite) TestRegister_Conflict() {
 	s.router.ServeHTTP(w, req)
 
 	// Assert
-	s.Equal(http.StatusConflict, w.Code)
-	s.JSONEq(`{"error": "a user with this username already exists"}`, w.Body.String())
+	assertProblem(s.T(), w, http.StatusConflict, "user_exists", "")
 	s.mockUsecase.AssertExpectations(s.T())
 }
 
@@ -118,11 +125,38 @@ func (s *UserControllerTestSuite) TestRegister_InternalError() {
 	s.router.ServeHTTP(w, req)
 
 	// Assert
-	s.Equal(http.StatusInternalServerError, w.Code)
-	s.JSONEq(`{"error": "could not register user"}`, w.Body.String())
+	assertProblem(s.T(), w, http.StatusInternalServerError, "internal", "")
 	s.mockUsecase.AssertExpectations(s.T())
 }
 
+// TestRegister_RoleErrors tests how role-related usecase errors are translated into HTTP responses.
+func (s *UserControllerTestSuite) TestRegister_RoleErrors() {
+	cases := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantCode   string
+		wantDetail string
+	}{
+		{"unknown role", usecase.ErrUnknownRole, http.StatusBadRequest, "unknown_role", ""},
+		{"privileged role", usecase.ErrForbidden, http.StatusForbidden, "forbidden", "registering with this role requires the user.manage permission"},
+	}
+	for _, tc := range cases {
+		s.Run(tc.name, func() {
+			s.SetupTest()
+			s.mockUsecase.On("Register", mock.Anything, domain.User{Username: "eve", Password: "password123", Role: domain.RoleAdmin}).Return(tc.err).Once()
+
+			body, _ := json.Marshal(gin.H{"username": "eve", "password": "password123", "role": "admin"})
+			req, _ := http.NewRequest(http.MethodPost, "/users/register", bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			s.router.ServeHTTP(w, req)
+
+			assertProblem(s.T(), w, tc.wantStatus, tc.wantCode, tc.wantDetail)
+		})
+	}
+}
+
 //--- Login Endpoint Tests ---//
 
 // TestLogin_Success tests a successful user login.
@@ -173,8 +207,7 @@ func (s *UserControllerTestSuite) TestLogin_Unauthorized_NotFound() {
 	s.router.ServeHTTP(w, req)
 
 	// Assert
-	s.Equal(http.StatusUnauthorized, w.Code)
-	s.JSONEq(`{"error": "invalid username or password"}`, w.Body.String())
+	assertProblem(s.T(), w, http.StatusUnauthorized, "invalid_credentials", "invalid username or password")
 	s.mockUsecase.AssertExpectations(s.T())
 }
 
@@ -191,8 +224,28 @@ func (s *UserControllerTestSuite) TestLogin_Unauthorized_InvalidCredentials() {
 	s.router.ServeHTTP(w, req)
 
 	// Assert
-	s.Equal(http.StatusUnauthorized, w.Code)
-	s.JSONEq(`{"error": "invalid username or password"}`, w.Body.String())
+	assertProblem(s.T(), w, http.StatusUnauthorized, "invalid_credentials", "invalid username or password")
+	s.mockUsecase.AssertExpectations(s.T())
+}
+
+// TestLogin_TooManyRequests_AccountLocked tests that a locked account is reported with 429 and a Retry-After header.
+func (s *UserControllerTestSuite) TestLogin_TooManyRequests_AccountLocked() {
+	// Arrange
+	lockErr := &usecase.AccountLockedError{Until: time.Now().Add(90 * time.Second)}
+	s.mockUsecase.On("Login", mock.Anything, "testuser", "password123").Return("", lockErr).Once()
+
+	// Act
+	body, _ := json.Marshal(gin.H{"username": "testuser", "password": "password123"})
+	req, _ := http.NewRequest(http.MethodPost, "/users/login", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+
+	// Assert
+	assertProblem(s.T(), w, http.StatusTooManyRequests, "account_locked", "account temporarily locked due to too many failed password attempts")
+	retryAfter, err := strconv.Atoi(w.Header().Get("Retry-After"))
+	s.NoError(err, "Retry-After should be a number of seconds")
+	s.InDelta(90, retryAfter, 2)
 	s.mockUsecase.AssertExpectations(s.T())
 }
 
@@ -210,7 +263,118 @@ func (s *UserControllerTestSuite) TestLogin_InternalError() {
 	s.router.ServeHTTP(w, req)
 
 	// Assert
-	s.Equal(http.StatusInternalServerError, w.Code)
-	s.JSONEq(`{"error": "an internal server error occurred"}`, w.Body.String())
+	assertProblem(s.T(), w, http.StatusInternalServerError, "internal", "")
+	s.mockUsecase.AssertExpectations(s.T())
+}
+
+//--- CreateUser Endpoint Tests ---//
+
+// TestCreateUser tests that administrators create users with a role and learn why super admins are refused.
+func (s *UserControllerTestSuite) TestCreateUser() {
+	s.mockUsecase.On("Register", mock.Anything, domain.User{Username: "mia", Password: "password123", Role: domain.RoleManager}).Return(nil).Once()
+	s.mockUsecase.On("Register", mock.Anything, domain.User{Username: "eve", Password: "password123", Role: domain.RoleSuperAdmin}).Return(usecase.ErrForbidden).Once()
+
+	post := func(username, role string) *httptest.ResponseRecorder {
+		body, _ := json.Marshal(gin.H{"username": username, "password": "password123", "role": role})
+		req, _ := http.NewRequest(http.MethodPost, "/users", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		s.router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := post("mia", "manager")
+	s.Equal(http.StatusCreated, w.Code)
+	s.JSONEq(`{"message": "User created"}`, w.Body.String())
+
+	assertProblem(s.T(), post("eve", "super_admin"), http.StatusForbidden, "forbidden", "only a super admin can create super admins")
+	s.mockUsecase.AssertExpectations(s.T())
+}
+
+//--- Me Endpoint Tests ---//
+
+// TestGetMe tests that the caller's account and time zone are returned.
+func (s *UserControllerTestSuite) TestGetMe() {
+	s.mockUsecase.On("Me", mock.Anything).Return(domain.User{ID: "user-1", Username: "alice", Role: domain.RoleMember, OrgID: "org-1"}, nil).Once()
+
+	req, _ := http.NewRequest(http.MethodGet, "/users/me", nil)
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+
+	s.Equal(http.StatusOK, w.Code)
+	s.JSONEq(`{"id":"user-1","username":"alice","role":"member","org_id":"org-1","time_zone":""}`, w.Body.String())
+}
+
+// TestUpdateMe tests setting the time zone, and that it must be present in the body.
+func (s *UserControllerTestSuite) TestUpdateMe() {
+	s.mockUsecase.On("SetTimeZone", mock.Anything, "Africa/Addis_Ababa").
+		Return(domain.User{ID: "user-1", Username: "alice", Role: domain.RoleMember, TimeZone: "Africa/Addis_Ababa"}, nil).Once()
+	s.mockUsecase.On("SetTimeZone", mock.Anything, "Nowhere").
+		Return(domain.User{}, usecase.WithDetail(usecase.ErrInvalidTimeZone, `"Nowhere" is not an IANA time zone name`)).Once()
+
+	patch := func(body string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest(http.MethodPatch, "/users/me", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		s.router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := patch(`{"time_zone":"Africa/Addis_Ababa"}`)
+	s.Equal(http.StatusOK, w.Code)
+	s.JSONEq(`{"id":"user-1","username":"alice","role":"member","org_id":"","time_zone":"Africa/Addis_Ababa"}`, w.Body.String())
+
+	assertProblem(s.T(), patch(`{"time_zone":"Nowhere"}`), http.StatusBadRequest, "invalid_time_zone", `"Nowhere" is not an IANA time zone name`)
+	s.Equal(http.StatusBadRequest, patch(`{}`).Code)
+	s.mockUsecase.AssertExpectations(s.T())
+}
+
+//--- ChangeRole Endpoint Tests ---//
+
+// TestChangeRole_Success tests a successful role change.
+func (s *UserControllerTestSuite) TestChangeRole_Success() {
+	// Arrange
+	s.mockUsecase.On("ChangeRole", mock.Anything, "alice", domain.RoleManager).Return(nil).Once()
+
+	// Act
+	body, _ := json.Marshal(gin.H{"role": "manager"})
+	req, _ := http.NewRequest(http.MethodPut, "/users/alice/role", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+
+	// Assert
+	s.Equal(http.StatusOK, w.Code)
+	s.JSONEq(`{"message": "Role updated"}`, w.Body.String())
 	s.mockUsecase.AssertExpectations(s.T())
 }
+
+// TestChangeRole_ErrorMapping tests how usecase errors are translated into HTTP responses.
+func (s *UserControllerTestSuite) TestChangeRole_ErrorMapping() {
+	cases := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantCode   string
+		wantDetail string
+	}{
+		{"unknown role", usecase.ErrUnknownRole, http.StatusBadRequest, "unknown_role", ""},
+		{"not allowed", usecase.ErrForbidden, http.StatusForbidden, "forbidden", ""},
+		{"no such user", usecase.ErrNotFound, http.StatusNotFound, "not_found", "user not found"},
+		{"database down", errors.New("database down"), http.StatusInternalServerError, "internal", ""},
+	}
+	for _, tc := range cases {
+		s.Run(tc.name, func() {
+			s.SetupTest()
+			s.mockUsecase.On("ChangeRole", mock.Anything, "alice", domain.RoleAdmin).Return(tc.err).Once()
+
+			body, _ := json.Marshal(gin.H{"role": "admin"})
+			req, _ := http.NewRequest(http.MethodPut, "/users/alice/role", bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			s.router.ServeHTTP(w, req)
+
+			assertProblem(s.T(), w, tc.wantStatus, tc.wantCode, tc.wantDetail)
+		})
+	}
+}
@@ -1,48 +1,121 @@
 package middleware
 
 import (
-	"net/http"
+	"errors"
 	"strings"
+	"task_manager_test/internal/domain"
 	"task_manager_test/internal/usecase"
 
 	"github.com/gin-gonic/gin"
 )
 
-// AuthMiddleware is a middleware function that checks for a valid JWT token in the Authorization header.
-func AuthMiddleware(jwtSvc usecase.IJWTService) gin.HandlerFunc {
+// Values stored under the "auth_method" context key.
+const (
+	AuthMethodSession     = "session"
+	AuthMethodAccessToken = "access_token"
+)
+
+// invalidToken reports a bearer token that is malformed, expired or no longer belongs to a user.
+var invalidToken = usecase.WithDetail(usecase.ErrUnauthenticated, "invalid or expired token")
+
+// abortWithError records err for ErrorHandler and stops the handler chain.
+func abortWithError(c *gin.Context, err error) {
+	_ = c.Error(err)
+	c.Abort()
+}
+
+// AuthMiddleware is a middleware function that checks for a valid bearer token in the Authorization header.
+// The token may be a JWT from /login, whose session must not have been revoked, or a personal access token.
+// Requests authenticated with an access token also carry its scopes under the "scopes" context key.
+func AuthMiddleware(jwtSvc usecase.IJWTService, sessions usecase.ISessionValidator, accessTokens usecase.IAccessTokenAuthenticator) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		auth := c.GetHeader("Authorization")
 		if !strings.HasPrefix(auth, "Bearer ") {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "missing token"})
-			c.Abort()
+			abortWithError(c, usecase.WithDetail(usecase.ErrUnauthenticated, "missing bearer token"))
 			return
 		}
 		tokenStr := strings.TrimPrefix(auth, "Bearer ")
+		if strings.HasPrefix(tokenStr, usecase.AccessTokenPrefix) && accessTokens != nil {
+			authenticateAccessToken(c, accessTokens, tokenStr)
+			return
+		}
 		claims, err := jwtSvc.ValidateToken(tokenStr)
 		if err != nil {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid or expired token"})
-			c.Abort()
+			abortWithError(c, invalidToken)
+			return
+		}
+		userID, _ := claims["sub"].(string)
+		username, _ := claims["username"].(string)
+		// Every query is confined to the token's organization, so tokens issued before organizations existed are
+		// refused and their holders must log in again.
+		orgID, _ := claims["org"].(string)
+		if orgID == "" {
+			abortWithError(c, invalidToken)
 			return
 		}
-		// Set username and role
-		if username, ok := claims["username"].(string); ok {
-			c.Set("username", username)
+		// Tokens issued before versioning carry no "ver" claim and are treated as version 0.
+		version, _ := claims["ver"].(float64)
+		if err := sessions.ValidateSession(c.Request.Context(), userID, int(version)); err != nil {
+			switch {
+			case errors.Is(err, usecase.ErrSessionRevoked):
+				err = usecase.WithDetail(err, "session has been revoked, please log in again")
+			case errors.Is(err, usecase.ErrNotFound):
+				err = invalidToken
+			}
+			abortWithError(c, err)
+			return
 		}
-		if role, ok := claims["role"].(string); ok {
-			c.Set("role", role)
+
+		// Set user ID, username, role and organization, and make them available to the use cases as the request's
+		// actor.
+		role, _ := claims["role"].(string)
+		setActor(c, usecase.Actor{UserID: userID, Username: username, Role: domain.Role(role), OrgID: orgID}, AuthMethodSession)
+		c.Next()
+	}
+}
+
+// authenticateAccessToken resolves a personal access token and sets the same context keys as a JWT, plus its scopes.
+func authenticateAccessToken(c *gin.Context, accessTokens usecase.IAccessTokenAuthenticator, tokenStr string) {
+	token, usr, err := accessTokens.Authenticate(c.Request.Context(), tokenStr)
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+	setActor(c, usecase.Actor{UserID: usr.ID, Username: usr.Username, Role: usr.Role, OrgID: usr.OrgID}, AuthMethodAccessToken)
+	c.Set("scopes", token.Scopes)
+	c.Next()
+}
+
+// setActor records the authenticated user in the gin context and in the request context.
+func setActor(c *gin.Context, actor usecase.Actor, method string) {
+	if actor.UserID != "" {
+		c.Set("user_id", actor.UserID)
+	}
+	c.Set("username", actor.Username)
+	c.Set("role", string(actor.Role))
+	c.Set("org_id", actor.OrgID)
+	c.Set("auth_method", method)
+	c.Request = c.Request.WithContext(usecase.WithActor(c.Request.Context(), actor))
+}
+
+// RequireScope rejects requests made with a personal access token that was not granted the scope.
+// Requests authenticated with a JWT are not restricted by scopes.
+func RequireScope(scope string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.GetString("auth_method") == AuthMethodAccessToken && !usecase.HasScope(c.GetStringSlice("scopes"), scope) {
+			abortWithError(c, usecase.WithDetail(usecase.ErrInsufficientScope, "token is missing the required scope: "+scope))
+			return
 		}
 		c.Next()
 	}
 }
 
-// AdminOnly is a middleware function that restricts access to only users with the "admin" role.
-func AdminOnly() gin.HandlerFunc {
+// RequireSession rejects requests made with a personal access token, for account operations such as changing the
+// password or managing tokens that must only be performed after an interactive login.
+func RequireSession() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		roleIfc, exists := c.Get("role")
-		role, _ := roleIfc.(string)
-		if !exists || role != "admin" {
-			c.JSON(http.StatusForbidden, gin.H{"error": "admin access required"})
-			c.Abort()
+		if c.GetString("auth_method") == AuthMethodAccessToken {
+			abortWithError(c, usecase.WithDetail(usecase.ErrForbidden, "this endpoint cannot be used with an access token"))
 			return
 		}
 		c.Next()
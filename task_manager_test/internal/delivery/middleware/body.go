@@ -0,0 +1,32 @@
+package middleware
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"task_manager_test/internal/usecase"
+
+	"github.com/gin-gonic/gin"
+)
+
+// MaxBodyBytes bounds the request bodies middleware reads into memory before the handler runs. JSON requests are far
+// smaller; uploads are never read this way.
+const MaxBodyBytes = 1 << 20
+
+// readBody reads the request body, up to MaxBodyBytes, and hands the handler an unread copy of it. Larger bodies are
+// reported as ErrRequestTooLarge.
+func readBody(c *gin.Context) ([]byte, error) {
+	if c.Request.Body == nil {
+		return nil, nil
+	}
+	body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, MaxBodyBytes))
+	if err != nil {
+		if maxErr := new(http.MaxBytesError); errors.As(err, &maxErr) {
+			return nil, usecase.WithDetail(usecase.ErrRequestTooLarge, "request bodies are limited to 1 MiB")
+		}
+		return nil, usecase.WithDetail(usecase.ErrInvalidRequest, "request body could not be read")
+	}
+	c.Request.Body = io.NopCloser(bytes.NewReader(body))
+	return body, nil
+}
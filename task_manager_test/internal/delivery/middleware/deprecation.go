@@ -0,0 +1,40 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Deprecation describes a deprecated API version.
+type Deprecation struct {
+	// Since is when the version was deprecated.
+	Since time.Time
+	// Sunset is when the version stops being served; zero when no date has been set.
+	Sunset time.Time
+	// Successor is the path of the version that replaces it, if any.
+	Successor string
+}
+
+// Deprecated marks every response as coming from a deprecated API version. It sets the Deprecation header of
+// RFC 9745, the Sunset header of RFC 8594 once a sunset date is set, and a Link to the successor version.
+func Deprecated(d Deprecation) gin.HandlerFunc {
+	deprecation := "@" + strconv.FormatInt(d.Since.Unix(), 10)
+	var sunset string
+	if !d.Sunset.IsZero() {
+		sunset = d.Sunset.UTC().Format(http.TimeFormat)
+	}
+	return func(c *gin.Context) {
+		h := c.Writer.Header()
+		h.Set("Deprecation", deprecation)
+		if sunset != "" {
+			h.Set("Sunset", sunset)
+		}
+		if d.Successor != "" {
+			h.Add("Link", "<"+d.Successor+`>; rel="successor-version"`)
+		}
+		c.Next()
+	}
+}
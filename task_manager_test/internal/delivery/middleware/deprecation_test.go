@@ -0,0 +1,42 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeprecated(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	since := time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)
+
+	for name, tc := range map[string]struct {
+		d          Deprecation
+		wantSunset string
+		wantLink   string
+	}{
+		"with sunset": {
+			d:          Deprecation{Since: since, Sunset: since.AddDate(0, 6, 0), Successor: "/api/v2"},
+			wantSunset: "Sun, 18 Apr 2027 00:00:00 GMT",
+			wantLink:   `</api/v2>; rel="successor-version"`,
+		},
+		"without sunset": {d: Deprecation{Since: since}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			r := gin.New()
+			r.Use(Deprecated(tc.d))
+			r.GET("/tasks", func(c *gin.Context) { c.Status(http.StatusNoContent) })
+			w := httptest.NewRecorder()
+
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/tasks", nil))
+
+			assert.Equal(t, "@1792281600", w.Header().Get("Deprecation"))
+			assert.Equal(t, tc.wantSunset, w.Header().Get("Sunset"))
+			assert.Equal(t, tc.wantLink, w.Header().Get("Link"))
+		})
+	}
+}
@@ -0,0 +1,215 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"task_manager_test/internal/domain"
+	"task_manager_test/internal/usecase"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// IdempotencyKeyHeader carries a client-chosen key that makes retries of a request safe.
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// IdempotentReplayedHeader marks a response replayed from the first request made with the same key.
+const IdempotentReplayedHeader = "Idempotent-Replayed"
+
+// maxIdempotencyKeyLength bounds the length of an idempotency key; UUIDs and similar keys fit easily.
+const maxIdempotencyKeyLength = 255
+
+// idempotencyLockTTL bounds how long a key stays held by a request that never completes, such as one interrupted by
+// a server restart. The reservation is renewed while the request is being handled, and completed requests are kept
+// for the configured TTL instead.
+const idempotencyLockTTL = time.Minute
+
+// idempotencyRenewInterval is how often the reservation of a request being handled is renewed, so that a renewal
+// failing now and then does not let it expire.
+const idempotencyRenewInterval = idempotencyLockTTL / 3
+
+// volatileHeaders are response headers that describe the request rather than its result, so they are not stored for
+// replay: the retry gets its own request ID and rate limit state.
+var volatileHeaders = []string{RequestIDHeader, "RateLimit-Limit", "RateLimit-Remaining", "RateLimit-Reset"}
+
+// IdempotencyKeys remembers the responses to requests made with an Idempotency-Key, per user, so that retries of a
+// request are answered with its first response instead of being applied again.
+type IdempotencyKeys struct {
+	store      usecase.IIdempotencyRepository
+	ttl        time.Duration
+	renewEvery time.Duration
+	now        func() time.Time
+}
+
+// NewIdempotencyKeys keeps responses in store for ttl after their request completed.
+func NewIdempotencyKeys(store usecase.IIdempotencyRepository, ttl time.Duration) *IdempotencyKeys {
+	return &IdempotencyKeys{store: store, ttl: ttl, renewEvery: idempotencyRenewInterval, now: time.Now}
+}
+
+// Idempotent makes requests carrying an Idempotency-Key safe to retry. The first request with a key is handled and
+// its response stored; retries with the same key and request are answered with the stored response, marked with
+// Idempotent-Replayed. A retry arriving while the first request is still being handled gets 409, and a key sent
+// again with a different method, path, query string or body gets 400. Bodies larger than MaxBodyBytes get 413.
+//
+// Only successful responses are stored. When the handler fails the key is released, so the client may retry the
+// request with it. Requests without the header, and every request when k is nil, pass through. The middleware must
+// run after AuthMiddleware, since keys are scoped to the authenticated user.
+func Idempotent(k *IdempotencyKeys) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(IdempotencyKeyHeader)
+		if k == nil || key == "" {
+			c.Next()
+			return
+		}
+		if !validIdempotencyKey(key) {
+			abortWithError(c, usecase.WithDetail(usecase.ErrInvalidIdempotencyKey, "keys are up to 255 printable ASCII characters"))
+			return
+		}
+		userID := c.GetString("user_id")
+		if userID == "" {
+			userID = AuthenticatedUserKey(c)
+		}
+
+		body, err := readBody(c)
+		if err != nil {
+			abortWithError(c, err)
+			return
+		}
+
+		now := k.now()
+		rec := domain.IdempotencyRecord{
+			UserID:      userID,
+			Key:         key,
+			RequestHash: requestHash(userID, c.Request.Method, c.Request.URL.RequestURI(), body),
+			CreatedAt:   now,
+			ExpiresAt:   now.Add(idempotencyLockTTL),
+		}
+		held, created, err := k.store.Reserve(c.Request.Context(), rec, now)
+		if err != nil {
+			abortWithError(c, err)
+			return
+		}
+		if !created {
+			switch {
+			case held.RequestHash != rec.RequestHash:
+				abortWithError(c, usecase.WithDetail(usecase.ErrIdempotencyKeyReused, "use a new key for a new request"))
+			case !held.Completed:
+				c.Header("Retry-After", "1")
+				abortWithError(c, usecase.WithDetail(usecase.ErrIdempotencyKeyInUse, "retry once the first request has completed"))
+			default:
+				replay(c, held)
+			}
+			return
+		}
+
+		w := &recordingWriter{ResponseWriter: c.Writer}
+		c.Writer = w
+		stop := k.hold(c.Request.Context(), rec)
+		c.Next()
+		stop()
+
+		// The outcome is recorded even if the client has gone away, since that is when it will retry.
+		ctx := context.WithoutCancel(c.Request.Context())
+		if len(c.Errors) > 0 || !w.Written() || w.Status() >= http.StatusInternalServerError {
+			// Should the release fail, the key is held until the reservation expires.
+			_ = k.store.Release(ctx, rec)
+			return
+		}
+		rec.Completed = true
+		rec.Status = w.Status()
+		header := w.Header().Clone()
+		for _, name := range volatileHeaders {
+			header.Del(name)
+		}
+		rec.Header = header
+		rec.Body = w.body.Bytes()
+		rec.ExpiresAt = k.now().Add(k.ttl)
+		if err := k.store.Complete(ctx, rec); err != nil {
+			// The response has been sent, so the failure is only logged by gin.
+			_ = c.Error(err).SetType(gin.ErrorTypePrivate)
+		}
+	}
+}
+
+// hold renews the reservation of rec every renewEvery until the returned function is called, so that the key stays
+// held however long the request takes. Renewal continues if the client goes away, since the handler still runs. The
+// returned function waits for a renewal in progress to finish, so none lands after the request's outcome is recorded.
+func (k *IdempotencyKeys) hold(ctx context.Context, rec domain.IdempotencyRecord) func() {
+	ctx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(k.renewEvery)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				rec.ExpiresAt = k.now().Add(idempotencyLockTTL)
+				// Other failures are retried on the next tick, before the reservation expires.
+				if err := k.store.Renew(ctx, rec); errors.Is(err, usecase.ErrNotFound) {
+					return
+				}
+			}
+		}
+	}()
+	return func() {
+		cancel()
+		<-done
+	}
+}
+
+// validIdempotencyKey accepts keys of printable ASCII up to maxIdempotencyKeyLength characters.
+func validIdempotencyKey(key string) bool {
+	if len(key) > maxIdempotencyKeyLength {
+		return false
+	}
+	for _, r := range key {
+		if r < 0x21 || r > 0x7e {
+			return false
+		}
+	}
+	return true
+}
+
+// requestHash identifies a request by the user making it, its method, path with query string, and body.
+func requestHash(userID, method, uri string, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(userID + "\n" + method + " " + uri + "\n"))
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// replay writes the stored response and stops the request.
+func replay(c *gin.Context, rec domain.IdempotencyRecord) {
+	for name, values := range rec.Header {
+		c.Writer.Header()[name] = values
+	}
+	c.Header(IdempotentReplayedHeader, "true")
+	c.Writer.WriteHeader(rec.Status)
+	_, _ = c.Writer.Write(rec.Body)
+	c.Abort()
+}
+
+// recordingWriter keeps a copy of the response body written through it.
+type recordingWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+// Write writes b to the response and the copy.
+func (w *recordingWriter) Write(b []byte) (int, error) {
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+// WriteString writes s to the response and the copy.
+func (w *recordingWriter) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
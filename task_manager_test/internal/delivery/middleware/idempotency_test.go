@@ -0,0 +1,308 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"task_manager_test/internal/domain"
+	"task_manager_test/internal/usecase"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+)
+
+// memoryIdempotencyStore is an in-memory IIdempotencyRepository with the semantics of the MongoDB one.
+type memoryIdempotencyStore struct {
+	mu       sync.Mutex
+	records  map[string]domain.IdempotencyRecord
+	renewals int
+}
+
+// Reserve implements usecase.IIdempotencyRepository.
+func (m *memoryIdempotencyStore) Reserve(_ context.Context, r domain.IdempotencyRecord, now time.Time) (domain.IdempotencyRecord, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if held, ok := m.records[r.UserID+"/"+r.Key]; ok && held.ExpiresAt.After(now) {
+		return held, false, nil
+	}
+	m.records[r.UserID+"/"+r.Key] = r
+	return r, true, nil
+}
+
+// Complete implements usecase.IIdempotencyRepository.
+func (m *memoryIdempotencyStore) Complete(_ context.Context, r domain.IdempotencyRecord) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if !m.reserved(r) {
+		return usecase.ErrNotFound
+	}
+	m.records[r.UserID+"/"+r.Key] = r
+	return nil
+}
+
+// Renew implements usecase.IIdempotencyRepository.
+func (m *memoryIdempotencyStore) Renew(_ context.Context, r domain.IdempotencyRecord) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if !m.reserved(r) {
+		return usecase.ErrNotFound
+	}
+	held := m.records[r.UserID+"/"+r.Key]
+	held.ExpiresAt = r.ExpiresAt
+	m.records[r.UserID+"/"+r.Key] = held
+	m.renewals++
+	return nil
+}
+
+// Release implements usecase.IIdempotencyRepository.
+func (m *memoryIdempotencyStore) Release(_ context.Context, r domain.IdempotencyRecord) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.reserved(r) {
+		delete(m.records, r.UserID+"/"+r.Key)
+	}
+	return nil
+}
+
+// reserved reports whether the key is held by the incomplete reservation of r's request.
+func (m *memoryIdempotencyStore) reserved(r domain.IdempotencyRecord) bool {
+	held, ok := m.records[r.UserID+"/"+r.Key]
+	return ok && !held.Completed && held.RequestHash == r.RequestHash && held.CreatedAt.Equal(r.CreatedAt)
+}
+
+// renewed returns how many times reservations were renewed.
+func (m *memoryIdempotencyStore) renewed() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.renewals
+}
+
+// IdempotencyTestSuite defines the test suite for the Idempotency-Key middleware.
+type IdempotencyTestSuite struct {
+	suite.Suite
+	router  *gin.Engine
+	keys    *IdempotencyKeys
+	now     time.Time
+	created int
+	// handle is the behaviour of the create handler, replaceable by tests.
+	handle gin.HandlerFunc
+}
+
+// SetupTest builds a router whose POST /tasks counts the tasks it creates, behind the middleware with a 24h TTL.
+func (s *IdempotencyTestSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+	s.keys = NewIdempotencyKeys(&memoryIdempotencyStore{records: map[string]domain.IdempotencyRecord{}}, 24*time.Hour)
+	s.now = time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	s.keys.now = func() time.Time { return s.now }
+	s.created = 0
+	s.handle = func(c *gin.Context) {
+		s.created++
+		var body struct {
+			Title string `json:"title"`
+		}
+		if err := c.ShouldBindJSON(&body); err != nil {
+			_ = c.Error(err).SetType(gin.ErrorTypeBind)
+			return
+		}
+		c.Header("Location", "/api/tasks/t1")
+		c.JSON(http.StatusCreated, gin.H{"id": "t1", "title": body.Title, "n": s.created})
+	}
+
+	s.router = gin.New()
+	s.router.Use(RequestID(), ErrorHandler())
+	s.router.POST("/tasks", func(c *gin.Context) {
+		c.Set("user_id", c.GetHeader("X-User"))
+		c.Set("username", c.GetHeader("X-User"))
+	}, Idempotent(s.keys), func(c *gin.Context) { s.handle(c) })
+}
+
+// TestIdempotency runs the entire test suite.
+func TestIdempotency(t *testing.T) {
+	suite.Run(t, new(IdempotencyTestSuite))
+}
+
+// post sends a JSON body to /tasks as the user, with the idempotency key unless it is empty.
+func (s *IdempotencyTestSuite) post(user, key, body string) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest(http.MethodPost, "/tasks", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-User", user)
+	if key != "" {
+		req.Header.Set(IdempotencyKeyHeader, key)
+	}
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	return w
+}
+
+// TestReplaysFirstResponse verifies that a retry gets the first response verbatim without creating another task.
+func (s *IdempotencyTestSuite) TestReplaysFirstResponse() {
+	first := s.post("alice", "k1", `{"title":"a"}`)
+	s.now = s.now.Add(time.Hour)
+	retry := s.post("alice", "k1", `{"title":"a"}`)
+
+	s.Equal(1, s.created)
+	s.Equal(http.StatusCreated, first.Code)
+	s.Empty(first.Header().Get(IdempotentReplayedHeader))
+	s.Equal(http.StatusCreated, retry.Code)
+	s.Equal(first.Body.String(), retry.Body.String())
+	s.Equal("/api/tasks/t1", retry.Header().Get("Location"))
+	s.Equal(first.Header().Get("Content-Type"), retry.Header().Get("Content-Type"))
+	s.Equal("true", retry.Header().Get(IdempotentReplayedHeader))
+	s.NotEqual(first.Header().Get(RequestIDHeader), retry.Header().Get(RequestIDHeader), "The retry should get its own request ID")
+}
+
+// TestKeysExpire verifies that a key is forgotten after the TTL.
+func (s *IdempotencyTestSuite) TestKeysExpire() {
+	s.post("alice", "k1", `{"title":"a"}`)
+	s.now = s.now.Add(25 * time.Hour)
+	w := s.post("alice", "k1", `{"title":"a"}`)
+
+	s.Equal(2, s.created)
+	s.Empty(w.Header().Get(IdempotentReplayedHeader))
+}
+
+// TestKeysAreScopedPerUser verifies that users cannot see each other's responses by guessing keys.
+func (s *IdempotencyTestSuite) TestKeysAreScopedPerUser() {
+	s.post("alice", "k1", `{"title":"a"}`)
+	w := s.post("bob", "k1", `{"title":"a"}`)
+
+	s.Equal(2, s.created)
+	s.Equal(http.StatusCreated, w.Code)
+	s.Empty(w.Header().Get(IdempotentReplayedHeader))
+}
+
+// TestRejectsKeyReusedForAnotherRequest verifies that a key cannot be reused with a different body.
+func (s *IdempotencyTestSuite) TestRejectsKeyReusedForAnotherRequest() {
+	s.post("alice", "k1", `{"title":"a"}`)
+	w := s.post("alice", "k1", `{"title":"b"}`)
+
+	s.Equal(1, s.created)
+	assertProblem(s.T(), w, http.StatusBadRequest, "idempotency_key_reused", "use a new key for a new request")
+}
+
+// TestRejectsKeyReusedWithAnotherQuery verifies that the query string is part of the request a key is bound to.
+func (s *IdempotencyTestSuite) TestRejectsKeyReusedWithAnotherQuery() {
+	s.post("alice", "k1", `{"title":"a"}`)
+
+	req, _ := http.NewRequest(http.MethodPost, "/tasks?notify=false", bytes.NewBufferString(`{"title":"a"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-User", "alice")
+	req.Header.Set(IdempotencyKeyHeader, "k1")
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+
+	s.Equal(1, s.created)
+	assertProblem(s.T(), w, http.StatusBadRequest, "idempotency_key_reused", "use a new key for a new request")
+}
+
+// TestRejectsOversizedBody verifies that a body larger than MaxBodyBytes is refused without being handled or
+// holding the key.
+func (s *IdempotencyTestSuite) TestRejectsOversizedBody() {
+	w := s.post("alice", "k1", `{"title":"`+strings.Repeat("a", MaxBodyBytes)+`"}`)
+
+	assertProblem(s.T(), w, http.StatusRequestEntityTooLarge, "request_too_large", "request bodies are limited to 1 MiB")
+	s.Zero(s.created)
+	s.Empty(s.keys.store.(*memoryIdempotencyStore).records)
+}
+
+// TestRejectsConcurrentDuplicates verifies that a retry arriving while the first request is in flight gets 409.
+func (s *IdempotencyTestSuite) TestRejectsConcurrentDuplicates() {
+	var duplicate *httptest.ResponseRecorder
+	s.handle = func(c *gin.Context) {
+		duplicate = s.post("alice", "k1", `{"title":"a"}`)
+		c.JSON(http.StatusCreated, gin.H{"id": "t1"})
+	}
+
+	first := s.post("alice", "k1", `{"title":"a"}`)
+
+	s.Equal(http.StatusCreated, first.Code)
+	assertProblem(s.T(), duplicate, http.StatusConflict, "idempotency_key_in_use", "retry once the first request has completed")
+	s.Equal("1", duplicate.Header().Get("Retry-After"))
+}
+
+// TestReleasesKeyOnFailure verifies that failed requests are not stored, so they can be retried with the same key.
+func (s *IdempotencyTestSuite) TestReleasesKeyOnFailure() {
+	failed := s.post("alice", "k1", `{"title":`)
+	s.handle = func(c *gin.Context) { _ = c.Error(usecase.ErrUnavailable) }
+	unavailable := s.post("alice", "k2", `{"title":"a"}`)
+
+	assertProblem(s.T(), failed, http.StatusBadRequest, "invalid_request", "request body is not valid JSON")
+	s.Equal(http.StatusServiceUnavailable, unavailable.Code)
+	s.Empty(s.keys.store.(*memoryIdempotencyStore).records)
+}
+
+// TestRetryAfterFailureIsHandled verifies that a key released after a failure is handled again on retry.
+func (s *IdempotencyTestSuite) TestRetryAfterFailureIsHandled() {
+	s.post("alice", "k1", `{"title":`)
+	w := s.post("alice", "k1", `{"title":"a"}`)
+
+	s.Equal(2, s.created)
+	s.Equal(http.StatusCreated, w.Code)
+	s.Empty(w.Header().Get(IdempotentReplayedHeader))
+}
+
+// TestRenewsKeyWhileHandling verifies that the reservation of a slow request is renewed until it completes.
+func (s *IdempotencyTestSuite) TestRenewsKeyWhileHandling() {
+	store := s.keys.store.(*memoryIdempotencyStore)
+	s.keys.renewEvery = time.Millisecond
+	s.handle = func(c *gin.Context) {
+		s.Eventually(func() bool { return store.renewed() > 0 }, time.Second, time.Millisecond)
+		c.JSON(http.StatusCreated, gin.H{"id": "t1"})
+	}
+
+	w := s.post("alice", "k1", `{"title":"a"}`)
+	renewals := store.renewed()
+
+	s.Equal(http.StatusCreated, w.Code)
+	s.True(store.records["alice/k1"].Completed)
+	s.Equal(renewals, store.renewed(), "Renewal should stop once the request has completed")
+}
+
+// TestKeepsLaterReservation verifies that a request whose reservation expired and was replaced by a retry neither
+// releases nor completes the retry's reservation.
+func (s *IdempotencyTestSuite) TestKeepsLaterReservation() {
+	store := s.keys.store.(*memoryIdempotencyStore)
+	retry := domain.IdempotencyRecord{UserID: "alice", Key: "k1", RequestHash: "retry", CreatedAt: s.now.Add(2 * time.Minute)}
+	s.handle = func(c *gin.Context) {
+		store.records["alice/k1"] = retry
+		_ = c.Error(usecase.ErrUnavailable)
+	}
+	s.post("alice", "k1", `{"title":"a"}`)
+	s.Equal(retry, store.records["alice/k1"], "A failed request should not release another reservation")
+
+	retry.Key = "k2"
+	s.handle = func(c *gin.Context) {
+		store.records["alice/k2"] = retry
+		c.JSON(http.StatusCreated, gin.H{"id": "t1"})
+	}
+	s.post("alice", "k2", `{"title":"a"}`)
+	s.Equal(retry, store.records["alice/k2"], "A request should not complete another reservation")
+}
+
+// TestPassesThroughWithoutKey verifies that requests without a key, or without a store, are always handled.
+func (s *IdempotencyTestSuite) TestPassesThroughWithoutKey() {
+	s.post("alice", "", `{"title":"a"}`)
+	s.post("alice", "", `{"title":"a"}`)
+	s.Equal(2, s.created)
+
+	s.keys = nil
+	s.router = gin.New()
+	s.router.POST("/tasks", Idempotent(nil), func(c *gin.Context) { s.handle(c) })
+	s.post("alice", "k1", `{"title":"a"}`)
+	s.post("alice", "k1", `{"title":"a"}`)
+	s.Equal(4, s.created)
+}
+
+// TestRejectsInvalidKey verifies that keys with unsafe characters or excessive length are refused.
+func (s *IdempotencyTestSuite) TestRejectsInvalidKey() {
+	for _, key := range []string{"has space", strings.Repeat("k", 256)} {
+		w := s.post("alice", key, `{"title":"a"}`)
+		assertProblem(s.T(), w, http.StatusBadRequest, "invalid_idempotency_key", "keys are up to 255 printable ASCII characters")
+	}
+	s.Zero(s.created)
+}
@@ -0,0 +1,21 @@
+package middleware
+
+import (
+	"task_manager_test/internal/domain"
+	"task_manager_test/internal/usecase"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequirePermission allows the request only if the authenticated user's role grants at least one of the
+// permissions under the access policy. It must run after AuthMiddleware. Use cases check the same policy again,
+// including ownership, so this is a coarse gate rather than the only line of defence.
+func RequirePermission(policy *usecase.AccessPolicy, perms ...domain.Permission) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !policy.CanAny(domain.Role(c.GetString("role")), perms...) {
+			abortWithError(c, usecase.ErrForbidden)
+			return
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,76 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"task_manager_test/internal/domain"
+	"task_manager_test/internal/usecase"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+)
+
+// PermissionMiddlewareTestSuite defines the test suite for the RequirePermission middleware.
+type PermissionMiddlewareTestSuite struct {
+	suite.Suite
+	policy *usecase.AccessPolicy
+}
+
+// SetupTest is run before each test in the suite.
+func (s *PermissionMiddlewareTestSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+	s.policy = usecase.DefaultAccessPolicy()
+}
+
+// TestPermissionMiddleware runs the entire test suite.
+func TestPermissionMiddleware(t *testing.T) {
+	suite.Run(t, new(PermissionMiddlewareTestSuite))
+}
+
+// serveAs runs a request through RequirePermission with the given role set in the context; a nil role leaves it unset.
+func (s *PermissionMiddlewareTestSuite) serveAs(role any, perms ...domain.Permission) *httptest.ResponseRecorder {
+	r := gin.New()
+	r.Use(ErrorHandler())
+	r.GET("/protected", func(c *gin.Context) {
+		if role != nil {
+			c.Set("role", role)
+		}
+		c.Next()
+	}, RequirePermission(s.policy, perms...), func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"status": "ok"})
+	})
+	req, _ := http.NewRequest(http.MethodGet, "/protected", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+// TestRequirePermission tests which roles of the default policy pass which permission checks.
+func (s *PermissionMiddlewareTestSuite) TestRequirePermission() {
+	cases := []struct {
+		name  string
+		role  any
+		perms []domain.Permission
+		want  int
+	}{
+		{"admin holds every permission", "admin", []domain.Permission{domain.PermUserManage}, http.StatusOK},
+		{"manager may delete any task", "manager", []domain.Permission{domain.PermTaskDeleteOwn, domain.PermTaskDeleteAny}, http.StatusOK},
+		{"member may create", "member", []domain.Permission{domain.PermTaskCreate}, http.StatusOK},
+		{"legacy user role acts as member", "user", []domain.Permission{domain.PermTaskCreate}, http.StatusOK},
+		{"viewer may not create", "viewer", []domain.Permission{domain.PermTaskCreate}, http.StatusForbidden},
+		{"member may not open the dashboard", "member", []domain.Permission{domain.PermAdminDashboard}, http.StatusForbidden},
+		{"unknown role", "superuser", []domain.Permission{domain.PermTaskReadOwn}, http.StatusForbidden},
+		{"no role in context", nil, []domain.Permission{domain.PermTaskReadOwn}, http.StatusForbidden},
+		{"role of the wrong type", 123, []domain.Permission{domain.PermTaskReadOwn}, http.StatusForbidden},
+	}
+	for _, tc := range cases {
+		s.Run(tc.name, func() {
+			w := s.serveAs(tc.role, tc.perms...)
+			s.Equal(tc.want, w.Code)
+			if tc.want == http.StatusForbidden {
+				assertProblem(s.T(), w, http.StatusForbidden, "forbidden", "")
+			}
+		})
+	}
+}
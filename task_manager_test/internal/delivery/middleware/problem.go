@@ -0,0 +1,254 @@
+package middleware
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"task_manager_test/internal/delivery/openapi"
+	"task_manager_test/internal/usecase"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+// ProblemContentType is the media type of RFC 7807 problem details.
+const ProblemContentType = "application/problem+json"
+
+// RequestIDHeader carries the request ID in both directions.
+const RequestIDHeader = "X-Request-ID"
+
+// problemTypePrefix is prepended to an error code to form the problem type. It is a relative URI reference, as
+// allowed by RFC 7807, so the types resolve against whichever host serves the API.
+const problemTypePrefix = "/problems/"
+
+// Problem is an RFC 7807 problem details object. Code repeats the last segment of Type for clients that prefer to
+// switch on a plain string.
+type Problem struct {
+	Type      string       `json:"type"`
+	Title     string       `json:"title"`
+	Status    int          `json:"status"`
+	Detail    string       `json:"detail,omitempty"`
+	Instance  string       `json:"instance,omitempty"`
+	Code      string       `json:"code"`
+	RequestID string       `json:"request_id,omitempty"`
+	Errors    []FieldError `json:"errors,omitempty"`
+}
+
+// FieldError describes one invalid field of a request body. Field is the JSON name of the field.
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// statusByKind maps each kind of application error to its HTTP status.
+var statusByKind = map[usecase.ErrorKind]int{
+	usecase.KindInternal:        http.StatusInternalServerError,
+	usecase.KindInvalid:         http.StatusBadRequest,
+	usecase.KindUnauthenticated: http.StatusUnauthorized,
+	usecase.KindForbidden:       http.StatusForbidden,
+	usecase.KindNotFound:        http.StatusNotFound,
+	usecase.KindConflict:        http.StatusConflict,
+	usecase.KindTooLarge:        http.StatusRequestEntityTooLarge,
+	usecase.KindTooManyRequests: http.StatusTooManyRequests,
+	usecase.KindUnavailable:     http.StatusServiceUnavailable,
+}
+
+// RequestID assigns every request an ID, taken from a well-formed X-Request-ID header or generated otherwise. The
+// ID is echoed in the response header, stored under the "request_id" context key and included in problems.
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(RequestIDHeader)
+		if !validRequestID(id) {
+			id = newRequestID()
+		}
+		c.Set("request_id", id)
+		c.Header(RequestIDHeader, id)
+		c.Next()
+	}
+}
+
+// validRequestID accepts client-supplied IDs of printable ASCII up to 128 characters, so they are safe to log.
+func validRequestID(id string) bool {
+	if id == "" || len(id) > 128 {
+		return false
+	}
+	for _, r := range id {
+		if r < 0x21 || r > 0x7e {
+			return false
+		}
+	}
+	return true
+}
+
+// newRequestID returns 16 random bytes in hex.
+func newRequestID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// ErrorHandler renders the last error recorded with c.Error as a problem, unless a response has already been
+// written. Handlers and middleware record application errors from the usecase package; errors recorded with
+// gin.ErrorTypeBind are request decoding failures and are reported field by field. Any other error is logged by
+// gin's logger and reported as an internal error without its text.
+func ErrorHandler() gin.HandlerFunc {
+	useJSONFieldNames()
+	return func(c *gin.Context) {
+		c.Next()
+		last := c.Errors.Last()
+		if last == nil || c.Writer.Written() {
+			return
+		}
+		p := NewProblem(c, last)
+		var unavailable *usecase.UnavailableError
+		if errors.As(last.Err, &unavailable) && unavailable.RetryAfter > 0 {
+			c.Header("Retry-After", strconv.Itoa(ceilSeconds(unavailable.RetryAfter)))
+		}
+		var locked *usecase.AccountLockedError
+		if errors.As(last.Err, &locked) {
+			c.Header("Retry-After", strconv.Itoa(max(ceilSeconds(time.Until(locked.Until)), 1)))
+		}
+		c.Header("Content-Type", ProblemContentType)
+		c.JSON(p.Status, p)
+	}
+}
+
+// NewProblem builds the problem for an error recorded on the request.
+func NewProblem(c *gin.Context, ginErr *gin.Error) Problem {
+	err := ginErr.Err
+	var fields []FieldError
+	if ginErr.IsType(gin.ErrorTypeBind) {
+		var detail string
+		detail, fields = describeBindError(err)
+		err = usecase.WithDetail(usecase.ErrInvalidRequest, detail)
+	}
+	appErr, detail := usecase.Describe(err)
+	return Problem{
+		Type:      problemTypePrefix + appErr.Code,
+		Title:     appErr.Message,
+		Status:    statusByKind[appErr.Kind],
+		Detail:    detail,
+		Instance:  c.Request.URL.Path,
+		Code:      appErr.Code,
+		RequestID: c.GetString("request_id"),
+		Errors:    fields,
+	}
+}
+
+// describeBindError explains why a request body could not be decoded or validated, without echoing decoder text.
+// Violations of the API specification found by ValidateRequests are reported the same way.
+func describeBindError(err error) (string, []FieldError) {
+	var validationErrs validator.ValidationErrors
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	var timeErr *time.ParseError
+	var specErr *openapi.ValidationError
+	switch {
+	case errors.As(err, &specErr):
+		fields := make([]FieldError, len(specErr.Violations))
+		for i, v := range specErr.Violations {
+			fields[i] = FieldError{Field: v.Field, Message: v.Message}
+		}
+		return specErr.Reason, fields
+	case errors.As(err, &validationErrs):
+		fields := make([]FieldError, len(validationErrs))
+		for i, fe := range validationErrs {
+			fields[i] = FieldError{Field: fieldPath(fe), Message: fieldMessage(fe)}
+		}
+		return "request body failed validation", fields
+	case errors.Is(err, io.EOF):
+		return "request body is required", nil
+	case errors.As(err, &syntaxErr), errors.Is(err, io.ErrUnexpectedEOF):
+		return "request body is not valid JSON", nil
+	case errors.As(err, &typeErr):
+		field := typeErr.Field
+		if field == "" {
+			return "request body must be a JSON object", nil
+		}
+		return "request body failed validation", []FieldError{{Field: field, Message: "must be a " + jsonTypeName(typeErr.Type)}}
+	case errors.As(err, &timeErr):
+		return "times must be in RFC 3339 format, e.g. 2025-06-01T10:00:00Z", nil
+	default:
+		return "request body could not be decoded", nil
+	}
+}
+
+// fieldPath returns the dotted JSON path of a failed field. Namespaces of named structs start with the type name,
+// which is the one segment that reads the same in the JSON and Go namespaces, and is dropped. Anonymous request
+// structs, as used by most handlers, have no such segment.
+func fieldPath(fe validator.FieldError) string {
+	ns := fe.Namespace()
+	top, rest, nested := strings.Cut(ns, ".")
+	goTop, _, _ := strings.Cut(fe.StructNamespace(), ".")
+	if nested && top == goTop {
+		return rest
+	}
+	return ns
+}
+
+// fieldMessage phrases a validator failure for clients.
+func fieldMessage(fe validator.FieldError) string {
+	switch fe.Tag() {
+	case "required":
+		return "is required"
+	case "oneof":
+		return "must be one of: " + strings.ReplaceAll(fe.Param(), " ", ", ")
+	case "min":
+		return "must be at least " + fe.Param()
+	case "max":
+		return "must be at most " + fe.Param()
+	case "email":
+		return "must be an email address"
+	default:
+		return "is invalid"
+	}
+}
+
+// jsonTypeName names a Go type in JSON terms.
+func jsonTypeName(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.Slice, reflect.Array:
+		return "array"
+	default:
+		return "object"
+	}
+}
+
+var jsonFieldNamesOnce sync.Once
+
+// useJSONFieldNames makes gin's validator report fields by their JSON names rather than their Go names.
+func useJSONFieldNames() {
+	jsonFieldNamesOnce.Do(func() {
+		v, ok := binding.Validator.Engine().(*validator.Validate)
+		if !ok {
+			return
+		}
+		v.RegisterTagNameFunc(func(f reflect.StructField) string {
+			name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+			if name == "-" {
+				return ""
+			}
+			if name == "" {
+				return f.Name
+			}
+			return name
+		})
+	})
+}
@@ -0,0 +1,198 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"task_manager_test/internal/usecase"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+// assertProblem checks that w holds a problem with the given status, code and detail.
+func assertProblem(t *testing.T, w *httptest.ResponseRecorder, status int, code, detail string) Problem {
+	t.Helper()
+	assert.Equal(t, status, w.Code)
+	assert.Equal(t, ProblemContentType, w.Header().Get("Content-Type"))
+	var p Problem
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &p), "body should be a problem: %s", w.Body.String())
+	assert.Equal(t, status, p.Status)
+	assert.Equal(t, code, p.Code)
+	assert.Equal(t, "/problems/"+code, p.Type)
+	assert.Equal(t, detail, p.Detail)
+	return p
+}
+
+// ProblemTestSuite defines the test suite for the request ID and error handling middleware.
+type ProblemTestSuite struct {
+	suite.Suite
+	router *gin.Engine
+}
+
+// SetupTest builds a router with both middlewares installed.
+func (s *ProblemTestSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+	s.router = gin.New()
+	s.router.Use(RequestID(), ErrorHandler())
+}
+
+// TestProblem runs the entire test suite.
+func TestProblem(t *testing.T) {
+	suite.Run(t, new(ProblemTestSuite))
+}
+
+// send performs a request against the suite router.
+func (s *ProblemTestSuite) send(method, path, body string, header http.Header) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest(method, path, bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range header {
+		req.Header.Set(k, v[0])
+	}
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	return w
+}
+
+// TestApplicationError tests that usecase errors are rendered with their kind's status, code and detail.
+func (s *ProblemTestSuite) TestApplicationError() {
+	s.router.GET("/tasks/:id", func(c *gin.Context) {
+		_ = c.Error(usecase.WithDetail(usecase.ErrNotFound, "task not found"))
+	})
+
+	w := s.send(http.MethodGet, "/tasks/42", "", http.Header{RequestIDHeader: {"req-1"}})
+
+	p := assertProblem(s.T(), w, http.StatusNotFound, "not_found", "task not found")
+	s.Equal("resource not found", p.Title)
+	s.Equal("/tasks/42", p.Instance)
+	s.Equal("req-1", p.RequestID)
+	s.Equal("req-1", w.Header().Get(RequestIDHeader))
+}
+
+// TestDetailedError tests that request errors from the usecase layer carry their reason as the detail.
+func (s *ProblemTestSuite) TestDetailedError() {
+	s.router.POST("/tasks", func(c *gin.Context) {
+		_ = c.Error(&usecase.TaskRequestError{Reason: "title cannot be empty"})
+	})
+
+	w := s.send(http.MethodPost, "/tasks", "{}", nil)
+
+	assertProblem(s.T(), w, http.StatusBadRequest, "invalid_task_request", "title cannot be empty")
+}
+
+// TestUnknownError tests that errors outside the usecase package are reported as internal without their text.
+func (s *ProblemTestSuite) TestUnknownError() {
+	s.router.GET("/tasks", func(c *gin.Context) {
+		_ = c.Error(errors.New("connection refused by 10.0.0.7:27017"))
+	})
+
+	w := s.send(http.MethodGet, "/tasks", "", nil)
+
+	assertProblem(s.T(), w, http.StatusInternalServerError, "internal", "")
+	s.NotContains(w.Body.String(), "10.0.0.7")
+}
+
+// TestUnavailableError tests that a failing database is reported as 503 with Retry-After when a delay is known.
+func (s *ProblemTestSuite) TestUnavailableError() {
+	s.router.GET("/tasks", func(c *gin.Context) {
+		_ = c.Error(&usecase.UnavailableError{RetryAfter: 2500 * time.Millisecond})
+	})
+	s.router.GET("/tasks/:id", func(c *gin.Context) {
+		_ = c.Error(&usecase.UnavailableError{Err: errors.New("connection refused by 10.0.0.7:27017")})
+	})
+
+	w := s.send(http.MethodGet, "/tasks", "", nil)
+	assertProblem(s.T(), w, http.StatusServiceUnavailable, "service_unavailable", "the database is temporarily unavailable, please retry later")
+	s.Equal("3", w.Header().Get("Retry-After"))
+
+	w = s.send(http.MethodGet, "/tasks/42", "", nil)
+	assertProblem(s.T(), w, http.StatusServiceUnavailable, "service_unavailable", "the database is temporarily unavailable, please retry later")
+	s.Empty(w.Header().Get("Retry-After"))
+	s.NotContains(w.Body.String(), "10.0.0.7")
+}
+
+// TestWrittenResponseIsKept tests that handlers which already responded are left alone.
+func (s *ProblemTestSuite) TestWrittenResponseIsKept() {
+	s.router.GET("/tasks", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"tasks": []string{}})
+		_ = c.Error(errors.New("late failure"))
+	})
+
+	w := s.send(http.MethodGet, "/tasks", "", nil)
+
+	s.Equal(http.StatusOK, w.Code)
+	s.JSONEq(`{"tasks": []}`, w.Body.String())
+}
+
+// TestBindErrors tests how request body decoding and validation failures are described.
+func (s *ProblemTestSuite) TestBindErrors() {
+	type member struct {
+		Username string `json:"username" binding:"required"`
+	}
+	s.router.POST("/bind", func(c *gin.Context) {
+		var body struct {
+			Title   string    `json:"title" binding:"required"`
+			Status  string    `json:"status" binding:"omitempty,oneof=pending done"`
+			Due     time.Time `json:"due_date"`
+			Count   int       `json:"count"`
+			Members []member  `json:"members" binding:"dive"`
+		}
+		if err := c.ShouldBindJSON(&body); err != nil {
+			_ = c.Error(err).SetType(gin.ErrorTypeBind)
+		}
+	})
+
+	cases := []struct {
+		name       string
+		body       string
+		wantDetail string
+		wantFields []FieldError
+	}{
+		{"validation", `{"status": "later", "members": [{}]}`, "request body failed validation", []FieldError{
+			{Field: "title", Message: "is required"},
+			{Field: "status", Message: "must be one of: pending, done"},
+			{Field: "members[0].username", Message: "is required"},
+		}},
+		{"empty body", ``, "request body is required", nil},
+		{"syntax", `{"title":`, "request body is not valid JSON", nil},
+		{"wrong type", `{"title": "x", "count": "many"}`, "request body failed validation", []FieldError{
+			{Field: "count", Message: "must be a number"},
+		}},
+		{"not an object", `[]`, "request body must be a JSON object", nil},
+		{"bad time", `{"title": "x", "due_date": "tomorrow"}`, "times must be in RFC 3339 format, e.g. 2025-06-01T10:00:00Z", nil},
+	}
+	for _, tc := range cases {
+		s.Run(tc.name, func() {
+			w := s.send(http.MethodPost, "/bind", tc.body, nil)
+
+			p := assertProblem(s.T(), w, http.StatusBadRequest, "invalid_request", tc.wantDetail)
+			s.Equal(tc.wantFields, p.Errors)
+		})
+	}
+}
+
+// TestRequestIDGeneration tests that missing or unsafe request IDs are replaced with generated ones.
+func (s *ProblemTestSuite) TestRequestIDGeneration() {
+	s.router.GET("/ping", func(c *gin.Context) { c.String(http.StatusOK, c.GetString("request_id")) })
+
+	for name, id := range map[string]string{
+		"missing":      "",
+		"control char": "abc\x01",
+		"too long":     strings.Repeat("a", 129),
+	} {
+		s.Run(name, func() {
+			w := s.send(http.MethodGet, "/ping", "", http.Header{RequestIDHeader: {id}})
+
+			generated := w.Header().Get(RequestIDHeader)
+			s.Len(generated, 32)
+			s.Equal(generated, w.Body.String())
+		})
+	}
+}
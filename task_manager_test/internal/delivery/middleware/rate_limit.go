@@ -0,0 +1,172 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"task_manager_test/internal/usecase"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxPeekBytes bounds how much of a request body is buffered to extract the username for rate limiting.
+const maxPeekBytes = 64 << 10
+
+// bucket is the token-bucket state for a single key.
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// RateLimiter is an in-memory token-bucket limiter keyed by an arbitrary string such as a client IP or username.
+// Each key may burst up to limit requests and then refills at limit per window.
+type RateLimiter struct {
+	limit  int
+	window time.Duration
+	rate   float64 // tokens per second
+
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+// NewRateLimiter constructs a limiter allowing limit requests per window for each key.
+func NewRateLimiter(limit int, window time.Duration) *RateLimiter {
+	return &RateLimiter{
+		limit:   limit,
+		window:  window,
+		rate:    float64(limit) / window.Seconds(),
+		buckets: make(map[string]*bucket),
+		now:     time.Now,
+	}
+}
+
+// RateLimitDecision describes the outcome of a single Allow call.
+type RateLimitDecision struct {
+	Allowed    bool
+	Limit      int
+	Remaining  int
+	Reset      time.Duration // time until the bucket is full again
+	RetryAfter time.Duration // time until the next request would be allowed; zero when Allowed
+}
+
+// Allow consumes a token for key if one is available.
+func (l *RateLimiter) Allow(key string) RateLimitDecision {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.sweep(now)
+
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(l.limit), last: now}
+		l.buckets[key] = b
+	}
+	b.tokens = math.Min(float64(l.limit), b.tokens+now.Sub(b.last).Seconds()*l.rate)
+	b.last = now
+
+	d := RateLimitDecision{Limit: l.limit}
+	if b.tokens >= 1 {
+		b.tokens--
+		d.Allowed = true
+	} else {
+		d.RetryAfter = l.durationFor(1 - b.tokens)
+	}
+	d.Remaining = int(math.Floor(b.tokens))
+	d.Reset = l.durationFor(float64(l.limit) - b.tokens)
+	return d
+}
+
+// durationFor converts a number of missing tokens into the time needed to refill them.
+func (l *RateLimiter) durationFor(tokens float64) time.Duration {
+	return time.Duration(tokens / l.rate * float64(time.Second))
+}
+
+// sweep drops buckets that have been idle long enough to be full again, bounding memory use.
+// It runs at most once per window and must be called with l.mu held.
+func (l *RateLimiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < l.window {
+		return
+	}
+	l.lastSweep = now
+	for k, b := range l.buckets {
+		if now.Sub(b.last) >= l.window {
+			delete(l.buckets, k)
+		}
+	}
+}
+
+// KeyFunc extracts the rate-limit key from a request. An empty key skips limiting for that request.
+type KeyFunc func(c *gin.Context) string
+
+// ClientIPKey keys requests by the client IP address.
+func ClientIPKey(c *gin.Context) string {
+	return "ip:" + c.ClientIP()
+}
+
+// AuthenticatedUserKey keys requests by the username set by AuthMiddleware.
+func AuthenticatedUserKey(c *gin.Context) string {
+	if username := c.GetString("username"); username != "" {
+		return "user:" + username
+	}
+	return ""
+}
+
+// BodyUsernameKey keys requests by the "username" field of a JSON body, restoring the body for the handler.
+func BodyUsernameKey(c *gin.Context) string {
+	if c.Request.Body == nil {
+		return ""
+	}
+	data, err := io.ReadAll(io.LimitReader(c.Request.Body, maxPeekBytes))
+	if err != nil {
+		return ""
+	}
+	c.Request.Body = io.NopCloser(io.MultiReader(bytes.NewReader(data), c.Request.Body))
+
+	var body struct {
+		Username string `json:"username"`
+	}
+	if json.Unmarshal(data, &body) != nil || body.Username == "" {
+		return ""
+	}
+	return "username:" + strings.ToLower(body.Username)
+}
+
+// RateLimit rejects requests with 429 once the bucket for their key is empty. It sets the standard
+// RateLimit-Limit, RateLimit-Remaining and RateLimit-Reset headers on every limited response, and Retry-After on
+// rejections. A nil limiter disables the middleware.
+func RateLimit(l *RateLimiter, key KeyFunc) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if l == nil {
+			c.Next()
+			return
+		}
+		k := key(c)
+		if k == "" {
+			c.Next()
+			return
+		}
+		d := l.Allow(k)
+		c.Header("RateLimit-Limit", strconv.Itoa(d.Limit))
+		c.Header("RateLimit-Remaining", strconv.Itoa(d.Remaining))
+		c.Header("RateLimit-Reset", strconv.Itoa(ceilSeconds(d.Reset)))
+		if !d.Allowed {
+			c.Header("Retry-After", strconv.Itoa(ceilSeconds(d.RetryAfter)))
+			abortWithError(c, usecase.ErrRateLimited)
+			return
+		}
+		c.Next()
+	}
+}
+
+// ceilSeconds rounds a duration up to whole seconds, as required by the Retry-After and RateLimit-Reset headers.
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
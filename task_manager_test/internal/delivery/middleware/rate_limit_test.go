@@ -0,0 +1,156 @@
+package middleware
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+)
+
+// RateLimitTestSuite defines the test suite for the token-bucket limiter and its middleware.
+type RateLimitTestSuite struct {
+	suite.Suite
+	router  *gin.Engine
+	limiter *RateLimiter
+	now     time.Time
+}
+
+// SetupTest builds a limiter of 2 requests per minute with a controllable clock.
+func (s *RateLimitTestSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+	s.router = gin.New()
+	s.router.Use(ErrorHandler())
+	s.limiter = NewRateLimiter(2, time.Minute)
+	s.now = time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	s.limiter.now = func() time.Time { return s.now }
+}
+
+// TestRateLimit runs the entire test suite.
+func TestRateLimit(t *testing.T) {
+	suite.Run(t, new(RateLimitTestSuite))
+}
+
+// post sends a JSON body to /login from the given remote address.
+func (s *RateLimitTestSuite) post(remoteAddr, body string) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest(http.MethodPost, "/login", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.RemoteAddr = remoteAddr
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	return w
+}
+
+// TestAllow_RefillsOverTime verifies the token-bucket arithmetic.
+func (s *RateLimitTestSuite) TestAllow_RefillsOverTime() {
+	s.True(s.limiter.Allow("k").Allowed)
+	s.True(s.limiter.Allow("k").Allowed)
+
+	denied := s.limiter.Allow("k")
+	s.False(denied.Allowed, "The third request within the window should be denied")
+	s.Equal(0, denied.Remaining)
+	s.Equal(30*time.Second, denied.RetryAfter, "One token refills every 30s at 2 per minute")
+
+	s.now = s.now.Add(30 * time.Second)
+	s.True(s.limiter.Allow("k").Allowed, "A token should be available after the refill interval")
+	s.True(s.limiter.Allow("other").Allowed, "Keys should have independent buckets")
+}
+
+// TestRateLimit_SetsHeadersAndRejects verifies the 429 response and the standard headers.
+func (s *RateLimitTestSuite) TestRateLimit_SetsHeadersAndRejects() {
+	// ARRANGE
+	s.router.POST("/login", RateLimit(s.limiter, ClientIPKey), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	// ACT
+	first := s.post("10.0.0.1:1234", `{}`)
+	s.post("10.0.0.1:1234", `{}`)
+	third := s.post("10.0.0.1:1234", `{}`)
+	otherIP := s.post("10.0.0.2:1234", `{}`)
+
+	// ASSERT
+	s.Equal(http.StatusOK, first.Code)
+	s.Equal("2", first.Header().Get("RateLimit-Limit"))
+	s.Equal("1", first.Header().Get("RateLimit-Remaining"))
+	s.Equal("30", first.Header().Get("RateLimit-Reset"))
+
+	assertProblem(s.T(), third, http.StatusTooManyRequests, "rate_limited", "")
+	s.Equal("30", third.Header().Get("Retry-After"))
+	s.Equal("0", third.Header().Get("RateLimit-Remaining"))
+
+	s.Equal(http.StatusOK, otherIP.Code, "A different client IP should not be affected")
+}
+
+// TestBodyUsernameKey_LimitsAcrossIPsAndPreservesBody verifies per-username limiting and that the handler still sees the body.
+func (s *RateLimitTestSuite) TestBodyUsernameKey_LimitsAcrossIPsAndPreservesBody() {
+	// ARRANGE
+	var seen []string
+	s.router.POST("/login", RateLimit(s.limiter, BodyUsernameKey), func(c *gin.Context) {
+		var body struct {
+			Username string `json:"username"`
+		}
+		s.Require().NoError(c.ShouldBindJSON(&body), "The handler should still be able to bind the body")
+		seen = append(seen, body.Username)
+		c.Status(http.StatusOK)
+	})
+
+	// ACT
+	s.post("10.0.0.1:1", `{"username": "Alice"}`)
+	s.post("10.0.0.2:1", `{"username": "alice"}`)
+	blocked := s.post("10.0.0.3:1", `{"username": "ALICE"}`)
+	other := s.post("10.0.0.3:1", `{"username": "bob"}`)
+
+	// ASSERT
+	s.Equal(http.StatusTooManyRequests, blocked.Code, "Usernames should be limited regardless of client IP and case")
+	s.Equal(http.StatusOK, other.Code)
+	s.Equal([]string{"Alice", "alice", "bob"}, seen)
+}
+
+// TestRateLimit_SkipsEmptyKeyAndNilLimiter verifies that unkeyed requests and disabled limiters pass through.
+func (s *RateLimitTestSuite) TestRateLimit_SkipsEmptyKeyAndNilLimiter() {
+	// ARRANGE
+	s.router.POST("/login", RateLimit(s.limiter, BodyUsernameKey), RateLimit(nil, ClientIPKey), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	// ACT & ASSERT
+	for i := 0; i < 5; i++ {
+		w := s.post("10.0.0.1:1", `not json`)
+		s.Equal(http.StatusOK, w.Code, "Requests without a username should not be limited by the username limiter")
+		s.Empty(w.Header().Get("RateLimit-Limit"))
+	}
+}
+
+// TestAuthenticatedUserKey_UsesContextUsername verifies per-user keys for the authenticated API.
+func (s *RateLimitTestSuite) TestAuthenticatedUserKey_UsesContextUsername() {
+	// ARRANGE
+	s.router.POST("/login", func(c *gin.Context) {
+		c.Set("username", "carol")
+		c.Next()
+	}, RateLimit(s.limiter, AuthenticatedUserKey), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	// ACT
+	s.post("10.0.0.1:1", ``)
+	s.post("10.0.0.2:1", ``)
+	w := s.post("10.0.0.3:1", ``)
+
+	// ASSERT
+	s.Equal(http.StatusTooManyRequests, w.Code, "The per-user limit should apply across client IPs")
+}
+
+// TestSweep_DropsIdleBuckets verifies that idle keys do not accumulate forever.
+func (s *RateLimitTestSuite) TestSweep_DropsIdleBuckets() {
+	s.limiter.Allow("a")
+	s.limiter.Allow("b")
+
+	s.now = s.now.Add(2 * time.Minute)
+	s.limiter.Allow("c")
+
+	s.Len(s.limiter.buckets, 1, "Only the active key should remain after a sweep")
+}
@@ -0,0 +1,37 @@
+package middleware
+
+import (
+	"task_manager_test/internal/delivery/openapi"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ValidateRequests rejects requests whose query parameters or JSON body do not match the operation documented
+// for their route in spec, before the handler runs. Routes the document does not describe pass unchecked. The
+// violations are reported by ErrorHandler like binding failures, field by field.
+//
+// spec is consulted on every request, so it may be filled in after the middleware is created.
+func ValidateRequests(spec *openapi.Document) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		op := spec.Operation(c.Request.Method, openapi.PathTemplate(c.FullPath()))
+		if op == nil {
+			c.Next()
+			return
+		}
+		var body []byte
+		// Only JSON bodies are validated, so uploads are left unread.
+		if op.RequestBody != nil && op.RequestBody.Content["application/json"].Schema != nil {
+			var err error
+			if body, err = readBody(c); err != nil {
+				abortWithError(c, err)
+				return
+			}
+		}
+		if err := spec.ValidateRequest(op, c.Request.URL.Query(), c.ContentType(), body); err != nil {
+			_ = c.Error(err).SetType(gin.ErrorTypeBind)
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,100 @@
+package middleware
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"task_manager_test/internal/delivery/openapi"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+)
+
+// ValidateTestSuite defines the test suite for the request validation middleware.
+type ValidateTestSuite struct {
+	suite.Suite
+	router   *gin.Engine
+	received string
+}
+
+// SetupTest documents POST /tasks and routes it through the validation middleware to a handler that echoes the
+// body it receives.
+func (s *ValidateTestSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+	s.router = gin.New()
+	s.router.Use(ErrorHandler())
+	s.received = ""
+
+	type taskRequest struct {
+		Title  string `json:"title" binding:"required"`
+		Status string `json:"status" binding:"omitempty,oneof=pending done"`
+	}
+	spec := openapi.New(openapi.Info{Title: "test", Version: "1"})
+	spec.Add(http.MethodPost, "/tasks/:id", &openapi.Operation{
+		Parameters:  []openapi.Parameter{{Name: "dry_run", In: "query", Schema: &openapi.Schema{Type: openapi.Types{"boolean"}}}},
+		RequestBody: &openapi.RequestBody{Required: true, Content: openapi.JSONContent(spec.SchemaOf(taskRequest{}))},
+	})
+
+	handler := func(c *gin.Context) {
+		body, _ := io.ReadAll(c.Request.Body)
+		s.received = string(body)
+		c.Status(http.StatusNoContent)
+	}
+	s.router.Use(ValidateRequests(spec))
+	s.router.POST("/tasks/:id", handler)
+	s.router.POST("/undocumented", handler)
+}
+
+// TestValidate runs the entire test suite.
+func TestValidate(t *testing.T) {
+	suite.Run(t, new(ValidateTestSuite))
+}
+
+// send posts body as JSON to the suite router.
+func (s *ValidateTestSuite) send(path, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	return w
+}
+
+// TestValidRequestReachesHandler tests that a matching request is passed on with its body intact.
+func (s *ValidateTestSuite) TestValidRequestReachesHandler() {
+	body := `{"title": "Write docs", "status": "done"}`
+
+	w := s.send("/tasks/1?dry_run=true", body)
+
+	s.Equal(http.StatusNoContent, w.Code)
+	s.Equal(body, s.received)
+}
+
+// TestInvalidBodyIsRejected tests that body violations are reported field by field without calling the handler.
+func (s *ValidateTestSuite) TestInvalidBodyIsRejected() {
+	w := s.send("/tasks/1", `{"status": "later"}`)
+
+	p := assertProblem(s.T(), w, http.StatusBadRequest, "invalid_request", "request body failed validation")
+	s.Equal([]FieldError{
+		{Field: "title", Message: "is required"},
+		{Field: "status", Message: "must be one of: pending, done"},
+	}, p.Errors)
+	s.Empty(s.received)
+}
+
+// TestInvalidQueryIsRejected tests that query parameters are checked against their schema.
+func (s *ValidateTestSuite) TestInvalidQueryIsRejected() {
+	w := s.send("/tasks/1?dry_run=maybe", `{"title": "x"}`)
+
+	p := assertProblem(s.T(), w, http.StatusBadRequest, "invalid_request", "query parameters failed validation")
+	s.Equal([]FieldError{{Field: "dry_run", Message: "must be a boolean"}}, p.Errors)
+}
+
+// TestUndocumentedRoutePasses tests that routes missing from the document are not checked.
+func (s *ValidateTestSuite) TestUndocumentedRoutePasses() {
+	w := s.send("/undocumented", `not json`)
+
+	s.Equal(http.StatusNoContent, w.Code)
+	s.Equal("not json", s.received)
+}
@@ -0,0 +1,179 @@
+// Package openapi builds OpenAPI 3.1 documents from Go types and validates requests against them. It knows
+// nothing about this API; the router describes its routes with it.
+package openapi
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Version is the OpenAPI version documents are written in.
+const Version = "3.1.0"
+
+// Document is the root of an OpenAPI document. Only the parts of the specification used by this API are modelled.
+type Document struct {
+	OpenAPI    string              `json:"openapi"`
+	Info       Info                `json:"info"`
+	Paths      map[string]PathItem `json:"paths"`
+	Components Components          `json:"components"`
+}
+
+// Info describes the API.
+type Info struct {
+	Title       string `json:"title"`
+	Version     string `json:"version"`
+	Description string `json:"description,omitempty"`
+}
+
+// PathItem holds the operations of one path, keyed by lower-case HTTP method.
+type PathItem map[string]*Operation
+
+// Operation describes one method on one path.
+type Operation struct {
+	OperationID string                `json:"operationId,omitempty"`
+	Summary     string                `json:"summary,omitempty"`
+	Description string                `json:"description,omitempty"`
+	Tags        []string              `json:"tags,omitempty"`
+	Parameters  []Parameter           `json:"parameters,omitempty"`
+	RequestBody *RequestBody          `json:"requestBody,omitempty"`
+	Responses   map[string]*Response  `json:"responses"`
+	Security    []SecurityRequirement `json:"security,omitempty"`
+	Deprecated  bool                  `json:"deprecated,omitempty"`
+}
+
+// Parameter describes a path or query parameter.
+type Parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
+// RequestBody describes the body an operation accepts, keyed by media type.
+type RequestBody struct {
+	Description string               `json:"description,omitempty"`
+	Required    bool                 `json:"required,omitempty"`
+	Content     map[string]MediaType `json:"content"`
+}
+
+// Response describes one response of an operation.
+type Response struct {
+	Description string               `json:"description"`
+	Headers     map[string]*Header   `json:"headers,omitempty"`
+	Content     map[string]MediaType `json:"content,omitempty"`
+}
+
+// Header describes a response header.
+type Header struct {
+	Description string  `json:"description,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
+// MediaType pairs a media type with the schema of its content.
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+// SecurityRequirement lists the schemes that together satisfy an operation's security, each with the scopes it
+// must hold. An operation is satisfied by any one of its requirements.
+type SecurityRequirement map[string][]string
+
+// SecurityScheme describes a way of authenticating.
+type SecurityScheme struct {
+	Type         string `json:"type"`
+	Scheme       string `json:"scheme,omitempty"`
+	BearerFormat string `json:"bearerFormat,omitempty"`
+	Description  string `json:"description,omitempty"`
+}
+
+// Components holds the named schemas and security schemes that operations refer to.
+type Components struct {
+	Schemas         map[string]*Schema         `json:"schemas,omitempty"`
+	SecuritySchemes map[string]*SecurityScheme `json:"securitySchemes,omitempty"`
+}
+
+// New returns a document without any paths.
+func New(info Info) *Document {
+	return &Document{
+		OpenAPI: Version,
+		Info:    info,
+		Paths:   map[string]PathItem{},
+		Components: Components{
+			Schemas:         map[string]*Schema{},
+			SecuritySchemes: map[string]*SecurityScheme{},
+		},
+	}
+}
+
+// Add documents op as method on ginPath, a route path in gin's syntax. A path parameter is declared for every
+// parameter in the path that op does not already declare.
+func (d *Document) Add(method, ginPath string, op *Operation) {
+	path := PathTemplate(ginPath)
+	for _, name := range pathParams(path) {
+		if !hasParameter(op.Parameters, name, "path") {
+			op.Parameters = append(op.Parameters, Parameter{Name: name, In: "path", Required: true, Schema: String()})
+		}
+	}
+	if op.OperationID == "" {
+		op.OperationID = operationID(method, path)
+	}
+	item := d.Paths[path]
+	if item == nil {
+		item = PathItem{}
+		d.Paths[path] = item
+	}
+	item[strings.ToLower(method)] = op
+}
+
+// Operation returns the operation documented for method on path, given in OpenAPI syntax, or nil.
+func (d *Document) Operation(method, path string) *Operation {
+	return d.Paths[path][strings.ToLower(method)]
+}
+
+var ginParam = regexp.MustCompile(`[:*]([^/]+)`)
+
+// PathTemplate converts a gin route path such as /tasks/:id into an OpenAPI path template such as /tasks/{id}.
+func PathTemplate(ginPath string) string {
+	return ginParam.ReplaceAllString(ginPath, "{$1}")
+}
+
+var templateParam = regexp.MustCompile(`\{([^}]+)\}`)
+
+// pathParams returns the names of the parameters in an OpenAPI path template, in order.
+func pathParams(path string) []string {
+	var names []string
+	for _, m := range templateParam.FindAllStringSubmatch(path, -1) {
+		names = append(names, m[1])
+	}
+	return names
+}
+
+// hasParameter reports whether params declares name in the given location.
+func hasParameter(params []Parameter, name, in string) bool {
+	for _, p := range params {
+		if p.Name == name && p.In == in {
+			return true
+		}
+	}
+	return false
+}
+
+// operationID derives an identifier such as getTasksById from the method and path.
+func operationID(method, path string) string {
+	var b strings.Builder
+	b.WriteString(strings.ToLower(method))
+	for _, segment := range strings.FieldsFunc(path, func(r rune) bool { return r == '/' || r == '.' || r == '-' || r == '_' }) {
+		if name, ok := strings.CutPrefix(segment, "{"); ok {
+			b.WriteString("By")
+			segment = strings.TrimSuffix(name, "}")
+		}
+		b.WriteString(strings.ToUpper(segment[:1]) + segment[1:])
+	}
+	return b.String()
+}
+
+// JSONContent describes content of the application/json media type.
+func JSONContent(s *Schema) map[string]MediaType {
+	return map[string]MediaType{"application/json": {Schema: s}}
+}
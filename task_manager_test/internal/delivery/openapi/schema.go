@@ -0,0 +1,257 @@
+package openapi
+
+import (
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// refPrefix locates named schemas within a document.
+const refPrefix = "#/components/schemas/"
+
+// Schema is a JSON Schema, restricted to the keywords this package generates and validates.
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 Types              `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Enum                 []string           `json:"enum,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	MinLength            *int               `json:"minLength,omitempty"`
+	MaxLength            *int               `json:"maxLength,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	Maximum              *float64           `json:"maximum,omitempty"`
+	MinItems             *int               `json:"minItems,omitempty"`
+	MaxItems             *int               `json:"maxItems,omitempty"`
+	AnyOf                []*Schema          `json:"anyOf,omitempty"`
+}
+
+// Types lists the JSON types a value may have. A single type is written as a string, as is conventional.
+type Types []string
+
+// MarshalJSON writes a single type as a string and several as an array.
+func (t Types) MarshalJSON() ([]byte, error) {
+	if len(t) == 1 {
+		return json.Marshal(t[0])
+	}
+	return json.Marshal([]string(t))
+}
+
+// UnmarshalJSON accepts both forms written by MarshalJSON.
+func (t *Types) UnmarshalJSON(data []byte) error {
+	var one string
+	if err := json.Unmarshal(data, &one); err == nil {
+		*t = Types{one}
+		return nil
+	}
+	return json.Unmarshal(data, (*[]string)(t))
+}
+
+// String returns a schema for any string.
+func String() *Schema {
+	return &Schema{Type: Types{"string"}}
+}
+
+// Integer returns a schema for any integer.
+func Integer() *Schema {
+	return &Schema{Type: Types{"integer"}}
+}
+
+// Binary returns a schema for raw file content.
+func Binary() *Schema {
+	return &Schema{Type: Types{"string"}, Format: "binary"}
+}
+
+// Object returns a schema for an object with the given properties, of which the named ones are required.
+func Object(properties map[string]*Schema, required ...string) *Schema {
+	return &Schema{Type: Types{"object"}, Properties: properties, Required: required}
+}
+
+// ArrayOf returns a schema for an array of items.
+func ArrayOf(items *Schema) *Schema {
+	return &Schema{Type: Types{"array"}, Items: items}
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// SchemaOf returns the schema of v's type. Named struct types are added to the document's components and
+// referenced, so every type is described once however many operations use it.
+//
+// Properties are named as encoding/json names them. Structs whose fields carry `binding` tags are request bodies:
+// their required properties are those tagged binding:"required", and the oneof, min, max and email rules are
+// translated into the matching keywords. Other structs are responses, in which every property without omitempty is
+// always present, and pointers without omitempty may be null. A `format` tag sets the format of a property, such as
+// date-time for times encoded as strings.
+func (d *Document) SchemaOf(v any) *Schema {
+	return d.schema(reflect.TypeOf(v))
+}
+
+// schema returns the schema of t, registering named structs.
+func (d *Document) schema(t reflect.Type) *Schema {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	switch {
+	case t == timeType:
+		return &Schema{Type: Types{"string"}, Format: "date-time"}
+	case t.Kind() == reflect.Struct && t.Name() != "":
+		if _, ok := d.Components.Schemas[t.Name()]; !ok {
+			// Register a placeholder first so that recursive types refer to themselves instead of recursing.
+			d.Components.Schemas[t.Name()] = &Schema{}
+			*d.Components.Schemas[t.Name()] = *d.structSchema(t)
+		}
+		return &Schema{Ref: refPrefix + t.Name()}
+	case t.Kind() == reflect.Struct:
+		return d.structSchema(t)
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return String()
+	case reflect.Bool:
+		return &Schema{Type: Types{"boolean"}}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		s := Integer()
+		if t.Kind() == reflect.Int64 || t.Kind() == reflect.Uint64 {
+			s.Format = "int64"
+		}
+		return s
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: Types{"number"}}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: Types{"string"}, Format: "byte"}
+		}
+		return ArrayOf(d.schema(t.Elem()))
+	case reflect.Map:
+		return &Schema{Type: Types{"object"}, AdditionalProperties: d.schema(t.Elem())}
+	default:
+		// Interfaces may hold any value.
+		return &Schema{}
+	}
+}
+
+// structSchema describes the fields of a struct type.
+func (d *Document) structSchema(t reflect.Type) *Schema {
+	s := Object(map[string]*Schema{})
+	request := isRequest(t)
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, omitempty, ok := jsonName(f)
+		if !ok {
+			continue
+		}
+		prop := d.schema(f.Type)
+		if format := f.Tag.Get("format"); format != "" {
+			prop.Format = format
+		}
+		binding := strings.Split(f.Tag.Get("binding"), ",")
+		switch {
+		case request:
+			if applyBinding(prop, f.Type, binding) {
+				s.Required = append(s.Required, name)
+			}
+		case !omitempty:
+			s.Required = append(s.Required, name)
+			if f.Type.Kind() == reflect.Pointer {
+				prop = nullable(prop)
+			}
+		}
+		s.Properties[name] = prop
+	}
+	return s
+}
+
+// isRequest reports whether any field of t carries a binding tag.
+func isRequest(t reflect.Type) bool {
+	for i := 0; i < t.NumField(); i++ {
+		if _, ok := t.Field(i).Tag.Lookup("binding"); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// jsonName returns the name encoding/json uses for f and whether it is omitted when empty; ok is false for
+// fields encoding/json skips.
+func jsonName(f reflect.StructField) (name string, omitempty, ok bool) {
+	if !f.IsExported() {
+		return "", false, false
+	}
+	tag := f.Tag.Get("json")
+	if tag == "-" {
+		return "", false, false
+	}
+	name, opts, _ := strings.Cut(tag, ",")
+	if name == "" {
+		name = f.Name
+	}
+	return name, strings.Contains(","+opts+",", ",omitempty,"), true
+}
+
+// nullable allows s to be null as well.
+func nullable(s *Schema) *Schema {
+	if s.Ref != "" {
+		// Keywords next to $ref apply in addition to the referenced schema, so null needs a union.
+		return &Schema{AnyOf: []*Schema{s, {Type: Types{"null"}}}}
+	}
+	s.Type = append(s.Type, "null")
+	return s
+}
+
+// applyBinding translates gin binding rules into schema keywords and reports whether the field is required.
+func applyBinding(s *Schema, t reflect.Type, rules []string) bool {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	required := false
+	for _, rule := range rules {
+		tag, param, _ := strings.Cut(rule, "=")
+		switch tag {
+		case "required":
+			required = true
+		case "oneof":
+			s.Enum = strings.Fields(param)
+		case "email":
+			s.Format = "email"
+		case "min", "max":
+			n, err := strconv.Atoi(param)
+			if err != nil {
+				continue
+			}
+			setBound(s, t, tag == "min", n)
+		}
+	}
+	return required
+}
+
+// setBound sets the lower or upper bound the validator applies to values of type t.
+func setBound(s *Schema, t reflect.Type, lower bool, n int) {
+	switch t.Kind() {
+	case reflect.String:
+		if lower {
+			s.MinLength = &n
+		} else {
+			s.MaxLength = &n
+		}
+	case reflect.Slice, reflect.Array, reflect.Map:
+		if lower {
+			s.MinItems = &n
+		} else {
+			s.MaxItems = &n
+		}
+	default:
+		f := float64(n)
+		if lower {
+			s.Minimum = &f
+		} else {
+			s.Maximum = &f
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package openapi
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type label struct {
+	Name string `json:"name"`
+}
+
+type taskRequest struct {
+	Title    string     `json:"title" binding:"required,max=100"`
+	Status   string     `json:"status" binding:"omitempty,oneof=pending done"`
+	Due      *time.Time `json:"due_date"`
+	Labels   []label    `json:"labels" binding:"max=5,dive"`
+	Contact  string     `json:"contact" binding:"omitempty,email"`
+	Priority int        `json:"priority" binding:"min=1"`
+}
+
+type taskResponse struct {
+	ID       string            `json:"id"`
+	Due      *time.Time        `json:"due_date"`
+	Label    *label            `json:"label"`
+	Parent   *taskResponse     `json:"parent,omitempty"`
+	Size     int64             `json:"size"`
+	Meta     map[string]string `json:"meta,omitempty"`
+	internal string
+}
+
+func TestSchemaOfRequest(t *testing.T) {
+	d := New(Info{})
+
+	ref := d.SchemaOf(taskRequest{})
+
+	assert.Equal(t, &Schema{Ref: "#/components/schemas/taskRequest"}, ref)
+	s := d.Components.Schemas["taskRequest"]
+	require.NotNil(t, s)
+	assert.Equal(t, []string{"title"}, s.Required)
+	assert.Equal(t, 100, *s.Properties["title"].MaxLength)
+	assert.Equal(t, []string{"pending", "done"}, s.Properties["status"].Enum)
+	assert.Equal(t, Types{"string"}, s.Properties["due_date"].Type, "request pointers are optional, not nullable")
+	assert.Equal(t, "date-time", s.Properties["due_date"].Format)
+	assert.Equal(t, 5, *s.Properties["labels"].MaxItems)
+	assert.Equal(t, "#/components/schemas/label", s.Properties["labels"].Items.Ref)
+	assert.Equal(t, "email", s.Properties["contact"].Format)
+	assert.Equal(t, 1.0, *s.Properties["priority"].Minimum)
+	assert.Contains(t, d.Components.Schemas, "label")
+}
+
+func TestSchemaOfResponse(t *testing.T) {
+	d := New(Info{})
+
+	d.SchemaOf(&taskResponse{})
+
+	s := d.Components.Schemas["taskResponse"]
+	require.NotNil(t, s)
+	assert.Equal(t, []string{"id", "due_date", "label", "size"}, s.Required)
+	assert.Equal(t, Types{"string", "null"}, s.Properties["due_date"].Type)
+	assert.Equal(t, []*Schema{{Ref: "#/components/schemas/label"}, {Type: Types{"null"}}}, s.Properties["label"].AnyOf)
+	assert.Equal(t, "#/components/schemas/taskResponse", s.Properties["parent"].Ref, "recursive types refer to themselves")
+	assert.Equal(t, "int64", s.Properties["size"].Format)
+	assert.Equal(t, String(), s.Properties["meta"].AdditionalProperties)
+	assert.NotContains(t, s.Properties, "internal")
+}
+
+func TestTypesJSON(t *testing.T) {
+	one, err := json.Marshal(Types{"string"})
+	require.NoError(t, err)
+	assert.JSONEq(t, `"string"`, string(one))
+	many, err := json.Marshal(Types{"string", "null"})
+	require.NoError(t, err)
+	assert.JSONEq(t, `["string", "null"]`, string(many))
+
+	var got Types
+	require.NoError(t, json.Unmarshal(one, &got))
+	assert.Equal(t, Types{"string"}, got)
+	require.NoError(t, json.Unmarshal(many, &got))
+	assert.Equal(t, Types{"string", "null"}, got)
+}
+
+func TestAdd(t *testing.T) {
+	d := New(Info{})
+	op := &Operation{}
+
+	d.Add("DELETE", "/projects/:pid/members/:username", op)
+
+	assert.Same(t, op, d.Operation("delete", "/projects/{pid}/members/{username}"))
+	assert.Equal(t, "deleteProjectsByPidMembersByUsername", op.OperationID)
+	assert.Equal(t, []Parameter{
+		{Name: "pid", In: "path", Required: true, Schema: String()},
+		{Name: "username", In: "path", Required: true, Schema: String()},
+	}, op.Parameters)
+}
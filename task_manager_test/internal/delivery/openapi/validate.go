@@ -0,0 +1,279 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"math"
+	"mime"
+	"net/mail"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Violation describes one way in which a request does not match its operation. Field is the JSON path of the
+// offending value within the body, such as members[0].username, or the name of a query parameter.
+type Violation struct {
+	Field   string
+	Message string
+}
+
+// ValidationError is returned when a request does not match its operation. Reason summarizes the failure and
+// Violations lists the offending fields, if the failure is attributable to fields.
+type ValidationError struct {
+	Reason     string
+	Violations []Violation
+}
+
+// Error implements the error interface.
+func (e *ValidationError) Error() string {
+	if len(e.Violations) == 0 {
+		return e.Reason
+	}
+	parts := make([]string, len(e.Violations))
+	for i, v := range e.Violations {
+		parts[i] = v.Field + " " + v.Message
+	}
+	return e.Reason + ": " + strings.Join(parts, "; ")
+}
+
+// ValidateRequest checks the query parameters and body of a request against op. Only JSON bodies are inspected;
+// other media types are left to the handler. body may be nil when the request has none.
+func (d *Document) ValidateRequest(op *Operation, query url.Values, contentType string, body []byte) error {
+	var violations []Violation
+	for _, p := range op.Parameters {
+		if p.In != "query" {
+			continue
+		}
+		values, present := query[p.Name]
+		if !present {
+			if p.Required {
+				violations = append(violations, Violation{Field: p.Name, Message: "is required"})
+			}
+			continue
+		}
+		for _, raw := range values {
+			d.validate(p.Schema, queryValue(p.Schema, raw), p.Name, &violations)
+		}
+	}
+	if len(violations) > 0 {
+		return &ValidationError{Reason: "query parameters failed validation", Violations: violations}
+	}
+
+	if op.RequestBody == nil {
+		return nil
+	}
+	media, _, _ := mime.ParseMediaType(contentType)
+	content, ok := op.RequestBody.Content["application/json"]
+	if !ok || (media != "" && media != "application/json") {
+		return nil
+	}
+	if len(bytes.TrimSpace(body)) == 0 {
+		if op.RequestBody.Required {
+			return &ValidationError{Reason: "request body is required"}
+		}
+		return nil
+	}
+	var value any
+	if err := json.Unmarshal(body, &value); err != nil {
+		return &ValidationError{Reason: "request body is not valid JSON"}
+	}
+	d.validate(content.Schema, value, "", &violations)
+	if len(violations) > 0 {
+		return &ValidationError{Reason: "request body failed validation", Violations: violations}
+	}
+	return nil
+}
+
+// queryValue converts a raw query parameter into the JSON value its schema expects, leaving it a string when it
+// cannot be converted so that validation reports the mismatch.
+func queryValue(s *Schema, raw string) any {
+	if s == nil {
+		return raw
+	}
+	switch {
+	case slices.Contains(s.Type, "integer"), slices.Contains(s.Type, "number"):
+		if f, err := strconv.ParseFloat(raw, 64); err == nil {
+			return f
+		}
+	case slices.Contains(s.Type, "boolean"):
+		if b, err := strconv.ParseBool(raw); err == nil {
+			return b
+		}
+	}
+	return raw
+}
+
+// resolve follows a $ref to the named schema.
+func (d *Document) resolve(s *Schema) *Schema {
+	for s != nil && s.Ref != "" {
+		s = d.Components.Schemas[strings.TrimPrefix(s.Ref, refPrefix)]
+	}
+	return s
+}
+
+// validate appends the ways in which v, decoded from JSON, does not match s. Validation stops at the first
+// mismatch of each value, so a value of the wrong type is not also reported as out of range.
+func (d *Document) validate(s *Schema, v any, path string, out *[]Violation) {
+	s = d.resolve(s)
+	if s == nil {
+		return
+	}
+	report := func(format string, args ...any) {
+		*out = append(*out, Violation{Field: path, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if len(s.AnyOf) > 0 {
+		var first []Violation
+		for i, alt := range s.AnyOf {
+			var vs []Violation
+			d.validate(alt, v, path, &vs)
+			if len(vs) == 0 {
+				return
+			}
+			if i == 0 {
+				first = vs
+			}
+		}
+		*out = append(*out, first...)
+		return
+	}
+
+	if len(s.Type) > 0 && !matchesType(s.Type, v) {
+		report("must be %s", typeNames(s.Type))
+		return
+	}
+	if len(s.Enum) > 0 {
+		str, ok := v.(string)
+		if !ok || !slices.Contains(s.Enum, str) {
+			report("must be one of: %s", strings.Join(s.Enum, ", "))
+			return
+		}
+	}
+
+	switch v := v.(type) {
+	case string:
+		n := utf8.RuneCountInString(v)
+		if s.MinLength != nil && n < *s.MinLength {
+			report("must be at least %d characters", *s.MinLength)
+		} else if s.MaxLength != nil && n > *s.MaxLength {
+			report("must be at most %d characters", *s.MaxLength)
+		} else if msg := checkFormat(s.Format, v); msg != "" {
+			report("%s", msg)
+		}
+	case float64:
+		if s.Minimum != nil && v < *s.Minimum {
+			report("must be at least %s", formatNumber(*s.Minimum))
+		} else if s.Maximum != nil && v > *s.Maximum {
+			report("must be at most %s", formatNumber(*s.Maximum))
+		}
+	case []any:
+		if s.MinItems != nil && len(v) < *s.MinItems {
+			report("must contain at least %d items", *s.MinItems)
+			return
+		}
+		if s.MaxItems != nil && len(v) > *s.MaxItems {
+			report("must contain at most %d items", *s.MaxItems)
+			return
+		}
+		for i, item := range v {
+			d.validate(s.Items, item, fmt.Sprintf("%s[%d]", path, i), out)
+		}
+	case map[string]any:
+		for _, name := range s.Required {
+			if _, ok := v[name]; !ok {
+				*out = append(*out, Violation{Field: join(path, name), Message: "is required"})
+			}
+		}
+		for _, name := range slices.Sorted(maps.Keys(v)) {
+			value := v[name]
+			if prop, ok := s.Properties[name]; ok {
+				d.validate(prop, value, join(path, name), out)
+			} else if s.AdditionalProperties != nil {
+				d.validate(s.AdditionalProperties, value, join(path, name), out)
+			}
+		}
+	}
+}
+
+// matchesType reports whether v, decoded from JSON, has one of the given types.
+func matchesType(types Types, v any) bool {
+	for _, t := range types {
+		switch v := v.(type) {
+		case nil:
+			if t == "null" {
+				return true
+			}
+		case string:
+			if t == "string" {
+				return true
+			}
+		case bool:
+			if t == "boolean" {
+				return true
+			}
+		case float64:
+			if t == "number" || (t == "integer" && v == math.Trunc(v)) {
+				return true
+			}
+		case []any:
+			if t == "array" {
+				return true
+			}
+		case map[string]any:
+			if t == "object" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// typeNames phrases a list of types for an error message, e.g. "a string or null".
+func typeNames(types Types) string {
+	names := make([]string, len(types))
+	for i, t := range types {
+		switch t {
+		case "null":
+			names[i] = "null"
+		case "array", "integer", "object":
+			names[i] = "an " + t
+		default:
+			names[i] = "a " + t
+		}
+	}
+	return strings.Join(names, " or ")
+}
+
+// checkFormat returns why v does not match format, or "" if it does or the format is not checked.
+func checkFormat(format, v string) string {
+	switch format {
+	case "date-time":
+		if _, err := time.Parse(time.RFC3339, v); err != nil {
+			return "must be an RFC 3339 date-time, e.g. 2025-06-01T10:00:00Z"
+		}
+	case "email":
+		if _, err := mail.ParseAddress(v); err != nil {
+			return "must be an email address"
+		}
+	}
+	return ""
+}
+
+// formatNumber prints a bound without a trailing .0 for whole numbers.
+func formatNumber(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+// join appends a property name to a JSON path.
+func join(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
@@ -0,0 +1,70 @@
+package openapi
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateRequest(t *testing.T) {
+	d := New(Info{})
+	op := &Operation{
+		Parameters: []Parameter{
+			{Name: "page", In: "query", Schema: &Schema{Type: Types{"integer"}, Minimum: ptr(1.0)}},
+			{Name: "mode", In: "query", Required: true, Schema: &Schema{Type: Types{"string"}, Enum: []string{"delete", "archive"}}},
+		},
+		RequestBody: &RequestBody{Required: true, Content: JSONContent(d.SchemaOf(taskRequest{}))},
+	}
+	query := url.Values{"mode": {"archive"}}
+
+	cases := []struct {
+		name        string
+		query       url.Values
+		contentType string
+		body        string
+		want        error
+	}{
+		{"valid", query, "application/json", `{"title": "x", "priority": 2, "due_date": "2025-06-01T10:00:00Z"}`, nil},
+		{"missing query", url.Values{}, "application/json", `{"title": "x"}`, &ValidationError{
+			Reason: "query parameters failed validation", Violations: []Violation{{Field: "mode", Message: "is required"}},
+		}},
+		{"bad query", url.Values{"mode": {"keep"}, "page": {"0"}}, "application/json", `{"title": "x"}`, &ValidationError{
+			Reason: "query parameters failed validation", Violations: []Violation{
+				{Field: "page", Message: "must be at least 1"},
+				{Field: "mode", Message: "must be one of: delete, archive"},
+			},
+		}},
+		{"empty body", query, "application/json", ``, &ValidationError{Reason: "request body is required"}},
+		{"malformed body", query, "application/json", `{"title":`, &ValidationError{Reason: "request body is not valid JSON"}},
+		{"invalid body", query, "application/json", `{"status": "later", "priority": 1.5, "due_date": "soon", "labels": [{"name": 3}], "contact": "nobody"}`, &ValidationError{
+			Reason: "request body failed validation", Violations: []Violation{
+				{Field: "title", Message: "is required"},
+				{Field: "contact", Message: "must be an email address"},
+				{Field: "due_date", Message: "must be an RFC 3339 date-time, e.g. 2025-06-01T10:00:00Z"},
+				{Field: "labels[0].name", Message: "must be a string"},
+				{Field: "priority", Message: "must be an integer"},
+				{Field: "status", Message: "must be one of: pending, done"},
+			},
+		}},
+		{"other media type", query, "text/plain", `not json`, nil},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := d.ValidateRequest(op, tc.query, tc.contentType, []byte(tc.body))
+
+			assert.Equal(t, tc.want, err)
+		})
+	}
+}
+
+func TestValidationErrorMessage(t *testing.T) {
+	err := &ValidationError{Reason: "request body failed validation", Violations: []Violation{
+		{Field: "title", Message: "is required"},
+		{Field: "status", Message: "must be a string"},
+	}}
+
+	assert.Equal(t, "request body failed validation: title is required; status must be a string", err.Error())
+}
+
+func ptr[T any](v T) *T { return &v }
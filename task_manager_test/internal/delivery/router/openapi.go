@@ -0,0 +1,301 @@
+package router
+
+import (
+	"net/http"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"task_manager_test/internal/delivery/controller"
+	"task_manager_test/internal/delivery/middleware"
+	"task_manager_test/internal/delivery/openapi"
+	"task_manager_test/internal/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Security scheme names used in the OpenAPI document.
+const (
+	sessionScheme     = "session"
+	accessTokenScheme = "accessToken"
+)
+
+// endpoint documents one route. Bodies are given as a Go value whose type is reflected into a schema, or as an
+// *openapi.Schema for bodies that have no Go type.
+type endpoint struct {
+	summary    string
+	tag        string
+	scope      string // scope an access token needs; "" when access tokens are not accepted or not needed
+	query      []openapi.Parameter
+	idempotent bool // whether the route accepts an Idempotency-Key header
+	request    any
+	optional   bool // whether the request body may be omitted
+	status     int  // success status; 200 when unset
+	response   any  // nil when the success response has no body
+	csv        bool // whether the success response may also be served as text/csv
+}
+
+// Schemas of bodies built from gin.H rather than a named type.
+var (
+	messageBody    = openapi.Object(map[string]*openapi.Schema{"message": openapi.String()}, "message")
+	tokenBody      = openapi.Object(map[string]*openapi.Schema{"token": openapi.String()}, "token")
+	uploadBody     = openapi.Object(map[string]*openapi.Schema{"file": openapi.Binary()}, "file")
+	cacheStatsBody = openapi.Object(map[string]*openapi.Schema{
+		"caches": {Type: openapi.Types{"object"}, AdditionalProperties: &openapi.Schema{Ref: "#/components/schemas/CacheStatsResponse"}},
+	}, "caches")
+)
+
+// agendaQuery documents the range parameter of the agenda endpoints.
+var agendaQuery = []openapi.Parameter{{Name: "range", In: "query", Description: "days to list, counted in your time zone; today when omitted",
+	Schema: &openapi.Schema{Type: openapi.Types{"string"}, Enum: []string{
+		string(domain.AgendaToday), string(domain.AgendaTomorrow), string(domain.AgendaWeek), string(domain.AgendaOverdue),
+	}}}}
+
+// timesheetQuery documents the parameters of the timesheet endpoint.
+var timesheetQuery = []openapi.Parameter{
+	{Name: "from", In: "query", Description: "first day (YYYY-MM-DD) in your time zone; this week when from and to are omitted", Schema: openapi.String()},
+	{Name: "to", In: "query", Description: "last day (YYYY-MM-DD), inclusive", Schema: openapi.String()},
+	{Name: "format", In: "query", Description: "json when omitted; csv downloads one row per day and task",
+		Schema: &openapi.Schema{Type: openapi.Types{"string"}, Enum: []string{"json", "csv"}}},
+}
+
+// idempotencyKeyHeader documents the header accepted by idempotent routes.
+var idempotencyKeyHeader = openapi.Parameter{Name: middleware.IdempotencyKeyHeader, In: "header",
+	Description: "key that makes retries of this request return its first response instead of applying it again",
+	Schema:      openapi.String()}
+
+// endpoints documents every route registered by SetupRouter, keyed by method and gin path.
+var endpoints = map[string]endpoint{
+	"GET /healthz": {summary: "Liveness probe", tag: "health",
+		response: openapi.Object(map[string]*openapi.Schema{"status": openapi.String()}, "status")},
+	"GET /readyz": {summary: "Readiness probe; 503 while a dependency is down or the server is shutting down", tag: "health",
+		response: openapi.Object(map[string]*openapi.Schema{
+			"status": openapi.String(),
+			"checks": {Type: openapi.Types{"object"}, AdditionalProperties: &openapi.Schema{Ref: "#/components/schemas/DependencyStatus"}},
+		}, "status")},
+	"GET /.well-known/jwks.json": {summary: "Public keys that verify issued tokens", tag: "auth",
+		response: openapi.Object(map[string]*openapi.Schema{"keys": openapi.ArrayOf(&openapi.Schema{Ref: "#/components/schemas/JSONWebKey"})}, "keys")},
+	"GET /openapi.json": {summary: "This document", tag: "docs", response: &openapi.Schema{Type: openapi.Types{"object"}}},
+	"GET /docs":         {summary: "Browsable API documentation (HTML)", tag: "docs"},
+
+	"POST /register":        {summary: "Register a user", tag: "auth", request: controller.RegisterRequest{}, status: http.StatusCreated, response: messageBody},
+	"POST /login":           {summary: "Log in and receive a JWT", tag: "auth", request: controller.LoginRequest{}, response: tokenBody},
+	"POST /password/forgot": {summary: "Request a password reset token", tag: "auth", request: controller.ForgotPasswordRequest{}, status: http.StatusAccepted, response: messageBody},
+	"POST /password/reset":  {summary: "Reset a password with a reset token", tag: "auth", request: controller.ResetPasswordRequest{}, response: messageBody},
+
+	"GET /api/tasks":  {summary: "List the tasks visible to the caller", tag: "tasks", scope: domain.ScopeTasksRead, response: []controller.TaskResponse{}},
+	"POST /api/tasks": {summary: "Create a task", tag: "tasks", scope: domain.ScopeTasksWrite, idempotent: true, request: controller.TaskRequest{}, status: http.StatusCreated, response: controller.TaskResponse{}},
+	"GET /api/tasks/agenda": {summary: "List your open tasks due in a range of days in your time zone", tag: "tasks", scope: domain.ScopeTasksRead,
+		query: agendaQuery, response: controller.AgendaResponse{}},
+	"GET /api/tasks/:id":    {summary: "Get a task", tag: "tasks", scope: domain.ScopeTasksRead, response: controller.TaskResponse{}},
+	"PUT /api/tasks/:id":    {summary: "Replace a task", tag: "tasks", scope: domain.ScopeTasksWrite, request: controller.TaskRequest{}, response: controller.TaskResponse{}},
+	"DELETE /api/tasks/:id": {summary: "Delete a task", tag: "tasks", scope: domain.ScopeTasksWrite, status: http.StatusNoContent},
+
+	"GET /api/tasks/:id/shares":              {summary: "List a task's shares", tag: "sharing", scope: domain.ScopeTasksRead, response: []controller.TaskShareResponse{}},
+	"POST /api/tasks/:id/shares":             {summary: "Share a task with a user", tag: "sharing", scope: domain.ScopeTasksWrite, idempotent: true, request: controller.ShareRequest{}, status: http.StatusCreated, response: controller.TaskShareResponse{}},
+	"DELETE /api/tasks/:id/shares/:username": {summary: "Revoke a share", tag: "sharing", scope: domain.ScopeTasksWrite, status: http.StatusNoContent},
+
+	"GET /api/tasks/:id/comments": {summary: "List a task's comment threads", tag: "comments", scope: domain.ScopeTasksRead,
+		query: []openapi.Parameter{
+			{Name: "page", In: "query", Description: "1-based page number", Schema: openapi.Integer()},
+			{Name: "per_page", In: "query", Description: "threads per page", Schema: openapi.Integer()},
+		},
+		response: controller.CommentPageResponse{}},
+	"POST /api/tasks/:id/comments":           {summary: "Post a comment or reply", tag: "comments", scope: domain.ScopeTasksWrite, idempotent: true, request: controller.CommentRequest{}, status: http.StatusCreated, response: controller.CommentResponse{}},
+	"PUT /api/tasks/:id/comments/:cid":       {summary: "Edit your comment", tag: "comments", scope: domain.ScopeTasksWrite, request: controller.CommentUpdateRequest{}, response: controller.CommentResponse{}},
+	"DELETE /api/tasks/:id/comments/:cid":    {summary: "Delete a comment and its replies", tag: "comments", scope: domain.ScopeTasksWrite, status: http.StatusNoContent},
+	"GET /api/tasks/:id/attachments":         {summary: "List a task's attachments", tag: "attachments", scope: domain.ScopeTasksRead, response: []controller.AttachmentResponse{}},
+	"POST /api/tasks/:id/attachments":        {summary: "Upload an attachment (multipart/form-data)", tag: "attachments", scope: domain.ScopeTasksWrite, request: uploadBody, status: http.StatusCreated, response: controller.AttachmentResponse{}},
+	"GET /api/tasks/:id/attachments/:aid":    {summary: "Download an attachment", tag: "attachments", scope: domain.ScopeTasksRead, response: openapi.Binary()},
+	"DELETE /api/tasks/:id/attachments/:aid": {summary: "Delete an attachment", tag: "attachments", scope: domain.ScopeTasksWrite, status: http.StatusNoContent},
+
+	"GET /api/tasks/:id/time":        {summary: "List the time logged against a task", tag: "time", scope: domain.ScopeTasksRead, response: controller.TaskTimeResponse{}},
+	"POST /api/tasks/:id/time":       {summary: "Log time spent on a task without a timer", tag: "time", scope: domain.ScopeTasksWrite, idempotent: true, request: controller.LogTimeRequest{}, status: http.StatusCreated, response: controller.TimeEntryResponse{}},
+	"POST /api/tasks/:id/time/start": {summary: "Start your timer on a task", tag: "time", scope: domain.ScopeTasksWrite, request: controller.StartTimerRequest{}, optional: true, status: http.StatusCreated, response: controller.TimeEntryResponse{}},
+	"POST /api/tasks/:id/time/stop":  {summary: "Stop your timer on a task", tag: "time", scope: domain.ScopeTasksWrite, response: controller.TimeEntryResponse{}},
+	"GET /api/timesheet": {summary: "Report the time you logged by day and task", tag: "time", scope: domain.ScopeTasksRead,
+		query: timesheetQuery, response: controller.TimesheetResponse{}, csv: true},
+
+	"GET /api/projects":      {summary: "List your projects", tag: "projects", scope: domain.ScopeTasksRead, response: []controller.ProjectResponse{}},
+	"POST /api/projects":     {summary: "Create a project", tag: "projects", scope: domain.ScopeTasksWrite, idempotent: true, request: controller.ProjectRequest{}, status: http.StatusCreated, response: controller.ProjectResponse{}},
+	"GET /api/projects/:pid": {summary: "Get a project", tag: "projects", scope: domain.ScopeTasksRead, response: controller.ProjectResponse{}},
+	"PUT /api/projects/:pid": {summary: "Rename or describe a project", tag: "projects", scope: domain.ScopeTasksWrite, request: controller.ProjectRequest{}, response: controller.ProjectResponse{}},
+	"DELETE /api/projects/:pid": {summary: "Delete a project", tag: "projects", scope: domain.ScopeTasksWrite, status: http.StatusNoContent,
+		query: []openapi.Parameter{{Name: "tasks", In: "query", Required: true, Description: "what happens to the project's tasks",
+			Schema: &openapi.Schema{Type: openapi.Types{"string"}, Enum: []string{string(domain.DisposeDelete), string(domain.DisposeArchive)}}}}},
+	"PUT /api/projects/:pid/members/:username":    {summary: "Add a member or change their role", tag: "projects", scope: domain.ScopeTasksWrite, request: controller.ProjectMemberRequest{}, response: controller.ProjectResponse{}},
+	"DELETE /api/projects/:pid/members/:username": {summary: "Remove a member", tag: "projects", scope: domain.ScopeTasksWrite, status: http.StatusNoContent},
+	"GET /api/projects/:pid/tasks":                {summary: "List a project's tasks", tag: "projects", scope: domain.ScopeTasksRead, response: []controller.TaskResponse{}},
+	"POST /api/projects/:pid/tasks":               {summary: "Create a task in a project", tag: "projects", scope: domain.ScopeTasksWrite, idempotent: true, request: controller.TaskRequest{}, status: http.StatusCreated, response: controller.TaskResponse{}},
+
+	"GET /api/org": {summary: "Get your organization", tag: "organizations", response: controller.OrganizationResponse{}},
+
+	"GET /api/me":               {summary: "Get your account", tag: "account", response: controller.MeResponse{}},
+	"PATCH /api/me":             {summary: "Change your time zone", tag: "account", request: controller.MeRequest{}, response: controller.MeResponse{}},
+	"DELETE /api/me":            {summary: "Delete your account and the data it owns", tag: "account", request: controller.DeleteAccountRequest{}, status: http.StatusNoContent},
+	"GET /api/me/export":        {summary: "Download your data as JSON", tag: "account", response: controller.AccountExportResponse{}},
+	"PUT /api/me/password":      {summary: "Change your password", tag: "account", request: controller.ChangePasswordRequest{}, response: tokenBody},
+	"POST /api/me/tokens":       {summary: "Create a personal access token", tag: "account", request: controller.AccessTokenRequest{}, status: http.StatusCreated, response: controller.AccessTokenResponse{}},
+	"GET /api/me/tokens":        {summary: "List your personal access tokens", tag: "account", response: []controller.AccessTokenResponse{}},
+	"DELETE /api/me/tokens/:id": {summary: "Revoke a personal access token", tag: "account", status: http.StatusNoContent},
+
+	"GET /api/admin/dashboard":              {summary: "Admin dashboard", tag: "admin", scope: domain.ScopeAdmin, response: messageBody},
+	"POST /api/admin/users":                 {summary: "Create a user in your organization", tag: "admin", scope: domain.ScopeAdmin, request: controller.RegisterRequest{}, status: http.StatusCreated, response: messageBody},
+	"PUT /api/admin/users/:username/role":   {summary: "Change a user's role", tag: "admin", scope: domain.ScopeAdmin, request: controller.RoleRequest{}, response: messageBody},
+	"GET /api/admin/users/:username/export": {summary: "Download a user's data as JSON", tag: "admin", scope: domain.ScopeAdmin, response: controller.AccountExportResponse{}},
+	"DELETE /api/admin/users/:username":     {summary: "Delete a user and the data it owns", tag: "admin", scope: domain.ScopeAdmin, status: http.StatusNoContent},
+	"GET /api/admin/cache":                  {summary: "Hit and miss statistics of the in-process caches", tag: "admin", scope: domain.ScopeAdmin, response: cacheStatsBody},
+	"GET /api/admin/orgs":                   {summary: "List organizations (super admin)", tag: "organizations", scope: domain.ScopeAdmin, response: []controller.OrganizationResponse{}},
+	"POST /api/admin/orgs":                  {summary: "Create an organization and its administrator (super admin)", tag: "organizations", scope: domain.ScopeAdmin, request: controller.OrganizationRequest{}, status: http.StatusCreated, response: controller.OrganizationResponse{}},
+}
+
+// endpointsV2 documents the /api/v2 routes whose bodies differ from /api; other /api/v2 routes are documented by
+// their entry in endpoints.
+var endpointsV2 = map[string]endpoint{
+	"GET /api/tasks":  {summary: "List the tasks visible to the caller", tag: "tasks", scope: domain.ScopeTasksRead, response: []controller.TaskResponseV2{}},
+	"POST /api/tasks": {summary: "Create a task", tag: "tasks", scope: domain.ScopeTasksWrite, idempotent: true, request: controller.TaskRequestV2{}, status: http.StatusCreated, response: controller.TaskResponseV2{}},
+	"GET /api/tasks/agenda": {summary: "List your open tasks due in a range of days in your time zone", tag: "tasks", scope: domain.ScopeTasksRead,
+		query: agendaQuery, response: controller.AgendaResponseV2{}},
+	"GET /api/tasks/:id":            {summary: "Get a task", tag: "tasks", scope: domain.ScopeTasksRead, response: controller.TaskResponseV2{}},
+	"PUT /api/tasks/:id":            {summary: "Replace a task", tag: "tasks", scope: domain.ScopeTasksWrite, request: controller.TaskRequestV2{}, response: controller.TaskResponseV2{}},
+	"GET /api/projects/:pid/tasks":  {summary: "List a project's tasks", tag: "projects", scope: domain.ScopeTasksRead, response: []controller.TaskResponseV2{}},
+	"POST /api/projects/:pid/tasks": {summary: "Create a task in a project", tag: "projects", scope: domain.ScopeTasksWrite, idempotent: true, request: controller.TaskRequestV2{}, status: http.StatusCreated, response: controller.TaskResponseV2{}},
+}
+
+// apiVersion splits a route path into its API version and the path the route has under /api, which is the same in
+// every version. Routes outside /api have version 0, and /api is an alias of version 1.
+func apiVersion(path string) (version int, apiPath string) {
+	for v, prefix := range map[int]string{1: "/api/v1/", 2: "/api/v2/"} {
+		if rest, ok := strings.CutPrefix(path, prefix); ok {
+			return v, "/api/" + rest
+		}
+	}
+	if strings.HasPrefix(path, "/api/") {
+		return 1, path
+	}
+	return 0, path
+}
+
+// lookupEndpoint returns the documentation of a route and whether there is any.
+func lookupEndpoint(method, path string) (endpoint, bool) {
+	version, apiPath := apiVersion(path)
+	key := method + " " + apiPath
+	if version == 2 {
+		if e, ok := endpointsV2[key]; ok {
+			return e, true
+		}
+	}
+	e, ok := endpoints[key]
+	return e, ok
+}
+
+// buildOpenAPI documents the given routes. Routes missing from endpoints are still listed, without schemas, so the
+// document never hides a route; the router tests keep the table complete. When v1Deprecated is set, version 1
+// routes, including their unversioned aliases, are marked deprecated.
+func buildOpenAPI(routes gin.RoutesInfo, v1Deprecated bool) *openapi.Document {
+	spec := openapi.New(openapi.Info{
+		Title:       "Task Manager API",
+		Version:     "1.0.0",
+		Description: "Errors are returned as application/problem+json; see the Problem schema.",
+	})
+	spec.Components.SecuritySchemes[sessionScheme] = &openapi.SecurityScheme{
+		Type: "http", Scheme: "bearer", BearerFormat: "JWT",
+		Description: "JWT returned by POST /login.",
+	}
+	spec.Components.SecuritySchemes[accessTokenScheme] = &openapi.SecurityScheme{
+		Type: "http", Scheme: "bearer",
+		Description: "Personal access token created with POST /api/me/tokens, limited to its scopes.",
+	}
+	// Referenced by name from the inline health, JWKS and cache schemas above.
+	spec.SchemaOf(controller.DependencyStatus{})
+	spec.SchemaOf(controller.JSONWebKey{})
+	spec.SchemaOf(controller.CacheStatsResponse{})
+	problem := spec.SchemaOf(middleware.Problem{})
+
+	sort.Slice(routes, func(i, j int) bool { return routes[i].Path < routes[j].Path })
+	for _, route := range routes {
+		e, _ := lookupEndpoint(route.Method, route.Path)
+		version, apiPath := apiVersion(route.Path)
+		op := &openapi.Operation{
+			Summary:    e.summary,
+			Parameters: slices.Clone(e.query),
+			Security:   security(apiPath, e.scope),
+			Deprecated: v1Deprecated && version == 1,
+			Responses: map[string]*openapi.Response{
+				"default": {
+					Description: "Error",
+					Content:     map[string]openapi.MediaType{middleware.ProblemContentType: {Schema: problem}},
+				},
+			},
+		}
+		if e.idempotent {
+			op.Parameters = append(op.Parameters, idempotencyKeyHeader)
+		}
+		if e.tag != "" {
+			op.Tags = []string{e.tag}
+		}
+		if e.request != nil {
+			op.RequestBody = requestBody(spec, e.request)
+			op.RequestBody.Required = !e.optional
+		}
+		status := e.status
+		if status == 0 {
+			status = http.StatusOK
+		}
+		resp := response(spec, status, e.response)
+		if e.csv {
+			resp.Content["text/csv"] = openapi.MediaType{Schema: openapi.String()}
+		}
+		op.Responses[strconv.Itoa(status)] = resp
+		spec.Add(route.Method, route.Path, op)
+	}
+	return spec
+}
+
+// security returns who may call a route, given its path under /api: nobody needs to authenticate outside /api, account management under
+// /api/me requires a login session, and other /api routes also accept access tokens holding scope.
+func security(path, scope string) []openapi.SecurityRequirement {
+	switch {
+	case !strings.HasPrefix(path, "/api/"):
+		return nil
+	case strings.HasPrefix(path, "/api/me/"):
+		return []openapi.SecurityRequirement{{sessionScheme: {}}}
+	default:
+		return []openapi.SecurityRequirement{{sessionScheme: {}}, {accessTokenScheme: {scope}}}
+	}
+}
+
+// requestBody describes a JSON body, or the multipart form of an upload.
+func requestBody(spec *openapi.Document, body any) *openapi.RequestBody {
+	if body == uploadBody {
+		return &openapi.RequestBody{Required: true, Content: map[string]openapi.MediaType{"multipart/form-data": {Schema: uploadBody}}}
+	}
+	return &openapi.RequestBody{Required: true, Content: openapi.JSONContent(schemaOf(spec, body))}
+}
+
+// response describes a success response; binary schemas are served as application/octet-stream.
+func response(spec *openapi.Document, status int, body any) *openapi.Response {
+	resp := &openapi.Response{Description: http.StatusText(status)}
+	switch {
+	case body == nil:
+	case isBinary(body):
+		resp.Content = map[string]openapi.MediaType{"application/octet-stream": {Schema: body.(*openapi.Schema)}}
+	default:
+		resp.Content = openapi.JSONContent(schemaOf(spec, body))
+	}
+	return resp
+}
+
+// isBinary reports whether body is a schema for raw file content.
+func isBinary(body any) bool {
+	s, ok := body.(*openapi.Schema)
+	return ok && s.Format == "binary" && len(s.Properties) == 0
+}
+
+// schemaOf returns body itself if it is already a schema, or reflects its type.
+func schemaOf(spec *openapi.Document, body any) *openapi.Schema {
+	if s, ok := body.(*openapi.Schema); ok {
+		return s
+	}
+	return spec.SchemaOf(body)
+}
@@ -3,6 +3,8 @@ package router
 import (
 	"task_manager_test/internal/delivery/controller"
 	"task_manager_test/internal/delivery/middleware"
+	"task_manager_test/internal/delivery/openapi"
+	"task_manager_test/internal/domain"
 	"task_manager_test/internal/usecase"
 
 	"github.com/gin-gonic/gin"
@@ -10,34 +12,210 @@ import (
 
 // RouterConfig holds the dependencies for the router.
 type RouterConfig struct {
-	UserCont *controller.UserController
-	TaskCont *controller.TaskController
-	JwtSvc   usecase.IJWTService
+	UserCont       *controller.UserController
+	TaskCont       *controller.TaskController
+	TaskV2Cont     *controller.TaskControllerV2
+	ShareCont      *controller.TaskShareController
+	ProjectCont    *controller.ProjectController
+	OrgCont        *controller.OrganizationController
+	CommentCont    *controller.CommentController
+	AttachmentCont *controller.AttachmentController
+	PasswordCont   *controller.PasswordController
+	HealthCont     *controller.HealthController
+	JWKSCont       *controller.JWKSController
+	CacheCont      *controller.CacheController
+	AccountCont    *controller.AccountController
+	TimeCont       *controller.TimeController
+	JwtSvc         usecase.IJWTService
+	Sessions       usecase.ISessionValidator
+	AccessTokens   usecase.IAccessTokenAuthenticator
+	TokenCont      *controller.AccessTokenController
+	Policy         *usecase.AccessPolicy
+
+	// Optional rate limiters; a nil limiter disables that limit.
+	AuthIPLimiter       *middleware.RateLimiter
+	AuthUsernameLimiter *middleware.RateLimiter
+	APIUserLimiter      *middleware.RateLimiter
+
+	// IdempotencyKeys makes creation requests carrying an Idempotency-Key safe to retry; nil ignores the header.
+	IdempotencyKeys *middleware.IdempotencyKeys
+
+	// TrustedProxies lists the reverse proxies whose X-Forwarded-For header determines the client IP. With none, the
+	// header is ignored, so clients cannot choose the IP their requests are rate limited by.
+	TrustedProxies []string
+
+	// V1Deprecation marks responses from /api and /api/v1 as deprecated; nil leaves them unmarked.
+	V1Deprecation *middleware.Deprecation
+
+	// DocsUI serves a browsable rendering of the OpenAPI document at /docs.
+	DocsUI bool
+	// ValidateRequests rejects requests that do not match the OpenAPI document before they reach a handler.
+	ValidateRequests bool
 }
 
 // SetupRouter constructs the Gin engine with all application routes.
 func SetupRouter(cfg *RouterConfig) *gin.Engine {
 	r := gin.Default()
+	if err := r.SetTrustedProxies(cfg.TrustedProxies); err != nil {
+		// The configuration is validated before the router is set up, so this is a programming error.
+		panic(err)
+	}
 
-	// Public routes for registration and login functionality.
-	r.POST("/register", cfg.UserCont.Register)
-	r.POST("/login", cfg.UserCont.Login)
+	// Every response carries a request ID, and errors recorded by handlers and middleware are rendered as problems.
+	r.Use(middleware.RequestID(), middleware.ErrorHandler())
 
-	// Protected API routes require a valid JWT.
-	api := r.Group("/api")
-	api.Use(middleware.AuthMiddleware(cfg.JwtSvc))
+	// Liveness and readiness probes for orchestrators and load balancers.
+	r.GET("/healthz", cfg.HealthCont.Liveness)
+	r.GET("/readyz", cfg.HealthCont.Readiness)
+
+	// Public keys for services that verify our tokens independently.
+	r.GET("/.well-known/jwks.json", cfg.JWKSCont.JWKS)
+
+	// The OpenAPI document describes the routes registered below; it is filled in once they all are.
+	spec := new(openapi.Document)
+	docs := controller.NewDocsController(spec)
+	r.GET("/openapi.json", docs.OpenAPI)
+	if cfg.DocsUI {
+		r.GET("/docs", docs.UI)
+	}
+	validate := func(c *gin.Context) { c.Next() }
+	if cfg.ValidateRequests {
+		validate = middleware.ValidateRequests(spec)
+	}
+
+	// Public routes for registration and login functionality, rate limited per client IP and per username.
+	public := r.Group("/")
+	public.Use(
+		middleware.RateLimit(cfg.AuthIPLimiter, middleware.ClientIPKey),
+		middleware.RateLimit(cfg.AuthUsernameLimiter, middleware.BodyUsernameKey),
+		validate,
+	)
 	{
-		api.GET("/tasks", cfg.TaskCont.GetTasks)
-		api.POST("/tasks", cfg.TaskCont.CreateTask)
-		api.GET("/tasks/:id", cfg.TaskCont.GetTask)
-		api.PUT("/tasks/:id", cfg.TaskCont.UpdateTask)
-		api.DELETE("/tasks/:id", cfg.TaskCont.DeleteTask)
+		public.POST("/register", cfg.UserCont.Register)
+		public.POST("/login", cfg.UserCont.Login)
+		public.POST("/password/forgot", cfg.PasswordCont.ForgotPassword)
+		public.POST("/password/reset", cfg.PasswordCont.ResetPassword)
+	}
 
-		// Admin-only subgroup for dashboard access.
-		admin := api.Group("/admin")
-		admin.Use(middleware.AdminOnly())
-		admin.GET("/dashboard", cfg.TaskCont.AdminDashboard)
+	// Protected API routes require a valid JWT or personal access token and are rate limited per user.
+	// /api/v1 and its alias /api serve the original task representation and are deprecated in favour of /api/v2,
+	// which serves the same resources through the v2 task controller.
+	auth := []gin.HandlerFunc{
+		middleware.AuthMiddleware(cfg.JwtSvc, cfg.Sessions, cfg.AccessTokens),
+		middleware.RateLimit(cfg.APIUserLimiter, middleware.AuthenticatedUserKey),
+		validate,
 	}
+	v1 := taskHandlers{
+		list:          cfg.TaskCont.GetTasks,
+		get:           cfg.TaskCont.GetTask,
+		create:        cfg.TaskCont.CreateTask,
+		update:        cfg.TaskCont.UpdateTask,
+		agenda:        cfg.TaskCont.GetAgenda,
+		listProject:   cfg.ProjectCont.ListProjectTasks,
+		createProject: cfg.ProjectCont.CreateProjectTask,
+	}
+	for _, prefix := range []string{"/api", "/api/v1"} {
+		group := r.Group(prefix)
+		if cfg.V1Deprecation != nil {
+			group.Use(middleware.Deprecated(*cfg.V1Deprecation))
+		}
+		group.Use(auth...)
+		registerAPI(group, cfg, v1)
+	}
+	v2 := r.Group("/api/v2")
+	v2.Use(auth...)
+	registerAPI(v2, cfg, taskHandlers{
+		list:          cfg.TaskV2Cont.GetTasks,
+		get:           cfg.TaskV2Cont.GetTask,
+		create:        cfg.TaskV2Cont.CreateTask,
+		update:        cfg.TaskV2Cont.UpdateTask,
+		agenda:        cfg.TaskV2Cont.GetAgenda,
+		listProject:   cfg.TaskV2Cont.ListProjectTasks,
+		createProject: cfg.TaskV2Cont.CreateProjectTask,
+	})
 
+	*spec = *buildOpenAPI(r.Routes(), cfg.V1Deprecation != nil)
 	return r
 }
+
+// taskHandlers are the handlers whose request or response bodies contain tasks, and so differ between API versions.
+type taskHandlers struct {
+	list, get, create, update  gin.HandlerFunc
+	agenda                     gin.HandlerFunc
+	listProject, createProject gin.HandlerFunc
+}
+
+// registerAPI registers the authenticated API routes on group, serving task bodies with the given handlers.
+// Each route requires a permission granted by the caller's role, and access tokens must additionally hold the
+// scope the route requires.
+func registerAPI(group *gin.RouterGroup, cfg *RouterConfig, tasks taskHandlers) {
+	readTasks := middleware.RequireScope(domain.ScopeTasksRead)
+	writeTasks := middleware.RequireScope(domain.ScopeTasksWrite)
+	can := func(perms ...domain.Permission) gin.HandlerFunc {
+		return middleware.RequirePermission(cfg.Policy, perms...)
+	}
+	// Requests that create resources may be retried safely with an Idempotency-Key.
+	idempotent := middleware.Idempotent(cfg.IdempotencyKeys)
+
+	group.GET("/tasks", readTasks, can(domain.PermTaskReadOwn, domain.PermTaskReadAny), tasks.list)
+	group.POST("/tasks", writeTasks, can(domain.PermTaskCreate), idempotent, tasks.create)
+	group.GET("/tasks/agenda", readTasks, can(domain.PermTaskReadOwn), tasks.agenda)
+	group.GET("/tasks/:id", readTasks, can(domain.PermTaskReadOwn, domain.PermTaskReadAny), tasks.get)
+	group.PUT("/tasks/:id", writeTasks, can(domain.PermTaskUpdateOwn, domain.PermTaskUpdateAny), tasks.update)
+	group.DELETE("/tasks/:id", writeTasks, can(domain.PermTaskDeleteOwn, domain.PermTaskDeleteAny), cfg.TaskCont.DeleteTask)
+	group.GET("/tasks/:id/shares", readTasks, can(domain.PermTaskReadOwn, domain.PermTaskReadAny), cfg.ShareCont.ListShares)
+	group.POST("/tasks/:id/shares", writeTasks, can(domain.PermTaskUpdateOwn, domain.PermTaskUpdateAny), idempotent, cfg.ShareCont.ShareTask)
+	group.DELETE("/tasks/:id/shares/:username", writeTasks, can(domain.PermTaskUpdateOwn, domain.PermTaskUpdateAny), cfg.ShareCont.RevokeShare)
+	group.GET("/tasks/:id/comments", readTasks, can(domain.PermTaskReadOwn, domain.PermTaskReadAny), cfg.CommentCont.ListComments)
+	group.POST("/tasks/:id/comments", writeTasks, can(domain.PermTaskReadOwn, domain.PermTaskReadAny), idempotent, cfg.CommentCont.CreateComment)
+	group.PUT("/tasks/:id/comments/:cid", writeTasks, can(domain.PermTaskReadOwn, domain.PermTaskReadAny), cfg.CommentCont.UpdateComment)
+	group.DELETE("/tasks/:id/comments/:cid", writeTasks, can(domain.PermTaskReadOwn, domain.PermTaskReadAny), cfg.CommentCont.DeleteComment)
+	group.GET("/tasks/:id/time", readTasks, can(domain.PermTaskReadOwn, domain.PermTaskReadAny), cfg.TimeCont.GetTaskTime)
+	group.POST("/tasks/:id/time", writeTasks, can(domain.PermTaskUpdateOwn, domain.PermTaskUpdateAny), idempotent, cfg.TimeCont.LogTime)
+	group.POST("/tasks/:id/time/start", writeTasks, can(domain.PermTaskUpdateOwn, domain.PermTaskUpdateAny), cfg.TimeCont.StartTimer)
+	group.POST("/tasks/:id/time/stop", writeTasks, can(domain.PermTaskUpdateOwn, domain.PermTaskUpdateAny), cfg.TimeCont.StopTimer)
+	group.GET("/timesheet", readTasks, can(domain.PermTaskReadOwn), cfg.TimeCont.GetTimesheet)
+	group.GET("/tasks/:id/attachments", readTasks, can(domain.PermTaskReadOwn, domain.PermTaskReadAny), cfg.AttachmentCont.ListAttachments)
+	group.POST("/tasks/:id/attachments", writeTasks, can(domain.PermTaskUpdateOwn, domain.PermTaskUpdateAny), cfg.AttachmentCont.UploadAttachment)
+	group.GET("/tasks/:id/attachments/:aid", readTasks, can(domain.PermTaskReadOwn, domain.PermTaskReadAny), cfg.AttachmentCont.DownloadAttachment)
+	group.DELETE("/tasks/:id/attachments/:aid", writeTasks, can(domain.PermTaskUpdateOwn, domain.PermTaskUpdateAny), cfg.AttachmentCont.DeleteAttachment)
+
+	// Projects are visible to their members only; roles within a project are checked by the use cases.
+	group.GET("/projects", readTasks, cfg.ProjectCont.ListProjects)
+	group.POST("/projects", writeTasks, can(domain.PermProjectCreate), idempotent, cfg.ProjectCont.CreateProject)
+	group.GET("/projects/:pid", readTasks, cfg.ProjectCont.GetProject)
+	group.PUT("/projects/:pid", writeTasks, cfg.ProjectCont.UpdateProject)
+	group.DELETE("/projects/:pid", writeTasks, cfg.ProjectCont.DeleteProject)
+	group.PUT("/projects/:pid/members/:username", writeTasks, cfg.ProjectCont.SetMember)
+	group.DELETE("/projects/:pid/members/:username", writeTasks, cfg.ProjectCont.RemoveMember)
+	group.GET("/projects/:pid/tasks", readTasks, can(domain.PermTaskReadOwn), tasks.listProject)
+	group.POST("/projects/:pid/tasks", writeTasks, can(domain.PermTaskCreate), idempotent, tasks.createProject)
+
+	// Every caller may see its own organization; the rest of the data it can reach is confined to it.
+	group.GET("/org", cfg.OrgCont.CurrentOrganization)
+
+	// Account management requires an interactive login rather than an access token.
+	me := group.Group("/me")
+	me.Use(middleware.RequireSession())
+	me.GET("", cfg.UserCont.GetMe)
+	me.PATCH("", cfg.UserCont.UpdateMe)
+	me.DELETE("", cfg.AccountCont.DeleteMe)
+	me.GET("/export", cfg.AccountCont.ExportMe)
+	me.PUT("/password", cfg.PasswordCont.ChangePassword)
+	me.POST("/tokens", cfg.TokenCont.CreateToken)
+	me.GET("/tokens", cfg.TokenCont.ListTokens)
+	me.DELETE("/tokens/:id", cfg.TokenCont.RevokeToken)
+
+	// Administrative subgroup; each route requires its own permission.
+	admin := group.Group("/admin")
+	admin.Use(middleware.RequireScope(domain.ScopeAdmin))
+	admin.GET("/dashboard", can(domain.PermAdminDashboard), cfg.TaskCont.AdminDashboard)
+	admin.POST("/users", can(domain.PermUserManage), cfg.UserCont.CreateUser)
+	admin.PUT("/users/:username/role", can(domain.PermUserManage), cfg.UserCont.ChangeRole)
+	admin.GET("/users/:username/export", can(domain.PermUserManage), cfg.AccountCont.ExportUser)
+	admin.DELETE("/users/:username", can(domain.PermUserManage), cfg.AccountCont.DeleteUser)
+	admin.GET("/cache", can(domain.PermAdminDashboard), cfg.CacheCont.Stats)
+	// Organizations are managed by super admins only, which the use cases check.
+	admin.GET("/orgs", cfg.OrgCont.ListOrganizations)
+	admin.POST("/orgs", cfg.OrgCont.CreateOrganization)
+}
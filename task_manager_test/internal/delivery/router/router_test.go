@@ -1,6 +1,7 @@
 package router
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -8,22 +9,43 @@ import (
 	"runtime"
 	"strings"
 	"task_manager_test/internal/delivery/controller"
+	"task_manager_test/internal/delivery/middleware"
+	"task_manager_test/internal/delivery/openapi"
+	"task_manager_test/internal/domain"
 	"task_manager_test/internal/mocks"
+	"task_manager_test/internal/usecase"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 )
 
 // RouterTestSuite defines the test suite for the main application router.
 type RouterTestSuite struct {
 	suite.Suite
-	router       *gin.Engine
-	mockUserCont *controller.UserController
-	mockTaskCont *controller.TaskController
-	mockJwtSvc   *mocks.IJWTService
+	router         *gin.Engine
+	mockUserCont   *controller.UserController
+	mockTaskCont   *controller.TaskController
+	taskV2Cont     *controller.TaskControllerV2
+	shareCont      *controller.TaskShareController
+	projectCont    *controller.ProjectController
+	orgCont        *controller.OrganizationController
+	commentCont    *controller.CommentController
+	attachmentCont *controller.AttachmentController
+	passwordCont   *controller.PasswordController
+	healthCont     *controller.HealthController
+	jwksCont       *controller.JWKSController
+	cacheCont      *controller.CacheController
+	tokenCont      *controller.AccessTokenController
+	accountCont    *controller.AccountController
+	timeCont       *controller.TimeController
+	mockJwtSvc     *mocks.IJWTService
+	mockSessions   *mocks.ISessionValidator
+	mockPATs       *mocks.IAccessTokenAuthenticator
 }
 
 // getHandlerName retrieves the full function name for a given handler.
@@ -37,14 +59,24 @@ func (s *RouterTestSuite) SetupTest() {
 
 	s.mockUserCont = &controller.UserController{}
 	s.mockTaskCont = &controller.TaskController{}
+	s.taskV2Cont = &controller.TaskControllerV2{}
+	s.shareCont = &controller.TaskShareController{}
+	s.projectCont = &controller.ProjectController{}
+	s.orgCont = &controller.OrganizationController{}
+	s.commentCont = &controller.CommentController{}
+	s.attachmentCont = &controller.AttachmentController{}
+	s.passwordCont = &controller.PasswordController{}
+	s.tokenCont = &controller.AccessTokenController{}
+	s.accountCont = &controller.AccountController{}
+	s.timeCont = &controller.TimeController{}
+	s.healthCont = controller.NewHealthController()
 	s.mockJwtSvc = new(mocks.IJWTService)
+	s.mockSessions = new(mocks.ISessionValidator)
+	s.mockPATs = new(mocks.IAccessTokenAuthenticator)
+	s.jwksCont = controller.NewJWKSController(s.mockJwtSvc)
+	s.cacheCont = controller.NewCacheController(nil)
 
-	cfg := &RouterConfig{
-		UserCont: s.mockUserCont,
-		TaskCont: s.mockTaskCont,
-		JwtSvc:   s.mockJwtSvc,
-	}
-	s.router = SetupRouter(cfg)
+	s.router = SetupRouter(s.config())
 }
 
 // TestRouter runs the entire test suite.
@@ -55,14 +87,59 @@ func TestRouter(t *testing.T) {
 // TestRouteRegistration verifies that all expected routes are registered correctly.
 func (s *RouterTestSuite) TestRouteRegistration() {
 	expectedRoutes := map[string]string{
-		"POST:/register":           getHandlerName(s.mockUserCont.Register),
-		"POST:/login":              getHandlerName(s.mockUserCont.Login),
-		"GET:/api/tasks":           getHandlerName(s.mockTaskCont.GetTasks),
-		"POST:/api/tasks":          getHandlerName(s.mockTaskCont.CreateTask),
-		"GET:/api/tasks/:id":       getHandlerName(s.mockTaskCont.GetTask),
-		"PUT:/api/tasks/:id":       getHandlerName(s.mockTaskCont.UpdateTask),
-		"DELETE:/api/tasks/:id":    getHandlerName(s.mockTaskCont.DeleteTask),
-		"GET:/api/admin/dashboard": getHandlerName(s.mockTaskCont.AdminDashboard),
+		"GET:/healthz":                                getHandlerName(s.healthCont.Liveness),
+		"GET:/readyz":                                 getHandlerName(s.healthCont.Readiness),
+		"GET:/.well-known/jwks.json":                  getHandlerName(s.jwksCont.JWKS),
+		"GET:/openapi.json":                           "OpenAPI-fm",
+		"POST:/register":                              getHandlerName(s.mockUserCont.Register),
+		"POST:/login":                                 getHandlerName(s.mockUserCont.Login),
+		"POST:/password/forgot":                       getHandlerName(s.passwordCont.ForgotPassword),
+		"POST:/password/reset":                        getHandlerName(s.passwordCont.ResetPassword),
+		"PUT:/api/me/password":                        getHandlerName(s.passwordCont.ChangePassword),
+		"POST:/api/me/tokens":                         getHandlerName(s.tokenCont.CreateToken),
+		"GET:/api/me/tokens":                          getHandlerName(s.tokenCont.ListTokens),
+		"DELETE:/api/me/tokens/:id":                   getHandlerName(s.tokenCont.RevokeToken),
+		"GET:/api/me":                                 getHandlerName(s.mockUserCont.GetMe),
+		"PATCH:/api/me":                               getHandlerName(s.mockUserCont.UpdateMe),
+		"DELETE:/api/me":                              getHandlerName(s.accountCont.DeleteMe),
+		"GET:/api/me/export":                          getHandlerName(s.accountCont.ExportMe),
+		"GET:/api/tasks":                              getHandlerName(s.mockTaskCont.GetTasks),
+		"POST:/api/tasks":                             getHandlerName(s.mockTaskCont.CreateTask),
+		"GET:/api/tasks/agenda":                       getHandlerName(s.mockTaskCont.GetAgenda),
+		"GET:/api/tasks/:id":                          getHandlerName(s.mockTaskCont.GetTask),
+		"PUT:/api/tasks/:id":                          getHandlerName(s.mockTaskCont.UpdateTask),
+		"DELETE:/api/tasks/:id":                       getHandlerName(s.mockTaskCont.DeleteTask),
+		"GET:/api/tasks/:id/comments":                 getHandlerName(s.commentCont.ListComments),
+		"POST:/api/tasks/:id/comments":                getHandlerName(s.commentCont.CreateComment),
+		"PUT:/api/tasks/:id/comments/:cid":            getHandlerName(s.commentCont.UpdateComment),
+		"DELETE:/api/tasks/:id/comments/:cid":         getHandlerName(s.commentCont.DeleteComment),
+		"GET:/api/tasks/:id/attachments":              getHandlerName(s.attachmentCont.ListAttachments),
+		"POST:/api/tasks/:id/attachments":             getHandlerName(s.attachmentCont.UploadAttachment),
+		"GET:/api/tasks/:id/attachments/:aid":         getHandlerName(s.attachmentCont.DownloadAttachment),
+		"DELETE:/api/tasks/:id/attachments/:aid":      getHandlerName(s.attachmentCont.DeleteAttachment),
+		"GET:/api/tasks/:id/time":                     getHandlerName(s.timeCont.GetTaskTime),
+		"POST:/api/tasks/:id/time":                    getHandlerName(s.timeCont.LogTime),
+		"POST:/api/tasks/:id/time/start":              getHandlerName(s.timeCont.StartTimer),
+		"POST:/api/tasks/:id/time/stop":               getHandlerName(s.timeCont.StopTimer),
+		"GET:/api/timesheet":                          getHandlerName(s.timeCont.GetTimesheet),
+		"GET:/api/projects":                           getHandlerName(s.projectCont.ListProjects),
+		"POST:/api/projects":                          getHandlerName(s.projectCont.CreateProject),
+		"GET:/api/projects/:pid":                      getHandlerName(s.projectCont.GetProject),
+		"PUT:/api/projects/:pid":                      getHandlerName(s.projectCont.UpdateProject),
+		"DELETE:/api/projects/:pid":                   getHandlerName(s.projectCont.DeleteProject),
+		"PUT:/api/projects/:pid/members/:username":    getHandlerName(s.projectCont.SetMember),
+		"DELETE:/api/projects/:pid/members/:username": getHandlerName(s.projectCont.RemoveMember),
+		"GET:/api/projects/:pid/tasks":                getHandlerName(s.projectCont.ListProjectTasks),
+		"POST:/api/projects/:pid/tasks":               getHandlerName(s.projectCont.CreateProjectTask),
+		"GET:/api/admin/dashboard":                    getHandlerName(s.mockTaskCont.AdminDashboard),
+		"GET:/api/org":                                getHandlerName(s.orgCont.CurrentOrganization),
+		"POST:/api/admin/users":                       getHandlerName(s.mockUserCont.CreateUser),
+		"PUT:/api/admin/users/:username/role":         getHandlerName(s.mockUserCont.ChangeRole),
+		"GET:/api/admin/users/:username/export":       getHandlerName(s.accountCont.ExportUser),
+		"DELETE:/api/admin/users/:username":           getHandlerName(s.accountCont.DeleteUser),
+		"GET:/api/admin/cache":                        getHandlerName(s.cacheCont.Stats),
+		"GET:/api/admin/orgs":                         getHandlerName(s.orgCont.ListOrganizations),
+		"POST:/api/admin/orgs":                        getHandlerName(s.orgCont.CreateOrganization),
 	}
 
 	registeredRoutes := s.router.Routes()
@@ -87,27 +164,308 @@ func (s *RouterTestSuite) TestPublicRoutes() {
 	assert.Equal(s.T(), http.StatusBadRequest, w.Code, "Public routes should not be blocked by auth middleware")
 }
 
+// TestHealthRoutesArePublic checks that the probes are reachable without authentication.
+func (s *RouterTestSuite) TestHealthRoutesArePublic() {
+	for _, path := range []string{"/healthz", "/readyz"} {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, path, nil)
+		s.router.ServeHTTP(w, req)
+		assert.Equal(s.T(), http.StatusOK, w.Code, "Probe %s should not be blocked by auth middleware", path)
+	}
+}
+
+// problem decodes the problem details rendered for a rejected request.
+func (s *RouterTestSuite) problem(w *httptest.ResponseRecorder) middleware.Problem {
+	assert.Equal(s.T(), middleware.ProblemContentType, w.Header().Get("Content-Type"))
+	var p middleware.Problem
+	assert.NoError(s.T(), json.Unmarshal(w.Body.Bytes(), &p))
+	return p
+}
+
 // TestAuthMiddlewareIsApplied verifies that routes under the /api group are protected.
 func (s *RouterTestSuite) TestAuthMiddlewareIsApplied() {
 	w := httptest.NewRecorder()
 	req, _ := http.NewRequest(http.MethodGet, "/api/tasks", nil)
 	s.router.ServeHTTP(w, req)
 	assert.Equal(s.T(), http.StatusUnauthorized, w.Code, "Routes under /api should be protected")
-	assert.JSONEq(s.T(), `{"error": "missing token"}`, w.Body.String(), "Should return a missing token error")
+	assert.Equal(s.T(), "missing bearer token", s.problem(w).Detail, "Should return a missing token problem")
+	assert.NotEmpty(s.T(), w.Header().Get(middleware.RequestIDHeader), "Every response should carry a request ID")
 }
 
-// TestAdminOnlyMiddlewareIsApplied verifies that routes under the /api/admin group are protected.
-func (s *RouterTestSuite) TestAdminOnlyMiddlewareIsApplied() {
+// TestPermissionsAreApplied verifies that routes under the /api/admin group require their permission.
+func (s *RouterTestSuite) TestPermissionsAreApplied() {
 	validUserToken := "a-valid-user-token"
-	userClaims := jwt.MapClaims{"username": "testuser", "role": "user"}
+	userClaims := jwt.MapClaims{"sub": "user-123", "username": "testuser", "role": "user", "org": "org-1"}
 	s.mockJwtSvc.On("ValidateToken", validUserToken).Return(userClaims, nil).Once()
+	s.mockSessions.On("ValidateSession", mock.Anything, "user-123", 0).Return(nil).Once()
 
 	w := httptest.NewRecorder()
 	req, _ := http.NewRequest(http.MethodGet, "/api/admin/dashboard", nil)
 	req.Header.Set("Authorization", "Bearer "+validUserToken)
 	s.router.ServeHTTP(w, req)
 
-	assert.Equal(s.T(), http.StatusForbidden, w.Code, "Routes under /api/admin should be protected by AdminOnly middleware")
-	assert.JSONEq(s.T(), `{"error": "admin access required"}`, w.Body.String(), "Should return an admin access required error")
+	assert.Equal(s.T(), http.StatusForbidden, w.Code, "Routes under /api/admin should require the admin.dashboard permission")
+	assert.Equal(s.T(), "forbidden", s.problem(w).Code, "Should return a permission denied problem")
 	s.mockJwtSvc.AssertExpectations(s.T())
 }
+
+// TestScopesAreApplied verifies that access tokens are limited to their scopes and kept out of account management.
+func (s *RouterTestSuite) TestScopesAreApplied() {
+	pat := usecase.AccessTokenPrefix + "read-only"
+	s.mockPATs.On("Authenticate", mock.Anything, pat).Return(
+		domain.AccessToken{Scopes: []string{domain.ScopeTasksRead}},
+		domain.User{ID: "user-1", Username: "ci-bot", Role: "admin"},
+		nil,
+	)
+
+	for _, tc := range []struct{ method, path, wantError string }{
+		{http.MethodDelete, "/api/tasks/123", "token is missing the required scope: tasks:write"},
+		{http.MethodGet, "/api/admin/dashboard", "token is missing the required scope: admin"},
+		{http.MethodGet, "/api/me/tokens", "this endpoint cannot be used with an access token"},
+	} {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(tc.method, tc.path, nil)
+		req.Header.Set("Authorization", "Bearer "+pat)
+		s.router.ServeHTTP(w, req)
+
+		assert.Equal(s.T(), http.StatusForbidden, w.Code, "%s %s should be forbidden for a read-only token", tc.method, tc.path)
+		assert.Equal(s.T(), tc.wantError, s.problem(w).Detail)
+	}
+}
+
+// TestRateLimitersAreApplied verifies that configured limiters guard the auth routes.
+func (s *RouterTestSuite) TestRateLimitersAreApplied() {
+	cfg := &RouterConfig{
+		UserCont:      s.mockUserCont,
+		TaskCont:      s.mockTaskCont,
+		PasswordCont:  s.passwordCont,
+		HealthCont:    s.healthCont,
+		JWKSCont:      s.jwksCont,
+		TokenCont:     s.tokenCont,
+		JwtSvc:        s.mockJwtSvc,
+		Sessions:      s.mockSessions,
+		AuthIPLimiter: middleware.NewRateLimiter(1, time.Minute),
+	}
+	router := SetupRouter(cfg)
+
+	first := httptest.NewRecorder()
+	router.ServeHTTP(first, httptest.NewRequest(http.MethodPost, "/login", nil))
+	second := httptest.NewRecorder()
+	router.ServeHTTP(second, httptest.NewRequest(http.MethodPost, "/login", nil))
+
+	assert.Equal(s.T(), http.StatusBadRequest, first.Code, "The first request should reach the controller")
+	assert.Equal(s.T(), http.StatusTooManyRequests, second.Code, "The second request should be rate limited")
+	assert.NotEmpty(s.T(), second.Header().Get("Retry-After"))
+}
+
+// TestRateLimitIgnoresForwardedForFromUntrustedClients verifies that a client cannot escape the per-IP limit by
+// claiming another address in X-Forwarded-For, while a trusted proxy's header is honoured.
+func (s *RouterTestSuite) TestRateLimitIgnoresForwardedForFromUntrustedClients() {
+	login := func(router *gin.Engine, forwardedFor string) int {
+		req := httptest.NewRequest(http.MethodPost, "/login", nil)
+		req.RemoteAddr = "192.0.2.1:1234"
+		req.Header.Set("X-Forwarded-For", forwardedFor)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	cfg := s.config()
+	cfg.AuthIPLimiter = middleware.NewRateLimiter(1, time.Minute)
+	router := SetupRouter(cfg)
+	assert.Equal(s.T(), http.StatusBadRequest, login(router, "198.51.100.1"))
+	assert.Equal(s.T(), http.StatusTooManyRequests, login(router, "198.51.100.2"), "A spoofed address should not reset the limit")
+
+	cfg = s.config()
+	cfg.AuthIPLimiter = middleware.NewRateLimiter(1, time.Minute)
+	cfg.TrustedProxies = []string{"192.0.2.0/24"}
+	router = SetupRouter(cfg)
+	assert.Equal(s.T(), http.StatusBadRequest, login(router, "198.51.100.1"))
+	assert.Equal(s.T(), http.StatusBadRequest, login(router, "198.51.100.2"), "Clients behind a trusted proxy should be limited separately")
+	assert.Equal(s.T(), http.StatusTooManyRequests, login(router, "198.51.100.1"))
+}
+
+// config returns a router configuration using the suite's controllers and mocks.
+func (s *RouterTestSuite) config() *RouterConfig {
+	return &RouterConfig{
+		UserCont:       s.mockUserCont,
+		TaskCont:       s.mockTaskCont,
+		TaskV2Cont:     s.taskV2Cont,
+		ShareCont:      s.shareCont,
+		ProjectCont:    s.projectCont,
+		OrgCont:        s.orgCont,
+		CommentCont:    s.commentCont,
+		AttachmentCont: s.attachmentCont,
+		PasswordCont:   s.passwordCont,
+		HealthCont:     s.healthCont,
+		JWKSCont:       s.jwksCont,
+		CacheCont:      s.cacheCont,
+		AccountCont:    s.accountCont,
+		TimeCont:       s.timeCont,
+		TokenCont:      s.tokenCont,
+		JwtSvc:         s.mockJwtSvc,
+		Sessions:       s.mockSessions,
+		AccessTokens:   s.mockPATs,
+		Policy:         usecase.DefaultAccessPolicy(),
+	}
+}
+
+// TestOpenAPIDocumentsEveryRoute verifies that the endpoint table matches the registered routes exactly and that the
+// served document lists them.
+func (s *RouterTestSuite) TestOpenAPIDocumentsEveryRoute() {
+	cfg := s.config()
+	cfg.DocsUI = true
+	router := SetupRouter(cfg)
+
+	registered := make(map[string]bool)
+	for _, route := range router.Routes() {
+		registered[route.Method+" "+route.Path] = true
+		_, ok := lookupEndpoint(route.Method, route.Path)
+		assert.True(s.T(), ok, "Route %s %s should be documented", route.Method, route.Path)
+	}
+	for key := range endpoints {
+		assert.True(s.T(), registered[key], "Documented route %s is not registered", key)
+	}
+	for key := range endpointsV2 {
+		method, path, _ := strings.Cut(key, " ")
+		assert.True(s.T(), registered[method+" /api/v2/"+strings.TrimPrefix(path, "/api/")], "Documented v2 route %s is not registered", key)
+	}
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+	assert.Equal(s.T(), http.StatusOK, w.Code)
+	var doc struct {
+		OpenAPI string                                `json:"openapi"`
+		Paths   map[string]map[string]json.RawMessage `json:"paths"`
+	}
+	assert.NoError(s.T(), json.Unmarshal(w.Body.Bytes(), &doc))
+	assert.Equal(s.T(), "3.1.0", doc.OpenAPI)
+	assert.Contains(s.T(), doc.Paths["/api/tasks/{id}"], "put")
+	assert.Contains(s.T(), doc.Paths["/api/projects/{pid}/members/{username}"], "delete")
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/docs", nil))
+	assert.Equal(s.T(), http.StatusOK, w.Code, "The docs UI should be served when enabled")
+}
+
+// TestOpenAPISecurity verifies how the document describes authentication.
+func (s *RouterTestSuite) TestOpenAPISecurity() {
+	spec := buildOpenAPI(s.router.Routes(), false)
+
+	assert.Empty(s.T(), spec.Operation(http.MethodPost, "/login").Security, "Public routes need no authentication")
+	assert.Equal(s.T(), []openapi.SecurityRequirement{{sessionScheme: {}}}, spec.Operation(http.MethodGet, "/api/me/tokens").Security)
+	assert.Equal(s.T(),
+		[]openapi.SecurityRequirement{{sessionScheme: {}}, {accessTokenScheme: {domain.ScopeTasksWrite}}},
+		spec.Operation(http.MethodDelete, "/api/tasks/{id}").Security)
+	assert.Contains(s.T(), spec.Components.Schemas, "TaskResponse")
+	assert.Contains(s.T(), spec.Components.Schemas, "TaskResponseV2")
+	assert.False(s.T(), spec.Operation(http.MethodGet, "/api/v1/tasks").Deprecated, "v1 is only deprecated once a date is configured")
+	deprecated := buildOpenAPI(s.router.Routes(), true)
+	assert.True(s.T(), deprecated.Operation(http.MethodGet, "/api/v1/tasks").Deprecated)
+	assert.False(s.T(), deprecated.Operation(http.MethodGet, "/api/v2/tasks").Deprecated)
+	assert.Equal(s.T(), []openapi.SecurityRequirement{{sessionScheme: {}}}, spec.Operation(http.MethodGet, "/api/v2/me/tokens").Security)
+	assert.Contains(s.T(), spec.Components.Schemas, "Problem")
+}
+
+// TestOpenAPIDocumentsIdempotencyKeys verifies that creation routes, and only those, document the Idempotency-Key
+// header.
+func (s *RouterTestSuite) TestOpenAPIDocumentsIdempotencyKeys() {
+	spec := buildOpenAPI(s.router.Routes(), false)
+
+	assert.Contains(s.T(), spec.Operation(http.MethodPost, "/api/tasks").Parameters, idempotencyKeyHeader)
+	assert.Contains(s.T(), spec.Operation(http.MethodPost, "/api/v2/projects/{pid}/tasks").Parameters, idempotencyKeyHeader)
+	assert.NotContains(s.T(), spec.Operation(http.MethodPut, "/api/tasks/{id}").Parameters, idempotencyKeyHeader)
+}
+
+// TestOpenAPIDocumentsTimeTracking verifies that the body of a timer start may be omitted and that the timesheet
+// may also be served as CSV.
+func (s *RouterTestSuite) TestOpenAPIDocumentsTimeTracking() {
+	spec := buildOpenAPI(s.router.Routes(), false)
+
+	assert.False(s.T(), spec.Operation(http.MethodPost, "/api/tasks/{id}/time/start").RequestBody.Required)
+	assert.True(s.T(), spec.Operation(http.MethodPost, "/api/tasks/{id}/time").RequestBody.Required)
+	content := spec.Operation(http.MethodGet, "/api/timesheet").Responses["200"].Content
+	assert.Contains(s.T(), content, "application/json")
+	assert.Contains(s.T(), content, "text/csv")
+}
+
+// TestDocsUIIsOptional verifies that /docs is only served when enabled.
+func (s *RouterTestSuite) TestDocsUIIsOptional() {
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/docs", nil))
+	assert.Equal(s.T(), http.StatusNotFound, w.Code)
+}
+
+// TestRequestValidationIsOptIn verifies that enabled validation rejects bodies that do not match the document
+// before they reach a handler.
+func (s *RouterTestSuite) TestRequestValidationIsOptIn() {
+	cfg := s.config()
+	cfg.ValidateRequests = true
+	router := SetupRouter(cfg)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/register", strings.NewReader(`{"username": "alice", "role": 5}`))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	assert.Equal(s.T(), http.StatusBadRequest, w.Code)
+	p := s.problem(w)
+	assert.Equal(s.T(), "request body failed validation", p.Detail)
+	assert.Equal(s.T(), []middleware.FieldError{
+		{Field: "password", Message: "is required"},
+		{Field: "role", Message: "must be a string"},
+	}, p.Errors)
+}
+
+// TestVersionedRoutes verifies that /api/v1 aliases /api and that /api/v2 serves tasks through the v2 controller
+// while sharing the other handlers.
+func (s *RouterTestSuite) TestVersionedRoutes() {
+	actualRoutes := make(map[string]string)
+	for _, route := range s.router.Routes() {
+		actualRoutes[route.Method+":"+route.Path] = route.Handler
+	}
+
+	for key, handler := range map[string]string{
+		"GET:/api/v1/tasks":                getHandlerName(s.mockTaskCont.GetTasks),
+		"DELETE:/api/v1/projects/:pid":     getHandlerName(s.projectCont.DeleteProject),
+		"GET:/api/v1/admin/dashboard":      getHandlerName(s.mockTaskCont.AdminDashboard),
+		"GET:/api/v2/tasks":                getHandlerName(s.taskV2Cont.GetTasks),
+		"PUT:/api/v2/tasks/:id":            getHandlerName(s.taskV2Cont.UpdateTask),
+		"GET:/api/v2/tasks/agenda":         getHandlerName(s.taskV2Cont.GetAgenda),
+		"DELETE:/api/v2/tasks/:id":         getHandlerName(s.mockTaskCont.DeleteTask),
+		"POST:/api/v2/projects/:pid/tasks": getHandlerName(s.taskV2Cont.CreateProjectTask),
+		"GET:/api/v2/tasks/:id/comments":   getHandlerName(s.commentCont.ListComments),
+		"POST:/api/v2/me/tokens":           getHandlerName(s.tokenCont.CreateToken),
+	} {
+		actual, ok := actualRoutes[key]
+		assert.True(s.T(), ok, "Expected route %s to be registered", key)
+		assert.True(s.T(), strings.HasSuffix(actual, handler), "Route %s is registered with wrong handler. Expected %s, got %s", key, handler, actual)
+	}
+}
+
+// TestV1IsDeprecated verifies that v1 responses, including errors, carry the deprecation headers and v2 ones do not.
+func (s *RouterTestSuite) TestV1IsDeprecated() {
+	deprecation := &middleware.Deprecation{
+		Since:     time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC),
+		Sunset:    time.Date(2027, 4, 18, 0, 0, 0, 0, time.UTC),
+		Successor: "/api/v2",
+	}
+	cfg := s.config()
+	cfg.V1Deprecation = deprecation
+	router := SetupRouter(cfg)
+
+	for path, deprecated := range map[string]bool{"/api/tasks": true, "/api/v1/tasks": true, "/api/v2/tasks": false} {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+
+		assert.Equal(s.T(), http.StatusUnauthorized, w.Code)
+		if deprecated {
+			assert.Equal(s.T(), "@1792281600", w.Header().Get("Deprecation"), path)
+			assert.Equal(s.T(), "Sun, 18 Apr 2027 00:00:00 GMT", w.Header().Get("Sunset"), path)
+			assert.Equal(s.T(), `</api/v2>; rel="successor-version"`, w.Header().Get("Link"), path)
+		} else {
+			assert.Empty(s.T(), w.Header().Get("Deprecation"), path)
+			assert.Empty(s.T(), w.Header().Get("Sunset"), path)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"task_manager_test/internal/domain"
+	"task_manager_test/internal/usecase"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// publicMethodPrefix selects the calls that need no credentials: registering and logging in.
+const publicMethodPrefix = "/taskmanager.v1.AuthService/"
+
+// invalidToken reports a bearer token that is malformed, expired or no longer belongs to a user.
+var invalidToken = usecase.WithDetail(usecase.ErrUnauthenticated, "invalid or expired token")
+
+// authenticator validates the JWT in the "authorization" metadata of a call, like middleware.AuthMiddleware does
+// for the Authorization header. Personal access tokens are not accepted.
+type authenticator struct {
+	jwtSvc   usecase.IJWTService
+	sessions usecase.ISessionValidator
+}
+
+// authenticate returns ctx carrying the caller as the use cases' actor.
+func (a authenticator) authenticate(ctx context.Context) (context.Context, error) {
+	var auth string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get("authorization"); len(values) > 0 {
+			auth = values[0]
+		}
+	}
+	if !strings.HasPrefix(auth, "Bearer ") {
+		return nil, usecase.WithDetail(usecase.ErrUnauthenticated, "missing bearer token")
+	}
+	claims, err := a.jwtSvc.ValidateToken(strings.TrimPrefix(auth, "Bearer "))
+	if err != nil {
+		return nil, invalidToken
+	}
+	userID, _ := claims["sub"].(string)
+	username, _ := claims["username"].(string)
+	// Tokens issued before organizations existed cannot be confined to one and are refused.
+	orgID, _ := claims["org"].(string)
+	if orgID == "" {
+		return nil, invalidToken
+	}
+	// Tokens issued before versioning carry no "ver" claim and are treated as version 0.
+	version, _ := claims["ver"].(float64)
+	if err := a.sessions.ValidateSession(ctx, userID, int(version)); err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrSessionRevoked):
+			err = usecase.WithDetail(err, "session has been revoked, please log in again")
+		case errors.Is(err, usecase.ErrNotFound):
+			err = invalidToken
+		}
+		return nil, err
+	}
+	role, _ := claims["role"].(string)
+	return usecase.WithActor(ctx, usecase.Actor{UserID: userID, Username: username, Role: domain.Role(role), OrgID: orgID}), nil
+}
+
+// unary authenticates unary calls outside AuthService.
+func (a authenticator) unary(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	if strings.HasPrefix(info.FullMethod, publicMethodPrefix) {
+		return handler(ctx, req)
+	}
+	ctx, err := a.authenticate(ctx)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return handler(ctx, req)
+}
+
+// stream authenticates streaming calls outside AuthService.
+func (a authenticator) stream(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if strings.HasPrefix(info.FullMethod, publicMethodPrefix) {
+		return handler(srv, ss)
+	}
+	ctx, err := a.authenticate(ss.Context())
+	if err != nil {
+		return statusError(err)
+	}
+	return handler(srv, &authenticatedStream{ServerStream: ss, ctx: ctx})
+}
+
+// authenticatedStream overrides the context of a stream with one carrying the actor.
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+// Context returns the context carrying the actor.
+func (s *authenticatedStream) Context() context.Context {
+	return s.ctx
+}
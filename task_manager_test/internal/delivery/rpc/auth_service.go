@@ -0,0 +1,68 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"task_manager_test/internal/delivery/rpc/pb"
+	"task_manager_test/internal/domain"
+	"task_manager_test/internal/usecase"
+)
+
+// AuthServer implements pb.AuthServiceServer on top of UserUsecase, like the /register and /login handlers.
+type AuthServer struct {
+	pb.UnimplementedAuthServiceServer
+	userUC usecase.UserUsecase
+}
+
+// NewAuthServer creates an AuthServer.
+func NewAuthServer(u usecase.UserUsecase) *AuthServer {
+	return &AuthServer{userUC: u}
+}
+
+// Register creates a user. Calls carry no actor, so only the default role can be registered.
+func (s *AuthServer) Register(ctx context.Context, req *pb.RegisterRequest) (*pb.RegisterResponse, error) {
+	if missing := required(field{"username", req.GetUsername()}, field{"password", req.GetPassword()}); len(missing) > 0 {
+		return nil, missingFields(missing...)
+	}
+	user := domain.User{Username: req.GetUsername(), Password: req.GetPassword(), Role: domain.Role(req.GetRole())}
+	if err := s.userUC.Register(ctx, user); err != nil {
+		if errors.Is(err, usecase.ErrForbidden) {
+			err = usecase.WithDetail(err, "registering with this role requires the user.manage permission")
+		}
+		return nil, statusError(err)
+	}
+	return &pb.RegisterResponse{}, nil
+}
+
+// Login returns a JWT for TaskService calls.
+func (s *AuthServer) Login(ctx context.Context, req *pb.LoginRequest) (*pb.LoginResponse, error) {
+	if missing := required(field{"username", req.GetUsername()}, field{"password", req.GetPassword()}); len(missing) > 0 {
+		return nil, missingFields(missing...)
+	}
+	token, err := s.userUC.Login(ctx, req.GetUsername(), req.GetPassword())
+	if err != nil {
+		// Unknown usernames are reported like wrong passwords so that logins cannot probe which accounts exist.
+		if errors.Is(err, usecase.ErrNotFound) || errors.Is(err, usecase.ErrInvalidCredentials) {
+			err = usecase.WithDetail(usecase.ErrInvalidCredentials, "invalid username or password")
+		}
+		return nil, statusError(err)
+	}
+	return &pb.LoginResponse{Token: token}, nil
+}
+
+// field is a request field checked by required.
+type field struct {
+	name  string
+	value string
+}
+
+// required returns the names of the fields left empty.
+func required(fields ...field) []string {
+	var missing []string
+	for _, f := range fields {
+		if f.value == "" {
+			missing = append(missing, f.name)
+		}
+	}
+	return missing
+}
@@ -0,0 +1,73 @@
+package rpc
+
+import (
+	"errors"
+	"math"
+	"task_manager_test/internal/usecase"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// errorDomain is the domain of the ErrorInfo attached to every error status.
+const errorDomain = "task-manager"
+
+// codeByKind maps each kind of application error to its gRPC code, mirroring the HTTP statuses of
+// middleware.ErrorHandler.
+var codeByKind = map[usecase.ErrorKind]codes.Code{
+	usecase.KindInternal:        codes.Internal,
+	usecase.KindInvalid:         codes.InvalidArgument,
+	usecase.KindUnauthenticated: codes.Unauthenticated,
+	usecase.KindForbidden:       codes.PermissionDenied,
+	usecase.KindNotFound:        codes.NotFound,
+	usecase.KindConflict:        codes.AlreadyExists,
+	usecase.KindTooLarge:        codes.ResourceExhausted,
+	usecase.KindTooManyRequests: codes.ResourceExhausted,
+	usecase.KindUnavailable:     codes.Unavailable,
+}
+
+// statusError converts an error from a use case into a gRPC status. The message is the error's detail, or its
+// title when it has none, and an ErrorInfo carries the same stable code as the HTTP problem. Errors that are not
+// application errors are reported as internal errors without their text.
+func statusError(err error) error {
+	appErr, detail := usecase.Describe(err)
+	msg := appErr.Message
+	if detail != "" {
+		msg = detail
+	}
+	details := []protoadapt.MessageV1{&errdetails.ErrorInfo{Reason: appErr.Code, Domain: errorDomain}}
+	var locked *usecase.AccountLockedError
+	if errors.As(err, &locked) {
+		retryAfter := math.Ceil(time.Until(locked.Until).Seconds())
+		details = append(details, &errdetails.RetryInfo{RetryDelay: durationpb.New(time.Duration(max(retryAfter, 1)) * time.Second)})
+	}
+	var unavailable *usecase.UnavailableError
+	if errors.As(err, &unavailable) && unavailable.RetryAfter > 0 {
+		details = append(details, &errdetails.RetryInfo{RetryDelay: durationpb.New(unavailable.RetryAfter)})
+	}
+	return withDetails(status.New(codeByKind[appErr.Kind], msg), details...)
+}
+
+// missingFields reports required request fields that were left empty, like a failed binding in the HTTP API.
+func missingFields(fields ...string) error {
+	violations := make([]*errdetails.BadRequest_FieldViolation, len(fields))
+	for i, f := range fields {
+		violations[i] = &errdetails.BadRequest_FieldViolation{Field: f, Description: "is required"}
+	}
+	invalid, _ := usecase.Describe(usecase.ErrInvalidRequest)
+	return withDetails(status.New(codes.InvalidArgument, "request failed validation"),
+		&errdetails.ErrorInfo{Reason: invalid.Code, Domain: errorDomain},
+		&errdetails.BadRequest{FieldViolations: violations})
+}
+
+// withDetails attaches details to st. Details that cannot be marshalled are dropped rather than hiding the error.
+func withDetails(st *status.Status, details ...protoadapt.MessageV1) error {
+	if with, err := st.WithDetails(details...); err == nil {
+		st = with
+	}
+	return st.Err()
+}
@@ -0,0 +1,64 @@
+// Package rpc serves the task and auth use cases over gRPC, for internal services that want typed, streaming
+// access without going through the JSON API. Errors carry the same codes as the HTTP problems.
+package rpc
+
+import (
+	"context"
+	"net"
+	"task_manager_test/internal/delivery/rpc/pb"
+	"task_manager_test/internal/usecase"
+	"time"
+
+	"google.golang.org/grpc"
+)
+
+// Config holds the dependencies of the gRPC server.
+type Config struct {
+	TaskUC   usecase.TaskUsecase
+	UserUC   usecase.UserUsecase
+	JwtSvc   usecase.IJWTService
+	Sessions usecase.ISessionValidator
+	// WatchInterval is how often WatchTasks looks for changes.
+	WatchInterval time.Duration
+}
+
+// Server serves TaskService and AuthService.
+type Server struct {
+	grpcServer *grpc.Server
+	tasks      *TaskServer
+}
+
+// New creates a Server whose TaskService calls are authenticated with a JWT from AuthService.Login.
+func New(cfg Config) *Server {
+	auth := authenticator{jwtSvc: cfg.JwtSvc, sessions: cfg.Sessions}
+	s := &Server{
+		grpcServer: grpc.NewServer(grpc.UnaryInterceptor(auth.unary), grpc.StreamInterceptor(auth.stream)),
+		tasks:      NewTaskServer(cfg.TaskUC, cfg.WatchInterval),
+	}
+	pb.RegisterTaskServiceServer(s.grpcServer, s.tasks)
+	pb.RegisterAuthServiceServer(s.grpcServer, NewAuthServer(cfg.UserUC))
+	return s
+}
+
+// Serve accepts connections on ln until Shutdown is called.
+func (s *Server) Serve(ln net.Listener) error {
+	return s.grpcServer.Serve(ln)
+}
+
+// Shutdown stops accepting calls, ends running watches and waits for the other calls to finish. Calls still
+// running when ctx is done are cancelled.
+func (s *Server) Shutdown(ctx context.Context) error {
+	s.tasks.stopWatches()
+	stopped := make(chan struct{})
+	go func() {
+		s.grpcServer.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+		return nil
+	case <-ctx.Done():
+		s.grpcServer.Stop()
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,365 @@
+package rpc
+
+import (
+	"context"
+	"net"
+	"task_manager_test/internal/delivery/rpc/pb"
+	"task_manager_test/internal/domain"
+	"task_manager_test/internal/mocks"
+	"task_manager_test/internal/usecase"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ServerTestSuite exercises the gRPC services end to end over an in-memory connection.
+type ServerTestSuite struct {
+	suite.Suite
+	mockTasks    *mocks.TaskUsecase
+	mockUsers    *mocks.UserUsecase
+	mockJWT      *mocks.IJWTService
+	mockSessions *mocks.ISessionValidator
+	server       *Server
+	conn         *grpc.ClientConn
+	tasks        pb.TaskServiceClient
+	auth         pb.AuthServiceClient
+	sampleTask   domain.Task
+}
+
+// SetupTest starts a server backed by fresh mocks and connects to it.
+func (s *ServerTestSuite) SetupTest() {
+	s.mockTasks = new(mocks.TaskUsecase)
+	s.mockUsers = new(mocks.UserUsecase)
+	s.mockJWT = new(mocks.IJWTService)
+	s.mockSessions = new(mocks.ISessionValidator)
+	s.server = New(Config{
+		TaskUC:        s.mockTasks,
+		UserUC:        s.mockUsers,
+		JwtSvc:        s.mockJWT,
+		Sessions:      s.mockSessions,
+		WatchInterval: 10 * time.Millisecond,
+	})
+
+	ln := bufconn.Listen(1 << 20)
+	go func() { _ = s.server.Serve(ln) }()
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return ln.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	s.Require().NoError(err)
+	s.conn = conn
+	s.tasks = pb.NewTaskServiceClient(conn)
+	s.auth = pb.NewAuthServiceClient(conn)
+
+	s.sampleTask = domain.Task{
+		ID:      "task-123",
+		Title:   "Sample Task",
+		DueDate: time.Date(2025, 1, 1, 15, 4, 5, 0, time.UTC),
+		Status:  "Pending",
+		OwnerID: "user-1",
+	}
+}
+
+// TearDownTest closes the connection and stops the server.
+func (s *ServerTestSuite) TearDownTest() {
+	s.conn.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	_ = s.server.Shutdown(ctx)
+}
+
+// TestServer runs the entire test suite.
+func TestServer(t *testing.T) {
+	suite.Run(t, new(ServerTestSuite))
+}
+
+// signedIn returns a context that sends a valid token for alice, and expects it to be validated.
+func (s *ServerTestSuite) signedIn() context.Context {
+	s.mockJWT.On("ValidateToken", "valid-token").
+		Return(jwt.MapClaims{"sub": "user-1", "username": "alice", "role": "member", "org": "org-1", "ver": float64(2)}, nil)
+	s.mockSessions.On("ValidateSession", mock.Anything, "user-1", 2).Return(nil)
+	return metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer valid-token")
+}
+
+// asAlice matches contexts carrying alice as the actor.
+func asAlice() any {
+	return mock.MatchedBy(func(ctx context.Context) bool {
+		actor, ok := usecase.ActorFromContext(ctx)
+		return ok && actor == usecase.Actor{UserID: "user-1", Username: "alice", Role: domain.RoleMember, OrgID: "org-1"}
+	})
+}
+
+// requireStatus asserts the code and message of err and returns its status for checking details.
+func (s *ServerTestSuite) requireStatus(err error, code codes.Code, msg string) *status.Status {
+	st, ok := status.FromError(err)
+	s.Require().True(ok, "expected a gRPC status, got %v", err)
+	s.Equal(code, st.Code())
+	s.Equal(msg, st.Message())
+	return st
+}
+
+// reason returns the ErrorInfo reason attached to st.
+func reason(st *status.Status) string {
+	for _, d := range st.Details() {
+		if info, ok := d.(*errdetails.ErrorInfo); ok {
+			return info.Reason
+		}
+	}
+	return ""
+}
+
+// TestTaskCallsRequireToken tests that calls without a bearer token are rejected before reaching the use cases.
+func (s *ServerTestSuite) TestTaskCallsRequireToken() {
+	_, err := s.tasks.ListTasks(context.Background(), &pb.ListTasksRequest{})
+
+	st := s.requireStatus(err, codes.Unauthenticated, "missing bearer token")
+	s.Equal("unauthenticated", reason(st))
+	s.mockTasks.AssertNotCalled(s.T(), "List", mock.Anything)
+}
+
+// TestRevokedSession tests that tokens of revoked sessions are rejected, also on streams.
+func (s *ServerTestSuite) TestRevokedSession() {
+	s.mockJWT.On("ValidateToken", "old-token").Return(jwt.MapClaims{"sub": "user-1", "username": "alice", "org": "org-1"}, nil)
+	s.mockSessions.On("ValidateSession", mock.Anything, "user-1", 0).Return(usecase.ErrSessionRevoked)
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer old-token")
+
+	stream, err := s.tasks.WatchTasks(ctx, &pb.WatchTasksRequest{})
+	s.Require().NoError(err)
+	_, err = stream.Recv()
+
+	st := s.requireStatus(err, codes.Unauthenticated, "session has been revoked, please log in again")
+	s.Equal("session_revoked", reason(st))
+}
+
+// TestListTasks tests that tasks are listed as the caller, with how they came to see each task.
+func (s *ServerTestSuite) TestListTasks() {
+	ctx := s.signedIn()
+	s.mockTasks.On("List", asAlice()).Return([]domain.TaskListItem{
+		{Task: s.sampleTask, Ownership: domain.OwnershipShared, Access: domain.ShareEdit},
+	}, nil).Once()
+
+	resp, err := s.tasks.ListTasks(ctx, &pb.ListTasksRequest{})
+
+	s.Require().NoError(err)
+	s.Require().Len(resp.Tasks, 1)
+	s.Equal("task-123", resp.Tasks[0].Id)
+	s.Equal(s.sampleTask.DueDate, resp.Tasks[0].DueDate.AsTime())
+	s.Equal("shared", resp.Tasks[0].Ownership)
+	s.Equal("edit", resp.Tasks[0].Access)
+	s.Nil(resp.Tasks[0].ArchivedAt)
+	s.mockTasks.AssertExpectations(s.T())
+}
+
+// TestGetTask_NotFound tests that missing tasks are reported like the HTTP API reports them.
+func (s *ServerTestSuite) TestGetTask_NotFound() {
+	ctx := s.signedIn()
+	s.mockTasks.On("Get", asAlice(), "missing").Return(domain.Task{}, usecase.ErrNotFound).Once()
+
+	_, err := s.tasks.GetTask(ctx, &pb.GetTaskRequest{Id: "missing"})
+
+	st := s.requireStatus(err, codes.NotFound, "task not found")
+	s.Equal("not_found", reason(st))
+}
+
+// TestGetTask_Unavailable tests that a failing database is reported as Unavailable with a retry delay.
+func (s *ServerTestSuite) TestGetTask_Unavailable() {
+	ctx := s.signedIn()
+	s.mockTasks.On("Get", asAlice(), "task-123").Return(domain.Task{}, &usecase.UnavailableError{RetryAfter: 10 * time.Second}).Once()
+
+	_, err := s.tasks.GetTask(ctx, &pb.GetTaskRequest{Id: "task-123"})
+
+	st := s.requireStatus(err, codes.Unavailable, "the database is temporarily unavailable, please retry later")
+	s.Equal("service_unavailable", reason(st))
+	var delay time.Duration
+	for _, d := range st.Details() {
+		if info, ok := d.(*errdetails.RetryInfo); ok {
+			delay = info.RetryDelay.AsDuration()
+		}
+	}
+	s.Equal(10*time.Second, delay)
+}
+
+// TestCreateTask tests that a task is created from the request fields.
+func (s *ServerTestSuite) TestCreateTask() {
+	ctx := s.signedIn()
+	input := domain.Task{Title: "Sample Task", DueDate: s.sampleTask.DueDate, Status: "Pending", ProjectID: "proj-1"}
+	created := s.sampleTask
+	created.ProjectID = "proj-1"
+	s.mockTasks.On("Create", asAlice(), input).Return(created, nil).Once()
+
+	task, err := s.tasks.CreateTask(ctx, &pb.CreateTaskRequest{
+		Title:     "Sample Task",
+		DueDate:   timestamppb.New(s.sampleTask.DueDate),
+		Status:    "Pending",
+		ProjectId: "proj-1",
+	})
+
+	s.Require().NoError(err)
+	s.Equal("task-123", task.Id)
+	s.Equal("proj-1", task.ProjectId)
+	s.mockTasks.AssertExpectations(s.T())
+}
+
+// TestCreateTask_MissingFields tests that required fields are reported together, like a failed HTTP binding.
+func (s *ServerTestSuite) TestCreateTask_MissingFields() {
+	ctx := s.signedIn()
+
+	_, err := s.tasks.CreateTask(ctx, &pb.CreateTaskRequest{Description: "no title"})
+
+	st := s.requireStatus(err, codes.InvalidArgument, "request failed validation")
+	s.Equal("invalid_request", reason(st))
+	var fields []string
+	for _, d := range st.Details() {
+		if br, ok := d.(*errdetails.BadRequest); ok {
+			for _, v := range br.FieldViolations {
+				fields = append(fields, v.Field)
+			}
+		}
+	}
+	s.Equal([]string{"title", "due_date", "status"}, fields)
+	s.mockTasks.AssertNotCalled(s.T(), "Create", mock.Anything, mock.Anything)
+}
+
+// TestUpdateTask_Forbidden tests that authorization failures map to PermissionDenied.
+func (s *ServerTestSuite) TestUpdateTask_Forbidden() {
+	ctx := s.signedIn()
+	s.mockTasks.On("Update", asAlice(), mock.AnythingOfType("domain.Task")).Return(domain.Task{}, usecase.ErrForbidden).Once()
+
+	_, err := s.tasks.UpdateTask(ctx, &pb.UpdateTaskRequest{
+		Id:      "task-123",
+		Title:   "Renamed",
+		DueDate: timestamppb.New(s.sampleTask.DueDate),
+		Status:  "Done",
+	})
+
+	st := s.requireStatus(err, codes.PermissionDenied, "permission denied")
+	s.Equal("forbidden", reason(st))
+}
+
+// TestDeleteTask tests that a task is deleted by ID.
+func (s *ServerTestSuite) TestDeleteTask() {
+	ctx := s.signedIn()
+	s.mockTasks.On("Delete", asAlice(), "task-123").Return(nil).Once()
+
+	_, err := s.tasks.DeleteTask(ctx, &pb.DeleteTaskRequest{Id: "task-123"})
+
+	s.NoError(err)
+	s.mockTasks.AssertExpectations(s.T())
+}
+
+// TestWatchTasks tests that the initial tasks are sent as ADDED, followed by an event for each later change.
+func (s *ServerTestSuite) TestWatchTasks() {
+	ctx, cancel := context.WithCancel(s.signedIn())
+	defer cancel()
+	other := domain.Task{ID: "task-456", Title: "Other", DueDate: s.sampleTask.DueDate, Status: "Pending", OwnerID: "user-1"}
+	renamed := s.sampleTask
+	renamed.Title = "Renamed"
+	owned := func(tasks ...domain.Task) []domain.TaskListItem {
+		items := make([]domain.TaskListItem, len(tasks))
+		for i, t := range tasks {
+			items[i] = domain.TaskListItem{Task: t, Ownership: domain.OwnershipOwner}
+		}
+		return items
+	}
+	s.mockTasks.On("List", asAlice()).Return(owned(s.sampleTask), nil).Once()
+	s.mockTasks.On("List", asAlice()).Return(owned(s.sampleTask), nil).Once()
+	s.mockTasks.On("List", asAlice()).Return(owned(renamed, other), nil).Once()
+	s.mockTasks.On("List", asAlice()).Return(owned(other), nil)
+
+	stream, err := s.tasks.WatchTasks(ctx, &pb.WatchTasksRequest{})
+	s.Require().NoError(err)
+
+	want := []struct {
+		typ   pb.TaskEvent_Type
+		id    string
+		title string
+	}{
+		{pb.TaskEvent_ADDED, "task-123", "Sample Task"},
+		{pb.TaskEvent_MODIFIED, "task-123", "Renamed"},
+		{pb.TaskEvent_ADDED, "task-456", "Other"},
+		{pb.TaskEvent_REMOVED, "task-123", "Renamed"},
+	}
+	for _, w := range want {
+		event, err := stream.Recv()
+		s.Require().NoError(err)
+		s.Equal(w.typ, event.Type)
+		s.Equal(w.id, event.Task.Id)
+		s.Equal(w.title, event.Task.Title)
+	}
+}
+
+// TestShutdownEndsWatches tests that shutting down ends running watches with Unavailable instead of waiting.
+func (s *ServerTestSuite) TestShutdownEndsWatches() {
+	ctx := s.signedIn()
+	s.mockTasks.On("List", asAlice()).Return([]domain.TaskListItem{{Task: s.sampleTask}}, nil)
+	stream, err := s.tasks.WatchTasks(ctx, &pb.WatchTasksRequest{})
+	s.Require().NoError(err)
+	_, err = stream.Recv()
+	s.Require().NoError(err, "The watch must be running before the server shuts down")
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	s.Require().NoError(s.server.Shutdown(shutdownCtx))
+
+	_, err = stream.Recv()
+	s.requireStatus(err, codes.Unavailable, "server is shutting down")
+}
+
+// TestRegister tests that registering needs no token.
+func (s *ServerTestSuite) TestRegister() {
+	s.mockUsers.On("Register", mock.Anything, domain.User{Username: "bob", Password: "pw"}).Return(nil).Once()
+
+	_, err := s.auth.Register(context.Background(), &pb.RegisterRequest{Username: "bob", Password: "pw"})
+
+	s.NoError(err)
+	s.mockJWT.AssertNotCalled(s.T(), "ValidateToken", mock.Anything)
+	s.mockUsers.AssertExpectations(s.T())
+}
+
+// TestLogin tests that a successful login returns the token.
+func (s *ServerTestSuite) TestLogin() {
+	s.mockUsers.On("Login", mock.Anything, "alice", "pw").Return("jwt", nil).Once()
+
+	resp, err := s.auth.Login(context.Background(), &pb.LoginRequest{Username: "alice", Password: "pw"})
+
+	s.Require().NoError(err)
+	s.Equal("jwt", resp.Token)
+}
+
+// TestLogin_UnknownUser tests that unknown usernames are reported like wrong passwords.
+func (s *ServerTestSuite) TestLogin_UnknownUser() {
+	s.mockUsers.On("Login", mock.Anything, "ghost", "pw").Return("", usecase.ErrNotFound).Once()
+
+	_, err := s.auth.Login(context.Background(), &pb.LoginRequest{Username: "ghost", Password: "pw"})
+
+	st := s.requireStatus(err, codes.Unauthenticated, "invalid username or password")
+	s.Equal("invalid_credentials", reason(st))
+}
+
+// TestLogin_Locked tests that a locked account is reported as ResourceExhausted with a retry delay.
+func (s *ServerTestSuite) TestLogin_Locked() {
+	until := time.Now().Add(90 * time.Second)
+	s.mockUsers.On("Login", mock.Anything, "alice", "pw").Return("", &usecase.AccountLockedError{Until: until}).Once()
+
+	_, err := s.auth.Login(context.Background(), &pb.LoginRequest{Username: "alice", Password: "pw"})
+
+	st := s.requireStatus(err, codes.ResourceExhausted, "account temporarily locked due to too many failed password attempts")
+	s.Equal("account_locked", reason(st))
+	var delay time.Duration
+	for _, d := range st.Details() {
+		if info, ok := d.(*errdetails.RetryInfo); ok {
+			delay = info.RetryDelay.AsDuration()
+		}
+	}
+	s.InDelta(90*time.Second, delay, float64(time.Second))
+}
@@ -0,0 +1,212 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"task_manager_test/internal/delivery/rpc/pb"
+	"task_manager_test/internal/domain"
+	"task_manager_test/internal/usecase"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// TaskServer implements pb.TaskServiceServer on top of TaskUsecase, with the same rules as the /api/tasks handlers.
+type TaskServer struct {
+	pb.UnimplementedTaskServiceServer
+	taskUC        usecase.TaskUsecase
+	watchInterval time.Duration
+
+	// stopping is closed when the server shuts down, ending every watch so that a graceful stop does not wait
+	// for clients to cancel them.
+	stopping chan struct{}
+	stopOnce sync.Once
+}
+
+// NewTaskServer creates a TaskServer; WatchTasks lists the caller's tasks every watchInterval to find changes.
+func NewTaskServer(t usecase.TaskUsecase, watchInterval time.Duration) *TaskServer {
+	return &TaskServer{taskUC: t, watchInterval: watchInterval, stopping: make(chan struct{})}
+}
+
+// stopWatches ends the running watches with codes.Unavailable, telling clients to reconnect.
+func (s *TaskServer) stopWatches() {
+	s.stopOnce.Do(func() { close(s.stopping) })
+}
+
+// toTask converts a domain.Task into its protobuf message.
+func toTask(t domain.Task) *pb.Task {
+	msg := &pb.Task{
+		Id:          t.ID,
+		Title:       t.Title,
+		Description: t.Description,
+		DueDate:     timestamppb.New(t.DueDate),
+		Status:      t.Status,
+		OwnerId:     t.OwnerID,
+		ProjectId:   t.ProjectID,
+	}
+	if !t.ArchivedAt.IsZero() {
+		msg.ArchivedAt = timestamppb.New(t.ArchivedAt)
+	}
+	return msg
+}
+
+// toListedTask converts a task as listed by TaskUsecase.List, including how the caller came to see it.
+func toListedTask(item domain.TaskListItem) *pb.Task {
+	msg := toTask(item.Task)
+	msg.Ownership = string(item.Ownership)
+	msg.Access = string(item.Access)
+	return msg
+}
+
+// validateTask requires the fields the HTTP API requires of a task body.
+func validateTask(title string, dueDate *timestamppb.Timestamp, status string) error {
+	var missing []string
+	if title == "" {
+		missing = append(missing, "title")
+	}
+	if dueDate == nil {
+		missing = append(missing, "due_date")
+	}
+	if status == "" {
+		missing = append(missing, "status")
+	}
+	if len(missing) > 0 {
+		return missingFields(missing...)
+	}
+	return nil
+}
+
+// taskNotFound names the task in a generic usecase.ErrNotFound, like the HTTP handlers do.
+func taskNotFound(err error) error {
+	if errors.Is(err, usecase.ErrNotFound) {
+		err = usecase.WithDetail(err, "task not found")
+	}
+	return statusError(err)
+}
+
+// ListTasks lists the personal tasks visible to the caller.
+func (s *TaskServer) ListTasks(ctx context.Context, _ *pb.ListTasksRequest) (*pb.ListTasksResponse, error) {
+	items, err := s.taskUC.List(ctx)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	resp := &pb.ListTasksResponse{Tasks: make([]*pb.Task, len(items))}
+	for i, item := range items {
+		resp.Tasks[i] = toListedTask(item)
+	}
+	return resp, nil
+}
+
+// GetTask retrieves a single task by ID.
+func (s *TaskServer) GetTask(ctx context.Context, req *pb.GetTaskRequest) (*pb.Task, error) {
+	task, err := s.taskUC.Get(ctx, req.GetId())
+	if err != nil {
+		return nil, taskNotFound(err)
+	}
+	return toTask(task), nil
+}
+
+// CreateTask creates a personal task, or a task in the project named by project_id.
+func (s *TaskServer) CreateTask(ctx context.Context, req *pb.CreateTaskRequest) (*pb.Task, error) {
+	if err := validateTask(req.GetTitle(), req.GetDueDate(), req.GetStatus()); err != nil {
+		return nil, err
+	}
+	created, err := s.taskUC.Create(ctx, domain.Task{
+		Title:       req.GetTitle(),
+		Description: req.GetDescription(),
+		DueDate:     req.GetDueDate().AsTime(),
+		Status:      req.GetStatus(),
+		ProjectID:   req.GetProjectId(),
+	})
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return toTask(created), nil
+}
+
+// UpdateTask replaces the task identified by id.
+func (s *TaskServer) UpdateTask(ctx context.Context, req *pb.UpdateTaskRequest) (*pb.Task, error) {
+	if err := validateTask(req.GetTitle(), req.GetDueDate(), req.GetStatus()); err != nil {
+		return nil, err
+	}
+	updated, err := s.taskUC.Update(ctx, domain.Task{
+		ID:          req.GetId(),
+		Title:       req.GetTitle(),
+		Description: req.GetDescription(),
+		DueDate:     req.GetDueDate().AsTime(),
+		Status:      req.GetStatus(),
+	})
+	if err != nil {
+		return nil, taskNotFound(err)
+	}
+	return toTask(updated), nil
+}
+
+// DeleteTask deletes the task identified by id.
+func (s *TaskServer) DeleteTask(ctx context.Context, req *pb.DeleteTaskRequest) (*emptypb.Empty, error) {
+	if err := s.taskUC.Delete(ctx, req.GetId()); err != nil {
+		return nil, taskNotFound(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// WatchTasks sends the caller's tasks as ADDED events, then lists them every watch interval and sends an event for
+// each task that was added, changed or removed since. It returns when the client cancels the call.
+func (s *TaskServer) WatchTasks(_ *pb.WatchTasksRequest, stream pb.TaskService_WatchTasksServer) error {
+	ctx := stream.Context()
+	ticker := time.NewTicker(s.watchInterval)
+	defer ticker.Stop()
+
+	seen := map[string]*pb.Task{}
+	for {
+		items, err := s.taskUC.List(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return statusError(err)
+		}
+		current := make(map[string]*pb.Task, len(items))
+		for _, item := range items {
+			task := toListedTask(item)
+			current[task.Id] = task
+			prev, ok := seen[task.Id]
+			switch {
+			case !ok:
+				err = stream.Send(&pb.TaskEvent{Type: pb.TaskEvent_ADDED, Task: task})
+			case !proto.Equal(prev, task):
+				err = stream.Send(&pb.TaskEvent{Type: pb.TaskEvent_MODIFIED, Task: task})
+			}
+			if err != nil {
+				return err
+			}
+		}
+		var removed []string
+		for id := range seen {
+			if _, ok := current[id]; !ok {
+				removed = append(removed, id)
+			}
+		}
+		slices.Sort(removed)
+		for _, id := range removed {
+			if err := stream.Send(&pb.TaskEvent{Type: pb.TaskEvent_REMOVED, Task: seen[id]}); err != nil {
+				return err
+			}
+		}
+		seen = current
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-s.stopping:
+			return status.Error(codes.Unavailable, "server is shutting down")
+		case <-ticker.C:
+		}
+	}
+}
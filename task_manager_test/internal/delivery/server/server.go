@@ -0,0 +1,123 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"time"
+)
+
+// Config holds the listen address and timeouts for the HTTP server.
+type Config struct {
+	Addr            string
+	ReadTimeout     time.Duration
+	WriteTimeout    time.Duration
+	IdleTimeout     time.Duration
+	ShutdownTimeout time.Duration
+}
+
+// shutdownHook is a named cleanup step that runs after the HTTP server has drained.
+type shutdownHook struct {
+	name string
+	fn   func(ctx context.Context) error
+}
+
+// Server wraps an http.Server and coordinates an ordered, deadline-bound shutdown.
+type Server struct {
+	httpServer      *http.Server
+	shutdownTimeout time.Duration
+	hooks           []shutdownHook
+}
+
+// New constructs a Server that serves handler with the timeouts from cfg.
+func New(cfg Config, handler http.Handler) *Server {
+	return &Server{
+		httpServer: &http.Server{
+			Addr:         cfg.Addr,
+			Handler:      handler,
+			ReadTimeout:  cfg.ReadTimeout,
+			WriteTimeout: cfg.WriteTimeout,
+			IdleTimeout:  cfg.IdleTimeout,
+		},
+		shutdownTimeout: cfg.ShutdownTimeout,
+	}
+}
+
+// OnDrain registers fn to be called as soon as shutdown begins, before in-flight requests are drained.
+// It is typically used to flip readiness so load balancers stop routing new traffic.
+func (s *Server) OnDrain(fn func()) {
+	s.httpServer.RegisterOnShutdown(fn)
+}
+
+// OnShutdown registers a cleanup step that runs after the HTTP server has drained.
+// Steps run sequentially in registration order, so dependencies should be registered after their dependants.
+func (s *Server) OnShutdown(name string, fn func(ctx context.Context) error) {
+	s.hooks = append(s.hooks, shutdownHook{name: name, fn: fn})
+}
+
+// Run listens on the configured address and serves requests until ctx is cancelled, then shuts down gracefully.
+func (s *Server) Run(ctx context.Context) error {
+	ln, err := net.Listen("tcp", s.httpServer.Addr)
+	if err != nil {
+		return err
+	}
+	return s.Serve(ctx, ln)
+}
+
+// Serve accepts connections on ln until ctx is cancelled, then shuts down gracefully.
+func (s *Server) Serve(ctx context.Context, ln net.Listener) error {
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- s.httpServer.Serve(ln)
+	}()
+
+	select {
+	case err := <-serveErr:
+		// The server stopped on its own, so there is nothing to drain, but cleanup must still run.
+		shutdownErr := s.Shutdown(context.Background())
+		if errors.Is(err, http.ErrServerClosed) {
+			return shutdownErr
+		}
+		return errors.Join(err, shutdownErr)
+	case <-ctx.Done():
+		return s.Shutdown(context.Background())
+	}
+}
+
+// Shutdown drains in-flight requests within the shutdown timeout and then runs the registered hooks in order.
+// If draining exceeds the deadline, remaining connections are closed forcibly. The hooks get a shutdown timeout of
+// their own, so a slow drain does not leave them without time to clean up.
+func (s *Server) Shutdown(parent context.Context) error {
+	drainCtx, cancel := s.withShutdownTimeout(parent)
+	defer cancel()
+
+	var errs []error
+	if err := s.httpServer.Shutdown(drainCtx); err != nil {
+		log.Printf("HTTP server did not drain in time: %v", err)
+		errs = append(errs, fmt.Errorf("drain http server: %w", err))
+		if closeErr := s.httpServer.Close(); closeErr != nil {
+			errs = append(errs, fmt.Errorf("close http server: %w", closeErr))
+		}
+	}
+
+	ctx, cancel := s.withShutdownTimeout(parent)
+	defer cancel()
+	for _, h := range s.hooks {
+		if err := h.fn(ctx); err != nil {
+			log.Printf("Shutdown step %q failed: %v", h.name, err)
+			errs = append(errs, fmt.Errorf("shutdown %s: %w", h.name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// withShutdownTimeout derives a context from parent that expires after the shutdown timeout, if one is configured.
+func (s *Server) withShutdownTimeout(parent context.Context) (context.Context, context.CancelFunc) {
+	if s.shutdownTimeout > 0 {
+		return context.WithTimeout(parent, s.shutdownTimeout)
+	}
+	return context.WithCancel(parent)
+}
@@ -0,0 +1,184 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// ServerTestSuite defines the test suite for the graceful-shutdown server.
+type ServerTestSuite struct {
+	suite.Suite
+	listener net.Listener
+}
+
+// SetupTest opens a fresh loopback listener for each test.
+func (s *ServerTestSuite) SetupTest() {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	s.Require().NoError(err, "Setup: failed to open listener")
+	s.listener = ln
+}
+
+// TestServer runs the entire test suite.
+func TestServer(t *testing.T) {
+	suite.Run(t, new(ServerTestSuite))
+}
+
+// TestShutdown_DrainsInFlightRequests verifies that a request in progress when shutdown begins still completes.
+func (s *ServerTestSuite) TestShutdown_DrainsInFlightRequests() {
+	// ARRANGE
+	started := make(chan struct{})
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		time.Sleep(200 * time.Millisecond)
+		_, _ = w.Write([]byte("done"))
+	})
+	srv := New(Config{ShutdownTimeout: 2 * time.Second}, handler)
+	ctx, cancel := context.WithCancel(context.Background())
+	runErr := make(chan error, 1)
+	go func() { runErr <- srv.Serve(ctx, s.listener) }()
+
+	// ACT
+	respCh := make(chan string, 1)
+	go func() {
+		resp, err := http.Get("http://" + s.listener.Addr().String())
+		if err != nil {
+			respCh <- err.Error()
+			return
+		}
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		respCh <- string(body)
+	}()
+	<-started
+	cancel()
+
+	// ASSERT
+	s.Equal("done", <-respCh, "In-flight request should complete during shutdown")
+	s.NoError(<-runErr, "Graceful shutdown should not report an error")
+}
+
+// TestShutdown_RunsHooksInOrderAfterDrain verifies that drain callbacks fire first and cleanup steps run in registration order.
+func (s *ServerTestSuite) TestShutdown_RunsHooksInOrderAfterDrain() {
+	// ARRANGE
+	srv := New(Config{ShutdownTimeout: time.Second}, http.NotFoundHandler())
+	var order []string
+	drained := make(chan struct{})
+	srv.OnDrain(func() { close(drained) })
+	srv.OnShutdown("workers", func(ctx context.Context) error {
+		<-drained
+		order = append(order, "workers")
+		return nil
+	})
+	srv.OnShutdown("mongodb", func(ctx context.Context) error {
+		order = append(order, "mongodb")
+		return nil
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// ACT
+	err := srv.Serve(ctx, s.listener)
+
+	// ASSERT
+	s.NoError(err)
+	s.Equal([]string{"workers", "mongodb"}, order, "Shutdown hooks should run in registration order")
+}
+
+// TestShutdown_ReportsHookErrorsAndContinues verifies that a failing step does not prevent later steps from running.
+func (s *ServerTestSuite) TestShutdown_ReportsHookErrorsAndContinues() {
+	// ARRANGE
+	srv := New(Config{ShutdownTimeout: time.Second}, http.NotFoundHandler())
+	hookErr := errors.New("flush failed")
+	mongoClosed := false
+	srv.OnShutdown("workers", func(ctx context.Context) error { return hookErr })
+	srv.OnShutdown("mongodb", func(ctx context.Context) error {
+		mongoClosed = true
+		return nil
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// ACT
+	err := srv.Serve(ctx, s.listener)
+
+	// ASSERT
+	s.ErrorIs(err, hookErr, "The hook error should be reported")
+	s.True(mongoClosed, "Later shutdown steps should still run")
+}
+
+// TestShutdown_ForceClosesAfterDeadline verifies that a request exceeding the drain deadline does not block shutdown forever.
+func (s *ServerTestSuite) TestShutdown_ForceClosesAfterDeadline() {
+	// ARRANGE
+	started := make(chan struct{})
+	release := make(chan struct{})
+	defer close(release)
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-release
+	})
+	srv := New(Config{ShutdownTimeout: 100 * time.Millisecond}, handler)
+	ctx, cancel := context.WithCancel(context.Background())
+	runErr := make(chan error, 1)
+	go func() { runErr <- srv.Serve(ctx, s.listener) }()
+	go func() {
+		resp, err := http.Get("http://" + s.listener.Addr().String())
+		if err == nil {
+			resp.Body.Close()
+		}
+	}()
+	<-started
+
+	// ACT
+	cancel()
+
+	// ASSERT
+	select {
+	case err := <-runErr:
+		s.ErrorIs(err, context.DeadlineExceeded, "Shutdown should report that draining timed out")
+	case <-time.After(2 * time.Second):
+		s.Fail("Shutdown should not wait beyond its deadline")
+	}
+}
+
+// TestShutdown_GivesHooksTimeAfterASlowDrain verifies that hooks still get a live context when draining used up the
+// whole shutdown timeout.
+func (s *ServerTestSuite) TestShutdown_GivesHooksTimeAfterASlowDrain() {
+	// ARRANGE
+	started := make(chan struct{})
+	release := make(chan struct{})
+	defer close(release)
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-release
+	})
+	srv := New(Config{ShutdownTimeout: 100 * time.Millisecond}, handler)
+	var hookErr error
+	srv.OnShutdown("mongodb", func(ctx context.Context) error {
+		hookErr = ctx.Err()
+		return nil
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	runErr := make(chan error, 1)
+	go func() { runErr <- srv.Serve(ctx, s.listener) }()
+	go func() {
+		resp, err := http.Get("http://" + s.listener.Addr().String())
+		if err == nil {
+			resp.Body.Close()
+		}
+	}()
+	<-started
+
+	// ACT
+	cancel()
+
+	// ASSERT
+	s.ErrorIs(<-runErr, context.DeadlineExceeded, "Draining should have timed out")
+	s.NoError(hookErr, "The hook's context should not have expired with the drain")
+}
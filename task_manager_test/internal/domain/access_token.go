@@ -0,0 +1,28 @@
+package domain
+
+import "time"
+
+// Scopes that can be granted to a personal access token.
+const (
+	ScopeTasksRead  = "tasks:read"
+	ScopeTasksWrite = "tasks:write"
+	ScopeAdmin      = "admin"
+)
+
+// AccessToken is a named, scoped personal access token used by scripts and bots instead of a password.
+// Only a hash of the token is stored; the plain value is shown once when the token is created.
+type AccessToken struct {
+	ID        string
+	UserID    string
+	Name      string
+	Scopes    []string
+	TokenHash string
+
+	CreatedAt time.Time
+	// ExpiresAt is the time after which the token is rejected; the zero value means it never expires.
+	ExpiresAt time.Time
+	// LastUsedAt is updated when the token authenticates a request, at most once per minute.
+	LastUsedAt time.Time
+	// RevokedAt is set when the owner revokes the token; the zero value means it is active.
+	RevokedAt time.Time
+}
@@ -0,0 +1,39 @@
+package domain
+
+import "time"
+
+// AuditAction names an operation recorded in the audit log.
+type AuditAction string
+
+// Actions recorded in the audit log.
+const (
+	// AuditAccountExported records that a user's data was exported.
+	AuditAccountExported AuditAction = "account.exported"
+	// AuditAccountDeleted records that a user's account and the data it owned were deleted.
+	AuditAccountDeleted AuditAction = "account.deleted"
+)
+
+// AuditEntry records an operation performed by a user on a user's account. Entries outlive the accounts they
+// mention, so both users are identified by name as well as by ID.
+type AuditEntry struct {
+	ID    string
+	OrgID string
+	// ActorID and ActorUsername identify the user who performed the operation.
+	ActorID       string
+	ActorUsername string
+	Action        AuditAction
+	// TargetUserID and TargetUsername identify the account operated on; they equal the actor for self-service operations.
+	TargetUserID   string
+	TargetUsername string
+	At             time.Time
+}
+
+// AccountExport is the data held about a user: the account without its password hash, the tasks it owns, the
+// comments it wrote and the audit entries mentioning it.
+type AccountExport struct {
+	User       User
+	Tasks      []Task
+	Comments   []Comment
+	AuditLog   []AuditEntry
+	ExportedAt time.Time
+}
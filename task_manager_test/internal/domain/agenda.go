@@ -0,0 +1,29 @@
+package domain
+
+import "time"
+
+// AgendaRange names a span of the user's local days to list due tasks for.
+type AgendaRange string
+
+// Agenda ranges. Day boundaries are midnights in the user's time zone.
+const (
+	// AgendaToday covers the current local day.
+	AgendaToday AgendaRange = "today"
+	// AgendaTomorrow covers the next local day.
+	AgendaTomorrow AgendaRange = "tomorrow"
+	// AgendaWeek covers the current local week, from Monday to Sunday.
+	AgendaWeek AgendaRange = "week"
+	// AgendaOverdue covers everything due before the current local day.
+	AgendaOverdue AgendaRange = "overdue"
+)
+
+// Agenda lists the open tasks due within a range of local days.
+type Agenda struct {
+	Range AgendaRange
+	// Location is the time zone the days were computed in.
+	Location *time.Location
+	// From and To bound the due dates, From inclusive and To exclusive. From is zero for AgendaOverdue.
+	From, To time.Time
+	// Tasks are ordered by due date.
+	Tasks []TaskListItem
+}
@@ -0,0 +1,17 @@
+package domain
+
+import "time"
+
+// Attachment describes a file attached to a task. The content itself is stored separately under its digest, so
+// identical files attached to several tasks are stored once.
+type Attachment struct {
+	ID       string
+	Filename string
+	// ContentType is sniffed from the content rather than taken from the upload.
+	ContentType string
+	Size        int64
+	// Digest is the hex-encoded SHA-256 of the content.
+	Digest     string
+	UploadedBy string
+	UploadedAt time.Time
+}
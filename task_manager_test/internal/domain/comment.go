@@ -0,0 +1,39 @@
+package domain
+
+import "time"
+
+// CommentMention is a user referenced as @username in a comment body.
+type CommentMention struct {
+	UserID   string
+	Username string
+}
+
+// Comment is a message posted on a task. Top-level comments may have replies; replies cannot be replied to.
+type Comment struct {
+	ID     string
+	TaskID string
+	// ParentID is the comment this one replies to, or empty for a top-level comment.
+	ParentID       string
+	AuthorID       string
+	AuthorUsername string
+	Body           string
+	Mentions       []CommentMention
+	CreatedAt      time.Time
+	// EditedAt is the time of the last edit, or zero if the comment was never edited.
+	EditedAt time.Time
+}
+
+// CommentThread is a top-level comment with its replies, oldest first.
+type CommentThread struct {
+	Comment Comment
+	Replies []Comment
+}
+
+// CommentPage is one page of a task's comment threads, oldest first.
+type CommentPage struct {
+	Threads []CommentThread
+	Page    int
+	PerPage int
+	// Total is the number of top-level comments on the task.
+	Total int64
+}
@@ -0,0 +1,23 @@
+package domain
+
+import "time"
+
+// IdempotencyRecord remembers a request made with an Idempotency-Key, so that retries of it are answered with the
+// first response instead of being applied again. Keys are scoped to the user who sent them.
+//
+// A record is created, incomplete, when the request starts, and completed with its response when it finishes.
+type IdempotencyRecord struct {
+	UserID string
+	Key    string
+	// RequestHash identifies the method, path and body of the request, so a key cannot be reused for another one.
+	RequestHash string
+	Completed   bool
+
+	// The stored response, set once Completed.
+	Status int
+	Header map[string][]string
+	Body   []byte
+
+	CreatedAt time.Time
+	ExpiresAt time.Time
+}
@@ -0,0 +1,15 @@
+package domain
+
+// JSONWebKey is the public half of a token signing key in RFC 7517 form. Only the members relevant to the key
+// type are set: N and E for RSA keys, Curve, X and Y for EC keys, and Curve and X for Ed25519 keys.
+type JSONWebKey struct {
+	KeyType   string
+	KeyID     string
+	Use       string
+	Algorithm string
+	N         string
+	E         string
+	Curve     string
+	X         string
+	Y         string
+}
@@ -0,0 +1,16 @@
+package domain
+
+import "time"
+
+// DefaultOrganizationSlug is the slug of the organization that self-registered users and data created before
+// organizations existed belong to.
+const DefaultOrganizationSlug = "default"
+
+// Organization is a tenant. Users, projects, tasks and comments belong to exactly one organization and are never
+// visible to the members of another.
+type Organization struct {
+	ID        string
+	Name      string
+	Slug      string
+	CreatedAt time.Time
+}
@@ -0,0 +1,12 @@
+package domain
+
+import "time"
+
+// PasswordReset is a single-use, time-limited password reset request. Only a hash of the token is stored.
+type PasswordReset struct {
+	ID        string
+	UserID    string
+	TokenHash string
+	ExpiresAt time.Time
+	UsedAt    time.Time
+}
@@ -0,0 +1,44 @@
+package domain
+
+import "time"
+
+// ProjectRole is a member's role within a single project, independent of the member's global Role.
+type ProjectRole string
+
+// Project roles, from least to most privileged.
+const (
+	// ProjectViewer can see the project and its tasks.
+	ProjectViewer ProjectRole = "viewer"
+	// ProjectEditor can also create, update and delete the project's tasks.
+	ProjectEditor ProjectRole = "editor"
+	// ProjectOwner can also rename and delete the project and manage its members.
+	ProjectOwner ProjectRole = "owner"
+)
+
+// ProjectMember is a user's membership of a project.
+type ProjectMember struct {
+	UserID   string
+	Username string
+	Role     ProjectRole
+}
+
+// Project groups tasks shared by a team. Only members can see the project and its tasks.
+type Project struct {
+	ID          string
+	Name        string
+	Description string
+	Members     []ProjectMember
+	CreatedBy   string
+	CreatedAt   time.Time
+}
+
+// TaskDisposition says what happens to a project's tasks when the project is deleted.
+type TaskDisposition string
+
+// Ways of disposing of a deleted project's tasks.
+const (
+	// DisposeDelete deletes the tasks together with the project.
+	DisposeDelete TaskDisposition = "delete"
+	// DisposeArchive keeps the tasks as archived personal tasks of their owners.
+	DisposeArchive TaskDisposition = "archive"
+)
@@ -0,0 +1,51 @@
+package domain
+
+// Role is the name of a set of permissions granted to a user.
+type Role string
+
+// Built-in roles, from least to most privileged.
+const (
+	RoleViewer  Role = "viewer"
+	RoleMember  Role = "member"
+	RoleManager Role = "manager"
+	RoleAdmin   Role = "admin"
+
+	// RoleSuperAdmin administers the whole deployment: it holds every permission, and alone may create organizations
+	// and grant or revoke super-admin rights. RoleAdmin is the administrator of a single organization.
+	RoleSuperAdmin Role = "super_admin"
+
+	// RoleLegacyUser is the role assigned to accounts before roles were introduced; it is treated as RoleMember.
+	RoleLegacyUser Role = "user"
+)
+
+// Permission names a single operation a role may be allowed to perform. Permissions ending in ".own" apply to
+// resources the user owns, and those ending in ".any" apply to every resource.
+type Permission string
+
+// Permissions understood by the application.
+const (
+	PermTaskReadOwn    Permission = "task.read.own"
+	PermTaskReadAny    Permission = "task.read.any"
+	PermTaskCreate     Permission = "task.create"
+	PermTaskUpdateOwn  Permission = "task.update.own"
+	PermTaskUpdateAny  Permission = "task.update.any"
+	PermTaskDeleteOwn  Permission = "task.delete.own"
+	PermTaskDeleteAny  Permission = "task.delete.any"
+	PermProjectCreate  Permission = "project.create"
+	PermUserManage     Permission = "user.manage"
+	PermAdminDashboard Permission = "admin.dashboard"
+)
+
+// AllPermissions lists every known permission, in a stable order.
+var AllPermissions = []Permission{
+	PermTaskReadOwn,
+	PermTaskReadAny,
+	PermTaskCreate,
+	PermTaskUpdateOwn,
+	PermTaskUpdateAny,
+	PermTaskDeleteOwn,
+	PermTaskDeleteAny,
+	PermProjectCreate,
+	PermUserManage,
+	PermAdminDashboard,
+}
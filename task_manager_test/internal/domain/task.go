@@ -2,6 +2,9 @@ package domain
 
 import "time"
 
+// TaskStatusCompleted is the status of finished tasks. Other statuses are free-form and count as open.
+const TaskStatusCompleted = "completed"
+
 // Task represents a user’s to-do item in the system.
 type Task struct {
 	ID          string
@@ -9,4 +12,26 @@ type Task struct {
 	Description string
 	DueDate     time.Time
 	Status      string
+	// OwnerID is the ID of the user who created the task; it is empty for tasks created before ownership existed.
+	OwnerID string
+	// ProjectID is the project the task belongs to; it is empty for personal tasks.
+	ProjectID string
+	// ArchivedAt is set when the task's project was deleted with its tasks archived.
+	ArchivedAt time.Time
+	// Attachments lists the files attached to the task, oldest first.
+	Attachments []Attachment
+}
+
+// TaskQuery selects tasks from storage. Unset filters select every task.
+type TaskQuery struct {
+	// All selects every task. Otherwise only the tasks owned by OwnerID or with one of the IDs are selected.
+	All     bool
+	OwnerID string
+	IDs     []string
+	// Personal leaves out the tasks that belong to a project.
+	Personal bool
+	// DueBefore, when set, selects only open tasks due from DueFrom, inclusive, to DueBefore, exclusive. Completed
+	// and archived tasks are not open.
+	DueFrom   time.Time
+	DueBefore time.Time
 }
@@ -0,0 +1,45 @@
+package domain
+
+import "time"
+
+// ShareAccess is the level of access a task share grants.
+type ShareAccess string
+
+// Access levels that can be granted on a shared task.
+const (
+	// ShareRead lets the grantee see the task.
+	ShareRead ShareAccess = "read"
+	// ShareEdit lets the grantee see and update the task, but not delete it or manage its shares.
+	ShareEdit ShareAccess = "edit"
+)
+
+// TaskShare grants a user other than the owner access to a task.
+type TaskShare struct {
+	ID     string
+	TaskID string
+	// UserID and Username identify the grantee.
+	UserID    string
+	Username  string
+	Access    ShareAccess
+	GrantedBy string
+	CreatedAt time.Time
+}
+
+// TaskOwnership describes how the requesting user came to see a task.
+type TaskOwnership string
+
+// Ownership values reported when listing tasks.
+const (
+	OwnershipOwner  TaskOwnership = "owner"
+	OwnershipShared TaskOwnership = "shared"
+	// OwnershipOther marks tasks visible only because the user's role may read every task.
+	OwnershipOther TaskOwnership = "other"
+)
+
+// TaskListItem is a task as seen by the user listing it.
+type TaskListItem struct {
+	Task      Task
+	Ownership TaskOwnership
+	// Access is the share level for shared tasks and empty otherwise.
+	Access ShareAccess
+}
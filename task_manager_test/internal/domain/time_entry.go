@@ -0,0 +1,70 @@
+package domain
+
+import "time"
+
+// TimeEntry is a span of work a user logged against a task, either timed with a timer or entered by hand.
+type TimeEntry struct {
+	ID       string
+	TaskID   string
+	UserID   string
+	Username string
+	Start    time.Time
+	// End is zero while the entry's timer is running.
+	End  time.Time
+	Note string
+	// Manual marks entries entered by hand rather than timed.
+	Manual    bool
+	CreatedAt time.Time
+}
+
+// Running reports whether the entry's timer has not been stopped.
+func (e TimeEntry) Running() bool {
+	return e.End.IsZero()
+}
+
+// Duration returns the time the entry covers, counting a running timer up to now.
+func (e TimeEntry) Duration(now time.Time) time.Duration {
+	if e.Running() {
+		return max(now.Sub(e.Start), 0)
+	}
+	return e.End.Sub(e.Start)
+}
+
+// TaskTime is the time logged against a task by every user.
+type TaskTime struct {
+	// Entries are ordered by start time.
+	Entries []TimeEntry
+	// Total counts running timers up to the time it was computed.
+	Total time.Duration
+}
+
+// HiddenTaskTitle stands in for the title of a task the user logged time on but can no longer read, for example
+// after leaving its project or losing its share.
+const HiddenTaskTitle = "(task not accessible)"
+
+// TimesheetTask is the time a user spent on a task during one day.
+type TimesheetTask struct {
+	TaskID string
+	// Title is HiddenTaskTitle for tasks the user can no longer read.
+	Title    string
+	Duration time.Duration
+}
+
+// TimesheetDay is the time a user logged during one day, by task.
+type TimesheetDay struct {
+	// Date is midnight at the start of the day in the timesheet's location.
+	Date  time.Time
+	Tasks []TimesheetTask
+	Total time.Duration
+}
+
+// Timesheet is the time a user logged over a range of days in the user's time zone. Entries spanning midnight are
+// split between the days they cover, and days without logged time are left out.
+type Timesheet struct {
+	Location *time.Location
+	// From and To are the first and last day of the range, at midnight in Location.
+	From  time.Time
+	To    time.Time
+	Days  []TimesheetDay
+	Total time.Duration
+}
@@ -1,9 +1,27 @@
 package domain
 
+import "time"
+
 // User represents an account in the system.
 type User struct {
 	ID       string
 	Username string
 	Password string
-	Role     string
+	Role     Role
+	// OrgID is the organization the user belongs to.
+	OrgID string
+	// TimeZone is the IANA name of the zone the user's days are counted in, such as "Africa/Addis_Ababa". Empty
+	// means UTC.
+	TimeZone string
+
+	// TokenVersion is embedded in issued tokens; bumping it (e.g. on a password change) revokes every existing session.
+	TokenVersion int
+
+	// FailedLoginAttempts counts consecutive failed logins since the last success or lockout.
+	FailedLoginAttempts int
+	// LockedUntil is the time until which logins are refused; the zero value means the account is not locked.
+	LockedUntil time.Time
+
+	// Disabled accounts cannot log in or use existing tokens until an operator re-enables them.
+	Disabled bool
 }
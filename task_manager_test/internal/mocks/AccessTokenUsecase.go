@@ -0,0 +1,276 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	domain "task_manager_test/internal/domain"
+	time "time"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// AccessTokenUsecase is an autogenerated mock type for the AccessTokenUsecase type
+type AccessTokenUsecase struct {
+	mock.Mock
+}
+
+type AccessTokenUsecase_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *AccessTokenUsecase) EXPECT() *AccessTokenUsecase_Expecter {
+	return &AccessTokenUsecase_Expecter{mock: &_m.Mock}
+}
+
+// Authenticate provides a mock function with given fields: ctx, token
+func (_m *AccessTokenUsecase) Authenticate(ctx context.Context, token string) (domain.AccessToken, domain.User, error) {
+	ret := _m.Called(ctx, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Authenticate")
+	}
+
+	var r0 domain.AccessToken
+	var r1 domain.User
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (domain.AccessToken, domain.User, error)); ok {
+		return rf(ctx, token)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) domain.AccessToken); ok {
+		r0 = rf(ctx, token)
+	} else {
+		r0 = ret.Get(0).(domain.AccessToken)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) domain.User); ok {
+		r1 = rf(ctx, token)
+	} else {
+		r1 = ret.Get(1).(domain.User)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string) error); ok {
+		r2 = rf(ctx, token)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// AccessTokenUsecase_Authenticate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Authenticate'
+type AccessTokenUsecase_Authenticate_Call struct {
+	*mock.Call
+}
+
+// Authenticate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - token string
+func (_e *AccessTokenUsecase_Expecter) Authenticate(ctx interface{}, token interface{}) *AccessTokenUsecase_Authenticate_Call {
+	return &AccessTokenUsecase_Authenticate_Call{Call: _e.mock.On("Authenticate", ctx, token)}
+}
+
+func (_c *AccessTokenUsecase_Authenticate_Call) Run(run func(ctx context.Context, token string)) *AccessTokenUsecase_Authenticate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *AccessTokenUsecase_Authenticate_Call) Return(_a0 domain.AccessToken, _a1 domain.User, _a2 error) *AccessTokenUsecase_Authenticate_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *AccessTokenUsecase_Authenticate_Call) RunAndReturn(run func(context.Context, string) (domain.AccessToken, domain.User, error)) *AccessTokenUsecase_Authenticate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Create provides a mock function with given fields: ctx, username, name, scopes, expiresAt
+func (_m *AccessTokenUsecase) Create(ctx context.Context, username string, name string, scopes []string, expiresAt time.Time) (domain.AccessToken, string, error) {
+	ret := _m.Called(ctx, username, name, scopes, expiresAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 domain.AccessToken
+	var r1 string
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, []string, time.Time) (domain.AccessToken, string, error)); ok {
+		return rf(ctx, username, name, scopes, expiresAt)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, []string, time.Time) domain.AccessToken); ok {
+		r0 = rf(ctx, username, name, scopes, expiresAt)
+	} else {
+		r0 = ret.Get(0).(domain.AccessToken)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, []string, time.Time) string); ok {
+		r1 = rf(ctx, username, name, scopes, expiresAt)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, string, []string, time.Time) error); ok {
+		r2 = rf(ctx, username, name, scopes, expiresAt)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// AccessTokenUsecase_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type AccessTokenUsecase_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - username string
+//   - name string
+//   - scopes []string
+//   - expiresAt time.Time
+func (_e *AccessTokenUsecase_Expecter) Create(ctx interface{}, username interface{}, name interface{}, scopes interface{}, expiresAt interface{}) *AccessTokenUsecase_Create_Call {
+	return &AccessTokenUsecase_Create_Call{Call: _e.mock.On("Create", ctx, username, name, scopes, expiresAt)}
+}
+
+func (_c *AccessTokenUsecase_Create_Call) Run(run func(ctx context.Context, username string, name string, scopes []string, expiresAt time.Time)) *AccessTokenUsecase_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].([]string), args[4].(time.Time))
+	})
+	return _c
+}
+
+func (_c *AccessTokenUsecase_Create_Call) Return(_a0 domain.AccessToken, _a1 string, _a2 error) *AccessTokenUsecase_Create_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *AccessTokenUsecase_Create_Call) RunAndReturn(run func(context.Context, string, string, []string, time.Time) (domain.AccessToken, string, error)) *AccessTokenUsecase_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// List provides a mock function with given fields: ctx, username
+func (_m *AccessTokenUsecase) List(ctx context.Context, username string) ([]domain.AccessToken, error) {
+	ret := _m.Called(ctx, username)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []domain.AccessToken
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]domain.AccessToken, error)); ok {
+		return rf(ctx, username)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []domain.AccessToken); ok {
+		r0 = rf(ctx, username)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.AccessToken)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, username)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AccessTokenUsecase_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type AccessTokenUsecase_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - ctx context.Context
+//   - username string
+func (_e *AccessTokenUsecase_Expecter) List(ctx interface{}, username interface{}) *AccessTokenUsecase_List_Call {
+	return &AccessTokenUsecase_List_Call{Call: _e.mock.On("List", ctx, username)}
+}
+
+func (_c *AccessTokenUsecase_List_Call) Run(run func(ctx context.Context, username string)) *AccessTokenUsecase_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *AccessTokenUsecase_List_Call) Return(_a0 []domain.AccessToken, _a1 error) *AccessTokenUsecase_List_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AccessTokenUsecase_List_Call) RunAndReturn(run func(context.Context, string) ([]domain.AccessToken, error)) *AccessTokenUsecase_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Revoke provides a mock function with given fields: ctx, username, id
+func (_m *AccessTokenUsecase) Revoke(ctx context.Context, username string, id string) error {
+	ret := _m.Called(ctx, username, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Revoke")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, username, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AccessTokenUsecase_Revoke_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Revoke'
+type AccessTokenUsecase_Revoke_Call struct {
+	*mock.Call
+}
+
+// Revoke is a helper method to define mock.On call
+//   - ctx context.Context
+//   - username string
+//   - id string
+func (_e *AccessTokenUsecase_Expecter) Revoke(ctx interface{}, username interface{}, id interface{}) *AccessTokenUsecase_Revoke_Call {
+	return &AccessTokenUsecase_Revoke_Call{Call: _e.mock.On("Revoke", ctx, username, id)}
+}
+
+func (_c *AccessTokenUsecase_Revoke_Call) Run(run func(ctx context.Context, username string, id string)) *AccessTokenUsecase_Revoke_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *AccessTokenUsecase_Revoke_Call) Return(_a0 error) *AccessTokenUsecase_Revoke_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *AccessTokenUsecase_Revoke_Call) RunAndReturn(run func(context.Context, string, string) error) *AccessTokenUsecase_Revoke_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewAccessTokenUsecase creates a new instance of AccessTokenUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAccessTokenUsecase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *AccessTokenUsecase {
+	mock := &AccessTokenUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
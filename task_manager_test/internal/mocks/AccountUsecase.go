@@ -0,0 +1,244 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	domain "task_manager_test/internal/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// AccountUsecase is an autogenerated mock type for the AccountUsecase type
+type AccountUsecase struct {
+	mock.Mock
+}
+
+type AccountUsecase_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *AccountUsecase) EXPECT() *AccountUsecase_Expecter {
+	return &AccountUsecase_Expecter{mock: &_m.Mock}
+}
+
+// Delete provides a mock function with given fields: ctx, password
+func (_m *AccountUsecase) Delete(ctx context.Context, password string) error {
+	ret := _m.Called(ctx, password)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, password)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AccountUsecase_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type AccountUsecase_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - password string
+func (_e *AccountUsecase_Expecter) Delete(ctx interface{}, password interface{}) *AccountUsecase_Delete_Call {
+	return &AccountUsecase_Delete_Call{Call: _e.mock.On("Delete", ctx, password)}
+}
+
+func (_c *AccountUsecase_Delete_Call) Run(run func(ctx context.Context, password string)) *AccountUsecase_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *AccountUsecase_Delete_Call) Return(_a0 error) *AccountUsecase_Delete_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *AccountUsecase_Delete_Call) RunAndReturn(run func(context.Context, string) error) *AccountUsecase_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteUser provides a mock function with given fields: ctx, username
+func (_m *AccountUsecase) DeleteUser(ctx context.Context, username string) error {
+	ret := _m.Called(ctx, username)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteUser")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, username)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AccountUsecase_DeleteUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteUser'
+type AccountUsecase_DeleteUser_Call struct {
+	*mock.Call
+}
+
+// DeleteUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - username string
+func (_e *AccountUsecase_Expecter) DeleteUser(ctx interface{}, username interface{}) *AccountUsecase_DeleteUser_Call {
+	return &AccountUsecase_DeleteUser_Call{Call: _e.mock.On("DeleteUser", ctx, username)}
+}
+
+func (_c *AccountUsecase_DeleteUser_Call) Run(run func(ctx context.Context, username string)) *AccountUsecase_DeleteUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *AccountUsecase_DeleteUser_Call) Return(_a0 error) *AccountUsecase_DeleteUser_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *AccountUsecase_DeleteUser_Call) RunAndReturn(run func(context.Context, string) error) *AccountUsecase_DeleteUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Export provides a mock function with given fields: ctx
+func (_m *AccountUsecase) Export(ctx context.Context) (domain.AccountExport, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Export")
+	}
+
+	var r0 domain.AccountExport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (domain.AccountExport, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) domain.AccountExport); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(domain.AccountExport)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AccountUsecase_Export_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Export'
+type AccountUsecase_Export_Call struct {
+	*mock.Call
+}
+
+// Export is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *AccountUsecase_Expecter) Export(ctx interface{}) *AccountUsecase_Export_Call {
+	return &AccountUsecase_Export_Call{Call: _e.mock.On("Export", ctx)}
+}
+
+func (_c *AccountUsecase_Export_Call) Run(run func(ctx context.Context)) *AccountUsecase_Export_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *AccountUsecase_Export_Call) Return(_a0 domain.AccountExport, _a1 error) *AccountUsecase_Export_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AccountUsecase_Export_Call) RunAndReturn(run func(context.Context) (domain.AccountExport, error)) *AccountUsecase_Export_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ExportUser provides a mock function with given fields: ctx, username
+func (_m *AccountUsecase) ExportUser(ctx context.Context, username string) (domain.AccountExport, error) {
+	ret := _m.Called(ctx, username)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExportUser")
+	}
+
+	var r0 domain.AccountExport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (domain.AccountExport, error)); ok {
+		return rf(ctx, username)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) domain.AccountExport); ok {
+		r0 = rf(ctx, username)
+	} else {
+		r0 = ret.Get(0).(domain.AccountExport)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, username)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AccountUsecase_ExportUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExportUser'
+type AccountUsecase_ExportUser_Call struct {
+	*mock.Call
+}
+
+// ExportUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - username string
+func (_e *AccountUsecase_Expecter) ExportUser(ctx interface{}, username interface{}) *AccountUsecase_ExportUser_Call {
+	return &AccountUsecase_ExportUser_Call{Call: _e.mock.On("ExportUser", ctx, username)}
+}
+
+func (_c *AccountUsecase_ExportUser_Call) Run(run func(ctx context.Context, username string)) *AccountUsecase_ExportUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *AccountUsecase_ExportUser_Call) Return(_a0 domain.AccountExport, _a1 error) *AccountUsecase_ExportUser_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AccountUsecase_ExportUser_Call) RunAndReturn(run func(context.Context, string) (domain.AccountExport, error)) *AccountUsecase_ExportUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewAccountUsecase creates a new instance of AccountUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAccountUsecase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *AccountUsecase {
+	mock := &AccountUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
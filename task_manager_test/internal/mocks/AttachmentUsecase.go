@@ -0,0 +1,271 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	io "io"
+	domain "task_manager_test/internal/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// AttachmentUsecase is an autogenerated mock type for the AttachmentUsecase type
+type AttachmentUsecase struct {
+	mock.Mock
+}
+
+type AttachmentUsecase_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *AttachmentUsecase) EXPECT() *AttachmentUsecase_Expecter {
+	return &AttachmentUsecase_Expecter{mock: &_m.Mock}
+}
+
+// Delete provides a mock function with given fields: ctx, taskID, attachmentID
+func (_m *AttachmentUsecase) Delete(ctx context.Context, taskID string, attachmentID string) error {
+	ret := _m.Called(ctx, taskID, attachmentID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, taskID, attachmentID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AttachmentUsecase_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type AttachmentUsecase_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - taskID string
+//   - attachmentID string
+func (_e *AttachmentUsecase_Expecter) Delete(ctx interface{}, taskID interface{}, attachmentID interface{}) *AttachmentUsecase_Delete_Call {
+	return &AttachmentUsecase_Delete_Call{Call: _e.mock.On("Delete", ctx, taskID, attachmentID)}
+}
+
+func (_c *AttachmentUsecase_Delete_Call) Run(run func(ctx context.Context, taskID string, attachmentID string)) *AttachmentUsecase_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *AttachmentUsecase_Delete_Call) Return(_a0 error) *AttachmentUsecase_Delete_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *AttachmentUsecase_Delete_Call) RunAndReturn(run func(context.Context, string, string) error) *AttachmentUsecase_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// List provides a mock function with given fields: ctx, taskID
+func (_m *AttachmentUsecase) List(ctx context.Context, taskID string) ([]domain.Attachment, error) {
+	ret := _m.Called(ctx, taskID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []domain.Attachment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]domain.Attachment, error)); ok {
+		return rf(ctx, taskID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []domain.Attachment); ok {
+		r0 = rf(ctx, taskID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Attachment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, taskID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AttachmentUsecase_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type AttachmentUsecase_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - ctx context.Context
+//   - taskID string
+func (_e *AttachmentUsecase_Expecter) List(ctx interface{}, taskID interface{}) *AttachmentUsecase_List_Call {
+	return &AttachmentUsecase_List_Call{Call: _e.mock.On("List", ctx, taskID)}
+}
+
+func (_c *AttachmentUsecase_List_Call) Run(run func(ctx context.Context, taskID string)) *AttachmentUsecase_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *AttachmentUsecase_List_Call) Return(_a0 []domain.Attachment, _a1 error) *AttachmentUsecase_List_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AttachmentUsecase_List_Call) RunAndReturn(run func(context.Context, string) ([]domain.Attachment, error)) *AttachmentUsecase_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Open provides a mock function with given fields: ctx, taskID, attachmentID
+func (_m *AttachmentUsecase) Open(ctx context.Context, taskID string, attachmentID string) (domain.Attachment, io.ReadCloser, error) {
+	ret := _m.Called(ctx, taskID, attachmentID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Open")
+	}
+
+	var r0 domain.Attachment
+	var r1 io.ReadCloser
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (domain.Attachment, io.ReadCloser, error)); ok {
+		return rf(ctx, taskID, attachmentID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) domain.Attachment); ok {
+		r0 = rf(ctx, taskID, attachmentID)
+	} else {
+		r0 = ret.Get(0).(domain.Attachment)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) io.ReadCloser); ok {
+		r1 = rf(ctx, taskID, attachmentID)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(io.ReadCloser)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, string) error); ok {
+		r2 = rf(ctx, taskID, attachmentID)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// AttachmentUsecase_Open_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Open'
+type AttachmentUsecase_Open_Call struct {
+	*mock.Call
+}
+
+// Open is a helper method to define mock.On call
+//   - ctx context.Context
+//   - taskID string
+//   - attachmentID string
+func (_e *AttachmentUsecase_Expecter) Open(ctx interface{}, taskID interface{}, attachmentID interface{}) *AttachmentUsecase_Open_Call {
+	return &AttachmentUsecase_Open_Call{Call: _e.mock.On("Open", ctx, taskID, attachmentID)}
+}
+
+func (_c *AttachmentUsecase_Open_Call) Run(run func(ctx context.Context, taskID string, attachmentID string)) *AttachmentUsecase_Open_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *AttachmentUsecase_Open_Call) Return(_a0 domain.Attachment, _a1 io.ReadCloser, _a2 error) *AttachmentUsecase_Open_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *AttachmentUsecase_Open_Call) RunAndReturn(run func(context.Context, string, string) (domain.Attachment, io.ReadCloser, error)) *AttachmentUsecase_Open_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Upload provides a mock function with given fields: ctx, taskID, filename, r
+func (_m *AttachmentUsecase) Upload(ctx context.Context, taskID string, filename string, r io.Reader) (domain.Attachment, error) {
+	ret := _m.Called(ctx, taskID, filename, r)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Upload")
+	}
+
+	var r0 domain.Attachment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, io.Reader) (domain.Attachment, error)); ok {
+		return rf(ctx, taskID, filename, r)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, io.Reader) domain.Attachment); ok {
+		r0 = rf(ctx, taskID, filename, r)
+	} else {
+		r0 = ret.Get(0).(domain.Attachment)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, io.Reader) error); ok {
+		r1 = rf(ctx, taskID, filename, r)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AttachmentUsecase_Upload_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Upload'
+type AttachmentUsecase_Upload_Call struct {
+	*mock.Call
+}
+
+// Upload is a helper method to define mock.On call
+//   - ctx context.Context
+//   - taskID string
+//   - filename string
+//   - r io.Reader
+func (_e *AttachmentUsecase_Expecter) Upload(ctx interface{}, taskID interface{}, filename interface{}, r interface{}) *AttachmentUsecase_Upload_Call {
+	return &AttachmentUsecase_Upload_Call{Call: _e.mock.On("Upload", ctx, taskID, filename, r)}
+}
+
+func (_c *AttachmentUsecase_Upload_Call) Run(run func(ctx context.Context, taskID string, filename string, r io.Reader)) *AttachmentUsecase_Upload_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(io.Reader))
+	})
+	return _c
+}
+
+func (_c *AttachmentUsecase_Upload_Call) Return(_a0 domain.Attachment, _a1 error) *AttachmentUsecase_Upload_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AttachmentUsecase_Upload_Call) RunAndReturn(run func(context.Context, string, string, io.Reader) (domain.Attachment, error)) *AttachmentUsecase_Upload_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewAttachmentUsecase creates a new instance of AttachmentUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAttachmentUsecase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *AttachmentUsecase {
+	mock := &AttachmentUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
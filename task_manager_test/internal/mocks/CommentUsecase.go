@@ -0,0 +1,262 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	domain "task_manager_test/internal/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// CommentUsecase is an autogenerated mock type for the CommentUsecase type
+type CommentUsecase struct {
+	mock.Mock
+}
+
+type CommentUsecase_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *CommentUsecase) EXPECT() *CommentUsecase_Expecter {
+	return &CommentUsecase_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function with given fields: ctx, taskID, parentID, body
+func (_m *CommentUsecase) Create(ctx context.Context, taskID string, parentID string, body string) (domain.Comment, error) {
+	ret := _m.Called(ctx, taskID, parentID, body)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 domain.Comment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) (domain.Comment, error)); ok {
+		return rf(ctx, taskID, parentID, body)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) domain.Comment); ok {
+		r0 = rf(ctx, taskID, parentID, body)
+	} else {
+		r0 = ret.Get(0).(domain.Comment)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, taskID, parentID, body)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CommentUsecase_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type CommentUsecase_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - taskID string
+//   - parentID string
+//   - body string
+func (_e *CommentUsecase_Expecter) Create(ctx interface{}, taskID interface{}, parentID interface{}, body interface{}) *CommentUsecase_Create_Call {
+	return &CommentUsecase_Create_Call{Call: _e.mock.On("Create", ctx, taskID, parentID, body)}
+}
+
+func (_c *CommentUsecase_Create_Call) Run(run func(ctx context.Context, taskID string, parentID string, body string)) *CommentUsecase_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *CommentUsecase_Create_Call) Return(_a0 domain.Comment, _a1 error) *CommentUsecase_Create_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *CommentUsecase_Create_Call) RunAndReturn(run func(context.Context, string, string, string) (domain.Comment, error)) *CommentUsecase_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Delete provides a mock function with given fields: ctx, taskID, commentID
+func (_m *CommentUsecase) Delete(ctx context.Context, taskID string, commentID string) error {
+	ret := _m.Called(ctx, taskID, commentID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, taskID, commentID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CommentUsecase_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type CommentUsecase_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - taskID string
+//   - commentID string
+func (_e *CommentUsecase_Expecter) Delete(ctx interface{}, taskID interface{}, commentID interface{}) *CommentUsecase_Delete_Call {
+	return &CommentUsecase_Delete_Call{Call: _e.mock.On("Delete", ctx, taskID, commentID)}
+}
+
+func (_c *CommentUsecase_Delete_Call) Run(run func(ctx context.Context, taskID string, commentID string)) *CommentUsecase_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *CommentUsecase_Delete_Call) Return(_a0 error) *CommentUsecase_Delete_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *CommentUsecase_Delete_Call) RunAndReturn(run func(context.Context, string, string) error) *CommentUsecase_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// List provides a mock function with given fields: ctx, taskID, page, perPage
+func (_m *CommentUsecase) List(ctx context.Context, taskID string, page int, perPage int) (domain.CommentPage, error) {
+	ret := _m.Called(ctx, taskID, page, perPage)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 domain.CommentPage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, int) (domain.CommentPage, error)); ok {
+		return rf(ctx, taskID, page, perPage)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, int) domain.CommentPage); ok {
+		r0 = rf(ctx, taskID, page, perPage)
+	} else {
+		r0 = ret.Get(0).(domain.CommentPage)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int, int) error); ok {
+		r1 = rf(ctx, taskID, page, perPage)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CommentUsecase_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type CommentUsecase_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - ctx context.Context
+//   - taskID string
+//   - page int
+//   - perPage int
+func (_e *CommentUsecase_Expecter) List(ctx interface{}, taskID interface{}, page interface{}, perPage interface{}) *CommentUsecase_List_Call {
+	return &CommentUsecase_List_Call{Call: _e.mock.On("List", ctx, taskID, page, perPage)}
+}
+
+func (_c *CommentUsecase_List_Call) Run(run func(ctx context.Context, taskID string, page int, perPage int)) *CommentUsecase_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(int), args[3].(int))
+	})
+	return _c
+}
+
+func (_c *CommentUsecase_List_Call) Return(_a0 domain.CommentPage, _a1 error) *CommentUsecase_List_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *CommentUsecase_List_Call) RunAndReturn(run func(context.Context, string, int, int) (domain.CommentPage, error)) *CommentUsecase_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Update provides a mock function with given fields: ctx, taskID, commentID, body
+func (_m *CommentUsecase) Update(ctx context.Context, taskID string, commentID string, body string) (domain.Comment, error) {
+	ret := _m.Called(ctx, taskID, commentID, body)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 domain.Comment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) (domain.Comment, error)); ok {
+		return rf(ctx, taskID, commentID, body)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) domain.Comment); ok {
+		r0 = rf(ctx, taskID, commentID, body)
+	} else {
+		r0 = ret.Get(0).(domain.Comment)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, taskID, commentID, body)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CommentUsecase_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type CommentUsecase_Update_Call struct {
+	*mock.Call
+}
+
+// Update is a helper method to define mock.On call
+//   - ctx context.Context
+//   - taskID string
+//   - commentID string
+//   - body string
+func (_e *CommentUsecase_Expecter) Update(ctx interface{}, taskID interface{}, commentID interface{}, body interface{}) *CommentUsecase_Update_Call {
+	return &CommentUsecase_Update_Call{Call: _e.mock.On("Update", ctx, taskID, commentID, body)}
+}
+
+func (_c *CommentUsecase_Update_Call) Run(run func(ctx context.Context, taskID string, commentID string, body string)) *CommentUsecase_Update_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *CommentUsecase_Update_Call) Return(_a0 domain.Comment, _a1 error) *CommentUsecase_Update_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *CommentUsecase_Update_Call) RunAndReturn(run func(context.Context, string, string, string) (domain.Comment, error)) *CommentUsecase_Update_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewCommentUsecase creates a new instance of CommentUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewCommentUsecase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *CommentUsecase {
+	mock := &CommentUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
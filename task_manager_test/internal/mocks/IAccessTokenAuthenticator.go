@@ -0,0 +1,101 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	domain "task_manager_test/internal/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IAccessTokenAuthenticator is an autogenerated mock type for the IAccessTokenAuthenticator type
+type IAccessTokenAuthenticator struct {
+	mock.Mock
+}
+
+type IAccessTokenAuthenticator_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *IAccessTokenAuthenticator) EXPECT() *IAccessTokenAuthenticator_Expecter {
+	return &IAccessTokenAuthenticator_Expecter{mock: &_m.Mock}
+}
+
+// Authenticate provides a mock function with given fields: ctx, token
+func (_m *IAccessTokenAuthenticator) Authenticate(ctx context.Context, token string) (domain.AccessToken, domain.User, error) {
+	ret := _m.Called(ctx, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Authenticate")
+	}
+
+	var r0 domain.AccessToken
+	var r1 domain.User
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (domain.AccessToken, domain.User, error)); ok {
+		return rf(ctx, token)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) domain.AccessToken); ok {
+		r0 = rf(ctx, token)
+	} else {
+		r0 = ret.Get(0).(domain.AccessToken)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) domain.User); ok {
+		r1 = rf(ctx, token)
+	} else {
+		r1 = ret.Get(1).(domain.User)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string) error); ok {
+		r2 = rf(ctx, token)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// IAccessTokenAuthenticator_Authenticate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Authenticate'
+type IAccessTokenAuthenticator_Authenticate_Call struct {
+	*mock.Call
+}
+
+// Authenticate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - token string
+func (_e *IAccessTokenAuthenticator_Expecter) Authenticate(ctx interface{}, token interface{}) *IAccessTokenAuthenticator_Authenticate_Call {
+	return &IAccessTokenAuthenticator_Authenticate_Call{Call: _e.mock.On("Authenticate", ctx, token)}
+}
+
+func (_c *IAccessTokenAuthenticator_Authenticate_Call) Run(run func(ctx context.Context, token string)) *IAccessTokenAuthenticator_Authenticate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *IAccessTokenAuthenticator_Authenticate_Call) Return(_a0 domain.AccessToken, _a1 domain.User, _a2 error) *IAccessTokenAuthenticator_Authenticate_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *IAccessTokenAuthenticator_Authenticate_Call) RunAndReturn(run func(context.Context, string) (domain.AccessToken, domain.User, error)) *IAccessTokenAuthenticator_Authenticate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewIAccessTokenAuthenticator creates a new instance of IAccessTokenAuthenticator. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIAccessTokenAuthenticator(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IAccessTokenAuthenticator {
+	mock := &IAccessTokenAuthenticator{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
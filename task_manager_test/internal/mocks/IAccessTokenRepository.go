@@ -0,0 +1,308 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	domain "task_manager_test/internal/domain"
+	time "time"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IAccessTokenRepository is an autogenerated mock type for the IAccessTokenRepository type
+type IAccessTokenRepository struct {
+	mock.Mock
+}
+
+type IAccessTokenRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *IAccessTokenRepository) EXPECT() *IAccessTokenRepository_Expecter {
+	return &IAccessTokenRepository_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function with given fields: ctx, t
+func (_m *IAccessTokenRepository) Create(ctx context.Context, t domain.AccessToken) (domain.AccessToken, error) {
+	ret := _m.Called(ctx, t)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 domain.AccessToken
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.AccessToken) (domain.AccessToken, error)); ok {
+		return rf(ctx, t)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.AccessToken) domain.AccessToken); ok {
+		r0 = rf(ctx, t)
+	} else {
+		r0 = ret.Get(0).(domain.AccessToken)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.AccessToken) error); ok {
+		r1 = rf(ctx, t)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IAccessTokenRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type IAccessTokenRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - t domain.AccessToken
+func (_e *IAccessTokenRepository_Expecter) Create(ctx interface{}, t interface{}) *IAccessTokenRepository_Create_Call {
+	return &IAccessTokenRepository_Create_Call{Call: _e.mock.On("Create", ctx, t)}
+}
+
+func (_c *IAccessTokenRepository_Create_Call) Run(run func(ctx context.Context, t domain.AccessToken)) *IAccessTokenRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.AccessToken))
+	})
+	return _c
+}
+
+func (_c *IAccessTokenRepository_Create_Call) Return(_a0 domain.AccessToken, _a1 error) *IAccessTokenRepository_Create_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IAccessTokenRepository_Create_Call) RunAndReturn(run func(context.Context, domain.AccessToken) (domain.AccessToken, error)) *IAccessTokenRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindByHash provides a mock function with given fields: ctx, tokenHash
+func (_m *IAccessTokenRepository) FindByHash(ctx context.Context, tokenHash string) (domain.AccessToken, error) {
+	ret := _m.Called(ctx, tokenHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByHash")
+	}
+
+	var r0 domain.AccessToken
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (domain.AccessToken, error)); ok {
+		return rf(ctx, tokenHash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) domain.AccessToken); ok {
+		r0 = rf(ctx, tokenHash)
+	} else {
+		r0 = ret.Get(0).(domain.AccessToken)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, tokenHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IAccessTokenRepository_FindByHash_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindByHash'
+type IAccessTokenRepository_FindByHash_Call struct {
+	*mock.Call
+}
+
+// FindByHash is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tokenHash string
+func (_e *IAccessTokenRepository_Expecter) FindByHash(ctx interface{}, tokenHash interface{}) *IAccessTokenRepository_FindByHash_Call {
+	return &IAccessTokenRepository_FindByHash_Call{Call: _e.mock.On("FindByHash", ctx, tokenHash)}
+}
+
+func (_c *IAccessTokenRepository_FindByHash_Call) Run(run func(ctx context.Context, tokenHash string)) *IAccessTokenRepository_FindByHash_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *IAccessTokenRepository_FindByHash_Call) Return(_a0 domain.AccessToken, _a1 error) *IAccessTokenRepository_FindByHash_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IAccessTokenRepository_FindByHash_Call) RunAndReturn(run func(context.Context, string) (domain.AccessToken, error)) *IAccessTokenRepository_FindByHash_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListByUser provides a mock function with given fields: ctx, userID
+func (_m *IAccessTokenRepository) ListByUser(ctx context.Context, userID string) ([]domain.AccessToken, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListByUser")
+	}
+
+	var r0 []domain.AccessToken
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]domain.AccessToken, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []domain.AccessToken); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.AccessToken)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IAccessTokenRepository_ListByUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListByUser'
+type IAccessTokenRepository_ListByUser_Call struct {
+	*mock.Call
+}
+
+// ListByUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *IAccessTokenRepository_Expecter) ListByUser(ctx interface{}, userID interface{}) *IAccessTokenRepository_ListByUser_Call {
+	return &IAccessTokenRepository_ListByUser_Call{Call: _e.mock.On("ListByUser", ctx, userID)}
+}
+
+func (_c *IAccessTokenRepository_ListByUser_Call) Run(run func(ctx context.Context, userID string)) *IAccessTokenRepository_ListByUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *IAccessTokenRepository_ListByUser_Call) Return(_a0 []domain.AccessToken, _a1 error) *IAccessTokenRepository_ListByUser_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IAccessTokenRepository_ListByUser_Call) RunAndReturn(run func(context.Context, string) ([]domain.AccessToken, error)) *IAccessTokenRepository_ListByUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Revoke provides a mock function with given fields: ctx, userID, id, now
+func (_m *IAccessTokenRepository) Revoke(ctx context.Context, userID string, id string, now time.Time) error {
+	ret := _m.Called(ctx, userID, id, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Revoke")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Time) error); ok {
+		r0 = rf(ctx, userID, id, now)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IAccessTokenRepository_Revoke_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Revoke'
+type IAccessTokenRepository_Revoke_Call struct {
+	*mock.Call
+}
+
+// Revoke is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - id string
+//   - now time.Time
+func (_e *IAccessTokenRepository_Expecter) Revoke(ctx interface{}, userID interface{}, id interface{}, now interface{}) *IAccessTokenRepository_Revoke_Call {
+	return &IAccessTokenRepository_Revoke_Call{Call: _e.mock.On("Revoke", ctx, userID, id, now)}
+}
+
+func (_c *IAccessTokenRepository_Revoke_Call) Run(run func(ctx context.Context, userID string, id string, now time.Time)) *IAccessTokenRepository_Revoke_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(time.Time))
+	})
+	return _c
+}
+
+func (_c *IAccessTokenRepository_Revoke_Call) Return(_a0 error) *IAccessTokenRepository_Revoke_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IAccessTokenRepository_Revoke_Call) RunAndReturn(run func(context.Context, string, string, time.Time) error) *IAccessTokenRepository_Revoke_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// TouchLastUsed provides a mock function with given fields: ctx, id, now
+func (_m *IAccessTokenRepository) TouchLastUsed(ctx context.Context, id string, now time.Time) error {
+	ret := _m.Called(ctx, id, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TouchLastUsed")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) error); ok {
+		r0 = rf(ctx, id, now)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IAccessTokenRepository_TouchLastUsed_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TouchLastUsed'
+type IAccessTokenRepository_TouchLastUsed_Call struct {
+	*mock.Call
+}
+
+// TouchLastUsed is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - now time.Time
+func (_e *IAccessTokenRepository_Expecter) TouchLastUsed(ctx interface{}, id interface{}, now interface{}) *IAccessTokenRepository_TouchLastUsed_Call {
+	return &IAccessTokenRepository_TouchLastUsed_Call{Call: _e.mock.On("TouchLastUsed", ctx, id, now)}
+}
+
+func (_c *IAccessTokenRepository_TouchLastUsed_Call) Run(run func(ctx context.Context, id string, now time.Time)) *IAccessTokenRepository_TouchLastUsed_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *IAccessTokenRepository_TouchLastUsed_Call) Return(_a0 error) *IAccessTokenRepository_TouchLastUsed_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IAccessTokenRepository_TouchLastUsed_Call) RunAndReturn(run func(context.Context, string, time.Time) error) *IAccessTokenRepository_TouchLastUsed_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewIAccessTokenRepository creates a new instance of IAccessTokenRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIAccessTokenRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IAccessTokenRepository {
+	mock := &IAccessTokenRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,97 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	domain "task_manager_test/internal/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IAccountRepository is an autogenerated mock type for the IAccountRepository type
+type IAccountRepository struct {
+	mock.Mock
+}
+
+type IAccountRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *IAccountRepository) EXPECT() *IAccountRepository_Expecter {
+	return &IAccountRepository_Expecter{mock: &_m.Mock}
+}
+
+// DeleteAccount provides a mock function with given fields: ctx, userID, entry
+func (_m *IAccountRepository) DeleteAccount(ctx context.Context, userID string, entry domain.AuditEntry) ([]domain.Attachment, error) {
+	ret := _m.Called(ctx, userID, entry)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteAccount")
+	}
+
+	var r0 []domain.Attachment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, domain.AuditEntry) ([]domain.Attachment, error)); ok {
+		return rf(ctx, userID, entry)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, domain.AuditEntry) []domain.Attachment); ok {
+		r0 = rf(ctx, userID, entry)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Attachment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, domain.AuditEntry) error); ok {
+		r1 = rf(ctx, userID, entry)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IAccountRepository_DeleteAccount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteAccount'
+type IAccountRepository_DeleteAccount_Call struct {
+	*mock.Call
+}
+
+// DeleteAccount is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - entry domain.AuditEntry
+func (_e *IAccountRepository_Expecter) DeleteAccount(ctx interface{}, userID interface{}, entry interface{}) *IAccountRepository_DeleteAccount_Call {
+	return &IAccountRepository_DeleteAccount_Call{Call: _e.mock.On("DeleteAccount", ctx, userID, entry)}
+}
+
+func (_c *IAccountRepository_DeleteAccount_Call) Run(run func(ctx context.Context, userID string, entry domain.AuditEntry)) *IAccountRepository_DeleteAccount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(domain.AuditEntry))
+	})
+	return _c
+}
+
+func (_c *IAccountRepository_DeleteAccount_Call) Return(_a0 []domain.Attachment, _a1 error) *IAccountRepository_DeleteAccount_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IAccountRepository_DeleteAccount_Call) RunAndReturn(run func(context.Context, string, domain.AuditEntry) ([]domain.Attachment, error)) *IAccountRepository_DeleteAccount_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewIAccountRepository creates a new instance of IAccountRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIAccountRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IAccountRepository {
+	mock := &IAccountRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,153 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	domain "task_manager_test/internal/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IAuditRepository is an autogenerated mock type for the IAuditRepository type
+type IAuditRepository struct {
+	mock.Mock
+}
+
+type IAuditRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *IAuditRepository) EXPECT() *IAuditRepository_Expecter {
+	return &IAuditRepository_Expecter{mock: &_m.Mock}
+}
+
+// ListByUser provides a mock function with given fields: ctx, userID
+func (_m *IAuditRepository) ListByUser(ctx context.Context, userID string) ([]domain.AuditEntry, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListByUser")
+	}
+
+	var r0 []domain.AuditEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]domain.AuditEntry, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []domain.AuditEntry); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.AuditEntry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IAuditRepository_ListByUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListByUser'
+type IAuditRepository_ListByUser_Call struct {
+	*mock.Call
+}
+
+// ListByUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *IAuditRepository_Expecter) ListByUser(ctx interface{}, userID interface{}) *IAuditRepository_ListByUser_Call {
+	return &IAuditRepository_ListByUser_Call{Call: _e.mock.On("ListByUser", ctx, userID)}
+}
+
+func (_c *IAuditRepository_ListByUser_Call) Run(run func(ctx context.Context, userID string)) *IAuditRepository_ListByUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *IAuditRepository_ListByUser_Call) Return(_a0 []domain.AuditEntry, _a1 error) *IAuditRepository_ListByUser_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IAuditRepository_ListByUser_Call) RunAndReturn(run func(context.Context, string) ([]domain.AuditEntry, error)) *IAuditRepository_ListByUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Record provides a mock function with given fields: ctx, e
+func (_m *IAuditRepository) Record(ctx context.Context, e domain.AuditEntry) (domain.AuditEntry, error) {
+	ret := _m.Called(ctx, e)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Record")
+	}
+
+	var r0 domain.AuditEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.AuditEntry) (domain.AuditEntry, error)); ok {
+		return rf(ctx, e)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.AuditEntry) domain.AuditEntry); ok {
+		r0 = rf(ctx, e)
+	} else {
+		r0 = ret.Get(0).(domain.AuditEntry)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.AuditEntry) error); ok {
+		r1 = rf(ctx, e)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IAuditRepository_Record_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Record'
+type IAuditRepository_Record_Call struct {
+	*mock.Call
+}
+
+// Record is a helper method to define mock.On call
+//   - ctx context.Context
+//   - e domain.AuditEntry
+func (_e *IAuditRepository_Expecter) Record(ctx interface{}, e interface{}) *IAuditRepository_Record_Call {
+	return &IAuditRepository_Record_Call{Call: _e.mock.On("Record", ctx, e)}
+}
+
+func (_c *IAuditRepository_Record_Call) Run(run func(ctx context.Context, e domain.AuditEntry)) *IAuditRepository_Record_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.AuditEntry))
+	})
+	return _c
+}
+
+func (_c *IAuditRepository_Record_Call) Return(_a0 domain.AuditEntry, _a1 error) *IAuditRepository_Record_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IAuditRepository_Record_Call) RunAndReturn(run func(context.Context, domain.AuditEntry) (domain.AuditEntry, error)) *IAuditRepository_Record_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewIAuditRepository creates a new instance of IAuditRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIAuditRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IAuditRepository {
+	mock := &IAuditRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,241 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	io "io"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IBlobStore is an autogenerated mock type for the IBlobStore type
+type IBlobStore struct {
+	mock.Mock
+}
+
+type IBlobStore_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *IBlobStore) EXPECT() *IBlobStore_Expecter {
+	return &IBlobStore_Expecter{mock: &_m.Mock}
+}
+
+// Delete provides a mock function with given fields: ctx, digest
+func (_m *IBlobStore) Delete(ctx context.Context, digest string) error {
+	ret := _m.Called(ctx, digest)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, digest)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IBlobStore_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type IBlobStore_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - digest string
+func (_e *IBlobStore_Expecter) Delete(ctx interface{}, digest interface{}) *IBlobStore_Delete_Call {
+	return &IBlobStore_Delete_Call{Call: _e.mock.On("Delete", ctx, digest)}
+}
+
+func (_c *IBlobStore_Delete_Call) Run(run func(ctx context.Context, digest string)) *IBlobStore_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *IBlobStore_Delete_Call) Return(_a0 error) *IBlobStore_Delete_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IBlobStore_Delete_Call) RunAndReturn(run func(context.Context, string) error) *IBlobStore_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Open provides a mock function with given fields: ctx, digest
+func (_m *IBlobStore) Open(ctx context.Context, digest string) (io.ReadCloser, error) {
+	ret := _m.Called(ctx, digest)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Open")
+	}
+
+	var r0 io.ReadCloser
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (io.ReadCloser, error)); ok {
+		return rf(ctx, digest)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) io.ReadCloser); ok {
+		r0 = rf(ctx, digest)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(io.ReadCloser)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, digest)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IBlobStore_Open_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Open'
+type IBlobStore_Open_Call struct {
+	*mock.Call
+}
+
+// Open is a helper method to define mock.On call
+//   - ctx context.Context
+//   - digest string
+func (_e *IBlobStore_Expecter) Open(ctx interface{}, digest interface{}) *IBlobStore_Open_Call {
+	return &IBlobStore_Open_Call{Call: _e.mock.On("Open", ctx, digest)}
+}
+
+func (_c *IBlobStore_Open_Call) Run(run func(ctx context.Context, digest string)) *IBlobStore_Open_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *IBlobStore_Open_Call) Return(_a0 io.ReadCloser, _a1 error) *IBlobStore_Open_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IBlobStore_Open_Call) RunAndReturn(run func(context.Context, string) (io.ReadCloser, error)) *IBlobStore_Open_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Put provides a mock function with given fields: ctx, r, maxSize
+func (_m *IBlobStore) Put(ctx context.Context, r io.Reader, maxSize int64) (string, int64, error) {
+	ret := _m.Called(ctx, r, maxSize)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Put")
+	}
+
+	var r0 string
+	var r1 int64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, io.Reader, int64) (string, int64, error)); ok {
+		return rf(ctx, r, maxSize)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, io.Reader, int64) string); ok {
+		r0 = rf(ctx, r, maxSize)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, io.Reader, int64) int64); ok {
+		r1 = rf(ctx, r, maxSize)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, io.Reader, int64) error); ok {
+		r2 = rf(ctx, r, maxSize)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// IBlobStore_Put_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Put'
+type IBlobStore_Put_Call struct {
+	*mock.Call
+}
+
+// Put is a helper method to define mock.On call
+//   - ctx context.Context
+//   - r io.Reader
+//   - maxSize int64
+func (_e *IBlobStore_Expecter) Put(ctx interface{}, r interface{}, maxSize interface{}) *IBlobStore_Put_Call {
+	return &IBlobStore_Put_Call{Call: _e.mock.On("Put", ctx, r, maxSize)}
+}
+
+func (_c *IBlobStore_Put_Call) Run(run func(ctx context.Context, r io.Reader, maxSize int64)) *IBlobStore_Put_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(io.Reader), args[2].(int64))
+	})
+	return _c
+}
+
+func (_c *IBlobStore_Put_Call) Return(digest string, size int64, err error) *IBlobStore_Put_Call {
+	_c.Call.Return(digest, size, err)
+	return _c
+}
+
+func (_c *IBlobStore_Put_Call) RunAndReturn(run func(context.Context, io.Reader, int64) (string, int64, error)) *IBlobStore_Put_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Release provides a mock function with given fields: digest
+func (_m *IBlobStore) Release(digest string) {
+	_m.Called(digest)
+}
+
+// IBlobStore_Release_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Release'
+type IBlobStore_Release_Call struct {
+	*mock.Call
+}
+
+// Release is a helper method to define mock.On call
+//   - digest string
+func (_e *IBlobStore_Expecter) Release(digest interface{}) *IBlobStore_Release_Call {
+	return &IBlobStore_Release_Call{Call: _e.mock.On("Release", digest)}
+}
+
+func (_c *IBlobStore_Release_Call) Run(run func(digest string)) *IBlobStore_Release_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *IBlobStore_Release_Call) Return() *IBlobStore_Release_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *IBlobStore_Release_Call) RunAndReturn(run func(string)) *IBlobStore_Release_Call {
+	_c.Run(run)
+	return _c
+}
+
+// NewIBlobStore creates a new instance of IBlobStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIBlobStore(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IBlobStore {
+	mock := &IBlobStore{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,488 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	domain "task_manager_test/internal/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ICommentRepository is an autogenerated mock type for the ICommentRepository type
+type ICommentRepository struct {
+	mock.Mock
+}
+
+type ICommentRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *ICommentRepository) EXPECT() *ICommentRepository_Expecter {
+	return &ICommentRepository_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function with given fields: ctx, c
+func (_m *ICommentRepository) Create(ctx context.Context, c domain.Comment) (domain.Comment, error) {
+	ret := _m.Called(ctx, c)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 domain.Comment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Comment) (domain.Comment, error)); ok {
+		return rf(ctx, c)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Comment) domain.Comment); ok {
+		r0 = rf(ctx, c)
+	} else {
+		r0 = ret.Get(0).(domain.Comment)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.Comment) error); ok {
+		r1 = rf(ctx, c)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ICommentRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type ICommentRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - c domain.Comment
+func (_e *ICommentRepository_Expecter) Create(ctx interface{}, c interface{}) *ICommentRepository_Create_Call {
+	return &ICommentRepository_Create_Call{Call: _e.mock.On("Create", ctx, c)}
+}
+
+func (_c *ICommentRepository_Create_Call) Run(run func(ctx context.Context, c domain.Comment)) *ICommentRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.Comment))
+	})
+	return _c
+}
+
+func (_c *ICommentRepository_Create_Call) Return(_a0 domain.Comment, _a1 error) *ICommentRepository_Create_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ICommentRepository_Create_Call) RunAndReturn(run func(context.Context, domain.Comment) (domain.Comment, error)) *ICommentRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Delete provides a mock function with given fields: ctx, id
+func (_m *ICommentRepository) Delete(ctx context.Context, id string) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ICommentRepository_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type ICommentRepository_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *ICommentRepository_Expecter) Delete(ctx interface{}, id interface{}) *ICommentRepository_Delete_Call {
+	return &ICommentRepository_Delete_Call{Call: _e.mock.On("Delete", ctx, id)}
+}
+
+func (_c *ICommentRepository_Delete_Call) Run(run func(ctx context.Context, id string)) *ICommentRepository_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *ICommentRepository_Delete_Call) Return(_a0 error) *ICommentRepository_Delete_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ICommentRepository_Delete_Call) RunAndReturn(run func(context.Context, string) error) *ICommentRepository_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteByTasks provides a mock function with given fields: ctx, taskIDs
+func (_m *ICommentRepository) DeleteByTasks(ctx context.Context, taskIDs []string) error {
+	ret := _m.Called(ctx, taskIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteByTasks")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) error); ok {
+		r0 = rf(ctx, taskIDs)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ICommentRepository_DeleteByTasks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteByTasks'
+type ICommentRepository_DeleteByTasks_Call struct {
+	*mock.Call
+}
+
+// DeleteByTasks is a helper method to define mock.On call
+//   - ctx context.Context
+//   - taskIDs []string
+func (_e *ICommentRepository_Expecter) DeleteByTasks(ctx interface{}, taskIDs interface{}) *ICommentRepository_DeleteByTasks_Call {
+	return &ICommentRepository_DeleteByTasks_Call{Call: _e.mock.On("DeleteByTasks", ctx, taskIDs)}
+}
+
+func (_c *ICommentRepository_DeleteByTasks_Call) Run(run func(ctx context.Context, taskIDs []string)) *ICommentRepository_DeleteByTasks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]string))
+	})
+	return _c
+}
+
+func (_c *ICommentRepository_DeleteByTasks_Call) Return(_a0 error) *ICommentRepository_DeleteByTasks_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ICommentRepository_DeleteByTasks_Call) RunAndReturn(run func(context.Context, []string) error) *ICommentRepository_DeleteByTasks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByID provides a mock function with given fields: ctx, id
+func (_m *ICommentRepository) GetByID(ctx context.Context, id string) (domain.Comment, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByID")
+	}
+
+	var r0 domain.Comment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (domain.Comment, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) domain.Comment); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(domain.Comment)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ICommentRepository_GetByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByID'
+type ICommentRepository_GetByID_Call struct {
+	*mock.Call
+}
+
+// GetByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *ICommentRepository_Expecter) GetByID(ctx interface{}, id interface{}) *ICommentRepository_GetByID_Call {
+	return &ICommentRepository_GetByID_Call{Call: _e.mock.On("GetByID", ctx, id)}
+}
+
+func (_c *ICommentRepository_GetByID_Call) Run(run func(ctx context.Context, id string)) *ICommentRepository_GetByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *ICommentRepository_GetByID_Call) Return(_a0 domain.Comment, _a1 error) *ICommentRepository_GetByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ICommentRepository_GetByID_Call) RunAndReturn(run func(context.Context, string) (domain.Comment, error)) *ICommentRepository_GetByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListByAuthor provides a mock function with given fields: ctx, authorID
+func (_m *ICommentRepository) ListByAuthor(ctx context.Context, authorID string) ([]domain.Comment, error) {
+	ret := _m.Called(ctx, authorID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListByAuthor")
+	}
+
+	var r0 []domain.Comment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]domain.Comment, error)); ok {
+		return rf(ctx, authorID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []domain.Comment); ok {
+		r0 = rf(ctx, authorID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Comment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, authorID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ICommentRepository_ListByAuthor_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListByAuthor'
+type ICommentRepository_ListByAuthor_Call struct {
+	*mock.Call
+}
+
+// ListByAuthor is a helper method to define mock.On call
+//   - ctx context.Context
+//   - authorID string
+func (_e *ICommentRepository_Expecter) ListByAuthor(ctx interface{}, authorID interface{}) *ICommentRepository_ListByAuthor_Call {
+	return &ICommentRepository_ListByAuthor_Call{Call: _e.mock.On("ListByAuthor", ctx, authorID)}
+}
+
+func (_c *ICommentRepository_ListByAuthor_Call) Run(run func(ctx context.Context, authorID string)) *ICommentRepository_ListByAuthor_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *ICommentRepository_ListByAuthor_Call) Return(_a0 []domain.Comment, _a1 error) *ICommentRepository_ListByAuthor_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ICommentRepository_ListByAuthor_Call) RunAndReturn(run func(context.Context, string) ([]domain.Comment, error)) *ICommentRepository_ListByAuthor_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListReplies provides a mock function with given fields: ctx, parentIDs
+func (_m *ICommentRepository) ListReplies(ctx context.Context, parentIDs []string) ([]domain.Comment, error) {
+	ret := _m.Called(ctx, parentIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListReplies")
+	}
+
+	var r0 []domain.Comment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) ([]domain.Comment, error)); ok {
+		return rf(ctx, parentIDs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string) []domain.Comment); ok {
+		r0 = rf(ctx, parentIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Comment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, parentIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ICommentRepository_ListReplies_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListReplies'
+type ICommentRepository_ListReplies_Call struct {
+	*mock.Call
+}
+
+// ListReplies is a helper method to define mock.On call
+//   - ctx context.Context
+//   - parentIDs []string
+func (_e *ICommentRepository_Expecter) ListReplies(ctx interface{}, parentIDs interface{}) *ICommentRepository_ListReplies_Call {
+	return &ICommentRepository_ListReplies_Call{Call: _e.mock.On("ListReplies", ctx, parentIDs)}
+}
+
+func (_c *ICommentRepository_ListReplies_Call) Run(run func(ctx context.Context, parentIDs []string)) *ICommentRepository_ListReplies_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]string))
+	})
+	return _c
+}
+
+func (_c *ICommentRepository_ListReplies_Call) Return(_a0 []domain.Comment, _a1 error) *ICommentRepository_ListReplies_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ICommentRepository_ListReplies_Call) RunAndReturn(run func(context.Context, []string) ([]domain.Comment, error)) *ICommentRepository_ListReplies_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListTopLevel provides a mock function with given fields: ctx, taskID, skip, limit
+func (_m *ICommentRepository) ListTopLevel(ctx context.Context, taskID string, skip int, limit int) ([]domain.Comment, int64, error) {
+	ret := _m.Called(ctx, taskID, skip, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListTopLevel")
+	}
+
+	var r0 []domain.Comment
+	var r1 int64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, int) ([]domain.Comment, int64, error)); ok {
+		return rf(ctx, taskID, skip, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, int) []domain.Comment); ok {
+		r0 = rf(ctx, taskID, skip, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Comment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int, int) int64); ok {
+		r1 = rf(ctx, taskID, skip, limit)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, int, int) error); ok {
+		r2 = rf(ctx, taskID, skip, limit)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// ICommentRepository_ListTopLevel_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListTopLevel'
+type ICommentRepository_ListTopLevel_Call struct {
+	*mock.Call
+}
+
+// ListTopLevel is a helper method to define mock.On call
+//   - ctx context.Context
+//   - taskID string
+//   - skip int
+//   - limit int
+func (_e *ICommentRepository_Expecter) ListTopLevel(ctx interface{}, taskID interface{}, skip interface{}, limit interface{}) *ICommentRepository_ListTopLevel_Call {
+	return &ICommentRepository_ListTopLevel_Call{Call: _e.mock.On("ListTopLevel", ctx, taskID, skip, limit)}
+}
+
+func (_c *ICommentRepository_ListTopLevel_Call) Run(run func(ctx context.Context, taskID string, skip int, limit int)) *ICommentRepository_ListTopLevel_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(int), args[3].(int))
+	})
+	return _c
+}
+
+func (_c *ICommentRepository_ListTopLevel_Call) Return(_a0 []domain.Comment, _a1 int64, _a2 error) *ICommentRepository_ListTopLevel_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *ICommentRepository_ListTopLevel_Call) RunAndReturn(run func(context.Context, string, int, int) ([]domain.Comment, int64, error)) *ICommentRepository_ListTopLevel_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Update provides a mock function with given fields: ctx, c
+func (_m *ICommentRepository) Update(ctx context.Context, c domain.Comment) (domain.Comment, error) {
+	ret := _m.Called(ctx, c)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 domain.Comment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Comment) (domain.Comment, error)); ok {
+		return rf(ctx, c)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Comment) domain.Comment); ok {
+		r0 = rf(ctx, c)
+	} else {
+		r0 = ret.Get(0).(domain.Comment)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.Comment) error); ok {
+		r1 = rf(ctx, c)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ICommentRepository_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type ICommentRepository_Update_Call struct {
+	*mock.Call
+}
+
+// Update is a helper method to define mock.On call
+//   - ctx context.Context
+//   - c domain.Comment
+func (_e *ICommentRepository_Expecter) Update(ctx interface{}, c interface{}) *ICommentRepository_Update_Call {
+	return &ICommentRepository_Update_Call{Call: _e.mock.On("Update", ctx, c)}
+}
+
+func (_c *ICommentRepository_Update_Call) Run(run func(ctx context.Context, c domain.Comment)) *ICommentRepository_Update_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.Comment))
+	})
+	return _c
+}
+
+func (_c *ICommentRepository_Update_Call) Return(_a0 domain.Comment, _a1 error) *ICommentRepository_Update_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ICommentRepository_Update_Call) RunAndReturn(run func(context.Context, domain.Comment) (domain.Comment, error)) *ICommentRepository_Update_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewICommentRepository creates a new instance of ICommentRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewICommentRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ICommentRepository {
+	mock := &ICommentRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,244 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	domain "task_manager_test/internal/domain"
+	time "time"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IIdempotencyRepository is an autogenerated mock type for the IIdempotencyRepository type
+type IIdempotencyRepository struct {
+	mock.Mock
+}
+
+type IIdempotencyRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *IIdempotencyRepository) EXPECT() *IIdempotencyRepository_Expecter {
+	return &IIdempotencyRepository_Expecter{mock: &_m.Mock}
+}
+
+// Complete provides a mock function with given fields: ctx, r
+func (_m *IIdempotencyRepository) Complete(ctx context.Context, r domain.IdempotencyRecord) error {
+	ret := _m.Called(ctx, r)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Complete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.IdempotencyRecord) error); ok {
+		r0 = rf(ctx, r)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IIdempotencyRepository_Complete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Complete'
+type IIdempotencyRepository_Complete_Call struct {
+	*mock.Call
+}
+
+// Complete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - r domain.IdempotencyRecord
+func (_e *IIdempotencyRepository_Expecter) Complete(ctx interface{}, r interface{}) *IIdempotencyRepository_Complete_Call {
+	return &IIdempotencyRepository_Complete_Call{Call: _e.mock.On("Complete", ctx, r)}
+}
+
+func (_c *IIdempotencyRepository_Complete_Call) Run(run func(ctx context.Context, r domain.IdempotencyRecord)) *IIdempotencyRepository_Complete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.IdempotencyRecord))
+	})
+	return _c
+}
+
+func (_c *IIdempotencyRepository_Complete_Call) Return(_a0 error) *IIdempotencyRepository_Complete_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IIdempotencyRepository_Complete_Call) RunAndReturn(run func(context.Context, domain.IdempotencyRecord) error) *IIdempotencyRepository_Complete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Release provides a mock function with given fields: ctx, r
+func (_m *IIdempotencyRepository) Release(ctx context.Context, r domain.IdempotencyRecord) error {
+	ret := _m.Called(ctx, r)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Release")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.IdempotencyRecord) error); ok {
+		r0 = rf(ctx, r)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IIdempotencyRepository_Release_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Release'
+type IIdempotencyRepository_Release_Call struct {
+	*mock.Call
+}
+
+// Release is a helper method to define mock.On call
+//   - ctx context.Context
+//   - r domain.IdempotencyRecord
+func (_e *IIdempotencyRepository_Expecter) Release(ctx interface{}, r interface{}) *IIdempotencyRepository_Release_Call {
+	return &IIdempotencyRepository_Release_Call{Call: _e.mock.On("Release", ctx, r)}
+}
+
+func (_c *IIdempotencyRepository_Release_Call) Run(run func(ctx context.Context, r domain.IdempotencyRecord)) *IIdempotencyRepository_Release_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.IdempotencyRecord))
+	})
+	return _c
+}
+
+func (_c *IIdempotencyRepository_Release_Call) Return(_a0 error) *IIdempotencyRepository_Release_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IIdempotencyRepository_Release_Call) RunAndReturn(run func(context.Context, domain.IdempotencyRecord) error) *IIdempotencyRepository_Release_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Renew provides a mock function with given fields: ctx, r
+func (_m *IIdempotencyRepository) Renew(ctx context.Context, r domain.IdempotencyRecord) error {
+	ret := _m.Called(ctx, r)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Renew")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.IdempotencyRecord) error); ok {
+		r0 = rf(ctx, r)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IIdempotencyRepository_Renew_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Renew'
+type IIdempotencyRepository_Renew_Call struct {
+	*mock.Call
+}
+
+// Renew is a helper method to define mock.On call
+//   - ctx context.Context
+//   - r domain.IdempotencyRecord
+func (_e *IIdempotencyRepository_Expecter) Renew(ctx interface{}, r interface{}) *IIdempotencyRepository_Renew_Call {
+	return &IIdempotencyRepository_Renew_Call{Call: _e.mock.On("Renew", ctx, r)}
+}
+
+func (_c *IIdempotencyRepository_Renew_Call) Run(run func(ctx context.Context, r domain.IdempotencyRecord)) *IIdempotencyRepository_Renew_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.IdempotencyRecord))
+	})
+	return _c
+}
+
+func (_c *IIdempotencyRepository_Renew_Call) Return(_a0 error) *IIdempotencyRepository_Renew_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IIdempotencyRepository_Renew_Call) RunAndReturn(run func(context.Context, domain.IdempotencyRecord) error) *IIdempotencyRepository_Renew_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Reserve provides a mock function with given fields: ctx, r, now
+func (_m *IIdempotencyRepository) Reserve(ctx context.Context, r domain.IdempotencyRecord, now time.Time) (domain.IdempotencyRecord, bool, error) {
+	ret := _m.Called(ctx, r, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Reserve")
+	}
+
+	var r0 domain.IdempotencyRecord
+	var r1 bool
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.IdempotencyRecord, time.Time) (domain.IdempotencyRecord, bool, error)); ok {
+		return rf(ctx, r, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.IdempotencyRecord, time.Time) domain.IdempotencyRecord); ok {
+		r0 = rf(ctx, r, now)
+	} else {
+		r0 = ret.Get(0).(domain.IdempotencyRecord)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.IdempotencyRecord, time.Time) bool); ok {
+		r1 = rf(ctx, r, now)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, domain.IdempotencyRecord, time.Time) error); ok {
+		r2 = rf(ctx, r, now)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// IIdempotencyRepository_Reserve_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Reserve'
+type IIdempotencyRepository_Reserve_Call struct {
+	*mock.Call
+}
+
+// Reserve is a helper method to define mock.On call
+//   - ctx context.Context
+//   - r domain.IdempotencyRecord
+//   - now time.Time
+func (_e *IIdempotencyRepository_Expecter) Reserve(ctx interface{}, r interface{}, now interface{}) *IIdempotencyRepository_Reserve_Call {
+	return &IIdempotencyRepository_Reserve_Call{Call: _e.mock.On("Reserve", ctx, r, now)}
+}
+
+func (_c *IIdempotencyRepository_Reserve_Call) Run(run func(ctx context.Context, r domain.IdempotencyRecord, now time.Time)) *IIdempotencyRepository_Reserve_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.IdempotencyRecord), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *IIdempotencyRepository_Reserve_Call) Return(_a0 domain.IdempotencyRecord, _a1 bool, _a2 error) *IIdempotencyRepository_Reserve_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *IIdempotencyRepository_Reserve_Call) RunAndReturn(run func(context.Context, domain.IdempotencyRecord, time.Time) (domain.IdempotencyRecord, bool, error)) *IIdempotencyRepository_Reserve_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewIIdempotencyRepository creates a new instance of IIdempotencyRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIIdempotencyRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IIdempotencyRepository {
+	mock := &IIdempotencyRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -3,6 +3,8 @@
 package mocks
 
 import (
+	domain "task_manager_test/internal/domain"
+
 	jwt "github.com/golang-jwt/jwt/v5"
 	mock "github.com/stretchr/testify/mock"
 )
@@ -20,9 +22,9 @@ func (_m *IJWTService) EXPECT() *IJWTService_Expecter {
 	return &IJWTService_Expecter{mock: &_m.Mock}
 }
 
-// GenerateToken provides a mock function with given fields: username, role
-func (_m *IJWTService) GenerateToken(username string, role string) (string, error) {
-	ret := _m.Called(username, role)
+// GenerateToken provides a mock function with given fields: u
+func (_m *IJWTService) GenerateToken(u domain.User) (string, error) {
+	ret := _m.Called(u)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GenerateToken")
@@ -30,17 +32,17 @@ func (_m *IJWTService) GenerateToken(username string, role string) (string, erro
 
 	var r0 string
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string, string) (string, error)); ok {
-		return rf(username, role)
+	if rf, ok := ret.Get(0).(func(domain.User) (string, error)); ok {
+		return rf(u)
 	}
-	if rf, ok := ret.Get(0).(func(string, string) string); ok {
-		r0 = rf(username, role)
+	if rf, ok := ret.Get(0).(func(domain.User) string); ok {
+		r0 = rf(u)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
 
-	if rf, ok := ret.Get(1).(func(string, string) error); ok {
-		r1 = rf(username, role)
+	if rf, ok := ret.Get(1).(func(domain.User) error); ok {
+		r1 = rf(u)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -54,15 +56,14 @@ type IJWTService_GenerateToken_Call struct {
 }
 
 // GenerateToken is a helper method to define mock.On call
-//   - username string
-//   - role string
-func (_e *IJWTService_Expecter) GenerateToken(username interface{}, role interface{}) *IJWTService_GenerateToken_Call {
-	return &IJWTService_GenerateToken_Call{Call: _e.mock.On("GenerateToken", username, role)}
+//   - u domain.User
+func (_e *IJWTService_Expecter) GenerateToken(u interface{}) *IJWTService_GenerateToken_Call {
+	return &IJWTService_GenerateToken_Call{Call: _e.mock.On("GenerateToken", u)}
 }
 
-func (_c *IJWTService_GenerateToken_Call) Run(run func(username string, role string)) *IJWTService_GenerateToken_Call {
+func (_c *IJWTService_GenerateToken_Call) Run(run func(u domain.User)) *IJWTService_GenerateToken_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(string))
+		run(args[0].(domain.User))
 	})
 	return _c
 }
@@ -72,7 +73,54 @@ func (_c *IJWTService_GenerateToken_Call) Return(_a0 string, _a1 error) *IJWTSer
 	return _c
 }
 
-func (_c *IJWTService_GenerateToken_Call) RunAndReturn(run func(string, string) (string, error)) *IJWTService_GenerateToken_Call {
+func (_c *IJWTService_GenerateToken_Call) RunAndReturn(run func(domain.User) (string, error)) *IJWTService_GenerateToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PublicKeys provides a mock function with no fields
+func (_m *IJWTService) PublicKeys() []domain.JSONWebKey {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for PublicKeys")
+	}
+
+	var r0 []domain.JSONWebKey
+	if rf, ok := ret.Get(0).(func() []domain.JSONWebKey); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.JSONWebKey)
+		}
+	}
+
+	return r0
+}
+
+// IJWTService_PublicKeys_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PublicKeys'
+type IJWTService_PublicKeys_Call struct {
+	*mock.Call
+}
+
+// PublicKeys is a helper method to define mock.On call
+func (_e *IJWTService_Expecter) PublicKeys() *IJWTService_PublicKeys_Call {
+	return &IJWTService_PublicKeys_Call{Call: _e.mock.On("PublicKeys")}
+}
+
+func (_c *IJWTService_PublicKeys_Call) Run(run func()) *IJWTService_PublicKeys_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *IJWTService_PublicKeys_Call) Return(_a0 []domain.JSONWebKey) *IJWTService_PublicKeys_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IJWTService_PublicKeys_Call) RunAndReturn(run func() []domain.JSONWebKey) *IJWTService_PublicKeys_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -0,0 +1,313 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	domain "task_manager_test/internal/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IOrganizationRepository is an autogenerated mock type for the IOrganizationRepository type
+type IOrganizationRepository struct {
+	mock.Mock
+}
+
+type IOrganizationRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *IOrganizationRepository) EXPECT() *IOrganizationRepository_Expecter {
+	return &IOrganizationRepository_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function with given fields: ctx, org
+func (_m *IOrganizationRepository) Create(ctx context.Context, org domain.Organization) (domain.Organization, error) {
+	ret := _m.Called(ctx, org)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 domain.Organization
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Organization) (domain.Organization, error)); ok {
+		return rf(ctx, org)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Organization) domain.Organization); ok {
+		r0 = rf(ctx, org)
+	} else {
+		r0 = ret.Get(0).(domain.Organization)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.Organization) error); ok {
+		r1 = rf(ctx, org)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IOrganizationRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type IOrganizationRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - org domain.Organization
+func (_e *IOrganizationRepository_Expecter) Create(ctx interface{}, org interface{}) *IOrganizationRepository_Create_Call {
+	return &IOrganizationRepository_Create_Call{Call: _e.mock.On("Create", ctx, org)}
+}
+
+func (_c *IOrganizationRepository_Create_Call) Run(run func(ctx context.Context, org domain.Organization)) *IOrganizationRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.Organization))
+	})
+	return _c
+}
+
+func (_c *IOrganizationRepository_Create_Call) Return(_a0 domain.Organization, _a1 error) *IOrganizationRepository_Create_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IOrganizationRepository_Create_Call) RunAndReturn(run func(context.Context, domain.Organization) (domain.Organization, error)) *IOrganizationRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Delete provides a mock function with given fields: ctx, id
+func (_m *IOrganizationRepository) Delete(ctx context.Context, id string) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IOrganizationRepository_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type IOrganizationRepository_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *IOrganizationRepository_Expecter) Delete(ctx interface{}, id interface{}) *IOrganizationRepository_Delete_Call {
+	return &IOrganizationRepository_Delete_Call{Call: _e.mock.On("Delete", ctx, id)}
+}
+
+func (_c *IOrganizationRepository_Delete_Call) Run(run func(ctx context.Context, id string)) *IOrganizationRepository_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *IOrganizationRepository_Delete_Call) Return(_a0 error) *IOrganizationRepository_Delete_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IOrganizationRepository_Delete_Call) RunAndReturn(run func(context.Context, string) error) *IOrganizationRepository_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindBySlug provides a mock function with given fields: ctx, slug
+func (_m *IOrganizationRepository) FindBySlug(ctx context.Context, slug string) (domain.Organization, error) {
+	ret := _m.Called(ctx, slug)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindBySlug")
+	}
+
+	var r0 domain.Organization
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (domain.Organization, error)); ok {
+		return rf(ctx, slug)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) domain.Organization); ok {
+		r0 = rf(ctx, slug)
+	} else {
+		r0 = ret.Get(0).(domain.Organization)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, slug)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IOrganizationRepository_FindBySlug_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindBySlug'
+type IOrganizationRepository_FindBySlug_Call struct {
+	*mock.Call
+}
+
+// FindBySlug is a helper method to define mock.On call
+//   - ctx context.Context
+//   - slug string
+func (_e *IOrganizationRepository_Expecter) FindBySlug(ctx interface{}, slug interface{}) *IOrganizationRepository_FindBySlug_Call {
+	return &IOrganizationRepository_FindBySlug_Call{Call: _e.mock.On("FindBySlug", ctx, slug)}
+}
+
+func (_c *IOrganizationRepository_FindBySlug_Call) Run(run func(ctx context.Context, slug string)) *IOrganizationRepository_FindBySlug_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *IOrganizationRepository_FindBySlug_Call) Return(_a0 domain.Organization, _a1 error) *IOrganizationRepository_FindBySlug_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IOrganizationRepository_FindBySlug_Call) RunAndReturn(run func(context.Context, string) (domain.Organization, error)) *IOrganizationRepository_FindBySlug_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByID provides a mock function with given fields: ctx, id
+func (_m *IOrganizationRepository) GetByID(ctx context.Context, id string) (domain.Organization, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByID")
+	}
+
+	var r0 domain.Organization
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (domain.Organization, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) domain.Organization); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(domain.Organization)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IOrganizationRepository_GetByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByID'
+type IOrganizationRepository_GetByID_Call struct {
+	*mock.Call
+}
+
+// GetByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *IOrganizationRepository_Expecter) GetByID(ctx interface{}, id interface{}) *IOrganizationRepository_GetByID_Call {
+	return &IOrganizationRepository_GetByID_Call{Call: _e.mock.On("GetByID", ctx, id)}
+}
+
+func (_c *IOrganizationRepository_GetByID_Call) Run(run func(ctx context.Context, id string)) *IOrganizationRepository_GetByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *IOrganizationRepository_GetByID_Call) Return(_a0 domain.Organization, _a1 error) *IOrganizationRepository_GetByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IOrganizationRepository_GetByID_Call) RunAndReturn(run func(context.Context, string) (domain.Organization, error)) *IOrganizationRepository_GetByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// List provides a mock function with given fields: ctx
+func (_m *IOrganizationRepository) List(ctx context.Context) ([]domain.Organization, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []domain.Organization
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]domain.Organization, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []domain.Organization); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Organization)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IOrganizationRepository_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type IOrganizationRepository_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *IOrganizationRepository_Expecter) List(ctx interface{}) *IOrganizationRepository_List_Call {
+	return &IOrganizationRepository_List_Call{Call: _e.mock.On("List", ctx)}
+}
+
+func (_c *IOrganizationRepository_List_Call) Run(run func(ctx context.Context)) *IOrganizationRepository_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *IOrganizationRepository_List_Call) Return(_a0 []domain.Organization, _a1 error) *IOrganizationRepository_List_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IOrganizationRepository_List_Call) RunAndReturn(run func(context.Context) ([]domain.Organization, error)) *IOrganizationRepository_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewIOrganizationRepository creates a new instance of IOrganizationRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIOrganizationRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IOrganizationRepository {
+	mock := &IOrganizationRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,200 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	domain "task_manager_test/internal/domain"
+	time "time"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IPasswordResetRepository is an autogenerated mock type for the IPasswordResetRepository type
+type IPasswordResetRepository struct {
+	mock.Mock
+}
+
+type IPasswordResetRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *IPasswordResetRepository) EXPECT() *IPasswordResetRepository_Expecter {
+	return &IPasswordResetRepository_Expecter{mock: &_m.Mock}
+}
+
+// Consume provides a mock function with given fields: ctx, tokenHash, now
+func (_m *IPasswordResetRepository) Consume(ctx context.Context, tokenHash string, now time.Time) (domain.PasswordReset, error) {
+	ret := _m.Called(ctx, tokenHash, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Consume")
+	}
+
+	var r0 domain.PasswordReset
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) (domain.PasswordReset, error)); ok {
+		return rf(ctx, tokenHash, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) domain.PasswordReset); ok {
+		r0 = rf(ctx, tokenHash, now)
+	} else {
+		r0 = ret.Get(0).(domain.PasswordReset)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Time) error); ok {
+		r1 = rf(ctx, tokenHash, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IPasswordResetRepository_Consume_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Consume'
+type IPasswordResetRepository_Consume_Call struct {
+	*mock.Call
+}
+
+// Consume is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tokenHash string
+//   - now time.Time
+func (_e *IPasswordResetRepository_Expecter) Consume(ctx interface{}, tokenHash interface{}, now interface{}) *IPasswordResetRepository_Consume_Call {
+	return &IPasswordResetRepository_Consume_Call{Call: _e.mock.On("Consume", ctx, tokenHash, now)}
+}
+
+func (_c *IPasswordResetRepository_Consume_Call) Run(run func(ctx context.Context, tokenHash string, now time.Time)) *IPasswordResetRepository_Consume_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *IPasswordResetRepository_Consume_Call) Return(_a0 domain.PasswordReset, _a1 error) *IPasswordResetRepository_Consume_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IPasswordResetRepository_Consume_Call) RunAndReturn(run func(context.Context, string, time.Time) (domain.PasswordReset, error)) *IPasswordResetRepository_Consume_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Create provides a mock function with given fields: ctx, r
+func (_m *IPasswordResetRepository) Create(ctx context.Context, r domain.PasswordReset) (domain.PasswordReset, error) {
+	ret := _m.Called(ctx, r)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 domain.PasswordReset
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.PasswordReset) (domain.PasswordReset, error)); ok {
+		return rf(ctx, r)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.PasswordReset) domain.PasswordReset); ok {
+		r0 = rf(ctx, r)
+	} else {
+		r0 = ret.Get(0).(domain.PasswordReset)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.PasswordReset) error); ok {
+		r1 = rf(ctx, r)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IPasswordResetRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type IPasswordResetRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - r domain.PasswordReset
+func (_e *IPasswordResetRepository_Expecter) Create(ctx interface{}, r interface{}) *IPasswordResetRepository_Create_Call {
+	return &IPasswordResetRepository_Create_Call{Call: _e.mock.On("Create", ctx, r)}
+}
+
+func (_c *IPasswordResetRepository_Create_Call) Run(run func(ctx context.Context, r domain.PasswordReset)) *IPasswordResetRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.PasswordReset))
+	})
+	return _c
+}
+
+func (_c *IPasswordResetRepository_Create_Call) Return(_a0 domain.PasswordReset, _a1 error) *IPasswordResetRepository_Create_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IPasswordResetRepository_Create_Call) RunAndReturn(run func(context.Context, domain.PasswordReset) (domain.PasswordReset, error)) *IPasswordResetRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteByUser provides a mock function with given fields: ctx, userID
+func (_m *IPasswordResetRepository) DeleteByUser(ctx context.Context, userID string) error {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteByUser")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IPasswordResetRepository_DeleteByUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteByUser'
+type IPasswordResetRepository_DeleteByUser_Call struct {
+	*mock.Call
+}
+
+// DeleteByUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *IPasswordResetRepository_Expecter) DeleteByUser(ctx interface{}, userID interface{}) *IPasswordResetRepository_DeleteByUser_Call {
+	return &IPasswordResetRepository_DeleteByUser_Call{Call: _e.mock.On("DeleteByUser", ctx, userID)}
+}
+
+func (_c *IPasswordResetRepository_DeleteByUser_Call) Run(run func(ctx context.Context, userID string)) *IPasswordResetRepository_DeleteByUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *IPasswordResetRepository_DeleteByUser_Call) Return(_a0 error) *IPasswordResetRepository_DeleteByUser_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IPasswordResetRepository_DeleteByUser_Call) RunAndReturn(run func(context.Context, string) error) *IPasswordResetRepository_DeleteByUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewIPasswordResetRepository creates a new instance of IPasswordResetRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIPasswordResetRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IPasswordResetRepository {
+	mock := &IPasswordResetRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
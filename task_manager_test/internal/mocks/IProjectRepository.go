@@ -0,0 +1,420 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	domain "task_manager_test/internal/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IProjectRepository is an autogenerated mock type for the IProjectRepository type
+type IProjectRepository struct {
+	mock.Mock
+}
+
+type IProjectRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *IProjectRepository) EXPECT() *IProjectRepository_Expecter {
+	return &IProjectRepository_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function with given fields: ctx, p
+func (_m *IProjectRepository) Create(ctx context.Context, p domain.Project) (domain.Project, error) {
+	ret := _m.Called(ctx, p)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 domain.Project
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Project) (domain.Project, error)); ok {
+		return rf(ctx, p)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Project) domain.Project); ok {
+		r0 = rf(ctx, p)
+	} else {
+		r0 = ret.Get(0).(domain.Project)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.Project) error); ok {
+		r1 = rf(ctx, p)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IProjectRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type IProjectRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - p domain.Project
+func (_e *IProjectRepository_Expecter) Create(ctx interface{}, p interface{}) *IProjectRepository_Create_Call {
+	return &IProjectRepository_Create_Call{Call: _e.mock.On("Create", ctx, p)}
+}
+
+func (_c *IProjectRepository_Create_Call) Run(run func(ctx context.Context, p domain.Project)) *IProjectRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.Project))
+	})
+	return _c
+}
+
+func (_c *IProjectRepository_Create_Call) Return(_a0 domain.Project, _a1 error) *IProjectRepository_Create_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IProjectRepository_Create_Call) RunAndReturn(run func(context.Context, domain.Project) (domain.Project, error)) *IProjectRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Delete provides a mock function with given fields: ctx, id
+func (_m *IProjectRepository) Delete(ctx context.Context, id string) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IProjectRepository_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type IProjectRepository_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *IProjectRepository_Expecter) Delete(ctx interface{}, id interface{}) *IProjectRepository_Delete_Call {
+	return &IProjectRepository_Delete_Call{Call: _e.mock.On("Delete", ctx, id)}
+}
+
+func (_c *IProjectRepository_Delete_Call) Run(run func(ctx context.Context, id string)) *IProjectRepository_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *IProjectRepository_Delete_Call) Return(_a0 error) *IProjectRepository_Delete_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IProjectRepository_Delete_Call) RunAndReturn(run func(context.Context, string) error) *IProjectRepository_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByID provides a mock function with given fields: ctx, id
+func (_m *IProjectRepository) GetByID(ctx context.Context, id string) (domain.Project, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByID")
+	}
+
+	var r0 domain.Project
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (domain.Project, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) domain.Project); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(domain.Project)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IProjectRepository_GetByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByID'
+type IProjectRepository_GetByID_Call struct {
+	*mock.Call
+}
+
+// GetByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *IProjectRepository_Expecter) GetByID(ctx interface{}, id interface{}) *IProjectRepository_GetByID_Call {
+	return &IProjectRepository_GetByID_Call{Call: _e.mock.On("GetByID", ctx, id)}
+}
+
+func (_c *IProjectRepository_GetByID_Call) Run(run func(ctx context.Context, id string)) *IProjectRepository_GetByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *IProjectRepository_GetByID_Call) Return(_a0 domain.Project, _a1 error) *IProjectRepository_GetByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IProjectRepository_GetByID_Call) RunAndReturn(run func(context.Context, string) (domain.Project, error)) *IProjectRepository_GetByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListByMember provides a mock function with given fields: ctx, userID
+func (_m *IProjectRepository) ListByMember(ctx context.Context, userID string) ([]domain.Project, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListByMember")
+	}
+
+	var r0 []domain.Project
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]domain.Project, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []domain.Project); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Project)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IProjectRepository_ListByMember_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListByMember'
+type IProjectRepository_ListByMember_Call struct {
+	*mock.Call
+}
+
+// ListByMember is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *IProjectRepository_Expecter) ListByMember(ctx interface{}, userID interface{}) *IProjectRepository_ListByMember_Call {
+	return &IProjectRepository_ListByMember_Call{Call: _e.mock.On("ListByMember", ctx, userID)}
+}
+
+func (_c *IProjectRepository_ListByMember_Call) Run(run func(ctx context.Context, userID string)) *IProjectRepository_ListByMember_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *IProjectRepository_ListByMember_Call) Return(_a0 []domain.Project, _a1 error) *IProjectRepository_ListByMember_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IProjectRepository_ListByMember_Call) RunAndReturn(run func(context.Context, string) ([]domain.Project, error)) *IProjectRepository_ListByMember_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RemoveMember provides a mock function with given fields: ctx, projectID, userID
+func (_m *IProjectRepository) RemoveMember(ctx context.Context, projectID string, userID string) error {
+	ret := _m.Called(ctx, projectID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveMember")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, projectID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IProjectRepository_RemoveMember_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveMember'
+type IProjectRepository_RemoveMember_Call struct {
+	*mock.Call
+}
+
+// RemoveMember is a helper method to define mock.On call
+//   - ctx context.Context
+//   - projectID string
+//   - userID string
+func (_e *IProjectRepository_Expecter) RemoveMember(ctx interface{}, projectID interface{}, userID interface{}) *IProjectRepository_RemoveMember_Call {
+	return &IProjectRepository_RemoveMember_Call{Call: _e.mock.On("RemoveMember", ctx, projectID, userID)}
+}
+
+func (_c *IProjectRepository_RemoveMember_Call) Run(run func(ctx context.Context, projectID string, userID string)) *IProjectRepository_RemoveMember_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *IProjectRepository_RemoveMember_Call) Return(_a0 error) *IProjectRepository_RemoveMember_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IProjectRepository_RemoveMember_Call) RunAndReturn(run func(context.Context, string, string) error) *IProjectRepository_RemoveMember_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetMember provides a mock function with given fields: ctx, projectID, m
+func (_m *IProjectRepository) SetMember(ctx context.Context, projectID string, m domain.ProjectMember) (domain.Project, error) {
+	ret := _m.Called(ctx, projectID, m)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetMember")
+	}
+
+	var r0 domain.Project
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, domain.ProjectMember) (domain.Project, error)); ok {
+		return rf(ctx, projectID, m)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, domain.ProjectMember) domain.Project); ok {
+		r0 = rf(ctx, projectID, m)
+	} else {
+		r0 = ret.Get(0).(domain.Project)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, domain.ProjectMember) error); ok {
+		r1 = rf(ctx, projectID, m)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IProjectRepository_SetMember_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetMember'
+type IProjectRepository_SetMember_Call struct {
+	*mock.Call
+}
+
+// SetMember is a helper method to define mock.On call
+//   - ctx context.Context
+//   - projectID string
+//   - m domain.ProjectMember
+func (_e *IProjectRepository_Expecter) SetMember(ctx interface{}, projectID interface{}, m interface{}) *IProjectRepository_SetMember_Call {
+	return &IProjectRepository_SetMember_Call{Call: _e.mock.On("SetMember", ctx, projectID, m)}
+}
+
+func (_c *IProjectRepository_SetMember_Call) Run(run func(ctx context.Context, projectID string, m domain.ProjectMember)) *IProjectRepository_SetMember_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(domain.ProjectMember))
+	})
+	return _c
+}
+
+func (_c *IProjectRepository_SetMember_Call) Return(_a0 domain.Project, _a1 error) *IProjectRepository_SetMember_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IProjectRepository_SetMember_Call) RunAndReturn(run func(context.Context, string, domain.ProjectMember) (domain.Project, error)) *IProjectRepository_SetMember_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Update provides a mock function with given fields: ctx, p
+func (_m *IProjectRepository) Update(ctx context.Context, p domain.Project) (domain.Project, error) {
+	ret := _m.Called(ctx, p)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 domain.Project
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Project) (domain.Project, error)); ok {
+		return rf(ctx, p)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Project) domain.Project); ok {
+		r0 = rf(ctx, p)
+	} else {
+		r0 = ret.Get(0).(domain.Project)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.Project) error); ok {
+		r1 = rf(ctx, p)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IProjectRepository_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type IProjectRepository_Update_Call struct {
+	*mock.Call
+}
+
+// Update is a helper method to define mock.On call
+//   - ctx context.Context
+//   - p domain.Project
+func (_e *IProjectRepository_Expecter) Update(ctx interface{}, p interface{}) *IProjectRepository_Update_Call {
+	return &IProjectRepository_Update_Call{Call: _e.mock.On("Update", ctx, p)}
+}
+
+func (_c *IProjectRepository_Update_Call) Run(run func(ctx context.Context, p domain.Project)) *IProjectRepository_Update_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.Project))
+	})
+	return _c
+}
+
+func (_c *IProjectRepository_Update_Call) Return(_a0 domain.Project, _a1 error) *IProjectRepository_Update_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IProjectRepository_Update_Call) RunAndReturn(run func(context.Context, domain.Project) (domain.Project, error)) *IProjectRepository_Update_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewIProjectRepository creates a new instance of IProjectRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIProjectRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IProjectRepository {
+	mock := &IProjectRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
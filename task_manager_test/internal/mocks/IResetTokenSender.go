@@ -0,0 +1,87 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	domain "task_manager_test/internal/domain"
+	time "time"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IResetTokenSender is an autogenerated mock type for the IResetTokenSender type
+type IResetTokenSender struct {
+	mock.Mock
+}
+
+type IResetTokenSender_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *IResetTokenSender) EXPECT() *IResetTokenSender_Expecter {
+	return &IResetTokenSender_Expecter{mock: &_m.Mock}
+}
+
+// SendPasswordReset provides a mock function with given fields: ctx, u, token, expiresAt
+func (_m *IResetTokenSender) SendPasswordReset(ctx context.Context, u domain.User, token string, expiresAt time.Time) error {
+	ret := _m.Called(ctx, u, token, expiresAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SendPasswordReset")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.User, string, time.Time) error); ok {
+		r0 = rf(ctx, u, token, expiresAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IResetTokenSender_SendPasswordReset_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SendPasswordReset'
+type IResetTokenSender_SendPasswordReset_Call struct {
+	*mock.Call
+}
+
+// SendPasswordReset is a helper method to define mock.On call
+//   - ctx context.Context
+//   - u domain.User
+//   - token string
+//   - expiresAt time.Time
+func (_e *IResetTokenSender_Expecter) SendPasswordReset(ctx interface{}, u interface{}, token interface{}, expiresAt interface{}) *IResetTokenSender_SendPasswordReset_Call {
+	return &IResetTokenSender_SendPasswordReset_Call{Call: _e.mock.On("SendPasswordReset", ctx, u, token, expiresAt)}
+}
+
+func (_c *IResetTokenSender_SendPasswordReset_Call) Run(run func(ctx context.Context, u domain.User, token string, expiresAt time.Time)) *IResetTokenSender_SendPasswordReset_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.User), args[2].(string), args[3].(time.Time))
+	})
+	return _c
+}
+
+func (_c *IResetTokenSender_SendPasswordReset_Call) Return(_a0 error) *IResetTokenSender_SendPasswordReset_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IResetTokenSender_SendPasswordReset_Call) RunAndReturn(run func(context.Context, domain.User, string, time.Time) error) *IResetTokenSender_SendPasswordReset_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewIResetTokenSender creates a new instance of IResetTokenSender. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIResetTokenSender(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IResetTokenSender {
+	mock := &IResetTokenSender{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,84 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ISessionValidator is an autogenerated mock type for the ISessionValidator type
+type ISessionValidator struct {
+	mock.Mock
+}
+
+type ISessionValidator_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *ISessionValidator) EXPECT() *ISessionValidator_Expecter {
+	return &ISessionValidator_Expecter{mock: &_m.Mock}
+}
+
+// ValidateSession provides a mock function with given fields: ctx, userID, tokenVersion
+func (_m *ISessionValidator) ValidateSession(ctx context.Context, userID string, tokenVersion int) error {
+	ret := _m.Called(ctx, userID, tokenVersion)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ValidateSession")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) error); ok {
+		r0 = rf(ctx, userID, tokenVersion)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ISessionValidator_ValidateSession_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ValidateSession'
+type ISessionValidator_ValidateSession_Call struct {
+	*mock.Call
+}
+
+// ValidateSession is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - tokenVersion int
+func (_e *ISessionValidator_Expecter) ValidateSession(ctx interface{}, userID interface{}, tokenVersion interface{}) *ISessionValidator_ValidateSession_Call {
+	return &ISessionValidator_ValidateSession_Call{Call: _e.mock.On("ValidateSession", ctx, userID, tokenVersion)}
+}
+
+func (_c *ISessionValidator_ValidateSession_Call) Run(run func(ctx context.Context, userID string, tokenVersion int)) *ISessionValidator_ValidateSession_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *ISessionValidator_ValidateSession_Call) Return(_a0 error) *ISessionValidator_ValidateSession_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ISessionValidator_ValidateSession_Call) RunAndReturn(run func(context.Context, string, int) error) *ISessionValidator_ValidateSession_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewISessionValidator creates a new instance of ISessionValidator. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewISessionValidator(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ISessionValidator {
+	mock := &ISessionValidator{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -5,6 +5,7 @@ package mocks
 import (
 	context "context"
 	domain "task_manager_test/internal/domain"
+	time "time"
 
 	mock "github.com/stretchr/testify/mock"
 )
@@ -22,6 +23,169 @@ func (_m *ITaskRepository) EXPECT() *ITaskRepository_Expecter {
 	return &ITaskRepository_Expecter{mock: &_m.Mock}
 }
 
+// AddAttachment provides a mock function with given fields: ctx, taskID, a
+func (_m *ITaskRepository) AddAttachment(ctx context.Context, taskID string, a domain.Attachment) (domain.Attachment, error) {
+	ret := _m.Called(ctx, taskID, a)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddAttachment")
+	}
+
+	var r0 domain.Attachment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, domain.Attachment) (domain.Attachment, error)); ok {
+		return rf(ctx, taskID, a)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, domain.Attachment) domain.Attachment); ok {
+		r0 = rf(ctx, taskID, a)
+	} else {
+		r0 = ret.Get(0).(domain.Attachment)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, domain.Attachment) error); ok {
+		r1 = rf(ctx, taskID, a)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ITaskRepository_AddAttachment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddAttachment'
+type ITaskRepository_AddAttachment_Call struct {
+	*mock.Call
+}
+
+// AddAttachment is a helper method to define mock.On call
+//   - ctx context.Context
+//   - taskID string
+//   - a domain.Attachment
+func (_e *ITaskRepository_Expecter) AddAttachment(ctx interface{}, taskID interface{}, a interface{}) *ITaskRepository_AddAttachment_Call {
+	return &ITaskRepository_AddAttachment_Call{Call: _e.mock.On("AddAttachment", ctx, taskID, a)}
+}
+
+func (_c *ITaskRepository_AddAttachment_Call) Run(run func(ctx context.Context, taskID string, a domain.Attachment)) *ITaskRepository_AddAttachment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(domain.Attachment))
+	})
+	return _c
+}
+
+func (_c *ITaskRepository_AddAttachment_Call) Return(_a0 domain.Attachment, _a1 error) *ITaskRepository_AddAttachment_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ITaskRepository_AddAttachment_Call) RunAndReturn(run func(context.Context, string, domain.Attachment) (domain.Attachment, error)) *ITaskRepository_AddAttachment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ArchiveByProject provides a mock function with given fields: ctx, projectID, at
+func (_m *ITaskRepository) ArchiveByProject(ctx context.Context, projectID string, at time.Time) error {
+	ret := _m.Called(ctx, projectID, at)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ArchiveByProject")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) error); ok {
+		r0 = rf(ctx, projectID, at)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ITaskRepository_ArchiveByProject_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ArchiveByProject'
+type ITaskRepository_ArchiveByProject_Call struct {
+	*mock.Call
+}
+
+// ArchiveByProject is a helper method to define mock.On call
+//   - ctx context.Context
+//   - projectID string
+//   - at time.Time
+func (_e *ITaskRepository_Expecter) ArchiveByProject(ctx interface{}, projectID interface{}, at interface{}) *ITaskRepository_ArchiveByProject_Call {
+	return &ITaskRepository_ArchiveByProject_Call{Call: _e.mock.On("ArchiveByProject", ctx, projectID, at)}
+}
+
+func (_c *ITaskRepository_ArchiveByProject_Call) Run(run func(ctx context.Context, projectID string, at time.Time)) *ITaskRepository_ArchiveByProject_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *ITaskRepository_ArchiveByProject_Call) Return(_a0 error) *ITaskRepository_ArchiveByProject_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ITaskRepository_ArchiveByProject_Call) RunAndReturn(run func(context.Context, string, time.Time) error) *ITaskRepository_ArchiveByProject_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CountAttachmentsByDigest provides a mock function with given fields: ctx, digest
+func (_m *ITaskRepository) CountAttachmentsByDigest(ctx context.Context, digest string) (int64, error) {
+	ret := _m.Called(ctx, digest)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountAttachmentsByDigest")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (int64, error)); ok {
+		return rf(ctx, digest)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) int64); ok {
+		r0 = rf(ctx, digest)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, digest)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ITaskRepository_CountAttachmentsByDigest_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountAttachmentsByDigest'
+type ITaskRepository_CountAttachmentsByDigest_Call struct {
+	*mock.Call
+}
+
+// CountAttachmentsByDigest is a helper method to define mock.On call
+//   - ctx context.Context
+//   - digest string
+func (_e *ITaskRepository_Expecter) CountAttachmentsByDigest(ctx interface{}, digest interface{}) *ITaskRepository_CountAttachmentsByDigest_Call {
+	return &ITaskRepository_CountAttachmentsByDigest_Call{Call: _e.mock.On("CountAttachmentsByDigest", ctx, digest)}
+}
+
+func (_c *ITaskRepository_CountAttachmentsByDigest_Call) Run(run func(ctx context.Context, digest string)) *ITaskRepository_CountAttachmentsByDigest_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *ITaskRepository_CountAttachmentsByDigest_Call) Return(_a0 int64, _a1 error) *ITaskRepository_CountAttachmentsByDigest_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ITaskRepository_CountAttachmentsByDigest_Call) RunAndReturn(run func(context.Context, string) (int64, error)) *ITaskRepository_CountAttachmentsByDigest_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Create provides a mock function with given fields: ctx, t
 func (_m *ITaskRepository) Create(ctx context.Context, t domain.Task) (domain.Task, error) {
 	ret := _m.Called(ctx, t)
@@ -126,29 +290,76 @@ func (_c *ITaskRepository_Delete_Call) RunAndReturn(run func(context.Context, st
 	return _c
 }
 
-// GetAll provides a mock function with given fields: ctx
-func (_m *ITaskRepository) GetAll(ctx context.Context) ([]domain.Task, error) {
-	ret := _m.Called(ctx)
+// DeleteByProject provides a mock function with given fields: ctx, projectID
+func (_m *ITaskRepository) DeleteByProject(ctx context.Context, projectID string) error {
+	ret := _m.Called(ctx, projectID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteByProject")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, projectID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ITaskRepository_DeleteByProject_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteByProject'
+type ITaskRepository_DeleteByProject_Call struct {
+	*mock.Call
+}
+
+// DeleteByProject is a helper method to define mock.On call
+//   - ctx context.Context
+//   - projectID string
+func (_e *ITaskRepository_Expecter) DeleteByProject(ctx interface{}, projectID interface{}) *ITaskRepository_DeleteByProject_Call {
+	return &ITaskRepository_DeleteByProject_Call{Call: _e.mock.On("DeleteByProject", ctx, projectID)}
+}
+
+func (_c *ITaskRepository_DeleteByProject_Call) Run(run func(ctx context.Context, projectID string)) *ITaskRepository_DeleteByProject_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *ITaskRepository_DeleteByProject_Call) Return(_a0 error) *ITaskRepository_DeleteByProject_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ITaskRepository_DeleteByProject_Call) RunAndReturn(run func(context.Context, string) error) *ITaskRepository_DeleteByProject_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Find provides a mock function with given fields: ctx, q
+func (_m *ITaskRepository) Find(ctx context.Context, q domain.TaskQuery) ([]domain.Task, error) {
+	ret := _m.Called(ctx, q)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetAll")
+		panic("no return value specified for Find")
 	}
 
 	var r0 []domain.Task
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context) ([]domain.Task, error)); ok {
-		return rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, domain.TaskQuery) ([]domain.Task, error)); ok {
+		return rf(ctx, q)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context) []domain.Task); ok {
-		r0 = rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, domain.TaskQuery) []domain.Task); ok {
+		r0 = rf(ctx, q)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]domain.Task)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = rf(ctx)
+	if rf, ok := ret.Get(1).(func(context.Context, domain.TaskQuery) error); ok {
+		r1 = rf(ctx, q)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -156,30 +367,31 @@ func (_m *ITaskRepository) GetAll(ctx context.Context) ([]domain.Task, error) {
 	return r0, r1
 }
 
-// ITaskRepository_GetAll_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAll'
-type ITaskRepository_GetAll_Call struct {
+// ITaskRepository_Find_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Find'
+type ITaskRepository_Find_Call struct {
 	*mock.Call
 }
 
-// GetAll is a helper method to define mock.On call
+// Find is a helper method to define mock.On call
 //   - ctx context.Context
-func (_e *ITaskRepository_Expecter) GetAll(ctx interface{}) *ITaskRepository_GetAll_Call {
-	return &ITaskRepository_GetAll_Call{Call: _e.mock.On("GetAll", ctx)}
+//   - q domain.TaskQuery
+func (_e *ITaskRepository_Expecter) Find(ctx interface{}, q interface{}) *ITaskRepository_Find_Call {
+	return &ITaskRepository_Find_Call{Call: _e.mock.On("Find", ctx, q)}
 }
 
-func (_c *ITaskRepository_GetAll_Call) Run(run func(ctx context.Context)) *ITaskRepository_GetAll_Call {
+func (_c *ITaskRepository_Find_Call) Run(run func(ctx context.Context, q domain.TaskQuery)) *ITaskRepository_Find_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context))
+		run(args[0].(context.Context), args[1].(domain.TaskQuery))
 	})
 	return _c
 }
 
-func (_c *ITaskRepository_GetAll_Call) Return(_a0 []domain.Task, _a1 error) *ITaskRepository_GetAll_Call {
+func (_c *ITaskRepository_Find_Call) Return(_a0 []domain.Task, _a1 error) *ITaskRepository_Find_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *ITaskRepository_GetAll_Call) RunAndReturn(run func(context.Context) ([]domain.Task, error)) *ITaskRepository_GetAll_Call {
+func (_c *ITaskRepository_Find_Call) RunAndReturn(run func(context.Context, domain.TaskQuery) ([]domain.Task, error)) *ITaskRepository_Find_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -241,6 +453,231 @@ func (_c *ITaskRepository_GetByID_Call) RunAndReturn(run func(context.Context, s
 	return _c
 }
 
+// GetByIDs provides a mock function with given fields: ctx, ids
+func (_m *ITaskRepository) GetByIDs(ctx context.Context, ids []string) ([]domain.Task, error) {
+	ret := _m.Called(ctx, ids)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByIDs")
+	}
+
+	var r0 []domain.Task
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) ([]domain.Task, error)); ok {
+		return rf(ctx, ids)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string) []domain.Task); ok {
+		r0 = rf(ctx, ids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Task)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, ids)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ITaskRepository_GetByIDs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByIDs'
+type ITaskRepository_GetByIDs_Call struct {
+	*mock.Call
+}
+
+// GetByIDs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ids []string
+func (_e *ITaskRepository_Expecter) GetByIDs(ctx interface{}, ids interface{}) *ITaskRepository_GetByIDs_Call {
+	return &ITaskRepository_GetByIDs_Call{Call: _e.mock.On("GetByIDs", ctx, ids)}
+}
+
+func (_c *ITaskRepository_GetByIDs_Call) Run(run func(ctx context.Context, ids []string)) *ITaskRepository_GetByIDs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]string))
+	})
+	return _c
+}
+
+func (_c *ITaskRepository_GetByIDs_Call) Return(_a0 []domain.Task, _a1 error) *ITaskRepository_GetByIDs_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ITaskRepository_GetByIDs_Call) RunAndReturn(run func(context.Context, []string) ([]domain.Task, error)) *ITaskRepository_GetByIDs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByOwner provides a mock function with given fields: ctx, ownerID
+func (_m *ITaskRepository) GetByOwner(ctx context.Context, ownerID string) ([]domain.Task, error) {
+	ret := _m.Called(ctx, ownerID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByOwner")
+	}
+
+	var r0 []domain.Task
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]domain.Task, error)); ok {
+		return rf(ctx, ownerID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []domain.Task); ok {
+		r0 = rf(ctx, ownerID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Task)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, ownerID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ITaskRepository_GetByOwner_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByOwner'
+type ITaskRepository_GetByOwner_Call struct {
+	*mock.Call
+}
+
+// GetByOwner is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ownerID string
+func (_e *ITaskRepository_Expecter) GetByOwner(ctx interface{}, ownerID interface{}) *ITaskRepository_GetByOwner_Call {
+	return &ITaskRepository_GetByOwner_Call{Call: _e.mock.On("GetByOwner", ctx, ownerID)}
+}
+
+func (_c *ITaskRepository_GetByOwner_Call) Run(run func(ctx context.Context, ownerID string)) *ITaskRepository_GetByOwner_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *ITaskRepository_GetByOwner_Call) Return(_a0 []domain.Task, _a1 error) *ITaskRepository_GetByOwner_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ITaskRepository_GetByOwner_Call) RunAndReturn(run func(context.Context, string) ([]domain.Task, error)) *ITaskRepository_GetByOwner_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByProject provides a mock function with given fields: ctx, projectID
+func (_m *ITaskRepository) GetByProject(ctx context.Context, projectID string) ([]domain.Task, error) {
+	ret := _m.Called(ctx, projectID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByProject")
+	}
+
+	var r0 []domain.Task
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]domain.Task, error)); ok {
+		return rf(ctx, projectID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []domain.Task); ok {
+		r0 = rf(ctx, projectID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Task)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, projectID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ITaskRepository_GetByProject_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByProject'
+type ITaskRepository_GetByProject_Call struct {
+	*mock.Call
+}
+
+// GetByProject is a helper method to define mock.On call
+//   - ctx context.Context
+//   - projectID string
+func (_e *ITaskRepository_Expecter) GetByProject(ctx interface{}, projectID interface{}) *ITaskRepository_GetByProject_Call {
+	return &ITaskRepository_GetByProject_Call{Call: _e.mock.On("GetByProject", ctx, projectID)}
+}
+
+func (_c *ITaskRepository_GetByProject_Call) Run(run func(ctx context.Context, projectID string)) *ITaskRepository_GetByProject_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *ITaskRepository_GetByProject_Call) Return(_a0 []domain.Task, _a1 error) *ITaskRepository_GetByProject_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ITaskRepository_GetByProject_Call) RunAndReturn(run func(context.Context, string) ([]domain.Task, error)) *ITaskRepository_GetByProject_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RemoveAttachment provides a mock function with given fields: ctx, taskID, attachmentID
+func (_m *ITaskRepository) RemoveAttachment(ctx context.Context, taskID string, attachmentID string) error {
+	ret := _m.Called(ctx, taskID, attachmentID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveAttachment")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, taskID, attachmentID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ITaskRepository_RemoveAttachment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveAttachment'
+type ITaskRepository_RemoveAttachment_Call struct {
+	*mock.Call
+}
+
+// RemoveAttachment is a helper method to define mock.On call
+//   - ctx context.Context
+//   - taskID string
+//   - attachmentID string
+func (_e *ITaskRepository_Expecter) RemoveAttachment(ctx interface{}, taskID interface{}, attachmentID interface{}) *ITaskRepository_RemoveAttachment_Call {
+	return &ITaskRepository_RemoveAttachment_Call{Call: _e.mock.On("RemoveAttachment", ctx, taskID, attachmentID)}
+}
+
+func (_c *ITaskRepository_RemoveAttachment_Call) Run(run func(ctx context.Context, taskID string, attachmentID string)) *ITaskRepository_RemoveAttachment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *ITaskRepository_RemoveAttachment_Call) Return(_a0 error) *ITaskRepository_RemoveAttachment_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ITaskRepository_RemoveAttachment_Call) RunAndReturn(run func(context.Context, string, string) error) *ITaskRepository_RemoveAttachment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Update provides a mock function with given fields: ctx, t
 func (_m *ITaskRepository) Update(ctx context.Context, t domain.Task) (domain.Task, error) {
 	ret := _m.Called(ctx, t)
@@ -0,0 +1,365 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	domain "task_manager_test/internal/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ITaskShareRepository is an autogenerated mock type for the ITaskShareRepository type
+type ITaskShareRepository struct {
+	mock.Mock
+}
+
+type ITaskShareRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *ITaskShareRepository) EXPECT() *ITaskShareRepository_Expecter {
+	return &ITaskShareRepository_Expecter{mock: &_m.Mock}
+}
+
+// Delete provides a mock function with given fields: ctx, taskID, userID
+func (_m *ITaskShareRepository) Delete(ctx context.Context, taskID string, userID string) error {
+	ret := _m.Called(ctx, taskID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, taskID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ITaskShareRepository_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type ITaskShareRepository_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - taskID string
+//   - userID string
+func (_e *ITaskShareRepository_Expecter) Delete(ctx interface{}, taskID interface{}, userID interface{}) *ITaskShareRepository_Delete_Call {
+	return &ITaskShareRepository_Delete_Call{Call: _e.mock.On("Delete", ctx, taskID, userID)}
+}
+
+func (_c *ITaskShareRepository_Delete_Call) Run(run func(ctx context.Context, taskID string, userID string)) *ITaskShareRepository_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *ITaskShareRepository_Delete_Call) Return(_a0 error) *ITaskShareRepository_Delete_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ITaskShareRepository_Delete_Call) RunAndReturn(run func(context.Context, string, string) error) *ITaskShareRepository_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteByTask provides a mock function with given fields: ctx, taskID
+func (_m *ITaskShareRepository) DeleteByTask(ctx context.Context, taskID string) error {
+	ret := _m.Called(ctx, taskID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteByTask")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, taskID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ITaskShareRepository_DeleteByTask_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteByTask'
+type ITaskShareRepository_DeleteByTask_Call struct {
+	*mock.Call
+}
+
+// DeleteByTask is a helper method to define mock.On call
+//   - ctx context.Context
+//   - taskID string
+func (_e *ITaskShareRepository_Expecter) DeleteByTask(ctx interface{}, taskID interface{}) *ITaskShareRepository_DeleteByTask_Call {
+	return &ITaskShareRepository_DeleteByTask_Call{Call: _e.mock.On("DeleteByTask", ctx, taskID)}
+}
+
+func (_c *ITaskShareRepository_DeleteByTask_Call) Run(run func(ctx context.Context, taskID string)) *ITaskShareRepository_DeleteByTask_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *ITaskShareRepository_DeleteByTask_Call) Return(_a0 error) *ITaskShareRepository_DeleteByTask_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ITaskShareRepository_DeleteByTask_Call) RunAndReturn(run func(context.Context, string) error) *ITaskShareRepository_DeleteByTask_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Find provides a mock function with given fields: ctx, taskID, userID
+func (_m *ITaskShareRepository) Find(ctx context.Context, taskID string, userID string) (domain.TaskShare, error) {
+	ret := _m.Called(ctx, taskID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Find")
+	}
+
+	var r0 domain.TaskShare
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (domain.TaskShare, error)); ok {
+		return rf(ctx, taskID, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) domain.TaskShare); ok {
+		r0 = rf(ctx, taskID, userID)
+	} else {
+		r0 = ret.Get(0).(domain.TaskShare)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, taskID, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ITaskShareRepository_Find_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Find'
+type ITaskShareRepository_Find_Call struct {
+	*mock.Call
+}
+
+// Find is a helper method to define mock.On call
+//   - ctx context.Context
+//   - taskID string
+//   - userID string
+func (_e *ITaskShareRepository_Expecter) Find(ctx interface{}, taskID interface{}, userID interface{}) *ITaskShareRepository_Find_Call {
+	return &ITaskShareRepository_Find_Call{Call: _e.mock.On("Find", ctx, taskID, userID)}
+}
+
+func (_c *ITaskShareRepository_Find_Call) Run(run func(ctx context.Context, taskID string, userID string)) *ITaskShareRepository_Find_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *ITaskShareRepository_Find_Call) Return(_a0 domain.TaskShare, _a1 error) *ITaskShareRepository_Find_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ITaskShareRepository_Find_Call) RunAndReturn(run func(context.Context, string, string) (domain.TaskShare, error)) *ITaskShareRepository_Find_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListByTask provides a mock function with given fields: ctx, taskID
+func (_m *ITaskShareRepository) ListByTask(ctx context.Context, taskID string) ([]domain.TaskShare, error) {
+	ret := _m.Called(ctx, taskID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListByTask")
+	}
+
+	var r0 []domain.TaskShare
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]domain.TaskShare, error)); ok {
+		return rf(ctx, taskID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []domain.TaskShare); ok {
+		r0 = rf(ctx, taskID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.TaskShare)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, taskID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ITaskShareRepository_ListByTask_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListByTask'
+type ITaskShareRepository_ListByTask_Call struct {
+	*mock.Call
+}
+
+// ListByTask is a helper method to define mock.On call
+//   - ctx context.Context
+//   - taskID string
+func (_e *ITaskShareRepository_Expecter) ListByTask(ctx interface{}, taskID interface{}) *ITaskShareRepository_ListByTask_Call {
+	return &ITaskShareRepository_ListByTask_Call{Call: _e.mock.On("ListByTask", ctx, taskID)}
+}
+
+func (_c *ITaskShareRepository_ListByTask_Call) Run(run func(ctx context.Context, taskID string)) *ITaskShareRepository_ListByTask_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *ITaskShareRepository_ListByTask_Call) Return(_a0 []domain.TaskShare, _a1 error) *ITaskShareRepository_ListByTask_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ITaskShareRepository_ListByTask_Call) RunAndReturn(run func(context.Context, string) ([]domain.TaskShare, error)) *ITaskShareRepository_ListByTask_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListByUser provides a mock function with given fields: ctx, userID
+func (_m *ITaskShareRepository) ListByUser(ctx context.Context, userID string) ([]domain.TaskShare, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListByUser")
+	}
+
+	var r0 []domain.TaskShare
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]domain.TaskShare, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []domain.TaskShare); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.TaskShare)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ITaskShareRepository_ListByUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListByUser'
+type ITaskShareRepository_ListByUser_Call struct {
+	*mock.Call
+}
+
+// ListByUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *ITaskShareRepository_Expecter) ListByUser(ctx interface{}, userID interface{}) *ITaskShareRepository_ListByUser_Call {
+	return &ITaskShareRepository_ListByUser_Call{Call: _e.mock.On("ListByUser", ctx, userID)}
+}
+
+func (_c *ITaskShareRepository_ListByUser_Call) Run(run func(ctx context.Context, userID string)) *ITaskShareRepository_ListByUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *ITaskShareRepository_ListByUser_Call) Return(_a0 []domain.TaskShare, _a1 error) *ITaskShareRepository_ListByUser_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ITaskShareRepository_ListByUser_Call) RunAndReturn(run func(context.Context, string) ([]domain.TaskShare, error)) *ITaskShareRepository_ListByUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Upsert provides a mock function with given fields: ctx, s
+func (_m *ITaskShareRepository) Upsert(ctx context.Context, s domain.TaskShare) (domain.TaskShare, error) {
+	ret := _m.Called(ctx, s)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Upsert")
+	}
+
+	var r0 domain.TaskShare
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.TaskShare) (domain.TaskShare, error)); ok {
+		return rf(ctx, s)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.TaskShare) domain.TaskShare); ok {
+		r0 = rf(ctx, s)
+	} else {
+		r0 = ret.Get(0).(domain.TaskShare)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.TaskShare) error); ok {
+		r1 = rf(ctx, s)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ITaskShareRepository_Upsert_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Upsert'
+type ITaskShareRepository_Upsert_Call struct {
+	*mock.Call
+}
+
+// Upsert is a helper method to define mock.On call
+//   - ctx context.Context
+//   - s domain.TaskShare
+func (_e *ITaskShareRepository_Expecter) Upsert(ctx interface{}, s interface{}) *ITaskShareRepository_Upsert_Call {
+	return &ITaskShareRepository_Upsert_Call{Call: _e.mock.On("Upsert", ctx, s)}
+}
+
+func (_c *ITaskShareRepository_Upsert_Call) Run(run func(ctx context.Context, s domain.TaskShare)) *ITaskShareRepository_Upsert_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.TaskShare))
+	})
+	return _c
+}
+
+func (_c *ITaskShareRepository_Upsert_Call) Return(_a0 domain.TaskShare, _a1 error) *ITaskShareRepository_Upsert_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ITaskShareRepository_Upsert_Call) RunAndReturn(run func(context.Context, domain.TaskShare) (domain.TaskShare, error)) *ITaskShareRepository_Upsert_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewITaskShareRepository creates a new instance of ITaskShareRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewITaskShareRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ITaskShareRepository {
+	mock := &ITaskShareRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,435 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	domain "task_manager_test/internal/domain"
+	time "time"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ITimeEntryRepository is an autogenerated mock type for the ITimeEntryRepository type
+type ITimeEntryRepository struct {
+	mock.Mock
+}
+
+type ITimeEntryRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *ITimeEntryRepository) EXPECT() *ITimeEntryRepository_Expecter {
+	return &ITimeEntryRepository_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function with given fields: ctx, e
+func (_m *ITimeEntryRepository) Create(ctx context.Context, e domain.TimeEntry) (domain.TimeEntry, error) {
+	ret := _m.Called(ctx, e)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 domain.TimeEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.TimeEntry) (domain.TimeEntry, error)); ok {
+		return rf(ctx, e)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.TimeEntry) domain.TimeEntry); ok {
+		r0 = rf(ctx, e)
+	} else {
+		r0 = ret.Get(0).(domain.TimeEntry)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.TimeEntry) error); ok {
+		r1 = rf(ctx, e)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ITimeEntryRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type ITimeEntryRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - e domain.TimeEntry
+func (_e *ITimeEntryRepository_Expecter) Create(ctx interface{}, e interface{}) *ITimeEntryRepository_Create_Call {
+	return &ITimeEntryRepository_Create_Call{Call: _e.mock.On("Create", ctx, e)}
+}
+
+func (_c *ITimeEntryRepository_Create_Call) Run(run func(ctx context.Context, e domain.TimeEntry)) *ITimeEntryRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.TimeEntry))
+	})
+	return _c
+}
+
+func (_c *ITimeEntryRepository_Create_Call) Return(_a0 domain.TimeEntry, _a1 error) *ITimeEntryRepository_Create_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ITimeEntryRepository_Create_Call) RunAndReturn(run func(context.Context, domain.TimeEntry) (domain.TimeEntry, error)) *ITimeEntryRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteByTasks provides a mock function with given fields: ctx, taskIDs
+func (_m *ITimeEntryRepository) DeleteByTasks(ctx context.Context, taskIDs []string) error {
+	ret := _m.Called(ctx, taskIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteByTasks")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) error); ok {
+		r0 = rf(ctx, taskIDs)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ITimeEntryRepository_DeleteByTasks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteByTasks'
+type ITimeEntryRepository_DeleteByTasks_Call struct {
+	*mock.Call
+}
+
+// DeleteByTasks is a helper method to define mock.On call
+//   - ctx context.Context
+//   - taskIDs []string
+func (_e *ITimeEntryRepository_Expecter) DeleteByTasks(ctx interface{}, taskIDs interface{}) *ITimeEntryRepository_DeleteByTasks_Call {
+	return &ITimeEntryRepository_DeleteByTasks_Call{Call: _e.mock.On("DeleteByTasks", ctx, taskIDs)}
+}
+
+func (_c *ITimeEntryRepository_DeleteByTasks_Call) Run(run func(ctx context.Context, taskIDs []string)) *ITimeEntryRepository_DeleteByTasks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]string))
+	})
+	return _c
+}
+
+func (_c *ITimeEntryRepository_DeleteByTasks_Call) Return(_a0 error) *ITimeEntryRepository_DeleteByTasks_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ITimeEntryRepository_DeleteByTasks_Call) RunAndReturn(run func(context.Context, []string) error) *ITimeEntryRepository_DeleteByTasks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindRunning provides a mock function with given fields: ctx, userID
+func (_m *ITimeEntryRepository) FindRunning(ctx context.Context, userID string) (domain.TimeEntry, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindRunning")
+	}
+
+	var r0 domain.TimeEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (domain.TimeEntry, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) domain.TimeEntry); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(domain.TimeEntry)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ITimeEntryRepository_FindRunning_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindRunning'
+type ITimeEntryRepository_FindRunning_Call struct {
+	*mock.Call
+}
+
+// FindRunning is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *ITimeEntryRepository_Expecter) FindRunning(ctx interface{}, userID interface{}) *ITimeEntryRepository_FindRunning_Call {
+	return &ITimeEntryRepository_FindRunning_Call{Call: _e.mock.On("FindRunning", ctx, userID)}
+}
+
+func (_c *ITimeEntryRepository_FindRunning_Call) Run(run func(ctx context.Context, userID string)) *ITimeEntryRepository_FindRunning_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *ITimeEntryRepository_FindRunning_Call) Return(_a0 domain.TimeEntry, _a1 error) *ITimeEntryRepository_FindRunning_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ITimeEntryRepository_FindRunning_Call) RunAndReturn(run func(context.Context, string) (domain.TimeEntry, error)) *ITimeEntryRepository_FindRunning_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListByTask provides a mock function with given fields: ctx, taskID
+func (_m *ITimeEntryRepository) ListByTask(ctx context.Context, taskID string) ([]domain.TimeEntry, error) {
+	ret := _m.Called(ctx, taskID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListByTask")
+	}
+
+	var r0 []domain.TimeEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]domain.TimeEntry, error)); ok {
+		return rf(ctx, taskID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []domain.TimeEntry); ok {
+		r0 = rf(ctx, taskID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.TimeEntry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, taskID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ITimeEntryRepository_ListByTask_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListByTask'
+type ITimeEntryRepository_ListByTask_Call struct {
+	*mock.Call
+}
+
+// ListByTask is a helper method to define mock.On call
+//   - ctx context.Context
+//   - taskID string
+func (_e *ITimeEntryRepository_Expecter) ListByTask(ctx interface{}, taskID interface{}) *ITimeEntryRepository_ListByTask_Call {
+	return &ITimeEntryRepository_ListByTask_Call{Call: _e.mock.On("ListByTask", ctx, taskID)}
+}
+
+func (_c *ITimeEntryRepository_ListByTask_Call) Run(run func(ctx context.Context, taskID string)) *ITimeEntryRepository_ListByTask_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *ITimeEntryRepository_ListByTask_Call) Return(_a0 []domain.TimeEntry, _a1 error) *ITimeEntryRepository_ListByTask_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ITimeEntryRepository_ListByTask_Call) RunAndReturn(run func(context.Context, string) ([]domain.TimeEntry, error)) *ITimeEntryRepository_ListByTask_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListByUser provides a mock function with given fields: ctx, userID, from, to
+func (_m *ITimeEntryRepository) ListByUser(ctx context.Context, userID string, from time.Time, to time.Time) ([]domain.TimeEntry, error) {
+	ret := _m.Called(ctx, userID, from, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListByUser")
+	}
+
+	var r0 []domain.TimeEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time, time.Time) ([]domain.TimeEntry, error)); ok {
+		return rf(ctx, userID, from, to)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time, time.Time) []domain.TimeEntry); ok {
+		r0 = rf(ctx, userID, from, to)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.TimeEntry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, userID, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ITimeEntryRepository_ListByUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListByUser'
+type ITimeEntryRepository_ListByUser_Call struct {
+	*mock.Call
+}
+
+// ListByUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - from time.Time
+//   - to time.Time
+func (_e *ITimeEntryRepository_Expecter) ListByUser(ctx interface{}, userID interface{}, from interface{}, to interface{}) *ITimeEntryRepository_ListByUser_Call {
+	return &ITimeEntryRepository_ListByUser_Call{Call: _e.mock.On("ListByUser", ctx, userID, from, to)}
+}
+
+func (_c *ITimeEntryRepository_ListByUser_Call) Run(run func(ctx context.Context, userID string, from time.Time, to time.Time)) *ITimeEntryRepository_ListByUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(time.Time), args[3].(time.Time))
+	})
+	return _c
+}
+
+func (_c *ITimeEntryRepository_ListByUser_Call) Return(_a0 []domain.TimeEntry, _a1 error) *ITimeEntryRepository_ListByUser_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ITimeEntryRepository_ListByUser_Call) RunAndReturn(run func(context.Context, string, time.Time, time.Time) ([]domain.TimeEntry, error)) *ITimeEntryRepository_ListByUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Start provides a mock function with given fields: ctx, e
+func (_m *ITimeEntryRepository) Start(ctx context.Context, e domain.TimeEntry) (domain.TimeEntry, error) {
+	ret := _m.Called(ctx, e)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Start")
+	}
+
+	var r0 domain.TimeEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.TimeEntry) (domain.TimeEntry, error)); ok {
+		return rf(ctx, e)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.TimeEntry) domain.TimeEntry); ok {
+		r0 = rf(ctx, e)
+	} else {
+		r0 = ret.Get(0).(domain.TimeEntry)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.TimeEntry) error); ok {
+		r1 = rf(ctx, e)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ITimeEntryRepository_Start_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Start'
+type ITimeEntryRepository_Start_Call struct {
+	*mock.Call
+}
+
+// Start is a helper method to define mock.On call
+//   - ctx context.Context
+//   - e domain.TimeEntry
+func (_e *ITimeEntryRepository_Expecter) Start(ctx interface{}, e interface{}) *ITimeEntryRepository_Start_Call {
+	return &ITimeEntryRepository_Start_Call{Call: _e.mock.On("Start", ctx, e)}
+}
+
+func (_c *ITimeEntryRepository_Start_Call) Run(run func(ctx context.Context, e domain.TimeEntry)) *ITimeEntryRepository_Start_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.TimeEntry))
+	})
+	return _c
+}
+
+func (_c *ITimeEntryRepository_Start_Call) Return(_a0 domain.TimeEntry, _a1 error) *ITimeEntryRepository_Start_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ITimeEntryRepository_Start_Call) RunAndReturn(run func(context.Context, domain.TimeEntry) (domain.TimeEntry, error)) *ITimeEntryRepository_Start_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Stop provides a mock function with given fields: ctx, userID, taskID, end
+func (_m *ITimeEntryRepository) Stop(ctx context.Context, userID string, taskID string, end time.Time) (domain.TimeEntry, error) {
+	ret := _m.Called(ctx, userID, taskID, end)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Stop")
+	}
+
+	var r0 domain.TimeEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Time) (domain.TimeEntry, error)); ok {
+		return rf(ctx, userID, taskID, end)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Time) domain.TimeEntry); ok {
+		r0 = rf(ctx, userID, taskID, end)
+	} else {
+		r0 = ret.Get(0).(domain.TimeEntry)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, time.Time) error); ok {
+		r1 = rf(ctx, userID, taskID, end)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ITimeEntryRepository_Stop_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Stop'
+type ITimeEntryRepository_Stop_Call struct {
+	*mock.Call
+}
+
+// Stop is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - taskID string
+//   - end time.Time
+func (_e *ITimeEntryRepository_Expecter) Stop(ctx interface{}, userID interface{}, taskID interface{}, end interface{}) *ITimeEntryRepository_Stop_Call {
+	return &ITimeEntryRepository_Stop_Call{Call: _e.mock.On("Stop", ctx, userID, taskID, end)}
+}
+
+func (_c *ITimeEntryRepository_Stop_Call) Run(run func(ctx context.Context, userID string, taskID string, end time.Time)) *ITimeEntryRepository_Stop_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(time.Time))
+	})
+	return _c
+}
+
+func (_c *ITimeEntryRepository_Stop_Call) Return(_a0 domain.TimeEntry, _a1 error) *ITimeEntryRepository_Stop_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ITimeEntryRepository_Stop_Call) RunAndReturn(run func(context.Context, string, string, time.Time) (domain.TimeEntry, error)) *ITimeEntryRepository_Stop_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewITimeEntryRepository creates a new instance of ITimeEntryRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewITimeEntryRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ITimeEntryRepository {
+	mock := &ITimeEntryRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
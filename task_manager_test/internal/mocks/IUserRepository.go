@@ -5,6 +5,7 @@ package mocks
 import (
 	context "context"
 	domain "task_manager_test/internal/domain"
+	time "time"
 
 	mock "github.com/stretchr/testify/mock"
 )
@@ -79,6 +80,63 @@ func (_c *IUserRepository_Create_Call) RunAndReturn(run func(context.Context, do
 	return _c
 }
 
+// FindByID provides a mock function with given fields: ctx, id
+func (_m *IUserRepository) FindByID(ctx context.Context, id string) (domain.User, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByID")
+	}
+
+	var r0 domain.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (domain.User, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) domain.User); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(domain.User)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IUserRepository_FindByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindByID'
+type IUserRepository_FindByID_Call struct {
+	*mock.Call
+}
+
+// FindByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *IUserRepository_Expecter) FindByID(ctx interface{}, id interface{}) *IUserRepository_FindByID_Call {
+	return &IUserRepository_FindByID_Call{Call: _e.mock.On("FindByID", ctx, id)}
+}
+
+func (_c *IUserRepository_FindByID_Call) Run(run func(ctx context.Context, id string)) *IUserRepository_FindByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *IUserRepository_FindByID_Call) Return(_a0 domain.User, _a1 error) *IUserRepository_FindByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IUserRepository_FindByID_Call) RunAndReturn(run func(context.Context, string) (domain.User, error)) *IUserRepository_FindByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // FindByUsername provides a mock function with given fields: ctx, username
 func (_m *IUserRepository) FindByUsername(ctx context.Context, username string) (domain.User, error) {
 	ret := _m.Called(ctx, username)
@@ -136,6 +194,515 @@ func (_c *IUserRepository_FindByUsername_Call) RunAndReturn(run func(context.Con
 	return _c
 }
 
+// IncrementFailedLogins provides a mock function with given fields: ctx, username
+func (_m *IUserRepository) IncrementFailedLogins(ctx context.Context, username string) (int, error) {
+	ret := _m.Called(ctx, username)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IncrementFailedLogins")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (int, error)); ok {
+		return rf(ctx, username)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) int); ok {
+		r0 = rf(ctx, username)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, username)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IUserRepository_IncrementFailedLogins_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IncrementFailedLogins'
+type IUserRepository_IncrementFailedLogins_Call struct {
+	*mock.Call
+}
+
+// IncrementFailedLogins is a helper method to define mock.On call
+//   - ctx context.Context
+//   - username string
+func (_e *IUserRepository_Expecter) IncrementFailedLogins(ctx interface{}, username interface{}) *IUserRepository_IncrementFailedLogins_Call {
+	return &IUserRepository_IncrementFailedLogins_Call{Call: _e.mock.On("IncrementFailedLogins", ctx, username)}
+}
+
+func (_c *IUserRepository_IncrementFailedLogins_Call) Run(run func(ctx context.Context, username string)) *IUserRepository_IncrementFailedLogins_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *IUserRepository_IncrementFailedLogins_Call) Return(_a0 int, _a1 error) *IUserRepository_IncrementFailedLogins_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IUserRepository_IncrementFailedLogins_Call) RunAndReturn(run func(context.Context, string) (int, error)) *IUserRepository_IncrementFailedLogins_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// List provides a mock function with given fields: ctx
+func (_m *IUserRepository) List(ctx context.Context) ([]domain.User, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []domain.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]domain.User, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []domain.User); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IUserRepository_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type IUserRepository_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *IUserRepository_Expecter) List(ctx interface{}) *IUserRepository_List_Call {
+	return &IUserRepository_List_Call{Call: _e.mock.On("List", ctx)}
+}
+
+func (_c *IUserRepository_List_Call) Run(run func(ctx context.Context)) *IUserRepository_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *IUserRepository_List_Call) Return(_a0 []domain.User, _a1 error) *IUserRepository_List_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IUserRepository_List_Call) RunAndReturn(run func(context.Context) ([]domain.User, error)) *IUserRepository_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Lock provides a mock function with given fields: ctx, username, until
+func (_m *IUserRepository) Lock(ctx context.Context, username string, until time.Time) error {
+	ret := _m.Called(ctx, username, until)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Lock")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) error); ok {
+		r0 = rf(ctx, username, until)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IUserRepository_Lock_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Lock'
+type IUserRepository_Lock_Call struct {
+	*mock.Call
+}
+
+// Lock is a helper method to define mock.On call
+//   - ctx context.Context
+//   - username string
+//   - until time.Time
+func (_e *IUserRepository_Expecter) Lock(ctx interface{}, username interface{}, until interface{}) *IUserRepository_Lock_Call {
+	return &IUserRepository_Lock_Call{Call: _e.mock.On("Lock", ctx, username, until)}
+}
+
+func (_c *IUserRepository_Lock_Call) Run(run func(ctx context.Context, username string, until time.Time)) *IUserRepository_Lock_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *IUserRepository_Lock_Call) Return(_a0 error) *IUserRepository_Lock_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IUserRepository_Lock_Call) RunAndReturn(run func(context.Context, string, time.Time) error) *IUserRepository_Lock_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReleaseAttachmentSpace provides a mock function with given fields: ctx, userID, size
+func (_m *IUserRepository) ReleaseAttachmentSpace(ctx context.Context, userID string, size int64) error {
+	ret := _m.Called(ctx, userID, size)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReleaseAttachmentSpace")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64) error); ok {
+		r0 = rf(ctx, userID, size)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IUserRepository_ReleaseAttachmentSpace_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReleaseAttachmentSpace'
+type IUserRepository_ReleaseAttachmentSpace_Call struct {
+	*mock.Call
+}
+
+// ReleaseAttachmentSpace is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - size int64
+func (_e *IUserRepository_Expecter) ReleaseAttachmentSpace(ctx interface{}, userID interface{}, size interface{}) *IUserRepository_ReleaseAttachmentSpace_Call {
+	return &IUserRepository_ReleaseAttachmentSpace_Call{Call: _e.mock.On("ReleaseAttachmentSpace", ctx, userID, size)}
+}
+
+func (_c *IUserRepository_ReleaseAttachmentSpace_Call) Run(run func(ctx context.Context, userID string, size int64)) *IUserRepository_ReleaseAttachmentSpace_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(int64))
+	})
+	return _c
+}
+
+func (_c *IUserRepository_ReleaseAttachmentSpace_Call) Return(_a0 error) *IUserRepository_ReleaseAttachmentSpace_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IUserRepository_ReleaseAttachmentSpace_Call) RunAndReturn(run func(context.Context, string, int64) error) *IUserRepository_ReleaseAttachmentSpace_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReserveAttachmentSpace provides a mock function with given fields: ctx, userID, maxSize, quota
+func (_m *IUserRepository) ReserveAttachmentSpace(ctx context.Context, userID string, maxSize int64, quota int64) (int64, error) {
+	ret := _m.Called(ctx, userID, maxSize, quota)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReserveAttachmentSpace")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64, int64) (int64, error)); ok {
+		return rf(ctx, userID, maxSize, quota)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64, int64) int64); ok {
+		r0 = rf(ctx, userID, maxSize, quota)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int64, int64) error); ok {
+		r1 = rf(ctx, userID, maxSize, quota)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IUserRepository_ReserveAttachmentSpace_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReserveAttachmentSpace'
+type IUserRepository_ReserveAttachmentSpace_Call struct {
+	*mock.Call
+}
+
+// ReserveAttachmentSpace is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - maxSize int64
+//   - quota int64
+func (_e *IUserRepository_Expecter) ReserveAttachmentSpace(ctx interface{}, userID interface{}, maxSize interface{}, quota interface{}) *IUserRepository_ReserveAttachmentSpace_Call {
+	return &IUserRepository_ReserveAttachmentSpace_Call{Call: _e.mock.On("ReserveAttachmentSpace", ctx, userID, maxSize, quota)}
+}
+
+func (_c *IUserRepository_ReserveAttachmentSpace_Call) Run(run func(ctx context.Context, userID string, maxSize int64, quota int64)) *IUserRepository_ReserveAttachmentSpace_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(int64), args[3].(int64))
+	})
+	return _c
+}
+
+func (_c *IUserRepository_ReserveAttachmentSpace_Call) Return(_a0 int64, _a1 error) *IUserRepository_ReserveAttachmentSpace_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IUserRepository_ReserveAttachmentSpace_Call) RunAndReturn(run func(context.Context, string, int64, int64) (int64, error)) *IUserRepository_ReserveAttachmentSpace_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ResetFailedLogins provides a mock function with given fields: ctx, username
+func (_m *IUserRepository) ResetFailedLogins(ctx context.Context, username string) error {
+	ret := _m.Called(ctx, username)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResetFailedLogins")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, username)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IUserRepository_ResetFailedLogins_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResetFailedLogins'
+type IUserRepository_ResetFailedLogins_Call struct {
+	*mock.Call
+}
+
+// ResetFailedLogins is a helper method to define mock.On call
+//   - ctx context.Context
+//   - username string
+func (_e *IUserRepository_Expecter) ResetFailedLogins(ctx interface{}, username interface{}) *IUserRepository_ResetFailedLogins_Call {
+	return &IUserRepository_ResetFailedLogins_Call{Call: _e.mock.On("ResetFailedLogins", ctx, username)}
+}
+
+func (_c *IUserRepository_ResetFailedLogins_Call) Run(run func(ctx context.Context, username string)) *IUserRepository_ResetFailedLogins_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *IUserRepository_ResetFailedLogins_Call) Return(_a0 error) *IUserRepository_ResetFailedLogins_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IUserRepository_ResetFailedLogins_Call) RunAndReturn(run func(context.Context, string) error) *IUserRepository_ResetFailedLogins_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetDisabled provides a mock function with given fields: ctx, username, disabled
+func (_m *IUserRepository) SetDisabled(ctx context.Context, username string, disabled bool) error {
+	ret := _m.Called(ctx, username, disabled)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetDisabled")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool) error); ok {
+		r0 = rf(ctx, username, disabled)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IUserRepository_SetDisabled_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetDisabled'
+type IUserRepository_SetDisabled_Call struct {
+	*mock.Call
+}
+
+// SetDisabled is a helper method to define mock.On call
+//   - ctx context.Context
+//   - username string
+//   - disabled bool
+func (_e *IUserRepository_Expecter) SetDisabled(ctx interface{}, username interface{}, disabled interface{}) *IUserRepository_SetDisabled_Call {
+	return &IUserRepository_SetDisabled_Call{Call: _e.mock.On("SetDisabled", ctx, username, disabled)}
+}
+
+func (_c *IUserRepository_SetDisabled_Call) Run(run func(ctx context.Context, username string, disabled bool)) *IUserRepository_SetDisabled_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(bool))
+	})
+	return _c
+}
+
+func (_c *IUserRepository_SetDisabled_Call) Return(_a0 error) *IUserRepository_SetDisabled_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IUserRepository_SetDisabled_Call) RunAndReturn(run func(context.Context, string, bool) error) *IUserRepository_SetDisabled_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetTimeZone provides a mock function with given fields: ctx, id, name
+func (_m *IUserRepository) SetTimeZone(ctx context.Context, id string, name string) error {
+	ret := _m.Called(ctx, id, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetTimeZone")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, id, name)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IUserRepository_SetTimeZone_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetTimeZone'
+type IUserRepository_SetTimeZone_Call struct {
+	*mock.Call
+}
+
+// SetTimeZone is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - name string
+func (_e *IUserRepository_Expecter) SetTimeZone(ctx interface{}, id interface{}, name interface{}) *IUserRepository_SetTimeZone_Call {
+	return &IUserRepository_SetTimeZone_Call{Call: _e.mock.On("SetTimeZone", ctx, id, name)}
+}
+
+func (_c *IUserRepository_SetTimeZone_Call) Run(run func(ctx context.Context, id string, name string)) *IUserRepository_SetTimeZone_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *IUserRepository_SetTimeZone_Call) Return(_a0 error) *IUserRepository_SetTimeZone_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IUserRepository_SetTimeZone_Call) RunAndReturn(run func(context.Context, string, string) error) *IUserRepository_SetTimeZone_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdatePassword provides a mock function with given fields: ctx, id, hashedPassword
+func (_m *IUserRepository) UpdatePassword(ctx context.Context, id string, hashedPassword string) error {
+	ret := _m.Called(ctx, id, hashedPassword)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdatePassword")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, id, hashedPassword)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IUserRepository_UpdatePassword_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdatePassword'
+type IUserRepository_UpdatePassword_Call struct {
+	*mock.Call
+}
+
+// UpdatePassword is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - hashedPassword string
+func (_e *IUserRepository_Expecter) UpdatePassword(ctx interface{}, id interface{}, hashedPassword interface{}) *IUserRepository_UpdatePassword_Call {
+	return &IUserRepository_UpdatePassword_Call{Call: _e.mock.On("UpdatePassword", ctx, id, hashedPassword)}
+}
+
+func (_c *IUserRepository_UpdatePassword_Call) Run(run func(ctx context.Context, id string, hashedPassword string)) *IUserRepository_UpdatePassword_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *IUserRepository_UpdatePassword_Call) Return(_a0 error) *IUserRepository_UpdatePassword_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IUserRepository_UpdatePassword_Call) RunAndReturn(run func(context.Context, string, string) error) *IUserRepository_UpdatePassword_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateRole provides a mock function with given fields: ctx, username, role
+func (_m *IUserRepository) UpdateRole(ctx context.Context, username string, role domain.Role) error {
+	ret := _m.Called(ctx, username, role)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateRole")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, domain.Role) error); ok {
+		r0 = rf(ctx, username, role)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IUserRepository_UpdateRole_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateRole'
+type IUserRepository_UpdateRole_Call struct {
+	*mock.Call
+}
+
+// UpdateRole is a helper method to define mock.On call
+//   - ctx context.Context
+//   - username string
+//   - role domain.Role
+func (_e *IUserRepository_Expecter) UpdateRole(ctx interface{}, username interface{}, role interface{}) *IUserRepository_UpdateRole_Call {
+	return &IUserRepository_UpdateRole_Call{Call: _e.mock.On("UpdateRole", ctx, username, role)}
+}
+
+func (_c *IUserRepository_UpdateRole_Call) Run(run func(ctx context.Context, username string, role domain.Role)) *IUserRepository_UpdateRole_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(domain.Role))
+	})
+	return _c
+}
+
+func (_c *IUserRepository_UpdateRole_Call) Return(_a0 error) *IUserRepository_UpdateRole_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IUserRepository_UpdateRole_Call) RunAndReturn(run func(context.Context, string, domain.Role) error) *IUserRepository_UpdateRole_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewIUserRepository creates a new instance of IUserRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewIUserRepository(t interface {
@@ -0,0 +1,266 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	domain "task_manager_test/internal/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// OrganizationUsecase is an autogenerated mock type for the OrganizationUsecase type
+type OrganizationUsecase struct {
+	mock.Mock
+}
+
+type OrganizationUsecase_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *OrganizationUsecase) EXPECT() *OrganizationUsecase_Expecter {
+	return &OrganizationUsecase_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function with given fields: ctx, org, admin
+func (_m *OrganizationUsecase) Create(ctx context.Context, org domain.Organization, admin domain.User) (domain.Organization, error) {
+	ret := _m.Called(ctx, org, admin)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 domain.Organization
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Organization, domain.User) (domain.Organization, error)); ok {
+		return rf(ctx, org, admin)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Organization, domain.User) domain.Organization); ok {
+		r0 = rf(ctx, org, admin)
+	} else {
+		r0 = ret.Get(0).(domain.Organization)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.Organization, domain.User) error); ok {
+		r1 = rf(ctx, org, admin)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// OrganizationUsecase_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type OrganizationUsecase_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - org domain.Organization
+//   - admin domain.User
+func (_e *OrganizationUsecase_Expecter) Create(ctx interface{}, org interface{}, admin interface{}) *OrganizationUsecase_Create_Call {
+	return &OrganizationUsecase_Create_Call{Call: _e.mock.On("Create", ctx, org, admin)}
+}
+
+func (_c *OrganizationUsecase_Create_Call) Run(run func(ctx context.Context, org domain.Organization, admin domain.User)) *OrganizationUsecase_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.Organization), args[2].(domain.User))
+	})
+	return _c
+}
+
+func (_c *OrganizationUsecase_Create_Call) Return(_a0 domain.Organization, _a1 error) *OrganizationUsecase_Create_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *OrganizationUsecase_Create_Call) RunAndReturn(run func(context.Context, domain.Organization, domain.User) (domain.Organization, error)) *OrganizationUsecase_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Current provides a mock function with given fields: ctx
+func (_m *OrganizationUsecase) Current(ctx context.Context) (domain.Organization, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Current")
+	}
+
+	var r0 domain.Organization
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (domain.Organization, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) domain.Organization); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(domain.Organization)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// OrganizationUsecase_Current_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Current'
+type OrganizationUsecase_Current_Call struct {
+	*mock.Call
+}
+
+// Current is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *OrganizationUsecase_Expecter) Current(ctx interface{}) *OrganizationUsecase_Current_Call {
+	return &OrganizationUsecase_Current_Call{Call: _e.mock.On("Current", ctx)}
+}
+
+func (_c *OrganizationUsecase_Current_Call) Run(run func(ctx context.Context)) *OrganizationUsecase_Current_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *OrganizationUsecase_Current_Call) Return(_a0 domain.Organization, _a1 error) *OrganizationUsecase_Current_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *OrganizationUsecase_Current_Call) RunAndReturn(run func(context.Context) (domain.Organization, error)) *OrganizationUsecase_Current_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindBySlug provides a mock function with given fields: ctx, slug
+func (_m *OrganizationUsecase) FindBySlug(ctx context.Context, slug string) (domain.Organization, error) {
+	ret := _m.Called(ctx, slug)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindBySlug")
+	}
+
+	var r0 domain.Organization
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (domain.Organization, error)); ok {
+		return rf(ctx, slug)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) domain.Organization); ok {
+		r0 = rf(ctx, slug)
+	} else {
+		r0 = ret.Get(0).(domain.Organization)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, slug)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// OrganizationUsecase_FindBySlug_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindBySlug'
+type OrganizationUsecase_FindBySlug_Call struct {
+	*mock.Call
+}
+
+// FindBySlug is a helper method to define mock.On call
+//   - ctx context.Context
+//   - slug string
+func (_e *OrganizationUsecase_Expecter) FindBySlug(ctx interface{}, slug interface{}) *OrganizationUsecase_FindBySlug_Call {
+	return &OrganizationUsecase_FindBySlug_Call{Call: _e.mock.On("FindBySlug", ctx, slug)}
+}
+
+func (_c *OrganizationUsecase_FindBySlug_Call) Run(run func(ctx context.Context, slug string)) *OrganizationUsecase_FindBySlug_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *OrganizationUsecase_FindBySlug_Call) Return(_a0 domain.Organization, _a1 error) *OrganizationUsecase_FindBySlug_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *OrganizationUsecase_FindBySlug_Call) RunAndReturn(run func(context.Context, string) (domain.Organization, error)) *OrganizationUsecase_FindBySlug_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// List provides a mock function with given fields: ctx
+func (_m *OrganizationUsecase) List(ctx context.Context) ([]domain.Organization, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []domain.Organization
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]domain.Organization, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []domain.Organization); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Organization)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// OrganizationUsecase_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type OrganizationUsecase_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *OrganizationUsecase_Expecter) List(ctx interface{}) *OrganizationUsecase_List_Call {
+	return &OrganizationUsecase_List_Call{Call: _e.mock.On("List", ctx)}
+}
+
+func (_c *OrganizationUsecase_List_Call) Run(run func(ctx context.Context)) *OrganizationUsecase_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *OrganizationUsecase_List_Call) Return(_a0 []domain.Organization, _a1 error) *OrganizationUsecase_List_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *OrganizationUsecase_List_Call) RunAndReturn(run func(context.Context) ([]domain.Organization, error)) *OrganizationUsecase_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewOrganizationUsecase creates a new instance of OrganizationUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewOrganizationUsecase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *OrganizationUsecase {
+	mock := &OrganizationUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
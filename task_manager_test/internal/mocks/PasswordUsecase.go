@@ -0,0 +1,236 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// PasswordUsecase is an autogenerated mock type for the PasswordUsecase type
+type PasswordUsecase struct {
+	mock.Mock
+}
+
+type PasswordUsecase_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *PasswordUsecase) EXPECT() *PasswordUsecase_Expecter {
+	return &PasswordUsecase_Expecter{mock: &_m.Mock}
+}
+
+// ChangePassword provides a mock function with given fields: ctx, username, currentPassword, newPassword
+func (_m *PasswordUsecase) ChangePassword(ctx context.Context, username string, currentPassword string, newPassword string) (string, error) {
+	ret := _m.Called(ctx, username, currentPassword, newPassword)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ChangePassword")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) (string, error)); ok {
+		return rf(ctx, username, currentPassword, newPassword)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) string); ok {
+		r0 = rf(ctx, username, currentPassword, newPassword)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, username, currentPassword, newPassword)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PasswordUsecase_ChangePassword_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ChangePassword'
+type PasswordUsecase_ChangePassword_Call struct {
+	*mock.Call
+}
+
+// ChangePassword is a helper method to define mock.On call
+//   - ctx context.Context
+//   - username string
+//   - currentPassword string
+//   - newPassword string
+func (_e *PasswordUsecase_Expecter) ChangePassword(ctx interface{}, username interface{}, currentPassword interface{}, newPassword interface{}) *PasswordUsecase_ChangePassword_Call {
+	return &PasswordUsecase_ChangePassword_Call{Call: _e.mock.On("ChangePassword", ctx, username, currentPassword, newPassword)}
+}
+
+func (_c *PasswordUsecase_ChangePassword_Call) Run(run func(ctx context.Context, username string, currentPassword string, newPassword string)) *PasswordUsecase_ChangePassword_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *PasswordUsecase_ChangePassword_Call) Return(_a0 string, _a1 error) *PasswordUsecase_ChangePassword_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *PasswordUsecase_ChangePassword_Call) RunAndReturn(run func(context.Context, string, string, string) (string, error)) *PasswordUsecase_ChangePassword_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RequestReset provides a mock function with given fields: ctx, username
+func (_m *PasswordUsecase) RequestReset(ctx context.Context, username string) error {
+	ret := _m.Called(ctx, username)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RequestReset")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, username)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// PasswordUsecase_RequestReset_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RequestReset'
+type PasswordUsecase_RequestReset_Call struct {
+	*mock.Call
+}
+
+// RequestReset is a helper method to define mock.On call
+//   - ctx context.Context
+//   - username string
+func (_e *PasswordUsecase_Expecter) RequestReset(ctx interface{}, username interface{}) *PasswordUsecase_RequestReset_Call {
+	return &PasswordUsecase_RequestReset_Call{Call: _e.mock.On("RequestReset", ctx, username)}
+}
+
+func (_c *PasswordUsecase_RequestReset_Call) Run(run func(ctx context.Context, username string)) *PasswordUsecase_RequestReset_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *PasswordUsecase_RequestReset_Call) Return(_a0 error) *PasswordUsecase_RequestReset_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *PasswordUsecase_RequestReset_Call) RunAndReturn(run func(context.Context, string) error) *PasswordUsecase_RequestReset_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ResetPassword provides a mock function with given fields: ctx, token, newPassword
+func (_m *PasswordUsecase) ResetPassword(ctx context.Context, token string, newPassword string) error {
+	ret := _m.Called(ctx, token, newPassword)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResetPassword")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, token, newPassword)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// PasswordUsecase_ResetPassword_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResetPassword'
+type PasswordUsecase_ResetPassword_Call struct {
+	*mock.Call
+}
+
+// ResetPassword is a helper method to define mock.On call
+//   - ctx context.Context
+//   - token string
+//   - newPassword string
+func (_e *PasswordUsecase_Expecter) ResetPassword(ctx interface{}, token interface{}, newPassword interface{}) *PasswordUsecase_ResetPassword_Call {
+	return &PasswordUsecase_ResetPassword_Call{Call: _e.mock.On("ResetPassword", ctx, token, newPassword)}
+}
+
+func (_c *PasswordUsecase_ResetPassword_Call) Run(run func(ctx context.Context, token string, newPassword string)) *PasswordUsecase_ResetPassword_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *PasswordUsecase_ResetPassword_Call) Return(_a0 error) *PasswordUsecase_ResetPassword_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *PasswordUsecase_ResetPassword_Call) RunAndReturn(run func(context.Context, string, string) error) *PasswordUsecase_ResetPassword_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Wait provides a mock function with given fields: ctx
+func (_m *PasswordUsecase) Wait(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Wait")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// PasswordUsecase_Wait_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Wait'
+type PasswordUsecase_Wait_Call struct {
+	*mock.Call
+}
+
+// Wait is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *PasswordUsecase_Expecter) Wait(ctx interface{}) *PasswordUsecase_Wait_Call {
+	return &PasswordUsecase_Wait_Call{Call: _e.mock.On("Wait", ctx)}
+}
+
+func (_c *PasswordUsecase_Wait_Call) Run(run func(ctx context.Context)) *PasswordUsecase_Wait_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *PasswordUsecase_Wait_Call) Return(_a0 error) *PasswordUsecase_Wait_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *PasswordUsecase_Wait_Call) RunAndReturn(run func(context.Context) error) *PasswordUsecase_Wait_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewPasswordUsecase creates a new instance of PasswordUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewPasswordUsecase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *PasswordUsecase {
+	mock := &PasswordUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
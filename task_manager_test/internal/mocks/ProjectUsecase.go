@@ -0,0 +1,421 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	domain "task_manager_test/internal/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ProjectUsecase is an autogenerated mock type for the ProjectUsecase type
+type ProjectUsecase struct {
+	mock.Mock
+}
+
+type ProjectUsecase_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *ProjectUsecase) EXPECT() *ProjectUsecase_Expecter {
+	return &ProjectUsecase_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function with given fields: ctx, p
+func (_m *ProjectUsecase) Create(ctx context.Context, p domain.Project) (domain.Project, error) {
+	ret := _m.Called(ctx, p)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 domain.Project
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Project) (domain.Project, error)); ok {
+		return rf(ctx, p)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Project) domain.Project); ok {
+		r0 = rf(ctx, p)
+	} else {
+		r0 = ret.Get(0).(domain.Project)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.Project) error); ok {
+		r1 = rf(ctx, p)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ProjectUsecase_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type ProjectUsecase_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - p domain.Project
+func (_e *ProjectUsecase_Expecter) Create(ctx interface{}, p interface{}) *ProjectUsecase_Create_Call {
+	return &ProjectUsecase_Create_Call{Call: _e.mock.On("Create", ctx, p)}
+}
+
+func (_c *ProjectUsecase_Create_Call) Run(run func(ctx context.Context, p domain.Project)) *ProjectUsecase_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.Project))
+	})
+	return _c
+}
+
+func (_c *ProjectUsecase_Create_Call) Return(_a0 domain.Project, _a1 error) *ProjectUsecase_Create_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ProjectUsecase_Create_Call) RunAndReturn(run func(context.Context, domain.Project) (domain.Project, error)) *ProjectUsecase_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Delete provides a mock function with given fields: ctx, id, tasks
+func (_m *ProjectUsecase) Delete(ctx context.Context, id string, tasks domain.TaskDisposition) error {
+	ret := _m.Called(ctx, id, tasks)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, domain.TaskDisposition) error); ok {
+		r0 = rf(ctx, id, tasks)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ProjectUsecase_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type ProjectUsecase_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - tasks domain.TaskDisposition
+func (_e *ProjectUsecase_Expecter) Delete(ctx interface{}, id interface{}, tasks interface{}) *ProjectUsecase_Delete_Call {
+	return &ProjectUsecase_Delete_Call{Call: _e.mock.On("Delete", ctx, id, tasks)}
+}
+
+func (_c *ProjectUsecase_Delete_Call) Run(run func(ctx context.Context, id string, tasks domain.TaskDisposition)) *ProjectUsecase_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(domain.TaskDisposition))
+	})
+	return _c
+}
+
+func (_c *ProjectUsecase_Delete_Call) Return(_a0 error) *ProjectUsecase_Delete_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ProjectUsecase_Delete_Call) RunAndReturn(run func(context.Context, string, domain.TaskDisposition) error) *ProjectUsecase_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Get provides a mock function with given fields: ctx, id
+func (_m *ProjectUsecase) Get(ctx context.Context, id string) (domain.Project, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 domain.Project
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (domain.Project, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) domain.Project); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(domain.Project)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ProjectUsecase_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type ProjectUsecase_Get_Call struct {
+	*mock.Call
+}
+
+// Get is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *ProjectUsecase_Expecter) Get(ctx interface{}, id interface{}) *ProjectUsecase_Get_Call {
+	return &ProjectUsecase_Get_Call{Call: _e.mock.On("Get", ctx, id)}
+}
+
+func (_c *ProjectUsecase_Get_Call) Run(run func(ctx context.Context, id string)) *ProjectUsecase_Get_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *ProjectUsecase_Get_Call) Return(_a0 domain.Project, _a1 error) *ProjectUsecase_Get_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ProjectUsecase_Get_Call) RunAndReturn(run func(context.Context, string) (domain.Project, error)) *ProjectUsecase_Get_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// List provides a mock function with given fields: ctx
+func (_m *ProjectUsecase) List(ctx context.Context) ([]domain.Project, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []domain.Project
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]domain.Project, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []domain.Project); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Project)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ProjectUsecase_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type ProjectUsecase_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *ProjectUsecase_Expecter) List(ctx interface{}) *ProjectUsecase_List_Call {
+	return &ProjectUsecase_List_Call{Call: _e.mock.On("List", ctx)}
+}
+
+func (_c *ProjectUsecase_List_Call) Run(run func(ctx context.Context)) *ProjectUsecase_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *ProjectUsecase_List_Call) Return(_a0 []domain.Project, _a1 error) *ProjectUsecase_List_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ProjectUsecase_List_Call) RunAndReturn(run func(context.Context) ([]domain.Project, error)) *ProjectUsecase_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RemoveMember provides a mock function with given fields: ctx, projectID, username
+func (_m *ProjectUsecase) RemoveMember(ctx context.Context, projectID string, username string) error {
+	ret := _m.Called(ctx, projectID, username)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveMember")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, projectID, username)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ProjectUsecase_RemoveMember_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveMember'
+type ProjectUsecase_RemoveMember_Call struct {
+	*mock.Call
+}
+
+// RemoveMember is a helper method to define mock.On call
+//   - ctx context.Context
+//   - projectID string
+//   - username string
+func (_e *ProjectUsecase_Expecter) RemoveMember(ctx interface{}, projectID interface{}, username interface{}) *ProjectUsecase_RemoveMember_Call {
+	return &ProjectUsecase_RemoveMember_Call{Call: _e.mock.On("RemoveMember", ctx, projectID, username)}
+}
+
+func (_c *ProjectUsecase_RemoveMember_Call) Run(run func(ctx context.Context, projectID string, username string)) *ProjectUsecase_RemoveMember_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *ProjectUsecase_RemoveMember_Call) Return(_a0 error) *ProjectUsecase_RemoveMember_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ProjectUsecase_RemoveMember_Call) RunAndReturn(run func(context.Context, string, string) error) *ProjectUsecase_RemoveMember_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetMember provides a mock function with given fields: ctx, projectID, username, role
+func (_m *ProjectUsecase) SetMember(ctx context.Context, projectID string, username string, role domain.ProjectRole) (domain.Project, error) {
+	ret := _m.Called(ctx, projectID, username, role)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetMember")
+	}
+
+	var r0 domain.Project
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, domain.ProjectRole) (domain.Project, error)); ok {
+		return rf(ctx, projectID, username, role)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, domain.ProjectRole) domain.Project); ok {
+		r0 = rf(ctx, projectID, username, role)
+	} else {
+		r0 = ret.Get(0).(domain.Project)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, domain.ProjectRole) error); ok {
+		r1 = rf(ctx, projectID, username, role)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ProjectUsecase_SetMember_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetMember'
+type ProjectUsecase_SetMember_Call struct {
+	*mock.Call
+}
+
+// SetMember is a helper method to define mock.On call
+//   - ctx context.Context
+//   - projectID string
+//   - username string
+//   - role domain.ProjectRole
+func (_e *ProjectUsecase_Expecter) SetMember(ctx interface{}, projectID interface{}, username interface{}, role interface{}) *ProjectUsecase_SetMember_Call {
+	return &ProjectUsecase_SetMember_Call{Call: _e.mock.On("SetMember", ctx, projectID, username, role)}
+}
+
+func (_c *ProjectUsecase_SetMember_Call) Run(run func(ctx context.Context, projectID string, username string, role domain.ProjectRole)) *ProjectUsecase_SetMember_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(domain.ProjectRole))
+	})
+	return _c
+}
+
+func (_c *ProjectUsecase_SetMember_Call) Return(_a0 domain.Project, _a1 error) *ProjectUsecase_SetMember_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ProjectUsecase_SetMember_Call) RunAndReturn(run func(context.Context, string, string, domain.ProjectRole) (domain.Project, error)) *ProjectUsecase_SetMember_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Update provides a mock function with given fields: ctx, p
+func (_m *ProjectUsecase) Update(ctx context.Context, p domain.Project) (domain.Project, error) {
+	ret := _m.Called(ctx, p)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 domain.Project
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Project) (domain.Project, error)); ok {
+		return rf(ctx, p)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Project) domain.Project); ok {
+		r0 = rf(ctx, p)
+	} else {
+		r0 = ret.Get(0).(domain.Project)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.Project) error); ok {
+		r1 = rf(ctx, p)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ProjectUsecase_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type ProjectUsecase_Update_Call struct {
+	*mock.Call
+}
+
+// Update is a helper method to define mock.On call
+//   - ctx context.Context
+//   - p domain.Project
+func (_e *ProjectUsecase_Expecter) Update(ctx interface{}, p interface{}) *ProjectUsecase_Update_Call {
+	return &ProjectUsecase_Update_Call{Call: _e.mock.On("Update", ctx, p)}
+}
+
+func (_c *ProjectUsecase_Update_Call) Run(run func(ctx context.Context, p domain.Project)) *ProjectUsecase_Update_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.Project))
+	})
+	return _c
+}
+
+func (_c *ProjectUsecase_Update_Call) Return(_a0 domain.Project, _a1 error) *ProjectUsecase_Update_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ProjectUsecase_Update_Call) RunAndReturn(run func(context.Context, domain.Project) (domain.Project, error)) *ProjectUsecase_Update_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewProjectUsecase creates a new instance of ProjectUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewProjectUsecase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ProjectUsecase {
+	mock := &ProjectUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -22,6 +22,63 @@ func (_m *TaskUsecase) EXPECT() *TaskUsecase_Expecter {
 	return &TaskUsecase_Expecter{mock: &_m.Mock}
 }
 
+// Agenda provides a mock function with given fields: ctx, rng
+func (_m *TaskUsecase) Agenda(ctx context.Context, rng domain.AgendaRange) (domain.Agenda, error) {
+	ret := _m.Called(ctx, rng)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Agenda")
+	}
+
+	var r0 domain.Agenda
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.AgendaRange) (domain.Agenda, error)); ok {
+		return rf(ctx, rng)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.AgendaRange) domain.Agenda); ok {
+		r0 = rf(ctx, rng)
+	} else {
+		r0 = ret.Get(0).(domain.Agenda)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.AgendaRange) error); ok {
+		r1 = rf(ctx, rng)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TaskUsecase_Agenda_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Agenda'
+type TaskUsecase_Agenda_Call struct {
+	*mock.Call
+}
+
+// Agenda is a helper method to define mock.On call
+//   - ctx context.Context
+//   - rng domain.AgendaRange
+func (_e *TaskUsecase_Expecter) Agenda(ctx interface{}, rng interface{}) *TaskUsecase_Agenda_Call {
+	return &TaskUsecase_Agenda_Call{Call: _e.mock.On("Agenda", ctx, rng)}
+}
+
+func (_c *TaskUsecase_Agenda_Call) Run(run func(ctx context.Context, rng domain.AgendaRange)) *TaskUsecase_Agenda_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.AgendaRange))
+	})
+	return _c
+}
+
+func (_c *TaskUsecase_Agenda_Call) Return(_a0 domain.Agenda, _a1 error) *TaskUsecase_Agenda_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *TaskUsecase_Agenda_Call) RunAndReturn(run func(context.Context, domain.AgendaRange) (domain.Agenda, error)) *TaskUsecase_Agenda_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Create provides a mock function with given fields: ctx, t
 func (_m *TaskUsecase) Create(ctx context.Context, t domain.Task) (domain.Task, error) {
 	ret := _m.Called(ctx, t)
@@ -183,24 +240,81 @@ func (_c *TaskUsecase_Get_Call) RunAndReturn(run func(context.Context, string) (
 	return _c
 }
 
+// GetForUpdate provides a mock function with given fields: ctx, id
+func (_m *TaskUsecase) GetForUpdate(ctx context.Context, id string) (domain.Task, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetForUpdate")
+	}
+
+	var r0 domain.Task
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (domain.Task, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) domain.Task); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(domain.Task)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TaskUsecase_GetForUpdate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetForUpdate'
+type TaskUsecase_GetForUpdate_Call struct {
+	*mock.Call
+}
+
+// GetForUpdate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *TaskUsecase_Expecter) GetForUpdate(ctx interface{}, id interface{}) *TaskUsecase_GetForUpdate_Call {
+	return &TaskUsecase_GetForUpdate_Call{Call: _e.mock.On("GetForUpdate", ctx, id)}
+}
+
+func (_c *TaskUsecase_GetForUpdate_Call) Run(run func(ctx context.Context, id string)) *TaskUsecase_GetForUpdate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *TaskUsecase_GetForUpdate_Call) Return(_a0 domain.Task, _a1 error) *TaskUsecase_GetForUpdate_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *TaskUsecase_GetForUpdate_Call) RunAndReturn(run func(context.Context, string) (domain.Task, error)) *TaskUsecase_GetForUpdate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // List provides a mock function with given fields: ctx
-func (_m *TaskUsecase) List(ctx context.Context) ([]domain.Task, error) {
+func (_m *TaskUsecase) List(ctx context.Context) ([]domain.TaskListItem, error) {
 	ret := _m.Called(ctx)
 
 	if len(ret) == 0 {
 		panic("no return value specified for List")
 	}
 
-	var r0 []domain.Task
+	var r0 []domain.TaskListItem
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context) ([]domain.Task, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context) ([]domain.TaskListItem, error)); ok {
 		return rf(ctx)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context) []domain.Task); ok {
+	if rf, ok := ret.Get(0).(func(context.Context) []domain.TaskListItem); ok {
 		r0 = rf(ctx)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]domain.Task)
+			r0 = ret.Get(0).([]domain.TaskListItem)
 		}
 	}
 
@@ -231,12 +345,237 @@ func (_c *TaskUsecase_List_Call) Run(run func(ctx context.Context)) *TaskUsecase
 	return _c
 }
 
-func (_c *TaskUsecase_List_Call) Return(_a0 []domain.Task, _a1 error) *TaskUsecase_List_Call {
+func (_c *TaskUsecase_List_Call) Return(_a0 []domain.TaskListItem, _a1 error) *TaskUsecase_List_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *TaskUsecase_List_Call) RunAndReturn(run func(context.Context) ([]domain.Task, error)) *TaskUsecase_List_Call {
+func (_c *TaskUsecase_List_Call) RunAndReturn(run func(context.Context) ([]domain.TaskListItem, error)) *TaskUsecase_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListByProject provides a mock function with given fields: ctx, projectID
+func (_m *TaskUsecase) ListByProject(ctx context.Context, projectID string) ([]domain.Task, error) {
+	ret := _m.Called(ctx, projectID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListByProject")
+	}
+
+	var r0 []domain.Task
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]domain.Task, error)); ok {
+		return rf(ctx, projectID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []domain.Task); ok {
+		r0 = rf(ctx, projectID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Task)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, projectID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TaskUsecase_ListByProject_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListByProject'
+type TaskUsecase_ListByProject_Call struct {
+	*mock.Call
+}
+
+// ListByProject is a helper method to define mock.On call
+//   - ctx context.Context
+//   - projectID string
+func (_e *TaskUsecase_Expecter) ListByProject(ctx interface{}, projectID interface{}) *TaskUsecase_ListByProject_Call {
+	return &TaskUsecase_ListByProject_Call{Call: _e.mock.On("ListByProject", ctx, projectID)}
+}
+
+func (_c *TaskUsecase_ListByProject_Call) Run(run func(ctx context.Context, projectID string)) *TaskUsecase_ListByProject_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *TaskUsecase_ListByProject_Call) Return(_a0 []domain.Task, _a1 error) *TaskUsecase_ListByProject_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *TaskUsecase_ListByProject_Call) RunAndReturn(run func(context.Context, string) ([]domain.Task, error)) *TaskUsecase_ListByProject_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListShares provides a mock function with given fields: ctx, taskID
+func (_m *TaskUsecase) ListShares(ctx context.Context, taskID string) ([]domain.TaskShare, error) {
+	ret := _m.Called(ctx, taskID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListShares")
+	}
+
+	var r0 []domain.TaskShare
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]domain.TaskShare, error)); ok {
+		return rf(ctx, taskID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []domain.TaskShare); ok {
+		r0 = rf(ctx, taskID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.TaskShare)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, taskID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TaskUsecase_ListShares_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListShares'
+type TaskUsecase_ListShares_Call struct {
+	*mock.Call
+}
+
+// ListShares is a helper method to define mock.On call
+//   - ctx context.Context
+//   - taskID string
+func (_e *TaskUsecase_Expecter) ListShares(ctx interface{}, taskID interface{}) *TaskUsecase_ListShares_Call {
+	return &TaskUsecase_ListShares_Call{Call: _e.mock.On("ListShares", ctx, taskID)}
+}
+
+func (_c *TaskUsecase_ListShares_Call) Run(run func(ctx context.Context, taskID string)) *TaskUsecase_ListShares_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *TaskUsecase_ListShares_Call) Return(_a0 []domain.TaskShare, _a1 error) *TaskUsecase_ListShares_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *TaskUsecase_ListShares_Call) RunAndReturn(run func(context.Context, string) ([]domain.TaskShare, error)) *TaskUsecase_ListShares_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Share provides a mock function with given fields: ctx, taskID, username, access
+func (_m *TaskUsecase) Share(ctx context.Context, taskID string, username string, access domain.ShareAccess) (domain.TaskShare, error) {
+	ret := _m.Called(ctx, taskID, username, access)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Share")
+	}
+
+	var r0 domain.TaskShare
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, domain.ShareAccess) (domain.TaskShare, error)); ok {
+		return rf(ctx, taskID, username, access)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, domain.ShareAccess) domain.TaskShare); ok {
+		r0 = rf(ctx, taskID, username, access)
+	} else {
+		r0 = ret.Get(0).(domain.TaskShare)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, domain.ShareAccess) error); ok {
+		r1 = rf(ctx, taskID, username, access)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TaskUsecase_Share_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Share'
+type TaskUsecase_Share_Call struct {
+	*mock.Call
+}
+
+// Share is a helper method to define mock.On call
+//   - ctx context.Context
+//   - taskID string
+//   - username string
+//   - access domain.ShareAccess
+func (_e *TaskUsecase_Expecter) Share(ctx interface{}, taskID interface{}, username interface{}, access interface{}) *TaskUsecase_Share_Call {
+	return &TaskUsecase_Share_Call{Call: _e.mock.On("Share", ctx, taskID, username, access)}
+}
+
+func (_c *TaskUsecase_Share_Call) Run(run func(ctx context.Context, taskID string, username string, access domain.ShareAccess)) *TaskUsecase_Share_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(domain.ShareAccess))
+	})
+	return _c
+}
+
+func (_c *TaskUsecase_Share_Call) Return(_a0 domain.TaskShare, _a1 error) *TaskUsecase_Share_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *TaskUsecase_Share_Call) RunAndReturn(run func(context.Context, string, string, domain.ShareAccess) (domain.TaskShare, error)) *TaskUsecase_Share_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Unshare provides a mock function with given fields: ctx, taskID, username
+func (_m *TaskUsecase) Unshare(ctx context.Context, taskID string, username string) error {
+	ret := _m.Called(ctx, taskID, username)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Unshare")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, taskID, username)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// TaskUsecase_Unshare_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Unshare'
+type TaskUsecase_Unshare_Call struct {
+	*mock.Call
+}
+
+// Unshare is a helper method to define mock.On call
+//   - ctx context.Context
+//   - taskID string
+//   - username string
+func (_e *TaskUsecase_Expecter) Unshare(ctx interface{}, taskID interface{}, username interface{}) *TaskUsecase_Unshare_Call {
+	return &TaskUsecase_Unshare_Call{Call: _e.mock.On("Unshare", ctx, taskID, username)}
+}
+
+func (_c *TaskUsecase_Unshare_Call) Run(run func(ctx context.Context, taskID string, username string)) *TaskUsecase_Unshare_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *TaskUsecase_Unshare_Call) Return(_a0 error) *TaskUsecase_Unshare_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *TaskUsecase_Unshare_Call) RunAndReturn(run func(context.Context, string, string) error) *TaskUsecase_Unshare_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -0,0 +1,328 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	domain "task_manager_test/internal/domain"
+	time "time"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// TimeUsecase is an autogenerated mock type for the TimeUsecase type
+type TimeUsecase struct {
+	mock.Mock
+}
+
+type TimeUsecase_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *TimeUsecase) EXPECT() *TimeUsecase_Expecter {
+	return &TimeUsecase_Expecter{mock: &_m.Mock}
+}
+
+// LogTime provides a mock function with given fields: ctx, taskID, start, end, note
+func (_m *TimeUsecase) LogTime(ctx context.Context, taskID string, start time.Time, end time.Time, note string) (domain.TimeEntry, error) {
+	ret := _m.Called(ctx, taskID, start, end, note)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LogTime")
+	}
+
+	var r0 domain.TimeEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time, time.Time, string) (domain.TimeEntry, error)); ok {
+		return rf(ctx, taskID, start, end, note)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time, time.Time, string) domain.TimeEntry); ok {
+		r0 = rf(ctx, taskID, start, end, note)
+	} else {
+		r0 = ret.Get(0).(domain.TimeEntry)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Time, time.Time, string) error); ok {
+		r1 = rf(ctx, taskID, start, end, note)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TimeUsecase_LogTime_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LogTime'
+type TimeUsecase_LogTime_Call struct {
+	*mock.Call
+}
+
+// LogTime is a helper method to define mock.On call
+//   - ctx context.Context
+//   - taskID string
+//   - start time.Time
+//   - end time.Time
+//   - note string
+func (_e *TimeUsecase_Expecter) LogTime(ctx interface{}, taskID interface{}, start interface{}, end interface{}, note interface{}) *TimeUsecase_LogTime_Call {
+	return &TimeUsecase_LogTime_Call{Call: _e.mock.On("LogTime", ctx, taskID, start, end, note)}
+}
+
+func (_c *TimeUsecase_LogTime_Call) Run(run func(ctx context.Context, taskID string, start time.Time, end time.Time, note string)) *TimeUsecase_LogTime_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(time.Time), args[3].(time.Time), args[4].(string))
+	})
+	return _c
+}
+
+func (_c *TimeUsecase_LogTime_Call) Return(_a0 domain.TimeEntry, _a1 error) *TimeUsecase_LogTime_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *TimeUsecase_LogTime_Call) RunAndReturn(run func(context.Context, string, time.Time, time.Time, string) (domain.TimeEntry, error)) *TimeUsecase_LogTime_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// StartTimer provides a mock function with given fields: ctx, taskID, note
+func (_m *TimeUsecase) StartTimer(ctx context.Context, taskID string, note string) (domain.TimeEntry, error) {
+	ret := _m.Called(ctx, taskID, note)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StartTimer")
+	}
+
+	var r0 domain.TimeEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (domain.TimeEntry, error)); ok {
+		return rf(ctx, taskID, note)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) domain.TimeEntry); ok {
+		r0 = rf(ctx, taskID, note)
+	} else {
+		r0 = ret.Get(0).(domain.TimeEntry)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, taskID, note)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TimeUsecase_StartTimer_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StartTimer'
+type TimeUsecase_StartTimer_Call struct {
+	*mock.Call
+}
+
+// StartTimer is a helper method to define mock.On call
+//   - ctx context.Context
+//   - taskID string
+//   - note string
+func (_e *TimeUsecase_Expecter) StartTimer(ctx interface{}, taskID interface{}, note interface{}) *TimeUsecase_StartTimer_Call {
+	return &TimeUsecase_StartTimer_Call{Call: _e.mock.On("StartTimer", ctx, taskID, note)}
+}
+
+func (_c *TimeUsecase_StartTimer_Call) Run(run func(ctx context.Context, taskID string, note string)) *TimeUsecase_StartTimer_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *TimeUsecase_StartTimer_Call) Return(_a0 domain.TimeEntry, _a1 error) *TimeUsecase_StartTimer_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *TimeUsecase_StartTimer_Call) RunAndReturn(run func(context.Context, string, string) (domain.TimeEntry, error)) *TimeUsecase_StartTimer_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// StopTimer provides a mock function with given fields: ctx, taskID
+func (_m *TimeUsecase) StopTimer(ctx context.Context, taskID string) (domain.TimeEntry, error) {
+	ret := _m.Called(ctx, taskID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StopTimer")
+	}
+
+	var r0 domain.TimeEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (domain.TimeEntry, error)); ok {
+		return rf(ctx, taskID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) domain.TimeEntry); ok {
+		r0 = rf(ctx, taskID)
+	} else {
+		r0 = ret.Get(0).(domain.TimeEntry)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, taskID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TimeUsecase_StopTimer_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StopTimer'
+type TimeUsecase_StopTimer_Call struct {
+	*mock.Call
+}
+
+// StopTimer is a helper method to define mock.On call
+//   - ctx context.Context
+//   - taskID string
+func (_e *TimeUsecase_Expecter) StopTimer(ctx interface{}, taskID interface{}) *TimeUsecase_StopTimer_Call {
+	return &TimeUsecase_StopTimer_Call{Call: _e.mock.On("StopTimer", ctx, taskID)}
+}
+
+func (_c *TimeUsecase_StopTimer_Call) Run(run func(ctx context.Context, taskID string)) *TimeUsecase_StopTimer_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *TimeUsecase_StopTimer_Call) Return(_a0 domain.TimeEntry, _a1 error) *TimeUsecase_StopTimer_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *TimeUsecase_StopTimer_Call) RunAndReturn(run func(context.Context, string) (domain.TimeEntry, error)) *TimeUsecase_StopTimer_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// TaskTime provides a mock function with given fields: ctx, taskID
+func (_m *TimeUsecase) TaskTime(ctx context.Context, taskID string) (domain.TaskTime, error) {
+	ret := _m.Called(ctx, taskID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TaskTime")
+	}
+
+	var r0 domain.TaskTime
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (domain.TaskTime, error)); ok {
+		return rf(ctx, taskID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) domain.TaskTime); ok {
+		r0 = rf(ctx, taskID)
+	} else {
+		r0 = ret.Get(0).(domain.TaskTime)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, taskID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TimeUsecase_TaskTime_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TaskTime'
+type TimeUsecase_TaskTime_Call struct {
+	*mock.Call
+}
+
+// TaskTime is a helper method to define mock.On call
+//   - ctx context.Context
+//   - taskID string
+func (_e *TimeUsecase_Expecter) TaskTime(ctx interface{}, taskID interface{}) *TimeUsecase_TaskTime_Call {
+	return &TimeUsecase_TaskTime_Call{Call: _e.mock.On("TaskTime", ctx, taskID)}
+}
+
+func (_c *TimeUsecase_TaskTime_Call) Run(run func(ctx context.Context, taskID string)) *TimeUsecase_TaskTime_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *TimeUsecase_TaskTime_Call) Return(_a0 domain.TaskTime, _a1 error) *TimeUsecase_TaskTime_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *TimeUsecase_TaskTime_Call) RunAndReturn(run func(context.Context, string) (domain.TaskTime, error)) *TimeUsecase_TaskTime_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Timesheet provides a mock function with given fields: ctx, from, to
+func (_m *TimeUsecase) Timesheet(ctx context.Context, from string, to string) (domain.Timesheet, error) {
+	ret := _m.Called(ctx, from, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Timesheet")
+	}
+
+	var r0 domain.Timesheet
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (domain.Timesheet, error)); ok {
+		return rf(ctx, from, to)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) domain.Timesheet); ok {
+		r0 = rf(ctx, from, to)
+	} else {
+		r0 = ret.Get(0).(domain.Timesheet)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TimeUsecase_Timesheet_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Timesheet'
+type TimeUsecase_Timesheet_Call struct {
+	*mock.Call
+}
+
+// Timesheet is a helper method to define mock.On call
+//   - ctx context.Context
+//   - from string
+//   - to string
+func (_e *TimeUsecase_Expecter) Timesheet(ctx interface{}, from interface{}, to interface{}) *TimeUsecase_Timesheet_Call {
+	return &TimeUsecase_Timesheet_Call{Call: _e.mock.On("Timesheet", ctx, from, to)}
+}
+
+func (_c *TimeUsecase_Timesheet_Call) Run(run func(ctx context.Context, from string, to string)) *TimeUsecase_Timesheet_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *TimeUsecase_Timesheet_Call) Return(_a0 domain.Timesheet, _a1 error) *TimeUsecase_Timesheet_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *TimeUsecase_Timesheet_Call) RunAndReturn(run func(context.Context, string, string) (domain.Timesheet, error)) *TimeUsecase_Timesheet_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewTimeUsecase creates a new instance of TimeUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewTimeUsecase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *TimeUsecase {
+	mock := &TimeUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
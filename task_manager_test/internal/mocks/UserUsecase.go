@@ -22,6 +22,112 @@ func (_m *UserUsecase) EXPECT() *UserUsecase_Expecter {
 	return &UserUsecase_Expecter{mock: &_m.Mock}
 }
 
+// ChangeRole provides a mock function with given fields: ctx, username, role
+func (_m *UserUsecase) ChangeRole(ctx context.Context, username string, role domain.Role) error {
+	ret := _m.Called(ctx, username, role)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ChangeRole")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, domain.Role) error); ok {
+		r0 = rf(ctx, username, role)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UserUsecase_ChangeRole_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ChangeRole'
+type UserUsecase_ChangeRole_Call struct {
+	*mock.Call
+}
+
+// ChangeRole is a helper method to define mock.On call
+//   - ctx context.Context
+//   - username string
+//   - role domain.Role
+func (_e *UserUsecase_Expecter) ChangeRole(ctx interface{}, username interface{}, role interface{}) *UserUsecase_ChangeRole_Call {
+	return &UserUsecase_ChangeRole_Call{Call: _e.mock.On("ChangeRole", ctx, username, role)}
+}
+
+func (_c *UserUsecase_ChangeRole_Call) Run(run func(ctx context.Context, username string, role domain.Role)) *UserUsecase_ChangeRole_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(domain.Role))
+	})
+	return _c
+}
+
+func (_c *UserUsecase_ChangeRole_Call) Return(_a0 error) *UserUsecase_ChangeRole_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *UserUsecase_ChangeRole_Call) RunAndReturn(run func(context.Context, string, domain.Role) error) *UserUsecase_ChangeRole_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListUsers provides a mock function with given fields: ctx
+func (_m *UserUsecase) ListUsers(ctx context.Context) ([]domain.User, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListUsers")
+	}
+
+	var r0 []domain.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]domain.User, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []domain.User); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UserUsecase_ListUsers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListUsers'
+type UserUsecase_ListUsers_Call struct {
+	*mock.Call
+}
+
+// ListUsers is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *UserUsecase_Expecter) ListUsers(ctx interface{}) *UserUsecase_ListUsers_Call {
+	return &UserUsecase_ListUsers_Call{Call: _e.mock.On("ListUsers", ctx)}
+}
+
+func (_c *UserUsecase_ListUsers_Call) Run(run func(ctx context.Context)) *UserUsecase_ListUsers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *UserUsecase_ListUsers_Call) Return(_a0 []domain.User, _a1 error) *UserUsecase_ListUsers_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *UserUsecase_ListUsers_Call) RunAndReturn(run func(context.Context) ([]domain.User, error)) *UserUsecase_ListUsers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Login provides a mock function with given fields: ctx, username, password
 func (_m *UserUsecase) Login(ctx context.Context, username string, password string) (string, error) {
 	ret := _m.Called(ctx, username, password)
@@ -80,6 +186,62 @@ func (_c *UserUsecase_Login_Call) RunAndReturn(run func(context.Context, string,
 	return _c
 }
 
+// Me provides a mock function with given fields: ctx
+func (_m *UserUsecase) Me(ctx context.Context) (domain.User, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Me")
+	}
+
+	var r0 domain.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (domain.User, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) domain.User); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(domain.User)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UserUsecase_Me_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Me'
+type UserUsecase_Me_Call struct {
+	*mock.Call
+}
+
+// Me is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *UserUsecase_Expecter) Me(ctx interface{}) *UserUsecase_Me_Call {
+	return &UserUsecase_Me_Call{Call: _e.mock.On("Me", ctx)}
+}
+
+func (_c *UserUsecase_Me_Call) Run(run func(ctx context.Context)) *UserUsecase_Me_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *UserUsecase_Me_Call) Return(_a0 domain.User, _a1 error) *UserUsecase_Me_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *UserUsecase_Me_Call) RunAndReturn(run func(context.Context) (domain.User, error)) *UserUsecase_Me_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Register provides a mock function with given fields: ctx, u
 func (_m *UserUsecase) Register(ctx context.Context, u domain.User) error {
 	ret := _m.Called(ctx, u)
@@ -127,6 +289,266 @@ func (_c *UserUsecase_Register_Call) RunAndReturn(run func(context.Context, doma
 	return _c
 }
 
+// SetDisabled provides a mock function with given fields: ctx, username, disabled
+func (_m *UserUsecase) SetDisabled(ctx context.Context, username string, disabled bool) error {
+	ret := _m.Called(ctx, username, disabled)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetDisabled")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool) error); ok {
+		r0 = rf(ctx, username, disabled)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UserUsecase_SetDisabled_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetDisabled'
+type UserUsecase_SetDisabled_Call struct {
+	*mock.Call
+}
+
+// SetDisabled is a helper method to define mock.On call
+//   - ctx context.Context
+//   - username string
+//   - disabled bool
+func (_e *UserUsecase_Expecter) SetDisabled(ctx interface{}, username interface{}, disabled interface{}) *UserUsecase_SetDisabled_Call {
+	return &UserUsecase_SetDisabled_Call{Call: _e.mock.On("SetDisabled", ctx, username, disabled)}
+}
+
+func (_c *UserUsecase_SetDisabled_Call) Run(run func(ctx context.Context, username string, disabled bool)) *UserUsecase_SetDisabled_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(bool))
+	})
+	return _c
+}
+
+func (_c *UserUsecase_SetDisabled_Call) Return(_a0 error) *UserUsecase_SetDisabled_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *UserUsecase_SetDisabled_Call) RunAndReturn(run func(context.Context, string, bool) error) *UserUsecase_SetDisabled_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetPassword provides a mock function with given fields: ctx, username, password
+func (_m *UserUsecase) SetPassword(ctx context.Context, username string, password string) error {
+	ret := _m.Called(ctx, username, password)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetPassword")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, username, password)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UserUsecase_SetPassword_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetPassword'
+type UserUsecase_SetPassword_Call struct {
+	*mock.Call
+}
+
+// SetPassword is a helper method to define mock.On call
+//   - ctx context.Context
+//   - username string
+//   - password string
+func (_e *UserUsecase_Expecter) SetPassword(ctx interface{}, username interface{}, password interface{}) *UserUsecase_SetPassword_Call {
+	return &UserUsecase_SetPassword_Call{Call: _e.mock.On("SetPassword", ctx, username, password)}
+}
+
+func (_c *UserUsecase_SetPassword_Call) Run(run func(ctx context.Context, username string, password string)) *UserUsecase_SetPassword_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *UserUsecase_SetPassword_Call) Return(_a0 error) *UserUsecase_SetPassword_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *UserUsecase_SetPassword_Call) RunAndReturn(run func(context.Context, string, string) error) *UserUsecase_SetPassword_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetTimeZone provides a mock function with given fields: ctx, name
+func (_m *UserUsecase) SetTimeZone(ctx context.Context, name string) (domain.User, error) {
+	ret := _m.Called(ctx, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetTimeZone")
+	}
+
+	var r0 domain.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (domain.User, error)); ok {
+		return rf(ctx, name)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) domain.User); ok {
+		r0 = rf(ctx, name)
+	} else {
+		r0 = ret.Get(0).(domain.User)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UserUsecase_SetTimeZone_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetTimeZone'
+type UserUsecase_SetTimeZone_Call struct {
+	*mock.Call
+}
+
+// SetTimeZone is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+func (_e *UserUsecase_Expecter) SetTimeZone(ctx interface{}, name interface{}) *UserUsecase_SetTimeZone_Call {
+	return &UserUsecase_SetTimeZone_Call{Call: _e.mock.On("SetTimeZone", ctx, name)}
+}
+
+func (_c *UserUsecase_SetTimeZone_Call) Run(run func(ctx context.Context, name string)) *UserUsecase_SetTimeZone_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *UserUsecase_SetTimeZone_Call) Return(_a0 domain.User, _a1 error) *UserUsecase_SetTimeZone_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *UserUsecase_SetTimeZone_Call) RunAndReturn(run func(context.Context, string) (domain.User, error)) *UserUsecase_SetTimeZone_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Usernames provides a mock function with given fields: ctx, ids
+func (_m *UserUsecase) Usernames(ctx context.Context, ids []string) (map[string]string, error) {
+	ret := _m.Called(ctx, ids)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Usernames")
+	}
+
+	var r0 map[string]string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) (map[string]string, error)); ok {
+		return rf(ctx, ids)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string) map[string]string); ok {
+		r0 = rf(ctx, ids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, ids)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UserUsecase_Usernames_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Usernames'
+type UserUsecase_Usernames_Call struct {
+	*mock.Call
+}
+
+// Usernames is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ids []string
+func (_e *UserUsecase_Expecter) Usernames(ctx interface{}, ids interface{}) *UserUsecase_Usernames_Call {
+	return &UserUsecase_Usernames_Call{Call: _e.mock.On("Usernames", ctx, ids)}
+}
+
+func (_c *UserUsecase_Usernames_Call) Run(run func(ctx context.Context, ids []string)) *UserUsecase_Usernames_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]string))
+	})
+	return _c
+}
+
+func (_c *UserUsecase_Usernames_Call) Return(_a0 map[string]string, _a1 error) *UserUsecase_Usernames_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *UserUsecase_Usernames_Call) RunAndReturn(run func(context.Context, []string) (map[string]string, error)) *UserUsecase_Usernames_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ValidateSession provides a mock function with given fields: ctx, userID, tokenVersion
+func (_m *UserUsecase) ValidateSession(ctx context.Context, userID string, tokenVersion int) error {
+	ret := _m.Called(ctx, userID, tokenVersion)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ValidateSession")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) error); ok {
+		r0 = rf(ctx, userID, tokenVersion)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UserUsecase_ValidateSession_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ValidateSession'
+type UserUsecase_ValidateSession_Call struct {
+	*mock.Call
+}
+
+// ValidateSession is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - tokenVersion int
+func (_e *UserUsecase_Expecter) ValidateSession(ctx interface{}, userID interface{}, tokenVersion interface{}) *UserUsecase_ValidateSession_Call {
+	return &UserUsecase_ValidateSession_Call{Call: _e.mock.On("ValidateSession", ctx, userID, tokenVersion)}
+}
+
+func (_c *UserUsecase_ValidateSession_Call) Run(run func(ctx context.Context, userID string, tokenVersion int)) *UserUsecase_ValidateSession_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *UserUsecase_ValidateSession_Call) Return(_a0 error) *UserUsecase_ValidateSession_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *UserUsecase_ValidateSession_Call) RunAndReturn(run func(context.Context, string, int) error) *UserUsecase_ValidateSession_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewUserUsecase creates a new instance of UserUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewUserUsecase(t interface {
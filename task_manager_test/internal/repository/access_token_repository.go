@@ -0,0 +1,139 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"task_manager_test/internal/domain"
+	"task_manager_test/internal/usecase"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// mongoAccessTokenRepository is the MongoDB-based implementation of the IAccessTokenRepository interface.
+// Tokens are looked up by globally unique hashes before the request's tenant is known, and otherwise always by
+// their owner, so the collection is not tenant-scoped.
+type mongoAccessTokenRepository struct {
+	collection *mongo.Collection
+}
+
+// Add a compile-time check to ensure this struct implements the correct interface.
+var _ usecase.IAccessTokenRepository = (*mongoAccessTokenRepository)(nil)
+
+// NewMongoAccessTokenRepository is the constructor for the implementation.
+func NewMongoAccessTokenRepository(db *mongo.Database) usecase.IAccessTokenRepository {
+	return &mongoAccessTokenRepository{
+		collection: db.Collection("access_tokens"),
+	}
+}
+
+// accessTokenRecord is the BSON shape of a personal access token document.
+type accessTokenRecord struct {
+	ID         primitive.ObjectID `bson:"_id"`
+	UserID     string             `bson:"user_id"`
+	Name       string             `bson:"name"`
+	Scopes     []string           `bson:"scopes"`
+	TokenHash  string             `bson:"token_hash"`
+	CreatedAt  time.Time          `bson:"created_at"`
+	ExpiresAt  time.Time          `bson:"expires_at,omitempty"`
+	LastUsedAt time.Time          `bson:"last_used_at,omitempty"`
+	RevokedAt  time.Time          `bson:"revoked_at,omitempty"`
+}
+
+// toDomain converts the stored document into a domain.AccessToken.
+func (r accessTokenRecord) toDomain() domain.AccessToken {
+	return domain.AccessToken{
+		ID:         r.ID.Hex(),
+		UserID:     r.UserID,
+		Name:       r.Name,
+		Scopes:     r.Scopes,
+		TokenHash:  r.TokenHash,
+		CreatedAt:  r.CreatedAt,
+		ExpiresAt:  r.ExpiresAt,
+		LastUsedAt: r.LastUsedAt,
+		RevokedAt:  r.RevokedAt,
+	}
+}
+
+// Create inserts a new token document, generating a new unique ID.
+func (r *mongoAccessTokenRepository) Create(ctx context.Context, t domain.AccessToken) (domain.AccessToken, error) {
+	rec := accessTokenRecord{
+		ID:        primitive.NewObjectID(),
+		UserID:    t.UserID,
+		Name:      t.Name,
+		Scopes:    t.Scopes,
+		TokenHash: t.TokenHash,
+		CreatedAt: t.CreatedAt,
+		ExpiresAt: t.ExpiresAt,
+	}
+	if _, err := r.collection.InsertOne(ctx, rec); err != nil {
+		return domain.AccessToken{}, err
+	}
+	t.ID = rec.ID.Hex()
+	return t, nil
+}
+
+// ListByUser returns every token of the user, newest first.
+func (r *mongoAccessTokenRepository) ListByUser(ctx context.Context, userID string) ([]domain.AccessToken, error) {
+	cursor, err := r.collection.Find(ctx, bson.M{"user_id": userID}, options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var recs []accessTokenRecord
+	if err := cursor.All(ctx, &recs); err != nil {
+		return nil, err
+	}
+	tokens := make([]domain.AccessToken, len(recs))
+	for i, rec := range recs {
+		tokens[i] = rec.toDomain()
+	}
+	return tokens, nil
+}
+
+// FindByHash looks a token up by the hash of its value.
+func (r *mongoAccessTokenRepository) FindByHash(ctx context.Context, tokenHash string) (domain.AccessToken, error) {
+	var rec accessTokenRecord
+	err := r.collection.FindOne(ctx, bson.M{"token_hash": tokenHash}).Decode(&rec)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return domain.AccessToken{}, usecase.ErrNotFound
+		}
+		return domain.AccessToken{}, err
+	}
+	return rec.toDomain(), nil
+}
+
+// Revoke marks an active token owned by the user as revoked. The owner is part of the filter so users cannot
+// revoke each other's tokens.
+func (r *mongoAccessTokenRepository) Revoke(ctx context.Context, userID, id string, now time.Time) error {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return usecase.ErrInvalidID
+	}
+	res, err := r.collection.UpdateOne(ctx,
+		bson.M{"_id": oid, "user_id": userID, "revoked_at": bson.M{"$exists": false}},
+		bson.M{"$set": bson.M{"revoked_at": now}},
+	)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return usecase.ErrNotFound
+	}
+	return nil
+}
+
+// TouchLastUsed records when the token last authenticated a request.
+func (r *mongoAccessTokenRepository) TouchLastUsed(ctx context.Context, id string, now time.Time) error {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return usecase.ErrInvalidID
+	}
+	_, err = r.collection.UpdateOne(ctx, bson.M{"_id": oid}, bson.M{"$set": bson.M{"last_used_at": now}})
+	return err
+}
@@ -0,0 +1,114 @@
+package repository
+
+import (
+	"context"
+	"os"
+	"task_manager_test/internal/domain"
+	"task_manager_test/internal/usecase"
+	"testing"
+	"time"
+
+	"github.com/joho/godotenv"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// AccessTokenRepositoryTestSuite defines the integration test suite for the access token repository.
+type AccessTokenRepositoryTestSuite struct {
+	suite.Suite
+	client     *mongo.Client
+	db         *mongo.Database
+	collection *mongo.Collection
+	repository usecase.IAccessTokenRepository
+}
+
+// SetupSuite connects to the dedicated test database, skipping the suite when it is not configured.
+func (s *AccessTokenRepositoryTestSuite) SetupSuite() {
+	if err := godotenv.Load("../../.env"); err != nil {
+		s.T().Log("No .env file found, proceeding with environment variables")
+	}
+
+	uri := os.Getenv("MONGODB_URI_TEST")
+	if uri == "" {
+		s.T().Skip("MONGODB_URI_TEST environment variable not set, skipping integration tests")
+	}
+
+	client, err := mongo.Connect(context.Background(), options.Client().ApplyURI(uri))
+	assert.NoError(s.T(), err, "Failed to connect to MongoDB")
+
+	s.client = client
+	s.db = client.Database("accesstokendb_test")
+	s.collection = s.db.Collection("access_tokens_test")
+}
+
+// TearDownSuite drops the test database and disconnects.
+func (s *AccessTokenRepositoryTestSuite) TearDownSuite() {
+	if s.client != nil {
+		assert.NoError(s.T(), s.db.Drop(context.Background()), "Failed to drop test database")
+		assert.NoError(s.T(), s.client.Disconnect(context.Background()), "Failed to disconnect from MongoDB")
+	}
+}
+
+// SetupTest instantiates a repository bound to the test collection.
+func (s *AccessTokenRepositoryTestSuite) SetupTest() {
+	s.repository = NewMongoAccessTokenRepository(s.db)
+	(s.repository.(*mongoAccessTokenRepository)).collection = s.collection
+}
+
+// TearDownTest drops the collection to isolate tests.
+func (s *AccessTokenRepositoryTestSuite) TearDownTest() {
+	assert.NoError(s.T(), s.collection.Drop(context.Background()), "TearDownTest: failed to drop collection")
+}
+
+// TestAccessTokenRepository is the entry point for the test suite.
+func TestAccessTokenRepository(t *testing.T) {
+	suite.Run(t, new(AccessTokenRepositoryTestSuite))
+}
+
+// TestLifecycle verifies creation, lookup by hash, last-used tracking and revocation.
+func (s *AccessTokenRepositoryTestSuite) TestLifecycle() {
+	// ARRANGE
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	created, err := s.repository.Create(ctx, domain.AccessToken{
+		UserID: "user-1", Name: "ci", Scopes: []string{"tasks:read"}, TokenHash: "hash-1", CreatedAt: now,
+	})
+	assert.NoError(s.T(), err, "Setup: failed to create token")
+
+	// ACT & ASSERT - lookup
+	found, err := s.repository.FindByHash(ctx, "hash-1")
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), created.ID, found.ID)
+	assert.Equal(s.T(), []string{"tasks:read"}, found.Scopes)
+	assert.True(s.T(), found.ExpiresAt.IsZero(), "Tokens without expiry should round-trip as the zero time")
+
+	// ACT & ASSERT - last used
+	assert.NoError(s.T(), s.repository.TouchLastUsed(ctx, created.ID, now))
+	found, _ = s.repository.FindByHash(ctx, "hash-1")
+	assert.WithinDuration(s.T(), now, found.LastUsedAt, time.Millisecond)
+
+	// ACT & ASSERT - revocation is owner-scoped and single-shot
+	assert.ErrorIs(s.T(), s.repository.Revoke(ctx, "user-2", created.ID, now), usecase.ErrNotFound, "Other users must not revoke the token")
+	assert.NoError(s.T(), s.repository.Revoke(ctx, "user-1", created.ID, now))
+	assert.ErrorIs(s.T(), s.repository.Revoke(ctx, "user-1", created.ID, now), usecase.ErrNotFound)
+	found, _ = s.repository.FindByHash(ctx, "hash-1")
+	assert.False(s.T(), found.RevokedAt.IsZero())
+}
+
+// TestListByUser verifies that only the user's tokens are listed, newest first.
+func (s *AccessTokenRepositoryTestSuite) TestListByUser() {
+	ctx := context.Background()
+	now := time.Now().UTC()
+	_, _ = s.repository.Create(ctx, domain.AccessToken{UserID: "user-1", Name: "old", TokenHash: "h1", CreatedAt: now.Add(-time.Hour)})
+	_, _ = s.repository.Create(ctx, domain.AccessToken{UserID: "user-1", Name: "new", TokenHash: "h2", CreatedAt: now})
+	_, _ = s.repository.Create(ctx, domain.AccessToken{UserID: "user-2", Name: "other", TokenHash: "h3", CreatedAt: now})
+
+	tokens, err := s.repository.ListByUser(ctx, "user-1")
+
+	assert.NoError(s.T(), err)
+	assert.Len(s.T(), tokens, 2)
+	assert.Equal(s.T(), "new", tokens[0].Name)
+	assert.Equal(s.T(), "old", tokens[1].Name)
+}
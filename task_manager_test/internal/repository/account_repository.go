@@ -0,0 +1,201 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"task_manager_test/internal/domain"
+	"task_manager_test/internal/usecase"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// mongoAccountRepository is the MongoDB-based implementation of the IAccountRepository interface. It spans the
+// collections holding a user's data and changes them in a multi-document transaction, which requires MongoDB to run
+// as a replica set or sharded cluster.
+type mongoAccountRepository struct {
+	client      *mongo.Client
+	users       *tenantCollection
+	tasks       *tenantCollection
+	shares      *tenantCollection
+	comments    *tenantCollection
+	projects    *tenantCollection
+	time        *tenantCollection
+	tokens      *mongo.Collection
+	resets      *mongo.Collection
+	idempotency *mongo.Collection
+	audit       *mongo.Collection
+}
+
+// Add a compile-time check to ensure this struct implements the correct interface.
+var _ usecase.IAccountRepository = (*mongoAccountRepository)(nil)
+
+// NewMongoAccountRepository is the constructor for the implementation.
+func NewMongoAccountRepository(db *mongo.Database) usecase.IAccountRepository {
+	return &mongoAccountRepository{
+		client:      db.Client(),
+		users:       newTenantCollection(db.Collection("users")),
+		tasks:       newTenantCollection(db.Collection("tasks")),
+		shares:      newTenantCollection(db.Collection("task_shares")),
+		comments:    newTenantCollection(db.Collection("comments")),
+		projects:    newTenantCollection(db.Collection("projects")),
+		time:        newTenantCollection(db.Collection("time_entries")),
+		tokens:      db.Collection("access_tokens"),
+		resets:      db.Collection("password_resets"),
+		idempotency: db.Collection("idempotency_keys"),
+		audit:       db.Collection("audit_log"),
+	}
+}
+
+// DeleteAccount runs the deletion in a transaction, so that a failure part-way leaves the account as it was.
+// WithTransaction retries the whole transaction on transient conflicts with concurrent writes.
+func (r *mongoAccountRepository) DeleteAccount(ctx context.Context, userID string, entry domain.AuditEntry) ([]domain.Attachment, error) {
+	oid, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, usecase.ErrInvalidID
+	}
+	session, err := r.client.StartSession()
+	if err != nil {
+		return nil, err
+	}
+	defer session.EndSession(ctx)
+
+	attachments, err := session.WithTransaction(ctx, func(sc mongo.SessionContext) (any, error) {
+		return r.deleteAccount(sc, oid, entry)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return attachments.([]domain.Attachment), nil
+}
+
+// deleteAccount performs the deletion within the transaction of ctx and returns the attachments of the deleted tasks.
+func (r *mongoAccountRepository) deleteAccount(ctx context.Context, oid primitive.ObjectID, entry domain.AuditEntry) ([]domain.Attachment, error) {
+	userID := oid.Hex()
+	if err := r.users.FindOne(ctx, bson.M{"_id": oid}).Err(); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, usecase.ErrNotFound
+		}
+		return nil, err
+	}
+
+	alone, err := r.soleProjects(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	aloneIDs := make([]string, len(alone))
+	for i, p := range alone {
+		aloneIDs[i] = p.Hex()
+	}
+	// Only personal tasks and tasks of projects that go away with the user are deleted. The tasks the user owns in
+	// projects other users still belong to stay with the project, without an owner.
+	personal := bson.M{"owner_id": userID, "$or": bson.A{
+		bson.M{"project_id": bson.M{"$exists": false}},
+		bson.M{"project_id": bson.M{"$in": aloneIDs}},
+	}}
+	var tasks []taskRecord
+	if err := r.findAll(ctx, r.tasks, personal, &tasks); err != nil {
+		return nil, err
+	}
+	taskIDs := make([]string, len(tasks))
+	var attachments []domain.Attachment
+	for i, t := range tasks {
+		taskIDs[i] = t.ID.Hex()
+		attachments = append(attachments, t.toDomain().Attachments...)
+	}
+
+	var written []commentRecord
+	if err := r.findAll(ctx, r.comments, bson.M{"author_id": userID}, &written); err != nil {
+		return nil, err
+	}
+	writtenIDs := make([]string, len(written))
+	for i, c := range written {
+		writtenIDs[i] = c.ID.Hex()
+	}
+	if _, err := r.comments.DeleteMany(ctx, bson.M{"$or": bson.A{
+		bson.M{"task_id": bson.M{"$in": taskIDs}},
+		bson.M{"author_id": userID},
+		bson.M{"parent_id": bson.M{"$in": writtenIDs}},
+	}}); err != nil {
+		return nil, err
+	}
+	if _, err := r.shares.DeleteMany(ctx, bson.M{"$or": bson.A{
+		bson.M{"task_id": bson.M{"$in": taskIDs}},
+		bson.M{"user_id": userID},
+	}}); err != nil {
+		return nil, err
+	}
+	if _, err := r.time.DeleteMany(ctx, bson.M{"$or": bson.A{
+		bson.M{"task_id": bson.M{"$in": taskIDs}},
+		bson.M{"user_id": userID},
+	}}); err != nil {
+		return nil, err
+	}
+	if _, err := r.tasks.DeleteMany(ctx, personal); err != nil {
+		return nil, err
+	}
+	if _, err := r.tasks.UpdateMany(ctx, bson.M{"owner_id": userID}, bson.M{"$unset": bson.M{"owner_id": ""}}); err != nil {
+		return nil, err
+	}
+
+	if err := r.leaveProjects(ctx, userID, alone, aloneIDs, entry); err != nil {
+		return nil, err
+	}
+
+	for _, coll := range []*mongo.Collection{r.tokens, r.resets, r.idempotency} {
+		if _, err := coll.DeleteMany(ctx, bson.M{"user_id": userID}); err != nil {
+			return nil, err
+		}
+	}
+	if _, err := r.users.DeleteOne(ctx, bson.M{"_id": oid}); err != nil {
+		return nil, err
+	}
+	if _, err := r.audit.InsertOne(ctx, newAuditRecord(entry)); err != nil {
+		return nil, err
+	}
+	return attachments, nil
+}
+
+// soleProjects returns the IDs of the projects the user is the only member of.
+func (r *mongoAccountRepository) soleProjects(ctx context.Context, userID string) ([]primitive.ObjectID, error) {
+	var alone []projectRecord
+	if err := r.findAll(ctx, r.projects, bson.M{"members": bson.M{"$size": 1}, "members.user_id": userID}, &alone); err != nil {
+		return nil, err
+	}
+	oids := make([]primitive.ObjectID, len(alone))
+	for i, p := range alone {
+		oids[i] = p.ID
+	}
+	return oids, nil
+}
+
+// leaveProjects deletes the projects the user is the only member of, archiving the tasks other users left in them
+// as deleting a project does, and removes the user from every other project.
+func (r *mongoAccountRepository) leaveProjects(ctx context.Context, userID string, alone []primitive.ObjectID, aloneIDs []string, entry domain.AuditEntry) error {
+	if len(alone) > 0 {
+		if _, err := r.tasks.UpdateMany(ctx, bson.M{"project_id": bson.M{"$in": aloneIDs}}, bson.M{
+			"$set":   bson.M{"archived_at": entry.At},
+			"$unset": bson.M{"project_id": ""},
+		}); err != nil {
+			return err
+		}
+		if _, err := r.projects.DeleteMany(ctx, bson.M{"_id": bson.M{"$in": alone}}); err != nil {
+			return err
+		}
+	}
+	_, err := r.projects.UpdateMany(ctx, bson.M{"members.user_id": userID},
+		bson.M{"$pull": bson.M{"members": bson.M{"user_id": userID}}})
+	return err
+}
+
+// findAll decodes every document of coll matching the filter into out, reading only their IDs and attachments.
+func (r *mongoAccountRepository) findAll(ctx context.Context, coll *tenantCollection, filter bson.M, out any) error {
+	cursor, err := coll.Find(ctx, filter, options.Find().SetProjection(bson.M{"_id": 1, "attachments": 1}))
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+	return cursor.All(ctx, out)
+}
@@ -0,0 +1,239 @@
+package repository
+
+import (
+	"context"
+	"os"
+	"task_manager_test/internal/domain"
+	"task_manager_test/internal/usecase"
+	"testing"
+	"time"
+
+	"github.com/joho/godotenv"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// AccountRepositoryTestSuite defines the integration test suite for the account repository. Deleting accounts
+// uses transactions, so the suite is skipped unless the test database is a replica set.
+type AccountRepositoryTestSuite struct {
+	suite.Suite
+	client     *mongo.Client
+	db         *mongo.Database
+	repository usecase.IAccountRepository
+	at         time.Time
+}
+
+// SetupSuite connects to the dedicated test database, skipping the suite when it is not configured or does not
+// support transactions.
+func (s *AccountRepositoryTestSuite) SetupSuite() {
+	if err := godotenv.Load("../../.env"); err != nil {
+		s.T().Log("No .env file found, proceeding with environment variables")
+	}
+
+	uri := os.Getenv("MONGODB_URI_TEST")
+	if uri == "" {
+		s.T().Skip("MONGODB_URI_TEST environment variable not set, skipping integration tests")
+	}
+
+	client, err := mongo.Connect(context.Background(), options.Client().ApplyURI(uri))
+	assert.NoError(s.T(), err, "Failed to connect to MongoDB")
+	s.client = client
+	s.db = client.Database("accountdb_test")
+
+	var hello bson.M
+	if err := s.db.RunCommand(context.Background(), bson.D{{Key: "hello", Value: 1}}).Decode(&hello); err != nil || hello["setName"] == nil {
+		s.T().Skip("the test database is not a replica set, skipping transaction tests")
+	}
+}
+
+// TearDownSuite drops the test database and disconnects.
+func (s *AccountRepositoryTestSuite) TearDownSuite() {
+	if s.client != nil {
+		assert.NoError(s.T(), s.db.Drop(context.Background()), "Failed to drop test database")
+		assert.NoError(s.T(), s.client.Disconnect(context.Background()), "Failed to disconnect from MongoDB")
+	}
+}
+
+// SetupTest creates a repository.
+func (s *AccountRepositoryTestSuite) SetupTest() {
+	s.repository = NewMongoAccountRepository(s.db)
+	s.at = time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+}
+
+// TearDownTest empties the collections to isolate tests. Collections are emptied rather than dropped, since
+// transactions cannot create them on older servers.
+func (s *AccountRepositoryTestSuite) TearDownTest() {
+	for _, coll := range append(Collections, "idempotency_keys") {
+		_, err := s.db.Collection(coll).DeleteMany(context.Background(), bson.M{})
+		assert.NoError(s.T(), err, "TearDownTest: failed to empty %s", coll)
+	}
+}
+
+// TestAccountRepository is the entry point for the test suite.
+func TestAccountRepository(t *testing.T) {
+	suite.Run(t, new(AccountRepositoryTestSuite))
+}
+
+// count returns the number of documents in the collection matching the filter.
+func (s *AccountRepositoryTestSuite) count(coll string, filter bson.M) int64 {
+	n, err := s.db.Collection(coll).CountDocuments(context.Background(), filter)
+	require.NoError(s.T(), err)
+	return n
+}
+
+// TestDeleteAccount_CascadesToOwnedData verifies that the user's data is deleted, other users' data is kept, the
+// user leaves shared projects, and the deletion is recorded.
+func (s *AccountRepositoryTestSuite) TestDeleteAccount_CascadesToOwnedData() {
+	// ARRANGE
+	ctx := testTenant()
+	users := NewMongoUserRepository(s.db)
+	tasks := NewMongoTaskRepository(s.db)
+	comments := NewMongoCommentRepository(s.db)
+	shares := NewMongoTaskShareRepository(s.db)
+	projects := NewMongoProjectRepository(s.db)
+	tokens := NewMongoAccessTokenRepository(s.db)
+	timeEntries := NewMongoTimeEntryRepository(s.db)
+
+	alice, err := users.Create(ctx, domain.User{Username: "alice", Role: domain.RoleMember})
+	require.NoError(s.T(), err)
+	bob, err := users.Create(ctx, domain.User{Username: "bob", Role: domain.RoleMember})
+	require.NoError(s.T(), err)
+
+	solo, err := projects.Create(ctx, domain.Project{Name: "Solo", Members: []domain.ProjectMember{{UserID: alice.ID, Role: domain.ProjectOwner}}})
+	require.NoError(s.T(), err)
+	shared, err := projects.Create(ctx, domain.Project{Name: "Shared", Members: []domain.ProjectMember{
+		{UserID: bob.ID, Role: domain.ProjectOwner}, {UserID: alice.ID, Role: domain.ProjectEditor},
+	}})
+	require.NoError(s.T(), err)
+
+	owned, err := tasks.Create(ctx, domain.Task{Title: "mine", OwnerID: alice.ID, DueDate: s.at, Status: "Pending"})
+	require.NoError(s.T(), err)
+	_, err = tasks.AddAttachment(ctx, owned.ID, domain.Attachment{Filename: "a.txt", Digest: "digest-1", UploadedAt: s.at})
+	require.NoError(s.T(), err)
+	kept, err := tasks.Create(ctx, domain.Task{Title: "bob's", OwnerID: bob.ID, ProjectID: solo.ID, DueDate: s.at, Status: "Pending"})
+	require.NoError(s.T(), err)
+
+	onOwned, err := comments.Create(ctx, domain.Comment{TaskID: owned.ID, AuthorID: bob.ID, CreatedAt: s.at})
+	require.NoError(s.T(), err)
+	written, err := comments.Create(ctx, domain.Comment{TaskID: kept.ID, AuthorID: alice.ID, CreatedAt: s.at})
+	require.NoError(s.T(), err)
+	_, err = comments.Create(ctx, domain.Comment{TaskID: kept.ID, ParentID: written.ID, AuthorID: bob.ID, CreatedAt: s.at})
+	require.NoError(s.T(), err)
+	bobs, err := comments.Create(ctx, domain.Comment{TaskID: kept.ID, AuthorID: bob.ID, CreatedAt: s.at})
+	require.NoError(s.T(), err)
+
+	_, err = shares.Upsert(ctx, domain.TaskShare{TaskID: owned.ID, UserID: bob.ID, Access: domain.ShareRead})
+	require.NoError(s.T(), err)
+	_, err = shares.Upsert(ctx, domain.TaskShare{TaskID: kept.ID, UserID: alice.ID, Access: domain.ShareRead})
+	require.NoError(s.T(), err)
+	_, err = tokens.Create(ctx, domain.AccessToken{UserID: alice.ID, TokenHash: "hash-1"})
+	require.NoError(s.T(), err)
+	_, err = timeEntries.Create(ctx, domain.TimeEntry{TaskID: owned.ID, UserID: bob.ID, Start: s.at, End: s.at.Add(time.Hour)})
+	require.NoError(s.T(), err)
+	_, err = timeEntries.Create(ctx, domain.TimeEntry{TaskID: kept.ID, UserID: alice.ID, Start: s.at, End: s.at.Add(time.Hour)})
+	require.NoError(s.T(), err)
+	bobsTime, err := timeEntries.Create(ctx, domain.TimeEntry{TaskID: kept.ID, UserID: bob.ID, Start: s.at, End: s.at.Add(time.Hour)})
+	require.NoError(s.T(), err)
+
+	entry := domain.AuditEntry{OrgID: "org-1", ActorID: alice.ID, Action: domain.AuditAccountDeleted, TargetUserID: alice.ID, At: s.at}
+
+	// ACT
+	attachments, err := s.repository.DeleteAccount(ctx, alice.ID, entry)
+
+	// ASSERT
+	require.NoError(s.T(), err)
+	require.Len(s.T(), attachments, 1)
+	assert.Equal(s.T(), "digest-1", attachments[0].Digest)
+
+	_, err = users.FindByID(ctx, alice.ID)
+	assert.ErrorIs(s.T(), err, usecase.ErrNotFound)
+	_, err = tasks.GetByID(ctx, owned.ID)
+	assert.ErrorIs(s.T(), err, usecase.ErrNotFound)
+	_, err = comments.GetByID(ctx, onOwned.ID)
+	assert.ErrorIs(s.T(), err, usecase.ErrNotFound, "Comments on deleted tasks should be deleted")
+	assert.Zero(s.T(), s.count("comments", bson.M{"parent_id": written.ID}), "Replies to the user's comments should be deleted")
+	_, err = comments.GetByID(ctx, bobs.ID)
+	assert.NoError(s.T(), err, "Other users' comments should be kept")
+	assert.Zero(s.T(), s.count("task_shares", bson.M{}))
+	assert.Zero(s.T(), s.count("access_tokens", bson.M{}))
+	keptTime, err := timeEntries.ListByTask(ctx, kept.ID)
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), []domain.TimeEntry{bobsTime}, keptTime, "Only other users' time on other users' tasks should be kept")
+	assert.Zero(s.T(), s.count("time_entries", bson.M{"task_id": owned.ID}), "Time on deleted tasks should be deleted")
+
+	task, err := tasks.GetByID(ctx, kept.ID)
+	assert.NoError(s.T(), err, "Other users' tasks should be kept")
+	assert.Empty(s.T(), task.ProjectID, "Tasks of deleted projects should be detached")
+	assert.Equal(s.T(), s.at, task.ArchivedAt.UTC())
+	_, err = projects.GetByID(ctx, solo.ID)
+	assert.ErrorIs(s.T(), err, usecase.ErrNotFound, "Projects the user was alone in should be deleted")
+	p, err := projects.GetByID(ctx, shared.ID)
+	assert.NoError(s.T(), err)
+	assert.Len(s.T(), p.Members, 1, "The user should leave shared projects")
+
+	assert.Equal(s.T(), int64(1), s.count("audit_log", bson.M{"target_user_id": alice.ID, "action": domain.AuditAccountDeleted}))
+}
+
+// TestDeleteAccount_KeepsTasksOfSharedProjects verifies that the user's tasks in projects other users still belong to
+// stay with the project, with their comments and other users' time, and lose their owner.
+func (s *AccountRepositoryTestSuite) TestDeleteAccount_KeepsTasksOfSharedProjects() {
+	// ARRANGE
+	ctx := testTenant()
+	users := NewMongoUserRepository(s.db)
+	tasks := NewMongoTaskRepository(s.db)
+	comments := NewMongoCommentRepository(s.db)
+	projects := NewMongoProjectRepository(s.db)
+	timeEntries := NewMongoTimeEntryRepository(s.db)
+
+	alice, err := users.Create(ctx, domain.User{Username: "alice", Role: domain.RoleMember})
+	require.NoError(s.T(), err)
+	bob, err := users.Create(ctx, domain.User{Username: "bob", Role: domain.RoleMember})
+	require.NoError(s.T(), err)
+	shared, err := projects.Create(ctx, domain.Project{Name: "Shared", Members: []domain.ProjectMember{
+		{UserID: bob.ID, Role: domain.ProjectOwner}, {UserID: alice.ID, Role: domain.ProjectEditor},
+	}})
+	require.NoError(s.T(), err)
+
+	projectTask, err := tasks.Create(ctx, domain.Task{Title: "team work", OwnerID: alice.ID, ProjectID: shared.ID, DueDate: s.at, Status: "Pending"})
+	require.NoError(s.T(), err)
+	_, err = tasks.AddAttachment(ctx, projectTask.ID, domain.Attachment{Filename: "a.txt", Digest: "digest-1", UploadedAt: s.at})
+	require.NoError(s.T(), err)
+	bobs, err := comments.Create(ctx, domain.Comment{TaskID: projectTask.ID, AuthorID: bob.ID, CreatedAt: s.at})
+	require.NoError(s.T(), err)
+	bobsTime, err := timeEntries.Create(ctx, domain.TimeEntry{TaskID: projectTask.ID, UserID: bob.ID, Start: s.at, End: s.at.Add(time.Hour)})
+	require.NoError(s.T(), err)
+	_, err = timeEntries.Create(ctx, domain.TimeEntry{TaskID: projectTask.ID, UserID: alice.ID, Start: s.at, End: s.at.Add(time.Hour)})
+	require.NoError(s.T(), err)
+
+	entry := domain.AuditEntry{OrgID: "org-1", ActorID: alice.ID, Action: domain.AuditAccountDeleted, TargetUserID: alice.ID, At: s.at}
+
+	// ACT
+	attachments, err := s.repository.DeleteAccount(ctx, alice.ID, entry)
+
+	// ASSERT
+	require.NoError(s.T(), err)
+	assert.Empty(s.T(), attachments, "Attachments of kept tasks should not be released")
+	task, err := tasks.GetByID(ctx, projectTask.ID)
+	require.NoError(s.T(), err, "Tasks of shared projects should be kept")
+	assert.Equal(s.T(), shared.ID, task.ProjectID)
+	assert.Empty(s.T(), task.OwnerID, "Kept tasks should no longer name the deleted user")
+	assert.True(s.T(), task.ArchivedAt.IsZero())
+	assert.Len(s.T(), task.Attachments, 1)
+	_, err = comments.GetByID(ctx, bobs.ID)
+	assert.NoError(s.T(), err, "Other users' comments on kept tasks should be kept")
+	keptTime, err := timeEntries.ListByTask(ctx, projectTask.ID)
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), []domain.TimeEntry{bobsTime}, keptTime, "Only the user's own time should be deleted")
+}
+
+// TestDeleteAccount_UnknownUser verifies that nothing is deleted or recorded for a missing user.
+func (s *AccountRepositoryTestSuite) TestDeleteAccount_UnknownUser() {
+	_, err := s.repository.DeleteAccount(testTenant(), "64b7f0f0f0f0f0f0f0f0f0f0", domain.AuditEntry{At: s.at})
+
+	assert.ErrorIs(s.T(), err, usecase.ErrNotFound)
+	assert.Zero(s.T(), s.count("audit_log", bson.M{}))
+}
@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"context"
+	"task_manager_test/internal/domain"
+	"task_manager_test/internal/usecase"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// mongoAuditRepository is the MongoDB-based implementation of the IAuditRepository interface. Entries record their
+// organization, but the collection is not tenant-scoped: they are looked up by globally unique user IDs, and must
+// stay readable after the users they mention are deleted.
+type mongoAuditRepository struct {
+	collection *mongo.Collection
+}
+
+// Add a compile-time check to ensure this struct implements the correct interface.
+var _ usecase.IAuditRepository = (*mongoAuditRepository)(nil)
+
+// NewMongoAuditRepository is the constructor for the implementation.
+func NewMongoAuditRepository(db *mongo.Database) usecase.IAuditRepository {
+	return &mongoAuditRepository{
+		collection: db.Collection("audit_log"),
+	}
+}
+
+// auditRecord is the BSON shape of an audit_log document.
+type auditRecord struct {
+	ID             primitive.ObjectID `bson:"_id"`
+	OrgID          string             `bson:"org_id"`
+	ActorID        string             `bson:"actor_id"`
+	ActorUsername  string             `bson:"actor_username"`
+	Action         domain.AuditAction `bson:"action"`
+	TargetUserID   string             `bson:"target_user_id"`
+	TargetUsername string             `bson:"target_username"`
+	At             time.Time          `bson:"at"`
+}
+
+// newAuditRecord converts the entry to a new document.
+func newAuditRecord(e domain.AuditEntry) auditRecord {
+	return auditRecord{
+		ID:             primitive.NewObjectID(),
+		OrgID:          e.OrgID,
+		ActorID:        e.ActorID,
+		ActorUsername:  e.ActorUsername,
+		Action:         e.Action,
+		TargetUserID:   e.TargetUserID,
+		TargetUsername: e.TargetUsername,
+		At:             e.At,
+	}
+}
+
+// toDomain converts the document to its domain representation.
+func (rec auditRecord) toDomain() domain.AuditEntry {
+	return domain.AuditEntry{
+		ID:             rec.ID.Hex(),
+		OrgID:          rec.OrgID,
+		ActorID:        rec.ActorID,
+		ActorUsername:  rec.ActorUsername,
+		Action:         rec.Action,
+		TargetUserID:   rec.TargetUserID,
+		TargetUsername: rec.TargetUsername,
+		At:             rec.At,
+	}
+}
+
+// Record inserts the entry and returns it with its ID.
+func (r *mongoAuditRepository) Record(ctx context.Context, e domain.AuditEntry) (domain.AuditEntry, error) {
+	rec := newAuditRecord(e)
+	if _, err := r.collection.InsertOne(ctx, rec); err != nil {
+		return domain.AuditEntry{}, err
+	}
+	return rec.toDomain(), nil
+}
+
+// ListByUser returns the entries whose actor or target is the user, oldest first.
+func (r *mongoAuditRepository) ListByUser(ctx context.Context, userID string) ([]domain.AuditEntry, error) {
+	cursor, err := r.collection.Find(ctx,
+		bson.M{"$or": bson.A{bson.M{"actor_id": userID}, bson.M{"target_user_id": userID}}},
+		options.Find().SetSort(bson.D{{Key: "at", Value: 1}, {Key: "_id", Value: 1}}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var recs []auditRecord
+	if err := cursor.All(ctx, &recs); err != nil {
+		return nil, err
+	}
+	entries := make([]domain.AuditEntry, len(recs))
+	for i, rec := range recs {
+		entries[i] = rec.toDomain()
+	}
+	return entries, nil
+}
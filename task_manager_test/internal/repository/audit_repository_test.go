@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"context"
+	"os"
+	"task_manager_test/internal/domain"
+	"task_manager_test/internal/usecase"
+	"testing"
+	"time"
+
+	"github.com/joho/godotenv"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// AuditRepositoryTestSuite defines the integration test suite for the audit repository.
+type AuditRepositoryTestSuite struct {
+	suite.Suite
+	client     *mongo.Client
+	db         *mongo.Database
+	repository usecase.IAuditRepository
+}
+
+// SetupSuite connects to the dedicated test database, skipping the suite when it is not configured.
+func (s *AuditRepositoryTestSuite) SetupSuite() {
+	if err := godotenv.Load("../../.env"); err != nil {
+		s.T().Log("No .env file found, proceeding with environment variables")
+	}
+
+	uri := os.Getenv("MONGODB_URI_TEST")
+	if uri == "" {
+		s.T().Skip("MONGODB_URI_TEST environment variable not set, skipping integration tests")
+	}
+
+	client, err := mongo.Connect(context.Background(), options.Client().ApplyURI(uri))
+	assert.NoError(s.T(), err, "Failed to connect to MongoDB")
+
+	s.client = client
+	s.db = client.Database("auditdb_test")
+}
+
+// TearDownSuite drops the test database and disconnects.
+func (s *AuditRepositoryTestSuite) TearDownSuite() {
+	if s.client != nil {
+		assert.NoError(s.T(), s.db.Drop(context.Background()), "Failed to drop test database")
+		assert.NoError(s.T(), s.client.Disconnect(context.Background()), "Failed to disconnect from MongoDB")
+	}
+}
+
+// SetupTest creates a repository.
+func (s *AuditRepositoryTestSuite) SetupTest() {
+	s.repository = NewMongoAuditRepository(s.db)
+}
+
+// TearDownTest drops the collection to isolate tests.
+func (s *AuditRepositoryTestSuite) TearDownTest() {
+	assert.NoError(s.T(), s.db.Collection("audit_log").Drop(context.Background()), "TearDownTest: failed to drop collection")
+}
+
+// TestAuditRepository is the entry point for the test suite.
+func TestAuditRepository(t *testing.T) {
+	suite.Run(t, new(AuditRepositoryTestSuite))
+}
+
+// TestListByUser_MatchesActorAndTarget verifies that a user's entries include those it performed and those
+// performed on it, oldest first, and that entries need no tenant in the context.
+func (s *AuditRepositoryTestSuite) TestListByUser_MatchesActorAndTarget() {
+	// ARRANGE
+	ctx := context.Background()
+	at := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	record := func(actor, target string, minute int) domain.AuditEntry {
+		e, err := s.repository.Record(ctx, domain.AuditEntry{
+			OrgID: "org-1", ActorID: actor, Action: domain.AuditAccountExported, TargetUserID: target,
+			At: at.Add(time.Duration(minute) * time.Minute),
+		})
+		assert.NoError(s.T(), err, "Setup: failed to record entry")
+		return e
+	}
+	byAdmin := record("admin-1", "user-1", 2)
+	bySelf := record("user-1", "user-1", 1)
+	record("admin-1", "user-2", 3)
+
+	// ACT
+	entries, err := s.repository.ListByUser(ctx, "user-1")
+
+	// ASSERT
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), []domain.AuditEntry{bySelf, byAdmin}, entries)
+	assert.NotEmpty(s.T(), bySelf.ID)
+}
@@ -0,0 +1,270 @@
+package repository
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"task_manager_test/internal/usecase"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// backupFormat and backupVersion identify the backup stream written by Export.
+const (
+	backupFormat  = "task-manager-backup"
+	backupVersion = 1
+)
+
+// importBatchSize is the number of documents inserted per round trip during Import.
+const importBatchSize = 500
+
+// ErrBackupTargetNotEmpty is returned by Import when the database already holds data and replace was not requested.
+var ErrBackupTargetNotEmpty = errors.New("database is not empty; pass replace to overwrite it")
+
+// Backup exports and imports the whole database, together with the attachment contents the tasks refer to.
+//
+// A backup is newline-delimited JSON: a header line, then one line per document in MongoDB canonical extended JSON,
+// so every BSON type survives the round trip, then one line per attachment blob.
+type Backup struct {
+	db    *mongo.Database
+	blobs usecase.IBlobStore
+}
+
+// NewBackup creates a Backup of db and blobs.
+func NewBackup(db *mongo.Database, blobs usecase.IBlobStore) *Backup {
+	return &Backup{db: db, blobs: blobs}
+}
+
+// BackupStats counts what was exported or imported.
+type BackupStats struct {
+	Documents map[string]int `json:"documents"`
+	Blobs     int            `json:"blobs"`
+	// MissingBlobs lists attachment digests whose content was not in the blob store when exporting.
+	MissingBlobs []string `json:"missing_blobs,omitempty"`
+}
+
+// backupHeader is the first line of a backup.
+type backupHeader struct {
+	Format    string    `json:"format"`
+	Version   int       `json:"version"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// backupLine is a document or blob line of a backup. Exactly one of Collection and Blob is set.
+type backupLine struct {
+	Collection string          `json:"collection,omitempty"`
+	Document   json.RawMessage `json:"document,omitempty"`
+	Blob       string          `json:"blob,omitempty"`
+	Data       []byte          `json:"data,omitempty"`
+}
+
+// Export writes every collection in Collections, then the content of every attachment, to w.
+// Attachments whose content is missing are reported in the stats rather than failing the export.
+func (b *Backup) Export(ctx context.Context, w io.Writer) (BackupStats, error) {
+	stats := BackupStats{Documents: map[string]int{}}
+	bw := bufio.NewWriter(w)
+	enc := json.NewEncoder(bw)
+	if err := enc.Encode(backupHeader{Format: backupFormat, Version: backupVersion, CreatedAt: time.Now().UTC()}); err != nil {
+		return stats, err
+	}
+
+	var digests []string
+	for _, coll := range Collections {
+		cursor, err := b.db.Collection(coll).Find(ctx, bson.M{}, options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}))
+		if err != nil {
+			return stats, fmt.Errorf("export %s: %w", coll, err)
+		}
+		for cursor.Next(ctx) {
+			doc, err := bson.MarshalExtJSON(cursor.Current, true, false)
+			if err != nil {
+				_ = cursor.Close(ctx)
+				return stats, fmt.Errorf("export %s: %w", coll, err)
+			}
+			if err := enc.Encode(backupLine{Collection: coll, Document: doc}); err != nil {
+				_ = cursor.Close(ctx)
+				return stats, err
+			}
+			stats.Documents[coll]++
+			if coll == "tasks" {
+				digests = append(digests, attachmentDigests(cursor.Current)...)
+			}
+		}
+		err = cursor.Err()
+		_ = cursor.Close(ctx)
+		if err != nil {
+			return stats, fmt.Errorf("export %s: %w", coll, err)
+		}
+	}
+
+	slices.Sort(digests)
+	for _, digest := range slices.Compact(digests) {
+		data, err := b.readBlob(ctx, digest)
+		if errors.Is(err, usecase.ErrNotFound) {
+			stats.MissingBlobs = append(stats.MissingBlobs, digest)
+			continue
+		}
+		if err != nil {
+			return stats, fmt.Errorf("export blob %s: %w", digest, err)
+		}
+		if err := enc.Encode(backupLine{Blob: digest, Data: data}); err != nil {
+			return stats, err
+		}
+		stats.Blobs++
+	}
+	return stats, bw.Flush()
+}
+
+// attachmentDigests returns the digests of the attachments of a raw task document.
+func attachmentDigests(task bson.Raw) []string {
+	var rec struct {
+		Attachments []struct {
+			Digest string `bson:"digest"`
+		} `bson:"attachments"`
+	}
+	if err := bson.Unmarshal(task, &rec); err != nil {
+		return nil
+	}
+	digests := make([]string, 0, len(rec.Attachments))
+	for _, a := range rec.Attachments {
+		digests = append(digests, a.Digest)
+	}
+	return digests
+}
+
+// readBlob reads the whole content with the given digest.
+func (b *Backup) readBlob(ctx context.Context, digest string) ([]byte, error) {
+	rc, err := b.blobs.Open(ctx, digest)
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	return io.ReadAll(rc)
+}
+
+// Import restores a backup written by Export. Unless replace is set, it refuses to run when any of the collections
+// already holds documents; with replace, those collections are emptied first. Import is not atomic: a failure
+// part-way leaves the documents imported so far in place.
+func (b *Backup) Import(ctx context.Context, r io.Reader, replace bool) (BackupStats, error) {
+	stats := BackupStats{Documents: map[string]int{}}
+	br := bufio.NewReader(r)
+	line, err := readLine(br)
+	if err != nil {
+		return stats, fmt.Errorf("read backup header: %w", err)
+	}
+	var header backupHeader
+	if err := json.Unmarshal(line, &header); err != nil || header.Format != backupFormat {
+		return stats, errors.New("not a task manager backup")
+	}
+	if header.Version != backupVersion {
+		return stats, fmt.Errorf("unsupported backup version %d", header.Version)
+	}
+	if err := b.prepareImport(ctx, replace); err != nil {
+		return stats, err
+	}
+
+	batches := map[string][]any{}
+	flush := func(coll string) error {
+		if len(batches[coll]) == 0 {
+			return nil
+		}
+		if _, err := b.db.Collection(coll).InsertMany(ctx, batches[coll]); err != nil {
+			return fmt.Errorf("import %s: %w", coll, err)
+		}
+		stats.Documents[coll] += len(batches[coll])
+		batches[coll] = batches[coll][:0]
+		return nil
+	}
+	for n := 2; ; n++ {
+		line, err := readLine(br)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return stats, err
+		}
+		var l backupLine
+		if err := json.Unmarshal(line, &l); err != nil {
+			return stats, fmt.Errorf("backup line %d: %w", n, err)
+		}
+		switch {
+		case l.Collection != "":
+			if !slices.Contains(Collections, l.Collection) {
+				return stats, fmt.Errorf("backup line %d: unknown collection %q", n, l.Collection)
+			}
+			var doc bson.D
+			if err := bson.UnmarshalExtJSON(l.Document, true, &doc); err != nil {
+				return stats, fmt.Errorf("backup line %d: %w", n, err)
+			}
+			batches[l.Collection] = append(batches[l.Collection], doc)
+			if len(batches[l.Collection]) >= importBatchSize {
+				if err := flush(l.Collection); err != nil {
+					return stats, err
+				}
+			}
+		case l.Blob != "":
+			digest, _, err := b.blobs.Put(ctx, bytes.NewReader(l.Data), int64(len(l.Data)))
+			if err != nil {
+				return stats, fmt.Errorf("import blob %s: %w", l.Blob, err)
+			}
+			// The restored documents refer to the content, so it needs no hold.
+			b.blobs.Release(digest)
+			if digest != l.Blob {
+				return stats, fmt.Errorf("backup line %d: blob content does not match digest %s", n, l.Blob)
+			}
+			stats.Blobs++
+		default:
+			return stats, fmt.Errorf("backup line %d: neither a document nor a blob", n)
+		}
+	}
+	for _, coll := range Collections {
+		if err := flush(coll); err != nil {
+			return stats, err
+		}
+	}
+	return stats, nil
+}
+
+// prepareImport empties the collections when replace is set, and otherwise checks that they are empty.
+func (b *Backup) prepareImport(ctx context.Context, replace bool) error {
+	for _, coll := range Collections {
+		c := b.db.Collection(coll)
+		if replace {
+			if _, err := c.DeleteMany(ctx, bson.M{}); err != nil {
+				return fmt.Errorf("empty %s: %w", coll, err)
+			}
+			continue
+		}
+		n, err := c.CountDocuments(ctx, bson.M{}, options.Count().SetLimit(1))
+		if err != nil {
+			return fmt.Errorf("count %s: %w", coll, err)
+		}
+		if n > 0 {
+			return fmt.Errorf("%w (%s has documents)", ErrBackupTargetNotEmpty, coll)
+		}
+	}
+	return nil
+}
+
+// readLine reads one line, without its newline. It returns io.EOF only when no data is left.
+func readLine(br *bufio.Reader) ([]byte, error) {
+	line, err := br.ReadBytes('\n')
+	if len(line) > 0 && errors.Is(err, io.EOF) {
+		err = nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	line = bytes.TrimRight(line, "\r\n")
+	if len(line) == 0 {
+		return readLine(br)
+	}
+	return line, nil
+}
@@ -0,0 +1,124 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"strings"
+	"task_manager_test/internal/domain"
+	"task_manager_test/internal/service"
+	"testing"
+	"time"
+
+	"github.com/joho/godotenv"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// BackupTestSuite exports a database and imports it into a second one.
+type BackupTestSuite struct {
+	suite.Suite
+	client *mongo.Client
+	source *mongo.Database
+	target *mongo.Database
+}
+
+// SetupSuite connects to the test database, skipping the suite when none is configured.
+func (s *BackupTestSuite) SetupSuite() {
+	if err := godotenv.Load("../../.env"); err != nil {
+		s.T().Log("No .env file found, proceeding with environment variables")
+	}
+	uri := os.Getenv("MONGODB_URI_TEST")
+	if uri == "" {
+		s.T().Skip("MONGODB_URI_TEST environment variable not set, skipping integration tests")
+	}
+	client, err := mongo.Connect(context.Background(), options.Client().ApplyURI(uri))
+	assert.NoError(s.T(), err, "Failed to connect to MongoDB")
+	s.client = client
+	s.source = client.Database("backupdb_source_test")
+	s.target = client.Database("backupdb_target_test")
+}
+
+// TearDownSuite disconnects from MongoDB.
+func (s *BackupTestSuite) TearDownSuite() {
+	if s.client != nil {
+		assert.NoError(s.T(), s.client.Disconnect(context.Background()))
+	}
+}
+
+// TearDownTest drops both databases.
+func (s *BackupTestSuite) TearDownTest() {
+	assert.NoError(s.T(), s.source.Drop(context.Background()))
+	assert.NoError(s.T(), s.target.Drop(context.Background()))
+}
+
+// TestBackupSuite runs the entire test suite.
+func TestBackupSuite(t *testing.T) {
+	suite.Run(t, new(BackupTestSuite))
+}
+
+// TestExportImport_RoundTrip verifies that documents and attachment contents survive an export and import.
+func (s *BackupTestSuite) TestExportImport_RoundTrip() {
+	// ARRANGE
+	ctx := testTenant()
+	sourceBlobs, err := service.NewLocalBlobStore(s.T().TempDir())
+	s.Require().NoError(err)
+	targetBlobs, err := service.NewLocalBlobStore(s.T().TempDir())
+	s.Require().NoError(err)
+	user, err := NewMongoUserRepository(s.source).Create(ctx, domain.User{Username: "alice", Password: "hash", Role: domain.RoleAdmin})
+	s.Require().NoError(err)
+	digest, size, err := sourceBlobs.Put(ctx, strings.NewReader("report"), 1024)
+	s.Require().NoError(err)
+	tasks := NewMongoTaskRepository(s.source)
+	task, err := tasks.Create(ctx, domain.Task{Title: "T", DueDate: time.Now().UTC().Truncate(time.Millisecond), Status: "pending", OwnerID: user.ID})
+	s.Require().NoError(err)
+	_, err = tasks.AddAttachment(ctx, task.ID, domain.Attachment{ID: "a1", Filename: "r.txt", Size: size, Digest: digest})
+	s.Require().NoError(err)
+
+	// ACT
+	var buf bytes.Buffer
+	exported, exportErr := NewBackup(s.source, sourceBlobs).Export(ctx, &buf)
+	imported, importErr := NewBackup(s.target, targetBlobs).Import(ctx, bytes.NewReader(buf.Bytes()), false)
+
+	// ASSERT
+	s.Require().NoError(exportErr)
+	s.Require().NoError(importErr)
+	s.Equal(exported, imported)
+	s.Equal(1, imported.Documents["users"])
+	s.Equal(1, imported.Blobs)
+	restored, err := NewMongoTaskRepository(s.target).GetByID(ctx, task.ID)
+	s.Require().NoError(err)
+	s.Equal(digest, restored.Attachments[0].Digest)
+	rc, err := targetBlobs.Open(ctx, digest)
+	s.Require().NoError(err)
+	rc.Close()
+}
+
+// TestImport_Fails_When_TargetIsNotEmpty verifies that imports only overwrite data when asked to.
+func (s *BackupTestSuite) TestImport_Fails_When_TargetIsNotEmpty() {
+	// ARRANGE
+	ctx := testTenant()
+	blobs, err := service.NewLocalBlobStore(s.T().TempDir())
+	s.Require().NoError(err)
+	_, err = NewMongoUserRepository(s.source).Create(ctx, domain.User{Username: "alice", Password: "hash", Role: domain.RoleAdmin})
+	s.Require().NoError(err)
+	_, err = NewMongoUserRepository(s.target).Create(ctx, domain.User{Username: "bob", Password: "hash", Role: domain.RoleMember})
+	s.Require().NoError(err)
+	var buf bytes.Buffer
+	_, err = NewBackup(s.source, blobs).Export(ctx, &buf)
+	s.Require().NoError(err)
+
+	// ACT
+	_, keepErr := NewBackup(s.target, blobs).Import(ctx, bytes.NewReader(buf.Bytes()), false)
+	_, replaceErr := NewBackup(s.target, blobs).Import(ctx, bytes.NewReader(buf.Bytes()), true)
+
+	// ASSERT
+	s.ErrorIs(keepErr, ErrBackupTargetNotEmpty)
+	s.NoError(replaceErr)
+	users, err := NewMongoUserRepository(s.target).List(ctx)
+	s.Require().NoError(err)
+	s.Require().Len(users, 1)
+	s.Equal("alice", users[0].Username)
+}
@@ -0,0 +1,211 @@
+package repository
+
+import (
+	"context"
+	"slices"
+	"strconv"
+	"sync"
+	"task_manager_test/internal/domain"
+	"task_manager_test/internal/usecase"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// CachedTaskRepository is a read-through cache in front of another task repository. Tasks read by ID are kept in
+// a size-bounded LRU for a fixed TTL, and every write through the cache invalidates the tasks it touches.
+// Concurrent misses for the same task are collapsed into a single load.
+//
+// Entries remember the organization they were loaded for and are only served within it; cross-tenant reads bypass
+// the cache. Writes made by other processes, such as other server instances or the admin CLI, are only observed
+// once the TTL has elapsed.
+type CachedTaskRepository struct {
+	// ITaskRepository serves the operations the cache does not intercept. Those are list queries, which are not
+	// cached, and Create, which cannot make a cached task stale.
+	usecase.ITaskRepository
+
+	ttl   time.Duration
+	loads singleflight.Group
+	now   func() time.Time
+
+	mu      sync.Mutex
+	entries *lru[string, cachedTask]
+	// epoch is advanced by every invalidation; loads that began in an earlier epoch may have read data the
+	// invalidation made stale, so their results are not stored.
+	epoch     uint64
+	hits      uint64
+	misses    uint64
+	evictions uint64
+}
+
+// Add a compile-time check to ensure this struct implements the correct interfaces.
+var (
+	_ usecase.ITaskRepository     = (*CachedTaskRepository)(nil)
+	_ usecase.ICacheStatsReporter = (*CachedTaskRepository)(nil)
+)
+
+// cachedTask is a task together with the organization it was read in.
+type cachedTask struct {
+	task  domain.Task
+	orgID string
+}
+
+// NewCachedTaskRepository wraps next with a cache holding at most size tasks, each for at most ttl.
+func NewCachedTaskRepository(next usecase.ITaskRepository, size int, ttl time.Duration) *CachedTaskRepository {
+	return &CachedTaskRepository{
+		ITaskRepository: next,
+		ttl:             ttl,
+		now:             time.Now,
+		entries:         newLRU[string, cachedTask](size),
+	}
+}
+
+// Stats returns the cache's hit and miss counters and its current occupancy.
+func (r *CachedTaskRepository) Stats() usecase.CacheStats {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return usecase.CacheStats{
+		Hits:      r.hits,
+		Misses:    r.misses,
+		Evictions: r.evictions,
+		Entries:   r.entries.len(),
+		Capacity:  r.entries.capacity,
+	}
+}
+
+// GetByID returns the cached task if it was read in the same organization within the TTL, and otherwise loads it,
+// sharing the load with concurrent callers asking for the same task.
+func (r *CachedTaskRepository) GetByID(ctx context.Context, id string) (domain.Task, error) {
+	tenant, ok := usecase.TenantFromContext(ctx)
+	if !ok || tenant.All {
+		return r.ITaskRepository.GetByID(ctx, id)
+	}
+
+	r.mu.Lock()
+	if entry, ok := r.entries.get(id, r.now()); ok && entry.orgID == tenant.OrgID {
+		r.hits++
+		r.mu.Unlock()
+		return cloneTask(entry.task), nil
+	}
+	r.misses++
+	epoch := r.epoch
+	r.mu.Unlock()
+
+	// The epoch is part of the key so that callers arriving after an invalidation do not join a load that
+	// started before it.
+	key := tenant.OrgID + "/" + id + "/" + strconv.FormatUint(epoch, 10)
+	loaded := r.loads.DoChan(key, func() (any, error) {
+		// The load is shared, so it must not be cancelled with the caller that happened to start it.
+		t, err := r.ITaskRepository.GetByID(context.WithoutCancel(ctx), id)
+		if err == nil {
+			r.store(id, cachedTask{task: t, orgID: tenant.OrgID}, epoch)
+		}
+		return t, err
+	})
+	select {
+	case res := <-loaded:
+		if res.Err != nil {
+			return domain.Task{}, res.Err
+		}
+		return cloneTask(res.Val.(domain.Task)), nil
+	case <-ctx.Done():
+		return domain.Task{}, ctx.Err()
+	}
+}
+
+// Update updates the task and drops it from the cache.
+func (r *CachedTaskRepository) Update(ctx context.Context, t domain.Task) (domain.Task, error) {
+	defer r.invalidate(t.ID)
+	return r.ITaskRepository.Update(ctx, t)
+}
+
+// Delete deletes the task and drops it from the cache.
+func (r *CachedTaskRepository) Delete(ctx context.Context, id string) error {
+	defer r.invalidate(id)
+	return r.ITaskRepository.Delete(ctx, id)
+}
+
+// AddAttachment adds the attachment and drops the task from the cache.
+func (r *CachedTaskRepository) AddAttachment(ctx context.Context, taskID string, a domain.Attachment) (domain.Attachment, error) {
+	defer r.invalidate(taskID)
+	return r.ITaskRepository.AddAttachment(ctx, taskID, a)
+}
+
+// RemoveAttachment removes the attachment and drops the task from the cache.
+func (r *CachedTaskRepository) RemoveAttachment(ctx context.Context, taskID, attachmentID string) error {
+	defer r.invalidate(taskID)
+	return r.ITaskRepository.RemoveAttachment(ctx, taskID, attachmentID)
+}
+
+// DeleteByProject deletes the project's tasks and drops them from the cache.
+func (r *CachedTaskRepository) DeleteByProject(ctx context.Context, projectID string) error {
+	defer r.invalidateProject(projectID)
+	return r.ITaskRepository.DeleteByProject(ctx, projectID)
+}
+
+// ArchiveByProject archives the project's tasks and drops them from the cache.
+func (r *CachedTaskRepository) ArchiveByProject(ctx context.Context, projectID string, at time.Time) error {
+	defer r.invalidateProject(projectID)
+	return r.ITaskRepository.ArchiveByProject(ctx, projectID, at)
+}
+
+// Accounts wraps an account repository so that deleting an account empties the cache. Account deletions touch
+// tasks the cache cannot identify, such as those archived with the user's projects, and they are rare.
+func (r *CachedTaskRepository) Accounts(next usecase.IAccountRepository) usecase.IAccountRepository {
+	return &cacheInvalidatingAccountRepository{IAccountRepository: next, cache: r}
+}
+
+// cacheInvalidatingAccountRepository is an account repository that empties a task cache on every deletion.
+type cacheInvalidatingAccountRepository struct {
+	usecase.IAccountRepository
+	cache *CachedTaskRepository
+}
+
+// DeleteAccount deletes the account and empties the cache.
+func (a *cacheInvalidatingAccountRepository) DeleteAccount(ctx context.Context, userID string, entry domain.AuditEntry) ([]domain.Attachment, error) {
+	defer a.cache.invalidateAll()
+	return a.IAccountRepository.DeleteAccount(ctx, userID, entry)
+}
+
+// store caches the task loaded in the given epoch, unless it has been invalidated since.
+func (r *CachedTaskRepository) store(id string, entry cachedTask, epoch uint64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if epoch != r.epoch {
+		return
+	}
+	if r.entries.add(id, entry, r.now().Add(r.ttl)) {
+		r.evictions++
+	}
+}
+
+// invalidate drops the task from the cache. It runs after the write whether or not it failed, since a failed
+// write may still have been applied.
+func (r *CachedTaskRepository) invalidate(id string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.epoch++
+	r.entries.remove(id)
+}
+
+// invalidateProject drops every cached task of the project.
+func (r *CachedTaskRepository) invalidateProject(projectID string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.epoch++
+	r.entries.removeFunc(func(e cachedTask) bool { return e.task.ProjectID == projectID })
+}
+
+// invalidateAll empties the cache.
+func (r *CachedTaskRepository) invalidateAll() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.epoch++
+	r.entries.removeFunc(func(cachedTask) bool { return true })
+}
+
+// cloneTask copies the task so that callers cannot modify the cached attachments.
+func cloneTask(t domain.Task) domain.Task {
+	t.Attachments = slices.Clone(t.Attachments)
+	return t
+}
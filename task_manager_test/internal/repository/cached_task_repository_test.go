@@ -0,0 +1,262 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"task_manager_test/internal/domain"
+	"task_manager_test/internal/mocks"
+	"task_manager_test/internal/usecase"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+// CachedTaskRepositoryTestSuite defines the test suite for the task cache, run against a mocked repository.
+type CachedTaskRepositoryTestSuite struct {
+	suite.Suite
+	next  *mocks.ITaskRepository
+	cache *CachedTaskRepository
+	now   time.Time
+	ctx   context.Context
+}
+
+// SetupTest runs before EACH test in the suite.
+func (s *CachedTaskRepositoryTestSuite) SetupTest() {
+	s.next = mocks.NewITaskRepository(s.T())
+	s.cache = NewCachedTaskRepository(s.next, 2, time.Minute)
+	s.now = time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	s.cache.now = func() time.Time { return s.now }
+	s.ctx = testTenant()
+}
+
+// TestCachedTaskRepositoryTestSuite is the Go test runner's entry point for this suite.
+func TestCachedTaskRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(CachedTaskRepositoryTestSuite))
+}
+
+// expectLoad makes the wrapped repository return the task the given number of times.
+func (s *CachedTaskRepositoryTestSuite) expectLoad(t domain.Task, times int) {
+	s.next.On("GetByID", mock.Anything, t.ID).Return(t, nil).Times(times)
+}
+
+// inOrg matches contexts confined to the organization.
+func inOrg(orgID string) any {
+	return mock.MatchedBy(func(ctx context.Context) bool {
+		scope, ok := usecase.TenantFromContext(ctx)
+		return ok && !scope.All && scope.OrgID == orgID
+	})
+}
+
+// TestGetByID_ServesRepeatedReadsFromCache tests that a task is loaded once and then served from memory.
+func (s *CachedTaskRepositoryTestSuite) TestGetByID_ServesRepeatedReadsFromCache() {
+	task := domain.Task{ID: "t1", Title: "Write report", Attachments: []domain.Attachment{{ID: "a1"}}}
+	s.expectLoad(task, 1)
+
+	first, err := s.cache.GetByID(s.ctx, "t1")
+	s.NoError(err)
+	second, err := s.cache.GetByID(s.ctx, "t1")
+	s.NoError(err)
+
+	s.Equal(task, first)
+	s.Equal(task, second)
+	s.Equal(usecase.CacheStats{Hits: 1, Misses: 1, Entries: 1, Capacity: 2}, s.cache.Stats())
+
+	second.Attachments[0].ID = "changed"
+	third, _ := s.cache.GetByID(s.ctx, "t1")
+	s.Equal("a1", third.Attachments[0].ID, "Callers must not be able to modify the cached task")
+}
+
+// TestGetByID_DoesNotCacheErrors tests that failed loads, including missing tasks, are retried.
+func (s *CachedTaskRepositoryTestSuite) TestGetByID_DoesNotCacheErrors() {
+	s.next.On("GetByID", mock.Anything, "t1").Return(domain.Task{}, usecase.ErrNotFound).Twice()
+
+	_, err := s.cache.GetByID(s.ctx, "t1")
+	s.ErrorIs(err, usecase.ErrNotFound)
+	_, err = s.cache.GetByID(s.ctx, "t1")
+	s.ErrorIs(err, usecase.ErrNotFound)
+
+	s.Equal(0, s.cache.Stats().Entries)
+}
+
+// TestGetByID_ExpiresAfterTTL tests that entries are reloaded once their TTL has elapsed.
+func (s *CachedTaskRepositoryTestSuite) TestGetByID_ExpiresAfterTTL() {
+	s.expectLoad(domain.Task{ID: "t1"}, 2)
+
+	_, _ = s.cache.GetByID(s.ctx, "t1")
+	s.now = s.now.Add(59 * time.Second)
+	_, _ = s.cache.GetByID(s.ctx, "t1")
+	s.now = s.now.Add(time.Second)
+	_, _ = s.cache.GetByID(s.ctx, "t1")
+
+	s.Equal(uint64(1), s.cache.Stats().Hits)
+	s.Equal(uint64(2), s.cache.Stats().Misses)
+}
+
+// TestGetByID_EvictsLeastRecentlyUsed tests that the cache never grows beyond its size.
+func (s *CachedTaskRepositoryTestSuite) TestGetByID_EvictsLeastRecentlyUsed() {
+	s.expectLoad(domain.Task{ID: "t1"}, 1)
+	s.expectLoad(domain.Task{ID: "t2"}, 2)
+	s.expectLoad(domain.Task{ID: "t3"}, 1)
+
+	_, _ = s.cache.GetByID(s.ctx, "t1")
+	_, _ = s.cache.GetByID(s.ctx, "t2")
+	_, _ = s.cache.GetByID(s.ctx, "t1") // t2 is now the least recently used
+	_, _ = s.cache.GetByID(s.ctx, "t3")
+	_, _ = s.cache.GetByID(s.ctx, "t1")
+	_, _ = s.cache.GetByID(s.ctx, "t2")
+
+	stats := s.cache.Stats()
+	s.Equal(2, stats.Entries)
+	s.Equal(uint64(2), stats.Evictions)
+	s.Equal(uint64(2), stats.Hits)
+}
+
+// TestGetByID_IsolatesTenants tests that a task cached for one organization is not served to another, and that
+// cross-tenant reads bypass the cache.
+func (s *CachedTaskRepositoryTestSuite) TestGetByID_IsolatesTenants() {
+	other := usecase.WithTenant(context.Background(), "org-2")
+	all := usecase.WithAllTenants(context.Background())
+	s.next.On("GetByID", inOrg("org-1"), "t1").Return(domain.Task{ID: "t1"}, nil).Once()
+	s.next.On("GetByID", inOrg("org-2"), "t1").Return(domain.Task{}, usecase.ErrNotFound).Once()
+	s.next.On("GetByID", all, "t1").Return(domain.Task{ID: "t1"}, nil).Once()
+
+	_, err := s.cache.GetByID(s.ctx, "t1")
+	s.NoError(err)
+	_, err = s.cache.GetByID(other, "t1")
+	s.ErrorIs(err, usecase.ErrNotFound)
+	_, err = s.cache.GetByID(all, "t1")
+	s.NoError(err)
+
+	s.Equal(uint64(0), s.cache.Stats().Hits)
+}
+
+// TestWrites_InvalidateTheTask tests that every write through the cache drops the tasks it touches.
+func (s *CachedTaskRepositoryTestSuite) TestWrites_InvalidateTheTask() {
+	writes := map[string]func(){
+		"Update": func() {
+			s.next.On("Update", s.ctx, mock.Anything).Return(domain.Task{}, nil).Once()
+			_, _ = s.cache.Update(s.ctx, domain.Task{ID: "t1"})
+		},
+		"Delete": func() {
+			s.next.On("Delete", s.ctx, "t1").Return(nil).Once()
+			_ = s.cache.Delete(s.ctx, "t1")
+		},
+		"AddAttachment": func() {
+			s.next.On("AddAttachment", s.ctx, "t1", mock.Anything).Return(domain.Attachment{}, nil).Once()
+			_, _ = s.cache.AddAttachment(s.ctx, "t1", domain.Attachment{})
+		},
+		"RemoveAttachment": func() {
+			s.next.On("RemoveAttachment", s.ctx, "t1", "a1").Return(errors.New("write failed")).Once()
+			_ = s.cache.RemoveAttachment(s.ctx, "t1", "a1")
+		},
+		"DeleteByProject": func() {
+			s.next.On("DeleteByProject", s.ctx, "p1").Return(nil).Once()
+			_ = s.cache.DeleteByProject(s.ctx, "p1")
+		},
+		"ArchiveByProject": func() {
+			s.next.On("ArchiveByProject", s.ctx, "p1", s.now).Return(nil).Once()
+			_ = s.cache.ArchiveByProject(s.ctx, "p1", s.now)
+		},
+	}
+	for name, write := range writes {
+		s.next.On("GetByID", mock.Anything, "t1").Return(domain.Task{ID: "t1", ProjectID: "p1"}, nil).Once()
+		_, _ = s.cache.GetByID(s.ctx, "t1")
+
+		write()
+
+		s.Equal(0, s.cache.Stats().Entries, "%s should invalidate the cached task", name)
+	}
+}
+
+// TestDeleteByProject_KeepsOtherTasks tests that project writes only drop the project's tasks.
+func (s *CachedTaskRepositoryTestSuite) TestDeleteByProject_KeepsOtherTasks() {
+	s.expectLoad(domain.Task{ID: "t1", ProjectID: "p1"}, 1)
+	s.expectLoad(domain.Task{ID: "t2", ProjectID: "p2"}, 1)
+	s.next.On("DeleteByProject", s.ctx, "p1").Return(nil)
+
+	_, _ = s.cache.GetByID(s.ctx, "t1")
+	_, _ = s.cache.GetByID(s.ctx, "t2")
+	s.NoError(s.cache.DeleteByProject(s.ctx, "p1"))
+
+	s.Equal(1, s.cache.Stats().Entries)
+	_, _ = s.cache.GetByID(s.ctx, "t2")
+	s.Equal(uint64(1), s.cache.Stats().Hits)
+}
+
+// TestAccounts_DeletionEmptiesTheCache tests that deleting an account through the wrapped repository drops every task.
+func (s *CachedTaskRepositoryTestSuite) TestAccounts_DeletionEmptiesTheCache() {
+	accounts := mocks.NewIAccountRepository(s.T())
+	entry := domain.AuditEntry{Action: domain.AuditAccountDeleted}
+	accounts.On("DeleteAccount", s.ctx, "user-1", entry).Return(nil, nil)
+	s.expectLoad(domain.Task{ID: "t1", OwnerID: "user-1"}, 2)
+	s.expectLoad(domain.Task{ID: "t2", ProjectID: "p1"}, 1)
+
+	_, _ = s.cache.GetByID(s.ctx, "t1")
+	_, _ = s.cache.GetByID(s.ctx, "t2")
+	_, err := s.cache.Accounts(accounts).DeleteAccount(s.ctx, "user-1", entry)
+
+	s.NoError(err)
+	s.Zero(s.cache.Stats().Entries)
+	_, _ = s.cache.GetByID(s.ctx, "t1")
+	s.Zero(s.cache.Stats().Hits)
+}
+
+// TestGetByID_CollapsesConcurrentMisses tests that concurrent readers of the same task share a single load.
+func (s *CachedTaskRepositoryTestSuite) TestGetByID_CollapsesConcurrentMisses() {
+	release := make(chan time.Time)
+	s.next.On("GetByID", mock.Anything, "t1").WaitUntil(release).Return(domain.Task{ID: "t1"}, nil).Once()
+
+	const readers = 10
+	var wg sync.WaitGroup
+	for range readers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			t, err := s.cache.GetByID(s.ctx, "t1")
+			s.NoError(err)
+			s.Equal("t1", t.ID)
+		}()
+	}
+	s.Eventually(func() bool { return s.cache.Stats().Misses == readers }, time.Second, time.Millisecond)
+	close(release)
+	wg.Wait()
+}
+
+// TestGetByID_ReturnsWhenCallerGivesUp tests that a cancelled caller does not wait for the load it started, and that
+// the load still completes for the benefit of later callers.
+func (s *CachedTaskRepositoryTestSuite) TestGetByID_ReturnsWhenCallerGivesUp() {
+	release := make(chan time.Time)
+	s.next.On("GetByID", mock.Anything, "t1").WaitUntil(release).Return(domain.Task{ID: "t1"}, nil).Once()
+	ctx, cancel := context.WithCancel(s.ctx)
+	cancel()
+
+	_, err := s.cache.GetByID(ctx, "t1")
+
+	s.ErrorIs(err, context.Canceled)
+	close(release)
+	s.Eventually(func() bool { return s.cache.Stats().Entries == 1 }, time.Second, time.Millisecond)
+}
+
+// TestGetByID_DiscardsLoadsOverlappingAWrite tests that a load started before an invalidation is not cached, so
+// that readers never see the task as it was before a completed write.
+func (s *CachedTaskRepositoryTestSuite) TestGetByID_DiscardsLoadsOverlappingAWrite() {
+	release := make(chan time.Time)
+	s.next.On("GetByID", mock.Anything, "t1").WaitUntil(release).Return(domain.Task{ID: "t1", Title: "old"}, nil).Once()
+	s.next.On("Update", s.ctx, mock.Anything).Return(domain.Task{}, nil).Once()
+
+	loaded := make(chan domain.Task)
+	go func() {
+		t, _ := s.cache.GetByID(s.ctx, "t1")
+		loaded <- t
+	}()
+	s.Eventually(func() bool { return s.cache.Stats().Misses == 1 }, time.Second, time.Millisecond)
+	_, _ = s.cache.Update(s.ctx, domain.Task{ID: "t1", Title: "new"})
+	close(release)
+
+	assert.Equal(s.T(), "old", (<-loaded).Title)
+	s.Equal(0, s.cache.Stats().Entries, "The stale load must not be cached")
+}
@@ -0,0 +1,200 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"task_manager_test/internal/domain"
+	"task_manager_test/internal/usecase"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// mongoCommentRepository is the MongoDB-based implementation of the ICommentRepository interface.
+type mongoCommentRepository struct {
+	collection *tenantCollection
+}
+
+// Add a compile-time check to ensure this struct implements the correct interface.
+var _ usecase.ICommentRepository = (*mongoCommentRepository)(nil)
+
+// NewMongoCommentRepository is the constructor for the implementation.
+func NewMongoCommentRepository(db *mongo.Database) usecase.ICommentRepository {
+	return &mongoCommentRepository{
+		collection: newTenantCollection(db.Collection("comments")),
+	}
+}
+
+// commentMentionRecord is the BSON shape of a mention embedded in a comment document.
+type commentMentionRecord struct {
+	UserID   string `bson:"user_id"`
+	Username string `bson:"username"`
+}
+
+// commentRecord is the BSON shape of a comment document.
+type commentRecord struct {
+	ID             primitive.ObjectID     `bson:"_id"`
+	TaskID         string                 `bson:"task_id"`
+	ParentID       string                 `bson:"parent_id,omitempty"`
+	AuthorID       string                 `bson:"author_id"`
+	AuthorUsername string                 `bson:"author_username"`
+	Body           string                 `bson:"body"`
+	Mentions       []commentMentionRecord `bson:"mentions,omitempty"`
+	CreatedAt      time.Time              `bson:"created_at"`
+	EditedAt       time.Time              `bson:"edited_at,omitempty"`
+}
+
+// toDomain converts the stored document into a domain.Comment.
+func (r commentRecord) toDomain() domain.Comment {
+	var mentions []domain.CommentMention
+	for _, m := range r.Mentions {
+		mentions = append(mentions, domain.CommentMention{UserID: m.UserID, Username: m.Username})
+	}
+	return domain.Comment{
+		ID:             r.ID.Hex(),
+		TaskID:         r.TaskID,
+		ParentID:       r.ParentID,
+		AuthorID:       r.AuthorID,
+		AuthorUsername: r.AuthorUsername,
+		Body:           r.Body,
+		Mentions:       mentions,
+		CreatedAt:      r.CreatedAt,
+		EditedAt:       r.EditedAt,
+	}
+}
+
+// mentionRecords converts mentions into their stored form.
+func mentionRecords(mentions []domain.CommentMention) []commentMentionRecord {
+	var recs []commentMentionRecord
+	for _, m := range mentions {
+		recs = append(recs, commentMentionRecord{UserID: m.UserID, Username: m.Username})
+	}
+	return recs
+}
+
+// Create inserts a new comment document, generating a new unique ID.
+func (r *mongoCommentRepository) Create(ctx context.Context, c domain.Comment) (domain.Comment, error) {
+	rec := commentRecord{
+		ID:             primitive.NewObjectID(),
+		TaskID:         c.TaskID,
+		ParentID:       c.ParentID,
+		AuthorID:       c.AuthorID,
+		AuthorUsername: c.AuthorUsername,
+		Body:           c.Body,
+		Mentions:       mentionRecords(c.Mentions),
+		CreatedAt:      c.CreatedAt,
+	}
+	if _, err := r.collection.InsertOne(ctx, rec); err != nil {
+		return domain.Comment{}, err
+	}
+	return rec.toDomain(), nil
+}
+
+// GetByID fetches a comment by its hexadecimal string ID.
+func (r *mongoCommentRepository) GetByID(ctx context.Context, id string) (domain.Comment, error) {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return domain.Comment{}, usecase.ErrInvalidID
+	}
+	var rec commentRecord
+	if err := r.collection.FindOne(ctx, bson.M{"_id": oid}).Decode(&rec); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return domain.Comment{}, usecase.ErrNotFound
+		}
+		return domain.Comment{}, err
+	}
+	return rec.toDomain(), nil
+}
+
+// ListTopLevel returns a page of the task's top-level comments, oldest first, and the number of top-level comments.
+func (r *mongoCommentRepository) ListTopLevel(ctx context.Context, taskID string, skip, limit int) ([]domain.Comment, int64, error) {
+	filter := bson.M{"task_id": taskID, "parent_id": bson.M{"$exists": false}}
+	total, err := r.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+	comments, err := r.find(ctx, filter, options.Find().SetSkip(int64(skip)).SetLimit(int64(limit)))
+	if err != nil {
+		return nil, 0, err
+	}
+	return comments, total, nil
+}
+
+// ListReplies returns the replies to the given comments, oldest first.
+func (r *mongoCommentRepository) ListReplies(ctx context.Context, parentIDs []string) ([]domain.Comment, error) {
+	return r.find(ctx, bson.M{"parent_id": bson.M{"$in": parentIDs}}, options.Find())
+}
+
+// ListByAuthor returns every comment written by the user, oldest first.
+func (r *mongoCommentRepository) ListByAuthor(ctx context.Context, authorID string) ([]domain.Comment, error) {
+	return r.find(ctx, bson.M{"author_id": authorID}, options.Find())
+}
+
+// find decodes every comment document matching the filter in the order they were posted.
+func (r *mongoCommentRepository) find(ctx context.Context, filter bson.M, opts *options.FindOptions) ([]domain.Comment, error) {
+	opts.SetSort(bson.D{{Key: "created_at", Value: 1}, {Key: "_id", Value: 1}})
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var recs []commentRecord
+	if err := cursor.All(ctx, &recs); err != nil {
+		return nil, err
+	}
+	comments := make([]domain.Comment, len(recs))
+	for i, rec := range recs {
+		comments[i] = rec.toDomain()
+	}
+	return comments, nil
+}
+
+// Update stores a new body, mentions and edit time for the comment.
+func (r *mongoCommentRepository) Update(ctx context.Context, c domain.Comment) (domain.Comment, error) {
+	oid, err := primitive.ObjectIDFromHex(c.ID)
+	if err != nil {
+		return domain.Comment{}, usecase.ErrInvalidID
+	}
+	var rec commentRecord
+	err = r.collection.FindOneAndUpdate(ctx, bson.M{"_id": oid},
+		bson.M{"$set": bson.M{"body": c.Body, "mentions": mentionRecords(c.Mentions), "edited_at": c.EditedAt}},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(&rec)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return domain.Comment{}, usecase.ErrNotFound
+		}
+		return domain.Comment{}, err
+	}
+	return rec.toDomain(), nil
+}
+
+// Delete removes the comment and its replies, returning ErrNotFound if the comment does not exist.
+func (r *mongoCommentRepository) Delete(ctx context.Context, id string) error {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return usecase.ErrInvalidID
+	}
+	res, err := r.collection.DeleteOne(ctx, bson.M{"_id": oid})
+	if err != nil {
+		return err
+	}
+	if res.DeletedCount == 0 {
+		return usecase.ErrNotFound
+	}
+	_, err = r.collection.DeleteMany(ctx, bson.M{"parent_id": id})
+	return err
+}
+
+// DeleteByTasks removes every comment on the given tasks.
+func (r *mongoCommentRepository) DeleteByTasks(ctx context.Context, taskIDs []string) error {
+	if len(taskIDs) == 0 {
+		return nil
+	}
+	_, err := r.collection.DeleteMany(ctx, bson.M{"task_id": bson.M{"$in": taskIDs}})
+	return err
+}
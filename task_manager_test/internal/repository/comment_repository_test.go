@@ -0,0 +1,177 @@
+package repository
+
+import (
+	"context"
+	"os"
+	"task_manager_test/internal/domain"
+	"task_manager_test/internal/usecase"
+	"testing"
+	"time"
+
+	"github.com/joho/godotenv"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// CommentRepositoryTestSuite defines the integration test suite for the comment repository.
+type CommentRepositoryTestSuite struct {
+	suite.Suite
+	client     *mongo.Client
+	db         *mongo.Database
+	collection *mongo.Collection
+	repository usecase.ICommentRepository
+}
+
+// SetupSuite connects to the dedicated test database, skipping the suite when it is not configured.
+func (s *CommentRepositoryTestSuite) SetupSuite() {
+	if err := godotenv.Load("../../.env"); err != nil {
+		s.T().Log("No .env file found, proceeding with environment variables")
+	}
+
+	uri := os.Getenv("MONGODB_URI_TEST")
+	if uri == "" {
+		s.T().Skip("MONGODB_URI_TEST environment variable not set, skipping integration tests")
+	}
+
+	client, err := mongo.Connect(context.Background(), options.Client().ApplyURI(uri))
+	assert.NoError(s.T(), err, "Failed to connect to MongoDB")
+
+	s.client = client
+	s.db = client.Database("commentdb_test")
+	s.collection = s.db.Collection("comments_test")
+}
+
+// TearDownSuite drops the test database and disconnects.
+func (s *CommentRepositoryTestSuite) TearDownSuite() {
+	if s.client != nil {
+		assert.NoError(s.T(), s.db.Drop(context.Background()), "Failed to drop test database")
+		assert.NoError(s.T(), s.client.Disconnect(context.Background()), "Failed to disconnect from MongoDB")
+	}
+}
+
+// SetupTest instantiates a repository bound to the test collection.
+func (s *CommentRepositoryTestSuite) SetupTest() {
+	s.repository = NewMongoCommentRepository(s.db)
+	(s.repository.(*mongoCommentRepository)).collection = newTenantCollection(s.collection)
+}
+
+// TearDownTest drops the collection to isolate tests.
+func (s *CommentRepositoryTestSuite) TearDownTest() {
+	assert.NoError(s.T(), s.collection.Drop(context.Background()), "TearDownTest: failed to drop collection")
+}
+
+// TestCommentRepository is the entry point for the test suite.
+func TestCommentRepository(t *testing.T) {
+	suite.Run(t, new(CommentRepositoryTestSuite))
+}
+
+// post stores a comment on the task, posted the given number of minutes after a fixed time.
+func (s *CommentRepositoryTestSuite) post(taskID, parentID string, minute int) domain.Comment {
+	c, err := s.repository.Create(testTenant(), domain.Comment{
+		TaskID:    taskID,
+		ParentID:  parentID,
+		AuthorID:  "user-1",
+		Body:      "comment",
+		CreatedAt: time.Date(2025, 1, 1, 12, minute, 0, 0, time.UTC),
+	})
+	assert.NoError(s.T(), err, "Setup: failed to create comment")
+	return c
+}
+
+// TestListTopLevel_PagesOldestFirst verifies paging, ordering and that replies are not counted as threads.
+func (s *CommentRepositoryTestSuite) TestListTopLevel_PagesOldestFirst() {
+	// ARRANGE
+	ctx := testTenant()
+	first := s.post("task-1", "", 1)
+	second := s.post("task-1", "", 2)
+	third := s.post("task-1", "", 3)
+	s.post("task-1", first.ID, 4)
+	s.post("task-2", "", 5)
+
+	// ACT
+	page, total, err := s.repository.ListTopLevel(ctx, "task-1", 1, 2)
+
+	// ASSERT
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), int64(3), total)
+	assert.Len(s.T(), page, 2)
+	assert.Equal(s.T(), second.ID, page[0].ID)
+	assert.Equal(s.T(), third.ID, page[1].ID)
+}
+
+// TestUpdate_StoresMentionsAndEditTime verifies that edits round-trip through the database.
+func (s *CommentRepositoryTestSuite) TestUpdate_StoresMentionsAndEditTime() {
+	// ARRANGE
+	ctx := testTenant()
+	c := s.post("task-1", "", 1)
+	c.Body = "edited @bob"
+	c.Mentions = []domain.CommentMention{{UserID: "user-2", Username: "bob"}}
+	c.EditedAt = time.Date(2025, 1, 1, 12, 5, 0, 0, time.UTC)
+
+	// ACT
+	_, err := s.repository.Update(ctx, c)
+
+	// ASSERT
+	assert.NoError(s.T(), err)
+	stored, err := s.repository.GetByID(ctx, c.ID)
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), c.Body, stored.Body)
+	assert.Equal(s.T(), c.Mentions, stored.Mentions)
+	assert.True(s.T(), c.EditedAt.Equal(stored.EditedAt))
+}
+
+// TestDelete_RemovesReplies verifies that deleting a thread removes its replies, and that the replies of other
+// threads are kept.
+func (s *CommentRepositoryTestSuite) TestDelete_RemovesReplies() {
+	// ARRANGE
+	ctx := testTenant()
+	doomed := s.post("task-1", "", 1)
+	kept := s.post("task-1", "", 2)
+	s.post("task-1", doomed.ID, 3)
+	keptReply := s.post("task-1", kept.ID, 4)
+
+	// ACT
+	err := s.repository.Delete(ctx, doomed.ID)
+
+	// ASSERT
+	assert.NoError(s.T(), err)
+	replies, err := s.repository.ListReplies(ctx, []string{doomed.ID, kept.ID})
+	assert.NoError(s.T(), err)
+	assert.Len(s.T(), replies, 1)
+	assert.Equal(s.T(), keptReply.ID, replies[0].ID)
+	assert.ErrorIs(s.T(), s.repository.Delete(ctx, doomed.ID), usecase.ErrNotFound)
+}
+
+// TestDeleteByTasks_RemovesEveryComment verifies cleanup when tasks are deleted.
+func (s *CommentRepositoryTestSuite) TestDeleteByTasks_RemovesEveryComment() {
+	ctx := testTenant()
+	top := s.post("task-1", "", 1)
+	s.post("task-1", top.ID, 2)
+	s.post("task-2", "", 3)
+
+	assert.NoError(s.T(), s.repository.DeleteByTasks(ctx, []string{"task-1"}))
+
+	_, total, err := s.repository.ListTopLevel(ctx, "task-1", 0, 10)
+	assert.NoError(s.T(), err)
+	assert.Zero(s.T(), total)
+	_, total, _ = s.repository.ListTopLevel(ctx, "task-2", 0, 10)
+	assert.Equal(s.T(), int64(1), total)
+}
+
+// TestListByAuthor_ReturnsOnlyTheAuthorsComments verifies that comments are selected by author, oldest first.
+func (s *CommentRepositoryTestSuite) TestListByAuthor_ReturnsOnlyTheAuthorsComments() {
+	ctx := testTenant()
+	first := s.post("task-1", "", 1)
+	second := s.post("task-2", first.ID, 2)
+	_, err := s.repository.Create(ctx, domain.Comment{TaskID: "task-1", AuthorID: "user-2", Body: "other", CreatedAt: time.Now()})
+	assert.NoError(s.T(), err, "Setup: failed to create comment")
+
+	comments, err := s.repository.ListByAuthor(ctx, "user-1")
+
+	assert.NoError(s.T(), err)
+	assert.Len(s.T(), comments, 2)
+	assert.Equal(s.T(), first.ID, comments[0].ID)
+	assert.Equal(s.T(), second.ID, comments[1].ID)
+}
@@ -0,0 +1,139 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"task_manager_test/internal/domain"
+	"task_manager_test/internal/usecase"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// mongoIdempotencyRepository is the MongoDB-based implementation of the IIdempotencyRepository interface.
+// Keys are scoped to globally unique user IDs, so the collection is not tenant-scoped. A unique index on the user
+// and key makes reservations atomic, and a TTL index deletes expired records.
+type mongoIdempotencyRepository struct {
+	collection *mongo.Collection
+}
+
+// Add a compile-time check to ensure this struct implements the correct interface.
+var _ usecase.IIdempotencyRepository = (*mongoIdempotencyRepository)(nil)
+
+// NewMongoIdempotencyRepository is the constructor for the implementation.
+func NewMongoIdempotencyRepository(db *mongo.Database) usecase.IIdempotencyRepository {
+	return &mongoIdempotencyRepository{
+		collection: db.Collection("idempotency_keys"),
+	}
+}
+
+// idempotencyRecord is the BSON shape of an idempotency_keys document.
+type idempotencyRecord struct {
+	ID          primitive.ObjectID  `bson:"_id"`
+	UserID      string              `bson:"user_id"`
+	Key         string              `bson:"key"`
+	RequestHash string              `bson:"request_hash"`
+	Completed   bool                `bson:"completed"`
+	Status      int                 `bson:"status,omitempty"`
+	Header      map[string][]string `bson:"header,omitempty"`
+	Body        []byte              `bson:"body,omitempty"`
+	CreatedAt   time.Time           `bson:"created_at"`
+	ExpiresAt   time.Time           `bson:"expires_at"`
+}
+
+// toDomain converts the document to its domain representation.
+func (rec idempotencyRecord) toDomain() domain.IdempotencyRecord {
+	return domain.IdempotencyRecord{
+		UserID:      rec.UserID,
+		Key:         rec.Key,
+		RequestHash: rec.RequestHash,
+		Completed:   rec.Completed,
+		Status:      rec.Status,
+		Header:      rec.Header,
+		Body:        rec.Body,
+		CreatedAt:   rec.CreatedAt,
+		ExpiresAt:   rec.ExpiresAt,
+	}
+}
+
+// Reserve inserts the record unless another one holds the user's key. MongoDB's TTL monitor only runs once a
+// minute, so an expired record still holding the key is deleted first.
+func (r *mongoIdempotencyRepository) Reserve(ctx context.Context, ir domain.IdempotencyRecord, now time.Time) (domain.IdempotencyRecord, bool, error) {
+	filter := bson.M{"user_id": ir.UserID, "key": ir.Key}
+	if _, err := r.collection.DeleteOne(ctx, bson.M{
+		"user_id": ir.UserID, "key": ir.Key, "expires_at": bson.M{"$lte": now},
+	}); err != nil {
+		return domain.IdempotencyRecord{}, false, err
+	}
+	_, err := r.collection.InsertOne(ctx, idempotencyRecord{
+		ID:          primitive.NewObjectID(),
+		UserID:      ir.UserID,
+		Key:         ir.Key,
+		RequestHash: ir.RequestHash,
+		CreatedAt:   ir.CreatedAt,
+		ExpiresAt:   ir.ExpiresAt,
+	})
+	if err == nil {
+		return ir, true, nil
+	}
+	if !mongo.IsDuplicateKeyError(err) {
+		return domain.IdempotencyRecord{}, false, err
+	}
+	var rec idempotencyRecord
+	if err := r.collection.FindOne(ctx, filter).Decode(&rec); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			// The holder was released between the insert and the lookup, so its request was still in progress.
+			return domain.IdempotencyRecord{}, false, usecase.ErrIdempotencyKeyInUse
+		}
+		return domain.IdempotencyRecord{}, false, err
+	}
+	return rec.toDomain(), false, nil
+}
+
+// Complete stores the response in the incomplete reservation, returning ErrNotFound if there is none.
+func (r *mongoIdempotencyRepository) Complete(ctx context.Context, ir domain.IdempotencyRecord) error {
+	return r.updateReservation(ctx, ir, bson.M{"$set": bson.M{
+		"completed":  true,
+		"status":     ir.Status,
+		"header":     ir.Header,
+		"body":       ir.Body,
+		"expires_at": ir.ExpiresAt,
+	}})
+}
+
+// Renew moves the expiry of the incomplete reservation, returning ErrNotFound if there is none.
+func (r *mongoIdempotencyRepository) Renew(ctx context.Context, ir domain.IdempotencyRecord) error {
+	return r.updateReservation(ctx, ir, bson.M{"$set": bson.M{"expires_at": ir.ExpiresAt}})
+}
+
+// Release deletes the incomplete reservation; completed records are kept until they expire.
+func (r *mongoIdempotencyRepository) Release(ctx context.Context, ir domain.IdempotencyRecord) error {
+	_, err := r.collection.DeleteOne(ctx, reservationFilter(ir))
+	return err
+}
+
+// updateReservation applies update to the incomplete reservation, mapping a missing one to usecase.ErrNotFound.
+func (r *mongoIdempotencyRepository) updateReservation(ctx context.Context, ir domain.IdempotencyRecord, update bson.M) error {
+	res, err := r.collection.UpdateOne(ctx, reservationFilter(ir), update)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return usecase.ErrNotFound
+	}
+	return nil
+}
+
+// reservationFilter selects the incomplete record made by the request of ir. The request hash and creation time tell
+// it apart from a later reservation of the same key, made once this one had expired.
+func reservationFilter(ir domain.IdempotencyRecord) bson.M {
+	return bson.M{
+		"user_id":      ir.UserID,
+		"key":          ir.Key,
+		"request_hash": ir.RequestHash,
+		"created_at":   ir.CreatedAt,
+		"completed":    false,
+	}
+}
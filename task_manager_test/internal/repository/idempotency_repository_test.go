@@ -0,0 +1,194 @@
+package repository
+
+import (
+	"context"
+	"os"
+	"task_manager_test/internal/domain"
+	"task_manager_test/internal/usecase"
+	"testing"
+	"time"
+
+	"github.com/joho/godotenv"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// IdempotencyRepositoryTestSuite defines the integration test suite for the idempotency repository.
+type IdempotencyRepositoryTestSuite struct {
+	suite.Suite
+	client     *mongo.Client
+	db         *mongo.Database
+	repository usecase.IIdempotencyRepository
+	now        time.Time
+}
+
+// SetupSuite connects to the dedicated test database, skipping the suite when it is not configured.
+func (s *IdempotencyRepositoryTestSuite) SetupSuite() {
+	if err := godotenv.Load("../../.env"); err != nil {
+		s.T().Log("No .env file found, proceeding with environment variables")
+	}
+
+	uri := os.Getenv("MONGODB_URI_TEST")
+	if uri == "" {
+		s.T().Skip("MONGODB_URI_TEST environment variable not set, skipping integration tests")
+	}
+
+	client, err := mongo.Connect(context.Background(), options.Client().ApplyURI(uri))
+	assert.NoError(s.T(), err, "Failed to connect to MongoDB")
+
+	s.client = client
+	s.db = client.Database("idempotencydb_test")
+}
+
+// TearDownSuite drops the test database and disconnects.
+func (s *IdempotencyRepositoryTestSuite) TearDownSuite() {
+	if s.client != nil {
+		assert.NoError(s.T(), s.db.Drop(context.Background()), "Failed to drop test database")
+		assert.NoError(s.T(), s.client.Disconnect(context.Background()), "Failed to disconnect from MongoDB")
+	}
+}
+
+// SetupTest creates the collection's indexes, which make reservations atomic, and a repository.
+func (s *IdempotencyRepositoryTestSuite) SetupTest() {
+	assert.NoError(s.T(), createIdempotencyKeys(context.Background(), s.db), "SetupTest: failed to create indexes")
+	s.repository = NewMongoIdempotencyRepository(s.db)
+	s.now = time.Now().UTC().Truncate(time.Millisecond)
+}
+
+// TearDownTest drops the collection to isolate tests.
+func (s *IdempotencyRepositoryTestSuite) TearDownTest() {
+	assert.NoError(s.T(), s.db.Collection("idempotency_keys").Drop(context.Background()), "TearDownTest: failed to drop collection")
+}
+
+// TestIdempotencyRepository is the entry point for the test suite.
+func TestIdempotencyRepository(t *testing.T) {
+	suite.Run(t, new(IdempotencyRepositoryTestSuite))
+}
+
+// record returns an incomplete record of user-1 for the key, expiring in a minute.
+func (s *IdempotencyRepositoryTestSuite) record(key, hash string) domain.IdempotencyRecord {
+	return domain.IdempotencyRecord{UserID: "user-1", Key: key, RequestHash: hash, CreatedAt: s.now, ExpiresAt: s.now.Add(time.Minute)}
+}
+
+// TestReserve_IsExclusive verifies that a key is held by the first reservation and that keys are scoped per user.
+func (s *IdempotencyRepositoryTestSuite) TestReserve_IsExclusive() {
+	ctx := context.Background()
+
+	_, first, err1 := s.repository.Reserve(ctx, s.record("k1", "hash-1"), s.now)
+	held, second, err2 := s.repository.Reserve(ctx, s.record("k1", "hash-2"), s.now)
+	other := s.record("k1", "hash-3")
+	other.UserID = "user-2"
+	_, third, err3 := s.repository.Reserve(ctx, other, s.now)
+
+	assert.NoError(s.T(), err1)
+	assert.True(s.T(), first)
+	assert.NoError(s.T(), err2)
+	assert.False(s.T(), second, "A held key must not be reserved again")
+	assert.Equal(s.T(), "hash-1", held.RequestHash, "The holder of the key should be returned")
+	assert.False(s.T(), held.Completed)
+	assert.NoError(s.T(), err3)
+	assert.True(s.T(), third, "Another user's key is independent")
+}
+
+// TestComplete_StoresResponse verifies that the completed response is returned to later reservations.
+func (s *IdempotencyRepositoryTestSuite) TestComplete_StoresResponse() {
+	ctx := context.Background()
+	rec := s.record("k1", "hash-1")
+	_, _, err := s.repository.Reserve(ctx, rec, s.now)
+	assert.NoError(s.T(), err, "Setup: failed to reserve key")
+
+	rec.Status = 201
+	rec.Header = map[string][]string{"Content-Type": {"application/json"}}
+	rec.Body = []byte(`{"id":"t1"}`)
+	rec.ExpiresAt = s.now.Add(24 * time.Hour)
+	assert.NoError(s.T(), s.repository.Complete(ctx, rec))
+
+	held, created, err := s.repository.Reserve(ctx, s.record("k1", "hash-1"), s.now)
+	assert.NoError(s.T(), err)
+	assert.False(s.T(), created)
+	assert.True(s.T(), held.Completed)
+	assert.Equal(s.T(), 201, held.Status)
+	assert.Equal(s.T(), []string{"application/json"}, held.Header["Content-Type"])
+	assert.Equal(s.T(), `{"id":"t1"}`, string(held.Body))
+	assert.ErrorIs(s.T(), s.repository.Complete(ctx, rec), usecase.ErrNotFound, "A record is completed only once")
+}
+
+// TestRelease_FreesIncompleteKeys verifies that releasing a key lets it be reserved again, but keeps completed ones.
+func (s *IdempotencyRepositoryTestSuite) TestRelease_FreesIncompleteKeys() {
+	ctx := context.Background()
+	pending := s.record("k1", "hash-1")
+	_, _, _ = s.repository.Reserve(ctx, pending, s.now)
+	done := s.record("k2", "hash-2")
+	_, _, _ = s.repository.Reserve(ctx, done, s.now)
+	done.Status = 201
+	assert.NoError(s.T(), s.repository.Complete(ctx, done), "Setup: failed to complete key")
+
+	assert.NoError(s.T(), s.repository.Release(ctx, pending))
+	assert.NoError(s.T(), s.repository.Release(ctx, done))
+
+	_, created1, err1 := s.repository.Reserve(ctx, s.record("k1", "hash-1"), s.now)
+	_, created2, err2 := s.repository.Reserve(ctx, s.record("k2", "hash-2"), s.now)
+	assert.NoError(s.T(), err1)
+	assert.True(s.T(), created1)
+	assert.NoError(s.T(), err2)
+	assert.False(s.T(), created2, "Completed records must survive a release")
+}
+
+// TestReserve_ReplacesExpiredRecords verifies that an expired record no longer holds its key, even before the TTL
+// monitor has deleted it.
+func (s *IdempotencyRepositoryTestSuite) TestReserve_ReplacesExpiredRecords() {
+	ctx := context.Background()
+	_, _, _ = s.repository.Reserve(ctx, s.record("k1", "hash-1"), s.now)
+
+	later := s.now.Add(time.Hour)
+	rec := s.record("k1", "hash-2")
+	rec.ExpiresAt = later.Add(time.Minute)
+	held, created, err := s.repository.Reserve(ctx, rec, later)
+
+	assert.NoError(s.T(), err)
+	assert.True(s.T(), created)
+	assert.Equal(s.T(), "hash-2", held.RequestHash)
+}
+
+// TestReservation_IgnoresLaterReservations verifies that a request whose reservation expired and was replaced can
+// neither renew, complete nor release the replacement.
+func (s *IdempotencyRepositoryTestSuite) TestReservation_IgnoresLaterReservations() {
+	ctx := context.Background()
+	expired := s.record("k1", "hash-1")
+	_, _, _ = s.repository.Reserve(ctx, expired, s.now)
+	later := s.now.Add(time.Hour)
+	retry := s.record("k1", "hash-1")
+	retry.CreatedAt = later
+	retry.ExpiresAt = later.Add(time.Minute)
+	_, created, err := s.repository.Reserve(ctx, retry, later)
+	assert.NoError(s.T(), err, "Setup: failed to reserve key again")
+	assert.True(s.T(), created, "Setup: the expired reservation should be replaced")
+
+	expired.ExpiresAt = later.Add(time.Hour)
+	assert.ErrorIs(s.T(), s.repository.Renew(ctx, expired), usecase.ErrNotFound)
+	assert.ErrorIs(s.T(), s.repository.Complete(ctx, expired), usecase.ErrNotFound)
+	assert.NoError(s.T(), s.repository.Release(ctx, expired))
+
+	held, created, err := s.repository.Reserve(ctx, s.record("k1", "hash-1"), later)
+	assert.NoError(s.T(), err)
+	assert.False(s.T(), created, "The replacement should still hold the key")
+	assert.False(s.T(), held.Completed)
+	assert.Equal(s.T(), later, held.CreatedAt.UTC())
+	assert.Equal(s.T(), later.Add(time.Minute), held.ExpiresAt.UTC(), "The replacement should not be renewed")
+}
+
+// TestRenew_ExtendsReservation verifies that a renewed reservation holds its key past its original expiry.
+func (s *IdempotencyRepositoryTestSuite) TestRenew_ExtendsReservation() {
+	ctx := context.Background()
+	rec := s.record("k1", "hash-1")
+	_, _, _ = s.repository.Reserve(ctx, rec, s.now)
+
+	rec.ExpiresAt = s.now.Add(2 * time.Minute)
+	assert.NoError(s.T(), s.repository.Renew(ctx, rec))
+
+	_, created, err := s.repository.Reserve(ctx, s.record("k1", "hash-2"), s.now.Add(90*time.Second))
+	assert.NoError(s.T(), err)
+	assert.False(s.T(), created, "A renewed reservation should still hold the key")
+}
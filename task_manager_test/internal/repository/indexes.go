@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Collections names every collection used by the repositories, in the order backups write them. Idempotency keys
+// only live for hours and are not backed up.
+var Collections = []string{
+	"organizations", "users", "access_tokens", "password_resets", "projects", "tasks", "task_shares", "comments",
+	"time_entries", "audit_log",
+}
+
+// initialIndexes lists the indexes created by the first migration: unique keys the repositories map to conflicts,
+// and the fields they filter and sort on. Later index changes belong in new migrations.
+var initialIndexes = map[string][]mongo.IndexModel{
+	"users": {
+		{Keys: bson.D{{Key: "username", Value: 1}}, Options: options.Index().SetUnique(true)},
+	},
+	"access_tokens": {
+		{Keys: bson.D{{Key: "token_hash", Value: 1}}, Options: options.Index().SetUnique(true)},
+		{Keys: bson.D{{Key: "user_id", Value: 1}}},
+	},
+	"password_resets": {
+		{Keys: bson.D{{Key: "token_hash", Value: 1}}, Options: options.Index().SetUnique(true)},
+		{Keys: bson.D{{Key: "user_id", Value: 1}}},
+	},
+	"projects": {
+		{Keys: bson.D{{Key: "members.user_id", Value: 1}}},
+	},
+	"tasks": {
+		{Keys: bson.D{{Key: "owner_id", Value: 1}}},
+		{Keys: bson.D{{Key: "project_id", Value: 1}}},
+		{Keys: bson.D{{Key: "duedate", Value: 1}}},
+		{Keys: bson.D{{Key: "status", Value: 1}}},
+		{Keys: bson.D{{Key: "attachments.digest", Value: 1}}},
+	},
+	"task_shares": {
+		{Keys: bson.D{{Key: "task_id", Value: 1}, {Key: "user_id", Value: 1}}, Options: options.Index().SetUnique(true)},
+		{Keys: bson.D{{Key: "user_id", Value: 1}}},
+	},
+	"comments": {
+		{Keys: bson.D{{Key: "task_id", Value: 1}, {Key: "created_at", Value: 1}}},
+	},
+}
+
+// createInitialIndexes creates initialIndexes. Indexes that already exist are left as they are. Duplicate usernames
+// are reported by name first, since they would otherwise fail the unique index with an opaque error.
+func createInitialIndexes(ctx context.Context, db *mongo.Database) error {
+	duplicates, err := duplicateUsernames(ctx, db.Collection("users"))
+	if err != nil {
+		return err
+	}
+	if len(duplicates) > 0 {
+		return fmt.Errorf("usernames %s are used by more than one user; rename or delete the extra accounts first",
+			strings.Join(duplicates, ", "))
+	}
+	for _, coll := range Collections {
+		if len(initialIndexes[coll]) == 0 {
+			continue // collections added later get their indexes from later migrations
+		}
+		if _, err := db.Collection(coll).Indexes().CreateMany(ctx, initialIndexes[coll]); err != nil {
+			return fmt.Errorf("create indexes on %s: %w", coll, err)
+		}
+	}
+	return nil
+}
+
+// duplicateUsernames returns the usernames held by more than one user document, sorted.
+func duplicateUsernames(ctx context.Context, users *mongo.Collection) ([]string, error) {
+	cursor, err := users.Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$group", Value: bson.M{"_id": "$username", "count": bson.M{"$sum": 1}}}},
+		{{Key: "$match", Value: bson.M{"count": bson.M{"$gt": 1}}}},
+		{{Key: "$sort", Value: bson.M{"_id": 1}}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	var groups []struct {
+		Username string `bson:"_id"`
+	}
+	if err := cursor.All(ctx, &groups); err != nil {
+		return nil, err
+	}
+	names := make([]string, len(groups))
+	for i, g := range groups {
+		names[i] = g.Username
+	}
+	return names, nil
+}
@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"container/list"
+	"time"
+)
+
+// lru is a size-bounded map that evicts its least recently used entry when full. Entries also expire at a fixed
+// time. It is not safe for concurrent use.
+type lru[K comparable, V any] struct {
+	capacity int
+	order    *list.List // of *lruEntry[K, V], most recently used first
+	items    map[K]*list.Element
+}
+
+// lruEntry is an element of the lru's recency list.
+type lruEntry[K comparable, V any] struct {
+	key       K
+	value     V
+	expiresAt time.Time
+}
+
+// newLRU returns an empty lru holding at most capacity entries.
+func newLRU[K comparable, V any](capacity int) *lru[K, V] {
+	return &lru[K, V]{capacity: capacity, order: list.New(), items: make(map[K]*list.Element, capacity)}
+}
+
+// get returns the value stored under key unless it has expired at now, marking it as recently used. Expired
+// entries are removed.
+func (l *lru[K, V]) get(key K, now time.Time) (V, bool) {
+	el, ok := l.items[key]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	entry := el.Value.(*lruEntry[K, V])
+	if !now.Before(entry.expiresAt) {
+		l.order.Remove(el)
+		delete(l.items, key)
+		var zero V
+		return zero, false
+	}
+	l.order.MoveToFront(el)
+	return entry.value, true
+}
+
+// add stores value under key until expiresAt, replacing any previous value. It reports whether another entry was
+// evicted to make room.
+func (l *lru[K, V]) add(key K, value V, expiresAt time.Time) (evicted bool) {
+	if el, ok := l.items[key]; ok {
+		entry := el.Value.(*lruEntry[K, V])
+		entry.value, entry.expiresAt = value, expiresAt
+		l.order.MoveToFront(el)
+		return false
+	}
+	if l.order.Len() >= l.capacity {
+		oldest := l.order.Back()
+		l.order.Remove(oldest)
+		delete(l.items, oldest.Value.(*lruEntry[K, V]).key)
+		evicted = true
+	}
+	l.items[key] = l.order.PushFront(&lruEntry[K, V]{key: key, value: value, expiresAt: expiresAt})
+	return evicted
+}
+
+// remove deletes the entry stored under key, if any.
+func (l *lru[K, V]) remove(key K) {
+	if el, ok := l.items[key]; ok {
+		l.order.Remove(el)
+		delete(l.items, key)
+	}
+}
+
+// removeFunc deletes every entry whose value matches.
+func (l *lru[K, V]) removeFunc(match func(V) bool) {
+	for el := l.order.Front(); el != nil; {
+		next := el.Next()
+		if entry := el.Value.(*lruEntry[K, V]); match(entry.value) {
+			l.order.Remove(el)
+			delete(l.items, entry.key)
+		}
+		el = next
+	}
+}
+
+// len returns the number of entries, including expired ones not yet removed.
+func (l *lru[K, V]) len() int {
+	return l.order.Len()
+}
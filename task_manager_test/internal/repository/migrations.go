@@ -0,0 +1,239 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"task_manager_test/internal/domain"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Migration is one change to the database schema or data. Migrations run in version order and each runs once;
+// Up must be idempotent, because two servers starting together may both apply it.
+type Migration struct {
+	Version int
+	Name    string
+	Up      func(ctx context.Context, db *mongo.Database) error
+}
+
+// migrations is the ordered list of migrations. Append new ones with the next version; never edit applied ones.
+var migrations = []Migration{
+	{Version: 1, Name: "create_initial_indexes", Up: createInitialIndexes},
+	{Version: 2, Name: "backfill_member_role", Up: backfillMemberRole},
+	{Version: 3, Name: "create_organizations", Up: createOrganizations},
+	{Version: 4, Name: "create_idempotency_keys", Up: createIdempotencyKeys},
+	{Version: 5, Name: "create_audit_log", Up: createAuditLog},
+	{Version: 6, Name: "create_time_entries", Up: createTimeEntries},
+	{Version: 7, Name: "count_attachment_usage", Up: countAttachmentUsage},
+}
+
+// backfillMemberRole gives users created before roles existed, or with the legacy "user" role, the member role
+// they are already treated as having.
+func backfillMemberRole(ctx context.Context, db *mongo.Database) error {
+	_, err := db.Collection("users").UpdateMany(ctx,
+		bson.M{"$or": bson.A{
+			bson.M{"role": bson.M{"$exists": false}},
+			bson.M{"role": ""},
+			bson.M{"role": domain.RoleLegacyUser},
+		}},
+		bson.M{"$set": bson.M{"role": domain.RoleMember}},
+	)
+	return err
+}
+
+// tenantCollections names the collections holding tenant data, whose documents carry an org_id.
+var tenantCollections = []string{"users", "projects", "tasks", "task_shares", "comments"}
+
+// createOrganizations introduces organizations: it creates the default organization and moves every document that
+// predates organizations into it, then indexes the organization ID the repositories filter on.
+func createOrganizations(ctx context.Context, db *mongo.Database) error {
+	orgs := db.Collection("organizations")
+	if _, err := orgs.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "slug", Value: 1}}, Options: options.Index().SetUnique(true),
+	}); err != nil {
+		return fmt.Errorf("create indexes on organizations: %w", err)
+	}
+	var def organizationRecord
+	err := orgs.FindOneAndUpdate(ctx,
+		bson.M{"slug": domain.DefaultOrganizationSlug},
+		bson.M{"$setOnInsert": bson.M{"name": "Default", "created_at": time.Now().UTC().Truncate(time.Millisecond)}},
+		options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After),
+	).Decode(&def)
+	if err != nil {
+		return fmt.Errorf("create default organization: %w", err)
+	}
+	for _, coll := range tenantCollections {
+		c := db.Collection(coll)
+		if _, err := c.UpdateMany(ctx,
+			bson.M{tenantField: bson.M{"$exists": false}},
+			bson.M{"$set": bson.M{tenantField: def.ID.Hex()}},
+		); err != nil {
+			return fmt.Errorf("assign %s to the default organization: %w", coll, err)
+		}
+		if _, err := c.Indexes().CreateOne(ctx, mongo.IndexModel{Keys: bson.D{{Key: tenantField, Value: 1}}}); err != nil {
+			return fmt.Errorf("create indexes on %s: %w", coll, err)
+		}
+	}
+	return nil
+}
+
+// createIdempotencyKeys indexes the idempotency_keys collection: the unique user and key that make reservations
+// atomic, and a TTL index that deletes records once they expire.
+func createIdempotencyKeys(ctx context.Context, db *mongo.Database) error {
+	_, err := db.Collection("idempotency_keys").Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "key", Value: 1}}, Options: options.Index().SetUnique(true)},
+		{Keys: bson.D{{Key: "expires_at", Value: 1}}, Options: options.Index().SetExpireAfterSeconds(0)},
+	})
+	if err != nil {
+		return fmt.Errorf("create indexes on idempotency_keys: %w", err)
+	}
+	return nil
+}
+
+// createAuditLog indexes the audit_log collection on the actor and target users that exports look entries up by.
+func createAuditLog(ctx context.Context, db *mongo.Database) error {
+	_, err := db.Collection("audit_log").Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "actor_id", Value: 1}}},
+		{Keys: bson.D{{Key: "target_user_id", Value: 1}}},
+	})
+	if err != nil {
+		return fmt.Errorf("create indexes on audit_log: %w", err)
+	}
+	return nil
+}
+
+// createTimeEntries indexes the time_entries collection: a unique index on the user of running timers, which
+// allows each user a single one, and the task and user with start time that entries are listed by.
+func createTimeEntries(ctx context.Context, db *mongo.Database) error {
+	_, err := db.Collection("time_entries").Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "user_id", Value: 1}},
+			Options: options.Index().SetUnique(true).SetPartialFilterExpression(bson.M{"running": true}),
+		},
+		{Keys: bson.D{{Key: "task_id", Value: 1}, {Key: "start", Value: 1}}},
+		{Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "start", Value: 1}}},
+		{Keys: bson.D{{Key: tenantField, Value: 1}}},
+	})
+	if err != nil {
+		return fmt.Errorf("create indexes on time_entries: %w", err)
+	}
+	return nil
+}
+
+// countAttachmentUsage stores on each user the total size of the attachments they uploaded, which uploads reserve
+// quota against from then on.
+func countAttachmentUsage(ctx context.Context, db *mongo.Database) error {
+	cursor, err := db.Collection("tasks").Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"attachments.0": bson.M{"$exists": true}}}},
+		{{Key: "$unwind", Value: "$attachments"}},
+		{{Key: "$group", Value: bson.M{"_id": "$attachments.uploaded_by", "total": bson.M{"$sum": "$attachments.size"}}}},
+	})
+	if err != nil {
+		return fmt.Errorf("sum attachment sizes: %w", err)
+	}
+	var usage []struct {
+		UserID string `bson:"_id"`
+		Total  int64  `bson:"total"`
+	}
+	if err := cursor.All(ctx, &usage); err != nil {
+		return fmt.Errorf("sum attachment sizes: %w", err)
+	}
+	users := db.Collection("users")
+	for _, u := range usage {
+		oid, err := primitive.ObjectIDFromHex(u.UserID)
+		if err != nil {
+			continue
+		}
+		if _, err := users.UpdateOne(ctx, bson.M{"_id": oid}, bson.M{"$set": bson.M{"attachment_bytes": u.Total}}); err != nil {
+			return fmt.Errorf("store attachment usage: %w", err)
+		}
+	}
+	return nil
+}
+
+// MigrationStatus describes a migration and when it was applied; AppliedAt is zero while it is pending.
+type MigrationStatus struct {
+	Version   int       `json:"version"`
+	Name      string    `json:"name"`
+	AppliedAt time.Time `json:"applied_at"`
+}
+
+// Pending reports whether the migration has not been applied.
+func (s MigrationStatus) Pending() bool {
+	return s.AppliedAt.IsZero()
+}
+
+// migrationRecord is the BSON shape of a schema_migrations document.
+type migrationRecord struct {
+	Version   int       `bson:"_id"`
+	Name      string    `bson:"name"`
+	AppliedAt time.Time `bson:"applied_at"`
+}
+
+// Migrator applies migrations and records them in the schema_migrations collection.
+type Migrator struct {
+	db         *mongo.Database
+	collection *mongo.Collection
+	migrations []Migration
+	now        func() time.Time
+}
+
+// NewMigrator creates a Migrator for db with the built-in migrations.
+func NewMigrator(db *mongo.Database) *Migrator {
+	return &Migrator{db: db, collection: db.Collection("schema_migrations"), migrations: migrations, now: time.Now}
+}
+
+// Status returns every migration in version order with the time it was applied.
+func (m *Migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	cursor, err := m.collection.Find(ctx, bson.M{})
+	if err != nil {
+		return nil, err
+	}
+	var recs []migrationRecord
+	if err := cursor.All(ctx, &recs); err != nil {
+		return nil, err
+	}
+	applied := make(map[int]time.Time, len(recs))
+	for _, rec := range recs {
+		applied[rec.Version] = rec.AppliedAt
+	}
+	statuses := make([]MigrationStatus, len(m.migrations))
+	for i, mig := range m.migrations {
+		statuses[i] = MigrationStatus{Version: mig.Version, Name: mig.Name, AppliedAt: applied[mig.Version]}
+	}
+	return statuses, nil
+}
+
+// Migrate applies the pending migrations in order and returns them. It stops at the first failure, returning the
+// migrations applied before it; the failed one stays pending and is retried by the next run.
+func (m *Migrator) Migrate(ctx context.Context) ([]MigrationStatus, error) {
+	statuses, err := m.Status(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var applied []MigrationStatus
+	for i, status := range statuses {
+		if !status.Pending() {
+			continue
+		}
+		if err := m.migrations[i].Up(ctx, m.db); err != nil {
+			return applied, fmt.Errorf("migration %d %s: %w", status.Version, status.Name, err)
+		}
+		status.AppliedAt = m.now().UTC().Truncate(time.Millisecond)
+		// Another server may have recorded the migration meanwhile; keep its record.
+		_, err := m.collection.UpdateOne(ctx,
+			bson.M{"_id": status.Version},
+			bson.M{"$setOnInsert": bson.M{"name": status.Name, "applied_at": status.AppliedAt}},
+			options.Update().SetUpsert(true),
+		)
+		if err != nil && !mongo.IsDuplicateKeyError(err) {
+			return applied, fmt.Errorf("record migration %d %s: %w", status.Version, status.Name, err)
+		}
+		applied = append(applied, status)
+	}
+	return applied, nil
+}
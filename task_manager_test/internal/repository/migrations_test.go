@@ -0,0 +1,200 @@
+package repository
+
+import (
+	"context"
+	"os"
+	"task_manager_test/internal/domain"
+	"task_manager_test/internal/usecase"
+	"testing"
+	"time"
+
+	"github.com/joho/godotenv"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// TestMigrations_AreOrdered guards the migration list against reused or out-of-order versions.
+func TestMigrations_AreOrdered(t *testing.T) {
+	for i, m := range migrations {
+		assert.Equal(t, i+1, m.Version, "Migration %s should have version %d", m.Name, i+1)
+		assert.NotEmpty(t, m.Name)
+		assert.NotNil(t, m.Up)
+	}
+}
+
+// MigratorTestSuite runs the migrations against a scratch database.
+type MigratorTestSuite struct {
+	suite.Suite
+	client   *mongo.Client
+	db       *mongo.Database
+	migrator *Migrator
+}
+
+// SetupSuite connects to the test database, skipping the suite when none is configured.
+func (s *MigratorTestSuite) SetupSuite() {
+	if err := godotenv.Load("../../.env"); err != nil {
+		s.T().Log("No .env file found, proceeding with environment variables")
+	}
+	uri := os.Getenv("MONGODB_URI_TEST")
+	if uri == "" {
+		s.T().Skip("MONGODB_URI_TEST environment variable not set, skipping integration tests")
+	}
+	client, err := mongo.Connect(context.Background(), options.Client().ApplyURI(uri))
+	assert.NoError(s.T(), err, "Failed to connect to MongoDB")
+	s.client = client
+	s.db = client.Database("migrationdb_test")
+}
+
+// TearDownSuite disconnects from MongoDB.
+func (s *MigratorTestSuite) TearDownSuite() {
+	if s.client != nil {
+		assert.NoError(s.T(), s.client.Disconnect(context.Background()))
+	}
+}
+
+// SetupTest creates a migrator with a fixed clock.
+func (s *MigratorTestSuite) SetupTest() {
+	s.migrator = NewMigrator(s.db)
+	s.migrator.now = func() time.Time { return time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC) }
+}
+
+// TearDownTest drops the scratch database.
+func (s *MigratorTestSuite) TearDownTest() {
+	assert.NoError(s.T(), s.db.Drop(context.Background()))
+}
+
+// TestMigratorSuite runs the entire test suite.
+func TestMigratorSuite(t *testing.T) {
+	suite.Run(t, new(MigratorTestSuite))
+}
+
+// TestMigrate_AppliesOnce verifies that migrations are recorded and not applied again.
+func (s *MigratorTestSuite) TestMigrate_AppliesOnce() {
+	ctx := context.Background()
+
+	before, err := s.migrator.Status(ctx)
+	s.Require().NoError(err)
+	applied, err := s.migrator.Migrate(ctx)
+	s.Require().NoError(err)
+	again, err := s.migrator.Migrate(ctx)
+	s.Require().NoError(err)
+	after, err := s.migrator.Status(ctx)
+	s.Require().NoError(err)
+
+	s.Len(before, len(migrations))
+	for _, status := range before {
+		s.True(status.Pending())
+	}
+	s.Len(applied, len(migrations))
+	s.Empty(again)
+	s.Equal(time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC), after[0].AppliedAt)
+}
+
+// TestMigrate_UniqueUsernames verifies that the unique index makes duplicate registrations fail as conflicts.
+func (s *MigratorTestSuite) TestMigrate_UniqueUsernames() {
+	ctx := context.Background()
+	_, err := s.migrator.Migrate(ctx)
+	s.Require().NoError(err)
+	users := NewMongoUserRepository(s.db)
+	ctx = testTenant()
+
+	_, first := users.Create(ctx, domain.User{Username: "alice", Password: "hash", Role: domain.RoleMember})
+	_, second := users.Create(ctx, domain.User{Username: "alice", Password: "hash", Role: domain.RoleMember})
+
+	s.NoError(first)
+	s.ErrorIs(second, usecase.ErrUserAlreadyExists)
+}
+
+// TestMigrate_Fails_When_UsernamesAreDuplicated verifies that existing duplicates are named and block the migration.
+func (s *MigratorTestSuite) TestMigrate_Fails_When_UsernamesAreDuplicated() {
+	ctx := context.Background()
+	_, err := s.db.Collection("users").InsertMany(ctx, []any{
+		bson.M{"username": "bob"}, bson.M{"username": "bob"}, bson.M{"username": "carol"},
+	})
+	s.Require().NoError(err)
+
+	applied, err := s.migrator.Migrate(ctx)
+
+	s.ErrorContains(err, "usernames bob are used by more than one user")
+	s.Empty(applied)
+	status, _ := s.migrator.Status(ctx)
+	s.True(status[0].Pending(), "A failed migration should be retried by the next run")
+}
+
+// TestMigrate_BackfillsMemberRole verifies that legacy and missing roles become member.
+func (s *MigratorTestSuite) TestMigrate_BackfillsMemberRole() {
+	ctx := context.Background()
+	_, err := s.db.Collection("users").InsertMany(ctx, []any{
+		bson.M{"username": "legacy", "role": "user"},
+		bson.M{"username": "old"},
+		bson.M{"username": "boss", "role": "admin"},
+	})
+	s.Require().NoError(err)
+
+	_, err = s.migrator.Migrate(ctx)
+	s.Require().NoError(err)
+
+	all, err := NewMongoUserRepository(s.db).List(usecase.WithAllTenants(ctx))
+	s.Require().NoError(err)
+	roles := map[string]domain.Role{}
+	for _, u := range all {
+		roles[u.Username] = u.Role
+	}
+	s.Equal(map[string]domain.Role{"boss": domain.RoleAdmin, "legacy": domain.RoleMember, "old": domain.RoleMember}, roles)
+}
+
+// TestMigrate_CountsAttachmentUsage verifies that users are charged for the attachments they uploaded before quotas
+// were reserved.
+func (s *MigratorTestSuite) TestMigrate_CountsAttachmentUsage() {
+	ctx := context.Background()
+	uploader, other := primitive.NewObjectID(), primitive.NewObjectID()
+	_, err := s.db.Collection("users").InsertMany(ctx, []any{
+		bson.M{"_id": uploader, "username": "uploader", "role": "member"},
+		bson.M{"_id": other, "username": "other", "role": "member"},
+	})
+	s.Require().NoError(err)
+	_, err = s.db.Collection("tasks").InsertMany(ctx, []any{
+		bson.M{"title": "a", "attachments": bson.A{
+			bson.M{"id": "a1", "size": 40, "uploaded_by": uploader.Hex()},
+			bson.M{"id": "a2", "size": 2, "uploaded_by": other.Hex()},
+		}},
+		bson.M{"title": "b", "attachments": bson.A{bson.M{"id": "a3", "size": 60, "uploaded_by": uploader.Hex()}}},
+		bson.M{"title": "c"},
+	})
+	s.Require().NoError(err)
+
+	_, err = s.migrator.Migrate(ctx)
+	s.Require().NoError(err)
+
+	users := NewMongoUserRepository(s.db)
+	all := usecase.WithAllTenants(ctx)
+	_, err = users.ReserveAttachmentSpace(all, uploader.Hex(), 10, 100)
+	s.ErrorIs(err, usecase.ErrAttachmentQuotaExceeded, "The uploader's 100 bytes should already fill the quota")
+	reserved, err := users.ReserveAttachmentSpace(all, other.Hex(), 10, 100)
+	s.Require().NoError(err)
+	s.Equal(int64(10), reserved)
+}
+
+// TestMigrate_AssignsDataToDefaultOrganization verifies that data predating organizations joins the default one.
+func (s *MigratorTestSuite) TestMigrate_AssignsDataToDefaultOrganization() {
+	ctx := context.Background()
+	_, err := s.db.Collection("users").InsertOne(ctx, bson.M{"username": "legacy", "role": "member"})
+	s.Require().NoError(err)
+
+	_, err = s.migrator.Migrate(ctx)
+	s.Require().NoError(err)
+
+	def, err := NewMongoOrganizationRepository(s.db).FindBySlug(ctx, domain.DefaultOrganizationSlug)
+	s.Require().NoError(err)
+	users, err := NewMongoUserRepository(s.db).List(usecase.WithTenant(ctx, def.ID))
+	s.Require().NoError(err)
+	s.Require().Len(users, 1)
+	s.Equal(def.ID, users[0].OrgID)
+	others, err := NewMongoUserRepository(s.db).List(usecase.WithTenant(ctx, "another-org"))
+	s.Require().NoError(err)
+	s.Empty(others, "Users of one organization must not be visible to another")
+}
@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"task_manager_test/internal/domain"
+	"task_manager_test/internal/usecase"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// mongoOrganizationRepository is the MongoDB-based implementation of the IOrganizationRepository interface.
+// Organizations are the tenants themselves, so the collection is not tenant-scoped.
+type mongoOrganizationRepository struct {
+	collection *mongo.Collection
+}
+
+// Add a compile-time check to ensure this struct implements the correct interface.
+var _ usecase.IOrganizationRepository = (*mongoOrganizationRepository)(nil)
+
+// NewMongoOrganizationRepository is the constructor for the implementation.
+func NewMongoOrganizationRepository(db *mongo.Database) usecase.IOrganizationRepository {
+	return &mongoOrganizationRepository{
+		collection: db.Collection("organizations"),
+	}
+}
+
+// organizationRecord is the BSON shape of an organization document.
+type organizationRecord struct {
+	ID        primitive.ObjectID `bson:"_id"`
+	Name      string             `bson:"name"`
+	Slug      string             `bson:"slug"`
+	CreatedAt time.Time          `bson:"created_at"`
+}
+
+// toDomain converts the stored document into a domain.Organization.
+func (r organizationRecord) toDomain() domain.Organization {
+	return domain.Organization{ID: r.ID.Hex(), Name: r.Name, Slug: r.Slug, CreatedAt: r.CreatedAt}
+}
+
+// Create inserts a new organization document, generating a new unique ID. The unique index on the slug turns a
+// taken slug into usecase.ErrOrganizationExists.
+func (r *mongoOrganizationRepository) Create(ctx context.Context, org domain.Organization) (domain.Organization, error) {
+	rec := organizationRecord{ID: primitive.NewObjectID(), Name: org.Name, Slug: org.Slug, CreatedAt: org.CreatedAt}
+	if _, err := r.collection.InsertOne(ctx, rec); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return domain.Organization{}, usecase.ErrOrganizationExists
+		}
+		return domain.Organization{}, err
+	}
+	return rec.toDomain(), nil
+}
+
+// GetByID fetches an organization by its hexadecimal string ID.
+func (r *mongoOrganizationRepository) GetByID(ctx context.Context, id string) (domain.Organization, error) {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return domain.Organization{}, usecase.ErrInvalidID
+	}
+	return r.findOne(ctx, bson.M{"_id": oid})
+}
+
+// FindBySlug fetches an organization by its slug.
+func (r *mongoOrganizationRepository) FindBySlug(ctx context.Context, slug string) (domain.Organization, error) {
+	return r.findOne(ctx, bson.M{"slug": slug})
+}
+
+// findOne decodes the organization matching filter, mapping a missing document to usecase.ErrOrganizationNotFound.
+func (r *mongoOrganizationRepository) findOne(ctx context.Context, filter bson.M) (domain.Organization, error) {
+	var rec organizationRecord
+	if err := r.collection.FindOne(ctx, filter).Decode(&rec); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return domain.Organization{}, usecase.ErrOrganizationNotFound
+		}
+		return domain.Organization{}, err
+	}
+	return rec.toDomain(), nil
+}
+
+// List returns every organization document, sorted by slug.
+func (r *mongoOrganizationRepository) List(ctx context.Context) ([]domain.Organization, error) {
+	cursor, err := r.collection.Find(ctx, bson.M{}, options.Find().SetSort(bson.D{{Key: "slug", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	var recs []organizationRecord
+	if err := cursor.All(ctx, &recs); err != nil {
+		return nil, err
+	}
+	orgs := make([]domain.Organization, len(recs))
+	for i, rec := range recs {
+		orgs[i] = rec.toDomain()
+	}
+	return orgs, nil
+}
+
+// Delete removes an organization document by its ID.
+func (r *mongoOrganizationRepository) Delete(ctx context.Context, id string) error {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return usecase.ErrInvalidID
+	}
+	res, err := r.collection.DeleteOne(ctx, bson.M{"_id": oid})
+	if err != nil {
+		return err
+	}
+	if res.DeletedCount == 0 {
+		return usecase.ErrOrganizationNotFound
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"task_manager_test/internal/domain"
+	"task_manager_test/internal/usecase"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// mongoPasswordResetRepository is the MongoDB-based implementation of the IPasswordResetRepository interface.
+// Like access tokens, resets are found by globally unique hashes or by user, so the collection is not tenant-scoped.
+type mongoPasswordResetRepository struct {
+	collection *mongo.Collection
+}
+
+// Add a compile-time check to ensure this struct implements the correct interface.
+var _ usecase.IPasswordResetRepository = (*mongoPasswordResetRepository)(nil)
+
+// NewMongoPasswordResetRepository is the constructor for the implementation.
+func NewMongoPasswordResetRepository(db *mongo.Database) usecase.IPasswordResetRepository {
+	return &mongoPasswordResetRepository{
+		collection: db.Collection("password_resets"),
+	}
+}
+
+// passwordResetRecord is the BSON shape of a password reset document.
+type passwordResetRecord struct {
+	ID        primitive.ObjectID `bson:"_id"`
+	UserID    string             `bson:"user_id"`
+	TokenHash string             `bson:"token_hash"`
+	ExpiresAt time.Time          `bson:"expires_at"`
+	UsedAt    time.Time          `bson:"used_at,omitempty"`
+}
+
+// Create inserts a new reset document, generating a new unique ID.
+func (r *mongoPasswordResetRepository) Create(ctx context.Context, pr domain.PasswordReset) (domain.PasswordReset, error) {
+	oid := primitive.NewObjectID()
+	_, err := r.collection.InsertOne(ctx, bson.D{
+		{Key: "_id", Value: oid},
+		{Key: "user_id", Value: pr.UserID},
+		{Key: "token_hash", Value: pr.TokenHash},
+		{Key: "expires_at", Value: pr.ExpiresAt},
+	})
+	if err != nil {
+		return domain.PasswordReset{}, err
+	}
+	pr.ID = oid.Hex()
+	return pr, nil
+}
+
+// Consume marks an unused, unexpired reset as used in a single atomic update and returns it.
+func (r *mongoPasswordResetRepository) Consume(ctx context.Context, tokenHash string, now time.Time) (domain.PasswordReset, error) {
+	var rec passwordResetRecord
+	err := r.collection.FindOneAndUpdate(ctx,
+		bson.M{
+			"token_hash": tokenHash,
+			"used_at":    bson.M{"$exists": false},
+			"expires_at": bson.M{"$gt": now},
+		},
+		bson.M{"$set": bson.M{"used_at": now}},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(&rec)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return domain.PasswordReset{}, usecase.ErrNotFound
+		}
+		return domain.PasswordReset{}, err
+	}
+	return domain.PasswordReset{
+		ID:        rec.ID.Hex(),
+		UserID:    rec.UserID,
+		TokenHash: rec.TokenHash,
+		ExpiresAt: rec.ExpiresAt,
+		UsedAt:    rec.UsedAt,
+	}, nil
+}
+
+// DeleteByUser removes every reset document belonging to the user.
+func (r *mongoPasswordResetRepository) DeleteByUser(ctx context.Context, userID string) error {
+	_, err := r.collection.DeleteMany(ctx, bson.M{"user_id": userID})
+	return err
+}
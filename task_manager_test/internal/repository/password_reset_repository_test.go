@@ -0,0 +1,114 @@
+package repository
+
+import (
+	"context"
+	"os"
+	"task_manager_test/internal/domain"
+	"task_manager_test/internal/usecase"
+	"testing"
+	"time"
+
+	"github.com/joho/godotenv"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// PasswordResetRepositoryTestSuite defines the integration test suite for the password reset repository.
+type PasswordResetRepositoryTestSuite struct {
+	suite.Suite
+	client     *mongo.Client
+	db         *mongo.Database
+	collection *mongo.Collection
+	repository usecase.IPasswordResetRepository
+}
+
+// SetupSuite connects to the dedicated test database, skipping the suite when it is not configured.
+func (s *PasswordResetRepositoryTestSuite) SetupSuite() {
+	if err := godotenv.Load("../../.env"); err != nil {
+		s.T().Log("No .env file found, proceeding with environment variables")
+	}
+
+	uri := os.Getenv("MONGODB_URI_TEST")
+	if uri == "" {
+		s.T().Skip("MONGODB_URI_TEST environment variable not set, skipping integration tests")
+	}
+
+	client, err := mongo.Connect(context.Background(), options.Client().ApplyURI(uri))
+	assert.NoError(s.T(), err, "Failed to connect to MongoDB")
+
+	s.client = client
+	s.db = client.Database("passwordresetdb_test")
+	s.collection = s.db.Collection("password_resets_test")
+}
+
+// TearDownSuite drops the test database and disconnects.
+func (s *PasswordResetRepositoryTestSuite) TearDownSuite() {
+	if s.client != nil {
+		assert.NoError(s.T(), s.db.Drop(context.Background()), "Failed to drop test database")
+		assert.NoError(s.T(), s.client.Disconnect(context.Background()), "Failed to disconnect from MongoDB")
+	}
+}
+
+// SetupTest instantiates a repository bound to the test collection.
+func (s *PasswordResetRepositoryTestSuite) SetupTest() {
+	s.repository = NewMongoPasswordResetRepository(s.db)
+	(s.repository.(*mongoPasswordResetRepository)).collection = s.collection
+}
+
+// TearDownTest drops the collection to isolate tests.
+func (s *PasswordResetRepositoryTestSuite) TearDownTest() {
+	assert.NoError(s.T(), s.collection.Drop(context.Background()), "TearDownTest: failed to drop collection")
+}
+
+// TestPasswordResetRepository is the entry point for the test suite.
+func TestPasswordResetRepository(t *testing.T) {
+	suite.Run(t, new(PasswordResetRepositoryTestSuite))
+}
+
+// TestConsume_IsSingleUse verifies that a token can be consumed exactly once.
+func (s *PasswordResetRepositoryTestSuite) TestConsume_IsSingleUse() {
+	// ARRANGE
+	ctx := context.Background()
+	now := time.Now().UTC()
+	_, err := s.repository.Create(ctx, domain.PasswordReset{UserID: "user-1", TokenHash: "hash-1", ExpiresAt: now.Add(time.Hour)})
+	assert.NoError(s.T(), err, "Setup: failed to create reset")
+
+	// ACT
+	first, err1 := s.repository.Consume(ctx, "hash-1", now)
+	_, err2 := s.repository.Consume(ctx, "hash-1", now)
+
+	// ASSERT
+	assert.NoError(s.T(), err1)
+	assert.Equal(s.T(), "user-1", first.UserID)
+	assert.False(s.T(), first.UsedAt.IsZero(), "The consumed reset should record when it was used")
+	assert.ErrorIs(s.T(), err2, usecase.ErrNotFound, "A used token must not be accepted again")
+}
+
+// TestConsume_Fails_When_Expired verifies that expired tokens are rejected.
+func (s *PasswordResetRepositoryTestSuite) TestConsume_Fails_When_Expired() {
+	ctx := context.Background()
+	now := time.Now().UTC()
+	_, err := s.repository.Create(ctx, domain.PasswordReset{UserID: "user-1", TokenHash: "hash-1", ExpiresAt: now.Add(-time.Minute)})
+	assert.NoError(s.T(), err, "Setup: failed to create reset")
+
+	_, err = s.repository.Consume(ctx, "hash-1", now)
+
+	assert.ErrorIs(s.T(), err, usecase.ErrNotFound)
+}
+
+// TestDeleteByUser verifies that outstanding tokens are removed after a successful reset.
+func (s *PasswordResetRepositoryTestSuite) TestDeleteByUser() {
+	ctx := context.Background()
+	now := time.Now().UTC()
+	_, _ = s.repository.Create(ctx, domain.PasswordReset{UserID: "user-1", TokenHash: "hash-1", ExpiresAt: now.Add(time.Hour)})
+	_, _ = s.repository.Create(ctx, domain.PasswordReset{UserID: "user-2", TokenHash: "hash-2", ExpiresAt: now.Add(time.Hour)})
+
+	assert.NoError(s.T(), s.repository.DeleteByUser(ctx, "user-1"))
+
+	_, err1 := s.repository.Consume(ctx, "hash-1", now)
+	_, err2 := s.repository.Consume(ctx, "hash-2", now)
+	assert.ErrorIs(s.T(), err1, usecase.ErrNotFound)
+	assert.NoError(s.T(), err2, "Other users' tokens must be untouched")
+}
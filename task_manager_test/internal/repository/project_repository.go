@@ -0,0 +1,197 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"task_manager_test/internal/domain"
+	"task_manager_test/internal/usecase"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// mongoProjectRepository is the MongoDB-based implementation of the IProjectRepository interface.
+// Members are embedded in the project document so that membership changes are atomic.
+type mongoProjectRepository struct {
+	collection *tenantCollection
+}
+
+// Add a compile-time check to ensure this struct implements the correct interface.
+var _ usecase.IProjectRepository = (*mongoProjectRepository)(nil)
+
+// NewMongoProjectRepository is the constructor for the implementation.
+func NewMongoProjectRepository(db *mongo.Database) usecase.IProjectRepository {
+	return &mongoProjectRepository{
+		collection: newTenantCollection(db.Collection("projects")),
+	}
+}
+
+// projectMemberRecord is the BSON shape of a member embedded in a project document.
+type projectMemberRecord struct {
+	UserID   string             `bson:"user_id"`
+	Username string             `bson:"username"`
+	Role     domain.ProjectRole `bson:"role"`
+}
+
+// projectRecord is the BSON shape of a project document.
+type projectRecord struct {
+	ID          primitive.ObjectID    `bson:"_id"`
+	Name        string                `bson:"name"`
+	Description string                `bson:"description"`
+	Members     []projectMemberRecord `bson:"members"`
+	CreatedBy   string                `bson:"created_by"`
+	CreatedAt   time.Time             `bson:"created_at"`
+}
+
+// toDomain converts the stored document into a domain.Project.
+func (r projectRecord) toDomain() domain.Project {
+	members := make([]domain.ProjectMember, len(r.Members))
+	for i, m := range r.Members {
+		members[i] = domain.ProjectMember{UserID: m.UserID, Username: m.Username, Role: m.Role}
+	}
+	return domain.Project{
+		ID:          r.ID.Hex(),
+		Name:        r.Name,
+		Description: r.Description,
+		Members:     members,
+		CreatedBy:   r.CreatedBy,
+		CreatedAt:   r.CreatedAt,
+	}
+}
+
+// Create inserts a new project document, generating a new unique ID.
+func (r *mongoProjectRepository) Create(ctx context.Context, p domain.Project) (domain.Project, error) {
+	members := make([]projectMemberRecord, len(p.Members))
+	for i, m := range p.Members {
+		members[i] = projectMemberRecord{UserID: m.UserID, Username: m.Username, Role: m.Role}
+	}
+	rec := projectRecord{
+		ID:          primitive.NewObjectID(),
+		Name:        p.Name,
+		Description: p.Description,
+		Members:     members,
+		CreatedBy:   p.CreatedBy,
+		CreatedAt:   p.CreatedAt,
+	}
+	if _, err := r.collection.InsertOne(ctx, rec); err != nil {
+		return domain.Project{}, err
+	}
+	return rec.toDomain(), nil
+}
+
+// GetByID fetches a project by its hexadecimal string ID.
+func (r *mongoProjectRepository) GetByID(ctx context.Context, id string) (domain.Project, error) {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return domain.Project{}, usecase.ErrInvalidID
+	}
+	var rec projectRecord
+	if err := r.collection.FindOne(ctx, bson.M{"_id": oid}).Decode(&rec); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return domain.Project{}, usecase.ErrNotFound
+		}
+		return domain.Project{}, err
+	}
+	return rec.toDomain(), nil
+}
+
+// ListByMember returns the projects the user is a member of, ordered by name.
+func (r *mongoProjectRepository) ListByMember(ctx context.Context, userID string) ([]domain.Project, error) {
+	cursor, err := r.collection.Find(ctx, bson.M{"members.user_id": userID},
+		options.Find().SetSort(bson.D{{Key: "name", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var recs []projectRecord
+	if err := cursor.All(ctx, &recs); err != nil {
+		return nil, err
+	}
+	projects := make([]domain.Project, len(recs))
+	for i, rec := range recs {
+		projects[i] = rec.toDomain()
+	}
+	return projects, nil
+}
+
+// Update changes the name and description of the project and returns the stored project.
+func (r *mongoProjectRepository) Update(ctx context.Context, p domain.Project) (domain.Project, error) {
+	oid, err := primitive.ObjectIDFromHex(p.ID)
+	if err != nil {
+		return domain.Project{}, usecase.ErrInvalidID
+	}
+	return r.findOneAndUpdate(ctx, bson.M{"_id": oid},
+		bson.M{"$set": bson.M{"name": p.Name, "description": p.Description}})
+}
+
+// Delete removes a project document by its ID.
+func (r *mongoProjectRepository) Delete(ctx context.Context, id string) error {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return usecase.ErrInvalidID
+	}
+	res, err := r.collection.DeleteOne(ctx, bson.M{"_id": oid})
+	if err != nil {
+		return err
+	}
+	if res.DeletedCount == 0 {
+		return usecase.ErrNotFound
+	}
+	return nil
+}
+
+// SetMember changes the role of an existing member, or appends the member if the user is not in the project yet.
+func (r *mongoProjectRepository) SetMember(ctx context.Context, projectID string, m domain.ProjectMember) (domain.Project, error) {
+	oid, err := primitive.ObjectIDFromHex(projectID)
+	if err != nil {
+		return domain.Project{}, usecase.ErrInvalidID
+	}
+	p, err := r.findOneAndUpdate(ctx, bson.M{"_id": oid, "members.user_id": m.UserID},
+		bson.M{"$set": bson.M{"members.$.role": m.Role, "members.$.username": m.Username}})
+	if !errors.Is(err, usecase.ErrNotFound) {
+		return p, err
+	}
+	// The guard on members.user_id keeps a concurrent SetMember for the same user from adding it twice.
+	p, err = r.findOneAndUpdate(ctx, bson.M{"_id": oid, "members.user_id": bson.M{"$ne": m.UserID}},
+		bson.M{"$push": bson.M{"members": projectMemberRecord{UserID: m.UserID, Username: m.Username, Role: m.Role}}})
+	if errors.Is(err, usecase.ErrNotFound) {
+		// Either the project is gone or the member was added in the meantime.
+		return r.GetByID(ctx, projectID)
+	}
+	return p, err
+}
+
+// RemoveMember removes the user from the project, returning ErrNotFound if the user is not a member.
+func (r *mongoProjectRepository) RemoveMember(ctx context.Context, projectID, userID string) error {
+	oid, err := primitive.ObjectIDFromHex(projectID)
+	if err != nil {
+		return usecase.ErrInvalidID
+	}
+	res, err := r.collection.UpdateOne(ctx, bson.M{"_id": oid, "members.user_id": userID},
+		bson.M{"$pull": bson.M{"members": bson.M{"user_id": userID}}})
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return usecase.ErrNotFound
+	}
+	return nil
+}
+
+// findOneAndUpdate applies the update to the matching project and returns the updated document.
+func (r *mongoProjectRepository) findOneAndUpdate(ctx context.Context, filter, update bson.M) (domain.Project, error) {
+	var rec projectRecord
+	err := r.collection.FindOneAndUpdate(ctx, filter, update,
+		options.FindOneAndUpdate().SetReturnDocument(options.After)).Decode(&rec)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return domain.Project{}, usecase.ErrNotFound
+		}
+		return domain.Project{}, err
+	}
+	return rec.toDomain(), nil
+}
@@ -0,0 +1,137 @@
+package repository
+
+import (
+	"context"
+	"os"
+	"task_manager_test/internal/domain"
+	"task_manager_test/internal/usecase"
+	"testing"
+	"time"
+
+	"github.com/joho/godotenv"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ProjectRepositoryTestSuite defines the integration test suite for the project repository.
+type ProjectRepositoryTestSuite struct {
+	suite.Suite
+	client     *mongo.Client
+	db         *mongo.Database
+	collection *mongo.Collection
+	repository usecase.IProjectRepository
+}
+
+// SetupSuite connects to the dedicated test database, skipping the suite when it is not configured.
+func (s *ProjectRepositoryTestSuite) SetupSuite() {
+	if err := godotenv.Load("../../.env"); err != nil {
+		s.T().Log("No .env file found, proceeding with environment variables")
+	}
+
+	uri := os.Getenv("MONGODB_URI_TEST")
+	if uri == "" {
+		s.T().Skip("MONGODB_URI_TEST environment variable not set, skipping integration tests")
+	}
+
+	client, err := mongo.Connect(context.Background(), options.Client().ApplyURI(uri))
+	assert.NoError(s.T(), err, "Failed to connect to MongoDB")
+
+	s.client = client
+	s.db = client.Database("projectdb_test")
+	s.collection = s.db.Collection("projects_test")
+}
+
+// TearDownSuite drops the test database and disconnects.
+func (s *ProjectRepositoryTestSuite) TearDownSuite() {
+	if s.client != nil {
+		assert.NoError(s.T(), s.db.Drop(context.Background()), "Failed to drop test database")
+		assert.NoError(s.T(), s.client.Disconnect(context.Background()), "Failed to disconnect from MongoDB")
+	}
+}
+
+// SetupTest instantiates a repository bound to the test collection.
+func (s *ProjectRepositoryTestSuite) SetupTest() {
+	s.repository = NewMongoProjectRepository(s.db)
+	(s.repository.(*mongoProjectRepository)).collection = newTenantCollection(s.collection)
+}
+
+// TearDownTest drops the collection to isolate tests.
+func (s *ProjectRepositoryTestSuite) TearDownTest() {
+	assert.NoError(s.T(), s.collection.Drop(context.Background()), "TearDownTest: failed to drop collection")
+}
+
+// TestProjectRepository is the entry point for the test suite.
+func TestProjectRepository(t *testing.T) {
+	suite.Run(t, new(ProjectRepositoryTestSuite))
+}
+
+// create stores a project owned by user-1.
+func (s *ProjectRepositoryTestSuite) create(name string) domain.Project {
+	p, err := s.repository.Create(testTenant(), domain.Project{
+		Name:      name,
+		Members:   []domain.ProjectMember{{UserID: "user-1", Username: "alice", Role: domain.ProjectOwner}},
+		CreatedBy: "user-1",
+		CreatedAt: time.Now().UTC().Truncate(time.Millisecond),
+	})
+	assert.NoError(s.T(), err, "Setup: failed to create project")
+	return p
+}
+
+// TestListByMember_OrdersByName verifies that only the member's projects are listed, alphabetically.
+func (s *ProjectRepositoryTestSuite) TestListByMember_OrdersByName() {
+	// ARRANGE
+	ctx := testTenant()
+	zeta := s.create("Zeta")
+	alpha := s.create("Alpha")
+	_, _ = s.repository.Create(ctx, domain.Project{Name: "Other", Members: []domain.ProjectMember{{UserID: "user-2", Role: domain.ProjectOwner}}})
+
+	// ACT
+	projects, err := s.repository.ListByMember(ctx, "user-1")
+
+	// ASSERT
+	assert.NoError(s.T(), err)
+	assert.Len(s.T(), projects, 2)
+	assert.Equal(s.T(), alpha.ID, projects[0].ID)
+	assert.Equal(s.T(), zeta.ID, projects[1].ID)
+}
+
+// TestSetMember_AddsThenUpdates verifies that setting a member twice changes the role instead of duplicating it.
+func (s *ProjectRepositoryTestSuite) TestSetMember_AddsThenUpdates() {
+	// ARRANGE
+	ctx := testTenant()
+	p := s.create("Launch")
+
+	// ACT
+	_, err := s.repository.SetMember(ctx, p.ID, domain.ProjectMember{UserID: "user-2", Username: "bob", Role: domain.ProjectViewer})
+	assert.NoError(s.T(), err)
+	updated, err := s.repository.SetMember(ctx, p.ID, domain.ProjectMember{UserID: "user-2", Username: "bob", Role: domain.ProjectEditor})
+
+	// ASSERT
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), []domain.ProjectMember{
+		{UserID: "user-1", Username: "alice", Role: domain.ProjectOwner},
+		{UserID: "user-2", Username: "bob", Role: domain.ProjectEditor},
+	}, updated.Members)
+}
+
+// TestRemoveMember_Fails_When_NotAMember verifies that removing a non-member reports ErrNotFound.
+func (s *ProjectRepositoryTestSuite) TestRemoveMember_Fails_When_NotAMember() {
+	ctx := testTenant()
+	p := s.create("Launch")
+
+	assert.ErrorIs(s.T(), s.repository.RemoveMember(ctx, p.ID, "user-9"), usecase.ErrNotFound)
+	assert.NoError(s.T(), s.repository.RemoveMember(ctx, p.ID, "user-1"))
+
+	stored, err := s.repository.GetByID(ctx, p.ID)
+	assert.NoError(s.T(), err)
+	assert.Empty(s.T(), stored.Members)
+}
+
+// TestUpdate_Fails_When_NotFound verifies that updating a missing project reports ErrNotFound.
+func (s *ProjectRepositoryTestSuite) TestUpdate_Fails_When_NotFound() {
+	_, err := s.repository.Update(testTenant(), domain.Project{ID: "507f1f77bcf86cd799439011", Name: "Missing"})
+
+	assert.ErrorIs(s.T(), err, usecase.ErrNotFound)
+}
@@ -0,0 +1,266 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"log"
+	"math/rand/v2"
+	"sync"
+	"task_manager_test/internal/usecase"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/topology"
+)
+
+// ResilienceOptions configures the timeouts, retries and circuit breaker of a Resilience.
+type ResilienceOptions struct {
+	// Timeout bounds each attempt of an operation; zero leaves attempts bounded only by the caller's context.
+	Timeout time.Duration
+	// MaxRetries is how many times an operation that failed with a transient error is attempted again.
+	MaxRetries int
+	// RetryBackoff bounds the random delay before the first retry; the bound doubles with every further retry.
+	RetryBackoff time.Duration
+	// FailureThreshold is the number of consecutive failed attempts that opens the circuit; zero never opens it.
+	FailureThreshold int
+	// Cooldown is how long an open circuit refuses calls before it lets a single probe through.
+	Cooldown time.Duration
+	// Logger reports the circuit opening and closing; nil disables logging.
+	Logger *log.Logger
+}
+
+// Resilience guards database calls. Each attempt gets a deadline, operations failing with transient errors are
+// retried after a jittered, exponentially growing delay, and after too many consecutive failures a circuit breaker
+// refuses calls for a while so that requests fail fast, with ErrUnavailable, instead of piling up on a database that
+// cannot serve them.
+//
+// A single Resilience is shared by the repositories of a database, so that they observe its health together.
+type Resilience struct {
+	opts  ResilienceOptions
+	now   func() time.Time
+	sleep func(ctx context.Context, d time.Duration) error
+
+	mu        sync.Mutex
+	failures  int       // consecutive failed attempts
+	openUntil time.Time // end of the current cooldown while the circuit is open
+	probing   bool      // whether the probe of a half-open circuit is in flight
+}
+
+// NewResilience returns a Resilience with the given options and a closed circuit.
+func NewResilience(opts ResilienceOptions) *Resilience {
+	return &Resilience{opts: opts, now: time.Now, sleep: sleepContext}
+}
+
+// opKind tells reads, which may always be repeated, from writes, which may only be repeated when the database
+// guarantees that the failed attempt had no effect.
+type opKind int
+
+const (
+	opRead opKind = iota
+	opWrite
+)
+
+// do runs fn under the guard. Transient failures that outlast the retries are reported as *usecase.UnavailableError,
+// as are calls refused by the open circuit; other errors, and those caused by the caller giving up, are returned as
+// they are.
+func (r *Resilience) do(ctx context.Context, kind opKind, fn func(ctx context.Context) error) error {
+	for attempt := 0; ; attempt++ {
+		probe, err := r.acquire()
+		if err != nil {
+			return err
+		}
+		err = r.attempt(ctx, fn)
+		if ctx.Err() != nil {
+			// The caller gave up, so the attempt says nothing about the database's health either way.
+			r.abandon(probe)
+			return err
+		}
+		failed := transient(err)
+		r.release(probe, failed, err)
+		if !failed {
+			return err
+		}
+		if attempt >= r.opts.MaxRetries || !retryable(kind, err) {
+			return &usecase.UnavailableError{Err: err}
+		}
+		if err := r.sleep(ctx, r.backoff(attempt)); err != nil {
+			return err
+		}
+	}
+}
+
+// attempt runs fn once with the per-attempt timeout.
+func (r *Resilience) attempt(ctx context.Context, fn func(ctx context.Context) error) error {
+	if r.opts.Timeout <= 0 {
+		return fn(ctx)
+	}
+	ctx, cancel := context.WithTimeout(ctx, r.opts.Timeout)
+	defer cancel()
+	return fn(ctx)
+}
+
+// backoff returns a random delay before the given retry, between zero and RetryBackoff doubled once per earlier
+// retry. Spreading retries out keeps clients that failed together from retrying together.
+func (r *Resilience) backoff(attempt int) time.Duration {
+	bound := r.opts.RetryBackoff << min(attempt, 16)
+	if bound <= 0 {
+		return 0
+	}
+	return rand.N(bound)
+}
+
+// acquire asks the circuit breaker for permission to make an attempt. While the circuit is open it refuses, telling
+// the caller when to come back; once the cooldown has passed it lets one probe through, and reports that the
+// attempt is the probe.
+func (r *Resilience) acquire() (probe bool, err error) {
+	if r.opts.FailureThreshold <= 0 {
+		return false, nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.failures < r.opts.FailureThreshold {
+		return false, nil
+	}
+	now := r.now()
+	if now.Before(r.openUntil) {
+		return false, &usecase.UnavailableError{RetryAfter: r.openUntil.Sub(now)}
+	}
+	if r.probing {
+		return false, &usecase.UnavailableError{RetryAfter: r.opts.Cooldown}
+	}
+	r.probing = true
+	return true, nil
+}
+
+// release records the outcome of an attempt. A success closes the circuit; a failure that reaches the threshold,
+// including that of a probe, opens it for another cooldown.
+func (r *Resilience) release(probe, failed bool, err error) {
+	if r.opts.FailureThreshold <= 0 {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if probe {
+		r.probing = false
+	}
+	if !failed {
+		if r.failures >= r.opts.FailureThreshold {
+			r.logf("database circuit closed")
+		}
+		r.failures = 0
+		return
+	}
+	r.failures++
+	if r.failures >= r.opts.FailureThreshold {
+		if r.failures == r.opts.FailureThreshold || probe {
+			r.logf("database circuit opened for %s after %d consecutive failures: %v", r.opts.Cooldown, r.failures, err)
+		}
+		r.openUntil = r.now().Add(r.opts.Cooldown)
+	}
+}
+
+// abandon gives up an attempt without recording its outcome. An abandoned probe leaves the circuit open, so that the
+// next call after it probes again.
+func (r *Resilience) abandon(probe bool) {
+	if !probe {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.probing = false
+}
+
+// logf logs to the configured logger, if any.
+func (r *Resilience) logf(format string, args ...any) {
+	if r.opts.Logger != nil {
+		r.opts.Logger.Printf(format, args...)
+	}
+}
+
+// transientCodes are the server error codes of failures that go away by themselves, such as a replica set
+// election or a node shutting down.
+var transientCodes = []int{
+	6,     // HostUnreachable
+	7,     // HostNotFound
+	89,    // NetworkTimeout
+	91,    // ShutdownInProgress
+	189,   // PrimarySteppedDown
+	9001,  // SocketException
+	10107, // NotWritablePrimary
+	11600, // InterruptedAtShutdown
+	11602, // InterruptedDueToReplStateChange
+	13435, // NotPrimaryNoSecondaryOk
+	13436, // NotPrimaryOrSecondary
+}
+
+// transient reports whether err is a failure to reach or hear back from the database, as opposed to an answer such
+// as a missing document or a duplicate key.
+func transient(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, context.DeadlineExceeded) || mongo.IsTimeout(err) || mongo.IsNetworkError(err) {
+		return true
+	}
+	var selection topology.ServerSelectionError
+	if errors.As(err, &selection) {
+		return true
+	}
+	var serverErr mongo.ServerError
+	if errors.As(err, &serverErr) {
+		if serverErr.HasErrorLabel("RetryableWriteError") || serverErr.HasErrorLabel("TransientTransactionError") {
+			return true
+		}
+		for _, code := range transientCodes {
+			if serverErr.HasErrorCode(code) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// retryable reports whether an operation of the given kind that failed with the transient error may be attempted
+// again. Writes may have been applied before the failure unless no server was selected to run them, or the server
+// labelled the error as safe to retry.
+func retryable(kind opKind, err error) bool {
+	if kind == opRead {
+		return true
+	}
+	var selection topology.ServerSelectionError
+	if errors.As(err, &selection) {
+		return true
+	}
+	var labeled mongo.LabeledError
+	return errors.As(err, &labeled) && labeled.HasErrorLabel("RetryableWriteError")
+}
+
+// sleepContext waits for d, or until ctx is done.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// guard runs fn under r, returning its result or the zero value on failure.
+func guard[T any](ctx context.Context, r *Resilience, kind opKind, fn func(ctx context.Context) (T, error)) (T, error) {
+	var out T
+	err := r.do(ctx, kind, func(ctx context.Context) error {
+		var err error
+		out, err = fn(ctx)
+		return err
+	})
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return out, nil
+}
@@ -0,0 +1,298 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"task_manager_test/internal/domain"
+	"task_manager_test/internal/mocks"
+	"task_manager_test/internal/usecase"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/topology"
+)
+
+// Errors as returned by the driver when the database cannot be reached or is failing over.
+var (
+	errNetwork        = mongo.CommandError{Message: "connection reset", Labels: []string{"NetworkError"}}
+	errStepDown       = mongo.CommandError{Code: 189, Message: "primary stepped down"}
+	errRetryableWrite = mongo.CommandError{Code: 91, Message: "shutdown in progress", Labels: []string{"RetryableWriteError"}}
+	errNoServer       = topology.ServerSelectionError{Wrapped: errors.New("server selection timeout")}
+	errDuplicateKey   = mongo.WriteException{WriteErrors: []mongo.WriteError{{Code: 11000, Message: "duplicate key"}}}
+	errNotFound       = usecase.ErrNotFound
+)
+
+// ResilienceTestSuite defines the test suite for the timeouts, retries and circuit breaker around the database.
+type ResilienceTestSuite struct {
+	suite.Suite
+	r      *Resilience
+	now    time.Time
+	sleeps []time.Duration
+	ctx    context.Context
+}
+
+// SetupTest runs before EACH test in the suite.
+func (s *ResilienceTestSuite) SetupTest() {
+	s.r = NewResilience(ResilienceOptions{
+		Timeout:          time.Second,
+		MaxRetries:       2,
+		RetryBackoff:     100 * time.Millisecond,
+		FailureThreshold: 3,
+		Cooldown:         10 * time.Second,
+	})
+	s.now = time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	s.r.now = func() time.Time { return s.now }
+	s.sleeps = nil
+	s.r.sleep = func(ctx context.Context, d time.Duration) error {
+		s.sleeps = append(s.sleeps, d)
+		return ctx.Err()
+	}
+	s.ctx = context.Background()
+}
+
+// TestResilienceTestSuite is the Go test runner's entry point for this suite.
+func TestResilienceTestSuite(t *testing.T) {
+	suite.Run(t, new(ResilienceTestSuite))
+}
+
+// failing returns an operation that fails with the given errors in turn and then succeeds, and a pointer to the
+// number of attempts made.
+func failing(errs ...error) (func(ctx context.Context) error, *int) {
+	attempts := 0
+	return func(ctx context.Context) error {
+		attempts++
+		if attempts <= len(errs) {
+			return errs[attempts-1]
+		}
+		return nil
+	}, &attempts
+}
+
+// TestDo_PassesThroughAnswers tests that successes and errors that are answers from the database are neither
+// retried nor wrapped.
+func (s *ResilienceTestSuite) TestDo_PassesThroughAnswers() {
+	for _, want := range []error{nil, errNotFound, errDuplicateKey} {
+		op, attempts := failing(want)
+		if want == nil {
+			op, attempts = failing()
+		}
+
+		err := s.r.do(s.ctx, opRead, op)
+
+		s.Equal(want, err)
+		s.Equal(1, *attempts)
+	}
+	s.Empty(s.sleeps)
+}
+
+// TestDo_RetriesTransientReads tests that reads are retried after a jittered delay until they succeed.
+func (s *ResilienceTestSuite) TestDo_RetriesTransientReads() {
+	op, attempts := failing(errNetwork, errStepDown)
+
+	err := s.r.do(s.ctx, opRead, op)
+
+	s.NoError(err)
+	s.Equal(3, *attempts)
+	s.Require().Len(s.sleeps, 2)
+	s.Less(s.sleeps[0], 100*time.Millisecond)
+	s.Less(s.sleeps[1], 200*time.Millisecond)
+}
+
+// TestDo_ReportsUnavailableAfterRetries tests that a transient failure outlasting the retries is reported as
+// unavailable, keeping the last failure as the cause.
+func (s *ResilienceTestSuite) TestDo_ReportsUnavailableAfterRetries() {
+	op, attempts := failing(errNetwork, errNetwork, errNoServer)
+
+	err := s.r.do(s.ctx, opRead, op)
+
+	var unavailable *usecase.UnavailableError
+	s.Require().ErrorAs(err, &unavailable)
+	s.ErrorIs(err, usecase.ErrUnavailable)
+	s.Zero(unavailable.RetryAfter)
+	s.ErrorAs(err, &topology.ServerSelectionError{})
+	s.Equal(3, *attempts)
+}
+
+// TestDo_RetriesWritesOnlyWhenSafe tests that writes are retried only when they cannot have been applied.
+func (s *ResilienceTestSuite) TestDo_RetriesWritesOnlyWhenSafe() {
+	s.r.opts.FailureThreshold = 0
+	op, attempts := failing(errNetwork)
+	err := s.r.do(s.ctx, opWrite, op)
+	s.ErrorIs(err, usecase.ErrUnavailable)
+	s.Equal(1, *attempts, "A write whose connection dropped may have been applied")
+
+	op, attempts = failing(errNoServer, errRetryableWrite)
+	err = s.r.do(s.ctx, opWrite, op)
+	s.NoError(err)
+	s.Equal(3, *attempts)
+}
+
+// TestDo_BoundsEachAttempt tests that every attempt runs with the operation timeout and a timed out attempt counts
+// as a failure of the database.
+func (s *ResilienceTestSuite) TestDo_BoundsEachAttempt() {
+	s.r.opts.Timeout = 10 * time.Millisecond
+	attempts := 0
+
+	err := s.r.do(s.ctx, opRead, func(ctx context.Context) error {
+		attempts++
+		_, hasDeadline := ctx.Deadline()
+		s.True(hasDeadline)
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	s.ErrorIs(err, usecase.ErrUnavailable)
+	s.ErrorIs(err, context.DeadlineExceeded)
+	s.Equal(3, attempts)
+}
+
+// TestDo_StopsWhenCallerGivesUp tests that the caller's own cancellation is neither retried nor blamed on the
+// database.
+func (s *ResilienceTestSuite) TestDo_StopsWhenCallerGivesUp() {
+	ctx, cancel := context.WithCancel(s.ctx)
+	cancel()
+	for range 5 {
+		attempts := 0
+		err := s.r.do(ctx, opRead, func(ctx context.Context) error {
+			attempts++
+			return ctx.Err()
+		})
+
+		s.ErrorIs(err, context.Canceled)
+		s.NotErrorIs(err, usecase.ErrUnavailable)
+		s.Equal(1, attempts)
+	}
+
+	op, _ := failing()
+	s.NoError(s.r.do(s.ctx, opRead, op), "Cancelled calls must not open the circuit")
+}
+
+// TestBreaker_OpensAfterConsecutiveFailures tests that the circuit opens after the threshold and then refuses calls
+// without attempting them, telling callers when to come back.
+func (s *ResilienceTestSuite) TestBreaker_OpensAfterConsecutiveFailures() {
+	s.r.opts.MaxRetries = 0
+	for range 3 {
+		op, _ := failing(errNetwork)
+		s.ErrorIs(s.r.do(s.ctx, opRead, op), usecase.ErrUnavailable)
+	}
+
+	s.now = s.now.Add(4 * time.Second)
+	op, attempts := failing()
+	err := s.r.do(s.ctx, opRead, op)
+
+	var unavailable *usecase.UnavailableError
+	s.Require().ErrorAs(err, &unavailable)
+	s.Equal(6*time.Second, unavailable.RetryAfter)
+	s.Nil(unavailable.Err)
+	s.Zero(*attempts)
+}
+
+// TestBreaker_SuccessResetsFailures tests that only consecutive failures open the circuit.
+func (s *ResilienceTestSuite) TestBreaker_SuccessResetsFailures() {
+	s.r.opts.MaxRetries = 0
+	for _, want := range []error{errNetwork, errNetwork, nil, errNetwork, errNetwork, nil} {
+		op, _ := failing(want)
+		if want == nil {
+			op, _ = failing()
+		}
+		_ = s.r.do(s.ctx, opRead, op)
+	}
+
+	op, attempts := failing()
+	s.NoError(s.r.do(s.ctx, opRead, op))
+	s.Equal(1, *attempts)
+}
+
+// TestBreaker_ProbesAfterCooldown tests that a single probe is let through once the cooldown has passed, and that
+// its outcome closes or reopens the circuit.
+func (s *ResilienceTestSuite) TestBreaker_ProbesAfterCooldown() {
+	s.r.opts.MaxRetries = 0
+	for range 3 {
+		op, _ := failing(errNetwork)
+		_ = s.r.do(s.ctx, opRead, op)
+	}
+
+	// A failed probe opens the circuit for another cooldown.
+	s.now = s.now.Add(10 * time.Second)
+	op, attempts := failing(errNetwork)
+	s.ErrorIs(s.r.do(s.ctx, opRead, op), usecase.ErrUnavailable)
+	s.Equal(1, *attempts)
+	op, attempts = failing()
+	s.ErrorIs(s.r.do(s.ctx, opRead, op), usecase.ErrUnavailable)
+	s.Zero(*attempts)
+
+	// While a probe is in flight, other calls are still refused.
+	s.now = s.now.Add(10 * time.Second)
+	err := s.r.do(s.ctx, opRead, func(ctx context.Context) error {
+		op, attempts := failing()
+		s.ErrorIs(s.r.do(s.ctx, opRead, op), usecase.ErrUnavailable)
+		s.Zero(*attempts)
+		return nil
+	})
+	s.NoError(err)
+
+	// A successful probe closes the circuit.
+	op, attempts = failing()
+	s.NoError(s.r.do(s.ctx, opRead, op))
+	s.Equal(1, *attempts)
+}
+
+// TestBreaker_CancelledProbeKeepsCircuitOpen tests that a probe whose caller gave up neither closes nor reopens the
+// circuit, and that the next call probes in its place.
+func (s *ResilienceTestSuite) TestBreaker_CancelledProbeKeepsCircuitOpen() {
+	s.r.opts.MaxRetries = 0
+	for range 3 {
+		op, _ := failing(errNetwork)
+		_ = s.r.do(s.ctx, opRead, op)
+	}
+	s.now = s.now.Add(10 * time.Second)
+
+	ctx, cancel := context.WithCancel(s.ctx)
+	err := s.r.do(ctx, opRead, func(ctx context.Context) error {
+		cancel()
+		return ctx.Err()
+	})
+	s.ErrorIs(err, context.Canceled)
+
+	// The circuit is still half open: the next call is let through as a probe, and its failure reopens the circuit.
+	op, attempts := failing(errNetwork)
+	s.ErrorIs(s.r.do(s.ctx, opRead, op), usecase.ErrUnavailable)
+	s.Equal(1, *attempts)
+	op, attempts = failing()
+	s.ErrorIs(s.r.do(s.ctx, opRead, op), usecase.ErrUnavailable)
+	s.Zero(*attempts, "The failed probe should have reopened the circuit")
+}
+
+// TestBreaker_Disabled tests that a zero threshold never refuses calls.
+func (s *ResilienceTestSuite) TestBreaker_Disabled() {
+	s.r.opts.MaxRetries = 0
+	s.r.opts.FailureThreshold = 0
+	for range 10 {
+		op, _ := failing(errNetwork)
+		_ = s.r.do(s.ctx, opRead, op)
+	}
+
+	op, attempts := failing()
+	s.NoError(s.r.do(s.ctx, opRead, op))
+	s.Equal(1, *attempts)
+}
+
+// TestDecorator_RetriesThroughRepository tests that repository calls are guarded and their results returned.
+func (s *ResilienceTestSuite) TestDecorator_RetriesThroughRepository() {
+	next := mocks.NewICommentRepository(s.T())
+	repo := NewResilientCommentRepository(next, s.r)
+	next.On("ListTopLevel", mock.Anything, "t1", 0, 10).Return(nil, int64(0), errStepDown).Once()
+	next.On("ListTopLevel", mock.Anything, "t1", 0, 10).Return([]domain.Comment{{ID: "c1"}}, int64(7), nil).Once()
+	next.On("Create", mock.Anything, domain.Comment{Body: "hi"}).Return(domain.Comment{}, errNetwork).Once()
+
+	comments, total, err := repo.ListTopLevel(s.ctx, "t1", 0, 10)
+	s.NoError(err)
+	s.Len(comments, 1)
+	s.Equal(int64(7), total)
+
+	_, err = repo.Create(s.ctx, domain.Comment{Body: "hi"})
+	s.ErrorIs(err, usecase.ErrUnavailable)
+}
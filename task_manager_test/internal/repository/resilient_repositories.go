@@ -0,0 +1,579 @@
+package repository
+
+import (
+	"context"
+	"task_manager_test/internal/domain"
+	"task_manager_test/internal/usecase"
+	"time"
+)
+
+// The decorators below run every call of a repository under a shared Resilience. Each call is declared as a read
+// or a write, which decides whether it may be retried after an ambiguous failure.
+
+// resilientUserRepository guards a user repository.
+type resilientUserRepository struct {
+	next usecase.IUserRepository
+	r    *Resilience
+}
+
+// Add a compile-time check to ensure this struct implements the correct interface.
+var _ usecase.IUserRepository = (*resilientUserRepository)(nil)
+
+// NewResilientUserRepository wraps next so that its calls are guarded by r.
+func NewResilientUserRepository(next usecase.IUserRepository, r *Resilience) usecase.IUserRepository {
+	return &resilientUserRepository{next: next, r: r}
+}
+
+// Create is guarded as a write.
+func (d *resilientUserRepository) Create(ctx context.Context, u domain.User) (domain.User, error) {
+	return guard(ctx, d.r, opWrite, func(ctx context.Context) (domain.User, error) { return d.next.Create(ctx, u) })
+}
+
+// FindByUsername is guarded as a read.
+func (d *resilientUserRepository) FindByUsername(ctx context.Context, username string) (domain.User, error) {
+	return guard(ctx, d.r, opRead, func(ctx context.Context) (domain.User, error) { return d.next.FindByUsername(ctx, username) })
+}
+
+// FindByID is guarded as a read.
+func (d *resilientUserRepository) FindByID(ctx context.Context, id string) (domain.User, error) {
+	return guard(ctx, d.r, opRead, func(ctx context.Context) (domain.User, error) { return d.next.FindByID(ctx, id) })
+}
+
+// UpdatePassword is guarded as a write.
+func (d *resilientUserRepository) UpdatePassword(ctx context.Context, id, hashedPassword string) error {
+	return d.r.do(ctx, opWrite, func(ctx context.Context) error { return d.next.UpdatePassword(ctx, id, hashedPassword) })
+}
+
+// UpdateRole is guarded as a write.
+func (d *resilientUserRepository) UpdateRole(ctx context.Context, username string, role domain.Role) error {
+	return d.r.do(ctx, opWrite, func(ctx context.Context) error { return d.next.UpdateRole(ctx, username, role) })
+}
+
+// IncrementFailedLogins is guarded as a write.
+func (d *resilientUserRepository) IncrementFailedLogins(ctx context.Context, username string) (int, error) {
+	return guard(ctx, d.r, opWrite, func(ctx context.Context) (int, error) { return d.next.IncrementFailedLogins(ctx, username) })
+}
+
+// Lock is guarded as a write.
+func (d *resilientUserRepository) Lock(ctx context.Context, username string, until time.Time) error {
+	return d.r.do(ctx, opWrite, func(ctx context.Context) error { return d.next.Lock(ctx, username, until) })
+}
+
+// ResetFailedLogins is guarded as a write.
+func (d *resilientUserRepository) ResetFailedLogins(ctx context.Context, username string) error {
+	return d.r.do(ctx, opWrite, func(ctx context.Context) error { return d.next.ResetFailedLogins(ctx, username) })
+}
+
+// List is guarded as a read.
+func (d *resilientUserRepository) List(ctx context.Context) ([]domain.User, error) {
+	return guard(ctx, d.r, opRead, d.next.List)
+}
+
+// SetDisabled is guarded as a write.
+func (d *resilientUserRepository) SetDisabled(ctx context.Context, username string, disabled bool) error {
+	return d.r.do(ctx, opWrite, func(ctx context.Context) error { return d.next.SetDisabled(ctx, username, disabled) })
+}
+
+// SetTimeZone is guarded as a write.
+func (d *resilientUserRepository) SetTimeZone(ctx context.Context, id, name string) error {
+	return d.r.do(ctx, opWrite, func(ctx context.Context) error { return d.next.SetTimeZone(ctx, id, name) })
+}
+
+// ReserveAttachmentSpace is guarded as a write.
+func (d *resilientUserRepository) ReserveAttachmentSpace(ctx context.Context, userID string, maxSize, quota int64) (int64, error) {
+	return guard(ctx, d.r, opWrite, func(ctx context.Context) (int64, error) {
+		return d.next.ReserveAttachmentSpace(ctx, userID, maxSize, quota)
+	})
+}
+
+// ReleaseAttachmentSpace is guarded as a write.
+func (d *resilientUserRepository) ReleaseAttachmentSpace(ctx context.Context, userID string, size int64) error {
+	return d.r.do(ctx, opWrite, func(ctx context.Context) error { return d.next.ReleaseAttachmentSpace(ctx, userID, size) })
+}
+
+// resilientOrganizationRepository guards an organization repository.
+type resilientOrganizationRepository struct {
+	next usecase.IOrganizationRepository
+	r    *Resilience
+}
+
+// Add a compile-time check to ensure this struct implements the correct interface.
+var _ usecase.IOrganizationRepository = (*resilientOrganizationRepository)(nil)
+
+// NewResilientOrganizationRepository wraps next so that its calls are guarded by r.
+func NewResilientOrganizationRepository(next usecase.IOrganizationRepository, r *Resilience) usecase.IOrganizationRepository {
+	return &resilientOrganizationRepository{next: next, r: r}
+}
+
+// Create is guarded as a write.
+func (d *resilientOrganizationRepository) Create(ctx context.Context, org domain.Organization) (domain.Organization, error) {
+	return guard(ctx, d.r, opWrite, func(ctx context.Context) (domain.Organization, error) { return d.next.Create(ctx, org) })
+}
+
+// GetByID is guarded as a read.
+func (d *resilientOrganizationRepository) GetByID(ctx context.Context, id string) (domain.Organization, error) {
+	return guard(ctx, d.r, opRead, func(ctx context.Context) (domain.Organization, error) { return d.next.GetByID(ctx, id) })
+}
+
+// FindBySlug is guarded as a read.
+func (d *resilientOrganizationRepository) FindBySlug(ctx context.Context, slug string) (domain.Organization, error) {
+	return guard(ctx, d.r, opRead, func(ctx context.Context) (domain.Organization, error) { return d.next.FindBySlug(ctx, slug) })
+}
+
+// List is guarded as a read.
+func (d *resilientOrganizationRepository) List(ctx context.Context) ([]domain.Organization, error) {
+	return guard(ctx, d.r, opRead, d.next.List)
+}
+
+// Delete is guarded as a write.
+func (d *resilientOrganizationRepository) Delete(ctx context.Context, id string) error {
+	return d.r.do(ctx, opWrite, func(ctx context.Context) error { return d.next.Delete(ctx, id) })
+}
+
+// resilientPasswordResetRepository guards a password reset repository.
+type resilientPasswordResetRepository struct {
+	next usecase.IPasswordResetRepository
+	r    *Resilience
+}
+
+// Add a compile-time check to ensure this struct implements the correct interface.
+var _ usecase.IPasswordResetRepository = (*resilientPasswordResetRepository)(nil)
+
+// NewResilientPasswordResetRepository wraps next so that its calls are guarded by r.
+func NewResilientPasswordResetRepository(next usecase.IPasswordResetRepository, r *Resilience) usecase.IPasswordResetRepository {
+	return &resilientPasswordResetRepository{next: next, r: r}
+}
+
+// Create is guarded as a write.
+func (d *resilientPasswordResetRepository) Create(ctx context.Context, pr domain.PasswordReset) (domain.PasswordReset, error) {
+	return guard(ctx, d.r, opWrite, func(ctx context.Context) (domain.PasswordReset, error) { return d.next.Create(ctx, pr) })
+}
+
+// Consume is guarded as a write.
+func (d *resilientPasswordResetRepository) Consume(ctx context.Context, tokenHash string, now time.Time) (domain.PasswordReset, error) {
+	return guard(ctx, d.r, opWrite, func(ctx context.Context) (domain.PasswordReset, error) { return d.next.Consume(ctx, tokenHash, now) })
+}
+
+// DeleteByUser is guarded as a write.
+func (d *resilientPasswordResetRepository) DeleteByUser(ctx context.Context, userID string) error {
+	return d.r.do(ctx, opWrite, func(ctx context.Context) error { return d.next.DeleteByUser(ctx, userID) })
+}
+
+// resilientAccessTokenRepository guards an access token repository.
+type resilientAccessTokenRepository struct {
+	next usecase.IAccessTokenRepository
+	r    *Resilience
+}
+
+// Add a compile-time check to ensure this struct implements the correct interface.
+var _ usecase.IAccessTokenRepository = (*resilientAccessTokenRepository)(nil)
+
+// NewResilientAccessTokenRepository wraps next so that its calls are guarded by r.
+func NewResilientAccessTokenRepository(next usecase.IAccessTokenRepository, r *Resilience) usecase.IAccessTokenRepository {
+	return &resilientAccessTokenRepository{next: next, r: r}
+}
+
+// Create is guarded as a write.
+func (d *resilientAccessTokenRepository) Create(ctx context.Context, t domain.AccessToken) (domain.AccessToken, error) {
+	return guard(ctx, d.r, opWrite, func(ctx context.Context) (domain.AccessToken, error) { return d.next.Create(ctx, t) })
+}
+
+// ListByUser is guarded as a read.
+func (d *resilientAccessTokenRepository) ListByUser(ctx context.Context, userID string) ([]domain.AccessToken, error) {
+	return guard(ctx, d.r, opRead, func(ctx context.Context) ([]domain.AccessToken, error) { return d.next.ListByUser(ctx, userID) })
+}
+
+// FindByHash is guarded as a read.
+func (d *resilientAccessTokenRepository) FindByHash(ctx context.Context, tokenHash string) (domain.AccessToken, error) {
+	return guard(ctx, d.r, opRead, func(ctx context.Context) (domain.AccessToken, error) { return d.next.FindByHash(ctx, tokenHash) })
+}
+
+// Revoke is guarded as a write.
+func (d *resilientAccessTokenRepository) Revoke(ctx context.Context, userID, id string, now time.Time) error {
+	return d.r.do(ctx, opWrite, func(ctx context.Context) error { return d.next.Revoke(ctx, userID, id, now) })
+}
+
+// TouchLastUsed is guarded as a write.
+func (d *resilientAccessTokenRepository) TouchLastUsed(ctx context.Context, id string, now time.Time) error {
+	return d.r.do(ctx, opWrite, func(ctx context.Context) error { return d.next.TouchLastUsed(ctx, id, now) })
+}
+
+// resilientTaskShareRepository guards a task share repository.
+type resilientTaskShareRepository struct {
+	next usecase.ITaskShareRepository
+	r    *Resilience
+}
+
+// Add a compile-time check to ensure this struct implements the correct interface.
+var _ usecase.ITaskShareRepository = (*resilientTaskShareRepository)(nil)
+
+// NewResilientTaskShareRepository wraps next so that its calls are guarded by r.
+func NewResilientTaskShareRepository(next usecase.ITaskShareRepository, r *Resilience) usecase.ITaskShareRepository {
+	return &resilientTaskShareRepository{next: next, r: r}
+}
+
+// Upsert is guarded as a write.
+func (d *resilientTaskShareRepository) Upsert(ctx context.Context, s domain.TaskShare) (domain.TaskShare, error) {
+	return guard(ctx, d.r, opWrite, func(ctx context.Context) (domain.TaskShare, error) { return d.next.Upsert(ctx, s) })
+}
+
+// Find is guarded as a read.
+func (d *resilientTaskShareRepository) Find(ctx context.Context, taskID, userID string) (domain.TaskShare, error) {
+	return guard(ctx, d.r, opRead, func(ctx context.Context) (domain.TaskShare, error) { return d.next.Find(ctx, taskID, userID) })
+}
+
+// ListByTask is guarded as a read.
+func (d *resilientTaskShareRepository) ListByTask(ctx context.Context, taskID string) ([]domain.TaskShare, error) {
+	return guard(ctx, d.r, opRead, func(ctx context.Context) ([]domain.TaskShare, error) { return d.next.ListByTask(ctx, taskID) })
+}
+
+// ListByUser is guarded as a read.
+func (d *resilientTaskShareRepository) ListByUser(ctx context.Context, userID string) ([]domain.TaskShare, error) {
+	return guard(ctx, d.r, opRead, func(ctx context.Context) ([]domain.TaskShare, error) { return d.next.ListByUser(ctx, userID) })
+}
+
+// Delete is guarded as a write.
+func (d *resilientTaskShareRepository) Delete(ctx context.Context, taskID, userID string) error {
+	return d.r.do(ctx, opWrite, func(ctx context.Context) error { return d.next.Delete(ctx, taskID, userID) })
+}
+
+// DeleteByTask is guarded as a write.
+func (d *resilientTaskShareRepository) DeleteByTask(ctx context.Context, taskID string) error {
+	return d.r.do(ctx, opWrite, func(ctx context.Context) error { return d.next.DeleteByTask(ctx, taskID) })
+}
+
+// resilientCommentRepository guards a comment repository.
+type resilientCommentRepository struct {
+	next usecase.ICommentRepository
+	r    *Resilience
+}
+
+// Add a compile-time check to ensure this struct implements the correct interface.
+var _ usecase.ICommentRepository = (*resilientCommentRepository)(nil)
+
+// NewResilientCommentRepository wraps next so that its calls are guarded by r.
+func NewResilientCommentRepository(next usecase.ICommentRepository, r *Resilience) usecase.ICommentRepository {
+	return &resilientCommentRepository{next: next, r: r}
+}
+
+// Create is guarded as a write.
+func (d *resilientCommentRepository) Create(ctx context.Context, c domain.Comment) (domain.Comment, error) {
+	return guard(ctx, d.r, opWrite, func(ctx context.Context) (domain.Comment, error) { return d.next.Create(ctx, c) })
+}
+
+// GetByID is guarded as a read.
+func (d *resilientCommentRepository) GetByID(ctx context.Context, id string) (domain.Comment, error) {
+	return guard(ctx, d.r, opRead, func(ctx context.Context) (domain.Comment, error) { return d.next.GetByID(ctx, id) })
+}
+
+// ListTopLevel is guarded as a read.
+func (d *resilientCommentRepository) ListTopLevel(ctx context.Context, taskID string, skip, limit int) ([]domain.Comment, int64, error) {
+	var total int64
+	comments, err := guard(ctx, d.r, opRead, func(ctx context.Context) ([]domain.Comment, error) {
+		var (
+			page []domain.Comment
+			err  error
+		)
+		page, total, err = d.next.ListTopLevel(ctx, taskID, skip, limit)
+		return page, err
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	return comments, total, nil
+}
+
+// ListReplies is guarded as a read.
+func (d *resilientCommentRepository) ListReplies(ctx context.Context, parentIDs []string) ([]domain.Comment, error) {
+	return guard(ctx, d.r, opRead, func(ctx context.Context) ([]domain.Comment, error) { return d.next.ListReplies(ctx, parentIDs) })
+}
+
+// ListByAuthor is guarded as a read.
+func (d *resilientCommentRepository) ListByAuthor(ctx context.Context, authorID string) ([]domain.Comment, error) {
+	return guard(ctx, d.r, opRead, func(ctx context.Context) ([]domain.Comment, error) { return d.next.ListByAuthor(ctx, authorID) })
+}
+
+// Update is guarded as a write.
+func (d *resilientCommentRepository) Update(ctx context.Context, c domain.Comment) (domain.Comment, error) {
+	return guard(ctx, d.r, opWrite, func(ctx context.Context) (domain.Comment, error) { return d.next.Update(ctx, c) })
+}
+
+// Delete is guarded as a write.
+func (d *resilientCommentRepository) Delete(ctx context.Context, id string) error {
+	return d.r.do(ctx, opWrite, func(ctx context.Context) error { return d.next.Delete(ctx, id) })
+}
+
+// DeleteByTasks is guarded as a write.
+func (d *resilientCommentRepository) DeleteByTasks(ctx context.Context, taskIDs []string) error {
+	return d.r.do(ctx, opWrite, func(ctx context.Context) error { return d.next.DeleteByTasks(ctx, taskIDs) })
+}
+
+// resilientProjectRepository guards a project repository.
+type resilientProjectRepository struct {
+	next usecase.IProjectRepository
+	r    *Resilience
+}
+
+// Add a compile-time check to ensure this struct implements the correct interface.
+var _ usecase.IProjectRepository = (*resilientProjectRepository)(nil)
+
+// NewResilientProjectRepository wraps next so that its calls are guarded by r.
+func NewResilientProjectRepository(next usecase.IProjectRepository, r *Resilience) usecase.IProjectRepository {
+	return &resilientProjectRepository{next: next, r: r}
+}
+
+// Create is guarded as a write.
+func (d *resilientProjectRepository) Create(ctx context.Context, p domain.Project) (domain.Project, error) {
+	return guard(ctx, d.r, opWrite, func(ctx context.Context) (domain.Project, error) { return d.next.Create(ctx, p) })
+}
+
+// GetByID is guarded as a read.
+func (d *resilientProjectRepository) GetByID(ctx context.Context, id string) (domain.Project, error) {
+	return guard(ctx, d.r, opRead, func(ctx context.Context) (domain.Project, error) { return d.next.GetByID(ctx, id) })
+}
+
+// ListByMember is guarded as a read.
+func (d *resilientProjectRepository) ListByMember(ctx context.Context, userID string) ([]domain.Project, error) {
+	return guard(ctx, d.r, opRead, func(ctx context.Context) ([]domain.Project, error) { return d.next.ListByMember(ctx, userID) })
+}
+
+// Update is guarded as a write.
+func (d *resilientProjectRepository) Update(ctx context.Context, p domain.Project) (domain.Project, error) {
+	return guard(ctx, d.r, opWrite, func(ctx context.Context) (domain.Project, error) { return d.next.Update(ctx, p) })
+}
+
+// Delete is guarded as a write.
+func (d *resilientProjectRepository) Delete(ctx context.Context, id string) error {
+	return d.r.do(ctx, opWrite, func(ctx context.Context) error { return d.next.Delete(ctx, id) })
+}
+
+// SetMember is guarded as a write.
+func (d *resilientProjectRepository) SetMember(ctx context.Context, projectID string, m domain.ProjectMember) (domain.Project, error) {
+	return guard(ctx, d.r, opWrite, func(ctx context.Context) (domain.Project, error) { return d.next.SetMember(ctx, projectID, m) })
+}
+
+// RemoveMember is guarded as a write.
+func (d *resilientProjectRepository) RemoveMember(ctx context.Context, projectID, userID string) error {
+	return d.r.do(ctx, opWrite, func(ctx context.Context) error { return d.next.RemoveMember(ctx, projectID, userID) })
+}
+
+// resilientTaskRepository guards a task repository.
+type resilientTaskRepository struct {
+	next usecase.ITaskRepository
+	r    *Resilience
+}
+
+// Add a compile-time check to ensure this struct implements the correct interface.
+var _ usecase.ITaskRepository = (*resilientTaskRepository)(nil)
+
+// NewResilientTaskRepository wraps next so that its calls are guarded by r.
+func NewResilientTaskRepository(next usecase.ITaskRepository, r *Resilience) usecase.ITaskRepository {
+	return &resilientTaskRepository{next: next, r: r}
+}
+
+// Find is guarded as a read.
+func (d *resilientTaskRepository) Find(ctx context.Context, q domain.TaskQuery) ([]domain.Task, error) {
+	return guard(ctx, d.r, opRead, func(ctx context.Context) ([]domain.Task, error) { return d.next.Find(ctx, q) })
+}
+
+// GetByOwner is guarded as a read.
+func (d *resilientTaskRepository) GetByOwner(ctx context.Context, ownerID string) ([]domain.Task, error) {
+	return guard(ctx, d.r, opRead, func(ctx context.Context) ([]domain.Task, error) { return d.next.GetByOwner(ctx, ownerID) })
+}
+
+// GetByIDs is guarded as a read.
+func (d *resilientTaskRepository) GetByIDs(ctx context.Context, ids []string) ([]domain.Task, error) {
+	return guard(ctx, d.r, opRead, func(ctx context.Context) ([]domain.Task, error) { return d.next.GetByIDs(ctx, ids) })
+}
+
+// GetByProject is guarded as a read.
+func (d *resilientTaskRepository) GetByProject(ctx context.Context, projectID string) ([]domain.Task, error) {
+	return guard(ctx, d.r, opRead, func(ctx context.Context) ([]domain.Task, error) { return d.next.GetByProject(ctx, projectID) })
+}
+
+// DeleteByProject is guarded as a write.
+func (d *resilientTaskRepository) DeleteByProject(ctx context.Context, projectID string) error {
+	return d.r.do(ctx, opWrite, func(ctx context.Context) error { return d.next.DeleteByProject(ctx, projectID) })
+}
+
+// ArchiveByProject is guarded as a write.
+func (d *resilientTaskRepository) ArchiveByProject(ctx context.Context, projectID string, at time.Time) error {
+	return d.r.do(ctx, opWrite, func(ctx context.Context) error { return d.next.ArchiveByProject(ctx, projectID, at) })
+}
+
+// AddAttachment is guarded as a write.
+func (d *resilientTaskRepository) AddAttachment(ctx context.Context, taskID string, a domain.Attachment) (domain.Attachment, error) {
+	return guard(ctx, d.r, opWrite, func(ctx context.Context) (domain.Attachment, error) { return d.next.AddAttachment(ctx, taskID, a) })
+}
+
+// RemoveAttachment is guarded as a write.
+func (d *resilientTaskRepository) RemoveAttachment(ctx context.Context, taskID, attachmentID string) error {
+	return d.r.do(ctx, opWrite, func(ctx context.Context) error { return d.next.RemoveAttachment(ctx, taskID, attachmentID) })
+}
+
+// CountAttachmentsByDigest is guarded as a read.
+func (d *resilientTaskRepository) CountAttachmentsByDigest(ctx context.Context, digest string) (int64, error) {
+	return guard(ctx, d.r, opRead, func(ctx context.Context) (int64, error) { return d.next.CountAttachmentsByDigest(ctx, digest) })
+}
+
+// GetByID is guarded as a read.
+func (d *resilientTaskRepository) GetByID(ctx context.Context, id string) (domain.Task, error) {
+	return guard(ctx, d.r, opRead, func(ctx context.Context) (domain.Task, error) { return d.next.GetByID(ctx, id) })
+}
+
+// Create is guarded as a write.
+func (d *resilientTaskRepository) Create(ctx context.Context, t domain.Task) (domain.Task, error) {
+	return guard(ctx, d.r, opWrite, func(ctx context.Context) (domain.Task, error) { return d.next.Create(ctx, t) })
+}
+
+// Update is guarded as a write.
+func (d *resilientTaskRepository) Update(ctx context.Context, t domain.Task) (domain.Task, error) {
+	return guard(ctx, d.r, opWrite, func(ctx context.Context) (domain.Task, error) { return d.next.Update(ctx, t) })
+}
+
+// Delete is guarded as a write.
+func (d *resilientTaskRepository) Delete(ctx context.Context, id string) error {
+	return d.r.do(ctx, opWrite, func(ctx context.Context) error { return d.next.Delete(ctx, id) })
+}
+
+// resilientIdempotencyRepository guards an idempotency repository.
+type resilientIdempotencyRepository struct {
+	next usecase.IIdempotencyRepository
+	r    *Resilience
+}
+
+// Add a compile-time check to ensure this struct implements the correct interface.
+var _ usecase.IIdempotencyRepository = (*resilientIdempotencyRepository)(nil)
+
+// NewResilientIdempotencyRepository wraps next so that its calls are guarded by r.
+func NewResilientIdempotencyRepository(next usecase.IIdempotencyRepository, r *Resilience) usecase.IIdempotencyRepository {
+	return &resilientIdempotencyRepository{next: next, r: r}
+}
+
+// Reserve is guarded as a write.
+func (d *resilientIdempotencyRepository) Reserve(ctx context.Context, ir domain.IdempotencyRecord, now time.Time) (domain.IdempotencyRecord, bool, error) {
+	var created bool
+	held, err := guard(ctx, d.r, opWrite, func(ctx context.Context) (domain.IdempotencyRecord, error) {
+		var (
+			rec domain.IdempotencyRecord
+			err error
+		)
+		rec, created, err = d.next.Reserve(ctx, ir, now)
+		return rec, err
+	})
+	if err != nil {
+		return domain.IdempotencyRecord{}, false, err
+	}
+	return held, created, nil
+}
+
+// Complete is guarded as a write.
+func (d *resilientIdempotencyRepository) Complete(ctx context.Context, ir domain.IdempotencyRecord) error {
+	return d.r.do(ctx, opWrite, func(ctx context.Context) error { return d.next.Complete(ctx, ir) })
+}
+
+// Renew is guarded as a write.
+func (d *resilientIdempotencyRepository) Renew(ctx context.Context, ir domain.IdempotencyRecord) error {
+	return d.r.do(ctx, opWrite, func(ctx context.Context) error { return d.next.Renew(ctx, ir) })
+}
+
+// Release is guarded as a write.
+func (d *resilientIdempotencyRepository) Release(ctx context.Context, ir domain.IdempotencyRecord) error {
+	return d.r.do(ctx, opWrite, func(ctx context.Context) error { return d.next.Release(ctx, ir) })
+}
+
+// resilientAuditRepository guards an audit repository.
+type resilientAuditRepository struct {
+	next usecase.IAuditRepository
+	r    *Resilience
+}
+
+// Add a compile-time check to ensure this struct implements the correct interface.
+var _ usecase.IAuditRepository = (*resilientAuditRepository)(nil)
+
+// NewResilientAuditRepository wraps next so that its calls are guarded by r.
+func NewResilientAuditRepository(next usecase.IAuditRepository, r *Resilience) usecase.IAuditRepository {
+	return &resilientAuditRepository{next: next, r: r}
+}
+
+// Record is guarded as a write.
+func (d *resilientAuditRepository) Record(ctx context.Context, e domain.AuditEntry) (domain.AuditEntry, error) {
+	return guard(ctx, d.r, opWrite, func(ctx context.Context) (domain.AuditEntry, error) { return d.next.Record(ctx, e) })
+}
+
+// ListByUser is guarded as a read.
+func (d *resilientAuditRepository) ListByUser(ctx context.Context, userID string) ([]domain.AuditEntry, error) {
+	return guard(ctx, d.r, opRead, func(ctx context.Context) ([]domain.AuditEntry, error) { return d.next.ListByUser(ctx, userID) })
+}
+
+// resilientAccountRepository guards an account repository.
+type resilientAccountRepository struct {
+	next usecase.IAccountRepository
+	r    *Resilience
+}
+
+// Add a compile-time check to ensure this struct implements the correct interface.
+var _ usecase.IAccountRepository = (*resilientAccountRepository)(nil)
+
+// NewResilientAccountRepository wraps next so that its calls are guarded by r.
+func NewResilientAccountRepository(next usecase.IAccountRepository, r *Resilience) usecase.IAccountRepository {
+	return &resilientAccountRepository{next: next, r: r}
+}
+
+// DeleteAccount is guarded as a write.
+func (d *resilientAccountRepository) DeleteAccount(ctx context.Context, userID string, entry domain.AuditEntry) ([]domain.Attachment, error) {
+	return guard(ctx, d.r, opWrite, func(ctx context.Context) ([]domain.Attachment, error) {
+		return d.next.DeleteAccount(ctx, userID, entry)
+	})
+}
+
+// resilientTimeEntryRepository guards a time entry repository.
+type resilientTimeEntryRepository struct {
+	next usecase.ITimeEntryRepository
+	r    *Resilience
+}
+
+// Add a compile-time check to ensure this struct implements the correct interface.
+var _ usecase.ITimeEntryRepository = (*resilientTimeEntryRepository)(nil)
+
+// NewResilientTimeEntryRepository wraps next so that its calls are guarded by r.
+func NewResilientTimeEntryRepository(next usecase.ITimeEntryRepository, r *Resilience) usecase.ITimeEntryRepository {
+	return &resilientTimeEntryRepository{next: next, r: r}
+}
+
+// Start is guarded as a write.
+func (d *resilientTimeEntryRepository) Start(ctx context.Context, e domain.TimeEntry) (domain.TimeEntry, error) {
+	return guard(ctx, d.r, opWrite, func(ctx context.Context) (domain.TimeEntry, error) { return d.next.Start(ctx, e) })
+}
+
+// Stop is guarded as a write.
+func (d *resilientTimeEntryRepository) Stop(ctx context.Context, userID, taskID string, end time.Time) (domain.TimeEntry, error) {
+	return guard(ctx, d.r, opWrite, func(ctx context.Context) (domain.TimeEntry, error) { return d.next.Stop(ctx, userID, taskID, end) })
+}
+
+// FindRunning is guarded as a read.
+func (d *resilientTimeEntryRepository) FindRunning(ctx context.Context, userID string) (domain.TimeEntry, error) {
+	return guard(ctx, d.r, opRead, func(ctx context.Context) (domain.TimeEntry, error) { return d.next.FindRunning(ctx, userID) })
+}
+
+// Create is guarded as a write.
+func (d *resilientTimeEntryRepository) Create(ctx context.Context, e domain.TimeEntry) (domain.TimeEntry, error) {
+	return guard(ctx, d.r, opWrite, func(ctx context.Context) (domain.TimeEntry, error) { return d.next.Create(ctx, e) })
+}
+
+// ListByTask is guarded as a read.
+func (d *resilientTimeEntryRepository) ListByTask(ctx context.Context, taskID string) ([]domain.TimeEntry, error) {
+	return guard(ctx, d.r, opRead, func(ctx context.Context) ([]domain.TimeEntry, error) { return d.next.ListByTask(ctx, taskID) })
+}
+
+// ListByUser is guarded as a read.
+func (d *resilientTimeEntryRepository) ListByUser(ctx context.Context, userID string, from, to time.Time) ([]domain.TimeEntry, error) {
+	return guard(ctx, d.r, opRead, func(ctx context.Context) ([]domain.TimeEntry, error) {
+		return d.next.ListByUser(ctx, userID, from, to)
+	})
+}
+
+// DeleteByTasks is guarded as a write.
+func (d *resilientTimeEntryRepository) DeleteByTasks(ctx context.Context, taskIDs []string) error {
+	return d.r.do(ctx, opWrite, func(ctx context.Context) error { return d.next.DeleteByTasks(ctx, taskIDs) })
+}
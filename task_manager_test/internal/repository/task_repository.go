@@ -11,11 +11,12 @@ import (
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 // mongoTaskRepository is the MongoDB-based implementation of the TaskRepository interface.
 type mongoTaskRepository struct {
-	collection *mongo.Collection
+	collection *tenantCollection
 }
 
 // Add a compile-time check to ensure this struct implements the correct interface.
@@ -24,13 +25,146 @@ var _ usecase.ITaskRepository = (*mongoTaskRepository)(nil)
 // NewMongoTaskRepository is the constructor for the implementation.
 func NewMongoTaskRepository(db *mongo.Database) usecase.ITaskRepository {
 	return &mongoTaskRepository{
-		collection: db.Collection("tasks"),
+		collection: newTenantCollection(db.Collection("tasks")),
 	}
 }
 
-// GetAll retrieves all task documents from MongoDB and maps them to domain.Task.
-func (r *mongoTaskRepository) GetAll(ctx context.Context) ([]domain.Task, error) {
-	cur, err := r.collection.Find(ctx, bson.M{})
+// taskRecord is the BSON shape of a task document.
+type taskRecord struct {
+	ID          primitive.ObjectID `bson:"_id"`
+	Title       string             `bson:"title"`
+	Description string             `bson:"description"`
+	DueDate     time.Time          `bson:"duedate"`
+	Status      string             `bson:"status"`
+	OwnerID     string             `bson:"owner_id,omitempty"`
+	ProjectID   string             `bson:"project_id,omitempty"`
+	ArchivedAt  time.Time          `bson:"archived_at,omitempty"`
+	Attachments []attachmentRecord `bson:"attachments,omitempty"`
+}
+
+// attachmentRecord is the BSON shape of an attachment embedded in a task document.
+type attachmentRecord struct {
+	ID          string    `bson:"id"`
+	Filename    string    `bson:"filename"`
+	ContentType string    `bson:"content_type"`
+	Size        int64     `bson:"size"`
+	Digest      string    `bson:"digest"`
+	UploadedBy  string    `bson:"uploaded_by"`
+	UploadedAt  time.Time `bson:"uploaded_at"`
+}
+
+// toDomain converts the stored document into a domain.Task.
+func (rec taskRecord) toDomain() domain.Task {
+	var attachments []domain.Attachment
+	for _, a := range rec.Attachments {
+		attachments = append(attachments, domain.Attachment{
+			ID:          a.ID,
+			Filename:    a.Filename,
+			ContentType: a.ContentType,
+			Size:        a.Size,
+			Digest:      a.Digest,
+			UploadedBy:  a.UploadedBy,
+			UploadedAt:  a.UploadedAt,
+		})
+	}
+	return domain.Task{
+		ID:          rec.ID.Hex(),
+		Title:       rec.Title,
+		Description: rec.Description,
+		DueDate:     rec.DueDate,
+		Status:      rec.Status,
+		OwnerID:     rec.OwnerID,
+		ProjectID:   rec.ProjectID,
+		ArchivedAt:  rec.ArchivedAt,
+		Attachments: attachments,
+	}
+}
+
+// editableFields are the stored task fields that Update may change.
+func editableFields(t domain.Task) bson.D {
+	return bson.D{
+		{Key: "title", Value: t.Title},
+		{Key: "description", Value: t.Description},
+		{Key: "duedate", Value: t.DueDate},
+		{Key: "status", Value: t.Status},
+		{Key: "owner_id", Value: t.OwnerID},
+	}
+}
+
+// taskDocument is the stored form of a new task, without its ID. Unset project and archive fields are omitted, and
+// attachments are added separately.
+func taskDocument(t domain.Task) bson.D {
+	doc := editableFields(t)
+	if t.ProjectID != "" {
+		doc = append(doc, bson.E{Key: "project_id", Value: t.ProjectID})
+	}
+	if !t.ArchivedAt.IsZero() {
+		doc = append(doc, bson.E{Key: "archived_at", Value: t.ArchivedAt})
+	}
+	return doc
+}
+
+// Find retrieves the task documents selected by the query. Project tasks carry a project_id and archived tasks an
+// archived_at, so both are recognised by the field being present.
+func (r *mongoTaskRepository) Find(ctx context.Context, q domain.TaskQuery) ([]domain.Task, error) {
+	filter := bson.M{}
+	if !q.All {
+		var scope bson.A
+		if q.OwnerID != "" {
+			scope = append(scope, bson.M{"owner_id": q.OwnerID})
+		}
+		if oids := objectIDs(q.IDs); len(oids) > 0 {
+			scope = append(scope, bson.M{"_id": bson.M{"$in": oids}})
+		}
+		if len(scope) == 0 {
+			return nil, nil
+		}
+		filter["$or"] = scope
+	}
+	if q.Personal {
+		filter["project_id"] = bson.M{"$exists": false}
+	}
+	if !q.DueBefore.IsZero() {
+		filter["duedate"] = bson.M{"$gte": q.DueFrom, "$lt": q.DueBefore}
+		filter["status"] = bson.M{"$ne": domain.TaskStatusCompleted}
+		filter["archived_at"] = bson.M{"$exists": false}
+	}
+	return r.find(ctx, filter, options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}))
+}
+
+// GetByOwner retrieves the task documents owned by the given user.
+func (r *mongoTaskRepository) GetByOwner(ctx context.Context, ownerID string) ([]domain.Task, error) {
+	return r.find(ctx, bson.M{"owner_id": ownerID})
+}
+
+// GetByProject retrieves the task documents that belong to the given project.
+func (r *mongoTaskRepository) GetByProject(ctx context.Context, projectID string) ([]domain.Task, error) {
+	return r.find(ctx, bson.M{"project_id": projectID})
+}
+
+// GetByIDs retrieves the task documents with the given IDs. Malformed and unknown IDs are skipped.
+func (r *mongoTaskRepository) GetByIDs(ctx context.Context, ids []string) ([]domain.Task, error) {
+	oids := objectIDs(ids)
+	if len(oids) == 0 {
+		return nil, nil
+	}
+	return r.find(ctx, bson.M{"_id": bson.M{"$in": oids}})
+}
+
+// objectIDs parses the hexadecimal task IDs, skipping malformed ones.
+func objectIDs(ids []string) []primitive.ObjectID {
+	oids := make([]primitive.ObjectID, 0, len(ids))
+	for _, id := range ids {
+		if oid, err := primitive.ObjectIDFromHex(id); err == nil {
+			oids = append(oids, oid)
+		}
+	}
+	return oids
+}
+
+// find decodes every task document matching the filter.
+func (r *mongoTaskRepository) find(ctx context.Context, filter bson.M, opts ...*options.FindOptions) ([]domain.Task, error) {
+	cur, err := r.collection.Find(ctx, filter, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -38,23 +172,11 @@ func (r *mongoTaskRepository) GetAll(ctx context.Context) ([]domain.Task, error)
 
 	var out []domain.Task
 	for cur.Next(ctx) {
-		var rec struct {
-			ID          primitive.ObjectID `bson:"_id"`
-			Title       string             `bson:"title"`
-			Description string             `bson:"description"`
-			DueDate     time.Time          `bson:"duedate"`
-			Status      string             `bson:"status"`
-		}
+		var rec taskRecord
 		if err := cur.Decode(&rec); err != nil {
 			return nil, err
 		}
-		out = append(out, domain.Task{
-			ID:          rec.ID.Hex(),
-			Title:       rec.Title,
-			Description: rec.Description,
-			DueDate:     rec.DueDate,
-			Status:      rec.Status,
-		})
+		out = append(out, rec.toDomain())
 	}
 	return out, nil
 }
@@ -65,13 +187,7 @@ func (r *mongoTaskRepository) GetByID(ctx context.Context, id string) (domain.Ta
 	if err != nil {
 		return domain.Task{}, usecase.ErrInvalidID
 	}
-	var rec struct {
-		ID          primitive.ObjectID `bson:"_id"`
-		Title       string             `bson:"title"`
-		Description string             `bson:"description"`
-		DueDate     time.Time          `bson:"duedate"`
-		Status      string             `bson:"status"`
-	}
+	var rec taskRecord
 	err = r.collection.FindOne(ctx, bson.M{"_id": oid}).Decode(&rec)
 	if err != nil {
 		if errors.Is(err, mongo.ErrNoDocuments) {
@@ -80,25 +196,13 @@ func (r *mongoTaskRepository) GetByID(ctx context.Context, id string) (domain.Ta
 		return domain.Task{}, err
 	}
 
-	return domain.Task{
-		ID:          rec.ID.Hex(),
-		Title:       rec.Title,
-		Description: rec.Description,
-		DueDate:     rec.DueDate,
-		Status:      rec.Status,
-	}, nil
+	return rec.toDomain(), nil
 }
 
 // Create inserts a new task document, generating a new unique ID.
 func (r *mongoTaskRepository) Create(ctx context.Context, t domain.Task) (domain.Task, error) {
 	oid := primitive.NewObjectID()
-	_, err := r.collection.InsertOne(ctx, bson.D{
-		{Key: "_id", Value: oid},
-		{Key: "title", Value: t.Title},
-		{Key: "description", Value: t.Description},
-		{Key: "duedate", Value: t.DueDate},
-		{Key: "status", Value: t.Status},
-	})
+	_, err := r.collection.InsertOne(ctx, append(bson.D{{Key: "_id", Value: oid}}, taskDocument(t)...))
 	if err != nil {
 		if mongo.IsDuplicateKeyError(err) {
 			return domain.Task{}, usecase.ErrTaskAlreadyExists
@@ -109,18 +213,14 @@ func (r *mongoTaskRepository) Create(ctx context.Context, t domain.Task) (domain
 	return t, err
 }
 
-// Update replaces an existing task document with the data in domain.Task.
+// Update writes the editable fields of domain.Task to an existing task document. The project, archive state and
+// attachments are changed only through their dedicated methods, so a concurrent upload is never overwritten.
 func (r *mongoTaskRepository) Update(ctx context.Context, t domain.Task) (domain.Task, error) {
 	oid, err := primitive.ObjectIDFromHex(t.ID)
 	if err != nil {
 		return domain.Task{}, usecase.ErrInvalidID
 	}
-	res, err := r.collection.ReplaceOne(ctx, bson.D{{Key: "_id", Value: oid}}, bson.D{
-		{Key: "title", Value: t.Title},
-		{Key: "description", Value: t.Description},
-		{Key: "duedate", Value: t.DueDate},
-		{Key: "status", Value: t.Status},
-	})
+	res, err := r.collection.UpdateOne(ctx, bson.D{{Key: "_id", Value: oid}}, bson.D{{Key: "$set", Value: editableFields(t)}})
 	if err != nil {
 		return domain.Task{}, err
 	}
@@ -145,3 +245,69 @@ func (r *mongoTaskRepository) Delete(ctx context.Context, id string) error {
 	}
 	return nil
 }
+
+// DeleteByProject removes every task document of the given project.
+func (r *mongoTaskRepository) DeleteByProject(ctx context.Context, projectID string) error {
+	_, err := r.collection.DeleteMany(ctx, bson.M{"project_id": projectID})
+	return err
+}
+
+// ArchiveByProject detaches every task of the given project from it and marks the tasks as archived at the given time.
+func (r *mongoTaskRepository) ArchiveByProject(ctx context.Context, projectID string, at time.Time) error {
+	_, err := r.collection.UpdateMany(ctx, bson.M{"project_id": projectID}, bson.M{
+		"$set":   bson.M{"archived_at": at},
+		"$unset": bson.M{"project_id": ""},
+	})
+	return err
+}
+
+// AddAttachment appends the attachment to the task document, assigning it a new unique ID.
+func (r *mongoTaskRepository) AddAttachment(ctx context.Context, taskID string, a domain.Attachment) (domain.Attachment, error) {
+	oid, err := primitive.ObjectIDFromHex(taskID)
+	if err != nil {
+		return domain.Attachment{}, usecase.ErrInvalidID
+	}
+	a.ID = primitive.NewObjectID().Hex()
+	rec := attachmentRecord{
+		ID:          a.ID,
+		Filename:    a.Filename,
+		ContentType: a.ContentType,
+		Size:        a.Size,
+		Digest:      a.Digest,
+		UploadedBy:  a.UploadedBy,
+		UploadedAt:  a.UploadedAt,
+	}
+	res, err := r.collection.UpdateOne(ctx, bson.M{"_id": oid}, bson.M{"$push": bson.M{"attachments": rec}})
+	if err != nil {
+		return domain.Attachment{}, err
+	}
+	if res.MatchedCount == 0 {
+		return domain.Attachment{}, usecase.ErrNotFound
+	}
+	return a, nil
+}
+
+// RemoveAttachment pulls the attachment from the task document.
+func (r *mongoTaskRepository) RemoveAttachment(ctx context.Context, taskID, attachmentID string) error {
+	oid, err := primitive.ObjectIDFromHex(taskID)
+	if err != nil {
+		return usecase.ErrInvalidID
+	}
+	res, err := r.collection.UpdateOne(ctx,
+		bson.M{"_id": oid, "attachments.id": attachmentID},
+		bson.M{"$pull": bson.M{"attachments": bson.M{"id": attachmentID}}},
+	)
+	if err != nil {
+		return err
+	}
+	if res.ModifiedCount == 0 {
+		return usecase.ErrNotFound
+	}
+	return nil
+}
+
+// CountAttachmentsByDigest counts the task documents with an attachment of the given content. Blobs are shared by
+// every organization, so it counts across all of them.
+func (r *mongoTaskRepository) CountAttachmentsByDigest(ctx context.Context, digest string) (int64, error) {
+	return r.collection.CountDocuments(usecase.WithAllTenants(ctx), bson.M{"attachments.digest": digest})
+}
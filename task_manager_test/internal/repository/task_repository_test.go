@@ -57,7 +57,7 @@ func (s *TaskRepositoryTestSuite) TearDownSuite() {
 // SetupTest runs before each individual test. It instantiates the repository.
 func (s *TaskRepositoryTestSuite) SetupTest() {
 	s.repository = NewMongoTaskRepository(s.db)
-	(s.repository.(*mongoTaskRepository)).collection = s.collection
+	(s.repository.(*mongoTaskRepository)).collection = newTenantCollection(s.collection)
 }
 
 // TearDownTest runs after each individual test. It's CRITICAL for ensuring test isolation by cleaning up any data created during the test.
@@ -76,7 +76,7 @@ func TestTaskRepositoryTestSuite(t *testing.T) {
 
 func (s *TaskRepositoryTestSuite) TestCreateAndGetByID_Success() {
 	// ARRANGE
-	ctx := context.Background()
+	ctx := testTenant()
 	taskToCreate := domain.Task{
 		Title:       "Integration Test Task",
 		Description: "A task created during an integration test.",
@@ -107,7 +107,7 @@ func (s *TaskRepositoryTestSuite) TestCreateAndGetByID_Success() {
 
 func (s *TaskRepositoryTestSuite) TestGetByID_Fails_When_NotFound() {
 	// ARRANGE
-	ctx := context.Background()
+	ctx := testTenant()
 	nonExistentID := primitive.NewObjectID().Hex()
 
 	// ACT
@@ -120,7 +120,7 @@ func (s *TaskRepositoryTestSuite) TestGetByID_Fails_When_NotFound() {
 
 func (s *TaskRepositoryTestSuite) TestGetByID_Fails_When_InvalidIDFormat() {
 	// ARRANGE
-	ctx := context.Background()
+	ctx := testTenant()
 	invalidID := "this-is-not-a-valid-object-id"
 
 	// ACT
@@ -133,7 +133,7 @@ func (s *TaskRepositoryTestSuite) TestGetByID_Fails_When_InvalidIDFormat() {
 
 func (s *TaskRepositoryTestSuite) TestUpdate_Success() {
 	// ARRANGE - Create a task first
-	ctx := context.Background()
+	ctx := testTenant()
 	initialTask, _ := s.repository.Create(ctx, domain.Task{Title: "Initial Title", Status: "To Do"})
 
 	// ACT - Update the task
@@ -158,7 +158,7 @@ func (s *TaskRepositoryTestSuite) TestUpdate_Success() {
 
 func (s *TaskRepositoryTestSuite) TestDelete_Success() {
 	// ARRANGE - Create a task to delete
-	ctx := context.Background()
+	ctx := testTenant()
 	taskToDelete, _ := s.repository.Create(ctx, domain.Task{Title: "Task to be Deleted"})
 
 	// ACT - Delete the task
@@ -174,7 +174,7 @@ func (s *TaskRepositoryTestSuite) TestDelete_Success() {
 
 func (s *TaskRepositoryTestSuite) TestDelete_Fails_When_NotFound() {
 	// ARRANGE
-	ctx := context.Background()
+	ctx := testTenant()
 	nonExistentID := primitive.NewObjectID().Hex()
 
 	// ACT
@@ -184,3 +184,165 @@ func (s *TaskRepositoryTestSuite) TestDelete_Fails_When_NotFound() {
 	assert.Error(s.T(), err, "Delete should return an error for a non-existent ID")
 	assert.ErrorIs(s.T(), err, usecase.ErrNotFound, "The error should be usecase.ErrNotFound")
 }
+
+func (s *TaskRepositoryTestSuite) TestGetByOwner_ReturnsOnlyOwnedTasks() {
+	// ARRANGE
+	ctx := testTenant()
+	mine, _ := s.repository.Create(ctx, domain.Task{Title: "Mine", OwnerID: "user-1"})
+	_, _ = s.repository.Create(ctx, domain.Task{Title: "Theirs", OwnerID: "user-2"})
+	_, _ = s.repository.Create(ctx, domain.Task{Title: "Unowned"})
+
+	// ACT
+	tasks, err := s.repository.GetByOwner(ctx, "user-1")
+
+	// ASSERT
+	assert.NoError(s.T(), err)
+	assert.Len(s.T(), tasks, 1)
+	assert.Equal(s.T(), mine.ID, tasks[0].ID)
+	assert.Equal(s.T(), "user-1", tasks[0].OwnerID, "The owner should round-trip through the database")
+}
+
+func (s *TaskRepositoryTestSuite) TestGetByIDs_SkipsUnknownIDs() {
+	// ARRANGE
+	ctx := testTenant()
+	first, _ := s.repository.Create(ctx, domain.Task{Title: "First"})
+	second, _ := s.repository.Create(ctx, domain.Task{Title: "Second"})
+	_, _ = s.repository.Create(ctx, domain.Task{Title: "Not requested"})
+
+	// ACT
+	tasks, err := s.repository.GetByIDs(ctx, []string{first.ID, second.ID, primitive.NewObjectID().Hex(), "not-an-id"})
+
+	// ASSERT
+	assert.NoError(s.T(), err)
+	assert.Len(s.T(), tasks, 2)
+}
+
+func (s *TaskRepositoryTestSuite) TestFind_SelectsPersonalTasksByOwnerOrID() {
+	// ARRANGE
+	ctx := testTenant()
+	mine, _ := s.repository.Create(ctx, domain.Task{Title: "Mine", OwnerID: "user-1"})
+	shared, _ := s.repository.Create(ctx, domain.Task{Title: "Shared", OwnerID: "user-2"})
+	_, _ = s.repository.Create(ctx, domain.Task{Title: "Theirs", OwnerID: "user-2"})
+	_, _ = s.repository.Create(ctx, domain.Task{Title: "In a project I left", OwnerID: "user-1", ProjectID: "proj-1"})
+
+	// ACT
+	tasks, err := s.repository.Find(ctx, domain.TaskQuery{OwnerID: "user-1", IDs: []string{shared.ID, "not-an-id"}, Personal: true})
+	all, allErr := s.repository.Find(ctx, domain.TaskQuery{All: true})
+
+	// ASSERT
+	assert.NoError(s.T(), err)
+	assert.Len(s.T(), tasks, 2)
+	assert.Equal(s.T(), []string{mine.ID, shared.ID}, []string{tasks[0].ID, tasks[1].ID}, "Tasks should be returned oldest first")
+	assert.NoError(s.T(), allErr)
+	assert.Len(s.T(), all, 4)
+}
+
+func (s *TaskRepositoryTestSuite) TestFind_SelectsOpenTasksDueInRange() {
+	// ARRANGE
+	ctx := testTenant()
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 1)
+	create := func(title, status string, due time.Time, archivedAt time.Time) domain.Task {
+		t, err := s.repository.Create(ctx, domain.Task{Title: title, OwnerID: "user-1", Status: status, DueDate: due, ArchivedAt: archivedAt})
+		s.Require().NoError(err)
+		return t
+	}
+	first := create("At midnight", "pending", from, time.Time{})
+	create("Yesterday", "pending", from.Add(-time.Second), time.Time{})
+	create("Tomorrow", "pending", to, time.Time{})
+	create("Done", domain.TaskStatusCompleted, from.Add(time.Hour), time.Time{})
+	create("Archived", "pending", from.Add(time.Hour), from)
+	last := create("Late", "pending", to.Add(-time.Second), time.Time{})
+
+	// ACT
+	tasks, err := s.repository.Find(ctx, domain.TaskQuery{OwnerID: "user-1", Personal: true, DueFrom: from, DueBefore: to})
+
+	// ASSERT
+	assert.NoError(s.T(), err)
+	var ids []string
+	for _, t := range tasks {
+		ids = append(ids, t.ID)
+	}
+	assert.Equal(s.T(), []string{first.ID, last.ID}, ids)
+}
+
+func (s *TaskRepositoryTestSuite) TestArchiveByProject_DetachesTasks() {
+	// ARRANGE
+	ctx := testTenant()
+	inProject, _ := s.repository.Create(ctx, domain.Task{Title: "In project", OwnerID: "user-1", ProjectID: "proj-1"})
+	_, _ = s.repository.Create(ctx, domain.Task{Title: "Elsewhere", OwnerID: "user-1", ProjectID: "proj-2"})
+	archivedAt := time.Now().UTC().Truncate(time.Millisecond)
+
+	// ACT
+	err := s.repository.ArchiveByProject(ctx, "proj-1", archivedAt)
+
+	// ASSERT
+	assert.NoError(s.T(), err)
+	remaining, _ := s.repository.GetByProject(ctx, "proj-1")
+	assert.Empty(s.T(), remaining, "Archived tasks should no longer belong to the project")
+	archived, err := s.repository.GetByID(ctx, inProject.ID)
+	assert.NoError(s.T(), err)
+	assert.Empty(s.T(), archived.ProjectID)
+	assert.True(s.T(), archivedAt.Equal(archived.ArchivedAt))
+	other, _ := s.repository.GetByProject(ctx, "proj-2")
+	assert.Len(s.T(), other, 1, "Tasks of other projects should be untouched")
+}
+
+func (s *TaskRepositoryTestSuite) TestDeleteByProject_RemovesOnlyProjectTasks() {
+	// ARRANGE
+	ctx := testTenant()
+	_, _ = s.repository.Create(ctx, domain.Task{Title: "In project", OwnerID: "user-1", ProjectID: "proj-1"})
+	personal, _ := s.repository.Create(ctx, domain.Task{Title: "Personal", OwnerID: "user-1"})
+
+	// ACT
+	err := s.repository.DeleteByProject(ctx, "proj-1")
+
+	// ASSERT
+	assert.NoError(s.T(), err)
+	tasks, _ := s.repository.GetByOwner(ctx, "user-1")
+	assert.Len(s.T(), tasks, 1)
+	assert.Equal(s.T(), personal.ID, tasks[0].ID)
+}
+
+func (s *TaskRepositoryTestSuite) TestAttachments_RoundTripAndSurviveUpdate() {
+	// ARRANGE
+	ctx := testTenant()
+	task, _ := s.repository.Create(ctx, domain.Task{Title: "With files", OwnerID: "user-1"})
+	uploadedAt := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	att := domain.Attachment{Filename: "a.png", ContentType: "image/png", Size: 40, Digest: "d1", UploadedBy: "user-1", UploadedAt: uploadedAt}
+
+	// ACT
+	first, err := s.repository.AddAttachment(ctx, task.ID, att)
+	assert.NoError(s.T(), err)
+	att.Digest, att.Size = "d2", 60
+	_, err = s.repository.AddAttachment(ctx, task.ID, att)
+	assert.NoError(s.T(), err)
+	task.Title = "Renamed"
+	_, err = s.repository.Update(ctx, task)
+	assert.NoError(s.T(), err)
+
+	// ASSERT
+	stored, _ := s.repository.GetByID(ctx, task.ID)
+	assert.Equal(s.T(), "Renamed", stored.Title)
+	assert.Len(s.T(), stored.Attachments, 2, "Update should keep attachments")
+	assert.Equal(s.T(), first.ID, stored.Attachments[0].ID)
+	assert.True(s.T(), uploadedAt.Equal(stored.Attachments[0].UploadedAt))
+	count, _ := s.repository.CountAttachmentsByDigest(ctx, "d1")
+	assert.Equal(s.T(), int64(1), count)
+}
+
+func (s *TaskRepositoryTestSuite) TestRemoveAttachment() {
+	// ARRANGE
+	ctx := testTenant()
+	task, _ := s.repository.Create(ctx, domain.Task{Title: "With files", OwnerID: "user-1"})
+	att, _ := s.repository.AddAttachment(ctx, task.ID, domain.Attachment{Filename: "a.txt", Size: 5, Digest: "d1", UploadedBy: "user-1"})
+
+	// ACT
+	err := s.repository.RemoveAttachment(ctx, task.ID, att.ID)
+
+	// ASSERT
+	assert.NoError(s.T(), err)
+	stored, _ := s.repository.GetByID(ctx, task.ID)
+	assert.Empty(s.T(), stored.Attachments)
+	assert.ErrorIs(s.T(), s.repository.RemoveAttachment(ctx, task.ID, att.ID), usecase.ErrNotFound)
+}
@@ -0,0 +1,134 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"task_manager_test/internal/domain"
+	"task_manager_test/internal/usecase"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// mongoTaskShareRepository is the MongoDB-based implementation of the ITaskShareRepository interface.
+type mongoTaskShareRepository struct {
+	collection *tenantCollection
+}
+
+// Add a compile-time check to ensure this struct implements the correct interface.
+var _ usecase.ITaskShareRepository = (*mongoTaskShareRepository)(nil)
+
+// NewMongoTaskShareRepository is the constructor for the implementation.
+func NewMongoTaskShareRepository(db *mongo.Database) usecase.ITaskShareRepository {
+	return &mongoTaskShareRepository{
+		collection: newTenantCollection(db.Collection("task_shares")),
+	}
+}
+
+// taskShareRecord is the BSON shape of a task share document.
+type taskShareRecord struct {
+	ID        primitive.ObjectID `bson:"_id"`
+	TaskID    string             `bson:"task_id"`
+	UserID    string             `bson:"user_id"`
+	Username  string             `bson:"username"`
+	Access    domain.ShareAccess `bson:"access"`
+	GrantedBy string             `bson:"granted_by"`
+	CreatedAt time.Time          `bson:"created_at"`
+}
+
+// toDomain converts the stored document into a domain.TaskShare.
+func (r taskShareRecord) toDomain() domain.TaskShare {
+	return domain.TaskShare{
+		ID:        r.ID.Hex(),
+		TaskID:    r.TaskID,
+		UserID:    r.UserID,
+		Username:  r.Username,
+		Access:    r.Access,
+		GrantedBy: r.GrantedBy,
+		CreatedAt: r.CreatedAt,
+	}
+}
+
+// Upsert creates the share or updates the access level of the existing share for the same task and user.
+// The original grant time and ID are kept when a share is updated.
+func (r *mongoTaskShareRepository) Upsert(ctx context.Context, s domain.TaskShare) (domain.TaskShare, error) {
+	var rec taskShareRecord
+	err := r.collection.FindOneAndUpdate(ctx,
+		bson.M{"task_id": s.TaskID, "user_id": s.UserID},
+		bson.M{
+			"$set": bson.M{"access": s.Access, "granted_by": s.GrantedBy, "username": s.Username},
+			"$setOnInsert": bson.M{
+				"_id":        primitive.NewObjectID(),
+				"created_at": s.CreatedAt,
+			},
+		},
+		options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After),
+	).Decode(&rec)
+	if err != nil {
+		return domain.TaskShare{}, err
+	}
+	return rec.toDomain(), nil
+}
+
+// Find returns the share of the task with the user.
+func (r *mongoTaskShareRepository) Find(ctx context.Context, taskID, userID string) (domain.TaskShare, error) {
+	var rec taskShareRecord
+	err := r.collection.FindOne(ctx, bson.M{"task_id": taskID, "user_id": userID}).Decode(&rec)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return domain.TaskShare{}, usecase.ErrNotFound
+		}
+		return domain.TaskShare{}, err
+	}
+	return rec.toDomain(), nil
+}
+
+// ListByTask returns every share of the task, oldest first.
+func (r *mongoTaskShareRepository) ListByTask(ctx context.Context, taskID string) ([]domain.TaskShare, error) {
+	return r.find(ctx, bson.M{"task_id": taskID})
+}
+
+// ListByUser returns every share granted to the user, oldest first.
+func (r *mongoTaskShareRepository) ListByUser(ctx context.Context, userID string) ([]domain.TaskShare, error) {
+	return r.find(ctx, bson.M{"user_id": userID})
+}
+
+// find decodes every share document matching the filter.
+func (r *mongoTaskShareRepository) find(ctx context.Context, filter bson.M) ([]domain.TaskShare, error) {
+	cursor, err := r.collection.Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var recs []taskShareRecord
+	if err := cursor.All(ctx, &recs); err != nil {
+		return nil, err
+	}
+	shares := make([]domain.TaskShare, len(recs))
+	for i, rec := range recs {
+		shares[i] = rec.toDomain()
+	}
+	return shares, nil
+}
+
+// Delete removes the share of the task with the user.
+func (r *mongoTaskShareRepository) Delete(ctx context.Context, taskID, userID string) error {
+	res, err := r.collection.DeleteOne(ctx, bson.M{"task_id": taskID, "user_id": userID})
+	if err != nil {
+		return err
+	}
+	if res.DeletedCount == 0 {
+		return usecase.ErrNotFound
+	}
+	return nil
+}
+
+// DeleteByTask removes every share of the task.
+func (r *mongoTaskShareRepository) DeleteByTask(ctx context.Context, taskID string) error {
+	_, err := r.collection.DeleteMany(ctx, bson.M{"task_id": taskID})
+	return err
+}
@@ -0,0 +1,131 @@
+package repository
+
+import (
+	"context"
+	"os"
+	"task_manager_test/internal/domain"
+	"task_manager_test/internal/usecase"
+	"testing"
+	"time"
+
+	"github.com/joho/godotenv"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// TaskShareRepositoryTestSuite defines the integration test suite for the task share repository.
+type TaskShareRepositoryTestSuite struct {
+	suite.Suite
+	client     *mongo.Client
+	db         *mongo.Database
+	collection *mongo.Collection
+	repository usecase.ITaskShareRepository
+}
+
+// SetupSuite connects to the dedicated test database, skipping the suite when it is not configured.
+func (s *TaskShareRepositoryTestSuite) SetupSuite() {
+	if err := godotenv.Load("../../.env"); err != nil {
+		s.T().Log("No .env file found, proceeding with environment variables")
+	}
+
+	uri := os.Getenv("MONGODB_URI_TEST")
+	if uri == "" {
+		s.T().Skip("MONGODB_URI_TEST environment variable not set, skipping integration tests")
+	}
+
+	client, err := mongo.Connect(context.Background(), options.Client().ApplyURI(uri))
+	assert.NoError(s.T(), err, "Failed to connect to MongoDB")
+
+	s.client = client
+	s.db = client.Database("tasksharedb_test")
+	s.collection = s.db.Collection("task_shares_test")
+}
+
+// TearDownSuite drops the test database and disconnects.
+func (s *TaskShareRepositoryTestSuite) TearDownSuite() {
+	if s.client != nil {
+		assert.NoError(s.T(), s.db.Drop(context.Background()), "Failed to drop test database")
+		assert.NoError(s.T(), s.client.Disconnect(context.Background()), "Failed to disconnect from MongoDB")
+	}
+}
+
+// SetupTest instantiates a repository bound to the test collection.
+func (s *TaskShareRepositoryTestSuite) SetupTest() {
+	s.repository = NewMongoTaskShareRepository(s.db)
+	(s.repository.(*mongoTaskShareRepository)).collection = newTenantCollection(s.collection)
+}
+
+// TearDownTest drops the collection to isolate tests.
+func (s *TaskShareRepositoryTestSuite) TearDownTest() {
+	assert.NoError(s.T(), s.collection.Drop(context.Background()), "TearDownTest: failed to drop collection")
+}
+
+// TestTaskShareRepository is the entry point for the test suite.
+func TestTaskShareRepository(t *testing.T) {
+	suite.Run(t, new(TaskShareRepositoryTestSuite))
+}
+
+// TestUpsert_UpdatesExistingShare verifies that sharing twice changes the access level instead of duplicating the share.
+func (s *TaskShareRepositoryTestSuite) TestUpsert_UpdatesExistingShare() {
+	// ARRANGE
+	ctx := testTenant()
+	granted := time.Now().UTC().Truncate(time.Millisecond)
+	first, err := s.repository.Upsert(ctx, domain.TaskShare{
+		TaskID: "task-1", UserID: "user-2", Username: "bob", Access: domain.ShareRead, GrantedBy: "user-1", CreatedAt: granted,
+	})
+	assert.NoError(s.T(), err, "Setup: failed to create share")
+
+	// ACT
+	second, err := s.repository.Upsert(ctx, domain.TaskShare{
+		TaskID: "task-1", UserID: "user-2", Username: "bob", Access: domain.ShareEdit, GrantedBy: "user-1", CreatedAt: granted.Add(time.Hour),
+	})
+
+	// ASSERT
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), first.ID, second.ID, "The existing share should be updated in place")
+	assert.Equal(s.T(), domain.ShareEdit, second.Access)
+	assert.WithinDuration(s.T(), granted, second.CreatedAt, time.Millisecond, "The original grant time should be kept")
+
+	shares, err := s.repository.ListByTask(ctx, "task-1")
+	assert.NoError(s.T(), err)
+	assert.Len(s.T(), shares, 1)
+}
+
+// TestLookupsAndDeletion verifies finding, listing and removing shares.
+func (s *TaskShareRepositoryTestSuite) TestLookupsAndDeletion() {
+	// ARRANGE
+	ctx := testTenant()
+	now := time.Now().UTC()
+	for _, sh := range []domain.TaskShare{
+		{TaskID: "task-1", UserID: "user-2", Access: domain.ShareRead, CreatedAt: now},
+		{TaskID: "task-1", UserID: "user-3", Access: domain.ShareEdit, CreatedAt: now.Add(time.Second)},
+		{TaskID: "task-2", UserID: "user-2", Access: domain.ShareEdit, CreatedAt: now.Add(2 * time.Second)},
+	} {
+		_, err := s.repository.Upsert(ctx, sh)
+		assert.NoError(s.T(), err, "Setup: failed to create share")
+	}
+
+	// ACT & ASSERT - lookups
+	found, err := s.repository.Find(ctx, "task-1", "user-3")
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), domain.ShareEdit, found.Access)
+
+	_, err = s.repository.Find(ctx, "task-2", "user-3")
+	assert.ErrorIs(s.T(), err, usecase.ErrNotFound)
+
+	byUser, err := s.repository.ListByUser(ctx, "user-2")
+	assert.NoError(s.T(), err)
+	assert.Len(s.T(), byUser, 2)
+	assert.Equal(s.T(), "task-1", byUser[0].TaskID, "Shares should be listed oldest first")
+
+	// ACT & ASSERT - deletion
+	assert.NoError(s.T(), s.repository.Delete(ctx, "task-1", "user-2"))
+	assert.ErrorIs(s.T(), s.repository.Delete(ctx, "task-1", "user-2"), usecase.ErrNotFound)
+
+	assert.NoError(s.T(), s.repository.DeleteByTask(ctx, "task-1"))
+	remaining, err := s.repository.ListByTask(ctx, "task-1")
+	assert.NoError(s.T(), err)
+	assert.Empty(s.T(), remaining)
+}
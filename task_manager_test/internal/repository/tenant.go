@@ -0,0 +1,156 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"task_manager_test/internal/usecase"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// tenantField is the field holding the organization ID on every document of tenant data.
+const tenantField = "org_id"
+
+// tenantCollection wraps a collection of tenant data so that every read and write is confined to the tenant of the
+// context, as returned by usecase.TenantFromContext. Repositories of tenant data use it instead of *mongo.Collection,
+// so a query that forgets to filter by organization still cannot see another organization's documents, and one that
+// runs without a tenant fails with usecase.ErrNoTenant instead of touching every organization.
+type tenantCollection struct {
+	coll *mongo.Collection
+}
+
+// newTenantCollection wraps coll.
+func newTenantCollection(coll *mongo.Collection) *tenantCollection {
+	return &tenantCollection{coll: coll}
+}
+
+// scope adds the tenant condition of ctx to filter, which must be a bson.M or bson.D. The filter is copied, never
+// modified. Contexts spanning every tenant leave it as it is.
+func scope(ctx context.Context, filter any) (any, error) {
+	tenant, ok := usecase.TenantFromContext(ctx)
+	if !ok {
+		return nil, usecase.ErrNoTenant
+	}
+	if tenant.All {
+		return filter, nil
+	}
+	switch f := filter.(type) {
+	case bson.M:
+		scoped := make(bson.M, len(f)+1)
+		for k, v := range f {
+			scoped[k] = v
+		}
+		scoped[tenantField] = tenant.OrgID
+		return scoped, nil
+	case bson.D:
+		return append(f[:len(f):len(f)], bson.E{Key: tenantField, Value: tenant.OrgID}), nil
+	default:
+		return nil, fmt.Errorf("tenant scope: unsupported filter type %T", filter)
+	}
+}
+
+// Find is mongo.Collection.Find confined to the tenant.
+func (c *tenantCollection) Find(ctx context.Context, filter any, opts ...*options.FindOptions) (*mongo.Cursor, error) {
+	scoped, err := scope(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	return c.coll.Find(ctx, scoped, opts...)
+}
+
+// FindOne is mongo.Collection.FindOne confined to the tenant.
+func (c *tenantCollection) FindOne(ctx context.Context, filter any, opts ...*options.FindOneOptions) *mongo.SingleResult {
+	scoped, err := scope(ctx, filter)
+	if err != nil {
+		return mongo.NewSingleResultFromDocument(bson.D{}, err, nil)
+	}
+	return c.coll.FindOne(ctx, scoped, opts...)
+}
+
+// FindOneAndUpdate is mongo.Collection.FindOneAndUpdate confined to the tenant. Upserted documents get the tenant's
+// organization ID from the filter.
+func (c *tenantCollection) FindOneAndUpdate(ctx context.Context, filter, update any, opts ...*options.FindOneAndUpdateOptions) *mongo.SingleResult {
+	scoped, err := scope(ctx, filter)
+	if err != nil {
+		return mongo.NewSingleResultFromDocument(bson.D{}, err, nil)
+	}
+	return c.coll.FindOneAndUpdate(ctx, scoped, update, opts...)
+}
+
+// InsertOne is mongo.Collection.InsertOne storing the document in the tenant. It requires a context confined to a
+// single organization, since a document spanning every tenant would belong to none.
+func (c *tenantCollection) InsertOne(ctx context.Context, document any, opts ...*options.InsertOneOptions) (*mongo.InsertOneResult, error) {
+	tenant, ok := usecase.TenantFromContext(ctx)
+	if !ok || tenant.All {
+		return nil, usecase.ErrNoTenant
+	}
+	doc, ok := document.(bson.D)
+	if !ok {
+		raw, err := bson.Marshal(document)
+		if err != nil {
+			return nil, err
+		}
+		if err := bson.Unmarshal(raw, &doc); err != nil {
+			return nil, err
+		}
+	}
+	doc = append(doc[:len(doc):len(doc)], bson.E{Key: tenantField, Value: tenant.OrgID})
+	return c.coll.InsertOne(ctx, doc, opts...)
+}
+
+// UpdateOne is mongo.Collection.UpdateOne confined to the tenant.
+func (c *tenantCollection) UpdateOne(ctx context.Context, filter, update any, opts ...*options.UpdateOptions) (*mongo.UpdateResult, error) {
+	scoped, err := scope(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	return c.coll.UpdateOne(ctx, scoped, update, opts...)
+}
+
+// UpdateMany is mongo.Collection.UpdateMany confined to the tenant.
+func (c *tenantCollection) UpdateMany(ctx context.Context, filter, update any, opts ...*options.UpdateOptions) (*mongo.UpdateResult, error) {
+	scoped, err := scope(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	return c.coll.UpdateMany(ctx, scoped, update, opts...)
+}
+
+// DeleteOne is mongo.Collection.DeleteOne confined to the tenant.
+func (c *tenantCollection) DeleteOne(ctx context.Context, filter any, opts ...*options.DeleteOptions) (*mongo.DeleteResult, error) {
+	scoped, err := scope(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	return c.coll.DeleteOne(ctx, scoped, opts...)
+}
+
+// DeleteMany is mongo.Collection.DeleteMany confined to the tenant.
+func (c *tenantCollection) DeleteMany(ctx context.Context, filter any, opts ...*options.DeleteOptions) (*mongo.DeleteResult, error) {
+	scoped, err := scope(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	return c.coll.DeleteMany(ctx, scoped, opts...)
+}
+
+// CountDocuments is mongo.Collection.CountDocuments confined to the tenant.
+func (c *tenantCollection) CountDocuments(ctx context.Context, filter any, opts ...*options.CountOptions) (int64, error) {
+	scoped, err := scope(ctx, filter)
+	if err != nil {
+		return 0, err
+	}
+	return c.coll.CountDocuments(ctx, scoped, opts...)
+}
+
+// Aggregate is mongo.Collection.Aggregate with the pipeline preceded by a $match on the tenant.
+func (c *tenantCollection) Aggregate(ctx context.Context, pipeline mongo.Pipeline, opts ...*options.AggregateOptions) (*mongo.Cursor, error) {
+	match, err := scope(ctx, bson.D{})
+	if err != nil {
+		return nil, err
+	}
+	scoped := append(mongo.Pipeline{{{Key: "$match", Value: match}}}, pipeline...)
+	return c.coll.Aggregate(ctx, scoped, opts...)
+}
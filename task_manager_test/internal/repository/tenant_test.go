@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"context"
+	"task_manager_test/internal/domain"
+	"task_manager_test/internal/usecase"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// testTenant returns the context the repository integration tests run in, confined to a single organization.
+func testTenant() context.Context {
+	return usecase.WithTenant(context.Background(), "org-1")
+}
+
+// TestScope tests that filters are confined to the tenant of the context without being modified.
+func TestScope(t *testing.T) {
+	filter := bson.M{"status": "Pending"}
+	scoped, err := scope(testTenant(), filter)
+	assert.NoError(t, err)
+	assert.Equal(t, bson.M{"status": "Pending", "org_id": "org-1"}, scoped)
+	assert.Equal(t, bson.M{"status": "Pending"}, filter, "The caller's filter must not be modified")
+
+	ordered := bson.D{{Key: "status", Value: "Pending"}}
+	scoped, err = scope(testTenant(), ordered)
+	assert.NoError(t, err)
+	assert.Equal(t, bson.D{{Key: "status", Value: "Pending"}, {Key: "org_id", Value: "org-1"}}, scoped)
+	assert.Len(t, ordered, 1)
+}
+
+// TestScope_ActorTenant tests that the actor's organization is used when no tenant is set explicitly.
+func TestScope_ActorTenant(t *testing.T) {
+	ctx := usecase.WithActor(context.Background(), usecase.Actor{UserID: "u1", Role: domain.RoleMember, OrgID: "org-2"})
+
+	scoped, err := scope(ctx, bson.M{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, bson.M{"org_id": "org-2"}, scoped)
+}
+
+// TestScope_AllTenants tests that cross-tenant contexts leave the filter alone.
+func TestScope_AllTenants(t *testing.T) {
+	scoped, err := scope(usecase.WithAllTenants(context.Background()), bson.M{"username": "alice"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, bson.M{"username": "alice"}, scoped)
+}
+
+// TestScope_Fails_Without_Tenant tests that unscoped queries are refused rather than run against every organization.
+func TestScope_Fails_Without_Tenant(t *testing.T) {
+	_, err := scope(context.Background(), bson.M{})
+	assert.ErrorIs(t, err, usecase.ErrNoTenant)
+
+	_, err = scope(testTenant(), "status = 'Pending'")
+	assert.Error(t, err)
+
+	_, err = (&tenantCollection{}).InsertOne(usecase.WithAllTenants(context.Background()), bson.D{})
+	assert.ErrorIs(t, err, usecase.ErrNoTenant, "Documents must be inserted into a single organization")
+
+	err = (&tenantCollection{}).FindOne(context.Background(), bson.M{}).Err()
+	assert.ErrorIs(t, err, usecase.ErrNoTenant)
+}
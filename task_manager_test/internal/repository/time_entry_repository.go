@@ -0,0 +1,173 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"task_manager_test/internal/domain"
+	"task_manager_test/internal/usecase"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// mongoTimeEntryRepository is the MongoDB-based implementation of the ITimeEntryRepository interface. Running
+// timers carry running: true, which a partial unique index on user_id restricts to one document per user, so two
+// timers started at once cannot both be stored.
+type mongoTimeEntryRepository struct {
+	collection *tenantCollection
+}
+
+// Add a compile-time check to ensure this struct implements the correct interface.
+var _ usecase.ITimeEntryRepository = (*mongoTimeEntryRepository)(nil)
+
+// NewMongoTimeEntryRepository is the constructor for the implementation.
+func NewMongoTimeEntryRepository(db *mongo.Database) usecase.ITimeEntryRepository {
+	return &mongoTimeEntryRepository{
+		collection: newTenantCollection(db.Collection("time_entries")),
+	}
+}
+
+// timeEntryRecord is the BSON shape of a time entry document.
+type timeEntryRecord struct {
+	ID       primitive.ObjectID `bson:"_id"`
+	TaskID   string             `bson:"task_id"`
+	UserID   string             `bson:"user_id"`
+	Username string             `bson:"username"`
+	Start    time.Time          `bson:"start"`
+	End      time.Time          `bson:"end,omitempty"`
+	// Running is only stored while the timer runs, so that the partial unique index ignores finished entries.
+	Running   bool      `bson:"running,omitempty"`
+	Note      string    `bson:"note,omitempty"`
+	Manual    bool      `bson:"manual,omitempty"`
+	CreatedAt time.Time `bson:"created_at"`
+}
+
+// newTimeEntryRecord converts e into its stored form with a new ID.
+func newTimeEntryRecord(e domain.TimeEntry) timeEntryRecord {
+	return timeEntryRecord{
+		ID:        primitive.NewObjectID(),
+		TaskID:    e.TaskID,
+		UserID:    e.UserID,
+		Username:  e.Username,
+		Start:     e.Start,
+		End:       e.End,
+		Running:   e.Running(),
+		Note:      e.Note,
+		Manual:    e.Manual,
+		CreatedAt: e.CreatedAt,
+	}
+}
+
+// toDomain converts the stored document into a domain.TimeEntry.
+func (r timeEntryRecord) toDomain() domain.TimeEntry {
+	return domain.TimeEntry{
+		ID:        r.ID.Hex(),
+		TaskID:    r.TaskID,
+		UserID:    r.UserID,
+		Username:  r.Username,
+		Start:     r.Start,
+		End:       r.End,
+		Note:      r.Note,
+		Manual:    r.Manual,
+		CreatedAt: r.CreatedAt,
+	}
+}
+
+// Start inserts the entry as a running timer. The partial unique index rejects it if the user already has one.
+func (r *mongoTimeEntryRepository) Start(ctx context.Context, e domain.TimeEntry) (domain.TimeEntry, error) {
+	e.End = time.Time{}
+	rec := newTimeEntryRecord(e)
+	if _, err := r.collection.InsertOne(ctx, rec); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return domain.TimeEntry{}, usecase.ErrTimerRunning
+		}
+		return domain.TimeEntry{}, err
+	}
+	return rec.toDomain(), nil
+}
+
+// Stop sets the end of the user's running timer on the task and clears its running flag in a single update.
+func (r *mongoTimeEntryRepository) Stop(ctx context.Context, userID, taskID string, end time.Time) (domain.TimeEntry, error) {
+	var rec timeEntryRecord
+	err := r.collection.FindOneAndUpdate(ctx,
+		bson.M{"user_id": userID, "task_id": taskID, "running": true},
+		bson.M{"$set": bson.M{"end": end}, "$unset": bson.M{"running": ""}},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(&rec)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return domain.TimeEntry{}, usecase.ErrNotFound
+		}
+		return domain.TimeEntry{}, err
+	}
+	return rec.toDomain(), nil
+}
+
+// FindRunning returns the user's running timer.
+func (r *mongoTimeEntryRepository) FindRunning(ctx context.Context, userID string) (domain.TimeEntry, error) {
+	var rec timeEntryRecord
+	if err := r.collection.FindOne(ctx, bson.M{"user_id": userID, "running": true}).Decode(&rec); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return domain.TimeEntry{}, usecase.ErrNotFound
+		}
+		return domain.TimeEntry{}, err
+	}
+	return rec.toDomain(), nil
+}
+
+// Create inserts a finished entry, generating a new unique ID.
+func (r *mongoTimeEntryRepository) Create(ctx context.Context, e domain.TimeEntry) (domain.TimeEntry, error) {
+	rec := newTimeEntryRecord(e)
+	if _, err := r.collection.InsertOne(ctx, rec); err != nil {
+		return domain.TimeEntry{}, err
+	}
+	return rec.toDomain(), nil
+}
+
+// ListByTask returns every entry logged against the task, ordered by start time.
+func (r *mongoTimeEntryRepository) ListByTask(ctx context.Context, taskID string) ([]domain.TimeEntry, error) {
+	return r.find(ctx, bson.M{"task_id": taskID})
+}
+
+// ListByUser returns the user's entries that overlap [from, to), ordered by start time. Running timers have no
+// end, so any started before to overlaps the range.
+func (r *mongoTimeEntryRepository) ListByUser(ctx context.Context, userID string, from, to time.Time) ([]domain.TimeEntry, error) {
+	return r.find(ctx, bson.M{
+		"user_id": userID,
+		"start":   bson.M{"$lt": to},
+		"$or": bson.A{
+			bson.M{"end": bson.M{"$gt": from}},
+			bson.M{"running": true},
+		},
+	})
+}
+
+// DeleteByTasks removes every entry logged against the given tasks, including running timers, which frees their
+// users to start new ones.
+func (r *mongoTimeEntryRepository) DeleteByTasks(ctx context.Context, taskIDs []string) error {
+	_, err := r.collection.DeleteMany(ctx, bson.M{"task_id": bson.M{"$in": taskIDs}})
+	return err
+}
+
+// find decodes every time entry document matching the filter.
+func (r *mongoTimeEntryRepository) find(ctx context.Context, filter bson.M) ([]domain.TimeEntry, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "start", Value: 1}, {Key: "_id", Value: 1}})
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var recs []timeEntryRecord
+	if err := cursor.All(ctx, &recs); err != nil {
+		return nil, err
+	}
+	entries := make([]domain.TimeEntry, len(recs))
+	for i, rec := range recs {
+		entries[i] = rec.toDomain()
+	}
+	return entries, nil
+}
@@ -0,0 +1,205 @@
+package repository
+
+import (
+	"context"
+	"os"
+	"sync"
+	"task_manager_test/internal/domain"
+	"task_manager_test/internal/usecase"
+	"testing"
+	"time"
+
+	"github.com/joho/godotenv"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// TimeEntryRepositoryTestSuite defines the integration test suite for the time entry repository.
+type TimeEntryRepositoryTestSuite struct {
+	suite.Suite
+	client     *mongo.Client
+	db         *mongo.Database
+	repository usecase.ITimeEntryRepository
+	at         time.Time
+}
+
+// SetupSuite connects to the dedicated test database, skipping the suite when it is not configured.
+func (s *TimeEntryRepositoryTestSuite) SetupSuite() {
+	if err := godotenv.Load("../../.env"); err != nil {
+		s.T().Log("No .env file found, proceeding with environment variables")
+	}
+
+	uri := os.Getenv("MONGODB_URI_TEST")
+	if uri == "" {
+		s.T().Skip("MONGODB_URI_TEST environment variable not set, skipping integration tests")
+	}
+
+	client, err := mongo.Connect(context.Background(), options.Client().ApplyURI(uri))
+	assert.NoError(s.T(), err, "Failed to connect to MongoDB")
+
+	s.client = client
+	s.db = client.Database("timeentrydb_test")
+}
+
+// TearDownSuite drops the test database and disconnects.
+func (s *TimeEntryRepositoryTestSuite) TearDownSuite() {
+	if s.client != nil {
+		assert.NoError(s.T(), s.db.Drop(context.Background()), "Failed to drop test database")
+		assert.NoError(s.T(), s.client.Disconnect(context.Background()), "Failed to disconnect from MongoDB")
+	}
+}
+
+// SetupTest creates the collection's indexes, which allow a single running timer per user, and a repository.
+func (s *TimeEntryRepositoryTestSuite) SetupTest() {
+	assert.NoError(s.T(), createTimeEntries(context.Background(), s.db), "SetupTest: failed to create indexes")
+	s.repository = NewMongoTimeEntryRepository(s.db)
+	s.at = time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+}
+
+// TearDownTest drops the collection to isolate tests.
+func (s *TimeEntryRepositoryTestSuite) TearDownTest() {
+	assert.NoError(s.T(), s.db.Collection("time_entries").Drop(context.Background()), "TearDownTest: failed to drop collection")
+}
+
+// TestTimeEntryRepository is the entry point for the test suite.
+func TestTimeEntryRepository(t *testing.T) {
+	suite.Run(t, new(TimeEntryRepositoryTestSuite))
+}
+
+// TestStart_AllowsOneRunningTimerPerUser verifies that of concurrent starts by one user exactly one succeeds, while
+// other users and finished entries are unaffected.
+func (s *TimeEntryRepositoryTestSuite) TestStart_AllowsOneRunningTimerPerUser() {
+	// ARRANGE
+	ctx := testTenant()
+	_, err := s.repository.Create(ctx, domain.TimeEntry{TaskID: "task-1", UserID: "user-1", Start: s.at.Add(-2 * time.Hour), End: s.at.Add(-time.Hour)})
+	require.NoError(s.T(), err)
+	_, err = s.repository.Start(ctx, domain.TimeEntry{TaskID: "task-1", UserID: "user-2", Start: s.at})
+	require.NoError(s.T(), err)
+
+	// ACT
+	var wg sync.WaitGroup
+	errs := make([]error, 5)
+	for i := range errs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, errs[i] = s.repository.Start(ctx, domain.TimeEntry{TaskID: "task-2", UserID: "user-1", Start: s.at})
+		}()
+	}
+	wg.Wait()
+
+	// ASSERT
+	started := 0
+	for _, err := range errs {
+		if err == nil {
+			started++
+		} else {
+			assert.ErrorIs(s.T(), err, usecase.ErrTimerRunning)
+		}
+	}
+	assert.Equal(s.T(), 1, started)
+	running, err := s.repository.FindRunning(ctx, "user-1")
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), "task-2", running.TaskID)
+}
+
+// TestStop_FreesTheTimer verifies that a stopped timer records its end and lets the user start another.
+func (s *TimeEntryRepositoryTestSuite) TestStop_FreesTheTimer() {
+	ctx := testTenant()
+	_, err := s.repository.Start(ctx, domain.TimeEntry{TaskID: "task-1", UserID: "user-1", Start: s.at})
+	require.NoError(s.T(), err)
+
+	_, err = s.repository.Stop(ctx, "user-1", "task-2", s.at.Add(time.Hour))
+	assert.ErrorIs(s.T(), err, usecase.ErrNotFound, "Only the timer on the given task should be stopped")
+
+	stopped, err := s.repository.Stop(ctx, "user-1", "task-1", s.at.Add(time.Hour))
+	assert.NoError(s.T(), err)
+	assert.False(s.T(), stopped.Running())
+	assert.Equal(s.T(), time.Hour, stopped.Duration(s.at))
+
+	_, err = s.repository.FindRunning(ctx, "user-1")
+	assert.ErrorIs(s.T(), err, usecase.ErrNotFound)
+	_, err = s.repository.Start(ctx, domain.TimeEntry{TaskID: "task-2", UserID: "user-1", Start: s.at.Add(time.Hour)})
+	assert.NoError(s.T(), err)
+}
+
+// TestListByUser_ReturnsOverlappingEntries verifies that entries overlapping the range, including running timers
+// started before its end, are listed by start time.
+func (s *TimeEntryRepositoryTestSuite) TestListByUser_ReturnsOverlappingEntries() {
+	// ARRANGE
+	ctx := testTenant()
+	create := func(userID string, startHour, endHour int) domain.TimeEntry {
+		e, err := s.repository.Create(ctx, domain.TimeEntry{
+			TaskID: "task-1", UserID: userID,
+			Start: s.at.Add(time.Duration(startHour) * time.Hour), End: s.at.Add(time.Duration(endHour) * time.Hour),
+		})
+		require.NoError(s.T(), err)
+		return e
+	}
+	create("user-1", -3, -2)
+	spanning := create("user-1", -1, 1)
+	inside := create("user-1", 2, 3)
+	create("user-1", 5, 6)
+	create("user-2", 2, 3)
+	running, err := s.repository.Start(ctx, domain.TimeEntry{TaskID: "task-2", UserID: "user-1", Start: s.at.Add(4 * time.Hour)})
+	require.NoError(s.T(), err)
+
+	// ACT
+	entries, err := s.repository.ListByUser(ctx, "user-1", s.at, s.at.Add(5*time.Hour))
+
+	// ASSERT
+	assert.NoError(s.T(), err)
+	var ids []string
+	for _, e := range entries {
+		ids = append(ids, e.ID)
+	}
+	assert.Equal(s.T(), []string{spanning.ID, inside.ID, running.ID}, ids)
+}
+
+// TestListByTask_IsConfinedToTheTenant verifies that a task's entries are listed by start time within the tenant.
+func (s *TimeEntryRepositoryTestSuite) TestListByTask_IsConfinedToTheTenant() {
+	ctx := testTenant()
+	later, err := s.repository.Create(ctx, domain.TimeEntry{TaskID: "task-1", UserID: "user-1", Start: s.at.Add(time.Hour), End: s.at.Add(2 * time.Hour), Manual: true})
+	require.NoError(s.T(), err)
+	earlier, err := s.repository.Start(ctx, domain.TimeEntry{TaskID: "task-1", UserID: "user-2", Start: s.at})
+	require.NoError(s.T(), err)
+	_, err = s.repository.Create(usecase.WithTenant(context.Background(), "org-2"), domain.TimeEntry{TaskID: "task-1", UserID: "user-3", Start: s.at, End: s.at.Add(time.Hour)})
+	require.NoError(s.T(), err)
+
+	entries, err := s.repository.ListByTask(ctx, "task-1")
+
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), []domain.TimeEntry{earlier, later}, entries)
+}
+
+// TestDeleteByTasks_RemovesRunningTimers verifies that deleting a task while a timer runs on it removes the timer
+// together with the task's other entries, so that its user can start a new one.
+func (s *TimeEntryRepositoryTestSuite) TestDeleteByTasks_RemovesRunningTimers() {
+	// ARRANGE
+	ctx := testTenant()
+	_, err := s.repository.Create(ctx, domain.TimeEntry{TaskID: "task-1", UserID: "user-2", Start: s.at.Add(-2 * time.Hour), End: s.at.Add(-time.Hour)})
+	require.NoError(s.T(), err)
+	_, err = s.repository.Start(ctx, domain.TimeEntry{TaskID: "task-1", UserID: "user-1", Start: s.at})
+	require.NoError(s.T(), err)
+	kept, err := s.repository.Create(ctx, domain.TimeEntry{TaskID: "task-2", UserID: "user-1", Start: s.at.Add(-2 * time.Hour), End: s.at.Add(-time.Hour)})
+	require.NoError(s.T(), err)
+
+	// ACT
+	err = s.repository.DeleteByTasks(ctx, []string{"task-1"})
+
+	// ASSERT
+	assert.NoError(s.T(), err)
+	entries, err := s.repository.ListByTask(ctx, "task-1")
+	assert.NoError(s.T(), err)
+	assert.Empty(s.T(), entries)
+	entries, err = s.repository.ListByTask(ctx, "task-2")
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), []domain.TimeEntry{kept}, entries)
+	_, err = s.repository.FindRunning(ctx, "user-1")
+	assert.ErrorIs(s.T(), err, usecase.ErrNotFound)
+	_, err = s.repository.Start(ctx, domain.TimeEntry{TaskID: "task-2", UserID: "user-1", Start: s.at})
+	assert.NoError(s.T(), err)
+}
@@ -5,15 +5,17 @@ import (
 	"errors"
 	"task_manager_test/internal/domain"
 	"task_manager_test/internal/usecase"
+	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 // mongoUserRepository is a MongoDB-backed implementation of the UserRepository interface.
 type mongoUserRepository struct {
-	collection *mongo.Collection
+	collection *tenantCollection
 }
 
 // Add this compile-time check. It will fail to compile if method signatures don't match.
@@ -22,11 +24,11 @@ var _ usecase.IUserRepository = (*mongoUserRepository)(nil)
 // NewMongoUserRepository initializes and returns a new user repository.
 func NewMongoUserRepository(db *mongo.Database) usecase.IUserRepository {
 	return &mongoUserRepository{
-		collection: db.Collection("users"),
+		collection: newTenantCollection(db.Collection("users")),
 	}
 }
 
-// Create inserts a new user document with a generated ObjectID.
+// Create inserts a new user document with a generated ObjectID into the tenant of ctx.
 func (r *mongoUserRepository) Create(ctx context.Context, u domain.User) (domain.User, error) {
 	oid := primitive.NewObjectID()
 	doc := bson.D{
@@ -47,26 +49,228 @@ func (r *mongoUserRepository) Create(ctx context.Context, u domain.User) (domain
 	return u, nil
 }
 
+// userRecord is the BSON shape of a user document.
+type userRecord struct {
+	ID           primitive.ObjectID `bson:"_id"`
+	Username     string             `bson:"username"`
+	Password     string             `bson:"password"`
+	Role         domain.Role        `bson:"role"`
+	OrgID        string             `bson:"org_id"`
+	TimeZone     string             `bson:"time_zone,omitempty"`
+	TokenVersion int                `bson:"token_version"`
+
+	FailedLogins int       `bson:"failed_logins"`
+	LockedUntil  time.Time `bson:"locked_until"`
+
+	Disabled bool `bson:"disabled"`
+}
+
+// toDomain maps a userRecord to domain.User.
+func (rec userRecord) toDomain() domain.User {
+	return domain.User{
+		ID:           rec.ID.Hex(),
+		Username:     rec.Username,
+		Password:     rec.Password,
+		Role:         rec.Role,
+		OrgID:        rec.OrgID,
+		TimeZone:     rec.TimeZone,
+		TokenVersion: rec.TokenVersion,
+
+		FailedLoginAttempts: rec.FailedLogins,
+		LockedUntil:         rec.LockedUntil,
+
+		Disabled: rec.Disabled,
+	}
+}
+
 // FindByUsername looks up a user document by username.
 func (r *mongoUserRepository) FindByUsername(ctx context.Context, username string) (domain.User, error) {
-	var rec struct {
-		ID       primitive.ObjectID `bson:"_id"`
-		Username string             `bson:"username"`
-		Password string             `bson:"password"`
-		Role     string             `bson:"role"`
+	return r.findOne(ctx, bson.M{"username": username})
+}
+
+// FindByID looks up a user document by its hexadecimal string ID.
+func (r *mongoUserRepository) FindByID(ctx context.Context, id string) (domain.User, error) {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return domain.User{}, usecase.ErrInvalidID
 	}
-	err := r.collection.FindOne(ctx, bson.M{"username": username}).Decode(&rec)
+	return r.findOne(ctx, bson.M{"_id": oid})
+}
+
+// findOne decodes the first user matching filter, mapping a missing document to usecase.ErrNotFound.
+func (r *mongoUserRepository) findOne(ctx context.Context, filter bson.M) (domain.User, error) {
+	var rec userRecord
+	err := r.collection.FindOne(ctx, filter).Decode(&rec)
 	if err != nil {
 		if errors.Is(err, mongo.ErrNoDocuments) {
 			return domain.User{}, usecase.ErrNotFound
 		}
 		return domain.User{}, err
 	}
+	return rec.toDomain(), nil
+}
 
-	return domain.User{
-		ID:       rec.ID.Hex(),
-		Username: rec.Username,
-		Password: rec.Password,
-		Role:     rec.Role,
-	}, nil
+// UpdatePassword replaces the password hash, bumps the token version and clears any lockout.
+func (r *mongoUserRepository) UpdatePassword(ctx context.Context, id, hashedPassword string) error {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return usecase.ErrInvalidID
+	}
+	res, err := r.collection.UpdateOne(ctx, bson.M{"_id": oid}, bson.M{
+		"$set":   bson.M{"password": hashedPassword, "failed_logins": 0},
+		"$inc":   bson.M{"token_version": 1},
+		"$unset": bson.M{"locked_until": ""},
+	})
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return usecase.ErrNotFound
+	}
+	return nil
+}
+
+// UpdateRole assigns a new role and increments the token version so tokens carrying the old role stop working.
+func (r *mongoUserRepository) UpdateRole(ctx context.Context, username string, role domain.Role) error {
+	res, err := r.collection.UpdateOne(ctx, bson.M{"username": username}, bson.M{
+		"$set": bson.M{"role": role},
+		"$inc": bson.M{"token_version": 1},
+	})
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return usecase.ErrNotFound
+	}
+	return nil
+}
+
+// IncrementFailedLogins atomically increments the failed login counter and returns the updated value.
+func (r *mongoUserRepository) IncrementFailedLogins(ctx context.Context, username string) (int, error) {
+	var rec struct {
+		FailedLogins int `bson:"failed_logins"`
+	}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After).SetProjection(bson.M{"failed_logins": 1})
+	err := r.collection.FindOneAndUpdate(ctx,
+		bson.M{"username": username},
+		bson.M{"$inc": bson.M{"failed_logins": 1}},
+		opts,
+	).Decode(&rec)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return 0, usecase.ErrNotFound
+		}
+		return 0, err
+	}
+	return rec.FailedLogins, nil
+}
+
+// Lock sets the lockout expiry and clears the failed login counter.
+func (r *mongoUserRepository) Lock(ctx context.Context, username string, until time.Time) error {
+	return r.updateLoginState(ctx, username, bson.M{"$set": bson.M{"failed_logins": 0, "locked_until": until}})
+}
+
+// ResetFailedLogins clears the failed login counter and any lockout.
+func (r *mongoUserRepository) ResetFailedLogins(ctx context.Context, username string) error {
+	return r.updateLoginState(ctx, username, bson.M{
+		"$set":   bson.M{"failed_logins": 0},
+		"$unset": bson.M{"locked_until": ""},
+	})
+}
+
+// updateLoginState applies update to the user's document, mapping a missing user to usecase.ErrNotFound.
+func (r *mongoUserRepository) updateLoginState(ctx context.Context, username string, update bson.M) error {
+	res, err := r.collection.UpdateOne(ctx, bson.M{"username": username}, update)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return usecase.ErrNotFound
+	}
+	return nil
+}
+
+// List returns every user document of the tenant, sorted by username.
+func (r *mongoUserRepository) List(ctx context.Context) ([]domain.User, error) {
+	cursor, err := r.collection.Find(ctx, bson.M{}, options.Find().SetSort(bson.D{{Key: "username", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	var recs []userRecord
+	if err := cursor.All(ctx, &recs); err != nil {
+		return nil, err
+	}
+	users := make([]domain.User, len(recs))
+	for i, rec := range recs {
+		users[i] = rec.toDomain()
+	}
+	return users, nil
+}
+
+// SetDisabled sets the disabled flag; disabling also increments the token version.
+func (r *mongoUserRepository) SetDisabled(ctx context.Context, username string, disabled bool) error {
+	update := bson.M{"$set": bson.M{"disabled": disabled}}
+	if disabled {
+		update["$inc"] = bson.M{"token_version": 1}
+	}
+	return r.updateLoginState(ctx, username, update)
+}
+
+// ReserveAttachmentSpace adds the reserved bytes to the user's attachment_bytes in a single conditional update, so
+// that concurrent uploads cannot together exceed the quota.
+func (r *mongoUserRepository) ReserveAttachmentSpace(ctx context.Context, userID string, maxSize, quota int64) (int64, error) {
+	oid, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return 0, usecase.ErrInvalidID
+	}
+	used := bson.M{"$ifNull": bson.A{"$attachment_bytes", 0}}
+	var rec struct {
+		AttachmentBytes int64 `bson:"attachment_bytes"`
+	}
+	err = r.collection.FindOneAndUpdate(ctx,
+		bson.M{"_id": oid, "$expr": bson.M{"$lt": bson.A{used, quota}}},
+		bson.A{bson.M{"$set": bson.M{"attachment_bytes": bson.M{
+			"$add": bson.A{used, bson.M{"$min": bson.A{maxSize, bson.M{"$subtract": bson.A{quota, used}}}}},
+		}}}},
+		options.FindOneAndUpdate().SetReturnDocument(options.Before).SetProjection(bson.M{"attachment_bytes": 1}),
+	).Decode(&rec)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return 0, usecase.ErrAttachmentQuotaExceeded
+		}
+		return 0, err
+	}
+	return min(maxSize, quota-rec.AttachmentBytes), nil
+}
+
+// ReleaseAttachmentSpace subtracts size from the user's attachment_bytes, never going below zero. User IDs are
+// unique, so the user is looked up in every tenant, whichever organization the request releasing the space is in.
+func (r *mongoUserRepository) ReleaseAttachmentSpace(ctx context.Context, userID string, size int64) error {
+	oid, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return usecase.ErrInvalidID
+	}
+	_, err = r.collection.UpdateOne(usecase.WithAllTenants(ctx),
+		bson.M{"_id": oid},
+		bson.A{bson.M{"$set": bson.M{"attachment_bytes": bson.M{
+			"$max": bson.A{0, bson.M{"$subtract": bson.A{bson.M{"$ifNull": bson.A{"$attachment_bytes", 0}}, size}}},
+		}}}},
+	)
+	return err
+}
+
+// SetTimeZone stores the user's time zone name.
+func (r *mongoUserRepository) SetTimeZone(ctx context.Context, id, name string) error {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return usecase.ErrInvalidID
+	}
+	res, err := r.collection.UpdateOne(ctx, bson.M{"_id": oid}, bson.M{"$set": bson.M{"time_zone": name}})
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return usecase.ErrNotFound
+	}
+	return nil
 }
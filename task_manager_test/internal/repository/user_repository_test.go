@@ -3,9 +3,11 @@ package repository
 import (
 	"context"
 	"os"
+	"sync"
 	"task_manager_test/internal/domain"
 	"task_manager_test/internal/usecase"
 	"testing"
+	"time"
 
 	"github.com/joho/godotenv"
 	"github.com/stretchr/testify/assert"
@@ -63,7 +65,7 @@ func (s *UserRepositoryTestSuite) SetupTest() {
 	// Create a new repository instance for each test to ensure isolation.
 	s.repository = NewMongoUserRepository(s.db)
 	// We manually set the collection to our specific test collection.
-	(s.repository.(*mongoUserRepository)).collection = s.collection
+	(s.repository.(*mongoUserRepository)).collection = newTenantCollection(s.collection)
 
 	indexModel := mongo.IndexModel{
 		Keys:    bson.M{"username": 1},
@@ -90,7 +92,7 @@ func TestUserRepositoryTestSuite(t *testing.T) {
 // TestCreateAndFindByUsername_Success tests the complete lifecycle of creating a user and then successfully retrieving them by their username.
 func (s *UserRepositoryTestSuite) TestCreateAndFindByUsername_Success() {
 	// ARRANGE
-	ctx := context.Background()
+	ctx := testTenant()
 	userToCreate := domain.User{
 		Username: "testuser",
 		Password: "password123",
@@ -117,7 +119,7 @@ func (s *UserRepositoryTestSuite) TestCreateAndFindByUsername_Success() {
 // TestCreate_Fails_When_UserAlreadyExists verifies that the repository correctly handles attempts to create a user with a username that is already taken.
 func (s *UserRepositoryTestSuite) TestCreate_Fails_When_UserAlreadyExists() {
 	// ARRANGE
-	ctx := context.Background()
+	ctx := testTenant()
 	// First, create an initial user.
 	_, err := s.repository.Create(ctx, domain.User{Username: "existinguser", Password: "p1", Role: "r1"})
 	assert.NoError(s.T(), err, "Setup: failed to create initial user")
@@ -140,7 +142,7 @@ func (s *UserRepositoryTestSuite) TestCreate_Fails_When_UserAlreadyExists() {
 // TestFindByUsername_Fails_When_NotFound ensures the repository returns the correct error when searching for a user that does not exist.
 func (s *UserRepositoryTestSuite) TestFindByUsername_Fails_When_NotFound() {
 	// ARRANGE
-	ctx := context.Background()
+	ctx := testTenant()
 	nonExistentUsername := "ghost"
 
 	// ACT
@@ -150,3 +152,198 @@ func (s *UserRepositoryTestSuite) TestFindByUsername_Fails_When_NotFound() {
 	assert.Error(s.T(), err, "FindByUsername should return an error for a non-existent user")
 	assert.ErrorIs(s.T(), err, usecase.ErrNotFound, "The error should be usecase.ErrNotFound")
 }
+
+// TestFailedLoginTracking_Lifecycle verifies that failed logins are counted atomically, locked, and reset.
+func (s *UserRepositoryTestSuite) TestFailedLoginTracking_Lifecycle() {
+	// ARRANGE
+	ctx := testTenant()
+	_, err := s.repository.Create(ctx, domain.User{Username: "lockme", Password: "p1", Role: "user"})
+	assert.NoError(s.T(), err, "Setup: failed to create user")
+
+	// ACT - Increment twice
+	first, err1 := s.repository.IncrementFailedLogins(ctx, "lockme")
+	second, err2 := s.repository.IncrementFailedLogins(ctx, "lockme")
+
+	// ASSERT - Counter increases
+	assert.NoError(s.T(), err1)
+	assert.NoError(s.T(), err2)
+	assert.Equal(s.T(), 1, first)
+	assert.Equal(s.T(), 2, second)
+
+	// ACT - Lock
+	until := time.Now().Add(15 * time.Minute).UTC().Truncate(time.Millisecond)
+	assert.NoError(s.T(), s.repository.Lock(ctx, "lockme", until))
+	locked, _ := s.repository.FindByUsername(ctx, "lockme")
+
+	// ASSERT - Lock is persisted and the counter cleared
+	assert.Equal(s.T(), 0, locked.FailedLoginAttempts)
+	assert.WithinDuration(s.T(), until, locked.LockedUntil, time.Millisecond)
+
+	// ACT - Reset
+	assert.NoError(s.T(), s.repository.ResetFailedLogins(ctx, "lockme"))
+	reset, _ := s.repository.FindByUsername(ctx, "lockme")
+
+	// ASSERT - Lock is cleared
+	assert.True(s.T(), reset.LockedUntil.IsZero(), "Lock should be cleared after a reset")
+}
+
+// TestIncrementFailedLogins_Fails_When_NotFound ensures unknown users are reported rather than silently created.
+func (s *UserRepositoryTestSuite) TestIncrementFailedLogins_Fails_When_NotFound() {
+	_, err := s.repository.IncrementFailedLogins(testTenant(), "ghost")
+
+	assert.ErrorIs(s.T(), err, usecase.ErrNotFound)
+}
+
+// TestAttachmentSpace_IsReservedWithinTheQuota verifies that concurrent reservations never exceed the quota, that the
+// last one gets what remains, and that released space can be reserved again.
+func (s *UserRepositoryTestSuite) TestAttachmentSpace_IsReservedWithinTheQuota() {
+	// ARRANGE
+	ctx := testTenant()
+	created, err := s.repository.Create(ctx, domain.User{Username: "uploader", Password: "p1", Role: "member"})
+	s.Require().NoError(err)
+
+	// ACT
+	var wg sync.WaitGroup
+	reserved := make([]int64, 5)
+	errs := make([]error, 5)
+	for i := range reserved {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			reserved[i], errs[i] = s.repository.ReserveAttachmentSpace(ctx, created.ID, 40, 100)
+		}()
+	}
+	wg.Wait()
+
+	// ASSERT
+	var total int64
+	var refused int
+	for i, err := range errs {
+		if err != nil {
+			assert.ErrorIs(s.T(), err, usecase.ErrAttachmentQuotaExceeded)
+			refused++
+		}
+		total += reserved[i]
+	}
+	assert.Equal(s.T(), int64(100), total, "Reservations should fill the quota exactly")
+	assert.Equal(s.T(), 2, refused)
+
+	assert.NoError(s.T(), s.repository.ReleaseAttachmentSpace(ctx, created.ID, 30))
+	again, err := s.repository.ReserveAttachmentSpace(ctx, created.ID, 40, 100)
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), int64(30), again)
+
+	assert.NoError(s.T(), s.repository.ReleaseAttachmentSpace(ctx, created.ID, 500), "Releasing too much should stop at zero")
+	again, err = s.repository.ReserveAttachmentSpace(ctx, created.ID, 40, 100)
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), int64(40), again)
+	assert.NoError(s.T(), s.repository.ReleaseAttachmentSpace(ctx, primitive.NewObjectID().Hex(), 10), "Releasing for a deleted user is not an error")
+}
+
+// TestUpdatePassword_RevokesSessionsAndClearsLockout verifies that a password change bumps the token version.
+func (s *UserRepositoryTestSuite) TestUpdatePassword_RevokesSessionsAndClearsLockout() {
+	// ARRANGE
+	ctx := testTenant()
+	created, err := s.repository.Create(ctx, domain.User{Username: "rotate", Password: "old-hash", Role: "user"})
+	assert.NoError(s.T(), err, "Setup: failed to create user")
+	assert.NoError(s.T(), s.repository.Lock(ctx, "rotate", time.Now().Add(time.Hour)))
+
+	// ACT
+	err = s.repository.UpdatePassword(ctx, created.ID, "new-hash")
+	updated, findErr := s.repository.FindByID(ctx, created.ID)
+
+	// ASSERT
+	assert.NoError(s.T(), err)
+	assert.NoError(s.T(), findErr)
+	assert.Equal(s.T(), "new-hash", updated.Password)
+	assert.Equal(s.T(), 1, updated.TokenVersion, "The token version should be bumped to revoke sessions")
+	assert.True(s.T(), updated.LockedUntil.IsZero(), "A new password should clear any lockout")
+}
+
+// TestUpdatePassword_Fails_When_NotFound ensures unknown IDs are reported.
+func (s *UserRepositoryTestSuite) TestUpdatePassword_Fails_When_NotFound() {
+	err := s.repository.UpdatePassword(testTenant(), primitive.NewObjectID().Hex(), "hash")
+
+	assert.ErrorIs(s.T(), err, usecase.ErrNotFound)
+}
+
+// TestUpdateRole_RevokesSessions verifies that a role change is stored and bumps the token version.
+func (s *UserRepositoryTestSuite) TestUpdateRole_RevokesSessions() {
+	// ARRANGE
+	ctx := testTenant()
+	created, err := s.repository.Create(ctx, domain.User{Username: "promoted", Password: "hash", Role: domain.RoleMember})
+	assert.NoError(s.T(), err, "Setup: failed to create user")
+
+	// ACT
+	err = s.repository.UpdateRole(ctx, "promoted", domain.RoleManager)
+	updated, findErr := s.repository.FindByID(ctx, created.ID)
+
+	// ASSERT
+	assert.NoError(s.T(), err)
+	assert.NoError(s.T(), findErr)
+	assert.Equal(s.T(), domain.RoleManager, updated.Role)
+	assert.Equal(s.T(), 1, updated.TokenVersion, "Tokens carrying the old role should be revoked")
+}
+
+// TestUpdateRole_Fails_When_NotFound ensures unknown usernames are reported.
+func (s *UserRepositoryTestSuite) TestUpdateRole_Fails_When_NotFound() {
+	err := s.repository.UpdateRole(testTenant(), "ghost", domain.RoleAdmin)
+
+	assert.ErrorIs(s.T(), err, usecase.ErrNotFound)
+}
+
+// TestList_SortsByUsername verifies that every user is returned in username order.
+func (s *UserRepositoryTestSuite) TestList_SortsByUsername() {
+	// ARRANGE
+	ctx := testTenant()
+	for _, name := range []string{"carol", "alice", "bob"} {
+		_, err := s.repository.Create(ctx, domain.User{Username: name, Password: "hash", Role: domain.RoleMember})
+		assert.NoError(s.T(), err, "Setup: failed to create user")
+	}
+
+	// ACT
+	users, err := s.repository.List(ctx)
+
+	// ASSERT
+	assert.NoError(s.T(), err)
+	if assert.Len(s.T(), users, 3) {
+		assert.Equal(s.T(), []string{"alice", "bob", "carol"}, []string{users[0].Username, users[1].Username, users[2].Username})
+	}
+}
+
+// TestSetDisabled_RevokesSessions verifies that disabling bumps the token version and enabling does not.
+func (s *UserRepositoryTestSuite) TestSetDisabled_RevokesSessions() {
+	// ARRANGE
+	ctx := testTenant()
+	created, err := s.repository.Create(ctx, domain.User{Username: "leaver", Password: "hash", Role: domain.RoleMember})
+	assert.NoError(s.T(), err, "Setup: failed to create user")
+
+	// ACT
+	disableErr := s.repository.SetDisabled(ctx, "leaver", true)
+	disabled, _ := s.repository.FindByID(ctx, created.ID)
+	enableErr := s.repository.SetDisabled(ctx, "leaver", false)
+	enabled, _ := s.repository.FindByID(ctx, created.ID)
+
+	// ASSERT
+	assert.NoError(s.T(), disableErr)
+	assert.NoError(s.T(), enableErr)
+	assert.True(s.T(), disabled.Disabled)
+	assert.Equal(s.T(), 1, disabled.TokenVersion, "Disabling should revoke existing sessions")
+	assert.False(s.T(), enabled.Disabled)
+	assert.Equal(s.T(), 1, enabled.TokenVersion)
+	assert.ErrorIs(s.T(), s.repository.SetDisabled(ctx, "ghost", true), usecase.ErrNotFound)
+}
+
+// TestSetTimeZone verifies that the time zone is stored and read back.
+func (s *UserRepositoryTestSuite) TestSetTimeZone() {
+	ctx := testTenant()
+	created, err := s.repository.Create(ctx, domain.User{Username: "traveller", Password: "hash", Role: domain.RoleMember})
+	s.Require().NoError(err)
+
+	err = s.repository.SetTimeZone(ctx, created.ID, "Africa/Addis_Ababa")
+	found, _ := s.repository.FindByID(ctx, created.ID)
+
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), "Africa/Addis_Ababa", found.TimeZone)
+	assert.ErrorIs(s.T(), s.repository.SetTimeZone(ctx, primitive.NewObjectID().Hex(), "UTC"), usecase.ErrNotFound)
+}
@@ -0,0 +1,52 @@
+package service
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"task_manager_test/internal/domain"
+	"task_manager_test/internal/usecase"
+
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// policyFile is the on-disk shape of an access policy: a map from role name to the permissions it grants.
+type policyFile struct {
+	Roles map[string][]string `yaml:"roles" toml:"roles"`
+}
+
+// LoadAccessPolicy reads a role-to-permission policy from a YAML or TOML file, chosen by extension:
+//
+//	roles:
+//	  member: [task.read.own, task.create, task.update.own, task.delete.own]
+//	  admin: ["*"]
+func LoadAccessPolicy(path string) (*usecase.AccessPolicy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read access policy: %w", err)
+	}
+
+	var file policyFile
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		dec := yaml.NewDecoder(bytes.NewReader(data))
+		dec.KnownFields(true)
+		err = dec.Decode(&file)
+	case ".toml":
+		err = toml.NewDecoder(bytes.NewReader(data)).DisallowUnknownFields().Decode(&file)
+	default:
+		return nil, fmt.Errorf("access policy %s must have a .yaml, .yml or .toml extension", path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+
+	roles := make(map[domain.Role][]string, len(file.Roles))
+	for name, perms := range file.Roles {
+		roles[domain.Role(name)] = perms
+	}
+	return usecase.NewAccessPolicy(roles)
+}
@@ -0,0 +1,54 @@
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"task_manager_test/internal/domain"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestLoadAccessPolicy verifies that YAML and TOML policies are both understood.
+func TestLoadAccessPolicy(t *testing.T) {
+	files := map[string]string{
+		"policy.yaml": "roles:\n  member: [task.read.own, task.create]\n  auditor: [task.read.any]\n  admin: [\"*\"]\n",
+		"policy.toml": "[roles]\nmember = [\"task.read.own\", \"task.create\"]\nauditor = [\"task.read.any\"]\nadmin = [\"*\"]\n",
+	}
+	for name, content := range files {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), name)
+			assert.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+			policy, err := LoadAccessPolicy(path)
+
+			assert.NoError(t, err)
+			assert.True(t, policy.Can(domain.RoleMember, domain.PermTaskCreate))
+			assert.False(t, policy.Can(domain.RoleMember, domain.PermTaskDeleteOwn))
+			assert.True(t, policy.Can("auditor", domain.PermTaskReadAny), "Custom roles should be supported")
+			assert.True(t, policy.Can(domain.RoleAdmin, domain.PermUserManage))
+		})
+	}
+}
+
+// TestLoadAccessPolicy_Errors verifies that misconfigured policies are reported.
+func TestLoadAccessPolicy_Errors(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		assert.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+		return path
+	}
+
+	_, err := LoadAccessPolicy(filepath.Join(dir, "missing.yaml"))
+	assert.ErrorContains(t, err, "read access policy")
+
+	_, err = LoadAccessPolicy(write("policy.json", "{}"))
+	assert.ErrorContains(t, err, "must have a .yaml, .yml or .toml extension")
+
+	_, err = LoadAccessPolicy(write("typo.yaml", "rolez:\n  member: [task.read.own]\n"))
+	assert.ErrorContains(t, err, "parse")
+
+	_, err = LoadAccessPolicy(write("unknown.yaml", "roles:\n  member: [task.fly]\n"))
+	assert.ErrorContains(t, err, `unknown permission "task.fly"`)
+}
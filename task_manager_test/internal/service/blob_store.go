@@ -0,0 +1,125 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"task_manager_test/internal/usecase"
+)
+
+// LocalBlobStore is a content-addressed usecase.IBlobStore on the local file system. Each file is stored as
+// <dir>/<first two hex digits>/<sha256>, so identical uploads share one file and no client-supplied name ever
+// reaches the file system.
+type LocalBlobStore struct {
+	dir string
+
+	// mu makes placing content and holding it one step with respect to Delete.
+	mu sync.Mutex
+	// held counts the holds Put placed on each digest that have not been released.
+	held map[string]int
+}
+
+// NewLocalBlobStore returns a store rooted at dir, creating the directory if needed.
+func NewLocalBlobStore(dir string) (*LocalBlobStore, error) {
+	if err := os.MkdirAll(filepath.Join(dir, "tmp"), 0o750); err != nil {
+		return nil, fmt.Errorf("create attachment directory: %w", err)
+	}
+	return &LocalBlobStore{dir: dir, held: make(map[string]int)}, nil
+}
+
+// Put writes the content to a temporary file while hashing it, then moves it into place under its digest and holds
+// it.
+func (s *LocalBlobStore) Put(_ context.Context, r io.Reader, maxSize int64) (string, int64, error) {
+	tmp, err := os.CreateTemp(filepath.Join(s.dir, "tmp"), "upload-*")
+	if err != nil {
+		return "", 0, err
+	}
+	defer os.Remove(tmp.Name())
+
+	h := sha256.New()
+	size, err := io.Copy(io.MultiWriter(tmp, h), io.LimitReader(r, maxSize+1))
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return "", 0, err
+	}
+	if size > maxSize {
+		return "", 0, usecase.ErrAttachmentTooLarge
+	}
+
+	digest := hex.EncodeToString(h.Sum(nil))
+	path := s.path(digest)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, err := os.Stat(path); err != nil {
+		if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+			return "", 0, err
+		}
+		if err := os.Rename(tmp.Name(), path); err != nil {
+			return "", 0, err
+		}
+	}
+	s.held[digest]++
+	return digest, size, nil
+}
+
+// Release ends one of the holds Put placed on the content.
+func (s *LocalBlobStore) Release(digest string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.held[digest] <= 1 {
+		delete(s.held, digest)
+		return
+	}
+	s.held[digest]--
+}
+
+// Open returns the content with the given digest.
+func (s *LocalBlobStore) Open(_ context.Context, digest string) (io.ReadCloser, error) {
+	if !validDigest(digest) {
+		return nil, usecase.ErrNotFound
+	}
+	f, err := os.Open(s.path(digest))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, usecase.ErrNotFound
+	}
+	return f, err
+}
+
+// Delete removes the content with the given digest, if present and not held.
+func (s *LocalBlobStore) Delete(_ context.Context, digest string) error {
+	if !validDigest(digest) {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.held[digest] > 0 {
+		return nil
+	}
+	err := os.Remove(s.path(digest))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+// path returns the location of the content with the given digest.
+func (s *LocalBlobStore) path(digest string) string {
+	return filepath.Join(s.dir, digest[:2], digest)
+}
+
+// validDigest reports whether digest is a hex-encoded SHA-256, so that it is safe to use as a file name.
+func validDigest(digest string) bool {
+	if len(digest) != sha256.Size*2 {
+		return false
+	}
+	_, err := hex.DecodeString(digest)
+	return err == nil
+}
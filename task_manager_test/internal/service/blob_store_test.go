@@ -0,0 +1,99 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"task_manager_test/internal/usecase"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// BlobStoreTestSuite defines the test suite for the local content-addressed blob store.
+type BlobStoreTestSuite struct {
+	suite.Suite
+	dir   string
+	store *LocalBlobStore
+}
+
+// SetupTest roots a fresh store in a temporary directory.
+func (s *BlobStoreTestSuite) SetupTest() {
+	s.dir = s.T().TempDir()
+	var err error
+	s.store, err = NewLocalBlobStore(s.dir)
+	s.Require().NoError(err)
+}
+
+// TestBlobStore runs the test suite.
+func TestBlobStore(t *testing.T) {
+	suite.Run(t, new(BlobStoreTestSuite))
+}
+
+// TestPut_StoresContentUnderItsDigest tests the round trip and that identical content is stored once.
+func (s *BlobStoreTestSuite) TestPut_StoresContentUnderItsDigest() {
+	ctx := context.Background()
+	sum := sha256.Sum256([]byte("hello"))
+	want := hex.EncodeToString(sum[:])
+
+	digest, size, err := s.store.Put(ctx, strings.NewReader("hello"), 5)
+	s.Require().NoError(err)
+	s.Equal(want, digest)
+	s.Equal(int64(5), size)
+	_, _, err = s.store.Put(ctx, strings.NewReader("hello"), 5)
+	s.Require().NoError(err)
+
+	s.FileExists(filepath.Join(s.dir, want[:2], want))
+	rc, err := s.store.Open(ctx, digest)
+	s.Require().NoError(err)
+	defer rc.Close()
+	content, _ := io.ReadAll(rc)
+	s.Equal("hello", string(content))
+	tmp, _ := os.ReadDir(filepath.Join(s.dir, "tmp"))
+	s.Empty(tmp, "Temporary files are removed")
+}
+
+// TestPut_Fails_When_ContentIsTooLarge tests that oversized content is rejected and not kept.
+func (s *BlobStoreTestSuite) TestPut_Fails_When_ContentIsTooLarge() {
+	_, _, err := s.store.Put(context.Background(), strings.NewReader("hello!"), 5)
+
+	s.ErrorIs(err, usecase.ErrAttachmentTooLarge)
+	entries, _ := os.ReadDir(s.dir)
+	s.Len(entries, 1, "Only the temporary directory remains")
+}
+
+// TestDelete_IsIdempotent tests that deleted and malformed digests are reported as missing.
+func (s *BlobStoreTestSuite) TestDelete_IsIdempotent() {
+	ctx := context.Background()
+	digest, _, err := s.store.Put(ctx, strings.NewReader("hello"), 5)
+	s.Require().NoError(err)
+	s.store.Release(digest)
+
+	s.NoError(s.store.Delete(ctx, digest))
+	s.NoError(s.store.Delete(ctx, digest))
+	_, err = s.store.Open(ctx, digest)
+	s.ErrorIs(err, usecase.ErrNotFound)
+	_, err = s.store.Open(ctx, "../../etc/passwd")
+	s.ErrorIs(err, usecase.ErrNotFound)
+}
+
+// TestDelete_KeepsHeldContent tests that content is kept until every Put of it has been released.
+func (s *BlobStoreTestSuite) TestDelete_KeepsHeldContent() {
+	ctx := context.Background()
+	digest, _, err := s.store.Put(ctx, strings.NewReader("hello"), 5)
+	s.Require().NoError(err)
+	_, _, err = s.store.Put(ctx, strings.NewReader("hello"), 5)
+	s.Require().NoError(err)
+
+	s.store.Release(digest)
+	s.NoError(s.store.Delete(ctx, digest))
+	s.FileExists(filepath.Join(s.dir, digest[:2], digest), "One hold remains")
+
+	s.store.Release(digest)
+	s.NoError(s.store.Delete(ctx, digest))
+	s.NoFileExists(filepath.Join(s.dir, digest[:2], digest))
+}
@@ -2,42 +2,98 @@ package service
 
 import (
 	"errors"
+	"slices"
+	"task_manager_test/internal/domain"
 	"task_manager_test/internal/usecase"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 )
 
-// jwtService implements the usecase.JWTService interface.
-type jwtService struct{ secret []byte }
+// jwtService implements the usecase.JWTService interface. It signs either with a shared HMAC secret or, when
+// signing is set, with an asymmetric key whose ID is written to the "kid" header.
+type jwtService struct {
+	secret   []byte
+	signing  *SigningKey
+	keys     []SigningKey          // verification keys in configuration order, signing key first
+	verify   map[string]SigningKey // keys indexed by key ID
+	methods  []string              // algorithms tokens may be signed with
+	ttl      time.Duration
+	issuer   string
+	audience string
+}
 
 // This compile-time check ensures that *jwtService satisfies the usecase.JWTService interface.
 var _ usecase.IJWTService = (*jwtService)(nil)
 
-// NewJWTService constructs a new JWTService instance with the provided HMAC secret.
-func NewJWTService(secret string) usecase.IJWTService {
-	return &jwtService{secret: []byte(secret)}
+// NewJWTService constructs a new JWTService instance that signs with the provided HMAC secret (HS256).
+// Issued tokens carry the given issuer and audience, and ValidateToken requires them; empty values are not checked.
+func NewJWTService(secret string, ttl time.Duration, issuer, audience string) usecase.IJWTService {
+	return &jwtService{secret: []byte(secret), methods: []string{jwt.SigningMethodHS256.Alg()}, ttl: ttl, issuer: issuer, audience: audience}
 }
 
-// GenerateToken creates a JWT signed with HS256, containing username, role, and expiration (24h).
-func (j *jwtService) GenerateToken(username, role string) (string, error) {
+// NewAsymmetricJWTService constructs a JWTService that signs with an RS256, ES256 or EdDSA private key and
+// accepts tokens signed by it or by any of the additional verification keys, which allows keys to be rotated
+// without invalidating tokens that are still in flight.
+func NewAsymmetricJWTService(signing SigningKey, verification []SigningKey, ttl time.Duration, issuer, audience string) (usecase.IJWTService, error) {
+	if !signing.CanSign() {
+		return nil, errors.New("signing key has no private key")
+	}
+	keys := []SigningKey{signing}
+	verify := map[string]SigningKey{signing.ID: signing}
+	methods := []string{signing.Method.Alg()}
+	for _, k := range verification {
+		if _, dup := verify[k.ID]; !dup {
+			k.private = nil
+			keys = append(keys, k)
+			verify[k.ID] = k
+			if !slices.Contains(methods, k.Method.Alg()) {
+				methods = append(methods, k.Method.Alg())
+			}
+		}
+	}
+	return &jwtService{signing: &signing, keys: keys, verify: verify, methods: methods, ttl: ttl, issuer: issuer, audience: audience}, nil
+}
+
+// GenerateToken creates a signed JWT containing the user ID, username, role, organization, token version, issuer,
+// audience, issue time and expiration (now + ttl).
+func (j *jwtService) GenerateToken(u domain.User) (string, error) {
+	now := time.Now()
 	claims := jwt.MapClaims{
-		"username": username,
-		"role":     role,
-		"exp":      time.Now().Add(24 * time.Hour).Unix(),
+		"sub":      u.ID,
+		"username": u.Username,
+		"role":     u.Role,
+		"org":      u.OrgID,
+		"ver":      u.TokenVersion,
+		"iat":      now.Unix(),
+		"exp":      now.Add(j.ttl).Unix(),
+	}
+	if j.issuer != "" {
+		claims["iss"] = j.issuer
 	}
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString(j.secret)
+	if j.audience != "" {
+		claims["aud"] = j.audience
+	}
+	if j.signing == nil {
+		return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(j.secret)
+	}
+	token := jwt.NewWithClaims(j.signing.Method, claims)
+	token.Header["kid"] = j.signing.ID
+	return token.SignedString(j.signing.private)
 }
 
-// ValidateToken parses and verifies a token string, returning claims if valid.
+// ValidateToken parses and verifies a token string, including its issuer and audience, returning claims if valid.
+// Tokens must be signed with an algorithm of the configured keys: HS256 for the HMAC secret, so that a token signed
+// with the secret under another HS* algorithm is refused.
 func (j *jwtService) ValidateToken(tokenStr string) (jwt.MapClaims, error) {
-	token, err := jwt.Parse(tokenStr, func(t *jwt.Token) (interface{}, error) {
-		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, errors.New("unexpected signing method")
-		}
-		return j.secret, nil
-	})
+	opts := []jwt.ParserOption{jwt.WithValidMethods(j.methods)}
+	if j.issuer != "" {
+		opts = append(opts, jwt.WithIssuer(j.issuer))
+	}
+	if j.audience != "" {
+		opts = append(opts, jwt.WithAudience(j.audience))
+	}
+	token, err := jwt.Parse(tokenStr, j.keyFor, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -50,3 +106,32 @@ func (j *jwtService) ValidateToken(tokenStr string) (jwt.MapClaims, error) {
 	}
 	return claims, nil
 }
+
+// keyFor selects the verification key for a token, refusing any algorithm other than the one the key was made for.
+func (j *jwtService) keyFor(t *jwt.Token) (interface{}, error) {
+	if j.signing == nil {
+		if t.Method.Alg() != jwt.SigningMethodHS256.Alg() {
+			return nil, errors.New("unexpected signing method")
+		}
+		return j.secret, nil
+	}
+	kid, _ := t.Header["kid"].(string)
+	key, ok := j.verify[kid]
+	if !ok {
+		return nil, errors.New("unknown signing key")
+	}
+	if t.Method.Alg() != key.Method.Alg() {
+		return nil, errors.New("unexpected signing method")
+	}
+	return key.public, nil
+}
+
+// PublicKeys returns the keys that verify issued tokens, with the current signing key first.
+// It is empty in HMAC mode, where the secret must never be published.
+func (j *jwtService) PublicKeys() []domain.JSONWebKey {
+	keys := make([]domain.JSONWebKey, 0, len(j.keys))
+	for _, k := range j.keys {
+		keys = append(keys, k.JWK())
+	}
+	return keys
+}
@@ -3,6 +3,7 @@ package service
 import (
 	"crypto/rand"
 	"crypto/rsa"
+	"task_manager_test/internal/domain"
 	"task_manager_test/internal/usecase"
 	"testing"
 	"time"
@@ -12,6 +13,12 @@ import (
 	"github.com/stretchr/testify/suite"
 )
 
+// testIssuer and testAudience are the claims every service under test issues and requires.
+const (
+	testIssuer   = "task-manager"
+	testAudience = "task-manager-api"
+)
+
 // JWTServiceTestSuite defines the test suite for the JWT service.
 type JWTServiceTestSuite struct {
 	suite.Suite
@@ -22,7 +29,7 @@ type JWTServiceTestSuite struct {
 // SetupTest runs before each test in the suite.
 func (s *JWTServiceTestSuite) SetupTest() {
 	s.secretKey = "a-very-secure-secret-key-for-testing"
-	s.jwtService = NewJWTService(s.secretKey)
+	s.jwtService = NewJWTService(s.secretKey, 24*time.Hour, testIssuer, testAudience)
 }
 
 // TestJWTServiceTestSuite is the entry point for the Go test runner.
@@ -36,10 +43,10 @@ func TestJWTServiceTestSuite(t *testing.T) {
 func (s *JWTServiceTestSuite) TestGenerateAndValidateToken_RoundTripSuccess() {
 	// ARRANGE
 	username := "testuser"
-	role := "admin"
+	role := domain.RoleAdmin
 
 	// ACT - Generate the token
-	tokenString, err := s.jwtService.GenerateToken(username, role)
+	tokenString, err := s.jwtService.GenerateToken(domain.User{ID: "user-123", Username: username, Role: role, OrgID: "org-1", TokenVersion: 3})
 
 	// ASSERT - Generation
 	assert.NoError(s.T(), err, "Token generation should not produce an error")
@@ -52,7 +59,12 @@ func (s *JWTServiceTestSuite) TestGenerateAndValidateToken_RoundTripSuccess() {
 	assert.NoError(s.T(), err, "Token validation should not produce an error for a valid token")
 	assert.NotNil(s.T(), claims, "Claims should not be nil for a valid token")
 	assert.Equal(s.T(), username, claims["username"], "Username in claims should match the original")
-	assert.Equal(s.T(), role, claims["role"], "Role in claims should match the original")
+	assert.Equal(s.T(), string(role), claims["role"], "Role in claims should match the original")
+	assert.Equal(s.T(), "user-123", claims["sub"], "Subject in claims should be the user ID")
+	assert.Equal(s.T(), "org-1", claims["org"], "Organization in claims should match the user's")
+	assert.Equal(s.T(), float64(3), claims["ver"], "Token version in claims should match the user's")
+	assert.Equal(s.T(), testIssuer, claims["iss"], "Issuer should be set")
+	assert.Equal(s.T(), testAudience, claims["aud"], "Audience should be set")
 
 	// Verify the expiration claim ('exp') is set correctly in the future
 	expClaim, ok := claims["exp"].(float64)
@@ -61,6 +73,22 @@ func (s *JWTServiceTestSuite) TestGenerateAndValidateToken_RoundTripSuccess() {
 	assert.InDelta(s.T(), expectedExp, int64(expClaim), 2, "Expiration time should be approximately 24 hours from now")
 }
 
+// TestGenerateToken_UsesConfiguredTTL verifies that the expiration claim follows the injected token lifetime.
+func (s *JWTServiceTestSuite) TestGenerateToken_UsesConfiguredTTL() {
+	// ARRANGE
+	shortLived := NewJWTService(s.secretKey, 15*time.Minute, testIssuer, testAudience)
+
+	// ACT
+	tokenString, err := shortLived.GenerateToken(domain.User{Username: "testuser", Role: "user"})
+	s.Require().NoError(err, "Setup: Failed to generate token")
+	claims, err := shortLived.ValidateToken(tokenString)
+
+	// ASSERT
+	assert.NoError(s.T(), err)
+	expClaim, _ := claims["exp"].(float64)
+	assert.InDelta(s.T(), time.Now().Add(15*time.Minute).Unix(), int64(expClaim), 2, "Expiration time should match the configured TTL")
+}
+
 // TestValidateToken_Fails_When_Expired tests the edge case where a token is expired.
 func (s *JWTServiceTestSuite) TestValidateToken_Fails_When_Expired() {
 	// ARRANGE
@@ -84,9 +112,9 @@ func (s *JWTServiceTestSuite) TestValidateToken_Fails_When_Expired() {
 // TestValidateToken_Fails_When_InvalidSignature tests the critical security case where a token was signed with a different secret key.
 func (s *JWTServiceTestSuite) TestValidateToken_Fails_When_InvalidSignature() {
 	// ARRANGE
-	tokenString, err := s.jwtService.GenerateToken("legituser", "user")
+	tokenString, err := s.jwtService.GenerateToken(domain.User{Username: "legituser", Role: "user"})
 	assert.NoError(s.T(), err, "Setup: Failed to generate token")
-	invalidService := NewJWTService("this-is-the-wrong-secret")
+	invalidService := NewJWTService("this-is-the-wrong-secret", 24*time.Hour, testIssuer, testAudience)
 
 	// ACT
 	_, err = invalidService.ValidateToken(tokenString)
@@ -113,8 +141,28 @@ func (s *JWTServiceTestSuite) TestValidateToken_Fails_When_InvalidSigningMethod(
 
 	// ASSERT
 	assert.Error(s.T(), err, "Validation should fail for a token with an unexpected signing method")
-	// Use ErrorContains to check for our specific error, ignoring the library's wrapper text.
-	assert.ErrorContains(s.T(), err, "unexpected signing method", "Error should indicate the signing method mismatch")
+	assert.ErrorIs(s.T(), err, jwt.ErrTokenSignatureInvalid)
+	assert.ErrorContains(s.T(), err, "signing method RS256 is invalid", "Error should indicate the signing method mismatch")
+}
+
+// TestValidateToken_Fails_When_SignedWithAnotherHMACAlgorithm tests that a token signed with the secret under an
+// HMAC algorithm other than HS256 is rejected.
+func (s *JWTServiceTestSuite) TestValidateToken_Fails_When_SignedWithAnotherHMACAlgorithm() {
+	for _, method := range []jwt.SigningMethod{jwt.SigningMethodHS384, jwt.SigningMethodHS512} {
+		s.Run(method.Alg(), func() {
+			// ARRANGE
+			claims := jwt.MapClaims{"sub": "user-1", "username": "alice", "role": "user", "iss": testIssuer, "aud": testAudience, "exp": time.Now().Add(time.Hour).Unix()}
+			tokenString, err := jwt.NewWithClaims(method, claims).SignedString([]byte(s.secretKey))
+			assert.NoError(s.T(), err, "Setup: Failed to sign token")
+
+			// ACT
+			_, err = s.jwtService.ValidateToken(tokenString)
+
+			// ASSERT
+			assert.ErrorIs(s.T(), err, jwt.ErrTokenSignatureInvalid)
+			assert.ErrorContains(s.T(), err, "signing method "+method.Alg()+" is invalid")
+		})
+	}
 }
 
 // TestValidateToken_Fails_When_MalformedToken tests how the service handles input that is not a valid JWT formatted string.
@@ -129,3 +177,36 @@ func (s *JWTServiceTestSuite) TestValidateToken_Fails_When_MalformedToken() {
 	assert.Error(s.T(), err, "Validation should fail for a malformed token string")
 	assert.ErrorContains(s.T(), err, "token is malformed", "Error should indicate a malformed token")
 }
+
+// TestValidateToken_Fails_When_IssuerOrAudienceDiffers tests that tokens minted for another issuer or audience are rejected.
+func (s *JWTServiceTestSuite) TestValidateToken_Fails_When_IssuerOrAudienceDiffers() {
+	cases := map[string]struct {
+		claims  jwt.MapClaims
+		wantErr string
+	}{
+		"wrong issuer":     {jwt.MapClaims{"iss": "someone-else", "aud": testAudience}, "token has invalid issuer"},
+		"missing issuer":   {jwt.MapClaims{"aud": testAudience}, "token is missing required claim"},
+		"wrong audience":   {jwt.MapClaims{"iss": testIssuer, "aud": "another-api"}, "token has invalid audience"},
+		"missing audience": {jwt.MapClaims{"iss": testIssuer}, "token is missing required claim"},
+	}
+	for name, tc := range cases {
+		s.Run(name, func() {
+			// ARRANGE
+			tc.claims["username"] = "testuser"
+			tc.claims["exp"] = time.Now().Add(time.Hour).Unix()
+			tokenString, err := jwt.NewWithClaims(jwt.SigningMethodHS256, tc.claims).SignedString([]byte(s.secretKey))
+			s.Require().NoError(err, "Setup: Failed to sign token")
+
+			// ACT
+			_, err = s.jwtService.ValidateToken(tokenString)
+
+			// ASSERT
+			assert.ErrorContains(s.T(), err, tc.wantErr)
+		})
+	}
+}
+
+// TestPublicKeys_EmptyInHMACMode tests that the shared secret is never published.
+func (s *JWTServiceTestSuite) TestPublicKeys_EmptyInHMACMode() {
+	assert.Empty(s.T(), s.jwtService.PublicKeys())
+}
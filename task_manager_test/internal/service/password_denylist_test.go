@@ -0,0 +1,27 @@
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestLoadPasswordDenylist verifies that comments and blank lines are skipped.
+func TestLoadPasswordDenylist(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "denylist.txt")
+	assert.NoError(t, os.WriteFile(path, []byte("# breached\npassword123\n\n  qwerty  \n"), 0o600))
+
+	list, err := LoadPasswordDenylist(path)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"password123", "qwerty"}, list)
+}
+
+// TestLoadPasswordDenylist_MissingFile verifies that a misconfigured path is reported.
+func TestLoadPasswordDenylist_MissingFile(t *testing.T) {
+	_, err := LoadPasswordDenylist(filepath.Join(t.TempDir(), "missing.txt"))
+
+	assert.ErrorContains(t, err, "open password denylist")
+}
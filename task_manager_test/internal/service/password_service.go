@@ -7,19 +7,19 @@ import (
 )
 
 // bcryptHasher is an implementation of the PasswordService interface using bcrypt.
-type bcryptHasher struct{}
+type bcryptHasher struct{ cost int }
 
 // This compile-time check ensures that *bcryptHasher satisfies the IPasswordService interface.
 var _ usecase.IPasswordService = (*bcryptHasher)(nil)
 
-// NewPasswordHasher constructs a new instance of bcryptHasher.
-func NewPasswordHasher() usecase.IPasswordService {
-	return &bcryptHasher{}
+// NewPasswordHasher constructs a new instance of bcryptHasher using the given bcrypt work factor.
+func NewPasswordHasher(cost int) usecase.IPasswordService {
+	return &bcryptHasher{cost: cost}
 }
 
 // Hash generates a bcrypt hash from a plain-text password.
 func (h *bcryptHasher) Hash(password string) (string, error) {
-	b, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	b, err := bcrypt.GenerateFromPassword([]byte(password), h.cost)
 	return string(b), err
 }
 
@@ -17,7 +17,7 @@ type PasswordServiceTestSuite struct {
 
 // SetupTest is run before each test in the suite and initializes the hasher.
 func (s *PasswordServiceTestSuite) SetupTest() {
-	s.hasher = NewPasswordHasher()
+	s.hasher = NewPasswordHasher(bcrypt.DefaultCost)
 }
 
 // TestPasswordService runs the entire test suite.
@@ -57,6 +57,17 @@ func (s *PasswordServiceTestSuite) TestHashEmptyPassword() {
 	s.Assert().NoError(err, "The generated hash should be verifiable against an empty password")
 }
 
+// TestHashUsesConfiguredCost verifies that the injected work factor is used for new hashes.
+func (s *PasswordServiceTestSuite) TestHashUsesConfiguredCost() {
+	hasher := NewPasswordHasher(bcrypt.MinCost)
+	hashedPassword, err := hasher.Hash("any-password")
+	s.Require().NoError(err, "Hashing should not produce an error")
+
+	cost, err := bcrypt.Cost([]byte(hashedPassword))
+	s.Assert().NoError(err, "The generated hash should carry a readable cost")
+	s.Assert().Equal(bcrypt.MinCost, cost, "The hash should use the configured cost")
+}
+
 // TestCompareSuccess tests the successful comparison of a correct password and hash.
 func (s *PasswordServiceTestSuite) TestCompareSuccess() {
 	password := "correct-password"
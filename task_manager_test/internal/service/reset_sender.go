@@ -0,0 +1,26 @@
+package service
+
+import (
+	"context"
+	"log"
+	"task_manager_test/internal/domain"
+	"task_manager_test/internal/usecase"
+	"time"
+)
+
+// logResetSender is a development implementation of usecase.IResetTokenSender that writes reset tokens to a log.
+type logResetSender struct{ logger *log.Logger }
+
+// This compile-time check ensures that *logResetSender satisfies the IResetTokenSender interface.
+var _ usecase.IResetTokenSender = (*logResetSender)(nil)
+
+// NewLogResetSender constructs a sender that logs reset tokens instead of delivering them. Never use it in production.
+func NewLogResetSender(logger *log.Logger) usecase.IResetTokenSender {
+	return &logResetSender{logger: logger}
+}
+
+// SendPasswordReset logs the token for the given user.
+func (s *logResetSender) SendPasswordReset(ctx context.Context, u domain.User, token string, expiresAt time.Time) error {
+	s.logger.Printf("password reset requested for %q: token=%s expires=%s", u.Username, token, expiresAt.UTC().Format(time.RFC3339))
+	return nil
+}
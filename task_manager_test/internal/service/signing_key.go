@@ -0,0 +1,217 @@
+package service
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"task_manager_test/internal/domain"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// minRSABits is the smallest RSA modulus accepted for signing or verification.
+const minRSABits = 2048
+
+// SigningKey is an asymmetric key used to sign or verify tokens. The algorithm is inferred from the key type:
+// RSA keys use RS256, P-256 keys use ES256 and Ed25519 keys use EdDSA. The key ID is the RFC 7638 thumbprint of
+// the public key, so it is stable across restarts and identical on every instance without extra configuration.
+type SigningKey struct {
+	ID      string
+	Method  jwt.SigningMethod
+	private crypto.Signer
+	public  crypto.PublicKey
+}
+
+// CanSign reports whether the key holds a private key.
+func (k SigningKey) CanSign() bool {
+	return k.private != nil
+}
+
+// LoadSigningKey reads a PEM-encoded private key (PKCS#8, PKCS#1 or SEC 1) used to sign new tokens.
+func LoadSigningKey(path string) (SigningKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return SigningKey{}, fmt.Errorf("read signing key: %w", err)
+	}
+	key, err := ParseSigningKeyPEM(data)
+	if err != nil {
+		return SigningKey{}, fmt.Errorf("signing key %s: %w", path, err)
+	}
+	return key, nil
+}
+
+// LoadVerificationKey reads a PEM-encoded public key (PKIX or PKCS#1) that is still accepted when verifying tokens,
+// typically the key that was rotated out. A private key file is also accepted; only its public half is kept.
+func LoadVerificationKey(path string) (SigningKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return SigningKey{}, fmt.Errorf("read verification key: %w", err)
+	}
+	key, err := ParseVerificationKeyPEM(data)
+	if err != nil {
+		return SigningKey{}, fmt.Errorf("verification key %s: %w", path, err)
+	}
+	return key, nil
+}
+
+// ParseSigningKeyPEM parses a PEM-encoded private key.
+func ParseSigningKeyPEM(data []byte) (SigningKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return SigningKey{}, errors.New("no PEM block found")
+	}
+	var (
+		priv any
+		err  error
+	)
+	switch block.Type {
+	case "PRIVATE KEY":
+		priv, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		priv, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		priv, err = x509.ParseECPrivateKey(block.Bytes)
+	default:
+		return SigningKey{}, fmt.Errorf("unsupported PEM block %q, expected a private key", block.Type)
+	}
+	if err != nil {
+		return SigningKey{}, err
+	}
+	signer, ok := priv.(crypto.Signer)
+	if !ok {
+		return SigningKey{}, fmt.Errorf("unsupported private key type %T", priv)
+	}
+	key, err := newSigningKey(signer.Public())
+	if err != nil {
+		return SigningKey{}, err
+	}
+	key.private = signer
+	return key, nil
+}
+
+// ParseVerificationKeyPEM parses a PEM-encoded public key, or the public half of a private key.
+func ParseVerificationKeyPEM(data []byte) (SigningKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return SigningKey{}, errors.New("no PEM block found")
+	}
+	switch block.Type {
+	case "PUBLIC KEY":
+		pub, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return SigningKey{}, err
+		}
+		return newSigningKey(pub)
+	case "RSA PUBLIC KEY":
+		pub, err := x509.ParsePKCS1PublicKey(block.Bytes)
+		if err != nil {
+			return SigningKey{}, err
+		}
+		return newSigningKey(pub)
+	default:
+		key, err := ParseSigningKeyPEM(data)
+		if err != nil {
+			return SigningKey{}, err
+		}
+		key.private = nil
+		return key, nil
+	}
+}
+
+// newSigningKey infers the algorithm for a public key and derives its key ID.
+func newSigningKey(pub crypto.PublicKey) (SigningKey, error) {
+	key := SigningKey{public: pub}
+	switch p := pub.(type) {
+	case *rsa.PublicKey:
+		if p.N.BitLen() < minRSABits {
+			return SigningKey{}, fmt.Errorf("RSA keys must be at least %d bits (got %d)", minRSABits, p.N.BitLen())
+		}
+		key.Method = jwt.SigningMethodRS256
+	case *ecdsa.PublicKey:
+		if p.Curve != elliptic.P256() {
+			return SigningKey{}, fmt.Errorf("EC keys must use the P-256 curve (got %s)", p.Curve.Params().Name)
+		}
+		key.Method = jwt.SigningMethodES256
+	case ed25519.PublicKey:
+		key.Method = jwt.SigningMethodEdDSA
+	default:
+		return SigningKey{}, fmt.Errorf("unsupported public key type %T", pub)
+	}
+	thumbprint, err := key.thumbprint()
+	if err != nil {
+		return SigningKey{}, err
+	}
+	key.ID = thumbprint
+	return key, nil
+}
+
+// JWK returns the public key in JSON Web Key form.
+func (k SigningKey) JWK() domain.JSONWebKey {
+	jwk := domain.JSONWebKey{KeyID: k.ID, Use: "sig", Algorithm: k.Method.Alg()}
+	switch p := k.public.(type) {
+	case *rsa.PublicKey:
+		jwk.KeyType = "RSA"
+		jwk.N = b64(p.N.Bytes())
+		jwk.E = b64(big.NewInt(int64(p.E)).Bytes())
+	case *ecdsa.PublicKey:
+		size := (p.Curve.Params().BitSize + 7) / 8
+		jwk.KeyType = "EC"
+		jwk.Curve = p.Curve.Params().Name
+		jwk.X = b64(p.X.FillBytes(make([]byte, size)))
+		jwk.Y = b64(p.Y.FillBytes(make([]byte, size)))
+	case ed25519.PublicKey:
+		jwk.KeyType = "OKP"
+		jwk.Curve = "Ed25519"
+		jwk.X = b64(p)
+	}
+	return jwk
+}
+
+// thumbprint computes the RFC 7638 JWK thumbprint: the SHA-256 of the required members in lexicographic order.
+func (k SigningKey) thumbprint() (string, error) {
+	jwk := k.JWK()
+	var members any
+	switch jwk.KeyType {
+	case "RSA":
+		members = struct {
+			E   string `json:"e"`
+			Kty string `json:"kty"`
+			N   string `json:"n"`
+		}{jwk.E, jwk.KeyType, jwk.N}
+	case "EC":
+		members = struct {
+			Crv string `json:"crv"`
+			Kty string `json:"kty"`
+			X   string `json:"x"`
+			Y   string `json:"y"`
+		}{jwk.Curve, jwk.KeyType, jwk.X, jwk.Y}
+	default:
+		members = struct {
+			Crv string `json:"crv"`
+			Kty string `json:"kty"`
+			X   string `json:"x"`
+		}{jwk.Curve, jwk.KeyType, jwk.X}
+	}
+	data, err := json.Marshal(members)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return b64(sum[:]), nil
+}
+
+// b64 encodes bytes as unpadded base64url, as used throughout the JOSE specifications.
+func b64(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
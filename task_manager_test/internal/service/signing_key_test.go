@@ -0,0 +1,228 @@
+package service
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"task_manager_test/internal/domain"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/suite"
+)
+
+// SigningKeyTestSuite defines the test suite for PEM key loading and asymmetric token signing.
+type SigningKeyTestSuite struct {
+	suite.Suite
+	rsaKey *rsa.PrivateKey
+	ecKey  *ecdsa.PrivateKey
+	edKey  ed25519.PrivateKey
+}
+
+// SetupSuite generates one key of each supported type; RSA generation is too slow to repeat per test.
+func (s *SigningKeyTestSuite) SetupSuite() {
+	var err error
+	s.rsaKey, err = rsa.GenerateKey(rand.Reader, 2048)
+	s.Require().NoError(err)
+	s.ecKey, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	s.Require().NoError(err)
+	_, s.edKey, err = ed25519.GenerateKey(rand.Reader)
+	s.Require().NoError(err)
+}
+
+// TestSigningKey runs the entire test suite.
+func TestSigningKey(t *testing.T) {
+	suite.Run(t, new(SigningKeyTestSuite))
+}
+
+// privatePEM encodes a private key as PKCS#8.
+func (s *SigningKeyTestSuite) privatePEM(key crypto.Signer) []byte {
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	s.Require().NoError(err)
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+}
+
+// publicPEM encodes the public half of a key as PKIX.
+func (s *SigningKeyTestSuite) publicPEM(key crypto.Signer) []byte {
+	der, err := x509.MarshalPKIXPublicKey(key.Public())
+	s.Require().NoError(err)
+	return pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+}
+
+// signingKey parses a private key through the PEM path used in production.
+func (s *SigningKeyTestSuite) signingKey(key crypto.Signer) SigningKey {
+	k, err := ParseSigningKeyPEM(s.privatePEM(key))
+	s.Require().NoError(err)
+	return k
+}
+
+// TestParse_InfersAlgorithmFromKeyType verifies the algorithm and JWK shape for every supported key type.
+func (s *SigningKeyTestSuite) TestParse_InfersAlgorithmFromKeyType() {
+	cases := []struct {
+		key      crypto.Signer
+		alg      string
+		kty      string
+		hasY     bool
+		hasNAndE bool
+	}{
+		{s.rsaKey, "RS256", "RSA", false, true},
+		{s.ecKey, "ES256", "EC", true, false},
+		{s.edKey, "EdDSA", "OKP", false, false},
+	}
+	for _, tc := range cases {
+		s.Run(tc.alg, func() {
+			key := s.signingKey(tc.key)
+			jwk := key.JWK()
+
+			s.Equal(tc.alg, key.Method.Alg())
+			s.Equal(tc.kty, jwk.KeyType)
+			s.Equal(tc.alg, jwk.Algorithm)
+			s.Equal("sig", jwk.Use)
+			s.Equal(key.ID, jwk.KeyID)
+			s.NotEmpty(key.ID, "The key ID should be derived from the public key")
+			s.Equal(tc.hasY, jwk.Y != "")
+			s.Equal(tc.hasNAndE, jwk.N != "" && jwk.E != "")
+		})
+	}
+}
+
+// TestParse_KeyIDIsStableAcrossEncodings verifies that the public and private files of one key share a key ID.
+func (s *SigningKeyTestSuite) TestParse_KeyIDIsStableAcrossEncodings() {
+	private := s.signingKey(s.ecKey)
+	public, err := ParseVerificationKeyPEM(s.publicPEM(s.ecKey))
+
+	s.Require().NoError(err)
+	s.Equal(private.ID, public.ID)
+	s.True(private.CanSign())
+	s.False(public.CanSign())
+}
+
+// TestParse_RejectsWeakOrUnsupportedKeys verifies that weak RSA keys, other curves and non-key PEM are refused.
+func (s *SigningKeyTestSuite) TestParse_RejectsWeakOrUnsupportedKeys() {
+	weak, err := rsa.GenerateKey(rand.Reader, 1024)
+	s.Require().NoError(err)
+	p384, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	s.Require().NoError(err)
+
+	_, err = ParseSigningKeyPEM(s.privatePEM(weak))
+	s.ErrorContains(err, "RSA keys must be at least 2048 bits")
+
+	_, err = ParseSigningKeyPEM(s.privatePEM(p384))
+	s.ErrorContains(err, "EC keys must use the P-256 curve")
+
+	_, err = ParseSigningKeyPEM(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: []byte("x")}))
+	s.ErrorContains(err, "unsupported PEM block")
+
+	_, err = ParseSigningKeyPEM([]byte("not pem"))
+	s.ErrorContains(err, "no PEM block found")
+}
+
+// TestLoadSigningKey_ReadsFile verifies loading from disk and that the path is named in errors.
+func (s *SigningKeyTestSuite) TestLoadSigningKey_ReadsFile() {
+	dir := s.T().TempDir()
+	path := filepath.Join(dir, "jwt.pem")
+	s.Require().NoError(os.WriteFile(path, s.privatePEM(s.edKey), 0o600))
+	bad := filepath.Join(dir, "bad.pem")
+	s.Require().NoError(os.WriteFile(bad, []byte("garbage"), 0o600))
+
+	key, err := LoadSigningKey(path)
+	s.NoError(err)
+	s.Equal("EdDSA", key.Method.Alg())
+
+	_, err = LoadVerificationKey(bad)
+	s.ErrorContains(err, "verification key "+bad)
+}
+
+// TestAsymmetricService_RoundTrip verifies that tokens signed with each key type validate and carry a kid header.
+func (s *SigningKeyTestSuite) TestAsymmetricService_RoundTrip() {
+	for _, key := range []crypto.Signer{s.rsaKey, s.ecKey, s.edKey} {
+		signing := s.signingKey(key)
+		s.Run(signing.Method.Alg(), func() {
+			// ARRANGE
+			svc, err := NewAsymmetricJWTService(signing, nil, time.Hour, testIssuer, testAudience)
+			s.Require().NoError(err)
+
+			// ACT
+			tokenString, err := svc.GenerateToken(domain.User{ID: "user-1", Username: "alice", Role: "user"})
+			s.Require().NoError(err)
+			claims, err := svc.ValidateToken(tokenString)
+
+			// ASSERT
+			s.NoError(err)
+			s.Equal("alice", claims["username"])
+			parsed, _, err := jwt.NewParser().ParseUnverified(tokenString, jwt.MapClaims{})
+			s.Require().NoError(err)
+			s.Equal(signing.ID, parsed.Header["kid"])
+			s.Equal(signing.Method.Alg(), parsed.Header["alg"])
+		})
+	}
+}
+
+// TestAsymmetricService_Rotation verifies that tokens from a rotated-out key still validate while it is configured.
+func (s *SigningKeyTestSuite) TestAsymmetricService_Rotation() {
+	// ARRANGE
+	oldKey := s.signingKey(s.rsaKey)
+	newKey := s.signingKey(s.ecKey)
+	oldSvc, err := NewAsymmetricJWTService(oldKey, nil, time.Hour, testIssuer, testAudience)
+	s.Require().NoError(err)
+	oldToken, err := oldSvc.GenerateToken(domain.User{Username: "alice"})
+	s.Require().NoError(err)
+	oldPublic, err := ParseVerificationKeyPEM(s.publicPEM(s.rsaKey))
+	s.Require().NoError(err)
+
+	rotated, err := NewAsymmetricJWTService(newKey, []SigningKey{oldPublic}, time.Hour, testIssuer, testAudience)
+	s.Require().NoError(err)
+	retired, err := NewAsymmetricJWTService(newKey, nil, time.Hour, testIssuer, testAudience)
+	s.Require().NoError(err)
+
+	// ACT
+	_, errDuringRotation := rotated.ValidateToken(oldToken)
+	_, errAfterRetirement := retired.ValidateToken(oldToken)
+	keys := rotated.PublicKeys()
+
+	// ASSERT
+	s.NoError(errDuringRotation, "Tokens from the previous key should be accepted during rotation")
+	s.ErrorIs(errAfterRetirement, jwt.ErrTokenSignatureInvalid, "Only the algorithms of configured keys should be accepted")
+	s.ErrorContains(errAfterRetirement, "signing method RS256 is invalid")
+	s.Require().Len(keys, 2)
+	s.Equal(newKey.ID, keys[0].KeyID, "The current signing key should be listed first")
+	s.Equal(oldKey.ID, keys[1].KeyID)
+}
+
+// TestAsymmetricService_RejectsAlgorithmConfusion verifies that the algorithm must match the key named by kid,
+// so an HMAC token cannot be forged using a published public key as the secret.
+func (s *SigningKeyTestSuite) TestAsymmetricService_RejectsAlgorithmConfusion() {
+	// ARRANGE
+	signing := s.signingKey(s.rsaKey)
+	svc, err := NewAsymmetricJWTService(signing, nil, time.Hour, testIssuer, testAudience)
+	s.Require().NoError(err)
+	forged := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"username": "mallory", "iss": testIssuer, "aud": testAudience})
+	forged.Header["kid"] = signing.ID
+	forgedString, err := forged.SignedString(s.publicPEM(s.rsaKey))
+	s.Require().NoError(err)
+
+	// ACT
+	_, err = svc.ValidateToken(forgedString)
+
+	// ASSERT
+	s.ErrorIs(err, jwt.ErrTokenSignatureInvalid)
+	s.ErrorContains(err, "signing method HS256 is invalid")
+}
+
+// TestAsymmetricService_RequiresPrivateKey verifies that a public key cannot be configured for signing.
+func (s *SigningKeyTestSuite) TestAsymmetricService_RequiresPrivateKey() {
+	public, err := ParseVerificationKeyPEM(s.publicPEM(s.edKey))
+	s.Require().NoError(err)
+
+	_, err = NewAsymmetricJWTService(public, nil, time.Hour, testIssuer, testAudience)
+
+	s.ErrorContains(err, "signing key has no private key")
+}
@@ -0,0 +1,129 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"task_manager_test/internal/domain"
+)
+
+// AllPermissionsWildcard may be used in a policy definition to grant every permission to a role.
+const AllPermissionsWildcard = "*"
+
+// AccessPolicy maps roles to the permissions they grant. It is shared by the HTTP middleware and the use cases so
+// that authorization does not live only at the edge.
+type AccessPolicy struct {
+	roles       map[domain.Role]map[domain.Permission]bool
+	defaultRole domain.Role
+}
+
+// DefaultAccessPolicy returns the built-in policy used when no policy file is configured.
+func DefaultAccessPolicy() *AccessPolicy {
+	p, err := NewAccessPolicy(map[domain.Role][]string{
+		domain.RoleViewer: {
+			string(domain.PermTaskReadOwn), string(domain.PermTaskReadAny),
+		},
+		domain.RoleMember: {
+			string(domain.PermTaskReadOwn), string(domain.PermTaskCreate),
+			string(domain.PermTaskUpdateOwn), string(domain.PermTaskDeleteOwn), string(domain.PermProjectCreate),
+		},
+		domain.RoleManager: {
+			string(domain.PermTaskReadOwn), string(domain.PermTaskReadAny), string(domain.PermTaskCreate),
+			string(domain.PermTaskUpdateOwn), string(domain.PermTaskUpdateAny), string(domain.PermTaskDeleteOwn),
+			string(domain.PermProjectCreate),
+		},
+		domain.RoleAdmin: {AllPermissionsWildcard},
+	})
+	if err != nil {
+		panic(err) // the built-in policy is static, so this is a programming error
+	}
+	return p
+}
+
+// NewAccessPolicy builds a policy from role names to permission names, rejecting unknown permissions.
+// The member role must be defined because it is assigned to self-registered users.
+func NewAccessPolicy(roles map[domain.Role][]string) (*AccessPolicy, error) {
+	p := &AccessPolicy{roles: make(map[domain.Role]map[domain.Permission]bool, len(roles)), defaultRole: domain.RoleMember}
+	for role, perms := range roles {
+		if role == "" {
+			return nil, fmt.Errorf("access policy: role names must not be empty")
+		}
+		if role == domain.RoleLegacyUser {
+			return nil, fmt.Errorf("access policy: role %q is reserved as an alias of %q", role, domain.RoleMember)
+		}
+		if role == domain.RoleSuperAdmin {
+			return nil, fmt.Errorf("access policy: role %q is reserved and always holds every permission", role)
+		}
+		granted := make(map[domain.Permission]bool, len(perms))
+		for _, name := range perms {
+			if name == AllPermissionsWildcard {
+				for _, perm := range domain.AllPermissions {
+					granted[perm] = true
+				}
+				continue
+			}
+			perm := domain.Permission(name)
+			if !slices.Contains(domain.AllPermissions, perm) {
+				return nil, fmt.Errorf("access policy: role %q: unknown permission %q", role, name)
+			}
+			granted[perm] = true
+		}
+		p.roles[role] = granted
+	}
+	if _, ok := p.roles[p.defaultRole]; !ok {
+		return nil, fmt.Errorf("access policy: the %q role must be defined", p.defaultRole)
+	}
+	return p, nil
+}
+
+// DefaultRole is the role given to self-registered users.
+func (p *AccessPolicy) DefaultRole() domain.Role {
+	return p.defaultRole
+}
+
+// HasRole reports whether the role is defined by the policy. The legacy "user" and the super-admin roles are always
+// accepted.
+func (p *AccessPolicy) HasRole(role domain.Role) bool {
+	if role == domain.RoleSuperAdmin {
+		return true
+	}
+	_, ok := p.roles[p.normalize(role)]
+	return ok
+}
+
+// Can reports whether the role grants the permission. Unknown roles grant nothing; the super-admin role grants
+// everything.
+func (p *AccessPolicy) Can(role domain.Role, perm domain.Permission) bool {
+	if role == domain.RoleSuperAdmin {
+		return true
+	}
+	return p.roles[p.normalize(role)][perm]
+}
+
+// CanAny reports whether the role grants at least one of the permissions.
+func (p *AccessPolicy) CanAny(role domain.Role, perms ...domain.Permission) bool {
+	for _, perm := range perms {
+		if p.Can(role, perm) {
+			return true
+		}
+	}
+	return false
+}
+
+// Authorize returns the actor from ctx if its role grants at least one of the permissions, and ErrForbidden otherwise.
+// A context without an actor is never authorized.
+func (p *AccessPolicy) Authorize(ctx context.Context, perms ...domain.Permission) (Actor, error) {
+	actor, ok := ActorFromContext(ctx)
+	if !ok || !p.CanAny(actor.Role, perms...) {
+		return Actor{}, ErrForbidden
+	}
+	return actor, nil
+}
+
+// normalize maps the legacy "user" role onto the member role.
+func (p *AccessPolicy) normalize(role domain.Role) domain.Role {
+	if role == domain.RoleLegacyUser {
+		return domain.RoleMember
+	}
+	return role
+}
@@ -0,0 +1,79 @@
+package usecase
+
+import (
+	"context"
+	"task_manager_test/internal/domain"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestDefaultAccessPolicy verifies the permissions granted by the built-in roles.
+func TestDefaultAccessPolicy(t *testing.T) {
+	p := DefaultAccessPolicy()
+
+	assert.Equal(t, domain.RoleMember, p.DefaultRole())
+	assert.True(t, p.Can(domain.RoleViewer, domain.PermTaskReadAny))
+	assert.False(t, p.Can(domain.RoleViewer, domain.PermTaskCreate))
+	assert.True(t, p.Can(domain.RoleMember, domain.PermTaskDeleteOwn))
+	assert.False(t, p.Can(domain.RoleMember, domain.PermTaskReadAny))
+	assert.True(t, p.Can(domain.RoleManager, domain.PermTaskUpdateAny))
+	assert.False(t, p.Can(domain.RoleManager, domain.PermTaskDeleteAny))
+	assert.False(t, p.Can(domain.RoleManager, domain.PermUserManage))
+	for _, perm := range domain.AllPermissions {
+		assert.True(t, p.Can(domain.RoleAdmin, perm), "admin should hold %s", perm)
+	}
+}
+
+// TestAccessPolicy_LegacyUserRole verifies that accounts created before roles existed act as members.
+func TestAccessPolicy_LegacyUserRole(t *testing.T) {
+	p := DefaultAccessPolicy()
+
+	assert.True(t, p.HasRole(domain.RoleLegacyUser))
+	assert.True(t, p.Can(domain.RoleLegacyUser, domain.PermTaskCreate))
+	assert.False(t, p.Can("superuser", domain.PermTaskReadOwn), "Unknown roles should grant nothing")
+}
+
+// TestAccessPolicy_SuperAdmin verifies that the super-admin role holds every permission under any policy.
+func TestAccessPolicy_SuperAdmin(t *testing.T) {
+	p, err := NewAccessPolicy(map[domain.Role][]string{domain.RoleMember: {"task.read.own"}})
+	assert.NoError(t, err)
+
+	assert.True(t, p.HasRole(domain.RoleSuperAdmin))
+	for _, perm := range domain.AllPermissions {
+		assert.True(t, p.Can(domain.RoleSuperAdmin, perm), "super admin should hold %s", perm)
+	}
+}
+
+// TestNewAccessPolicy_Rejects verifies that malformed policies fail at startup rather than at request time.
+func TestNewAccessPolicy_Rejects(t *testing.T) {
+	cases := map[string]map[domain.Role][]string{
+		"unknown permission": {domain.RoleMember: {"task.read.own", "task.fly"}},
+		"missing member":     {domain.RoleAdmin: {AllPermissionsWildcard}},
+		"reserved role":      {domain.RoleMember: {"task.read.own"}, domain.RoleLegacyUser: {"task.read.own"}},
+		"empty role":         {domain.RoleMember: {"task.read.own"}, "": {"task.read.own"}},
+		"super admin":        {domain.RoleMember: {"task.read.own"}, domain.RoleSuperAdmin: {"task.read.own"}},
+	}
+	for name, roles := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := NewAccessPolicy(roles)
+			assert.Error(t, err)
+		})
+	}
+}
+
+// TestAccessPolicy_Authorize verifies any-of semantics and that a missing actor is never authorized.
+func TestAccessPolicy_Authorize(t *testing.T) {
+	p := DefaultAccessPolicy()
+	ctx := WithActor(context.Background(), Actor{UserID: "user-1", Role: domain.RoleMember})
+
+	actor, err := p.Authorize(ctx, domain.PermTaskReadAny, domain.PermTaskReadOwn)
+	assert.NoError(t, err)
+	assert.Equal(t, "user-1", actor.UserID)
+
+	_, err = p.Authorize(ctx, domain.PermUserManage)
+	assert.ErrorIs(t, err, ErrForbidden)
+
+	_, err = p.Authorize(context.Background(), domain.PermTaskReadOwn)
+	assert.ErrorIs(t, err, ErrForbidden)
+}
@@ -0,0 +1,169 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"task_manager_test/internal/domain"
+	"time"
+	"unicode/utf8"
+)
+
+// AccessTokenPrefix starts every personal access token so it can be told apart from a JWT and spotted by secret scanners.
+const AccessTokenPrefix = "tmpat_"
+
+// maxTokenNameLength bounds the display name of a personal access token.
+const maxTokenNameLength = 100
+
+// lastUsedResolution limits how often the last-used timestamp is written, so busy tokens do not cause a write per request.
+const lastUsedResolution = time.Minute
+
+// knownScopes lists every scope a token may be granted.
+var knownScopes = []string{domain.ScopeTasksRead, domain.ScopeTasksWrite, domain.ScopeAdmin}
+
+// HasScope reports whether the granted scopes allow an operation requiring the given scope.
+// tasks:write implies tasks:read, and admin implies every scope.
+func HasScope(granted []string, required string) bool {
+	for _, g := range granted {
+		if g == required || g == domain.ScopeAdmin ||
+			(g == domain.ScopeTasksWrite && required == domain.ScopeTasksRead) {
+			return true
+		}
+	}
+	return false
+}
+
+// AccessTokenUsecase manages personal access tokens for the signed-in user and authenticates requests made with them.
+type AccessTokenUsecase interface {
+	IAccessTokenAuthenticator
+	// Create issues a token and returns its record together with the plain token, which is never available again.
+	// A zero expiresAt creates a token that does not expire.
+	Create(ctx context.Context, username, name string, scopes []string, expiresAt time.Time) (domain.AccessToken, string, error)
+	List(ctx context.Context, username string) ([]domain.AccessToken, error)
+	Revoke(ctx context.Context, username, id string) error
+}
+
+// accessTokenUsecase is the concrete implementation of AccessTokenUsecase.
+type accessTokenUsecase struct {
+	tokens IAccessTokenRepository
+	users  IUserRepository
+	access *AccessPolicy
+	now    func() time.Time
+}
+
+// NewAccessTokenUsecase creates a new instance of accessTokenUsecase with dependencies injected.
+func NewAccessTokenUsecase(tokens IAccessTokenRepository, users IUserRepository, access *AccessPolicy) AccessTokenUsecase {
+	return &accessTokenUsecase{tokens: tokens, users: users, access: access, now: time.Now}
+}
+
+// Create validates the request, stores only the token hash and returns the plain token once.
+func (u *accessTokenUsecase) Create(ctx context.Context, username, name string, scopes []string, expiresAt time.Time) (domain.AccessToken, string, error) {
+	usr, err := u.users.FindByUsername(ctx, username)
+	if err != nil {
+		return domain.AccessToken{}, "", err
+	}
+
+	name = strings.TrimSpace(name)
+	switch {
+	case name == "":
+		return domain.AccessToken{}, "", &TokenRequestError{Reason: "name is required"}
+	case utf8.RuneCountInString(name) > maxTokenNameLength:
+		return domain.AccessToken{}, "", &TokenRequestError{Reason: fmt.Sprintf("name must be at most %d characters long", maxTokenNameLength)}
+	case len(scopes) == 0:
+		return domain.AccessToken{}, "", &TokenRequestError{Reason: "at least one scope is required"}
+	}
+	for _, s := range scopes {
+		if !slices.Contains(knownScopes, s) {
+			return domain.AccessToken{}, "", &TokenRequestError{Reason: fmt.Sprintf("unknown scope %q", s)}
+		}
+	}
+	// The admin scope reaches the admin endpoints, so it is limited to roles that may use them.
+	if slices.Contains(scopes, domain.ScopeAdmin) && !u.access.CanAny(usr.Role, domain.PermUserManage, domain.PermAdminDashboard) {
+		return domain.AccessToken{}, "", ErrForbidden
+	}
+	now := u.now()
+	if !expiresAt.IsZero() && !expiresAt.After(now) {
+		return domain.AccessToken{}, "", &TokenRequestError{Reason: "expiry must be in the future"}
+	}
+
+	secret, err := newOpaqueToken()
+	if err != nil {
+		return domain.AccessToken{}, "", err
+	}
+	token := AccessTokenPrefix + secret
+	created, err := u.tokens.Create(ctx, domain.AccessToken{
+		UserID:    usr.ID,
+		Name:      name,
+		Scopes:    slices.Compact(slices.Sorted(slices.Values(scopes))),
+		TokenHash: hashToken(token),
+		CreatedAt: now,
+		ExpiresAt: expiresAt,
+	})
+	if err != nil {
+		return domain.AccessToken{}, "", err
+	}
+	return created, token, nil
+}
+
+// List returns the user's tokens without their hashes.
+func (u *accessTokenUsecase) List(ctx context.Context, username string) ([]domain.AccessToken, error) {
+	usr, err := u.users.FindByUsername(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+	tokens, err := u.tokens.ListByUser(ctx, usr.ID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range tokens {
+		tokens[i].TokenHash = ""
+	}
+	return tokens, nil
+}
+
+// Revoke disables one of the user's tokens. Tokens of other users are reported as not found.
+func (u *accessTokenUsecase) Revoke(ctx context.Context, username, id string) error {
+	usr, err := u.users.FindByUsername(ctx, username)
+	if err != nil {
+		return err
+	}
+	return u.tokens.Revoke(ctx, usr.ID, id, u.now())
+}
+
+// Authenticate resolves an active token and its owner and records when it was last used.
+func (u *accessTokenUsecase) Authenticate(ctx context.Context, token string) (domain.AccessToken, domain.User, error) {
+	if !strings.HasPrefix(token, AccessTokenPrefix) {
+		return domain.AccessToken{}, domain.User{}, ErrInvalidAccessToken
+	}
+	t, err := u.tokens.FindByHash(ctx, hashToken(token))
+	if errors.Is(err, ErrNotFound) {
+		return domain.AccessToken{}, domain.User{}, ErrInvalidAccessToken
+	}
+	if err != nil {
+		return domain.AccessToken{}, domain.User{}, err
+	}
+	now := u.now()
+	if !t.RevokedAt.IsZero() || (!t.ExpiresAt.IsZero() && !now.Before(t.ExpiresAt)) {
+		return domain.AccessToken{}, domain.User{}, ErrInvalidAccessToken
+	}
+	// The request is not yet authenticated, so its tenant is whichever the token's owner belongs to.
+	usr, err := u.users.FindByID(WithAllTenants(ctx), t.UserID)
+	if errors.Is(err, ErrNotFound) {
+		return domain.AccessToken{}, domain.User{}, ErrInvalidAccessToken
+	}
+	if err != nil {
+		return domain.AccessToken{}, domain.User{}, err
+	}
+	if usr.Disabled {
+		return domain.AccessToken{}, domain.User{}, ErrAccountDisabled
+	}
+	if now.Sub(t.LastUsedAt) >= lastUsedResolution {
+		if err := u.tokens.TouchLastUsed(ctx, t.ID, now); err != nil {
+			return domain.AccessToken{}, domain.User{}, err
+		}
+		t.LastUsedAt = now
+	}
+	return t, usr, nil
+}
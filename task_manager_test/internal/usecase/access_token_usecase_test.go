@@ -0,0 +1,234 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"task_manager_test/internal/domain"
+	"task_manager_test/internal/mocks"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+// AccessTokenUsecaseTestSuite defines the test suite for the personal access token use case.
+type AccessTokenUsecaseTestSuite struct {
+	suite.Suite
+	mockTokenRepo *mocks.IAccessTokenRepository
+	mockUserRepo  *mocks.IUserRepository
+	usecase       AccessTokenUsecase
+	now           time.Time
+	alice         domain.User
+}
+
+// SetupTest runs before EACH test in the suite.
+func (s *AccessTokenUsecaseTestSuite) SetupTest() {
+	s.mockTokenRepo = mocks.NewIAccessTokenRepository(s.T())
+	s.mockUserRepo = mocks.NewIUserRepository(s.T())
+	s.usecase = NewAccessTokenUsecase(s.mockTokenRepo, s.mockUserRepo, DefaultAccessPolicy())
+
+	s.now = time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	s.usecase.(*accessTokenUsecase).now = func() time.Time { return s.now }
+	s.alice = domain.User{ID: "user-1", Username: "alice", Role: "user"}
+}
+
+// TestAccessTokenUsecaseTestSuite is the Go test runner's entry point for this suite.
+func TestAccessTokenUsecaseTestSuite(t *testing.T) {
+	suite.Run(t, new(AccessTokenUsecaseTestSuite))
+}
+
+// --- Test Cases for the Create Method ---
+
+// TestCreate_Success tests that only the hash is stored and the prefixed token is returned once.
+func (s *AccessTokenUsecaseTestSuite) TestCreate_Success() {
+	// ARRANGE
+	ctx := context.Background()
+	expiresAt := s.now.Add(30 * 24 * time.Hour)
+	var stored domain.AccessToken
+	s.mockUserRepo.On("FindByUsername", ctx, "alice").Return(s.alice, nil)
+	s.mockTokenRepo.On("Create", ctx, mock.Anything).Run(func(args mock.Arguments) {
+		stored = args.Get(1).(domain.AccessToken)
+	}).Return(func(_ context.Context, t domain.AccessToken) (domain.AccessToken, error) {
+		t.ID = "tok-1"
+		return t, nil
+	})
+
+	// ACT
+	created, token, err := s.usecase.Create(ctx, "alice", "  ci  ", []string{"tasks:write", "tasks:read", "tasks:write"}, expiresAt)
+
+	// ASSERT
+	assert.NoError(s.T(), err)
+	assert.True(s.T(), strings.HasPrefix(token, AccessTokenPrefix), "Tokens should carry a recognisable prefix")
+	assert.Equal(s.T(), "tok-1", created.ID)
+	assert.Equal(s.T(), "user-1", stored.UserID)
+	assert.Equal(s.T(), "ci", stored.Name)
+	assert.Equal(s.T(), []string{"tasks:read", "tasks:write"}, stored.Scopes, "Scopes should be sorted and de-duplicated")
+	assert.Equal(s.T(), hashToken(token), stored.TokenHash, "Only the hash of the token should be stored")
+	assert.Equal(s.T(), s.now, stored.CreatedAt)
+	assert.Equal(s.T(), expiresAt, stored.ExpiresAt)
+}
+
+// TestCreate_Fails_When_RequestIsInvalid tests the validation of names, scopes and expiry.
+func (s *AccessTokenUsecaseTestSuite) TestCreate_Fails_When_RequestIsInvalid() {
+	cases := []struct {
+		name       string
+		tokenName  string
+		scopes     []string
+		expiresAt  time.Time
+		wantReason string
+	}{
+		{"blank name", " ", []string{"tasks:read"}, time.Time{}, "name is required"},
+		{"long name", strings.Repeat("x", 101), []string{"tasks:read"}, time.Time{}, "name must be at most 100 characters long"},
+		{"no scopes", "ci", nil, time.Time{}, "at least one scope is required"},
+		{"unknown scope", "ci", []string{"tasks:delete"}, time.Time{}, `unknown scope "tasks:delete"`},
+		{"past expiry", "ci", []string{"tasks:read"}, s.now.Add(-time.Hour), "expiry must be in the future"},
+	}
+	s.mockUserRepo.On("FindByUsername", mock.Anything, "alice").Return(s.alice, nil)
+	for _, tc := range cases {
+		s.Run(tc.name, func() {
+			_, _, err := s.usecase.Create(context.Background(), "alice", tc.tokenName, tc.scopes, tc.expiresAt)
+
+			var reqErr *TokenRequestError
+			assert.ErrorAs(s.T(), err, &reqErr)
+			assert.Equal(s.T(), tc.wantReason, reqErr.Reason)
+			assert.ErrorIs(s.T(), err, ErrInvalidTokenRequest)
+		})
+	}
+	s.mockTokenRepo.AssertNotCalled(s.T(), "Create", mock.Anything, mock.Anything)
+}
+
+// TestCreate_Fails_When_NonAdminRequestsAdminScope tests that a token cannot exceed its owner's privileges.
+func (s *AccessTokenUsecaseTestSuite) TestCreate_Fails_When_NonAdminRequestsAdminScope() {
+	s.mockUserRepo.On("FindByUsername", mock.Anything, "alice").Return(s.alice, nil)
+
+	_, _, err := s.usecase.Create(context.Background(), "alice", "ci", []string{domain.ScopeAdmin}, time.Time{})
+
+	assert.ErrorIs(s.T(), err, ErrForbidden)
+}
+
+// TestCreate_AllowsAdminScope_For_AdministrativeRoles tests that roles allowed on the admin endpoints, including
+// super admins, may create admin tokens.
+func (s *AccessTokenUsecaseTestSuite) TestCreate_AllowsAdminScope_For_AdministrativeRoles() {
+	for _, role := range []domain.Role{domain.RoleAdmin, domain.RoleSuperAdmin} {
+		s.Run(string(role), func() {
+			usr := domain.User{ID: "user-2", Username: "root", Role: role}
+			s.mockUserRepo.On("FindByUsername", mock.Anything, "root").Return(usr, nil).Once()
+			s.mockTokenRepo.On("Create", mock.Anything, mock.Anything).Return(func(_ context.Context, t domain.AccessToken) (domain.AccessToken, error) {
+				return t, nil
+			}).Once()
+
+			created, _, err := s.usecase.Create(context.Background(), "root", "ops", []string{domain.ScopeAdmin}, time.Time{})
+
+			assert.NoError(s.T(), err)
+			assert.Equal(s.T(), []string{domain.ScopeAdmin}, created.Scopes)
+		})
+	}
+}
+
+// --- Test Cases for the List and Revoke Methods ---
+
+// TestList_HidesHashes tests that stored hashes never leave the use case.
+func (s *AccessTokenUsecaseTestSuite) TestList_HidesHashes() {
+	ctx := context.Background()
+	s.mockUserRepo.On("FindByUsername", ctx, "alice").Return(s.alice, nil)
+	s.mockTokenRepo.On("ListByUser", ctx, "user-1").Return([]domain.AccessToken{{ID: "tok-1", TokenHash: "secret-hash"}}, nil)
+
+	tokens, err := s.usecase.List(ctx, "alice")
+
+	assert.NoError(s.T(), err)
+	assert.Len(s.T(), tokens, 1)
+	assert.Empty(s.T(), tokens[0].TokenHash)
+}
+
+// TestRevoke_ScopesToOwner tests that revocation is limited to the caller's own tokens.
+func (s *AccessTokenUsecaseTestSuite) TestRevoke_ScopesToOwner() {
+	ctx := context.Background()
+	s.mockUserRepo.On("FindByUsername", ctx, "alice").Return(s.alice, nil)
+	s.mockTokenRepo.On("Revoke", ctx, "user-1", "tok-of-bob", s.now).Return(ErrNotFound)
+
+	err := s.usecase.Revoke(ctx, "alice", "tok-of-bob")
+
+	assert.ErrorIs(s.T(), err, ErrNotFound)
+}
+
+// --- Test Cases for the Authenticate Method ---
+
+// TestAuthenticate_Success tests that an active token resolves to its owner and records its use.
+func (s *AccessTokenUsecaseTestSuite) TestAuthenticate_Success() {
+	// ARRANGE
+	ctx := context.Background()
+	token := AccessTokenPrefix + "secret"
+	stored := domain.AccessToken{ID: "tok-1", UserID: "user-1", Scopes: []string{"tasks:read"}, LastUsedAt: s.now.Add(-time.Hour)}
+	s.mockTokenRepo.On("FindByHash", ctx, hashToken(token)).Return(stored, nil)
+	s.mockUserRepo.On("FindByID", allTenants, "user-1").Return(s.alice, nil)
+	s.mockTokenRepo.On("TouchLastUsed", ctx, "tok-1", s.now).Return(nil)
+
+	// ACT
+	got, usr, err := s.usecase.Authenticate(ctx, token)
+
+	// ASSERT
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), "alice", usr.Username)
+	assert.Equal(s.T(), s.now, got.LastUsedAt)
+}
+
+// TestAuthenticate_ThrottlesLastUsedWrites tests that recently used tokens are not written again.
+func (s *AccessTokenUsecaseTestSuite) TestAuthenticate_ThrottlesLastUsedWrites() {
+	ctx := context.Background()
+	token := AccessTokenPrefix + "secret"
+	stored := domain.AccessToken{ID: "tok-1", UserID: "user-1", LastUsedAt: s.now.Add(-10 * time.Second)}
+	s.mockTokenRepo.On("FindByHash", ctx, hashToken(token)).Return(stored, nil)
+	s.mockUserRepo.On("FindByID", allTenants, "user-1").Return(s.alice, nil)
+
+	_, _, err := s.usecase.Authenticate(ctx, token)
+
+	assert.NoError(s.T(), err)
+	s.mockTokenRepo.AssertNotCalled(s.T(), "TouchLastUsed", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// TestAuthenticate_Fails_When_TokenIsInactive tests that unknown, revoked and expired tokens are rejected alike.
+func (s *AccessTokenUsecaseTestSuite) TestAuthenticate_Fails_When_TokenIsInactive() {
+	token := AccessTokenPrefix + "secret"
+	cases := map[string]struct {
+		stored domain.AccessToken
+		err    error
+	}{
+		"unknown": {domain.AccessToken{}, ErrNotFound},
+		"revoked": {domain.AccessToken{ID: "tok-1", RevokedAt: s.now.Add(-time.Minute)}, nil},
+		"expired": {domain.AccessToken{ID: "tok-1", ExpiresAt: s.now}, nil},
+	}
+	for name, tc := range cases {
+		s.Run(name, func() {
+			s.SetupTest()
+			s.mockTokenRepo.On("FindByHash", mock.Anything, hashToken(token)).Return(tc.stored, tc.err)
+
+			_, _, err := s.usecase.Authenticate(context.Background(), token)
+
+			assert.ErrorIs(s.T(), err, ErrInvalidAccessToken)
+		})
+	}
+}
+
+// TestAuthenticate_Fails_When_UserIsDisabled tests that the tokens of disabled users stop working.
+func (s *AccessTokenUsecaseTestSuite) TestAuthenticate_Fails_When_UserIsDisabled() {
+	ctx := context.Background()
+	token := AccessTokenPrefix + "secret"
+	disabled := s.alice
+	disabled.Disabled = true
+	s.mockTokenRepo.On("FindByHash", ctx, hashToken(token)).Return(domain.AccessToken{ID: "tok-1", UserID: "user-1"}, nil)
+	s.mockUserRepo.On("FindByID", allTenants, "user-1").Return(disabled, nil)
+
+	_, _, err := s.usecase.Authenticate(ctx, token)
+
+	assert.ErrorIs(s.T(), err, ErrAccountDisabled)
+	s.mockTokenRepo.AssertNotCalled(s.T(), "TouchLastUsed", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// TestAuthenticate_Fails_When_PrefixIsMissing tests that non-PAT strings are rejected without a lookup.
+func (s *AccessTokenUsecaseTestSuite) TestAuthenticate_Fails_When_PrefixIsMissing() {
+	_, _, err := s.usecase.Authenticate(context.Background(), "a.jwt.token")
+
+	assert.ErrorIs(s.T(), err, ErrInvalidAccessToken)
+}
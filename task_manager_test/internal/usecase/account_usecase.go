@@ -0,0 +1,179 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"task_manager_test/internal/domain"
+	"time"
+)
+
+// AccountUsecase defines the operations that honor users' requests for their data: exporting it and deleting the
+// account together with the data it owns. Every operation is recorded in the audit log.
+type AccountUsecase interface {
+	// Export returns the actor's data.
+	Export(ctx context.Context) (domain.AccountExport, error)
+	// Delete verifies the actor's password and deletes the actor's account and the data it owns.
+	Delete(ctx context.Context, password string) error
+	// ExportUser returns the data of a user of the caller's organization. The caller must hold the user.manage
+	// permission, and be a super admin if the user is one.
+	ExportUser(ctx context.Context, username string) (domain.AccountExport, error)
+	// DeleteUser deletes a user of the caller's organization and the data it owns. The caller must hold the
+	// user.manage permission, and be a super admin if the user is one. Callers delete their own account with Delete.
+	DeleteUser(ctx context.Context, username string) error
+}
+
+// accountUsecase is the concrete implementation of AccountUsecase.
+type accountUsecase struct {
+	users    IUserRepository
+	tasks    ITaskRepository
+	comments ICommentRepository
+	projects IProjectRepository
+	accounts IAccountRepository
+	audit    IAuditRepository
+	blobs    IBlobStore
+	lockout  lockoutGuard
+	access   *AccessPolicy
+	now      func() time.Time
+}
+
+// NewAccountUsecase creates a new instance of accountUsecase with dependencies injected.
+func NewAccountUsecase(users IUserRepository, tasks ITaskRepository, comments ICommentRepository, projects IProjectRepository, accounts IAccountRepository, audit IAuditRepository, blobs IBlobStore, pwd IPasswordService, lockout LockoutPolicy, access *AccessPolicy) AccountUsecase {
+	return &accountUsecase{
+		users:    users,
+		tasks:    tasks,
+		comments: comments,
+		projects: projects,
+		accounts: accounts,
+		audit:    audit,
+		blobs:    blobs,
+		lockout:  lockoutGuard{users: users, pwd: pwd, policy: lockout},
+		access:   access,
+		now:      time.Now,
+	}
+}
+
+// Export looks up the actor's account and exports it.
+func (u *accountUsecase) Export(ctx context.Context) (domain.AccountExport, error) {
+	actor, ok := ActorFromContext(ctx)
+	if !ok {
+		return domain.AccountExport{}, ErrUnauthenticated
+	}
+	usr, err := u.users.FindByID(ctx, actor.UserID)
+	if err != nil {
+		return domain.AccountExport{}, err
+	}
+	return u.export(ctx, actor, usr)
+}
+
+// Delete re-authenticates the actor before deleting the account, so that a stolen session cannot destroy it. Wrong
+// passwords count towards the same lockout as failed logins.
+func (u *accountUsecase) Delete(ctx context.Context, password string) error {
+	actor, ok := ActorFromContext(ctx)
+	if !ok {
+		return ErrUnauthenticated
+	}
+	usr, err := u.users.FindByID(ctx, actor.UserID)
+	if err != nil {
+		return err
+	}
+	if err := u.lockout.check(ctx, usr, password, u.now()); err != nil {
+		return err
+	}
+	return u.delete(ctx, actor, usr)
+}
+
+// ExportUser exports the account of another user on behalf of an administrator.
+func (u *accountUsecase) ExportUser(ctx context.Context, username string) (domain.AccountExport, error) {
+	actor, usr, err := u.managed(ctx, username)
+	if err != nil {
+		return domain.AccountExport{}, err
+	}
+	return u.export(ctx, actor, usr)
+}
+
+// DeleteUser deletes the account of another user on behalf of an administrator.
+func (u *accountUsecase) DeleteUser(ctx context.Context, username string) error {
+	actor, usr, err := u.managed(ctx, username)
+	if err != nil {
+		return err
+	}
+	if usr.ID == actor.UserID {
+		return WithDetail(ErrForbidden, "delete your own account through /me, which asks for your password")
+	}
+	return u.delete(ctx, actor, usr)
+}
+
+// managed authorizes an administrator to operate on the user's account and looks the user up.
+func (u *accountUsecase) managed(ctx context.Context, username string) (Actor, domain.User, error) {
+	actor, err := u.access.Authorize(ctx, domain.PermUserManage)
+	if err != nil {
+		return Actor{}, domain.User{}, err
+	}
+	usr, err := u.users.FindByUsername(ctx, username)
+	if err != nil {
+		return Actor{}, domain.User{}, err
+	}
+	if usr.Role == domain.RoleSuperAdmin && actor.Role != domain.RoleSuperAdmin {
+		return Actor{}, domain.User{}, ErrForbidden
+	}
+	return actor, usr, nil
+}
+
+// export records the export, then gathers the user's data. The entry is recorded first so that the export lists it.
+func (u *accountUsecase) export(ctx context.Context, actor Actor, usr domain.User) (domain.AccountExport, error) {
+	entry := u.entry(actor, usr, domain.AuditAccountExported)
+	if _, err := u.audit.Record(ctx, entry); err != nil {
+		return domain.AccountExport{}, err
+	}
+	tasks, err := u.tasks.GetByOwner(ctx, usr.ID)
+	if err != nil {
+		return domain.AccountExport{}, err
+	}
+	comments, err := u.comments.ListByAuthor(ctx, usr.ID)
+	if err != nil {
+		return domain.AccountExport{}, err
+	}
+	entries, err := u.audit.ListByUser(ctx, usr.ID)
+	if err != nil {
+		return domain.AccountExport{}, err
+	}
+	usr.Password = ""
+	return domain.AccountExport{User: usr, Tasks: tasks, Comments: comments, AuditLog: entries, ExportedAt: entry.At}, nil
+}
+
+// delete refuses to orphan projects other users belong to, then deletes the account and records the deletion in a
+// single transaction. The content of the deleted attachments is released afterwards, since blobs live outside the
+// database; should that fail, the account is gone and only unreferenced content is left behind.
+func (u *accountUsecase) delete(ctx context.Context, actor Actor, usr domain.User) error {
+	projects, err := u.projects.ListByMember(ctx, usr.ID)
+	if err != nil {
+		return err
+	}
+	var owned []string
+	for _, p := range projects {
+		if isLastOwner(p, usr.ID) && len(p.Members) > 1 {
+			owned = append(owned, p.Name)
+		}
+	}
+	if len(owned) > 0 {
+		return WithDetail(ErrAccountOwnsProjects, "hand over ownership of "+strings.Join(owned, ", ")+" first")
+	}
+	attachments, err := u.accounts.DeleteAccount(ctx, usr.ID, u.entry(actor, usr, domain.AuditAccountDeleted))
+	if err != nil {
+		return err
+	}
+	return releaseAttachments(ctx, u.tasks, u.users, u.blobs, attachments)
+}
+
+// entry returns an audit entry of the actor performing the action on the user.
+func (u *accountUsecase) entry(actor Actor, usr domain.User, action domain.AuditAction) domain.AuditEntry {
+	return domain.AuditEntry{
+		OrgID:          usr.OrgID,
+		ActorID:        actor.UserID,
+		ActorUsername:  actor.Username,
+		Action:         action,
+		TargetUserID:   usr.ID,
+		TargetUsername: usr.Username,
+		At:             u.now(),
+	}
+}
@@ -0,0 +1,231 @@
+package usecase
+
+import (
+	"context"
+	"task_manager_test/internal/domain"
+	"task_manager_test/internal/mocks"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+// AccountUsecaseTestSuite defines the test suite for the account use case.
+type AccountUsecaseTestSuite struct {
+	suite.Suite
+	mockUserRepo     *mocks.IUserRepository
+	mockTaskRepo     *mocks.ITaskRepository
+	mockCommentRepo  *mocks.ICommentRepository
+	mockProjectRepo  *mocks.IProjectRepository
+	mockAccountRepo  *mocks.IAccountRepository
+	mockAuditRepo    *mocks.IAuditRepository
+	mockBlobStore    *mocks.IBlobStore
+	mockPwdSvc       *mocks.IPasswordService
+	usecase          AccountUsecase
+	now              time.Time
+	alice, adminUser domain.User
+	aliceCtx         context.Context
+	adminCtx         context.Context
+}
+
+// SetupTest runs before EACH test in the suite.
+func (s *AccountUsecaseTestSuite) SetupTest() {
+	s.mockUserRepo = mocks.NewIUserRepository(s.T())
+	s.mockTaskRepo = mocks.NewITaskRepository(s.T())
+	s.mockCommentRepo = mocks.NewICommentRepository(s.T())
+	s.mockProjectRepo = mocks.NewIProjectRepository(s.T())
+	s.mockAccountRepo = mocks.NewIAccountRepository(s.T())
+	s.mockAuditRepo = mocks.NewIAuditRepository(s.T())
+	s.mockBlobStore = mocks.NewIBlobStore(s.T())
+	s.mockPwdSvc = mocks.NewIPasswordService(s.T())
+
+	s.usecase = NewAccountUsecase(s.mockUserRepo, s.mockTaskRepo, s.mockCommentRepo, s.mockProjectRepo, s.mockAccountRepo,
+		s.mockAuditRepo, s.mockBlobStore, s.mockPwdSvc, LockoutPolicy{MaxAttempts: 3, Duration: 15 * time.Minute}, DefaultAccessPolicy())
+	s.now = time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	s.usecase.(*accountUsecase).now = func() time.Time { return s.now }
+
+	s.alice = domain.User{ID: "user-1", Username: "alice", Password: "hash", Role: domain.RoleMember, OrgID: "org-1"}
+	s.adminUser = domain.User{ID: "admin-1", Username: "root", Role: domain.RoleAdmin, OrgID: "org-1"}
+	s.aliceCtx = WithActor(context.Background(), Actor{UserID: "user-1", Username: "alice", Role: domain.RoleMember, OrgID: "org-1"})
+	s.adminCtx = WithActor(context.Background(), Actor{UserID: "admin-1", Username: "root", Role: domain.RoleAdmin, OrgID: "org-1"})
+}
+
+// TestAccountUsecaseTestSuite is the Go test runner's entry point for this suite.
+func TestAccountUsecaseTestSuite(t *testing.T) {
+	suite.Run(t, new(AccountUsecaseTestSuite))
+}
+
+// --- Test Cases for the Export Method ---
+
+// TestExport_Success tests that the export gathers the actor's data without the password hash and records itself.
+func (s *AccountUsecaseTestSuite) TestExport_Success() {
+	// ARRANGE
+	ctx := s.aliceCtx
+	tasks := []domain.Task{{ID: "task-1", OwnerID: "user-1"}}
+	comments := []domain.Comment{{ID: "comment-1", AuthorID: "user-1"}}
+	entry := domain.AuditEntry{
+		OrgID: "org-1", ActorID: "user-1", ActorUsername: "alice", Action: domain.AuditAccountExported,
+		TargetUserID: "user-1", TargetUsername: "alice", At: s.now,
+	}
+	s.mockUserRepo.On("FindByID", ctx, "user-1").Return(s.alice, nil)
+	s.mockAuditRepo.On("Record", ctx, entry).Return(entry, nil)
+	s.mockTaskRepo.On("GetByOwner", ctx, "user-1").Return(tasks, nil)
+	s.mockCommentRepo.On("ListByAuthor", ctx, "user-1").Return(comments, nil)
+	s.mockAuditRepo.On("ListByUser", ctx, "user-1").Return([]domain.AuditEntry{entry}, nil)
+
+	// ACT
+	export, err := s.usecase.Export(ctx)
+
+	// ASSERT
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), "alice", export.User.Username)
+	assert.Empty(s.T(), export.User.Password, "The password hash must not be exported")
+	assert.Equal(s.T(), tasks, export.Tasks)
+	assert.Equal(s.T(), comments, export.Comments)
+	assert.Equal(s.T(), []domain.AuditEntry{entry}, export.AuditLog)
+	assert.Equal(s.T(), s.now, export.ExportedAt)
+}
+
+// TestExport_Fails_When_Unauthenticated tests that an anonymous context has nothing to export.
+func (s *AccountUsecaseTestSuite) TestExport_Fails_When_Unauthenticated() {
+	_, err := s.usecase.Export(context.Background())
+
+	assert.ErrorIs(s.T(), err, ErrUnauthenticated)
+}
+
+// --- Test Cases for the Delete Method ---
+
+// TestDelete_Success tests that the account is deleted with its audit entry and the released content is removed.
+func (s *AccountUsecaseTestSuite) TestDelete_Success() {
+	// ARRANGE
+	ctx := s.aliceCtx
+	attachment := domain.Attachment{ID: "att-1", Digest: "digest-1"}
+	s.mockUserRepo.On("FindByID", ctx, "user-1").Return(s.alice, nil)
+	s.mockPwdSvc.On("Compare", "hash", "secret").Return(true)
+	s.mockProjectRepo.On("ListByMember", ctx, "user-1").Return([]domain.Project{{
+		ID: "project-1", Name: "Solo", Members: []domain.ProjectMember{{UserID: "user-1", Role: domain.ProjectOwner}},
+	}}, nil)
+	s.mockAccountRepo.On("DeleteAccount", ctx, "user-1", domain.AuditEntry{
+		OrgID: "org-1", ActorID: "user-1", ActorUsername: "alice", Action: domain.AuditAccountDeleted,
+		TargetUserID: "user-1", TargetUsername: "alice", At: s.now,
+	}).Return([]domain.Attachment{attachment}, nil)
+	s.mockTaskRepo.On("CountAttachmentsByDigest", ctx, "digest-1").Return(int64(0), nil)
+	s.mockBlobStore.On("Delete", ctx, "digest-1").Return(nil)
+
+	// ACT
+	err := s.usecase.Delete(ctx, "secret")
+
+	// ASSERT
+	assert.NoError(s.T(), err)
+}
+
+// TestDelete_Fails_When_PasswordIsWrong tests that deleting an account requires re-authentication, and that the
+// wrong password counts as a failed attempt.
+func (s *AccountUsecaseTestSuite) TestDelete_Fails_When_PasswordIsWrong() {
+	ctx := s.aliceCtx
+	s.mockUserRepo.On("FindByID", ctx, "user-1").Return(s.alice, nil)
+	s.mockPwdSvc.On("Compare", "hash", "guess").Return(false)
+	s.mockUserRepo.On("IncrementFailedLogins", ctx, "alice").Return(1, nil).Once()
+
+	err := s.usecase.Delete(ctx, "guess")
+
+	assert.ErrorIs(s.T(), err, ErrIncorrectPassword)
+	s.mockAccountRepo.AssertNotCalled(s.T(), "DeleteAccount", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// TestDelete_LocksAccount_When_ThresholdReached tests that guesses through account deletion lock the account like
+// failed logins do, and that the locked account is then reported as such, even with the right password.
+func (s *AccountUsecaseTestSuite) TestDelete_LocksAccount_When_ThresholdReached() {
+	ctx := s.aliceCtx
+	until := s.now.Add(15 * time.Minute)
+	s.mockUserRepo.On("FindByID", ctx, "user-1").Return(s.alice, nil).Once()
+	s.mockPwdSvc.On("Compare", "hash", "guess").Return(false)
+	s.mockUserRepo.On("IncrementFailedLogins", ctx, "alice").Return(3, nil).Once()
+	s.mockUserRepo.On("Lock", ctx, "alice", until).Return(nil).Once()
+
+	err := s.usecase.Delete(ctx, "guess")
+	assert.ErrorIs(s.T(), err, ErrIncorrectPassword)
+
+	locked := s.alice
+	locked.FailedLoginAttempts, locked.LockedUntil = 3, until
+	s.mockUserRepo.On("FindByID", ctx, "user-1").Return(locked, nil).Once()
+	s.mockPwdSvc.On("Compare", "hash", "secret").Return(true)
+
+	err = s.usecase.Delete(ctx, "secret")
+
+	var lockedErr *AccountLockedError
+	s.Require().ErrorAs(err, &lockedErr)
+	assert.Equal(s.T(), until, lockedErr.Until)
+	s.mockAccountRepo.AssertNotCalled(s.T(), "DeleteAccount", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// TestDelete_Fails_When_LastOwnerOfSharedProject tests that projects other users belong to are not orphaned.
+func (s *AccountUsecaseTestSuite) TestDelete_Fails_When_LastOwnerOfSharedProject() {
+	ctx := s.aliceCtx
+	s.mockUserRepo.On("FindByID", ctx, "user-1").Return(s.alice, nil)
+	s.mockPwdSvc.On("Compare", "hash", "secret").Return(true)
+	s.mockProjectRepo.On("ListByMember", ctx, "user-1").Return([]domain.Project{{
+		ID: "project-1", Name: "Launch", Members: []domain.ProjectMember{
+			{UserID: "user-1", Role: domain.ProjectOwner},
+			{UserID: "user-2", Role: domain.ProjectEditor},
+		},
+	}}, nil)
+
+	err := s.usecase.Delete(ctx, "secret")
+
+	assert.ErrorIs(s.T(), err, ErrAccountOwnsProjects)
+	_, detail := Describe(err)
+	assert.Equal(s.T(), "hand over ownership of Launch first", detail)
+	s.mockAccountRepo.AssertNotCalled(s.T(), "DeleteAccount", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// --- Test Cases for the administrative operations ---
+
+// TestExportUser_Success tests that an admin's export of another user is recorded with the admin as the actor.
+func (s *AccountUsecaseTestSuite) TestExportUser_Success() {
+	ctx := s.adminCtx
+	s.mockUserRepo.On("FindByUsername", ctx, "alice").Return(s.alice, nil)
+	s.mockAuditRepo.On("Record", ctx, mock.MatchedBy(func(e domain.AuditEntry) bool {
+		return e.ActorID == "admin-1" && e.TargetUserID == "user-1" && e.Action == domain.AuditAccountExported
+	})).Return(domain.AuditEntry{}, nil)
+	s.mockTaskRepo.On("GetByOwner", ctx, "user-1").Return(nil, nil)
+	s.mockCommentRepo.On("ListByAuthor", ctx, "user-1").Return(nil, nil)
+	s.mockAuditRepo.On("ListByUser", ctx, "user-1").Return(nil, nil)
+
+	export, err := s.usecase.ExportUser(ctx, "alice")
+
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), "user-1", export.User.ID)
+}
+
+// TestDeleteUser_Success tests that an admin deletes another user without its password.
+func (s *AccountUsecaseTestSuite) TestDeleteUser_Success() {
+	ctx := s.adminCtx
+	s.mockUserRepo.On("FindByUsername", ctx, "alice").Return(s.alice, nil)
+	s.mockProjectRepo.On("ListByMember", ctx, "user-1").Return(nil, nil)
+	s.mockAccountRepo.On("DeleteAccount", ctx, "user-1", mock.MatchedBy(func(e domain.AuditEntry) bool {
+		return e.ActorID == "admin-1" && e.ActorUsername == "root" && e.TargetUsername == "alice" && e.Action == domain.AuditAccountDeleted
+	})).Return(nil, nil)
+
+	err := s.usecase.DeleteUser(ctx, "alice")
+
+	assert.NoError(s.T(), err)
+}
+
+// TestDeleteUser_Fails_When_Forbidden tests the permission, super-admin and self-deletion checks.
+func (s *AccountUsecaseTestSuite) TestDeleteUser_Fails_When_Forbidden() {
+	err := s.usecase.DeleteUser(s.aliceCtx, "root")
+	assert.ErrorIs(s.T(), err, ErrForbidden, "Members cannot delete other users")
+
+	s.mockUserRepo.On("FindByUsername", s.adminCtx, "super").Return(domain.User{ID: "super-1", Role: domain.RoleSuperAdmin}, nil)
+	err = s.usecase.DeleteUser(s.adminCtx, "super")
+	assert.ErrorIs(s.T(), err, ErrForbidden, "Only super admins may delete super admins")
+
+	s.mockUserRepo.On("FindByUsername", s.adminCtx, "root").Return(s.adminUser, nil)
+	err = s.usecase.DeleteUser(s.adminCtx, "root")
+	assert.ErrorIs(s.T(), err, ErrForbidden, "Admins must delete their own account with their password")
+
+	s.mockAccountRepo.AssertNotCalled(s.T(), "DeleteAccount", mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,29 @@
+package usecase
+
+import (
+	"context"
+	"task_manager_test/internal/domain"
+)
+
+// Actor identifies the authenticated user on whose behalf a use case runs.
+type Actor struct {
+	UserID   string
+	Username string
+	Role     domain.Role
+	// OrgID is the organization the actor belongs to; it scopes the actor's queries, see TenantFromContext.
+	OrgID string
+}
+
+// actorKey is the context key under which the Actor is stored.
+type actorKey struct{}
+
+// WithActor returns a copy of ctx carrying the actor. The delivery layer sets it once the request is authenticated.
+func WithActor(ctx context.Context, a Actor) context.Context {
+	return context.WithValue(ctx, actorKey{}, a)
+}
+
+// ActorFromContext returns the actor stored in ctx, if any.
+func ActorFromContext(ctx context.Context) (Actor, bool) {
+	a, ok := ctx.Value(actorKey{}).(Actor)
+	return a, ok
+}
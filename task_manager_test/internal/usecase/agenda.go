@@ -0,0 +1,87 @@
+package usecase
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"task_manager_test/internal/domain"
+	"time"
+)
+
+// LoadTimeZone returns the location with the IANA name, or UTC for the empty name. "Local" is rejected, since it
+// names the server's zone rather than the user's.
+func LoadTimeZone(name string) (*time.Location, error) {
+	if name == "" {
+		return time.UTC, nil
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil || name == "Local" {
+		return nil, WithDetail(ErrInvalidTimeZone, fmt.Sprintf("%q is not an IANA time zone name", name))
+	}
+	return loc, nil
+}
+
+// agendaBounds returns the due dates covered by the range at now in loc: from inclusive, to exclusive. It reports
+// false for unknown ranges.
+func agendaBounds(rng domain.AgendaRange, now time.Time, loc *time.Location) (from, to time.Time, ok bool) {
+	now = now.In(loc)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+	switch rng {
+	case domain.AgendaToday:
+		return today, today.AddDate(0, 0, 1), true
+	case domain.AgendaTomorrow:
+		return today.AddDate(0, 0, 1), today.AddDate(0, 0, 2), true
+	case domain.AgendaWeek:
+		monday := today.AddDate(0, 0, -(int(today.Weekday())+6)%7)
+		return monday, monday.AddDate(0, 0, 7), true
+	case domain.AgendaOverdue:
+		return time.Time{}, today, true
+	}
+	return time.Time{}, time.Time{}, false
+}
+
+// Agenda lists the open tasks due within the range from the actor's personal tasks, the ones List shows them.
+// Archived and completed tasks are left out, as are project tasks, which may belong to projects the actor has left.
+func (u *taskUsecase) Agenda(ctx context.Context, rng domain.AgendaRange) (domain.Agenda, error) {
+	actor, err := u.access.Authorize(ctx, domain.PermTaskReadOwn)
+	if err != nil {
+		return domain.Agenda{}, err
+	}
+	usr, err := u.users.FindByID(ctx, actor.UserID)
+	if err != nil {
+		return domain.Agenda{}, err
+	}
+	loc, err := LoadTimeZone(usr.TimeZone)
+	if err != nil {
+		return domain.Agenda{}, err
+	}
+	from, to, ok := agendaBounds(rng, u.now(), loc)
+	if !ok {
+		return domain.Agenda{}, &TaskRequestError{Reason: "range must be today, tomorrow, week or overdue"}
+	}
+
+	shared, err := u.sharedWith(ctx, actor.UserID)
+	if err != nil {
+		return domain.Agenda{}, err
+	}
+	q := personalTasks(actor, shared)
+	q.DueFrom, q.DueBefore = from, to
+	tasks, err := u.repo.Find(ctx, q)
+	if err != nil {
+		return domain.Agenda{}, err
+	}
+
+	agenda := domain.Agenda{Range: rng, Location: loc, From: from, To: to, Tasks: []domain.TaskListItem{}}
+	for _, t := range tasks {
+		item := domain.TaskListItem{Task: t, Ownership: domain.OwnershipOwner}
+		if !isOwner(actor, t) {
+			item.Ownership, item.Access = domain.OwnershipShared, shared[t.ID]
+		}
+		agenda.Tasks = append(agenda.Tasks, item)
+	}
+	slices.SortStableFunc(agenda.Tasks, func(a, b domain.TaskListItem) int {
+		return cmp.Or(a.Task.DueDate.Compare(b.Task.DueDate), cmp.Compare(a.Task.ID, b.Task.ID))
+	})
+	return agenda, nil
+}
@@ -0,0 +1,116 @@
+package usecase
+
+import (
+	"slices"
+	"task_manager_test/internal/domain"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// TestLoadTimeZone tests which zone names are accepted.
+func TestLoadTimeZone(t *testing.T) {
+	loc, err := LoadTimeZone("")
+	assert.NoError(t, err)
+	assert.Equal(t, time.UTC, loc)
+
+	loc, err = LoadTimeZone("Africa/Addis_Ababa")
+	assert.NoError(t, err)
+	assert.Equal(t, "Africa/Addis_Ababa", loc.String())
+
+	for _, name := range []string{"Local", "Mars/Olympus_Mons", "+03:00"} {
+		_, err := LoadTimeZone(name)
+		assert.ErrorIs(t, err, ErrInvalidTimeZone, name)
+	}
+}
+
+// TestAgendaBounds tests that ranges start and end at local midnights, including across a daylight saving change.
+func TestAgendaBounds(t *testing.T) {
+	addis, err := time.LoadLocation("Africa/Addis_Ababa")
+	require.NoError(t, err)
+	sf, err := time.LoadLocation("America/Los_Angeles")
+	require.NoError(t, err)
+	// Wednesday 5 March 2025, 22:30 UTC: already Thursday in Addis Ababa, still Wednesday in San Francisco.
+	now := time.Date(2025, 3, 5, 22, 30, 0, 0, time.UTC)
+
+	cases := []struct {
+		rng      domain.AgendaRange
+		loc      *time.Location
+		from, to time.Time
+	}{
+		{domain.AgendaToday, addis, time.Date(2025, 3, 6, 0, 0, 0, 0, addis), time.Date(2025, 3, 7, 0, 0, 0, 0, addis)},
+		{domain.AgendaToday, sf, time.Date(2025, 3, 5, 0, 0, 0, 0, sf), time.Date(2025, 3, 6, 0, 0, 0, 0, sf)},
+		{domain.AgendaTomorrow, sf, time.Date(2025, 3, 6, 0, 0, 0, 0, sf), time.Date(2025, 3, 7, 0, 0, 0, 0, sf)},
+		{domain.AgendaOverdue, addis, time.Time{}, time.Date(2025, 3, 6, 0, 0, 0, 0, addis)},
+		// The week of 3 March contains the switch to daylight saving time on Sunday 9 March, so it lasts 167 hours.
+		{domain.AgendaWeek, sf, time.Date(2025, 3, 3, 0, 0, 0, 0, sf), time.Date(2025, 3, 10, 0, 0, 0, 0, sf)},
+	}
+	for _, tc := range cases {
+		from, to, ok := agendaBounds(tc.rng, now, tc.loc)
+		assert.True(t, ok)
+		assert.True(t, tc.from.Equal(from), "%s in %s starts at %s, want %s", tc.rng, tc.loc, from, tc.from)
+		assert.True(t, tc.to.Equal(to), "%s in %s ends at %s, want %s", tc.rng, tc.loc, to, tc.to)
+	}
+	from, to, _ := agendaBounds(domain.AgendaWeek, now, sf)
+	assert.Equal(t, 167*time.Hour, to.Sub(from))
+
+	_, _, ok := agendaBounds("month", now, time.UTC)
+	assert.False(t, ok)
+}
+
+// --- Test Cases for the Agenda Method ---
+
+// TestAgenda tests that the actor's personal tasks due within the local day are queried and listed earliest first.
+func (s *TaskUsecaseTestSuite) TestAgenda() {
+	// ARRANGE: at 12:00 UTC on 1 January it is 15:00 in Addis Ababa, whose day runs from 21:00 to 21:00 UTC.
+	ctx := as("user-1", domain.RoleMember)
+	s.mockUserRepo.On("FindByID", ctx, "user-1").Return(domain.User{ID: "user-1", TimeZone: "Africa/Addis_Ababa"}, nil)
+	midnight := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	at := func(hours int) time.Time { return midnight.Add(time.Duration(hours) * time.Hour) }
+	s.mockShareRepo.On("ListByUser", ctx, "user-1").Return([]domain.TaskShare{{TaskID: "shared", Access: domain.ShareRead}}, nil)
+	s.mockTaskRepo.On("Find", ctx, mock.MatchedBy(func(q domain.TaskQuery) bool {
+		return !q.All && q.OwnerID == "user-1" && slices.Equal(q.IDs, []string{"shared"}) && q.Personal &&
+			q.DueFrom.Equal(at(-3)) && q.DueBefore.Equal(at(21))
+	})).Return([]domain.Task{
+		{ID: "late", OwnerID: "user-1", DueDate: at(20), Status: "pending"},
+		{ID: "early", OwnerID: "user-1", DueDate: at(-3), Status: "pending"},
+		{ID: "shared", OwnerID: "user-2", DueDate: at(9), Status: "pending"},
+	}, nil)
+
+	// ACT
+	agenda, err := s.usecase.Agenda(ctx, domain.AgendaToday)
+
+	// ASSERT
+	s.Require().NoError(err)
+	assert.Equal(s.T(), "Africa/Addis_Ababa", agenda.Location.String())
+	assert.True(s.T(), agenda.From.Equal(at(-3)))
+	assert.True(s.T(), agenda.To.Equal(at(21)))
+	var ids []string
+	for _, item := range agenda.Tasks {
+		ids = append(ids, item.Task.ID)
+	}
+	assert.Equal(s.T(), []string{"early", "shared", "late"}, ids)
+	assert.Equal(s.T(), domain.OwnershipShared, agenda.Tasks[1].Ownership)
+	assert.Equal(s.T(), domain.ShareRead, agenda.Tasks[1].Access)
+}
+
+// TestAgenda_Fails_When_RangeIsUnknown tests that unknown ranges are rejected before any task is loaded.
+func (s *TaskUsecaseTestSuite) TestAgenda_Fails_When_RangeIsUnknown() {
+	ctx := as("user-1", domain.RoleMember)
+	s.mockUserRepo.On("FindByID", ctx, "user-1").Return(domain.User{ID: "user-1"}, nil)
+
+	_, err := s.usecase.Agenda(ctx, "month")
+
+	assert.ErrorIs(s.T(), err, ErrInvalidTaskRequest)
+	s.mockTaskRepo.AssertNotCalled(s.T(), "Find", mock.Anything, mock.Anything)
+}
+
+// TestAgenda_Fails_When_ActorCannotReadOwnTasks tests that the agenda requires task.read.own.
+func (s *TaskUsecaseTestSuite) TestAgenda_Fails_When_ActorCannotReadOwnTasks() {
+	_, err := s.usecase.Agenda(as("user-1", "auditor"), domain.AgendaToday)
+
+	assert.ErrorIs(s.T(), err, ErrForbidden)
+}
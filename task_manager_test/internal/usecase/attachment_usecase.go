@@ -0,0 +1,270 @@
+package usecase
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"task_manager_test/internal/domain"
+	"time"
+	"unicode/utf8"
+)
+
+// MaxAttachmentFilenameLength is the maximum number of characters in an attachment's file name.
+const MaxAttachmentFilenameLength = 255
+
+// sniffLength is the number of leading bytes inspected to detect an attachment's content type.
+const sniffLength = 512
+
+// AttachmentUsecase defines application-level operations for files attached to tasks.
+type AttachmentUsecase interface {
+	// Upload stores the content read from r and attaches it to the task under the given file name.
+	Upload(ctx context.Context, taskID, filename string, r io.Reader) (domain.Attachment, error)
+	// List returns the task's attachments.
+	List(ctx context.Context, taskID string) ([]domain.Attachment, error)
+	// Open returns an attachment together with its content. The caller must close the content.
+	Open(ctx context.Context, taskID, attachmentID string) (domain.Attachment, io.ReadCloser, error)
+	// Delete removes an attachment from the task.
+	Delete(ctx context.Context, taskID, attachmentID string) error
+}
+
+// attachmentUsecase implements AttachmentUsecase. Attachments follow the task's authorization: anyone who can read
+// the task may list and download them, and anyone who can update it may upload and delete them. Contents are stored
+// once per digest and removed when no task refers to them any more. Each upload reserves its space in the uploader's
+// quota before the content is read, and removing an attachment returns it.
+type attachmentUsecase struct {
+	repo    ITaskRepository
+	tasks   TaskUsecase
+	users   IUserRepository
+	blobs   IBlobStore
+	maxSize int64
+	quota   int64
+	now     func() time.Time
+}
+
+// NewAttachmentUsecase constructs a new AttachmentUsecase. Files may be at most maxSize bytes, and each user may
+// upload at most quota bytes in total.
+func NewAttachmentUsecase(repo ITaskRepository, tasks TaskUsecase, users IUserRepository, blobs IBlobStore, maxSize, quota int64) AttachmentUsecase {
+	return &attachmentUsecase{repo: repo, tasks: tasks, users: users, blobs: blobs, maxSize: maxSize, quota: quota, now: time.Now}
+}
+
+// Upload stores the file and records it on the task. The content type is detected from the content rather than
+// trusted from the client.
+func (u *attachmentUsecase) Upload(ctx context.Context, taskID, filename string, r io.Reader) (domain.Attachment, error) {
+	if _, err := u.tasks.GetForUpdate(ctx, taskID); err != nil {
+		return domain.Attachment{}, err
+	}
+	actor, _ := ActorFromContext(ctx)
+	filename, err := validateFilename(filename)
+	if err != nil {
+		return domain.Attachment{}, err
+	}
+
+	reserved, err := u.users.ReserveAttachmentSpace(ctx, actor.UserID, u.maxSize, u.quota)
+	if err != nil {
+		return domain.Attachment{}, err
+	}
+	a, err := u.upload(ctx, taskID, filename, r, reserved)
+	// The space the file did not take is returned even if the client has gone away, all of it if the upload failed.
+	// Once the file is stored, failing to return the rest only leaves it reserved, so the upload still succeeds.
+	if unused := reserved - a.Size; unused > 0 {
+		relErr := u.users.ReleaseAttachmentSpace(context.WithoutCancel(ctx), actor.UserID, unused)
+		if err != nil {
+			err = errors.Join(err, relErr)
+		}
+	}
+	return a, err
+}
+
+// upload stores at most limit bytes of the content and attaches it to the task. The content is held from the moment
+// it is stored, and its digest locked while the attachment is recorded, so that a concurrent removal of the last
+// attachment with the same content cannot delete it in between.
+func (u *attachmentUsecase) upload(ctx context.Context, taskID, filename string, r io.Reader, limit int64) (domain.Attachment, error) {
+	actor, _ := ActorFromContext(ctx)
+	br := bufio.NewReaderSize(r, sniffLength)
+	head, err := br.Peek(sniffLength)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return domain.Attachment{}, err
+	}
+	if len(head) == 0 {
+		return domain.Attachment{}, &AttachmentRequestError{Reason: "file is empty"}
+	}
+	digest, size, err := u.blobs.Put(ctx, br, limit)
+	if errors.Is(err, ErrAttachmentTooLarge) && limit < u.maxSize {
+		return domain.Attachment{}, ErrAttachmentQuotaExceeded
+	}
+	if err != nil {
+		return domain.Attachment{}, err
+	}
+
+	unlock := blobLocks.lock(digest)
+	a, err := u.repo.AddAttachment(ctx, taskID, domain.Attachment{
+		Filename:    filename,
+		ContentType: http.DetectContentType(head),
+		Size:        size,
+		Digest:      digest,
+		UploadedBy:  actor.UserID,
+		UploadedAt:  u.now(),
+	})
+	u.blobs.Release(digest)
+	unlock()
+	if err != nil {
+		if relErr := releaseBlobs(ctx, u.repo, u.blobs, []domain.Attachment{{Digest: digest}}); relErr != nil {
+			return domain.Attachment{}, errors.Join(err, relErr)
+		}
+		return domain.Attachment{}, err
+	}
+	return a, nil
+}
+
+// List returns the attachments of a task the actor can read.
+func (u *attachmentUsecase) List(ctx context.Context, taskID string) ([]domain.Attachment, error) {
+	task, err := u.tasks.Get(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	return task.Attachments, nil
+}
+
+// Open returns an attachment of a task the actor can read.
+func (u *attachmentUsecase) Open(ctx context.Context, taskID, attachmentID string) (domain.Attachment, io.ReadCloser, error) {
+	task, err := u.tasks.Get(ctx, taskID)
+	if err != nil {
+		return domain.Attachment{}, nil, err
+	}
+	a, err := findAttachment(task, attachmentID)
+	if err != nil {
+		return domain.Attachment{}, nil, err
+	}
+	content, err := u.blobs.Open(ctx, a.Digest)
+	if err != nil {
+		return domain.Attachment{}, nil, err
+	}
+	return a, content, nil
+}
+
+// Delete removes the attachment from a task the actor can update, releasing its content if no other task uses it.
+func (u *attachmentUsecase) Delete(ctx context.Context, taskID, attachmentID string) error {
+	task, err := u.tasks.GetForUpdate(ctx, taskID)
+	if err != nil {
+		return err
+	}
+	a, err := findAttachment(task, attachmentID)
+	if err != nil {
+		return err
+	}
+	if err := u.repo.RemoveAttachment(ctx, taskID, attachmentID); err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return ErrAttachmentNotFound
+		}
+		return err
+	}
+	return releaseAttachments(ctx, u.repo, u.users, u.blobs, []domain.Attachment{a})
+}
+
+// findAttachment returns the task's attachment with the given ID.
+func findAttachment(task domain.Task, attachmentID string) (domain.Attachment, error) {
+	for _, a := range task.Attachments {
+		if a.ID == attachmentID {
+			return a, nil
+		}
+	}
+	return domain.Attachment{}, ErrAttachmentNotFound
+}
+
+// validateFilename strips any directories from the client's file name, using either separator, and checks it.
+func validateFilename(filename string) (string, error) {
+	filename = strings.TrimSpace(filename[strings.LastIndexAny(filename, `/\`)+1:])
+	switch {
+	case filename == "" || filename == "." || filename == "..":
+		return "", &AttachmentRequestError{Reason: "filename is required"}
+	case utf8.RuneCountInString(filename) > MaxAttachmentFilenameLength:
+		return "", &AttachmentRequestError{Reason: "filename must be at most 255 characters"}
+	}
+	return filename, nil
+}
+
+// releaseAttachments returns the space of removed attachments to their uploaders' quotas and deletes their contents
+// if no task refers to them any more.
+func releaseAttachments(ctx context.Context, tasks ITaskRepository, users IUserRepository, blobs IBlobStore, attachments []domain.Attachment) error {
+	sizes := make(map[string]int64)
+	for _, a := range attachments {
+		sizes[a.UploadedBy] += a.Size
+	}
+	for userID, size := range sizes {
+		if userID == "" || size == 0 {
+			continue
+		}
+		if err := users.ReleaseAttachmentSpace(ctx, userID, size); err != nil {
+			return err
+		}
+	}
+	return releaseBlobs(ctx, tasks, blobs, attachments)
+}
+
+// releaseBlobs deletes the contents of removed attachments that no task refers to any more. Each digest is locked
+// while its references are counted and its content deleted, so that no upload records a new reference in between.
+func releaseBlobs(ctx context.Context, tasks ITaskRepository, blobs IBlobStore, attachments []domain.Attachment) error {
+	seen := make(map[string]bool)
+	for _, a := range attachments {
+		if seen[a.Digest] {
+			continue
+		}
+		seen[a.Digest] = true
+		if err := releaseBlob(ctx, tasks, blobs, a.Digest); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// releaseBlob deletes the content with the given digest if no task refers to it.
+func releaseBlob(ctx context.Context, tasks ITaskRepository, blobs IBlobStore, digest string) error {
+	defer blobLocks.lock(digest)()
+	n, err := tasks.CountAttachmentsByDigest(ctx, digest)
+	if err != nil || n > 0 {
+		return err
+	}
+	return blobs.Delete(ctx, digest)
+}
+
+// blobLocks serializes recording and removing references to the same content within the process, which every use
+// case shares along with the blob store.
+var blobLocks = digestLocks{locks: make(map[string]*digestLock)}
+
+// digestLocks is a set of mutexes keyed by digest, each of which exists only while it is held or waited for.
+type digestLocks struct {
+	mu    sync.Mutex
+	locks map[string]*digestLock
+}
+
+// digestLock is the mutex of one digest and the number of goroutines holding or waiting for it.
+type digestLock struct {
+	sync.Mutex
+	refs int
+}
+
+// lock locks the digest and returns the function that unlocks it.
+func (l *digestLocks) lock(digest string) (unlock func()) {
+	l.mu.Lock()
+	dl, ok := l.locks[digest]
+	if !ok {
+		dl = new(digestLock)
+		l.locks[digest] = dl
+	}
+	dl.refs++
+	l.mu.Unlock()
+
+	dl.Lock()
+	return func() {
+		dl.Unlock()
+		l.mu.Lock()
+		if dl.refs--; dl.refs == 0 {
+			delete(l.locks, digest)
+		}
+		l.mu.Unlock()
+	}
+}
@@ -0,0 +1,284 @@
+package usecase
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"sync/atomic"
+	"task_manager_test/internal/domain"
+	"task_manager_test/internal/mocks"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+// AttachmentUsecaseTestSuite defines the test suite for the attachment use case.
+type AttachmentUsecaseTestSuite struct {
+	suite.Suite
+	mockTaskRepo  *mocks.ITaskRepository
+	mockTasks     *mocks.TaskUsecase
+	mockUserRepo  *mocks.IUserRepository
+	mockBlobStore *mocks.IBlobStore
+	usecase       AttachmentUsecase
+	now           time.Time
+}
+
+// SetupTest runs before EACH test in the suite. Files may be 100 bytes and each user may upload 1000 bytes.
+func (s *AttachmentUsecaseTestSuite) SetupTest() {
+	s.mockTaskRepo = mocks.NewITaskRepository(s.T())
+	s.mockTasks = mocks.NewTaskUsecase(s.T())
+	s.mockUserRepo = mocks.NewIUserRepository(s.T())
+	s.mockBlobStore = mocks.NewIBlobStore(s.T())
+
+	s.usecase = NewAttachmentUsecase(s.mockTaskRepo, s.mockTasks, s.mockUserRepo, s.mockBlobStore, 100, 1000)
+
+	s.now = time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	s.usecase.(*attachmentUsecase).now = func() time.Time { return s.now }
+}
+
+// TestAttachmentUsecaseTestSuite is the Go test runner's entry point for this suite.
+func TestAttachmentUsecaseTestSuite(t *testing.T) {
+	suite.Run(t, new(AttachmentUsecaseTestSuite))
+}
+
+// pngHeader is the signature that identifies a PNG image.
+const pngHeader = "\x89PNG\r\n\x1a\n"
+
+// --- Test Cases for the Upload Method ---
+
+// TestUpload_SniffsContentType tests that the stored content type comes from the content, and that the client's
+// path is reduced to a file name.
+func (s *AttachmentUsecaseTestSuite) TestUpload_SniffsContentType() {
+	// ARRANGE
+	ctx := as("user-1", domain.RoleMember)
+	s.mockTasks.On("GetForUpdate", ctx, "task-123").Return(domain.Task{ID: "task-123"}, nil)
+	s.mockUserRepo.On("ReserveAttachmentSpace", ctx, "user-1", int64(100), int64(1000)).Return(int64(100), nil)
+	s.mockBlobStore.On("Put", ctx, mock.Anything, int64(100)).Return("abc", int64(12), nil)
+	s.mockBlobStore.On("Release", "abc").Once()
+	s.mockUserRepo.On("ReleaseAttachmentSpace", mock.Anything, "user-1", int64(88)).Return(nil).Once()
+	want := domain.Attachment{
+		Filename:    "shot.txt",
+		ContentType: "image/png",
+		Size:        12,
+		Digest:      "abc",
+		UploadedBy:  "user-1",
+		UploadedAt:  s.now,
+	}
+	stored := want
+	stored.ID = "att-1"
+	s.mockTaskRepo.On("AddAttachment", ctx, "task-123", want).Return(stored, nil)
+
+	// ACT
+	got, err := s.usecase.Upload(ctx, "task-123", `C:\Users\me\shot.txt`, strings.NewReader(pngHeader+"data"))
+
+	// ASSERT
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), stored, got)
+}
+
+// TestUpload_PassesTheWholeContentToTheStore tests that sniffing does not consume the start of the content.
+func (s *AttachmentUsecaseTestSuite) TestUpload_PassesTheWholeContentToTheStore() {
+	ctx := as("user-1", domain.RoleMember)
+	s.mockTasks.On("GetForUpdate", ctx, "task-123").Return(domain.Task{ID: "task-123"}, nil)
+	s.mockUserRepo.On("ReserveAttachmentSpace", ctx, "user-1", int64(100), int64(1000)).Return(int64(100), nil)
+	var stored []byte
+	s.mockBlobStore.On("Put", ctx, mock.Anything, int64(100)).Run(func(args mock.Arguments) {
+		stored, _ = io.ReadAll(args.Get(1).(io.Reader))
+	}).Return("abc", int64(5), nil)
+	s.mockBlobStore.On("Release", "abc")
+	s.mockTaskRepo.On("AddAttachment", ctx, "task-123", mock.Anything).Return(domain.Attachment{Size: 5}, nil)
+	s.mockUserRepo.On("ReleaseAttachmentSpace", mock.Anything, "user-1", int64(95)).Return(nil)
+
+	_, err := s.usecase.Upload(ctx, "task-123", "notes.txt", strings.NewReader("hello"))
+
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), "hello", string(stored))
+}
+
+// TestUpload_LimitsSizeToRemainingQuota tests that the quota caps the upload, is reported as such, and that the
+// reserved space is returned.
+func (s *AttachmentUsecaseTestSuite) TestUpload_LimitsSizeToRemainingQuota() {
+	ctx := as("user-1", domain.RoleMember)
+	s.mockTasks.On("GetForUpdate", ctx, "task-123").Return(domain.Task{ID: "task-123"}, nil)
+	s.mockUserRepo.On("ReserveAttachmentSpace", ctx, "user-1", int64(100), int64(1000)).Return(int64(50), nil)
+	s.mockBlobStore.On("Put", ctx, mock.Anything, int64(50)).Return("", int64(0), ErrAttachmentTooLarge)
+	s.mockUserRepo.On("ReleaseAttachmentSpace", mock.Anything, "user-1", int64(50)).Return(nil).Once()
+
+	_, err := s.usecase.Upload(ctx, "task-123", "big.bin", bytes.NewReader(make([]byte, 80)))
+
+	assert.ErrorIs(s.T(), err, ErrAttachmentQuotaExceeded)
+	s.mockTaskRepo.AssertNotCalled(s.T(), "AddAttachment")
+}
+
+// TestUpload_Fails_When_FileIsTooLarge tests that the per-file limit is reported when the quota is not the cause.
+func (s *AttachmentUsecaseTestSuite) TestUpload_Fails_When_FileIsTooLarge() {
+	ctx := as("user-1", domain.RoleMember)
+	s.mockTasks.On("GetForUpdate", ctx, "task-123").Return(domain.Task{ID: "task-123"}, nil)
+	s.mockUserRepo.On("ReserveAttachmentSpace", ctx, "user-1", int64(100), int64(1000)).Return(int64(100), nil)
+	s.mockBlobStore.On("Put", ctx, mock.Anything, int64(100)).Return("", int64(0), ErrAttachmentTooLarge)
+	s.mockUserRepo.On("ReleaseAttachmentSpace", mock.Anything, "user-1", int64(100)).Return(nil).Once()
+
+	_, err := s.usecase.Upload(ctx, "task-123", "big.bin", bytes.NewReader(make([]byte, 200)))
+
+	assert.ErrorIs(s.T(), err, ErrAttachmentTooLarge)
+}
+
+// TestUpload_Fails_When_RequestIsInvalid tests the validation of file names and content, and the exhausted quota.
+func (s *AttachmentUsecaseTestSuite) TestUpload_Fails_When_RequestIsInvalid() {
+	ctx := as("user-1", domain.RoleMember)
+	full := as("user-2", domain.RoleMember)
+	s.mockTasks.On("GetForUpdate", mock.Anything, "task-123").Return(domain.Task{ID: "task-123"}, nil)
+	s.mockUserRepo.On("ReserveAttachmentSpace", full, "user-2", int64(100), int64(1000)).Return(int64(0), ErrAttachmentQuotaExceeded)
+	s.mockUserRepo.On("ReserveAttachmentSpace", ctx, "user-1", int64(100), int64(1000)).Return(int64(100), nil)
+	s.mockUserRepo.On("ReleaseAttachmentSpace", mock.Anything, "user-1", int64(100)).Return(nil)
+
+	cases := map[string]struct {
+		filename string
+		content  string
+		reason   string
+	}{
+		"no filename":   {"  ", "data", "filename is required"},
+		"only a path":   {"uploads/", "data", "filename is required"},
+		"long filename": {strings.Repeat("x", MaxAttachmentFilenameLength+1), "data", "filename must be at most 255 characters"},
+		"empty file":    {"empty.txt", "", "file is empty"},
+	}
+	for name, tc := range cases {
+		s.Run(name, func() {
+			_, err := s.usecase.Upload(ctx, "task-123", tc.filename, strings.NewReader(tc.content))
+
+			var reqErr *AttachmentRequestError
+			assert.ErrorAs(s.T(), err, &reqErr)
+			assert.Equal(s.T(), tc.reason, reqErr.Reason)
+		})
+	}
+
+	_, err := s.usecase.Upload(full, "task-123", "a.txt", strings.NewReader("data"))
+	assert.ErrorIs(s.T(), err, ErrAttachmentQuotaExceeded)
+	s.mockBlobStore.AssertNotCalled(s.T(), "Put")
+}
+
+// TestUpload_Fails_When_TaskIsReadOnly tests that uploads follow the task's update authorization.
+func (s *AttachmentUsecaseTestSuite) TestUpload_Fails_When_TaskIsReadOnly() {
+	ctx := as("user-1", domain.RoleMember)
+	s.mockTasks.On("GetForUpdate", ctx, "task-123").Return(domain.Task{}, ErrForbidden)
+
+	_, err := s.usecase.Upload(ctx, "task-123", "a.txt", strings.NewReader("data"))
+
+	assert.ErrorIs(s.T(), err, ErrForbidden)
+}
+
+// TestUpload_ReleasesContent_When_TaskIsGone tests that stored content and the reserved space are released when the
+// attachment cannot be recorded.
+func (s *AttachmentUsecaseTestSuite) TestUpload_ReleasesContent_When_TaskIsGone() {
+	ctx := as("user-1", domain.RoleMember)
+	s.mockTasks.On("GetForUpdate", ctx, "task-123").Return(domain.Task{ID: "task-123"}, nil)
+	s.mockUserRepo.On("ReserveAttachmentSpace", ctx, "user-1", int64(100), int64(1000)).Return(int64(100), nil)
+	s.mockBlobStore.On("Put", ctx, mock.Anything, int64(100)).Return("abc", int64(4), nil)
+	s.mockTaskRepo.On("AddAttachment", ctx, "task-123", mock.Anything).Return(domain.Attachment{}, ErrNotFound)
+	s.mockBlobStore.On("Release", "abc").Once()
+	s.mockTaskRepo.On("CountAttachmentsByDigest", ctx, "abc").Return(int64(0), nil)
+	s.mockBlobStore.On("Delete", ctx, "abc").Return(nil).Once()
+	s.mockUserRepo.On("ReleaseAttachmentSpace", mock.Anything, "user-1", int64(100)).Return(nil).Once()
+
+	_, err := s.usecase.Upload(ctx, "task-123", "a.txt", strings.NewReader("data"))
+
+	assert.ErrorIs(s.T(), err, ErrNotFound)
+}
+
+// TestUpload_RecordsContentBeforeItCanBeReleased tests that removing the last other attachment with the same content
+// while an upload records it waits, and then finds the new reference instead of deleting the content.
+func (s *AttachmentUsecaseTestSuite) TestUpload_RecordsContentBeforeItCanBeReleased() {
+	// ARRANGE
+	ctx := as("user-1", domain.RoleMember)
+	s.mockTasks.On("GetForUpdate", ctx, "task-123").Return(domain.Task{ID: "task-123"}, nil)
+	s.mockUserRepo.On("ReserveAttachmentSpace", ctx, "user-1", int64(100), int64(1000)).Return(int64(100), nil)
+	s.mockUserRepo.On("ReleaseAttachmentSpace", mock.Anything, "user-1", int64(96)).Return(nil)
+	s.mockBlobStore.On("Put", ctx, mock.Anything, int64(100)).Return("abc", int64(4), nil)
+	s.mockBlobStore.On("Release", "abc").Once()
+	recording, proceed := make(chan struct{}), make(chan struct{})
+	var recorded atomic.Bool
+	s.mockTaskRepo.On("AddAttachment", ctx, "task-123", mock.Anything).Run(func(mock.Arguments) {
+		close(recording)
+		<-proceed
+		recorded.Store(true)
+	}).Return(domain.Attachment{Size: 4, Digest: "abc"}, nil)
+	s.mockTaskRepo.On("CountAttachmentsByDigest", ctx, "abc").Run(func(mock.Arguments) {
+		assert.True(s.T(), recorded.Load(), "References should only be counted once the upload has recorded its own")
+	}).Return(int64(1), nil)
+
+	// ACT
+	uploaded := make(chan error)
+	go func() {
+		_, err := s.usecase.Upload(ctx, "task-123", "a.txt", strings.NewReader("data"))
+		uploaded <- err
+	}()
+	<-recording
+	released := make(chan error)
+	go func() {
+		released <- releaseBlobs(ctx, s.mockTaskRepo, s.mockBlobStore, []domain.Attachment{{Digest: "abc"}})
+	}()
+	time.Sleep(20 * time.Millisecond)
+	close(proceed)
+
+	// ASSERT
+	assert.NoError(s.T(), <-uploaded)
+	assert.NoError(s.T(), <-released)
+	s.mockBlobStore.AssertNotCalled(s.T(), "Delete", mock.Anything, mock.Anything)
+}
+
+// --- Test Cases for the Open Method ---
+
+// TestOpen_ChecksTaskAccess tests that downloads go through the task's read authorization and unknown
+// attachments are reported.
+func (s *AttachmentUsecaseTestSuite) TestOpen_ChecksTaskAccess() {
+	ctx := as("user-1", domain.RoleMember)
+	att := domain.Attachment{ID: "att-1", Digest: "abc"}
+	s.mockTasks.On("Get", ctx, "task-123").Return(domain.Task{ID: "task-123", Attachments: []domain.Attachment{att}}, nil)
+	s.mockTasks.On("Get", ctx, "task-999").Return(domain.Task{}, ErrNotFound)
+	s.mockBlobStore.On("Open", ctx, "abc").Return(io.NopCloser(strings.NewReader("data")), nil)
+
+	got, content, err := s.usecase.Open(ctx, "task-123", "att-1")
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), att, got)
+	assert.NoError(s.T(), content.Close())
+
+	_, _, err = s.usecase.Open(ctx, "task-123", "att-2")
+	assert.ErrorIs(s.T(), err, ErrAttachmentNotFound)
+	_, _, err = s.usecase.Open(ctx, "task-999", "att-1")
+	assert.ErrorIs(s.T(), err, ErrNotFound)
+}
+
+// --- Test Cases for the Delete Method ---
+
+// TestDelete_KeepsSharedContent tests that content still attached elsewhere is kept, and that the space is returned
+// to the uploader's quota.
+func (s *AttachmentUsecaseTestSuite) TestDelete_KeepsSharedContent() {
+	ctx := as("user-1", domain.RoleMember)
+	task := domain.Task{ID: "task-123", Attachments: []domain.Attachment{{ID: "att-1", Digest: "abc", Size: 7, UploadedBy: "user-2"}}}
+	s.mockTasks.On("GetForUpdate", ctx, "task-123").Return(task, nil)
+	s.mockTaskRepo.On("RemoveAttachment", ctx, "task-123", "att-1").Return(nil)
+	s.mockUserRepo.On("ReleaseAttachmentSpace", ctx, "user-2", int64(7)).Return(nil).Once()
+	s.mockTaskRepo.On("CountAttachmentsByDigest", ctx, "abc").Return(int64(1), nil)
+
+	err := s.usecase.Delete(ctx, "task-123", "att-1")
+
+	assert.NoError(s.T(), err)
+	s.mockBlobStore.AssertNotCalled(s.T(), "Delete", mock.Anything, mock.Anything)
+}
+
+// TestDelete_Fails_When_AttachmentIsGone tests that a concurrent removal is reported as a missing attachment.
+func (s *AttachmentUsecaseTestSuite) TestDelete_Fails_When_AttachmentIsGone() {
+	ctx := as("user-1", domain.RoleMember)
+	task := domain.Task{ID: "task-123", Attachments: []domain.Attachment{{ID: "att-1", Digest: "abc"}}}
+	s.mockTasks.On("GetForUpdate", ctx, "task-123").Return(task, nil)
+	s.mockTaskRepo.On("RemoveAttachment", ctx, "task-123", "att-1").Return(ErrNotFound)
+
+	err := s.usecase.Delete(ctx, "task-123", "att-1")
+
+	assert.ErrorIs(s.T(), err, ErrAttachmentNotFound)
+	assert.False(s.T(), errors.Is(err, ErrNotFound), "The task itself still exists")
+}
@@ -0,0 +1,223 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"task_manager_test/internal/domain"
+	"time"
+	"unicode/utf8"
+)
+
+// Limits applied to comments.
+const (
+	// MaxCommentLength is the maximum number of characters in a comment body.
+	MaxCommentLength = 5000
+	// DefaultCommentsPerPage is the page size used when none is requested.
+	DefaultCommentsPerPage = 20
+	// MaxCommentsPerPage caps the requested page size.
+	MaxCommentsPerPage = 100
+)
+
+// mentionPattern matches @username references in a comment body.
+var mentionPattern = regexp.MustCompile(`@([A-Za-z0-9_.-]+)`)
+
+// CommentUsecase defines application-level operations for discussing tasks.
+type CommentUsecase interface {
+	// Create posts a comment on the task, or a reply when parentID names a top-level comment of the same task.
+	Create(ctx context.Context, taskID, parentID, body string) (domain.Comment, error)
+	// List returns a page of the task's top-level comments with their replies.
+	List(ctx context.Context, taskID string, page, perPage int) (domain.CommentPage, error)
+	// Update changes the body of the actor's own comment within the edit window.
+	Update(ctx context.Context, taskID, commentID, body string) (domain.Comment, error)
+	// Delete removes a comment together with its replies.
+	Delete(ctx context.Context, taskID, commentID string) error
+}
+
+// commentUsecase implements CommentUsecase. Anyone who can read a task may discuss it; tasks the actor cannot read
+// are reported as ErrNotFound by the TaskUsecase. Authors may edit their comments within the edit window, and
+// comments may be deleted by their author, the task owner, or holders of task.delete.any.
+type commentUsecase struct {
+	repo       ICommentRepository
+	tasks      TaskUsecase
+	users      IUserRepository
+	access     *AccessPolicy
+	editWindow time.Duration
+	now        func() time.Time
+}
+
+// NewCommentUsecase constructs a new CommentUsecase. Comments can be edited for editWindow after they are posted.
+func NewCommentUsecase(repo ICommentRepository, tasks TaskUsecase, users IUserRepository, access *AccessPolicy, editWindow time.Duration) CommentUsecase {
+	return &commentUsecase{repo: repo, tasks: tasks, users: users, access: access, editWindow: editWindow, now: time.Now}
+}
+
+// Create posts a comment, resolving @username mentions against the user repository.
+func (u *commentUsecase) Create(ctx context.Context, taskID, parentID, body string) (domain.Comment, error) {
+	if _, err := u.tasks.Get(ctx, taskID); err != nil {
+		return domain.Comment{}, err
+	}
+	actor, _ := ActorFromContext(ctx)
+	body, err := validateCommentBody(body)
+	if err != nil {
+		return domain.Comment{}, err
+	}
+	if parentID != "" {
+		parent, err := u.load(ctx, taskID, parentID)
+		if err != nil {
+			return domain.Comment{}, err
+		}
+		if parent.ParentID != "" {
+			return domain.Comment{}, &CommentRequestError{Reason: "replies cannot be replied to"}
+		}
+	}
+	mentions, err := u.resolveMentions(ctx, body)
+	if err != nil {
+		return domain.Comment{}, err
+	}
+	return u.repo.Create(ctx, domain.Comment{
+		TaskID:         taskID,
+		ParentID:       parentID,
+		AuthorID:       actor.UserID,
+		AuthorUsername: actor.Username,
+		Body:           body,
+		Mentions:       mentions,
+		CreatedAt:      u.now(),
+	})
+}
+
+// List returns a page of threads. Pages start at 1; a non-positive perPage selects DefaultCommentsPerPage.
+func (u *commentUsecase) List(ctx context.Context, taskID string, page, perPage int) (domain.CommentPage, error) {
+	if _, err := u.tasks.Get(ctx, taskID); err != nil {
+		return domain.CommentPage{}, err
+	}
+	if page < 1 {
+		return domain.CommentPage{}, &CommentRequestError{Reason: "page must be at least 1"}
+	}
+	if perPage <= 0 {
+		perPage = DefaultCommentsPerPage
+	}
+	perPage = min(perPage, MaxCommentsPerPage)
+
+	top, total, err := u.repo.ListTopLevel(ctx, taskID, (page-1)*perPage, perPage)
+	if err != nil {
+		return domain.CommentPage{}, err
+	}
+	result := domain.CommentPage{Threads: make([]domain.CommentThread, len(top)), Page: page, PerPage: perPage, Total: total}
+	if len(top) == 0 {
+		return result, nil
+	}
+	ids := make([]string, len(top))
+	index := make(map[string]int, len(top))
+	for i, c := range top {
+		ids[i] = c.ID
+		index[c.ID] = i
+		result.Threads[i].Comment = c
+	}
+	replies, err := u.repo.ListReplies(ctx, ids)
+	if err != nil {
+		return domain.CommentPage{}, err
+	}
+	for _, r := range replies {
+		if i, ok := index[r.ParentID]; ok {
+			result.Threads[i].Replies = append(result.Threads[i].Replies, r)
+		}
+	}
+	return result, nil
+}
+
+// Update lets the author change the comment body until the edit window has passed. Mentions are resolved again.
+func (u *commentUsecase) Update(ctx context.Context, taskID, commentID, body string) (domain.Comment, error) {
+	if _, err := u.tasks.Get(ctx, taskID); err != nil {
+		return domain.Comment{}, err
+	}
+	actor, _ := ActorFromContext(ctx)
+	c, err := u.load(ctx, taskID, commentID)
+	if err != nil {
+		return domain.Comment{}, err
+	}
+	if c.AuthorID != actor.UserID {
+		return domain.Comment{}, ErrForbidden
+	}
+	now := u.now()
+	if now.Sub(c.CreatedAt) > u.editWindow {
+		return domain.Comment{}, ErrCommentEditWindowClosed
+	}
+	if c.Body, err = validateCommentBody(body); err != nil {
+		return domain.Comment{}, err
+	}
+	if c.Mentions, err = u.resolveMentions(ctx, c.Body); err != nil {
+		return domain.Comment{}, err
+	}
+	c.EditedAt = now
+	return u.repo.Update(ctx, c)
+}
+
+// Delete removes the comment and its replies.
+func (u *commentUsecase) Delete(ctx context.Context, taskID, commentID string) error {
+	task, err := u.tasks.Get(ctx, taskID)
+	if err != nil {
+		return err
+	}
+	actor, _ := ActorFromContext(ctx)
+	c, err := u.load(ctx, taskID, commentID)
+	if err != nil {
+		return err
+	}
+	if c.AuthorID != actor.UserID && !isOwner(actor, task) && !u.access.Can(actor.Role, domain.PermTaskDeleteAny) {
+		return ErrForbidden
+	}
+	if err := u.repo.Delete(ctx, commentID); err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return ErrCommentNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// load fetches a comment of the task, reporting comments of other tasks as ErrCommentNotFound.
+func (u *commentUsecase) load(ctx context.Context, taskID, commentID string) (domain.Comment, error) {
+	c, err := u.repo.GetByID(ctx, commentID)
+	if errors.Is(err, ErrNotFound) || errors.Is(err, ErrInvalidID) || (err == nil && c.TaskID != taskID) {
+		return domain.Comment{}, ErrCommentNotFound
+	}
+	return c, err
+}
+
+// resolveMentions returns the existing users mentioned in the body, each once and in order of first mention.
+// Mentions of unknown users are left as plain text.
+func (u *commentUsecase) resolveMentions(ctx context.Context, body string) ([]domain.CommentMention, error) {
+	var mentions []domain.CommentMention
+	seen := make(map[string]bool)
+	for _, m := range mentionPattern.FindAllStringSubmatch(body, -1) {
+		// A trailing dot usually ends the sentence rather than the username.
+		username := strings.TrimRight(m[1], ".")
+		if username == "" || seen[username] {
+			continue
+		}
+		seen[username] = true
+		usr, err := u.users.FindByUsername(ctx, username)
+		if errors.Is(err, ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		mentions = append(mentions, domain.CommentMention{UserID: usr.ID, Username: usr.Username})
+	}
+	return mentions, nil
+}
+
+// validateCommentBody trims the body and checks that it is neither blank nor too long.
+func validateCommentBody(body string) (string, error) {
+	body = strings.TrimSpace(body)
+	if body == "" {
+		return "", &CommentRequestError{Reason: "body is required"}
+	}
+	if utf8.RuneCountInString(body) > MaxCommentLength {
+		return "", &CommentRequestError{Reason: fmt.Sprintf("body must be at most %d characters", MaxCommentLength)}
+	}
+	return body, nil
+}
@@ -0,0 +1,209 @@
+package usecase
+
+import (
+	"strings"
+	"task_manager_test/internal/domain"
+	"task_manager_test/internal/mocks"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+// CommentUsecaseTestSuite defines the test suite for the comment use case.
+type CommentUsecaseTestSuite struct {
+	suite.Suite
+	mockCommentRepo *mocks.ICommentRepository
+	mockTasks       *mocks.TaskUsecase
+	mockUserRepo    *mocks.IUserRepository
+	usecase         CommentUsecase
+	now             time.Time
+}
+
+// SetupTest runs before EACH test in the suite.
+func (s *CommentUsecaseTestSuite) SetupTest() {
+	s.mockCommentRepo = mocks.NewICommentRepository(s.T())
+	s.mockTasks = mocks.NewTaskUsecase(s.T())
+	s.mockUserRepo = mocks.NewIUserRepository(s.T())
+
+	s.usecase = NewCommentUsecase(s.mockCommentRepo, s.mockTasks, s.mockUserRepo, DefaultAccessPolicy(), 15*time.Minute)
+
+	s.now = time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	s.usecase.(*commentUsecase).now = func() time.Time { return s.now }
+}
+
+// TestCommentUsecaseTestSuite is the Go test runner's entry point for this suite.
+func TestCommentUsecaseTestSuite(t *testing.T) {
+	suite.Run(t, new(CommentUsecaseTestSuite))
+}
+
+// visibleTask makes task-123, owned by user-2, readable to every actor.
+func (s *CommentUsecaseTestSuite) visibleTask() {
+	s.mockTasks.On("Get", mock.Anything, "task-123").Return(domain.Task{ID: "task-123", OwnerID: "user-2"}, nil)
+}
+
+// --- Test Cases for the Create Method ---
+
+// TestCreate_ResolvesMentions tests that known @usernames are stored as references and unknown ones are ignored.
+func (s *CommentUsecaseTestSuite) TestCreate_ResolvesMentions() {
+	// ARRANGE
+	ctx := as("user-1", domain.RoleMember)
+	s.visibleTask()
+	s.mockUserRepo.On("FindByUsername", ctx, "bob").Return(domain.User{ID: "user-2", Username: "bob"}, nil).Once()
+	s.mockUserRepo.On("FindByUsername", ctx, "ghost").Return(domain.User{}, ErrNotFound)
+	want := domain.Comment{
+		TaskID:         "task-123",
+		AuthorID:       "user-1",
+		AuthorUsername: "user-1",
+		Body:           "@bob can you check this? cc @ghost and @bob.",
+		Mentions:       []domain.CommentMention{{UserID: "user-2", Username: "bob"}},
+		CreatedAt:      s.now,
+	}
+	s.mockCommentRepo.On("Create", ctx, want).Return(want, nil)
+
+	// ACT
+	got, err := s.usecase.Create(ctx, "task-123", "", "  @bob can you check this? cc @ghost and @bob. ")
+
+	// ASSERT
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), want, got)
+}
+
+// TestCreate_Fails_When_RequestIsInvalid tests the validation of comment bodies and reply targets.
+func (s *CommentUsecaseTestSuite) TestCreate_Fails_When_RequestIsInvalid() {
+	ctx := as("user-1", domain.RoleMember)
+	s.visibleTask()
+	s.mockCommentRepo.On("GetByID", ctx, "reply-1").Return(domain.Comment{ID: "reply-1", TaskID: "task-123", ParentID: "top-1"}, nil)
+
+	cases := map[string]struct {
+		parentID string
+		body     string
+		reason   string
+	}{
+		"blank body":     {"", "   ", "body is required"},
+		"body too long":  {"", strings.Repeat("x", MaxCommentLength+1), "body must be at most 5000 characters"},
+		"reply to reply": {"reply-1", "nested", "replies cannot be replied to"},
+	}
+	for name, tc := range cases {
+		s.Run(name, func() {
+			_, err := s.usecase.Create(ctx, "task-123", tc.parentID, tc.body)
+
+			var reqErr *CommentRequestError
+			assert.ErrorAs(s.T(), err, &reqErr)
+			assert.Equal(s.T(), tc.reason, reqErr.Reason)
+		})
+	}
+	s.mockCommentRepo.AssertNotCalled(s.T(), "Create")
+}
+
+// TestCreate_Fails_When_TaskIsHidden tests that tasks the actor cannot read cannot be discussed.
+func (s *CommentUsecaseTestSuite) TestCreate_Fails_When_TaskIsHidden() {
+	ctx := as("user-1", domain.RoleMember)
+	s.mockTasks.On("Get", ctx, "task-123").Return(domain.Task{}, ErrNotFound)
+
+	_, err := s.usecase.Create(ctx, "task-123", "", "hello")
+
+	assert.ErrorIs(s.T(), err, ErrNotFound)
+}
+
+// TestCreate_Fails_When_ParentIsOnAnotherTask tests that replies must stay on the parent's task.
+func (s *CommentUsecaseTestSuite) TestCreate_Fails_When_ParentIsOnAnotherTask() {
+	ctx := as("user-1", domain.RoleMember)
+	s.visibleTask()
+	s.mockCommentRepo.On("GetByID", ctx, "top-1").Return(domain.Comment{ID: "top-1", TaskID: "task-999"}, nil)
+
+	_, err := s.usecase.Create(ctx, "task-123", "top-1", "hello")
+
+	assert.ErrorIs(s.T(), err, ErrCommentNotFound)
+}
+
+// --- Test Cases for the List Method ---
+
+// TestList_GroupsRepliesUnderTheirThread tests paging of top-level comments and attaching their replies.
+func (s *CommentUsecaseTestSuite) TestList_GroupsRepliesUnderTheirThread() {
+	// ARRANGE
+	ctx := as("user-1", domain.RoleMember)
+	s.visibleTask()
+	top := []domain.Comment{{ID: "c3"}, {ID: "c4"}}
+	replies := []domain.Comment{{ID: "r1", ParentID: "c4"}, {ID: "r2", ParentID: "c3"}, {ID: "r3", ParentID: "c4"}}
+	s.mockCommentRepo.On("ListTopLevel", ctx, "task-123", 2, 2).Return(top, int64(5), nil)
+	s.mockCommentRepo.On("ListReplies", ctx, []string{"c3", "c4"}).Return(replies, nil)
+
+	// ACT
+	page, err := s.usecase.List(ctx, "task-123", 2, 2)
+
+	// ASSERT
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), domain.CommentPage{
+		Threads: []domain.CommentThread{
+			{Comment: top[0], Replies: []domain.Comment{replies[1]}},
+			{Comment: top[1], Replies: []domain.Comment{replies[0], replies[2]}},
+		},
+		Page: 2, PerPage: 2, Total: 5,
+	}, page)
+}
+
+// TestList_ClampsPageSize tests the default and maximum page sizes.
+func (s *CommentUsecaseTestSuite) TestList_ClampsPageSize() {
+	ctx := as("user-1", domain.RoleMember)
+	s.visibleTask()
+	s.mockCommentRepo.On("ListTopLevel", ctx, "task-123", 0, DefaultCommentsPerPage).Return(nil, int64(0), nil).Once()
+	s.mockCommentRepo.On("ListTopLevel", ctx, "task-123", 0, MaxCommentsPerPage).Return(nil, int64(0), nil).Once()
+
+	_, err := s.usecase.List(ctx, "task-123", 1, 0)
+	assert.NoError(s.T(), err)
+	_, err = s.usecase.List(ctx, "task-123", 1, 1000)
+	assert.NoError(s.T(), err)
+	_, err = s.usecase.List(ctx, "task-123", 0, 10)
+	assert.ErrorIs(s.T(), err, ErrInvalidCommentRequest)
+}
+
+// --- Test Cases for the Update Method ---
+
+// TestUpdate_EditWindow tests that authors can edit within the window and not after it.
+func (s *CommentUsecaseTestSuite) TestUpdate_EditWindow() {
+	ctx := as("user-1", domain.RoleMember)
+	s.visibleTask()
+	fresh := domain.Comment{ID: "c1", TaskID: "task-123", AuthorID: "user-1", Body: "old", CreatedAt: s.now.Add(-10 * time.Minute)}
+	stale := domain.Comment{ID: "c2", TaskID: "task-123", AuthorID: "user-1", Body: "old", CreatedAt: s.now.Add(-20 * time.Minute)}
+	s.mockCommentRepo.On("GetByID", ctx, "c1").Return(fresh, nil)
+	s.mockCommentRepo.On("GetByID", ctx, "c2").Return(stale, nil)
+	edited := fresh
+	edited.Body = "new"
+	edited.EditedAt = s.now
+	s.mockCommentRepo.On("Update", ctx, edited).Return(edited, nil).Once()
+
+	got, err := s.usecase.Update(ctx, "task-123", "c1", "new")
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), edited, got)
+
+	_, err = s.usecase.Update(ctx, "task-123", "c2", "new")
+	assert.ErrorIs(s.T(), err, ErrCommentEditWindowClosed)
+}
+
+// TestUpdate_Fails_When_NotAuthor tests that only the author may edit a comment, even within the window.
+func (s *CommentUsecaseTestSuite) TestUpdate_Fails_When_NotAuthor() {
+	ctx := as("user-2", domain.RoleAdmin)
+	s.visibleTask()
+	s.mockCommentRepo.On("GetByID", ctx, "c1").Return(domain.Comment{ID: "c1", TaskID: "task-123", AuthorID: "user-1", CreatedAt: s.now}, nil)
+
+	_, err := s.usecase.Update(ctx, "task-123", "c1", "new")
+
+	assert.ErrorIs(s.T(), err, ErrForbidden)
+}
+
+// --- Test Cases for the Delete Method ---
+
+// TestDelete_AllowedActors tests that the author, the task owner and admins may delete a comment, and others may not.
+func (s *CommentUsecaseTestSuite) TestDelete_AllowedActors() {
+	s.visibleTask()
+	s.mockCommentRepo.On("GetByID", mock.Anything, "c1").Return(domain.Comment{ID: "c1", TaskID: "task-123", AuthorID: "user-1"}, nil)
+	s.mockCommentRepo.On("Delete", mock.Anything, "c1").Return(nil).Times(3)
+
+	assert.NoError(s.T(), s.usecase.Delete(as("user-1", domain.RoleMember), "task-123", "c1"), "The author may delete")
+	assert.NoError(s.T(), s.usecase.Delete(as("user-2", domain.RoleMember), "task-123", "c1"), "The task owner may delete")
+	assert.NoError(s.T(), s.usecase.Delete(as("admin-1", domain.RoleAdmin), "task-123", "c1"), "task.delete.any may delete")
+	assert.ErrorIs(s.T(), s.usecase.Delete(as("user-3", domain.RoleManager), "task-123", "c1"), ErrForbidden)
+}
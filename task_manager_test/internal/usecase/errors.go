@@ -1,18 +1,470 @@
 package usecase
 
-import "errors"
+import (
+	"errors"
+	"time"
+)
+
+// ErrorKind classifies an application error by how a client should react to it. The delivery layers map each kind
+// to their own status codes.
+type ErrorKind int
+
+// Error kinds, from the most to the least specific reaction expected of the client.
+const (
+	// KindInternal is an unexpected failure; the client may retry later.
+	KindInternal ErrorKind = iota
+	// KindInvalid means the request is malformed or breaks a rule and must be corrected.
+	KindInvalid
+	// KindUnauthenticated means the request carries no valid credentials.
+	KindUnauthenticated
+	// KindForbidden means the caller is known but not allowed to do this.
+	KindForbidden
+	// KindNotFound means the addressed resource does not exist or is hidden from the caller.
+	KindNotFound
+	// KindConflict means the request conflicts with the current state.
+	KindConflict
+	// KindTooLarge means the request exceeds a size limit or quota.
+	KindTooLarge
+	// KindTooManyRequests means the caller must wait before trying again.
+	KindTooManyRequests
+	// KindUnavailable means a dependency is failing; the client may retry later, when told after the given delay.
+	KindUnavailable
+)
+
+// Error is an application error with a stable, machine-readable code. Codes are part of the API contract and do
+// not change once published; messages may. Every sentinel below is an *Error, so callers match them with errors.Is
+// and classify any application error with errors.As or Describe.
+type Error struct {
+	Kind    ErrorKind
+	Code    string
+	Message string
+}
+
+// Error implements the error interface.
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// newError declares a sentinel application error.
+func newError(kind ErrorKind, code, message string) error {
+	return &Error{Kind: kind, Code: code, Message: message}
+}
+
+// detailed is implemented by errors that explain a particular failure in terms that are safe to show to clients.
+type detailed interface {
+	Detail() string
+}
+
+// detailError annotates an application error with a client-safe explanation of one occurrence.
+type detailError struct {
+	err    error
+	detail string
+}
+
+// WithDetail annotates err with an explanation of this particular failure that is safe to show to clients, such as
+// which resource was not found. The result still matches err with errors.Is and errors.As.
+func WithDetail(err error, detail string) error {
+	return &detailError{err: err, detail: detail}
+}
+
+// Error implements the error interface.
+func (e *detailError) Error() string {
+	return e.err.Error() + ": " + e.detail
+}
+
+// Unwrap exposes the annotated error.
+func (e *detailError) Unwrap() error {
+	return e.err
+}
+
+// Detail returns the explanation given to WithDetail.
+func (e *detailError) Detail() string {
+	return e.detail
+}
+
+// Describe returns the application error behind err and a client-safe explanation of this occurrence, which is
+// empty when there is nothing to add to the error's message. Errors that are not application errors, such as
+// database failures, are reported as ErrInternal so that their text never reaches clients.
+func Describe(err error) (*Error, string) {
+	var appErr *Error
+	if !errors.As(err, &appErr) {
+		errors.As(ErrInternal, &appErr)
+		return appErr, ""
+	}
+	var d detailed
+	if errors.As(err, &d) {
+		return appErr, d.Detail()
+	}
+	return appErr, ""
+}
 
 var (
 	// ErrUserAlreadyExists is returned from the Register use case when a user with the given username already exists in the system.
-	ErrUserAlreadyExists = errors.New("user already exists")
+	ErrUserAlreadyExists = newError(KindConflict, "user_exists", "user already exists")
 
-	ErrTaskAlreadyExists = errors.New("task already exists")
+	// ErrTaskAlreadyExists is returned when a task conflicts with one that is already stored.
+	ErrTaskAlreadyExists = newError(KindConflict, "task_exists", "task already exists")
 
-	ErrInvalidID = errors.New("invalid ID format")
+	// ErrInvalidID is returned when an ID is not in the format the store uses.
+	ErrInvalidID = newError(KindInvalid, "invalid_id", "invalid ID format")
 
 	// ErrInvalidCredentials is returned from the Login use case when the provided password does not match the stored hash for the user.
-	ErrInvalidCredentials = errors.New("invalid credentials")
+	ErrInvalidCredentials = newError(KindUnauthenticated, "invalid_credentials", "invalid credentials")
 
 	// ErrNotFound is a generic error returned when a requested resource (like a user or a task) cannot be found.
-	ErrNotFound = errors.New("resource not found")
+	ErrNotFound = newError(KindNotFound, "not_found", "resource not found")
+
+	// ErrAccountLocked is returned from the Login use case while an account is temporarily locked after too many failed attempts.
+	ErrAccountLocked = newError(KindTooManyRequests, "account_locked", "account temporarily locked")
+
+	// ErrAccountDisabled is returned when a disabled account logs in or uses a token issued before it was disabled.
+	ErrAccountDisabled = newError(KindForbidden, "account_disabled", "account is disabled")
+
+	// ErrWeakPassword is returned when a new password does not satisfy the password policy.
+	ErrWeakPassword = newError(KindInvalid, "weak_password", "password does not meet the password policy")
+
+	// ErrInvalidResetToken is returned when a password reset token is unknown, expired or already used.
+	ErrInvalidResetToken = newError(KindInvalid, "invalid_reset_token", "invalid or expired reset token")
+
+	// ErrSessionRevoked is returned when a token was issued before the user's sessions were invalidated.
+	ErrSessionRevoked = newError(KindUnauthenticated, "session_revoked", "session has been revoked")
+
+	// ErrInvalidAccessToken is returned when a personal access token is unknown, expired or revoked.
+	ErrInvalidAccessToken = newError(KindUnauthenticated, "invalid_access_token", "invalid or expired access token")
+
+	// ErrInvalidTokenRequest is returned when a personal access token cannot be created as requested.
+	ErrInvalidTokenRequest = newError(KindInvalid, "invalid_token_request", "invalid access token request")
+
+	// ErrForbidden is returned when the caller is authenticated but not allowed to perform the operation.
+	ErrForbidden = newError(KindForbidden, "forbidden", "permission denied")
+
+	// ErrUnknownRole is returned when a role is not defined by the access policy.
+	ErrUnknownRole = newError(KindInvalid, "unknown_role", "unknown role")
+
+	// ErrInvalidShareRequest is returned when a task cannot be shared as requested.
+	ErrInvalidShareRequest = newError(KindInvalid, "invalid_share_request", "invalid share request")
+
+	// ErrShareNotFound is returned when revoking a share the user does not have.
+	ErrShareNotFound = newError(KindNotFound, "share_not_found", "share not found")
+
+	// ErrProjectNotFound is returned when a project does not exist or the caller is not a member of it.
+	ErrProjectNotFound = newError(KindNotFound, "project_not_found", "project not found")
+
+	// ErrInvalidProjectRequest is returned when a project or its membership cannot be changed as requested.
+	ErrInvalidProjectRequest = newError(KindInvalid, "invalid_project_request", "invalid project request")
+
+	// ErrCommentNotFound is returned when a comment does not exist on the requested task.
+	ErrCommentNotFound = newError(KindNotFound, "comment_not_found", "comment not found")
+
+	// ErrInvalidCommentRequest is returned when a comment cannot be posted or edited as requested.
+	ErrInvalidCommentRequest = newError(KindInvalid, "invalid_comment_request", "invalid comment request")
+
+	// ErrAttachmentNotFound is returned when a task has no attachment with the requested ID.
+	ErrAttachmentNotFound = newError(KindNotFound, "attachment_not_found", "attachment not found")
+
+	// ErrAttachmentTooLarge is returned when an upload exceeds the maximum attachment size.
+	ErrAttachmentTooLarge = newError(KindTooLarge, "attachment_too_large", "attachment is too large")
+
+	// ErrAttachmentQuotaExceeded is returned when an upload would take the user over their attachment quota.
+	ErrAttachmentQuotaExceeded = newError(KindTooLarge, "attachment_quota_exceeded", "attachment quota exceeded")
+
+	// ErrInvalidAttachmentRequest is returned when an upload is malformed.
+	ErrInvalidAttachmentRequest = newError(KindInvalid, "invalid_attachment_request", "invalid attachment request")
+
+	// ErrInvalidTaskRequest is returned when a task cannot be created or updated as requested.
+	ErrInvalidTaskRequest = newError(KindInvalid, "invalid_task_request", "invalid task request")
+
+	// ErrIncorrectPassword is returned when a signed-in user confirms an operation with the wrong password.
+	ErrIncorrectPassword = newError(KindForbidden, "incorrect_password", "current password is incorrect")
+
+	// ErrInvalidRequest is returned when a request cannot be decoded or fails validation before reaching a use case.
+	ErrInvalidRequest = newError(KindInvalid, "invalid_request", "invalid request")
+	// ErrRequestTooLarge is returned when a request body exceeds the size the server reads before handling it.
+	ErrRequestTooLarge = newError(KindTooLarge, "request_too_large", "request body is too large")
+
+	// ErrUnauthenticated is returned when a request carries no usable credentials.
+	ErrUnauthenticated = newError(KindUnauthenticated, "unauthenticated", "authentication required")
+
+	// ErrInsufficientScope is returned when a personal access token lacks the scope an endpoint requires.
+	ErrInsufficientScope = newError(KindForbidden, "insufficient_scope", "token is missing a required scope")
+
+	// ErrRateLimited is returned when a client has used up its request allowance.
+	ErrRateLimited = newError(KindTooManyRequests, "rate_limited", "too many requests")
+
+	// ErrUnavailable is returned when the database is failing or unreachable, or while calls to it are refused to let
+	// it recover.
+	ErrUnavailable = newError(KindUnavailable, "service_unavailable", "service temporarily unavailable")
+
+	// ErrInternal stands in for unexpected errors, whose details are not shown to clients.
+	ErrInternal = newError(KindInternal, "internal", "internal server error")
+
+	// ErrOrganizationExists is returned when an organization is created with a slug that is already taken.
+	ErrOrganizationExists = newError(KindConflict, "organization_exists", "organization already exists")
+
+	// ErrOrganizationNotFound is returned when an organization does not exist.
+	ErrOrganizationNotFound = newError(KindNotFound, "organization_not_found", "organization not found")
+
+	// ErrInvalidTimeZone is returned when a time zone is not a known IANA time zone name.
+	ErrInvalidTimeZone = newError(KindInvalid, "invalid_time_zone", "unknown time zone")
+
+	// ErrInvalidOrganizationRequest is returned when an organization cannot be created as requested.
+	ErrInvalidOrganizationRequest = newError(KindInvalid, "invalid_organization_request", "invalid organization request")
+
+	// ErrCommentEditWindowClosed is returned when the author edits a comment after the edit window has passed.
+	ErrCommentEditWindowClosed = newError(KindForbidden, "comment_edit_window_closed", "comment can no longer be edited")
+
+	// ErrInvalidIdempotencyKey is returned when an Idempotency-Key header is too long or contains unsafe characters.
+	ErrInvalidIdempotencyKey = newError(KindInvalid, "invalid_idempotency_key", "invalid idempotency key")
+
+	// ErrIdempotencyKeyReused is returned when an idempotency key is sent again with a different request.
+	ErrIdempotencyKeyReused = newError(KindInvalid, "idempotency_key_reused", "idempotency key was used for a different request")
+
+	// ErrIdempotencyKeyInUse is returned when a request is retried while the first request with its idempotency key is
+	// still being processed.
+	ErrIdempotencyKeyInUse = newError(KindConflict, "idempotency_key_in_use", "a request with this idempotency key is in progress")
+
+	// ErrAccountOwnsProjects is returned when deleting an account that is the last owner of projects other users
+	// belong to; ownership must be handed over first.
+	ErrAccountOwnsProjects = newError(KindConflict, "account_owns_projects", "account is the last owner of shared projects")
+
+	// ErrTimerRunning is returned when starting a timer while another of the user's timers is running.
+	ErrTimerRunning = newError(KindConflict, "timer_running", "another timer is already running")
+
+	// ErrTimerNotRunning is returned when stopping a timer the user is not running on the task.
+	ErrTimerNotRunning = newError(KindConflict, "timer_not_running", "no timer is running on this task")
+
+	// ErrInvalidTimeEntryRequest is returned when time cannot be logged or reported as requested.
+	ErrInvalidTimeEntryRequest = newError(KindInvalid, "invalid_time_entry_request", "invalid time entry request")
 )
+
+// AccountLockedError carries the time at which a locked account becomes usable again. It matches ErrAccountLocked with errors.Is.
+type AccountLockedError struct {
+	Until time.Time
+}
+
+// Error implements the error interface.
+func (e *AccountLockedError) Error() string {
+	return ErrAccountLocked.Error() + " until " + e.Until.UTC().Format(time.RFC3339)
+}
+
+// Unwrap lets errors.Is(err, ErrAccountLocked) match.
+func (e *AccountLockedError) Unwrap() error {
+	return ErrAccountLocked
+}
+
+// Detail returns a client-facing explanation without the unlock time.
+func (e *AccountLockedError) Detail() string {
+	return "account temporarily locked due to too many failed password attempts"
+}
+
+// UnavailableError reports a failing dependency. It matches ErrUnavailable with errors.Is, and the failure that
+// caused it, if any, with errors.Is and errors.As.
+type UnavailableError struct {
+	// RetryAfter is how long clients should wait before trying again; zero when unknown.
+	RetryAfter time.Duration
+	// Err is the last failure of the dependency; nil when the call was refused without being attempted.
+	Err error
+}
+
+// Error implements the error interface.
+func (e *UnavailableError) Error() string {
+	if e.Err == nil {
+		return ErrUnavailable.Error()
+	}
+	return ErrUnavailable.Error() + ": " + e.Err.Error()
+}
+
+// Unwrap lets errors.Is(err, ErrUnavailable) match, as well as the underlying failure.
+func (e *UnavailableError) Unwrap() []error {
+	if e.Err == nil {
+		return []error{ErrUnavailable}
+	}
+	return []error{ErrUnavailable, e.Err}
+}
+
+// Detail returns a client-facing explanation without the underlying failure.
+func (e *UnavailableError) Detail() string {
+	return "the database is temporarily unavailable, please retry later"
+}
+
+// PasswordPolicyError explains why a password was rejected. It matches ErrWeakPassword with errors.Is.
+type PasswordPolicyError struct {
+	Reason string
+}
+
+// Error implements the error interface.
+func (e *PasswordPolicyError) Error() string {
+	return ErrWeakPassword.Error() + ": " + e.Reason
+}
+
+// Unwrap lets errors.Is(err, ErrWeakPassword) match.
+func (e *PasswordPolicyError) Unwrap() error {
+	return ErrWeakPassword
+}
+
+// Detail returns the reason prefixed with "password", e.g. "password must be at least 8 characters long".
+func (e *PasswordPolicyError) Detail() string {
+	return "password " + e.Reason
+}
+
+// TokenRequestError explains why a personal access token request was rejected. It matches ErrInvalidTokenRequest with errors.Is.
+type TokenRequestError struct {
+	Reason string
+}
+
+// Error implements the error interface.
+func (e *TokenRequestError) Error() string {
+	return ErrInvalidTokenRequest.Error() + ": " + e.Reason
+}
+
+// Unwrap lets errors.Is(err, ErrInvalidTokenRequest) match.
+func (e *TokenRequestError) Unwrap() error {
+	return ErrInvalidTokenRequest
+}
+
+// Detail returns the reason, which is safe to show to clients.
+func (e *TokenRequestError) Detail() string {
+	return e.Reason
+}
+
+// ShareRequestError explains why a task share request was rejected. It matches ErrInvalidShareRequest with errors.Is.
+type ShareRequestError struct {
+	Reason string
+}
+
+// Error implements the error interface.
+func (e *ShareRequestError) Error() string {
+	return ErrInvalidShareRequest.Error() + ": " + e.Reason
+}
+
+// Unwrap lets errors.Is(err, ErrInvalidShareRequest) match.
+func (e *ShareRequestError) Unwrap() error {
+	return ErrInvalidShareRequest
+}
+
+// Detail returns the reason, which is safe to show to clients.
+func (e *ShareRequestError) Detail() string {
+	return e.Reason
+}
+
+// ProjectRequestError explains why a project request was rejected. It matches ErrInvalidProjectRequest with errors.Is.
+type ProjectRequestError struct {
+	Reason string
+}
+
+// Error implements the error interface.
+func (e *ProjectRequestError) Error() string {
+	return ErrInvalidProjectRequest.Error() + ": " + e.Reason
+}
+
+// Unwrap lets errors.Is(err, ErrInvalidProjectRequest) match.
+func (e *ProjectRequestError) Unwrap() error {
+	return ErrInvalidProjectRequest
+}
+
+// Detail returns the reason, which is safe to show to clients.
+func (e *ProjectRequestError) Detail() string {
+	return e.Reason
+}
+
+// CommentRequestError explains why a comment request was rejected. It matches ErrInvalidCommentRequest with errors.Is.
+type CommentRequestError struct {
+	Reason string
+}
+
+// Error implements the error interface.
+func (e *CommentRequestError) Error() string {
+	return ErrInvalidCommentRequest.Error() + ": " + e.Reason
+}
+
+// Unwrap lets errors.Is(err, ErrInvalidCommentRequest) match.
+func (e *CommentRequestError) Unwrap() error {
+	return ErrInvalidCommentRequest
+}
+
+// Detail returns the reason, which is safe to show to clients.
+func (e *CommentRequestError) Detail() string {
+	return e.Reason
+}
+
+// AttachmentRequestError explains why an upload was rejected. It matches ErrInvalidAttachmentRequest with errors.Is.
+type AttachmentRequestError struct {
+	Reason string
+}
+
+// Error implements the error interface.
+func (e *AttachmentRequestError) Error() string {
+	return ErrInvalidAttachmentRequest.Error() + ": " + e.Reason
+}
+
+// Unwrap lets errors.Is(err, ErrInvalidAttachmentRequest) match.
+func (e *AttachmentRequestError) Unwrap() error {
+	return ErrInvalidAttachmentRequest
+}
+
+// Detail returns the reason, which is safe to show to clients.
+func (e *AttachmentRequestError) Detail() string {
+	return e.Reason
+}
+
+// TaskRequestError explains why a task request was rejected. It matches ErrInvalidTaskRequest with errors.Is.
+type TaskRequestError struct {
+	Reason string
+}
+
+// Error implements the error interface.
+func (e *TaskRequestError) Error() string {
+	return ErrInvalidTaskRequest.Error() + ": " + e.Reason
+}
+
+// Unwrap lets errors.Is(err, ErrInvalidTaskRequest) match.
+func (e *TaskRequestError) Unwrap() error {
+	return ErrInvalidTaskRequest
+}
+
+// Detail returns the reason, which is safe to show to clients.
+func (e *TaskRequestError) Detail() string {
+	return e.Reason
+}
+
+// OrganizationRequestError explains why an organization request was rejected. It matches
+// ErrInvalidOrganizationRequest with errors.Is.
+type OrganizationRequestError struct {
+	Reason string
+}
+
+// Error implements the error interface.
+func (e *OrganizationRequestError) Error() string {
+	return ErrInvalidOrganizationRequest.Error() + ": " + e.Reason
+}
+
+// Unwrap lets errors.Is(err, ErrInvalidOrganizationRequest) match.
+func (e *OrganizationRequestError) Unwrap() error {
+	return ErrInvalidOrganizationRequest
+}
+
+// Detail returns the reason, which is safe to show to clients.
+func (e *OrganizationRequestError) Detail() string {
+	return e.Reason
+}
+
+// TimeEntryRequestError explains why a time entry or timesheet request was rejected. It matches
+// ErrInvalidTimeEntryRequest with errors.Is.
+type TimeEntryRequestError struct {
+	Reason string
+}
+
+// Error implements the error interface.
+func (e *TimeEntryRequestError) Error() string {
+	return ErrInvalidTimeEntryRequest.Error() + ": " + e.Reason
+}
+
+// Unwrap lets errors.Is(err, ErrInvalidTimeEntryRequest) match.
+func (e *TimeEntryRequestError) Unwrap() error {
+	return ErrInvalidTimeEntryRequest
+}
+
+// Detail returns the reason, which is safe to show to clients.
+func (e *TimeEntryRequestError) Detail() string {
+	return e.Reason
+}
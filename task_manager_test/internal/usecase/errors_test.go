@@ -0,0 +1,56 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestDescribe tests how errors are classified and which explanations are considered safe for clients.
+func TestDescribe(t *testing.T) {
+	cases := []struct {
+		name       string
+		err        error
+		wantCode   string
+		wantDetail string
+	}{
+		{"sentinel", ErrForbidden, "forbidden", ""},
+		{"wrapped sentinel", fmt.Errorf("loading task: %w", ErrNotFound), "not_found", ""},
+		{"with detail", WithDetail(ErrNotFound, "task not found"), "not_found", "task not found"},
+		{"request error", &ShareRequestError{Reason: "unknown user bob"}, "invalid_share_request", "unknown user bob"},
+		{"password policy", &PasswordPolicyError{Reason: "must be at least 8 characters"}, "weak_password", "password must be at least 8 characters"},
+		{"foreign error", errors.New("dial tcp 10.0.0.7:27017: connection refused"), "internal", ""},
+		{"detail on foreign error", WithDetail(errors.New("boom"), "could not save"), "internal", ""},
+		{"unavailable", &UnavailableError{Err: errors.New("connection refused")}, "service_unavailable", "the database is temporarily unavailable, please retry later"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			appErr, detail := Describe(tc.err)
+
+			assert.Equal(t, tc.wantCode, appErr.Code)
+			assert.Equal(t, tc.wantDetail, detail)
+		})
+	}
+}
+
+// TestWithDetail_KeepsIdentity tests that annotated errors still match their sentinel and request error types.
+func TestWithDetail_KeepsIdentity(t *testing.T) {
+	err := WithDetail(&TaskRequestError{Reason: "title cannot be empty"}, "ignored")
+
+	var reqErr *TaskRequestError
+	assert.ErrorAs(t, err, &reqErr)
+	assert.ErrorIs(t, err, ErrInvalidTaskRequest)
+}
+
+// TestUnavailableError_KeepsCause tests that a failing dependency is reported as unavailable without losing its cause.
+func TestUnavailableError_KeepsCause(t *testing.T) {
+	cause := errors.New("connection refused")
+	err := fmt.Errorf("loading task: %w", &UnavailableError{Err: cause})
+
+	assert.ErrorIs(t, err, ErrUnavailable)
+	assert.ErrorIs(t, err, cause)
+	assert.EqualError(t, err, "loading task: service temporarily unavailable: connection refused")
+	assert.ErrorIs(t, &UnavailableError{}, ErrUnavailable)
+}
@@ -2,20 +2,217 @@ package usecase
 
 import (
 	"context"
+	"io"
 	"task_manager_test/internal/domain"
+	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 )
 
-// IUserRepository defines domain-centric user methods for creating and finding users.
+// IUserRepository defines domain-centric user methods for creating and finding users and tracking failed logins.
+// Like the other repositories of tenant data, it confines every call to the tenant of the context (see
+// TenantFromContext) and fails with ErrNoTenant when there is none.
 type IUserRepository interface {
 	Create(ctx context.Context, u domain.User) (domain.User, error)
 	FindByUsername(ctx context.Context, username string) (domain.User, error)
+	FindByID(ctx context.Context, id string) (domain.User, error)
+	// UpdatePassword stores a new password hash, increments the token version to revoke existing sessions, and clears any lockout.
+	UpdatePassword(ctx context.Context, id, hashedPassword string) error
+	// UpdateRole assigns a new role and increments the token version so tokens carrying the old role are revoked.
+	UpdateRole(ctx context.Context, username string, role domain.Role) error
+	// IncrementFailedLogins atomically increments the user's consecutive failed login counter and returns the new value.
+	IncrementFailedLogins(ctx context.Context, username string) (int, error)
+	// Lock refuses logins for the user until the given time and resets the failed login counter.
+	Lock(ctx context.Context, username string, until time.Time) error
+	// ResetFailedLogins clears the failed login counter and any lock after a successful login.
+	ResetFailedLogins(ctx context.Context, username string) error
+	// List returns every user of the tenant, ordered by username.
+	List(ctx context.Context) ([]domain.User, error)
+	// SetDisabled disables or re-enables the user. Disabling also increments the token version to revoke existing sessions.
+	SetDisabled(ctx context.Context, username string, disabled bool) error
+	// SetTimeZone stores the IANA name of the user's time zone.
+	SetTimeZone(ctx context.Context, id, name string) error
+	// ReserveAttachmentSpace atomically counts up to maxSize bytes of the user's attachment quota as used and returns
+	// the number reserved, which is less than maxSize when less remains. It returns ErrAttachmentQuotaExceeded if
+	// nothing remains.
+	ReserveAttachmentSpace(ctx context.Context, userID string, maxSize, quota int64) (int64, error)
+	// ReleaseAttachmentSpace returns size bytes to the user's attachment quota. Releasing space of a user who no longer
+	// exists is not an error.
+	ReleaseAttachmentSpace(ctx context.Context, userID string, size int64) error
+}
+
+// IOrganizationRepository stores organizations. Organizations are not tenant data, so it ignores the tenant scope.
+type IOrganizationRepository interface {
+	// Create stores a new organization, returning ErrOrganizationExists if its slug is taken.
+	Create(ctx context.Context, org domain.Organization) (domain.Organization, error)
+	GetByID(ctx context.Context, id string) (domain.Organization, error)
+	FindBySlug(ctx context.Context, slug string) (domain.Organization, error)
+	// List returns every organization, ordered by slug.
+	List(ctx context.Context) ([]domain.Organization, error)
+	// Delete removes an organization; it is used to roll back one whose creation failed half-way.
+	Delete(ctx context.Context, id string) error
+}
+
+// IPasswordResetRepository stores hashed, single-use password reset tokens.
+type IPasswordResetRepository interface {
+	Create(ctx context.Context, r domain.PasswordReset) (domain.PasswordReset, error)
+	// Consume atomically marks the unused, unexpired reset with the given token hash as used and returns it.
+	Consume(ctx context.Context, tokenHash string, now time.Time) (domain.PasswordReset, error)
+	// DeleteByUser removes every outstanding reset for the user.
+	DeleteByUser(ctx context.Context, userID string) error
+}
+
+// IIdempotencyRepository stores the requests made with an idempotency key and their responses, per user.
+type IIdempotencyRepository interface {
+	// Reserve atomically records the incomplete request unless the user's key is held by a record that has not
+	// expired. It returns the record holding the key and whether it is the one just created.
+	Reserve(ctx context.Context, r domain.IdempotencyRecord, now time.Time) (domain.IdempotencyRecord, bool, error)
+	// Complete stores the response of the reserved request and extends the record's lifetime to r.ExpiresAt. The
+	// reservation is identified by its user, key, request hash and creation time, so that a request whose reservation
+	// expired cannot complete the one that replaced it. It returns ErrNotFound if the reservation is gone.
+	Complete(ctx context.Context, r domain.IdempotencyRecord) error
+	// Renew extends the lifetime of the incomplete reservation to r.ExpiresAt while its request is still being
+	// handled. It returns ErrNotFound if the reservation is gone.
+	Renew(ctx context.Context, r domain.IdempotencyRecord) error
+	// Release deletes the incomplete reservation, so that the request can be retried. Reservations made since by other
+	// requests with the same key are left alone.
+	Release(ctx context.Context, r domain.IdempotencyRecord) error
+}
+
+// IAuditRepository stores the audit log. Entries carry their organization and must outlive the users they
+// mention, so it ignores the tenant scope.
+type IAuditRepository interface {
+	Record(ctx context.Context, e domain.AuditEntry) (domain.AuditEntry, error)
+	// ListByUser returns the entries whose actor or target is the user, oldest first.
+	ListByUser(ctx context.Context, userID string) ([]domain.AuditEntry, error)
+}
+
+// IAccountRepository removes accounts together with the data they own.
+type IAccountRepository interface {
+	// DeleteAccount deletes, in a single transaction, the user and its personal tasks with their comments and shares,
+	// the comments it wrote with their replies, the shares granted to it, the time logged by it or against those tasks,
+	// its access tokens, password resets and idempotency keys, and the projects it is the only member of with its tasks
+	// in them, detaching their remaining tasks. The user is removed from the other projects, where the tasks it owns
+	// stay without an owner, and the audit entry is recorded. It returns
+	// the attachments of the deleted tasks, whose content the caller must release, or ErrNotFound if the user does not
+	// exist.
+	DeleteAccount(ctx context.Context, userID string, entry domain.AuditEntry) ([]domain.Attachment, error)
+}
+
+// ITimeEntryRepository stores the time users log against tasks. A user has at most one running timer.
+type ITimeEntryRepository interface {
+	// Start stores the entry as a running timer, returning ErrTimerRunning if the user already has one.
+	Start(ctx context.Context, e domain.TimeEntry) (domain.TimeEntry, error)
+	// Stop ends the user's running timer on the task at the given time and returns it, or ErrNotFound if the user
+	// has no timer running on the task.
+	Stop(ctx context.Context, userID, taskID string, end time.Time) (domain.TimeEntry, error)
+	// FindRunning returns the user's running timer, or ErrNotFound.
+	FindRunning(ctx context.Context, userID string) (domain.TimeEntry, error)
+	// Create stores a finished entry.
+	Create(ctx context.Context, e domain.TimeEntry) (domain.TimeEntry, error)
+	// ListByTask returns every entry logged against the task, ordered by start time.
+	ListByTask(ctx context.Context, taskID string) ([]domain.TimeEntry, error)
+	// ListByUser returns the user's entries that overlap [from, to), including running timers started before to,
+	// ordered by start time.
+	ListByUser(ctx context.Context, userID string, from, to time.Time) ([]domain.TimeEntry, error)
+	// DeleteByTasks removes every entry logged against the given tasks, including running timers.
+	DeleteByTasks(ctx context.Context, taskIDs []string) error
+}
+
+// IAccessTokenRepository stores hashed personal access tokens.
+type IAccessTokenRepository interface {
+	Create(ctx context.Context, t domain.AccessToken) (domain.AccessToken, error)
+	// ListByUser returns every token of the user, including revoked and expired ones, newest first.
+	ListByUser(ctx context.Context, userID string) ([]domain.AccessToken, error)
+	FindByHash(ctx context.Context, tokenHash string) (domain.AccessToken, error)
+	// Revoke marks an active token owned by the user as revoked, returning ErrNotFound if there is none.
+	Revoke(ctx context.Context, userID, id string, now time.Time) error
+	TouchLastUsed(ctx context.Context, id string, now time.Time) error
+}
+
+// ITaskShareRepository stores the users a task has been shared with. A user has at most one share per task.
+type ITaskShareRepository interface {
+	// Upsert creates the share, or updates the access level of an existing share for the same task and user.
+	Upsert(ctx context.Context, s domain.TaskShare) (domain.TaskShare, error)
+	// Find returns the share of the task with the user, or ErrNotFound.
+	Find(ctx context.Context, taskID, userID string) (domain.TaskShare, error)
+	ListByTask(ctx context.Context, taskID string) ([]domain.TaskShare, error)
+	ListByUser(ctx context.Context, userID string) ([]domain.TaskShare, error)
+	// Delete removes the share of the task with the user, returning ErrNotFound if there is none.
+	Delete(ctx context.Context, taskID, userID string) error
+	// DeleteByTask removes every share of the task.
+	DeleteByTask(ctx context.Context, taskID string) error
+}
+
+// IBlobStore stores file contents addressed by the hex-encoded SHA-256 of the content.
+type IBlobStore interface {
+	// Put stores the content read from r and returns its digest and size. It returns ErrAttachmentTooLarge as soon
+	// as more than maxSize bytes are read. Storing content that is already present keeps the existing copy. Stored
+	// content is held, and Delete leaves it in place, until Release is called with its digest, so that it cannot be
+	// removed before the caller has recorded a reference to it.
+	Put(ctx context.Context, r io.Reader, maxSize int64) (digest string, size int64, err error)
+	// Release ends a hold that Put placed on the content with the given digest.
+	Release(digest string)
+	// Open returns the content with the given digest, or ErrNotFound.
+	Open(ctx context.Context, digest string) (io.ReadCloser, error)
+	// Delete removes the content with the given digest unless it is held. Deleting missing content is not an error.
+	Delete(ctx context.Context, digest string) error
+}
+
+// ICommentRepository stores task comments and their replies.
+type ICommentRepository interface {
+	Create(ctx context.Context, c domain.Comment) (domain.Comment, error)
+	GetByID(ctx context.Context, id string) (domain.Comment, error)
+	// ListTopLevel returns a page of the task's top-level comments, oldest first, and the number of top-level comments.
+	ListTopLevel(ctx context.Context, taskID string, skip, limit int) ([]domain.Comment, int64, error)
+	// ListReplies returns the replies to the given comments, oldest first.
+	ListReplies(ctx context.Context, parentIDs []string) ([]domain.Comment, error)
+	// ListByAuthor returns every comment written by the user, oldest first.
+	ListByAuthor(ctx context.Context, authorID string) ([]domain.Comment, error)
+	// Update stores a new body, mentions and edit time for the comment.
+	Update(ctx context.Context, c domain.Comment) (domain.Comment, error)
+	// Delete removes the comment and its replies, returning ErrNotFound if the comment does not exist.
+	Delete(ctx context.Context, id string) error
+	// DeleteByTasks removes every comment on the given tasks.
+	DeleteByTasks(ctx context.Context, taskIDs []string) error
+}
+
+// IProjectRepository stores projects together with their members.
+type IProjectRepository interface {
+	Create(ctx context.Context, p domain.Project) (domain.Project, error)
+	GetByID(ctx context.Context, id string) (domain.Project, error)
+	// ListByMember returns the projects the user is a member of, ordered by name.
+	ListByMember(ctx context.Context, userID string) ([]domain.Project, error)
+	// Update changes the name and description of the project and returns the stored project.
+	Update(ctx context.Context, p domain.Project) (domain.Project, error)
+	Delete(ctx context.Context, id string) error
+	// SetMember adds the member to the project, or changes the role of an existing member, and returns the stored project.
+	SetMember(ctx context.Context, projectID string, m domain.ProjectMember) (domain.Project, error)
+	// RemoveMember removes the user from the project, returning ErrNotFound if the user is not a member.
+	RemoveMember(ctx context.Context, projectID, userID string) error
 }
 
 // ITaskRepository defines CRUD operations for domain.Task.
 type ITaskRepository interface {
-	GetAll(ctx context.Context) ([]domain.Task, error)
+	// Find returns the tasks selected by the query, oldest first.
+	Find(ctx context.Context, q domain.TaskQuery) ([]domain.Task, error)
+	// GetByOwner returns the tasks owned by the given user.
+	GetByOwner(ctx context.Context, ownerID string) ([]domain.Task, error)
+	// GetByIDs returns the tasks with the given IDs, silently skipping IDs that do not exist.
+	GetByIDs(ctx context.Context, ids []string) ([]domain.Task, error)
+	// GetByProject returns the tasks belonging to the project.
+	GetByProject(ctx context.Context, projectID string) ([]domain.Task, error)
+	// DeleteByProject deletes every task belonging to the project.
+	DeleteByProject(ctx context.Context, projectID string) error
+	// ArchiveByProject detaches every task from the project and marks it archived at the given time.
+	ArchiveByProject(ctx context.Context, projectID string, at time.Time) error
+	// AddAttachment appends the attachment to the task, assigning it an ID.
+	AddAttachment(ctx context.Context, taskID string, a domain.Attachment) (domain.Attachment, error)
+	// RemoveAttachment removes the attachment from the task, returning ErrNotFound if the task has no such attachment.
+	RemoveAttachment(ctx context.Context, taskID, attachmentID string) error
+	// CountAttachmentsByDigest returns the number of tasks with an attachment of the given content in any organization,
+	// since blobs are shared by all of them.
+	CountAttachmentsByDigest(ctx context.Context, digest string) (int64, error)
 	GetByID(ctx context.Context, id string) (domain.Task, error)
 	Create(ctx context.Context, t domain.Task) (domain.Task, error)
 	Update(ctx context.Context, t domain.Task) (domain.Task, error)
@@ -24,8 +221,15 @@ type ITaskRepository interface {
 
 // IJWTService defines methods for generating and validating JWT tokens.
 type IJWTService interface {
-	GenerateToken(username, role string) (string, error)
+	GenerateToken(u domain.User) (string, error)
 	ValidateToken(tokenStr string) (jwt.MapClaims, error)
+	// PublicKeys returns the public keys that verify issued tokens, for publication as a JWKS.
+	PublicKeys() []domain.JSONWebKey
+}
+
+// IAccessTokenAuthenticator resolves a personal access token to the token record and the user it acts as.
+type IAccessTokenAuthenticator interface {
+	Authenticate(ctx context.Context, token string) (domain.AccessToken, domain.User, error)
 }
 
 // IPasswordService defines methods for hashing and verifying passwords.
@@ -33,3 +237,28 @@ type IPasswordService interface {
 	Hash(password string) (string, error)
 	Compare(hashed, plain string) bool
 }
+
+// IResetTokenSender delivers a password reset token to the user, e.g. by email.
+type IResetTokenSender interface {
+	SendPasswordReset(ctx context.Context, u domain.User, token string, expiresAt time.Time) error
+}
+
+// ISessionValidator checks that an authenticated session has not been revoked since its token was issued. Sessions
+// are identified by the user's ID, the token's "sub" claim.
+type ISessionValidator interface {
+	ValidateSession(ctx context.Context, userID string, tokenVersion int) error
+}
+
+// CacheStats counts the lookups answered by an in-process cache since it was created.
+type CacheStats struct {
+	Hits      uint64
+	Misses    uint64
+	Evictions uint64
+	Entries   int
+	Capacity  int
+}
+
+// ICacheStatsReporter reports the statistics of a cache, for operators tuning its size and TTL.
+type ICacheStatsReporter interface {
+	Stats() CacheStats
+}
@@ -0,0 +1,59 @@
+package usecase
+
+import (
+	"context"
+	"task_manager_test/internal/domain"
+	"time"
+)
+
+// LockoutPolicy controls temporary account locking after consecutive failed password attempts.
+// A MaxAttempts of zero disables locking.
+type LockoutPolicy struct {
+	MaxAttempts int
+	Duration    time.Duration
+}
+
+// lockoutGuard checks passwords under the LockoutPolicy. Every operation that asks for a user's password goes
+// through it, so guesses made through any of them count towards the same lock.
+type lockoutGuard struct {
+	users  IUserRepository
+	pwd    IPasswordService
+	policy LockoutPolicy
+}
+
+// check compares the password with the user's. While the account is locked it returns an AccountLockedError,
+// without counting the attempt or extending the lock. A wrong password returns ErrIncorrectPassword and counts as a
+// failed attempt, locking the account once the threshold is reached; a right one clears the failed attempts.
+// The password is compared even on a locked account, so that both take as long.
+func (g lockoutGuard) check(ctx context.Context, usr domain.User, password string, now time.Time) error {
+	matches := g.pwd.Compare(usr.Password, password)
+	if usr.LockedUntil.After(now) {
+		return &AccountLockedError{Until: usr.LockedUntil}
+	}
+	if !matches {
+		return g.recordFailure(ctx, usr.Username, now)
+	}
+	if usr.FailedLoginAttempts > 0 || !usr.LockedUntil.IsZero() {
+		return g.users.ResetFailedLogins(ctx, usr.Username)
+	}
+	return nil
+}
+
+// recordFailure counts a failed attempt and locks the account once the policy threshold is reached. It returns
+// ErrIncorrectPassword unless the repository fails.
+func (g lockoutGuard) recordFailure(ctx context.Context, username string, now time.Time) error {
+	if g.policy.MaxAttempts <= 0 {
+		return ErrIncorrectPassword
+	}
+	attempts, err := g.users.IncrementFailedLogins(ctx, username)
+	if err != nil {
+		return err
+	}
+	if attempts < g.policy.MaxAttempts {
+		return ErrIncorrectPassword
+	}
+	if err := g.users.Lock(ctx, username, now.Add(g.policy.Duration)); err != nil {
+		return err
+	}
+	return ErrIncorrectPassword
+}
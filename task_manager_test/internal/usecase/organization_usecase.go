@@ -0,0 +1,92 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strings"
+	"task_manager_test/internal/domain"
+	"time"
+)
+
+// OrganizationUsecase defines the operations on organizations, the tenants that partition users and their data.
+type OrganizationUsecase interface {
+	// Create stores a new organization and registers its first administrator with the admin role. Only super admins
+	// may create organizations.
+	Create(ctx context.Context, org domain.Organization, admin domain.User) (domain.Organization, error)
+	// List returns every organization, ordered by slug. Only super admins may list them.
+	List(ctx context.Context) ([]domain.Organization, error)
+	// FindBySlug returns the organization with the slug. Only super admins may look up other organizations.
+	FindBySlug(ctx context.Context, slug string) (domain.Organization, error)
+	// Current returns the actor's organization.
+	Current(ctx context.Context) (domain.Organization, error)
+}
+
+// slugPattern is the form of an organization slug: lowercase letters, digits and inner hyphens.
+var slugPattern = regexp.MustCompile(`^[a-z0-9](?:[a-z0-9-]{0,61}[a-z0-9])?$`)
+
+// organizationUsecase implements OrganizationUsecase.
+type organizationUsecase struct {
+	repo  IOrganizationRepository
+	users UserUsecase
+	now   func() time.Time
+}
+
+// NewOrganizationUsecase constructs an OrganizationUsecase. Administrators are registered through users, so they are
+// subject to the same password policy as everyone else.
+func NewOrganizationUsecase(repo IOrganizationRepository, users UserUsecase) OrganizationUsecase {
+	return &organizationUsecase{repo: repo, users: users, now: time.Now}
+}
+
+// Create validates the name and slug, stores the organization and registers the administrator in it. If the
+// administrator cannot be registered, the organization is removed again so that the request can be retried.
+func (u *organizationUsecase) Create(ctx context.Context, org domain.Organization, admin domain.User) (domain.Organization, error) {
+	if !isSuperAdmin(ctx) {
+		return domain.Organization{}, ErrForbidden
+	}
+	org.Name = strings.TrimSpace(org.Name)
+	if org.Name == "" {
+		return domain.Organization{}, &OrganizationRequestError{Reason: "name is required"}
+	}
+	if !slugPattern.MatchString(org.Slug) {
+		return domain.Organization{}, &OrganizationRequestError{Reason: "slug must be 1-63 lowercase letters, digits or inner hyphens"}
+	}
+	org.CreatedAt = u.now()
+	created, err := u.repo.Create(ctx, org)
+	if err != nil {
+		return domain.Organization{}, err
+	}
+	admin.Role = domain.RoleAdmin
+	if err := u.users.Register(WithTenant(ctx, created.ID), admin); err != nil {
+		if delErr := u.repo.Delete(context.WithoutCancel(ctx), created.ID); delErr != nil {
+			return domain.Organization{}, errors.Join(err, delErr)
+		}
+		return domain.Organization{}, err
+	}
+	return created, nil
+}
+
+// List returns every organization.
+func (u *organizationUsecase) List(ctx context.Context) ([]domain.Organization, error) {
+	if !isSuperAdmin(ctx) {
+		return nil, ErrForbidden
+	}
+	return u.repo.List(ctx)
+}
+
+// FindBySlug returns the organization with the slug.
+func (u *organizationUsecase) FindBySlug(ctx context.Context, slug string) (domain.Organization, error) {
+	if !isSuperAdmin(ctx) {
+		return domain.Organization{}, ErrForbidden
+	}
+	return u.repo.FindBySlug(ctx, slug)
+}
+
+// Current returns the organization of the actor.
+func (u *organizationUsecase) Current(ctx context.Context) (domain.Organization, error) {
+	actor, ok := ActorFromContext(ctx)
+	if !ok || actor.OrgID == "" {
+		return domain.Organization{}, ErrForbidden
+	}
+	return u.repo.GetByID(ctx, actor.OrgID)
+}
@@ -0,0 +1,140 @@
+package usecase
+
+import (
+	"context"
+	"task_manager_test/internal/domain"
+	"task_manager_test/internal/mocks"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+// OrganizationUsecaseTestSuite defines the test suite for the organization use case.
+type OrganizationUsecaseTestSuite struct {
+	suite.Suite
+	mockOrgRepo *mocks.IOrganizationRepository
+	mockUsers   *mocks.UserUsecase
+	usecase     OrganizationUsecase
+	now         time.Time
+	super       context.Context
+	admin       context.Context
+}
+
+// SetupTest runs before EACH test in the suite.
+func (s *OrganizationUsecaseTestSuite) SetupTest() {
+	s.mockOrgRepo = mocks.NewIOrganizationRepository(s.T())
+	s.mockUsers = mocks.NewUserUsecase(s.T())
+	s.usecase = NewOrganizationUsecase(s.mockOrgRepo, s.mockUsers)
+
+	s.now = time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	s.usecase.(*organizationUsecase).now = func() time.Time { return s.now }
+	s.super = WithActor(context.Background(), Actor{UserID: "root-1", Username: "root", Role: domain.RoleSuperAdmin, OrgID: "org-default"})
+	s.admin = WithActor(context.Background(), Actor{UserID: "admin-1", Username: "ada", Role: domain.RoleAdmin, OrgID: "org-1"})
+}
+
+// TestOrganizationUsecaseTestSuite is the Go test runner's entry point for this suite.
+func TestOrganizationUsecaseTestSuite(t *testing.T) {
+	suite.Run(t, new(OrganizationUsecaseTestSuite))
+}
+
+// --- Test Cases for the Create Method ---
+
+// TestCreate_Success tests that the organization is stored and its administrator registered inside it.
+func (s *OrganizationUsecaseTestSuite) TestCreate_Success() {
+	// ARRANGE
+	org := domain.Organization{Name: "Acme", Slug: "acme", CreatedAt: s.now}
+	s.mockOrgRepo.On("Create", s.super, org).Return(domain.Organization{ID: "org-2", Name: "Acme", Slug: "acme", CreatedAt: s.now}, nil)
+	s.mockUsers.On("Register", inTenant("org-2"), domain.User{Username: "wile", Password: "a-long-passphrase", Role: domain.RoleAdmin}).Return(nil)
+
+	// ACT
+	created, err := s.usecase.Create(s.super, domain.Organization{Name: "  Acme ", Slug: "acme"},
+		domain.User{Username: "wile", Password: "a-long-passphrase", Role: domain.RoleSuperAdmin})
+
+	// ASSERT
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), "org-2", created.ID)
+}
+
+// TestCreate_Fails_When_ActorIsNotSuperAdmin tests that organization admins cannot create organizations.
+func (s *OrganizationUsecaseTestSuite) TestCreate_Fails_When_ActorIsNotSuperAdmin() {
+	_, err := s.usecase.Create(s.admin, domain.Organization{Name: "Acme", Slug: "acme"}, domain.User{Username: "wile"})
+
+	assert.ErrorIs(s.T(), err, ErrForbidden)
+}
+
+// TestCreate_Fails_When_RequestIsInvalid tests that names and slugs are validated before anything is stored.
+func (s *OrganizationUsecaseTestSuite) TestCreate_Fails_When_RequestIsInvalid() {
+	for name, org := range map[string]domain.Organization{
+		"missing name":     {Name: " ", Slug: "acme"},
+		"uppercase slug":   {Name: "Acme", Slug: "Acme"},
+		"trailing hyphen":  {Name: "Acme", Slug: "acme-"},
+		"empty slug":       {Name: "Acme"},
+		"slug with spaces": {Name: "Acme", Slug: "ac me"},
+	} {
+		_, err := s.usecase.Create(s.super, org, domain.User{Username: "wile"})
+
+		assert.ErrorIs(s.T(), err, ErrInvalidOrganizationRequest, name)
+	}
+}
+
+// TestCreate_RemovesOrganization_When_AdminCannotBeRegistered tests that a failed registration leaves no orphan.
+func (s *OrganizationUsecaseTestSuite) TestCreate_RemovesOrganization_When_AdminCannotBeRegistered() {
+	s.mockOrgRepo.On("Create", s.super, mock.Anything).Return(domain.Organization{ID: "org-2", Slug: "acme"}, nil)
+	s.mockUsers.On("Register", inTenant("org-2"), mock.Anything).Return(ErrWeakPassword)
+	s.mockOrgRepo.On("Delete", mock.Anything, "org-2").Return(nil)
+
+	_, err := s.usecase.Create(s.super, domain.Organization{Name: "Acme", Slug: "acme"}, domain.User{Username: "wile", Password: "short"})
+
+	assert.ErrorIs(s.T(), err, ErrWeakPassword)
+}
+
+// TestCreate_Fails_When_SlugIsTaken tests that repository conflicts are passed through.
+func (s *OrganizationUsecaseTestSuite) TestCreate_Fails_When_SlugIsTaken() {
+	s.mockOrgRepo.On("Create", s.super, mock.Anything).Return(domain.Organization{}, ErrOrganizationExists)
+
+	_, err := s.usecase.Create(s.super, domain.Organization{Name: "Acme", Slug: "acme"}, domain.User{Username: "wile"})
+
+	assert.ErrorIs(s.T(), err, ErrOrganizationExists)
+	s.mockUsers.AssertNotCalled(s.T(), "Register", mock.Anything, mock.Anything)
+}
+
+// --- Test Cases for the List, FindBySlug and Current Methods ---
+
+// TestList_RequiresSuperAdmin tests that only super admins see every organization.
+func (s *OrganizationUsecaseTestSuite) TestList_RequiresSuperAdmin() {
+	s.mockOrgRepo.On("List", s.super).Return([]domain.Organization{{ID: "org-1", Slug: "acme"}}, nil)
+
+	orgs, err := s.usecase.List(s.super)
+	assert.NoError(s.T(), err)
+	assert.Len(s.T(), orgs, 1)
+
+	_, err = s.usecase.List(s.admin)
+	assert.ErrorIs(s.T(), err, ErrForbidden)
+}
+
+// TestFindBySlug_RequiresSuperAdmin tests that organization admins cannot look up other organizations.
+func (s *OrganizationUsecaseTestSuite) TestFindBySlug_RequiresSuperAdmin() {
+	s.mockOrgRepo.On("FindBySlug", s.super, "acme").Return(domain.Organization{ID: "org-1", Slug: "acme"}, nil)
+
+	org, err := s.usecase.FindBySlug(s.super, "acme")
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), "org-1", org.ID)
+
+	_, err = s.usecase.FindBySlug(s.admin, "acme")
+	assert.ErrorIs(s.T(), err, ErrForbidden)
+}
+
+// TestCurrent tests that the actor's own organization is returned.
+func (s *OrganizationUsecaseTestSuite) TestCurrent() {
+	s.mockOrgRepo.On("GetByID", s.admin, "org-1").Return(domain.Organization{ID: "org-1", Slug: "acme"}, nil)
+
+	org, err := s.usecase.Current(s.admin)
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), "acme", org.Slug)
+
+	_, err = s.usecase.Current(context.Background())
+	assert.ErrorIs(s.T(), err, ErrForbidden)
+}
@@ -0,0 +1,43 @@
+package usecase
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// bcryptMaxBytes is the longest input bcrypt accepts; longer passwords would be silently truncated or rejected.
+const bcryptMaxBytes = 72
+
+// PasswordPolicy describes the rules every new password must satisfy.
+type PasswordPolicy struct {
+	MinLength int
+	// Denylist holds lower-cased passwords known from breaches that must never be accepted.
+	Denylist map[string]struct{}
+}
+
+// NewPasswordPolicy builds a policy from a minimum length and a list of breached passwords.
+func NewPasswordPolicy(minLength int, denylist []string) PasswordPolicy {
+	set := make(map[string]struct{}, len(denylist))
+	for _, p := range denylist {
+		set[strings.ToLower(p)] = struct{}{}
+	}
+	return PasswordPolicy{MinLength: minLength, Denylist: set}
+}
+
+// Validate returns a *PasswordPolicyError describing the first rule the password breaks, or nil.
+func (p PasswordPolicy) Validate(username, password string) error {
+	if n := utf8.RuneCountInString(password); n < p.MinLength {
+		return &PasswordPolicyError{Reason: fmt.Sprintf("must be at least %d characters long", p.MinLength)}
+	}
+	if len(password) > bcryptMaxBytes {
+		return &PasswordPolicyError{Reason: fmt.Sprintf("must be at most %d bytes long", bcryptMaxBytes)}
+	}
+	if username != "" && strings.EqualFold(password, username) {
+		return &PasswordPolicyError{Reason: "must not be the same as the username"}
+	}
+	if _, breached := p.Denylist[strings.ToLower(password)]; breached {
+		return &PasswordPolicyError{Reason: "appears in a list of breached passwords"}
+	}
+	return nil
+}
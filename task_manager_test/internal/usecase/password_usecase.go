@@ -0,0 +1,170 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync"
+	"task_manager_test/internal/domain"
+	"time"
+)
+
+// resetRequestTimeout bounds the background work of a single password reset request.
+const resetRequestTimeout = 30 * time.Second
+
+// PasswordUsecase defines the password change and self-service reset operations.
+type PasswordUsecase interface {
+	// ChangePassword verifies the current password, stores the new one, revokes every existing session and
+	// returns a fresh token so the caller stays signed in.
+	ChangePassword(ctx context.Context, username, currentPassword, newPassword string) (string, error)
+	// RequestReset issues a reset token for the user and delivers it through the configured sender. The work is
+	// done in the background, and unknown usernames are ignored, so callers cannot probe which accounts exist.
+	RequestReset(ctx context.Context, username string) error
+	// Wait blocks until the reset requests in progress have finished, or until ctx is done.
+	Wait(ctx context.Context) error
+	// ResetPassword consumes a reset token and sets a new password, revoking every existing session.
+	ResetPassword(ctx context.Context, token, newPassword string) error
+}
+
+// passwordUsecase is the concrete implementation of PasswordUsecase.
+type passwordUsecase struct {
+	users      IUserRepository
+	resets     IPasswordResetRepository
+	pwdService IPasswordService
+	jwtService IJWTService
+	sender     IResetTokenSender
+	policy     PasswordPolicy
+	lockout    lockoutGuard
+	resetTTL   time.Duration
+	now        func() time.Time
+	pending    sync.WaitGroup
+}
+
+// NewPasswordUsecase creates a new instance of passwordUsecase with dependencies injected.
+func NewPasswordUsecase(users IUserRepository, resets IPasswordResetRepository, pwd IPasswordService, jwtSvc IJWTService, sender IResetTokenSender, policy PasswordPolicy, lockout LockoutPolicy, resetTTL time.Duration) PasswordUsecase {
+	return &passwordUsecase{
+		users:      users,
+		resets:     resets,
+		pwdService: pwd,
+		jwtService: jwtSvc,
+		sender:     sender,
+		policy:     policy,
+		lockout:    lockoutGuard{users: users, pwd: pwd, policy: lockout},
+		resetTTL:   resetTTL,
+		now:        time.Now,
+	}
+}
+
+// ChangePassword replaces the password of an authenticated user. Wrong current passwords count towards the same
+// lockout as failed logins.
+func (u *passwordUsecase) ChangePassword(ctx context.Context, username, currentPassword, newPassword string) (string, error) {
+	usr, err := u.users.FindByUsername(ctx, username)
+	if err != nil {
+		return "", err
+	}
+	if err := u.lockout.check(ctx, usr, currentPassword, u.now()); err != nil {
+		return "", err
+	}
+	if err := u.policy.Validate(usr.Username, newPassword); err != nil {
+		return "", err
+	}
+	if err := u.setPassword(ctx, usr.ID, newPassword); err != nil {
+		return "", err
+	}
+	usr.TokenVersion++
+	return u.jwtService.GenerateToken(usr)
+}
+
+// RequestReset issues the token in the background and returns at once, so that answering takes as long whether the
+// account exists or not. Failures can then only be logged.
+func (u *passwordUsecase) RequestReset(ctx context.Context, username string) error {
+	// The requester is anonymous and usernames are unique across organizations.
+	ctx = context.WithoutCancel(WithAllTenants(ctx))
+	u.pending.Add(1)
+	go func() {
+		defer u.pending.Done()
+		ctx, cancel := context.WithTimeout(ctx, resetRequestTimeout)
+		defer cancel()
+		if err := u.issueReset(ctx, username); err != nil {
+			log.Printf("Password reset request for %q failed: %v", username, err)
+		}
+	}()
+	return nil
+}
+
+// Wait waits for the background work started by RequestReset.
+func (u *passwordUsecase) Wait(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		u.pending.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// issueReset creates a reset record holding only the token hash and sends the plain token to the user.
+func (u *passwordUsecase) issueReset(ctx context.Context, username string) error {
+	usr, err := u.users.FindByUsername(ctx, username)
+	if errors.Is(err, ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	token, err := newOpaqueToken()
+	if err != nil {
+		return err
+	}
+	expiresAt := u.now().Add(u.resetTTL)
+	if _, err := u.resets.Create(ctx, domain.PasswordReset{
+		UserID:    usr.ID,
+		TokenHash: hashToken(token),
+		ExpiresAt: expiresAt,
+	}); err != nil {
+		return err
+	}
+	return u.sender.SendPasswordReset(ctx, usr, token, expiresAt)
+}
+
+// ResetPassword consumes a reset token and sets a new password. Policy checks that do not depend on the account run
+// before the token is consumed, so a weak password does not usually burn the token.
+func (u *passwordUsecase) ResetPassword(ctx context.Context, token, newPassword string) error {
+	if err := u.policy.Validate("", newPassword); err != nil {
+		return err
+	}
+	// The token identifies the user, whichever organization it belongs to.
+	ctx = WithAllTenants(ctx)
+	reset, err := u.resets.Consume(ctx, hashToken(token), u.now())
+	if errors.Is(err, ErrNotFound) {
+		return ErrInvalidResetToken
+	}
+	if err != nil {
+		return err
+	}
+	usr, err := u.users.FindByID(ctx, reset.UserID)
+	if err != nil {
+		return err
+	}
+	if err := u.policy.Validate(usr.Username, newPassword); err != nil {
+		return err
+	}
+	if err := u.setPassword(ctx, usr.ID, newPassword); err != nil {
+		return err
+	}
+	return u.resets.DeleteByUser(ctx, usr.ID)
+}
+
+// setPassword hashes and stores a new password; the repository bumps the token version to revoke sessions.
+func (u *passwordUsecase) setPassword(ctx context.Context, userID, password string) error {
+	hashed, err := u.pwdService.Hash(password)
+	if err != nil {
+		return err
+	}
+	return u.users.UpdatePassword(ctx, userID, hashed)
+}
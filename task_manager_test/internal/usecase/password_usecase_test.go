@@ -0,0 +1,227 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"task_manager_test/internal/domain"
+	"task_manager_test/internal/mocks"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+// PasswordUsecaseTestSuite defines the test suite for the password use case.
+type PasswordUsecaseTestSuite struct {
+	suite.Suite
+	mockUserRepo  *mocks.IUserRepository
+	mockResetRepo *mocks.IPasswordResetRepository
+	mockPwdSvc    *mocks.IPasswordService
+	mockJwtSvc    *mocks.IJWTService
+	mockSender    *mocks.IResetTokenSender
+	usecase       PasswordUsecase
+	now           time.Time
+}
+
+// SetupTest runs before EACH test in the suite.
+func (s *PasswordUsecaseTestSuite) SetupTest() {
+	s.mockUserRepo = mocks.NewIUserRepository(s.T())
+	s.mockResetRepo = mocks.NewIPasswordResetRepository(s.T())
+	s.mockPwdSvc = mocks.NewIPasswordService(s.T())
+	s.mockJwtSvc = mocks.NewIJWTService(s.T())
+	s.mockSender = mocks.NewIResetTokenSender(s.T())
+
+	s.usecase = NewPasswordUsecase(s.mockUserRepo, s.mockResetRepo, s.mockPwdSvc, s.mockJwtSvc, s.mockSender,
+		NewPasswordPolicy(8, []string{"password123"}), LockoutPolicy{MaxAttempts: 3, Duration: 15 * time.Minute}, 30*time.Minute)
+
+	s.now = time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	s.usecase.(*passwordUsecase).now = func() time.Time { return s.now }
+}
+
+// TestPasswordUsecaseTestSuite is the Go test runner's entry point for this suite.
+func TestPasswordUsecaseTestSuite(t *testing.T) {
+	suite.Run(t, new(PasswordUsecaseTestSuite))
+}
+
+// --- Test Cases for the ChangePassword Method ---
+
+// TestChangePassword_Success tests that the password is replaced and a token for the new session version is issued.
+func (s *PasswordUsecaseTestSuite) TestChangePassword_Success() {
+	// ARRANGE
+	ctx := context.Background()
+	stored := domain.User{ID: "user-1", Username: "alice", Password: "old-hash", Role: "user", TokenVersion: 4}
+	s.mockUserRepo.On("FindByUsername", ctx, "alice").Return(stored, nil)
+	s.mockPwdSvc.On("Compare", "old-hash", "old-password").Return(true)
+	s.mockPwdSvc.On("Hash", "brand-new-secret").Return("new-hash", nil)
+	s.mockUserRepo.On("UpdatePassword", ctx, "user-1", "new-hash").Return(nil)
+	s.mockJwtSvc.On("GenerateToken", mock.MatchedBy(func(u domain.User) bool {
+		return u.ID == "user-1" && u.TokenVersion == 5
+	})).Return("fresh-token", nil)
+
+	// ACT
+	token, err := s.usecase.ChangePassword(ctx, "alice", "old-password", "brand-new-secret")
+
+	// ASSERT
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), "fresh-token", token)
+}
+
+// TestChangePassword_Fails_When_CurrentPasswordIsWrong tests that the current password must be proven, and that
+// the wrong password counts as a failed attempt.
+func (s *PasswordUsecaseTestSuite) TestChangePassword_Fails_When_CurrentPasswordIsWrong() {
+	ctx := context.Background()
+	s.mockUserRepo.On("FindByUsername", ctx, "alice").Return(domain.User{ID: "user-1", Username: "alice", Password: "old-hash"}, nil)
+	s.mockPwdSvc.On("Compare", "old-hash", "guess").Return(false)
+	s.mockUserRepo.On("IncrementFailedLogins", ctx, "alice").Return(1, nil).Once()
+
+	_, err := s.usecase.ChangePassword(ctx, "alice", "guess", "brand-new-secret")
+
+	assert.ErrorIs(s.T(), err, ErrIncorrectPassword)
+	s.mockUserRepo.AssertNotCalled(s.T(), "UpdatePassword", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// TestChangePassword_Fails_When_AccountIsLocked tests that a locked account cannot change its password, even with
+// the right current password, and that the attempt does not count.
+func (s *PasswordUsecaseTestSuite) TestChangePassword_Fails_When_AccountIsLocked() {
+	ctx := context.Background()
+	until := s.now.Add(time.Minute)
+	s.mockUserRepo.On("FindByUsername", ctx, "alice").Return(domain.User{ID: "user-1", Username: "alice", Password: "old-hash", FailedLoginAttempts: 3, LockedUntil: until}, nil)
+	s.mockPwdSvc.On("Compare", "old-hash", "old-secret").Return(true)
+
+	_, err := s.usecase.ChangePassword(ctx, "alice", "old-secret", "brand-new-secret")
+
+	assert.ErrorIs(s.T(), err, ErrAccountLocked)
+	s.mockUserRepo.AssertNotCalled(s.T(), "IncrementFailedLogins", mock.Anything, mock.Anything)
+	s.mockUserRepo.AssertNotCalled(s.T(), "UpdatePassword", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// TestChangePassword_Fails_When_NewPasswordIsWeak tests that the policy applies to password changes.
+func (s *PasswordUsecaseTestSuite) TestChangePassword_Fails_When_NewPasswordIsWeak() {
+	ctx := context.Background()
+	s.mockUserRepo.On("FindByUsername", ctx, "alice").Return(domain.User{ID: "user-1", Username: "alice", Password: "old-hash"}, nil)
+	s.mockPwdSvc.On("Compare", "old-hash", "old-password").Return(true)
+
+	_, err := s.usecase.ChangePassword(ctx, "alice", "old-password", "password123")
+
+	assert.ErrorIs(s.T(), err, ErrWeakPassword)
+}
+
+// --- Test Cases for the RequestReset Method ---
+
+// TestRequestReset_Success tests that only the token hash is stored and the plain token is delivered.
+func (s *PasswordUsecaseTestSuite) TestRequestReset_Success() {
+	// ARRANGE
+	ctx := context.Background()
+	usr := domain.User{ID: "user-1", Username: "alice"}
+	expiresAt := s.now.Add(30 * time.Minute)
+	var stored domain.PasswordReset
+	s.mockUserRepo.On("FindByUsername", allTenants, "alice").Return(usr, nil)
+	s.mockResetRepo.On("Create", allTenants, mock.Anything).Run(func(args mock.Arguments) {
+		stored = args.Get(1).(domain.PasswordReset)
+	}).Return(domain.PasswordReset{}, nil)
+	var sent string
+	s.mockSender.On("SendPasswordReset", allTenants, usr, mock.Anything, expiresAt).Run(func(args mock.Arguments) {
+		sent = args.String(2)
+	}).Return(nil)
+
+	// ACT
+	err := s.usecase.RequestReset(ctx, "alice")
+	s.Require().NoError(s.usecase.Wait(ctx))
+
+	// ASSERT
+	assert.NoError(s.T(), err)
+	assert.NotEmpty(s.T(), sent)
+	assert.Equal(s.T(), "user-1", stored.UserID)
+	assert.Equal(s.T(), expiresAt, stored.ExpiresAt)
+	assert.Equal(s.T(), hashToken(sent), stored.TokenHash, "Only the hash of the delivered token should be stored")
+	assert.NotEqual(s.T(), sent, stored.TokenHash)
+}
+
+// TestRequestReset_UnknownUser tests that unknown accounts are not revealed.
+func (s *PasswordUsecaseTestSuite) TestRequestReset_UnknownUser() {
+	ctx := context.Background()
+	s.mockUserRepo.On("FindByUsername", allTenants, "ghost").Return(domain.User{}, ErrNotFound)
+
+	err := s.usecase.RequestReset(ctx, "ghost")
+	s.Require().NoError(s.usecase.Wait(ctx))
+
+	assert.NoError(s.T(), err)
+	s.mockSender.AssertNotCalled(s.T(), "SendPasswordReset", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+// TestRequestReset_ReturnsBeforeTheTokenIsSent tests that the caller does not wait for the account to be looked up,
+// so that known and unknown accounts take as long to answer, nor is cut short when the request ends.
+func (s *PasswordUsecaseTestSuite) TestRequestReset_ReturnsBeforeTheTokenIsSent() {
+	// ARRANGE
+	ctx, cancel := context.WithCancel(context.Background())
+	usr := domain.User{ID: "user-1", Username: "alice"}
+	lookup := make(chan struct{})
+	s.mockUserRepo.On("FindByUsername", allTenants, "alice").Run(func(args mock.Arguments) {
+		<-lookup
+		assert.NoError(s.T(), args.Get(0).(context.Context).Err(), "The request ending should not cancel the work")
+	}).Return(usr, nil)
+	s.mockResetRepo.On("Create", allTenants, mock.Anything).Return(domain.PasswordReset{}, nil)
+	s.mockSender.On("SendPasswordReset", allTenants, usr, mock.Anything, mock.Anything).Return(nil).Once()
+
+	// ACT
+	err := s.usecase.RequestReset(ctx, "alice")
+	cancel()
+
+	// ASSERT
+	assert.NoError(s.T(), err)
+	waitCtx, stop := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer stop()
+	assert.ErrorIs(s.T(), s.usecase.Wait(waitCtx), context.DeadlineExceeded, "The work should still be in progress")
+	close(lookup)
+	s.NoError(s.usecase.Wait(context.Background()))
+}
+
+// --- Test Cases for the ResetPassword Method ---
+
+// TestResetPassword_Success tests that a valid token sets the password and clears outstanding reset tokens.
+func (s *PasswordUsecaseTestSuite) TestResetPassword_Success() {
+	// ARRANGE
+	ctx := context.Background()
+	s.mockResetRepo.On("Consume", allTenants, hashToken("reset-token"), s.now).Return(domain.PasswordReset{UserID: "user-1"}, nil)
+	s.mockUserRepo.On("FindByID", allTenants, "user-1").Return(domain.User{ID: "user-1", Username: "alice"}, nil)
+	s.mockPwdSvc.On("Hash", "brand-new-secret").Return("new-hash", nil)
+	s.mockUserRepo.On("UpdatePassword", allTenants, "user-1", "new-hash").Return(nil)
+	s.mockResetRepo.On("DeleteByUser", allTenants, "user-1").Return(nil)
+
+	// ACT
+	err := s.usecase.ResetPassword(ctx, "reset-token", "brand-new-secret")
+
+	// ASSERT
+	assert.NoError(s.T(), err)
+}
+
+// TestResetPassword_Fails_When_TokenIsInvalid tests that expired, used or unknown tokens are rejected.
+func (s *PasswordUsecaseTestSuite) TestResetPassword_Fails_When_TokenIsInvalid() {
+	ctx := context.Background()
+	s.mockResetRepo.On("Consume", allTenants, hashToken("used-token"), s.now).Return(domain.PasswordReset{}, ErrNotFound)
+
+	err := s.usecase.ResetPassword(ctx, "used-token", "brand-new-secret")
+
+	assert.ErrorIs(s.T(), err, ErrInvalidResetToken)
+}
+
+// TestResetPassword_Fails_When_PasswordIsWeak tests that a weak password does not consume the token.
+func (s *PasswordUsecaseTestSuite) TestResetPassword_Fails_When_PasswordIsWeak() {
+	err := s.usecase.ResetPassword(context.Background(), "reset-token", "short")
+
+	assert.ErrorIs(s.T(), err, ErrWeakPassword)
+	s.mockResetRepo.AssertNotCalled(s.T(), "Consume", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// TestResetPassword_Fails_When_RepositoryFails tests that infrastructure errors are not masked.
+func (s *PasswordUsecaseTestSuite) TestResetPassword_Fails_When_RepositoryFails() {
+	ctx := context.Background()
+	dbErr := errors.New("database down")
+	s.mockResetRepo.On("Consume", allTenants, hashToken("reset-token"), s.now).Return(domain.PasswordReset{}, dbErr)
+
+	err := s.usecase.ResetPassword(ctx, "reset-token", "brand-new-secret")
+
+	assert.ErrorIs(s.T(), err, dbErr)
+}
@@ -0,0 +1,237 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"task_manager_test/internal/domain"
+	"time"
+)
+
+// ProjectUsecase defines application-level operations for managing projects and their members.
+type ProjectUsecase interface {
+	// Create stores a new project with the actor as its only owner.
+	Create(ctx context.Context, p domain.Project) (domain.Project, error)
+	// List returns the projects the actor is a member of.
+	List(ctx context.Context) ([]domain.Project, error)
+	Get(ctx context.Context, id string) (domain.Project, error)
+	Update(ctx context.Context, p domain.Project) (domain.Project, error)
+	// Delete removes the project, deleting or archiving its tasks as requested.
+	Delete(ctx context.Context, id string, tasks domain.TaskDisposition) error
+	// SetMember adds the named user to the project or changes their role.
+	SetMember(ctx context.Context, projectID, username string, role domain.ProjectRole) (domain.Project, error)
+	// RemoveMember removes the named user from the project.
+	RemoveMember(ctx context.Context, projectID, username string) error
+}
+
+// projectUsecase implements ProjectUsecase. Projects are visible to their members only; anyone else gets
+// ErrProjectNotFound. Changing a project or its membership requires the owner role within the project.
+type projectUsecase struct {
+	repo     IProjectRepository
+	tasks    ITaskRepository
+	comments ICommentRepository
+	time     ITimeEntryRepository
+	blobs    IBlobStore
+	users    IUserRepository
+	access   *AccessPolicy
+	now      func() time.Time
+}
+
+// NewProjectUsecase constructs a new ProjectUsecase, injecting the repository and access policy dependencies.
+func NewProjectUsecase(repo IProjectRepository, tasks ITaskRepository, comments ICommentRepository, timeEntries ITimeEntryRepository, blobs IBlobStore, users IUserRepository, access *AccessPolicy) ProjectUsecase {
+	return &projectUsecase{repo: repo, tasks: tasks, comments: comments, time: timeEntries, blobs: blobs, users: users, access: access, now: time.Now}
+}
+
+// Create requires the project.create permission. The name must not be blank.
+func (u *projectUsecase) Create(ctx context.Context, p domain.Project) (domain.Project, error) {
+	actor, err := u.access.Authorize(ctx, domain.PermProjectCreate)
+	if err != nil {
+		return domain.Project{}, err
+	}
+	p.Name = strings.TrimSpace(p.Name)
+	if p.Name == "" {
+		return domain.Project{}, &ProjectRequestError{Reason: "name is required"}
+	}
+	p.Members = []domain.ProjectMember{{UserID: actor.UserID, Username: actor.Username, Role: domain.ProjectOwner}}
+	p.CreatedBy = actor.UserID
+	p.CreatedAt = u.now()
+	return u.repo.Create(ctx, p)
+}
+
+// List returns the projects the actor is a member of.
+func (u *projectUsecase) List(ctx context.Context) ([]domain.Project, error) {
+	actor, ok := ActorFromContext(ctx)
+	if !ok {
+		return nil, ErrForbidden
+	}
+	return u.repo.ListByMember(ctx, actor.UserID)
+}
+
+// Get returns a project the actor is a member of.
+func (u *projectUsecase) Get(ctx context.Context, id string) (domain.Project, error) {
+	_, p, _, err := u.load(ctx, id)
+	return p, err
+}
+
+// Update renames the project or changes its description. Only project owners may do so.
+func (u *projectUsecase) Update(ctx context.Context, p domain.Project) (domain.Project, error) {
+	_, _, role, err := u.load(ctx, p.ID)
+	if err != nil {
+		return domain.Project{}, err
+	}
+	if role != domain.ProjectOwner {
+		return domain.Project{}, ErrForbidden
+	}
+	p.Name = strings.TrimSpace(p.Name)
+	if p.Name == "" {
+		return domain.Project{}, &ProjectRequestError{Reason: "name is required"}
+	}
+	return u.repo.Update(ctx, p)
+}
+
+// Delete removes the project after deleting or archiving its tasks. Only project owners may do so, and the caller
+// must choose what happens to the tasks explicitly.
+func (u *projectUsecase) Delete(ctx context.Context, id string, tasks domain.TaskDisposition) error {
+	_, _, role, err := u.load(ctx, id)
+	if err != nil {
+		return err
+	}
+	if role != domain.ProjectOwner {
+		return ErrForbidden
+	}
+	switch tasks {
+	case domain.DisposeDelete:
+		err = u.deleteTasks(ctx, id)
+	case domain.DisposeArchive:
+		err = u.tasks.ArchiveByProject(ctx, id, u.now())
+	default:
+		return &ProjectRequestError{Reason: "tasks must be delete or archive"}
+	}
+	if err != nil {
+		return err
+	}
+	return u.repo.Delete(ctx, id)
+}
+
+// deleteTasks deletes the project's tasks together with their comments, logged time and attachments.
+func (u *projectUsecase) deleteTasks(ctx context.Context, projectID string) error {
+	tasks, err := u.tasks.GetByProject(ctx, projectID)
+	if err != nil {
+		return err
+	}
+	ids := make([]string, len(tasks))
+	var attachments []domain.Attachment
+	for i, t := range tasks {
+		ids[i] = t.ID
+		attachments = append(attachments, t.Attachments...)
+	}
+	if err := u.tasks.DeleteByProject(ctx, projectID); err != nil {
+		return err
+	}
+	if err := u.comments.DeleteByTasks(ctx, ids); err != nil {
+		return err
+	}
+	if err := u.time.DeleteByTasks(ctx, ids); err != nil {
+		return err
+	}
+	return releaseAttachments(ctx, u.tasks, u.users, u.blobs, attachments)
+}
+
+// SetMember adds a user to the project or changes their role. Only project owners may do so, and the last owner
+// cannot be demoted.
+func (u *projectUsecase) SetMember(ctx context.Context, projectID, username string, role domain.ProjectRole) (domain.Project, error) {
+	_, p, actorRole, err := u.load(ctx, projectID)
+	if err != nil {
+		return domain.Project{}, err
+	}
+	if actorRole != domain.ProjectOwner {
+		return domain.Project{}, ErrForbidden
+	}
+	if role != domain.ProjectViewer && role != domain.ProjectEditor && role != domain.ProjectOwner {
+		return domain.Project{}, &ProjectRequestError{Reason: "role must be owner, editor or viewer"}
+	}
+	usr, err := u.users.FindByUsername(ctx, username)
+	if errors.Is(err, ErrNotFound) {
+		return domain.Project{}, &ProjectRequestError{Reason: "unknown user " + username}
+	}
+	if err != nil {
+		return domain.Project{}, err
+	}
+	if role != domain.ProjectOwner && isLastOwner(p, usr.ID) {
+		return domain.Project{}, &ProjectRequestError{Reason: "a project must keep at least one owner"}
+	}
+	return u.repo.SetMember(ctx, projectID, domain.ProjectMember{UserID: usr.ID, Username: usr.Username, Role: role})
+}
+
+// RemoveMember removes a user from the project. Owners may remove anyone and members may remove themselves, but
+// the last owner cannot leave. It returns ErrNotFound if the user is not a member.
+func (u *projectUsecase) RemoveMember(ctx context.Context, projectID, username string) error {
+	actor, p, actorRole, err := u.load(ctx, projectID)
+	if err != nil {
+		return err
+	}
+	var target domain.ProjectMember
+	for _, m := range p.Members {
+		if m.Username == username {
+			target = m
+		}
+	}
+	if actorRole != domain.ProjectOwner && target.UserID != actor.UserID {
+		return ErrForbidden
+	}
+	if target.UserID == "" {
+		return ErrNotFound
+	}
+	if isLastOwner(p, target.UserID) {
+		return &ProjectRequestError{Reason: "a project must keep at least one owner"}
+	}
+	return u.repo.RemoveMember(ctx, projectID, target.UserID)
+}
+
+// load fetches a project the actor is a member of, with the actor's role in it. Projects the actor does not belong
+// to are reported as ErrProjectNotFound so their existence is not revealed.
+func (u *projectUsecase) load(ctx context.Context, id string) (Actor, domain.Project, domain.ProjectRole, error) {
+	actor, ok := ActorFromContext(ctx)
+	if !ok {
+		return Actor{}, domain.Project{}, "", ErrForbidden
+	}
+	p, err := loadProject(ctx, u.repo, id)
+	if err != nil {
+		return Actor{}, domain.Project{}, "", err
+	}
+	role := memberRole(p, actor.UserID)
+	if role == "" {
+		return Actor{}, domain.Project{}, "", ErrProjectNotFound
+	}
+	return actor, p, role, nil
+}
+
+// loadProject fetches a project, translating a missing project into ErrProjectNotFound.
+func loadProject(ctx context.Context, repo IProjectRepository, id string) (domain.Project, error) {
+	p, err := repo.GetByID(ctx, id)
+	if errors.Is(err, ErrNotFound) {
+		return domain.Project{}, ErrProjectNotFound
+	}
+	return p, err
+}
+
+// memberRole returns the user's role in the project, or the empty role if the user is not a member.
+func memberRole(p domain.Project, userID string) domain.ProjectRole {
+	for _, m := range p.Members {
+		if m.UserID == userID {
+			return m.Role
+		}
+	}
+	return ""
+}
+
+// isLastOwner reports whether the user is the project's only owner.
+func isLastOwner(p domain.Project, userID string) bool {
+	owners := 0
+	for _, m := range p.Members {
+		if m.Role == domain.ProjectOwner {
+			owners++
+		}
+	}
+	return owners == 1 && memberRole(p, userID) == domain.ProjectOwner
+}
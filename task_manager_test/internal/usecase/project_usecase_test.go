@@ -0,0 +1,196 @@
+package usecase
+
+import (
+	"task_manager_test/internal/domain"
+	"task_manager_test/internal/mocks"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+// ProjectUsecaseTestSuite defines the test suite for the project use case.
+type ProjectUsecaseTestSuite struct {
+	suite.Suite
+	mockProjectRepo *mocks.IProjectRepository
+	mockTaskRepo    *mocks.ITaskRepository
+	mockCommentRepo *mocks.ICommentRepository
+	mockTimeRepo    *mocks.ITimeEntryRepository
+	mockBlobStore   *mocks.IBlobStore
+	mockUserRepo    *mocks.IUserRepository
+	usecase         ProjectUsecase
+	now             time.Time
+}
+
+// SetupTest runs before EACH test in the suite.
+func (s *ProjectUsecaseTestSuite) SetupTest() {
+	s.mockProjectRepo = mocks.NewIProjectRepository(s.T())
+	s.mockTaskRepo = mocks.NewITaskRepository(s.T())
+	s.mockCommentRepo = mocks.NewICommentRepository(s.T())
+	s.mockTimeRepo = mocks.NewITimeEntryRepository(s.T())
+	s.mockBlobStore = mocks.NewIBlobStore(s.T())
+	s.mockUserRepo = mocks.NewIUserRepository(s.T())
+
+	s.usecase = NewProjectUsecase(s.mockProjectRepo, s.mockTaskRepo, s.mockCommentRepo, s.mockTimeRepo, s.mockBlobStore, s.mockUserRepo, DefaultAccessPolicy())
+
+	s.now = time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	s.usecase.(*projectUsecase).now = func() time.Time { return s.now }
+}
+
+// TestProjectUsecaseTestSuite is the Go test runner's entry point for this suite.
+func TestProjectUsecaseTestSuite(t *testing.T) {
+	suite.Run(t, new(ProjectUsecaseTestSuite))
+}
+
+// --- Test Cases for the Create Method ---
+
+// TestCreate_Success tests that the creator becomes the project's only owner.
+func (s *ProjectUsecaseTestSuite) TestCreate_Success() {
+	// ARRANGE
+	ctx := as("user-1", domain.RoleMember)
+	want := domain.Project{
+		Name:      "Launch",
+		Members:   []domain.ProjectMember{{UserID: "user-1", Username: "user-1", Role: domain.ProjectOwner}},
+		CreatedBy: "user-1",
+		CreatedAt: s.now,
+	}
+	s.mockProjectRepo.On("Create", ctx, want).Return(want, nil)
+
+	// ACT
+	got, err := s.usecase.Create(ctx, domain.Project{Name: "  Launch "})
+
+	// ASSERT
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), want, got)
+}
+
+// TestCreate_Fails_When_NotAllowed tests the name rule and the project.create permission.
+func (s *ProjectUsecaseTestSuite) TestCreate_Fails_When_NotAllowed() {
+	_, err := s.usecase.Create(as("user-1", domain.RoleMember), domain.Project{Name: " "})
+	assert.ErrorIs(s.T(), err, ErrInvalidProjectRequest)
+
+	_, err = s.usecase.Create(as("viewer-1", domain.RoleViewer), domain.Project{Name: "Launch"})
+	assert.ErrorIs(s.T(), err, ErrForbidden)
+
+	s.mockProjectRepo.AssertNotCalled(s.T(), "Create")
+}
+
+// --- Test Cases for reading projects ---
+
+// TestGet_HidesProjectsFromNonMembers tests that even admins cannot see projects they do not belong to.
+func (s *ProjectUsecaseTestSuite) TestGet_HidesProjectsFromNonMembers() {
+	s.mockProjectRepo.On("GetByID", mock.Anything, "proj-1").Return(teamProject, nil)
+
+	got, err := s.usecase.Get(as("viewer-1", domain.RoleMember), "proj-1")
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), teamProject, got)
+
+	_, err = s.usecase.Get(as("admin-1", domain.RoleAdmin), "proj-1")
+	assert.ErrorIs(s.T(), err, ErrProjectNotFound)
+}
+
+// --- Test Cases for the Update Method ---
+
+// TestUpdate_RequiresOwner tests that only project owners can rename a project.
+func (s *ProjectUsecaseTestSuite) TestUpdate_RequiresOwner() {
+	owner := as("owner-1", domain.RoleMember)
+	s.mockProjectRepo.On("GetByID", mock.Anything, "proj-1").Return(teamProject, nil)
+	want := domain.Project{ID: "proj-1", Name: "Renamed"}
+	s.mockProjectRepo.On("Update", owner, want).Return(want, nil).Once()
+
+	_, err := s.usecase.Update(as("editor-1", domain.RoleMember), domain.Project{ID: "proj-1", Name: "Renamed"})
+	assert.ErrorIs(s.T(), err, ErrForbidden)
+
+	_, err = s.usecase.Update(owner, domain.Project{ID: "proj-1", Name: "Renamed"})
+	assert.NoError(s.T(), err)
+}
+
+// --- Test Cases for the Delete Method ---
+
+// TestDelete_AppliesTaskDisposition tests that the project's tasks are deleted, with their comments and attachments,
+// or archived before the project.
+func (s *ProjectUsecaseTestSuite) TestDelete_AppliesTaskDisposition() {
+	ctx := as("owner-1", domain.RoleMember)
+	s.mockProjectRepo.On("GetByID", ctx, "proj-1").Return(teamProject, nil)
+	s.mockProjectRepo.On("Delete", ctx, "proj-1").Return(nil).Twice()
+	s.mockTaskRepo.On("GetByProject", ctx, "proj-1").Return([]domain.Task{
+		{ID: "t1", Attachments: []domain.Attachment{{ID: "a1", Digest: "d1"}}}, {ID: "t2"},
+	}, nil).Once()
+	s.mockTaskRepo.On("DeleteByProject", ctx, "proj-1").Return(nil).Once()
+	s.mockCommentRepo.On("DeleteByTasks", ctx, []string{"t1", "t2"}).Return(nil).Once()
+	s.mockTimeRepo.On("DeleteByTasks", ctx, []string{"t1", "t2"}).Return(nil).Once()
+	s.mockTaskRepo.On("CountAttachmentsByDigest", ctx, "d1").Return(int64(0), nil).Once()
+	s.mockBlobStore.On("Delete", ctx, "d1").Return(nil).Once()
+	s.mockTaskRepo.On("ArchiveByProject", ctx, "proj-1", s.now).Return(nil).Once()
+
+	assert.NoError(s.T(), s.usecase.Delete(ctx, "proj-1", domain.DisposeDelete))
+	assert.NoError(s.T(), s.usecase.Delete(ctx, "proj-1", domain.DisposeArchive))
+}
+
+// TestDelete_Fails_When_DispositionIsMissing tests that the caller must decide what happens to the tasks.
+func (s *ProjectUsecaseTestSuite) TestDelete_Fails_When_DispositionIsMissing() {
+	ctx := as("owner-1", domain.RoleMember)
+	s.mockProjectRepo.On("GetByID", ctx, "proj-1").Return(teamProject, nil)
+
+	err := s.usecase.Delete(ctx, "proj-1", "")
+
+	assert.ErrorIs(s.T(), err, ErrInvalidProjectRequest)
+	s.mockProjectRepo.AssertNotCalled(s.T(), "Delete", mock.Anything, mock.Anything)
+}
+
+// --- Test Cases for Membership ---
+
+// TestSetMember_Success tests that owners can add users with a project role.
+func (s *ProjectUsecaseTestSuite) TestSetMember_Success() {
+	ctx := as("owner-1", domain.RoleMember)
+	s.mockProjectRepo.On("GetByID", ctx, "proj-1").Return(teamProject, nil)
+	s.mockUserRepo.On("FindByUsername", ctx, "bob").Return(domain.User{ID: "user-2", Username: "bob"}, nil)
+	member := domain.ProjectMember{UserID: "user-2", Username: "bob", Role: domain.ProjectEditor}
+	s.mockProjectRepo.On("SetMember", ctx, "proj-1", member).Return(teamProject, nil)
+
+	_, err := s.usecase.SetMember(ctx, "proj-1", "bob", domain.ProjectEditor)
+
+	assert.NoError(s.T(), err)
+}
+
+// TestSetMember_Fails_When_RequestIsInvalid tests the validation of membership changes.
+func (s *ProjectUsecaseTestSuite) TestSetMember_Fails_When_RequestIsInvalid() {
+	ctx := as("owner-1", domain.RoleMember)
+	s.mockProjectRepo.On("GetByID", ctx, "proj-1").Return(teamProject, nil)
+	s.mockUserRepo.On("FindByUsername", ctx, "ghost").Return(domain.User{}, ErrNotFound)
+	s.mockUserRepo.On("FindByUsername", ctx, "olivia").Return(domain.User{ID: "owner-1", Username: "olivia"}, nil)
+
+	cases := map[string]struct {
+		username string
+		role     domain.ProjectRole
+		reason   string
+	}{
+		"bad role":          {"bob", "admin", "role must be owner, editor or viewer"},
+		"unknown user":      {"ghost", domain.ProjectViewer, "unknown user ghost"},
+		"demote last owner": {"olivia", domain.ProjectEditor, "a project must keep at least one owner"},
+	}
+	for name, tc := range cases {
+		s.Run(name, func() {
+			_, err := s.usecase.SetMember(ctx, "proj-1", tc.username, tc.role)
+
+			var reqErr *ProjectRequestError
+			assert.ErrorAs(s.T(), err, &reqErr)
+			assert.Equal(s.T(), tc.reason, reqErr.Reason)
+		})
+	}
+	s.mockProjectRepo.AssertNotCalled(s.T(), "SetMember")
+}
+
+// TestRemoveMember tests that owners remove anyone, members may leave, and the last owner stays.
+func (s *ProjectUsecaseTestSuite) TestRemoveMember() {
+	s.mockProjectRepo.On("GetByID", mock.Anything, "proj-1").Return(teamProject, nil)
+	s.mockProjectRepo.On("RemoveMember", mock.Anything, "proj-1", "viewer-1").Return(nil).Twice()
+
+	assert.NoError(s.T(), s.usecase.RemoveMember(as("owner-1", domain.RoleMember), "proj-1", "vera"))
+	assert.NoError(s.T(), s.usecase.RemoveMember(as("viewer-1", domain.RoleMember), "proj-1", "vera"), "Members can leave")
+	assert.ErrorIs(s.T(), s.usecase.RemoveMember(as("editor-1", domain.RoleMember), "proj-1", "vera"), ErrForbidden)
+	assert.ErrorIs(s.T(), s.usecase.RemoveMember(as("owner-1", domain.RoleMember), "proj-1", "nobody"), ErrNotFound)
+	assert.ErrorIs(s.T(), s.usecase.RemoveMember(as("owner-1", domain.RoleMember), "proj-1", "olivia"), ErrInvalidProjectRequest)
+}
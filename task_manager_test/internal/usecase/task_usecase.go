@@ -3,53 +3,361 @@ package usecase
 import (
 	"context"
 	"errors"
+	"maps"
+	"slices"
 	"strings"
 	"task_manager_test/internal/domain"
+	"time"
 )
 
 // TaskUsecase defines application-level operations for managing domain.Task entities.
 type TaskUsecase interface {
-	List(ctx context.Context) ([]domain.Task, error)
+	// List returns the personal tasks visible to the actor; tasks in projects are listed with ListByProject.
+	List(ctx context.Context) ([]domain.TaskListItem, error)
+	// ListByProject returns the tasks of a project the actor is a member of.
+	ListByProject(ctx context.Context, projectID string) ([]domain.Task, error)
 	Get(ctx context.Context, id string) (domain.Task, error)
+	// GetForUpdate returns a task the actor may update. Tasks the actor may only read return ErrForbidden.
+	GetForUpdate(ctx context.Context, id string) (domain.Task, error)
 	Create(ctx context.Context, t domain.Task) (domain.Task, error)
 	Update(ctx context.Context, t domain.Task) (domain.Task, error)
 	Delete(ctx context.Context, id string) error
+
+	// Share grants the named user access to the task, replacing the access level of an existing share.
+	Share(ctx context.Context, taskID, username string, access domain.ShareAccess) (domain.TaskShare, error)
+	ListShares(ctx context.Context, taskID string) ([]domain.TaskShare, error)
+	// Unshare revokes the named user's share of the task, returning ErrShareNotFound if there is none.
+	Unshare(ctx context.Context, taskID, username string) error
+
+	// Agenda returns the actor's open personal tasks, owned or shared with them, that are due within the range of
+	// days in the actor's time zone.
+	Agenda(ctx context.Context, rng domain.AgendaRange) (domain.Agenda, error)
 }
 
 // taskUsecase implements TaskUsecase, orchestrating domain logic via TaskRepository.
+// Every operation is authorized against the AccessPolicy using the Actor in the context. A share lets the grantee
+// treat the task as their own for reading and, with edit access, updating; the grantee's role still has to hold
+// the matching ".own" permission. Tasks in a project are governed by project membership instead: every member may
+// read them and editors and owners may change them, again capped by the ".own" permissions of their global role.
 type taskUsecase struct {
-	repo ITaskRepository
+	repo     ITaskRepository
+	shares   ITaskShareRepository
+	projects IProjectRepository
+	comments ICommentRepository
+	time     ITimeEntryRepository
+	blobs    IBlobStore
+	users    IUserRepository
+	access   *AccessPolicy
+	now      func() time.Time
 }
 
-// NewTaskUsecase constructs a new TaskUsecase, injecting the repository dependency.
-func NewTaskUsecase(repo ITaskRepository) TaskUsecase {
-	return &taskUsecase{repo}
+// NewTaskUsecase constructs a new TaskUsecase, injecting the repository and access policy dependencies.
+func NewTaskUsecase(repo ITaskRepository, shares ITaskShareRepository, projects IProjectRepository, comments ICommentRepository, timeEntries ITimeEntryRepository, blobs IBlobStore, users IUserRepository, access *AccessPolicy) TaskUsecase {
+	return &taskUsecase{repo: repo, shares: shares, projects: projects, comments: comments, time: timeEntries, blobs: blobs, users: users, access: access, now: time.Now}
+}
+
+// List retrieves every personal task for actors with task.read.any, and otherwise the actor's own tasks and the
+// tasks shared with the actor. Each task is marked with how the actor came to see it.
+func (u *taskUsecase) List(ctx context.Context) ([]domain.TaskListItem, error) {
+	actor, err := u.access.Authorize(ctx, domain.PermTaskReadAny, domain.PermTaskReadOwn)
+	if err != nil {
+		return nil, err
+	}
+	shared, err := u.sharedWith(ctx, actor.UserID)
+	if err != nil {
+		return nil, err
+	}
+	q := personalTasks(actor, shared)
+	if u.access.Can(actor.Role, domain.PermTaskReadAny) {
+		q = domain.TaskQuery{All: true, Personal: true}
+	}
+	tasks, err := u.repo.Find(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]domain.TaskListItem, 0, len(tasks))
+	for _, t := range tasks {
+		item := domain.TaskListItem{Task: t, Ownership: domain.OwnershipOther}
+		if isOwner(actor, t) {
+			item.Ownership = domain.OwnershipOwner
+		} else if access, ok := shared[t.ID]; ok {
+			item.Ownership = domain.OwnershipShared
+			item.Access = access
+		}
+		items = append(items, item)
+	}
+	return items, nil
 }
 
-// List retrieves all domain.Task records using the repository layer.
-func (u *taskUsecase) List(ctx context.Context) ([]domain.Task, error) {
-	return u.repo.GetAll(ctx)
+// sharedWith returns the access level of every task shared with the user, by task ID.
+func (u *taskUsecase) sharedWith(ctx context.Context, userID string) (map[string]domain.ShareAccess, error) {
+	shares, err := u.shares.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	shared := make(map[string]domain.ShareAccess, len(shares))
+	for _, s := range shares {
+		shared[s.TaskID] = s.Access
+	}
+	return shared, nil
+}
+
+// personalTasks selects the tasks outside projects that the actor owns or that are shared with them.
+func personalTasks(actor Actor, shared map[string]domain.ShareAccess) domain.TaskQuery {
+	return domain.TaskQuery{OwnerID: actor.UserID, IDs: slices.Sorted(maps.Keys(shared)), Personal: true}
+}
+
+// ListByProject returns the project's tasks to its members. Other users get ErrProjectNotFound.
+func (u *taskUsecase) ListByProject(ctx context.Context, projectID string) ([]domain.Task, error) {
+	actor, err := u.access.Authorize(ctx, domain.PermTaskReadOwn)
+	if err != nil {
+		return nil, err
+	}
+	p, err := loadProject(ctx, u.projects, projectID)
+	if err != nil {
+		return nil, err
+	}
+	if memberRole(p, actor.UserID) == "" {
+		return nil, ErrProjectNotFound
+	}
+	return u.repo.GetByProject(ctx, projectID)
 }
 
 // Get fetches a task by its ID. Delegates error handling (e.g. invalid ID, missing record) to the repository.
+// Tasks the actor may not read are reported as not found so their existence is not revealed.
 func (u *taskUsecase) Get(ctx context.Context, id string) (domain.Task, error) {
-	return u.repo.GetByID(ctx, id)
+	ta, err := u.load(ctx, id)
+	return ta.task, err
 }
 
-// Create builds and persists a new domain.Task entity.
+// GetForUpdate fetches a task the actor may update, for operations that change a task without replacing it.
+func (u *taskUsecase) GetForUpdate(ctx context.Context, id string) (domain.Task, error) {
+	ta, err := u.load(ctx, id)
+	if err != nil {
+		return domain.Task{}, err
+	}
+	if !u.canUpdate(ta) {
+		return domain.Task{}, ErrForbidden
+	}
+	return ta.task, nil
+}
+
+// Create builds and persists a new domain.Task entity owned by the actor. Tasks created in a project require the
+// editor or owner role in that project.
 func (u *taskUsecase) Create(ctx context.Context, t domain.Task) (domain.Task, error) {
+	actor, err := u.access.Authorize(ctx, domain.PermTaskCreate)
+	if err != nil {
+		return domain.Task{}, err
+	}
+	if t.ProjectID != "" {
+		p, err := loadProject(ctx, u.projects, t.ProjectID)
+		if err != nil {
+			return domain.Task{}, err
+		}
+		switch memberRole(p, actor.UserID) {
+		case "":
+			return domain.Task{}, ErrProjectNotFound
+		case domain.ProjectViewer:
+			return domain.Task{}, ErrForbidden
+		}
+	}
 	if strings.TrimSpace(t.Title) == "" {
-		return domain.Task{}, errors.New("task title cannot be empty")
+		return domain.Task{}, &TaskRequestError{Reason: "title cannot be empty"}
 	}
+	t.OwnerID = actor.UserID
+	t.ArchivedAt = time.Time{}
 	return u.repo.Create(ctx, t)
 }
 
-// Update modifies an existing domain.Task identified by its ID.
+// Update modifies an existing domain.Task identified by its ID, keeping its owner, project, archive state and
+// attachments.
 func (u *taskUsecase) Update(ctx context.Context, t domain.Task) (domain.Task, error) {
+	ta, err := u.load(ctx, t.ID)
+	if err != nil {
+		return domain.Task{}, err
+	}
+	if !u.canUpdate(ta) {
+		return domain.Task{}, ErrForbidden
+	}
+	t.OwnerID = ta.task.OwnerID
+	t.ProjectID = ta.task.ProjectID
+	t.ArchivedAt = ta.task.ArchivedAt
+	t.Attachments = ta.task.Attachments
 	return u.repo.Update(ctx, t)
 }
 
-// Delete removes a task by its ID. Business errors like 'not found' are surfaced from repository.
+// Delete removes a task by its ID together with its shares, comments, logged time and attachments. Business errors like 'not found' are surfaced from repository.
 func (u *taskUsecase) Delete(ctx context.Context, id string) error {
-	return u.repo.Delete(ctx, id)
+	ta, err := u.load(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !u.canDelete(ta) {
+		return ErrForbidden
+	}
+	// The task goes last, so that if removing its shares, comments or time fails it is still there to delete again.
+	if err := u.shares.DeleteByTask(ctx, id); err != nil {
+		return err
+	}
+	if err := u.comments.DeleteByTasks(ctx, []string{id}); err != nil {
+		return err
+	}
+	if err := u.time.DeleteByTasks(ctx, []string{id}); err != nil {
+		return err
+	}
+	if err := u.repo.Delete(ctx, id); err != nil {
+		return err
+	}
+	return releaseAttachments(ctx, u.repo, u.users, u.blobs, ta.task.Attachments)
+}
+
+// Share grants access to a task. Only actors who may update the task as its owner, or who hold task.update.any,
+// may manage its shares.
+func (u *taskUsecase) Share(ctx context.Context, taskID, username string, access domain.ShareAccess) (domain.TaskShare, error) {
+	ta, err := u.load(ctx, taskID)
+	if err != nil {
+		return domain.TaskShare{}, err
+	}
+	if !u.canManageShares(ta) {
+		return domain.TaskShare{}, ErrForbidden
+	}
+	if ta.task.ProjectID != "" {
+		return domain.TaskShare{}, &ShareRequestError{Reason: "tasks in a project are shared through project membership"}
+	}
+	if access != domain.ShareRead && access != domain.ShareEdit {
+		return domain.TaskShare{}, &ShareRequestError{Reason: "access must be read or edit"}
+	}
+	grantee, err := u.users.FindByUsername(ctx, username)
+	if errors.Is(err, ErrNotFound) {
+		return domain.TaskShare{}, &ShareRequestError{Reason: "unknown user " + username}
+	}
+	if err != nil {
+		return domain.TaskShare{}, err
+	}
+	if grantee.ID == ta.task.OwnerID {
+		return domain.TaskShare{}, &ShareRequestError{Reason: "a task cannot be shared with its owner"}
+	}
+	return u.shares.Upsert(ctx, domain.TaskShare{
+		TaskID:    taskID,
+		UserID:    grantee.ID,
+		Username:  grantee.Username,
+		Access:    access,
+		GrantedBy: ta.actor.UserID,
+		CreatedAt: u.now(),
+	})
+}
+
+// ListShares returns the shares of a task to anyone who can read it.
+func (u *taskUsecase) ListShares(ctx context.Context, taskID string) ([]domain.TaskShare, error) {
+	if _, err := u.load(ctx, taskID); err != nil {
+		return nil, err
+	}
+	return u.shares.ListByTask(ctx, taskID)
+}
+
+// Unshare revokes a user's share. It returns ErrShareNotFound if the user has no share of the task.
+func (u *taskUsecase) Unshare(ctx context.Context, taskID, username string) error {
+	ta, err := u.load(ctx, taskID)
+	if err != nil {
+		return err
+	}
+	if !u.canManageShares(ta) {
+		return ErrForbidden
+	}
+	grantee, err := u.users.FindByUsername(ctx, username)
+	if err == nil {
+		err = u.shares.Delete(ctx, taskID, grantee.ID)
+	}
+	if errors.Is(err, ErrNotFound) {
+		return ErrShareNotFound
+	}
+	return err
+}
+
+// taskAccess records how the actor relates to a task loaded by load.
+type taskAccess struct {
+	actor Actor
+	task  domain.Task
+	owner bool
+	// share is the access level of the actor's share of the task, or empty if there is none.
+	share domain.ShareAccess
+	// projectRole is the actor's role in the task's project; it is only set for tasks in a project.
+	projectRole domain.ProjectRole
+}
+
+// load fetches a task the actor is allowed to read, returning ErrNotFound for tasks hidden from the actor.
+func (u *taskUsecase) load(ctx context.Context, id string) (taskAccess, error) {
+	actor, err := u.access.Authorize(ctx, domain.PermTaskReadAny, domain.PermTaskReadOwn)
+	if err != nil {
+		return taskAccess{}, err
+	}
+	t, err := u.repo.GetByID(ctx, id)
+	if err != nil {
+		return taskAccess{}, err
+	}
+	ta := taskAccess{actor: actor, task: t, owner: isOwner(actor, t)}
+	if t.ProjectID != "" {
+		p, err := loadProject(ctx, u.projects, t.ProjectID)
+		if err != nil && !errors.Is(err, ErrProjectNotFound) {
+			return taskAccess{}, err
+		}
+		ta.projectRole = memberRole(p, actor.UserID)
+		if ta.projectRole == "" || !u.access.Can(actor.Role, domain.PermTaskReadOwn) {
+			return taskAccess{}, ErrNotFound
+		}
+		return ta, nil
+	}
+	if !ta.owner {
+		s, err := u.shares.Find(ctx, id, actor.UserID)
+		if err != nil && !errors.Is(err, ErrNotFound) {
+			return taskAccess{}, err
+		}
+		ta.share = s.Access
+	}
+	readable := u.access.Can(actor.Role, domain.PermTaskReadAny) ||
+		((ta.owner || ta.share != "") && u.access.Can(actor.Role, domain.PermTaskReadOwn))
+	if !readable {
+		return taskAccess{}, ErrNotFound
+	}
+	return ta, nil
+}
+
+// canUpdate reports whether the actor holds task.update.any, or owns the task or has edit access to it and holds
+// task.update.own. In a project, editors and owners holding task.update.own may update every task.
+func (u *taskUsecase) canUpdate(ta taskAccess) bool {
+	if ta.task.ProjectID != "" {
+		return editsProjectTasks(ta.projectRole) && u.access.Can(ta.actor.Role, domain.PermTaskUpdateOwn)
+	}
+	return u.access.Can(ta.actor.Role, domain.PermTaskUpdateAny) ||
+		((ta.owner || ta.share == domain.ShareEdit) && u.access.Can(ta.actor.Role, domain.PermTaskUpdateOwn))
+}
+
+// canDelete reports whether the actor holds task.delete.any, or owns the task and holds task.delete.own.
+// In a project, editors and owners holding task.delete.own may delete every task.
+func (u *taskUsecase) canDelete(ta taskAccess) bool {
+	if ta.task.ProjectID != "" {
+		return editsProjectTasks(ta.projectRole) && u.access.Can(ta.actor.Role, domain.PermTaskDeleteOwn)
+	}
+	return u.access.Can(ta.actor.Role, domain.PermTaskDeleteAny) ||
+		(ta.owner && u.access.Can(ta.actor.Role, domain.PermTaskDeleteOwn))
+}
+
+// canManageShares reports whether the actor may grant and revoke shares of the task. Edit access is not enough.
+func (u *taskUsecase) canManageShares(ta taskAccess) bool {
+	if ta.task.ProjectID != "" {
+		return ta.projectRole == domain.ProjectOwner
+	}
+	return u.access.Can(ta.actor.Role, domain.PermTaskUpdateAny) ||
+		(ta.owner && u.access.Can(ta.actor.Role, domain.PermTaskUpdateOwn))
+}
+
+// editsProjectTasks reports whether the project role may create, update and delete the project's tasks.
+func editsProjectTasks(role domain.ProjectRole) bool {
+	return role == domain.ProjectEditor || role == domain.ProjectOwner
+}
+
+// isOwner reports whether the actor created the task. Tasks without an owner belong to nobody.
+func isOwner(actor Actor, t domain.Task) bool {
+	return t.OwnerID != "" && t.OwnerID == actor.UserID
 }
@@ -10,23 +10,41 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 )
 
 // TaskUsecaseTestSuite defines the test suite for the task use case.
 type TaskUsecaseTestSuite struct {
 	suite.Suite
-	mockTaskRepo *mocks.ITaskRepository
-	usecase      TaskUsecase
+	mockTaskRepo    *mocks.ITaskRepository
+	mockShareRepo   *mocks.ITaskShareRepository
+	mockProjectRepo *mocks.IProjectRepository
+	mockCommentRepo *mocks.ICommentRepository
+	mockTimeRepo    *mocks.ITimeEntryRepository
+	mockBlobStore   *mocks.IBlobStore
+	mockUserRepo    *mocks.IUserRepository
+	usecase         TaskUsecase
+	now             time.Time
 }
 
 // SetupTest is a method from testify/suite. It runs before EACH test, ensuring a clean state by re-initializing the mock and the use case.
 func (s *TaskUsecaseTestSuite) SetupTest() {
 	// Create a new instance of the mock repository for each test.
 	s.mockTaskRepo = mocks.NewITaskRepository(s.T())
-
-	// Create a new instance of the use case, injecting our mock repository.
-	s.usecase = NewTaskUsecase(s.mockTaskRepo)
+	s.mockShareRepo = mocks.NewITaskShareRepository(s.T())
+	s.mockProjectRepo = mocks.NewIProjectRepository(s.T())
+	s.mockCommentRepo = mocks.NewICommentRepository(s.T())
+	s.mockTimeRepo = mocks.NewITimeEntryRepository(s.T())
+	s.mockBlobStore = mocks.NewIBlobStore(s.T())
+	s.mockUserRepo = mocks.NewIUserRepository(s.T())
+
+	// Create a new instance of the use case, injecting our mock repositories and the built-in access policy.
+	s.usecase = NewTaskUsecase(s.mockTaskRepo, s.mockShareRepo, s.mockProjectRepo, s.mockCommentRepo, s.mockTimeRepo, s.mockBlobStore, s.mockUserRepo, DefaultAccessPolicy())
+
+	// Freeze the clock so share timestamps are deterministic.
+	s.now = time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	s.usecase.(*taskUsecase).now = func() time.Time { return s.now }
 }
 
 // TestTaskUsecaseTestSuite is the Go test runner's entry point for this suite.
@@ -34,28 +52,35 @@ func TestTaskUsecaseTestSuite(t *testing.T) {
 	suite.Run(t, new(TaskUsecaseTestSuite))
 }
 
+// as returns a context carrying an actor with the given ID and role.
+func as(userID string, role domain.Role) context.Context {
+	return WithActor(context.Background(), Actor{UserID: userID, Username: userID, Role: role})
+}
+
 // --- Test Cases for the Create Method ---
 
 // TestCreate_Success tests the happy path for task creation.
 func (s *TaskUsecaseTestSuite) TestCreate_Success() {
 	// ARRANGE: Define inputs and set up mock expectations.
-	ctx := context.Background()
+	ctx := as("user-1", domain.RoleMember)
 	taskToCreate := domain.Task{Title: "A Valid Title", Description: "A description", DueDate: time.Now()}
+	owned := taskToCreate
+	owned.OwnerID = "user-1"
 
-	s.mockTaskRepo.On("Create", ctx, taskToCreate).Return(taskToCreate, nil)
+	s.mockTaskRepo.On("Create", ctx, owned).Return(owned, nil)
 
 	// ACT: Call the method we are testing.
 	createdTask, err := s.usecase.Create(ctx, taskToCreate)
 
 	// ASSERT: Verify the outcome.
 	assert.NoError(s.T(), err, "Create should not return an error on success")
-	assert.Equal(s.T(), taskToCreate, createdTask, "The created task should match the input task")
+	assert.Equal(s.T(), owned, createdTask, "The created task should be owned by the actor")
 }
 
 // TestCreate_Fails_When_TitleIsEmpty tests the specific business rule within the Create method.
 func (s *TaskUsecaseTestSuite) TestCreate_Fails_When_TitleIsEmpty() {
 	// ARRANGE
-	ctx := context.Background()
+	ctx := as("user-1", domain.RoleMember)
 	// Create a task with an invalid (empty) title.
 	taskWithEmptyTitle := domain.Task{Title: " ", Description: "A description"}
 
@@ -64,7 +89,9 @@ func (s *TaskUsecaseTestSuite) TestCreate_Fails_When_TitleIsEmpty() {
 
 	// ASSERT
 	assert.Error(s.T(), err, "Create should return an error for an empty title")
-	assert.EqualError(s.T(), err, "task title cannot be empty", "The error message should be specific")
+	var reqErr *TaskRequestError
+	assert.ErrorAs(s.T(), err, &reqErr, "The error should be a TaskRequestError")
+	assert.Equal(s.T(), "title cannot be empty", reqErr.Reason, "The reason should be specific")
 
 	s.mockTaskRepo.AssertNotCalled(s.T(), "Create")
 }
@@ -72,8 +99,8 @@ func (s *TaskUsecaseTestSuite) TestCreate_Fails_When_TitleIsEmpty() {
 // TestCreate_Fails_When_RepositoryFails tests how the use case handles an error from its dependency.
 func (s *TaskUsecaseTestSuite) TestCreate_Fails_When_RepositoryFails() {
 	// ARRANGE
-	ctx := context.Background()
-	taskToCreate := domain.Task{Title: "A Valid Title"}
+	ctx := as("user-1", domain.RoleMember)
+	taskToCreate := domain.Task{Title: "A Valid Title", OwnerID: "user-1"}
 	repoError := errors.New("database connection failed")
 
 	// Configure the mock to return an error when Create is called.
@@ -87,13 +114,71 @@ func (s *TaskUsecaseTestSuite) TestCreate_Fails_When_RepositoryFails() {
 	assert.ErrorIs(s.T(), err, repoError, "The error should be the one returned by the repository")
 }
 
-// TestGet_Success tests the happy path for retrieving a single task.
+// TestCreate_Fails_When_ActorLacksPermission tests that viewers and anonymous callers cannot create tasks.
+func (s *TaskUsecaseTestSuite) TestCreate_Fails_When_ActorLacksPermission() {
+	_, err := s.usecase.Create(as("viewer-1", domain.RoleViewer), domain.Task{Title: "A Valid Title"})
+	assert.ErrorIs(s.T(), err, ErrForbidden)
+
+	_, err = s.usecase.Create(context.Background(), domain.Task{Title: "A Valid Title"})
+	assert.ErrorIs(s.T(), err, ErrForbidden, "A context without an actor must not be trusted")
+}
+
+// --- Test Cases for the List Method ---
+
+// TestList_ScopedToOwner tests that members only see their own tasks while viewers see every task.
+func (s *TaskUsecaseTestSuite) TestList_ScopedToOwner() {
+	member := as("user-1", domain.RoleMember)
+	viewer := as("viewer-1", domain.RoleViewer)
+	own := []domain.Task{{ID: "t1", OwnerID: "user-1"}}
+	all := []domain.Task{{ID: "t1", OwnerID: "user-1"}, {ID: "t2", OwnerID: "user-2"}}
+	s.mockShareRepo.On("ListByUser", member, "user-1").Return(nil, nil)
+	s.mockShareRepo.On("ListByUser", viewer, "viewer-1").Return(nil, nil)
+	s.mockTaskRepo.On("Find", member, domain.TaskQuery{OwnerID: "user-1", Personal: true}).Return(own, nil)
+	s.mockTaskRepo.On("Find", viewer, domain.TaskQuery{All: true, Personal: true}).Return(all, nil)
 
+	got, err := s.usecase.List(member)
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), []domain.TaskListItem{{Task: own[0], Ownership: domain.OwnershipOwner}}, got)
+
+	got, err = s.usecase.List(viewer)
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), []domain.TaskListItem{
+		{Task: all[0], Ownership: domain.OwnershipOther},
+		{Task: all[1], Ownership: domain.OwnershipOther},
+	}, got)
+}
+
+// TestList_IncludesSharedTasks tests that tasks shared with a member are listed with their own, with the share level.
+func (s *TaskUsecaseTestSuite) TestList_IncludesSharedTasks() {
+	ctx := as("user-1", domain.RoleMember)
+	own := domain.Task{ID: "t1", OwnerID: "user-1"}
+	sharedRead := domain.Task{ID: "t2", OwnerID: "user-2"}
+	sharedEdit := domain.Task{ID: "t3", OwnerID: "user-3"}
+	s.mockShareRepo.On("ListByUser", ctx, "user-1").Return([]domain.TaskShare{
+		{TaskID: "t3", UserID: "user-1", Access: domain.ShareEdit},
+		{TaskID: "t2", UserID: "user-1", Access: domain.ShareRead},
+	}, nil)
+	s.mockTaskRepo.On("Find", ctx, domain.TaskQuery{OwnerID: "user-1", IDs: []string{"t2", "t3"}, Personal: true}).
+		Return([]domain.Task{own, sharedRead, sharedEdit}, nil)
+
+	got, err := s.usecase.List(ctx)
+
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), []domain.TaskListItem{
+		{Task: own, Ownership: domain.OwnershipOwner},
+		{Task: sharedRead, Ownership: domain.OwnershipShared, Access: domain.ShareRead},
+		{Task: sharedEdit, Ownership: domain.OwnershipShared, Access: domain.ShareEdit},
+	}, got)
+}
+
+// --- Test Cases for the Get Method ---
+
+// TestGet_Success tests the happy path for retrieving a single task.
 func (s *TaskUsecaseTestSuite) TestGet_Success() {
 	// ARRANGE
-	ctx := context.Background()
+	ctx := as("user-1", domain.RoleMember)
 	taskID := "task-123"
-	expectedTask := domain.Task{ID: taskID, Title: "Test Task"}
+	expectedTask := domain.Task{ID: taskID, Title: "Test Task", OwnerID: "user-1"}
 
 	// Configure the mock to return the expected task when GetByID is called.
 	s.mockTaskRepo.On("GetByID", ctx, taskID).Return(expectedTask, nil)
@@ -109,7 +194,7 @@ func (s *TaskUsecaseTestSuite) TestGet_Success() {
 // TestGet_Fails_When_NotFound tests the case where the repository returns ErrNotFound.
 func (s *TaskUsecaseTestSuite) TestGet_Fails_When_NotFound() {
 	// ARRANGE
-	ctx := context.Background()
+	ctx := as("user-1", domain.RoleMember)
 	taskID := "non-existent-id"
 
 	// Configure the mock to return our application's standard ErrNotFound.
@@ -124,14 +209,88 @@ func (s *TaskUsecaseTestSuite) TestGet_Fails_When_NotFound() {
 	assert.ErrorIs(s.T(), err, ErrNotFound)
 }
 
+// TestGet_HidesOtherUsersTasks tests that a member cannot tell another user's task apart from a missing one.
+func (s *TaskUsecaseTestSuite) TestGet_HidesOtherUsersTasks() {
+	ctx := as("user-1", domain.RoleMember)
+	s.mockTaskRepo.On("GetByID", ctx, "task-123").Return(domain.Task{ID: "task-123", OwnerID: "user-2"}, nil)
+	s.mockShareRepo.On("Find", ctx, "task-123", "user-1").Return(domain.TaskShare{}, ErrNotFound)
+
+	_, err := s.usecase.Get(ctx, "task-123")
+
+	assert.ErrorIs(s.T(), err, ErrNotFound)
+}
+
+// TestGet_AllowsShareGrantee tests that a share makes another user's task readable.
+func (s *TaskUsecaseTestSuite) TestGet_AllowsShareGrantee() {
+	ctx := as("user-1", domain.RoleMember)
+	task := domain.Task{ID: "task-123", OwnerID: "user-2"}
+	s.mockTaskRepo.On("GetByID", ctx, "task-123").Return(task, nil)
+	s.mockShareRepo.On("Find", ctx, "task-123", "user-1").Return(domain.TaskShare{Access: domain.ShareRead}, nil)
+
+	got, err := s.usecase.Get(ctx, "task-123")
+
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), task, got)
+}
+
+// --- Test Cases for the Update Method ---
+
+// TestUpdate_KeepsOwner tests that the owner cannot be changed through an update.
+func (s *TaskUsecaseTestSuite) TestUpdate_KeepsOwner() {
+	ctx := as("manager-1", domain.RoleManager)
+	s.mockTaskRepo.On("GetByID", ctx, "task-123").Return(domain.Task{ID: "task-123", OwnerID: "user-2"}, nil)
+	s.mockShareRepo.On("Find", ctx, "task-123", "manager-1").Return(domain.TaskShare{}, ErrNotFound)
+	want := domain.Task{ID: "task-123", Title: "Edited", OwnerID: "user-2"}
+	s.mockTaskRepo.On("Update", ctx, want).Return(want, nil)
+
+	got, err := s.usecase.Update(ctx, domain.Task{ID: "task-123", Title: "Edited", OwnerID: "manager-1"})
+
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), want, got)
+}
+
+// TestUpdate_Fails_When_ActorMayOnlyRead tests that a readable task is not necessarily editable.
+func (s *TaskUsecaseTestSuite) TestUpdate_Fails_When_ActorMayOnlyRead() {
+	ctx := as("viewer-1", domain.RoleViewer)
+	s.mockTaskRepo.On("GetByID", ctx, "task-123").Return(domain.Task{ID: "task-123", OwnerID: "user-2"}, nil)
+	s.mockShareRepo.On("Find", ctx, "task-123", "viewer-1").Return(domain.TaskShare{}, ErrNotFound)
+
+	_, err := s.usecase.Update(ctx, domain.Task{ID: "task-123", Title: "Edited"})
+
+	assert.ErrorIs(s.T(), err, ErrForbidden)
+	s.mockTaskRepo.AssertNotCalled(s.T(), "Update")
+}
+
+// TestUpdate_DependsOnShareAccess tests that edit shares allow updates and read shares do not.
+func (s *TaskUsecaseTestSuite) TestUpdate_DependsOnShareAccess() {
+	ctx := as("user-1", domain.RoleMember)
+	task := domain.Task{ID: "task-123", Title: "Edited", OwnerID: "user-2"}
+	s.mockTaskRepo.On("GetByID", ctx, "task-123").Return(task, nil)
+	s.mockShareRepo.On("Find", ctx, "task-123", "user-1").Return(domain.TaskShare{Access: domain.ShareRead}, nil).Once()
+	s.mockShareRepo.On("Find", ctx, "task-123", "user-1").Return(domain.TaskShare{Access: domain.ShareEdit}, nil).Once()
+	s.mockTaskRepo.On("Update", ctx, task).Return(task, nil).Once()
+
+	_, err := s.usecase.Update(ctx, domain.Task{ID: "task-123", Title: "Edited"})
+	assert.ErrorIs(s.T(), err, ErrForbidden, "Read access must not allow updates")
+
+	_, err = s.usecase.Update(ctx, domain.Task{ID: "task-123", Title: "Edited"})
+	assert.NoError(s.T(), err, "Edit access should allow updates")
+}
+
+// --- Test Cases for the Delete Method ---
+
 // TestDelete_Success tests the happy path for deleting a task.
 func (s *TaskUsecaseTestSuite) TestDelete_Success() {
 	// ARRANGE
-	ctx := context.Background()
+	ctx := as("user-1", domain.RoleMember)
 	taskID := "task-to-delete"
 
-	// Configure the mock repository to return no error for the Delete operation.
+	// Configure the mock repository to find the actor's task and delete it without error.
+	s.mockTaskRepo.On("GetByID", ctx, taskID).Return(domain.Task{ID: taskID, OwnerID: "user-1"}, nil)
 	s.mockTaskRepo.On("Delete", ctx, taskID).Return(nil)
+	s.mockShareRepo.On("DeleteByTask", ctx, taskID).Return(nil)
+	s.mockCommentRepo.On("DeleteByTasks", ctx, []string{taskID}).Return(nil)
+	s.mockTimeRepo.On("DeleteByTasks", ctx, []string{taskID}).Return(nil)
 
 	// ACT
 	err := s.usecase.Delete(ctx, taskID)
@@ -140,14 +299,62 @@ func (s *TaskUsecaseTestSuite) TestDelete_Success() {
 	assert.NoError(s.T(), err)
 }
 
+// TestDelete_ReleasesAttachments tests that deleting a task removes attachment contents no other task uses.
+func (s *TaskUsecaseTestSuite) TestDelete_ReleasesAttachments() {
+	// ARRANGE
+	ctx := as("user-1", domain.RoleMember)
+	task := domain.Task{ID: "task-123", OwnerID: "user-1", Attachments: []domain.Attachment{
+		{ID: "a1", Digest: "shared"}, {ID: "a2", Digest: "unique"}, {ID: "a3", Digest: "unique"},
+	}}
+	s.mockTaskRepo.On("GetByID", ctx, "task-123").Return(task, nil)
+	s.mockTaskRepo.On("Delete", ctx, "task-123").Return(nil)
+	s.mockShareRepo.On("DeleteByTask", ctx, "task-123").Return(nil)
+	s.mockCommentRepo.On("DeleteByTasks", ctx, []string{"task-123"}).Return(nil)
+	s.mockTimeRepo.On("DeleteByTasks", ctx, []string{"task-123"}).Return(nil)
+	s.mockTaskRepo.On("CountAttachmentsByDigest", ctx, "shared").Return(int64(1), nil).Once()
+	s.mockTaskRepo.On("CountAttachmentsByDigest", ctx, "unique").Return(int64(0), nil).Once()
+	s.mockBlobStore.On("Delete", ctx, "unique").Return(nil).Once()
+
+	// ACT
+	err := s.usecase.Delete(ctx, "task-123")
+
+	// ASSERT
+	assert.NoError(s.T(), err)
+	s.mockBlobStore.AssertNotCalled(s.T(), "Delete", ctx, "shared")
+}
+
+// TestDelete_RemovesRunningTimers tests that deleting a task while a timer runs on it removes the task's time
+// entries, and that a failure to do so is reported with the task kept, so that the deletion can be retried.
+func (s *TaskUsecaseTestSuite) TestDelete_RemovesRunningTimers() {
+	// ARRANGE
+	ctx := as("user-1", domain.RoleMember)
+	repoError := errors.New("connection reset")
+	s.mockTaskRepo.On("GetByID", ctx, "task-123").Return(domain.Task{ID: "task-123", OwnerID: "user-1"}, nil)
+	s.mockShareRepo.On("DeleteByTask", ctx, "task-123").Return(nil)
+	s.mockCommentRepo.On("DeleteByTasks", ctx, []string{"task-123"}).Return(nil)
+	s.mockTimeRepo.On("DeleteByTasks", ctx, []string{"task-123"}).Return(repoError).Once()
+
+	// ACT
+	err := s.usecase.Delete(ctx, "task-123")
+
+	// ASSERT
+	assert.ErrorIs(s.T(), err, repoError)
+	s.mockTimeRepo.AssertExpectations(s.T())
+	s.mockTaskRepo.AssertNotCalled(s.T(), "Delete", mock.Anything, mock.Anything)
+}
+
 // TestDelete_Fails_When_RepositoryFails tests error propagation for the Delete operation.
 func (s *TaskUsecaseTestSuite) TestDelete_Fails_When_RepositoryFails() {
 	// ARRANGE
-	ctx := context.Background()
+	ctx := as("user-1", domain.RoleMember)
 	taskID := "task-to-delete"
 	repoError := errors.New("permission denied")
 
-	// Configure the mock repository to return an error.
+	// Configure the mock repository to return an error once the task's shares, comments and time are gone.
+	s.mockTaskRepo.On("GetByID", ctx, taskID).Return(domain.Task{ID: taskID, OwnerID: "user-1"}, nil)
+	s.mockShareRepo.On("DeleteByTask", ctx, taskID).Return(nil)
+	s.mockCommentRepo.On("DeleteByTasks", ctx, []string{taskID}).Return(nil)
+	s.mockTimeRepo.On("DeleteByTasks", ctx, []string{taskID}).Return(nil)
 	s.mockTaskRepo.On("Delete", ctx, taskID).Return(repoError)
 
 	// ACT
@@ -157,3 +364,231 @@ func (s *TaskUsecaseTestSuite) TestDelete_Fails_When_RepositoryFails() {
 	assert.Error(s.T(), err)
 	assert.ErrorIs(s.T(), err, repoError)
 }
+
+// TestDelete_RequiresDeleteAny tests that managers may edit but not delete other users' tasks under the default policy.
+func (s *TaskUsecaseTestSuite) TestDelete_RequiresDeleteAny() {
+	manager := as("manager-1", domain.RoleManager)
+	admin := as("admin-1", domain.RoleAdmin)
+	other := domain.Task{ID: "task-123", OwnerID: "user-2"}
+	s.mockTaskRepo.On("GetByID", manager, "task-123").Return(other, nil)
+	s.mockTaskRepo.On("GetByID", admin, "task-123").Return(other, nil)
+	s.mockShareRepo.On("Find", mock.Anything, "task-123", mock.Anything).Return(domain.TaskShare{}, ErrNotFound)
+	s.mockTaskRepo.On("Delete", admin, "task-123").Return(nil)
+	s.mockShareRepo.On("DeleteByTask", admin, "task-123").Return(nil)
+	s.mockCommentRepo.On("DeleteByTasks", admin, []string{"task-123"}).Return(nil)
+	s.mockTimeRepo.On("DeleteByTasks", admin, []string{"task-123"}).Return(nil)
+
+	assert.ErrorIs(s.T(), s.usecase.Delete(manager, "task-123"), ErrForbidden)
+	assert.NoError(s.T(), s.usecase.Delete(admin, "task-123"))
+}
+
+// TestDelete_Fails_When_ActorOnlyHasEditShare tests that edit access does not include deleting the task.
+func (s *TaskUsecaseTestSuite) TestDelete_Fails_When_ActorOnlyHasEditShare() {
+	ctx := as("user-1", domain.RoleMember)
+	s.mockTaskRepo.On("GetByID", ctx, "task-123").Return(domain.Task{ID: "task-123", OwnerID: "user-2"}, nil)
+	s.mockShareRepo.On("Find", ctx, "task-123", "user-1").Return(domain.TaskShare{Access: domain.ShareEdit}, nil)
+
+	err := s.usecase.Delete(ctx, "task-123")
+
+	assert.ErrorIs(s.T(), err, ErrForbidden)
+	s.mockTaskRepo.AssertNotCalled(s.T(), "Delete")
+}
+
+// --- Test Cases for Sharing ---
+
+// TestShare_Success tests that the owner can grant access to another user.
+func (s *TaskUsecaseTestSuite) TestShare_Success() {
+	// ARRANGE
+	ctx := as("user-1", domain.RoleMember)
+	s.mockTaskRepo.On("GetByID", ctx, "task-123").Return(domain.Task{ID: "task-123", OwnerID: "user-1"}, nil)
+	s.mockUserRepo.On("FindByUsername", ctx, "bob").Return(domain.User{ID: "user-2", Username: "bob"}, nil)
+	want := domain.TaskShare{TaskID: "task-123", UserID: "user-2", Username: "bob", Access: domain.ShareEdit, GrantedBy: "user-1", CreatedAt: s.now}
+	s.mockShareRepo.On("Upsert", ctx, want).Return(want, nil)
+
+	// ACT
+	share, err := s.usecase.Share(ctx, "task-123", "bob", domain.ShareEdit)
+
+	// ASSERT
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), want, share)
+}
+
+// TestShare_Fails_When_RequestIsInvalid tests the validation of share requests made by the owner.
+func (s *TaskUsecaseTestSuite) TestShare_Fails_When_RequestIsInvalid() {
+	ctx := as("user-1", domain.RoleMember)
+	s.mockTaskRepo.On("GetByID", ctx, "task-123").Return(domain.Task{ID: "task-123", OwnerID: "user-1"}, nil)
+	s.mockUserRepo.On("FindByUsername", ctx, "ghost").Return(domain.User{}, ErrNotFound)
+	s.mockUserRepo.On("FindByUsername", ctx, "alice").Return(domain.User{ID: "user-1", Username: "alice"}, nil)
+
+	cases := map[string]struct {
+		username string
+		access   domain.ShareAccess
+		reason   string
+	}{
+		"bad access":    {"bob", "admin", "access must be read or edit"},
+		"unknown user":  {"ghost", domain.ShareRead, "unknown user ghost"},
+		"sharing owner": {"alice", domain.ShareRead, "a task cannot be shared with its owner"},
+	}
+	for name, tc := range cases {
+		s.Run(name, func() {
+			_, err := s.usecase.Share(ctx, "task-123", tc.username, tc.access)
+
+			var reqErr *ShareRequestError
+			assert.ErrorAs(s.T(), err, &reqErr)
+			assert.Equal(s.T(), tc.reason, reqErr.Reason)
+			assert.ErrorIs(s.T(), err, ErrInvalidShareRequest)
+		})
+	}
+	s.mockShareRepo.AssertNotCalled(s.T(), "Upsert")
+}
+
+// TestShare_ForbiddenVsNotFound tests that grantees cannot reshare and strangers cannot tell the task exists.
+func (s *TaskUsecaseTestSuite) TestShare_ForbiddenVsNotFound() {
+	grantee := as("user-1", domain.RoleMember)
+	stranger := as("user-3", domain.RoleMember)
+	task := domain.Task{ID: "task-123", OwnerID: "user-2"}
+	s.mockTaskRepo.On("GetByID", mock.Anything, "task-123").Return(task, nil)
+	s.mockShareRepo.On("Find", grantee, "task-123", "user-1").Return(domain.TaskShare{Access: domain.ShareEdit}, nil)
+	s.mockShareRepo.On("Find", stranger, "task-123", "user-3").Return(domain.TaskShare{}, ErrNotFound)
+
+	_, err := s.usecase.Share(grantee, "task-123", "carol", domain.ShareRead)
+	assert.ErrorIs(s.T(), err, ErrForbidden, "Edit access does not include managing shares")
+
+	_, err = s.usecase.Share(stranger, "task-123", "carol", domain.ShareRead)
+	assert.ErrorIs(s.T(), err, ErrNotFound, "A task the caller cannot read must look missing")
+
+	assert.ErrorIs(s.T(), s.usecase.Unshare(stranger, "task-123", "user-1"), ErrNotFound)
+}
+
+// TestListShares_Success tests that grantees can see who else the task is shared with.
+func (s *TaskUsecaseTestSuite) TestListShares_Success() {
+	ctx := as("user-1", domain.RoleMember)
+	shares := []domain.TaskShare{{TaskID: "task-123", UserID: "user-1", Username: "alice", Access: domain.ShareRead}}
+	s.mockTaskRepo.On("GetByID", ctx, "task-123").Return(domain.Task{ID: "task-123", OwnerID: "user-2"}, nil)
+	s.mockShareRepo.On("Find", ctx, "task-123", "user-1").Return(shares[0], nil)
+	s.mockShareRepo.On("ListByTask", ctx, "task-123").Return(shares, nil)
+
+	got, err := s.usecase.ListShares(ctx, "task-123")
+
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), shares, got)
+}
+
+// TestUnshare tests revoking a share and reporting shares that do not exist.
+func (s *TaskUsecaseTestSuite) TestUnshare() {
+	ctx := as("user-1", domain.RoleMember)
+	s.mockTaskRepo.On("GetByID", ctx, "task-123").Return(domain.Task{ID: "task-123", OwnerID: "user-1"}, nil)
+	s.mockUserRepo.On("FindByUsername", ctx, "bob").Return(domain.User{ID: "user-2", Username: "bob"}, nil)
+	s.mockUserRepo.On("FindByUsername", ctx, "ghost").Return(domain.User{}, ErrNotFound)
+	s.mockShareRepo.On("Delete", ctx, "task-123", "user-2").Return(nil).Once()
+	s.mockShareRepo.On("Delete", ctx, "task-123", "user-2").Return(ErrNotFound).Once()
+
+	assert.NoError(s.T(), s.usecase.Unshare(ctx, "task-123", "bob"))
+	assert.ErrorIs(s.T(), s.usecase.Unshare(ctx, "task-123", "bob"), ErrShareNotFound, "A second revoke finds nothing")
+	assert.ErrorIs(s.T(), s.usecase.Unshare(ctx, "task-123", "ghost"), ErrShareNotFound)
+}
+
+// --- Test Cases for Project Tasks ---
+
+// teamProject is a project with one member of every project role.
+var teamProject = domain.Project{ID: "proj-1", Name: "Team", Members: []domain.ProjectMember{
+	{UserID: "owner-1", Username: "olivia", Role: domain.ProjectOwner},
+	{UserID: "editor-1", Username: "eddie", Role: domain.ProjectEditor},
+	{UserID: "viewer-1", Username: "vera", Role: domain.ProjectViewer},
+}}
+
+// TestListByProject tests that members list the project's tasks and everyone else is told the project is missing.
+func (s *TaskUsecaseTestSuite) TestListByProject() {
+	tasks := []domain.Task{{ID: "t1", OwnerID: "owner-1", ProjectID: "proj-1"}}
+	s.mockProjectRepo.On("GetByID", mock.Anything, "proj-1").Return(teamProject, nil)
+	s.mockTaskRepo.On("GetByProject", mock.Anything, "proj-1").Return(tasks, nil).Once()
+
+	got, err := s.usecase.ListByProject(as("viewer-1", domain.RoleMember), "proj-1")
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), tasks, got)
+
+	_, err = s.usecase.ListByProject(as("admin-1", domain.RoleAdmin), "proj-1")
+	assert.ErrorIs(s.T(), err, ErrProjectNotFound, "Global permissions do not reach into projects")
+}
+
+// TestProjectTask_AccessFollowsMembership tests that project roles decide who may read and change a project task.
+func (s *TaskUsecaseTestSuite) TestProjectTask_AccessFollowsMembership() {
+	task := domain.Task{ID: "task-123", Title: "Plan", OwnerID: "owner-1", ProjectID: "proj-1"}
+	s.mockTaskRepo.On("GetByID", mock.Anything, "task-123").Return(task, nil)
+	s.mockProjectRepo.On("GetByID", mock.Anything, "proj-1").Return(teamProject, nil)
+
+	cases := []struct {
+		name      string
+		actor     context.Context
+		readErr   error
+		updateErr error
+	}{
+		{"editor", as("editor-1", domain.RoleMember), nil, nil},
+		{"viewer", as("viewer-1", domain.RoleMember), nil, ErrForbidden},
+		{"editor with read-only global role", as("editor-1", domain.RoleViewer), nil, ErrForbidden},
+		{"non-member manager", as("manager-1", domain.RoleManager), ErrNotFound, ErrNotFound},
+	}
+	for _, tc := range cases {
+		s.Run(tc.name, func() {
+			want := task
+			want.Title = "Edited"
+			if tc.updateErr == nil {
+				s.mockTaskRepo.On("Update", tc.actor, want).Return(want, nil).Once()
+			}
+
+			_, err := s.usecase.Get(tc.actor, "task-123")
+			assert.ErrorIs(s.T(), err, tc.readErr)
+
+			_, err = s.usecase.Update(tc.actor, domain.Task{ID: "task-123", Title: "Edited"})
+			assert.ErrorIs(s.T(), err, tc.updateErr)
+		})
+	}
+	s.mockShareRepo.AssertNotCalled(s.T(), "Find")
+}
+
+// TestCreate_InProject tests that only editors and owners can add tasks to a project.
+func (s *TaskUsecaseTestSuite) TestCreate_InProject() {
+	s.mockProjectRepo.On("GetByID", mock.Anything, "proj-1").Return(teamProject, nil)
+	editor := as("editor-1", domain.RoleMember)
+	want := domain.Task{Title: "Plan", OwnerID: "editor-1", ProjectID: "proj-1"}
+	s.mockTaskRepo.On("Create", editor, want).Return(want, nil).Once()
+
+	_, err := s.usecase.Create(editor, domain.Task{Title: "Plan", ProjectID: "proj-1"})
+	assert.NoError(s.T(), err)
+
+	_, err = s.usecase.Create(as("viewer-1", domain.RoleMember), domain.Task{Title: "Plan", ProjectID: "proj-1"})
+	assert.ErrorIs(s.T(), err, ErrForbidden)
+
+	_, err = s.usecase.Create(as("user-9", domain.RoleMember), domain.Task{Title: "Plan", ProjectID: "proj-1"})
+	assert.ErrorIs(s.T(), err, ErrProjectNotFound)
+}
+
+// TestShare_Fails_When_TaskIsInProject tests that project tasks cannot be shared outside the project.
+func (s *TaskUsecaseTestSuite) TestShare_Fails_When_TaskIsInProject() {
+	ctx := as("owner-1", domain.RoleMember)
+	s.mockTaskRepo.On("GetByID", ctx, "task-123").Return(domain.Task{ID: "task-123", OwnerID: "owner-1", ProjectID: "proj-1"}, nil)
+	s.mockProjectRepo.On("GetByID", ctx, "proj-1").Return(teamProject, nil)
+
+	_, err := s.usecase.Share(ctx, "task-123", "bob", domain.ShareRead)
+
+	assert.ErrorIs(s.T(), err, ErrInvalidShareRequest)
+	s.mockShareRepo.AssertNotCalled(s.T(), "Upsert")
+}
+
+// --- Test Cases for the GetForUpdate Method ---
+
+// TestGetForUpdate tests that read-only access is forbidden and edit access is allowed.
+func (s *TaskUsecaseTestSuite) TestGetForUpdate() {
+	ctx := as("user-1", domain.RoleMember)
+	task := domain.Task{ID: "task-123", OwnerID: "user-2"}
+	s.mockTaskRepo.On("GetByID", ctx, "task-123").Return(task, nil)
+	s.mockShareRepo.On("Find", ctx, "task-123", "user-1").Return(domain.TaskShare{Access: domain.ShareRead}, nil).Once()
+	s.mockShareRepo.On("Find", ctx, "task-123", "user-1").Return(domain.TaskShare{Access: domain.ShareEdit}, nil).Once()
+
+	_, err := s.usecase.GetForUpdate(ctx, "task-123")
+	assert.ErrorIs(s.T(), err, ErrForbidden)
+
+	got, err := s.usecase.GetForUpdate(ctx, "task-123")
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), task, got)
+}
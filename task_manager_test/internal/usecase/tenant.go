@@ -0,0 +1,44 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrNoTenant is returned by tenant-scoped repositories when the context names no organization. It signals a
+// programming error, a query path that forgot to establish its tenant, and is reported as an internal error.
+var ErrNoTenant = errors.New("no tenant in context")
+
+// TenantScope selects the organizations a repository call may read and write.
+type TenantScope struct {
+	// OrgID is the organization the call is confined to.
+	OrgID string
+	// All lifts the confinement. It is meant for the few cross-tenant operations: logging in by globally unique
+	// username, resolving a token's user, and maintenance run by operators.
+	All bool
+}
+
+// tenantKey is the context key under which an explicit TenantScope is stored.
+type tenantKey struct{}
+
+// WithTenant returns a copy of ctx confined to the organization.
+func WithTenant(ctx context.Context, orgID string) context.Context {
+	return context.WithValue(ctx, tenantKey{}, TenantScope{OrgID: orgID})
+}
+
+// WithAllTenants returns a copy of ctx that may read and write every organization. Use it sparingly.
+func WithAllTenants(ctx context.Context) context.Context {
+	return context.WithValue(ctx, tenantKey{}, TenantScope{All: true})
+}
+
+// TenantFromContext returns the tenant scope of ctx: the one set by WithTenant or WithAllTenants if any, and
+// otherwise the organization of the actor. It reports false when ctx names no organization at all.
+func TenantFromContext(ctx context.Context) (TenantScope, bool) {
+	if scope, ok := ctx.Value(tenantKey{}).(TenantScope); ok {
+		return scope, true
+	}
+	if actor, ok := ActorFromContext(ctx); ok && actor.OrgID != "" {
+		return TenantScope{OrgID: actor.OrgID}, true
+	}
+	return TenantScope{}, false
+}
@@ -0,0 +1,289 @@
+package usecase
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"task_manager_test/internal/domain"
+	"time"
+	"unicode/utf8"
+)
+
+// Limits applied to time tracking.
+const (
+	// MaxTimeEntryNoteLength is the maximum number of characters in a time entry note.
+	MaxTimeEntryNoteLength = 500
+	// MaxTimeEntryDuration caps the length of a manual time entry.
+	MaxTimeEntryDuration = 24 * time.Hour
+	// MaxTimesheetDays caps the number of days a timesheet covers.
+	MaxTimesheetDays = 366
+)
+
+// TimeUsecase defines application-level operations for tracking the time spent on tasks.
+type TimeUsecase interface {
+	// StartTimer starts the actor's timer on the task. It returns ErrTimerRunning while another of the actor's
+	// timers is running, on this task or any other.
+	StartTimer(ctx context.Context, taskID, note string) (domain.TimeEntry, error)
+	// StopTimer stops the actor's timer on the task, returning ErrTimerNotRunning if there is none.
+	StopTimer(ctx context.Context, taskID string) (domain.TimeEntry, error)
+	// LogTime records time the actor spent on the task without a timer.
+	LogTime(ctx context.Context, taskID string, start, end time.Time, note string) (domain.TimeEntry, error)
+	// TaskTime returns the time every user logged against the task.
+	TaskTime(ctx context.Context, taskID string) (domain.TaskTime, error)
+	// Timesheet returns the time the actor logged between the from and to dates, inclusive, given as YYYY-MM-DD in
+	// the actor's time zone. When both are empty, it covers the current week from Monday to Sunday.
+	Timesheet(ctx context.Context, from, to string) (domain.Timesheet, error)
+}
+
+// timeUsecase implements TimeUsecase. Time is logged by users who may update the task, and read by those who may
+// read it; tasks the actor cannot read are reported as ErrNotFound by the TaskUsecase. A user can always stop
+// their own timer, even after losing access to the task, since it would otherwise keep them from starting another.
+type timeUsecase struct {
+	repo   ITimeEntryRepository
+	tasks  TaskUsecase
+	users  IUserRepository
+	access *AccessPolicy
+	now    func() time.Time
+}
+
+// NewTimeUsecase constructs a new TimeUsecase.
+func NewTimeUsecase(repo ITimeEntryRepository, tasks TaskUsecase, users IUserRepository, access *AccessPolicy) TimeUsecase {
+	return &timeUsecase{repo: repo, tasks: tasks, users: users, access: access, now: time.Now}
+}
+
+// StartTimer stores a running entry. The repository enforces the single running timer atomically; the running
+// timer is only looked up afterwards, to tell the actor which task it is on.
+func (u *timeUsecase) StartTimer(ctx context.Context, taskID, note string) (domain.TimeEntry, error) {
+	if _, err := u.tasks.GetForUpdate(ctx, taskID); err != nil {
+		return domain.TimeEntry{}, err
+	}
+	actor, _ := ActorFromContext(ctx)
+	note, err := validateTimeEntryNote(note)
+	if err != nil {
+		return domain.TimeEntry{}, err
+	}
+	now := u.now()
+	entry, err := u.repo.Start(ctx, domain.TimeEntry{
+		TaskID:    taskID,
+		UserID:    actor.UserID,
+		Username:  actor.Username,
+		Start:     now,
+		Note:      note,
+		CreatedAt: now,
+	})
+	if errors.Is(err, ErrTimerRunning) {
+		running, findErr := u.repo.FindRunning(ctx, actor.UserID)
+		if findErr != nil {
+			return domain.TimeEntry{}, err
+		}
+		if running.TaskID == taskID {
+			return domain.TimeEntry{}, WithDetail(ErrTimerRunning, "a timer is already running on this task")
+		}
+		return domain.TimeEntry{}, WithDetail(ErrTimerRunning, fmt.Sprintf("a timer is already running on task %s; stop it first", running.TaskID))
+	}
+	return entry, err
+}
+
+// StopTimer ends the actor's running timer on the task now. The task itself is not checked, as the timer only ever
+// belongs to the actor.
+func (u *timeUsecase) StopTimer(ctx context.Context, taskID string) (domain.TimeEntry, error) {
+	actor, _ := ActorFromContext(ctx)
+	entry, err := u.repo.Stop(ctx, actor.UserID, taskID, u.now())
+	if errors.Is(err, ErrNotFound) {
+		return domain.TimeEntry{}, ErrTimerNotRunning
+	}
+	return entry, err
+}
+
+// LogTime stores a manual entry. Entries must end after they start, may not lie in the future, and may not be longer
+// than MaxTimeEntryDuration.
+func (u *timeUsecase) LogTime(ctx context.Context, taskID string, start, end time.Time, note string) (domain.TimeEntry, error) {
+	if _, err := u.tasks.GetForUpdate(ctx, taskID); err != nil {
+		return domain.TimeEntry{}, err
+	}
+	actor, _ := ActorFromContext(ctx)
+	note, err := validateTimeEntryNote(note)
+	if err != nil {
+		return domain.TimeEntry{}, err
+	}
+	now := u.now()
+	switch {
+	case !end.After(start):
+		return domain.TimeEntry{}, &TimeEntryRequestError{Reason: "end must be after start"}
+	case end.After(now):
+		return domain.TimeEntry{}, &TimeEntryRequestError{Reason: "time cannot be logged in the future"}
+	case end.Sub(start) > MaxTimeEntryDuration:
+		return domain.TimeEntry{}, &TimeEntryRequestError{Reason: fmt.Sprintf("a time entry cannot be longer than %d hours", int(MaxTimeEntryDuration.Hours()))}
+	}
+	return u.repo.Create(ctx, domain.TimeEntry{
+		TaskID:    taskID,
+		UserID:    actor.UserID,
+		Username:  actor.Username,
+		Start:     start,
+		End:       end,
+		Note:      note,
+		Manual:    true,
+		CreatedAt: now,
+	})
+}
+
+// TaskTime lists the task's entries and totals them, counting running timers up to now.
+func (u *timeUsecase) TaskTime(ctx context.Context, taskID string) (domain.TaskTime, error) {
+	if _, err := u.tasks.Get(ctx, taskID); err != nil {
+		return domain.TaskTime{}, err
+	}
+	entries, err := u.repo.ListByTask(ctx, taskID)
+	if err != nil {
+		return domain.TaskTime{}, err
+	}
+	now := u.now()
+	result := domain.TaskTime{Entries: entries}
+	for _, e := range entries {
+		result.Total += e.Duration(now)
+	}
+	return result, nil
+}
+
+// titles looks up the title of each task through the access checks of TaskUsecase.Get, so that a timesheet does not
+// reveal the titles of tasks the actor can no longer read; those get domain.HiddenTaskTitle.
+func (u *timeUsecase) titles(ctx context.Context, taskIDs []string) (map[string]string, error) {
+	titles := make(map[string]string, len(taskIDs))
+	for _, id := range taskIDs {
+		t, err := u.tasks.Get(ctx, id)
+		switch {
+		case errors.Is(err, ErrNotFound), errors.Is(err, ErrForbidden):
+			titles[id] = domain.HiddenTaskTitle
+		case err != nil:
+			return nil, err
+		default:
+			titles[id] = t.Title
+		}
+	}
+	return titles, nil
+}
+
+// Timesheet clips the actor's entries to the range and splits them at midnight in the actor's time zone, counting
+// running timers up to now. Days are listed in order and their tasks by title.
+func (u *timeUsecase) Timesheet(ctx context.Context, from, to string) (domain.Timesheet, error) {
+	actor, err := u.access.Authorize(ctx, domain.PermTaskReadOwn)
+	if err != nil {
+		return domain.Timesheet{}, err
+	}
+	usr, err := u.users.FindByID(ctx, actor.UserID)
+	if err != nil {
+		return domain.Timesheet{}, err
+	}
+	loc, err := LoadTimeZone(usr.TimeZone)
+	if err != nil {
+		return domain.Timesheet{}, err
+	}
+	now := u.now()
+	first, last, err := timesheetBounds(from, to, now, loc)
+	if err != nil {
+		return domain.Timesheet{}, err
+	}
+	end := last.AddDate(0, 0, 1)
+
+	entries, err := u.repo.ListByUser(ctx, actor.UserID, first, end)
+	if err != nil {
+		return domain.Timesheet{}, err
+	}
+	sheet := domain.Timesheet{Location: loc, From: first, To: last}
+	days := make(map[time.Time]map[string]time.Duration)
+	var taskIDs []string
+	for _, e := range entries {
+		start := maxTime(e.Start, first)
+		stop := e.End
+		if e.Running() {
+			stop = now
+		}
+		stop = minTime(stop, end)
+		for start.Before(stop) {
+			local := start.In(loc)
+			day := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc)
+			chunk := minTime(stop, day.AddDate(0, 0, 1)).Sub(start)
+			if days[day] == nil {
+				days[day] = make(map[string]time.Duration)
+			}
+			if !slices.Contains(taskIDs, e.TaskID) {
+				taskIDs = append(taskIDs, e.TaskID)
+			}
+			days[day][e.TaskID] += chunk
+			sheet.Total += chunk
+			start = start.Add(chunk)
+		}
+	}
+	if len(days) == 0 {
+		return sheet, nil
+	}
+
+	titles, err := u.titles(ctx, taskIDs)
+	if err != nil {
+		return domain.Timesheet{}, err
+	}
+	for day, byTask := range days {
+		d := domain.TimesheetDay{Date: day}
+		for taskID, spent := range byTask {
+			d.Tasks = append(d.Tasks, domain.TimesheetTask{TaskID: taskID, Title: titles[taskID], Duration: spent})
+			d.Total += spent
+		}
+		slices.SortFunc(d.Tasks, func(a, b domain.TimesheetTask) int {
+			return cmp.Or(strings.Compare(a.Title, b.Title), strings.Compare(a.TaskID, b.TaskID))
+		})
+		sheet.Days = append(sheet.Days, d)
+	}
+	slices.SortFunc(sheet.Days, func(a, b domain.TimesheetDay) int { return a.Date.Compare(b.Date) })
+	return sheet, nil
+}
+
+// timesheetBounds parses the first and last day of a timesheet in loc, defaulting to the week of now.
+func timesheetBounds(from, to string, now time.Time, loc *time.Location) (first, last time.Time, err error) {
+	if from == "" && to == "" {
+		monday, sunday, _ := agendaBounds(domain.AgendaWeek, now, loc)
+		return monday, sunday.AddDate(0, 0, -1), nil
+	}
+	if from == "" || to == "" {
+		return time.Time{}, time.Time{}, &TimeEntryRequestError{Reason: "from and to must be given together"}
+	}
+	if first, err = time.ParseInLocation(time.DateOnly, from, loc); err != nil {
+		return time.Time{}, time.Time{}, &TimeEntryRequestError{Reason: "from must be a date in YYYY-MM-DD format"}
+	}
+	if last, err = time.ParseInLocation(time.DateOnly, to, loc); err != nil {
+		return time.Time{}, time.Time{}, &TimeEntryRequestError{Reason: "to must be a date in YYYY-MM-DD format"}
+	}
+	if last.Before(first) {
+		return time.Time{}, time.Time{}, &TimeEntryRequestError{Reason: "to must not be before from"}
+	}
+	if first.AddDate(0, 0, MaxTimesheetDays).Before(last.AddDate(0, 0, 1)) {
+		return time.Time{}, time.Time{}, &TimeEntryRequestError{Reason: fmt.Sprintf("a timesheet cannot cover more than %d days", MaxTimesheetDays)}
+	}
+	return first, last, nil
+}
+
+// validateTimeEntryNote trims the note and checks its length.
+func validateTimeEntryNote(note string) (string, error) {
+	note = strings.TrimSpace(note)
+	if utf8.RuneCountInString(note) > MaxTimeEntryNoteLength {
+		return "", &TimeEntryRequestError{Reason: fmt.Sprintf("note must be at most %d characters", MaxTimeEntryNoteLength)}
+	}
+	return note, nil
+}
+
+// minTime returns the earlier of a and b.
+func minTime(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
+
+// maxTime returns the later of a and b.
+func maxTime(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,291 @@
+package usecase
+
+import (
+	"strings"
+	"task_manager_test/internal/domain"
+	"task_manager_test/internal/mocks"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+// TimeUsecaseTestSuite defines the test suite for the time tracking use case.
+type TimeUsecaseTestSuite struct {
+	suite.Suite
+	mockTimeRepo *mocks.ITimeEntryRepository
+	mockTasks    *mocks.TaskUsecase
+	mockUserRepo *mocks.IUserRepository
+	usecase      TimeUsecase
+	now          time.Time
+}
+
+// SetupTest runs before EACH test in the suite.
+func (s *TimeUsecaseTestSuite) SetupTest() {
+	s.mockTimeRepo = mocks.NewITimeEntryRepository(s.T())
+	s.mockTasks = mocks.NewTaskUsecase(s.T())
+	s.mockUserRepo = mocks.NewIUserRepository(s.T())
+
+	s.usecase = NewTimeUsecase(s.mockTimeRepo, s.mockTasks, s.mockUserRepo, DefaultAccessPolicy())
+
+	s.now = time.Date(2025, 1, 2, 12, 0, 0, 0, time.UTC)
+	s.usecase.(*timeUsecase).now = func() time.Time { return s.now }
+}
+
+// TestTimeUsecaseTestSuite is the Go test runner's entry point for this suite.
+func TestTimeUsecaseTestSuite(t *testing.T) {
+	suite.Run(t, new(TimeUsecaseTestSuite))
+}
+
+// updatableTask makes task-123 updatable by every actor.
+func (s *TimeUsecaseTestSuite) updatableTask() {
+	s.mockTasks.On("GetForUpdate", mock.Anything, "task-123").Return(domain.Task{ID: "task-123"}, nil)
+}
+
+// --- Test Cases for the StartTimer Method ---
+
+// TestStartTimer_Success tests that a running entry is stored for the actor with a trimmed note.
+func (s *TimeUsecaseTestSuite) TestStartTimer_Success() {
+	// ARRANGE
+	ctx := as("user-1", domain.RoleMember)
+	s.updatableTask()
+	want := domain.TimeEntry{TaskID: "task-123", UserID: "user-1", Username: "user-1", Start: s.now, Note: "review", CreatedAt: s.now}
+	s.mockTimeRepo.On("Start", ctx, want).Return(want, nil).Once()
+
+	// ACT
+	entry, err := s.usecase.StartTimer(ctx, "task-123", "  review ")
+
+	// ASSERT
+	assert.NoError(s.T(), err)
+	assert.True(s.T(), entry.Running())
+}
+
+// TestStartTimer_Fails_When_TimerRunning tests that the conflict names the task of the running timer.
+func (s *TimeUsecaseTestSuite) TestStartTimer_Fails_When_TimerRunning() {
+	ctx := as("user-1", domain.RoleMember)
+	s.updatableTask()
+	s.mockTimeRepo.On("Start", ctx, mock.Anything).Return(domain.TimeEntry{}, ErrTimerRunning)
+	s.mockTimeRepo.On("FindRunning", ctx, "user-1").Return(domain.TimeEntry{TaskID: "task-9"}, nil)
+
+	_, err := s.usecase.StartTimer(ctx, "task-123", "")
+
+	assert.ErrorIs(s.T(), err, ErrTimerRunning)
+	_, detail := Describe(err)
+	assert.Equal(s.T(), "a timer is already running on task task-9; stop it first", detail)
+}
+
+// TestStartTimer_Fails_When_TaskIsReadOnly tests that time is only logged by users who may update the task.
+func (s *TimeUsecaseTestSuite) TestStartTimer_Fails_When_TaskIsReadOnly() {
+	ctx := as("user-1", domain.RoleMember)
+	s.mockTasks.On("GetForUpdate", ctx, "task-123").Return(domain.Task{}, ErrForbidden)
+
+	_, err := s.usecase.StartTimer(ctx, "task-123", "")
+
+	assert.ErrorIs(s.T(), err, ErrForbidden)
+	s.mockTimeRepo.AssertNotCalled(s.T(), "Start", mock.Anything, mock.Anything)
+}
+
+// --- Test Cases for the StopTimer Method ---
+
+// TestStopTimer tests that the timer is stopped now without consulting the task, so that a timer on a task the
+// actor has lost access to can still be stopped, and that stopping a timer that is not running conflicts.
+func (s *TimeUsecaseTestSuite) TestStopTimer() {
+	ctx := as("user-1", domain.RoleMember)
+	stopped := domain.TimeEntry{ID: "entry-1", Start: s.now.Add(-time.Hour), End: s.now}
+	s.mockTimeRepo.On("Stop", ctx, "user-1", "task-123", s.now).Return(stopped, nil).Once()
+
+	entry, err := s.usecase.StopTimer(ctx, "task-123")
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), stopped, entry)
+
+	s.mockTimeRepo.On("Stop", ctx, "user-1", "task-123", s.now).Return(domain.TimeEntry{}, ErrNotFound).Once()
+	_, err = s.usecase.StopTimer(ctx, "task-123")
+	assert.ErrorIs(s.T(), err, ErrTimerNotRunning)
+}
+
+// --- Test Cases for the LogTime Method ---
+
+// TestLogTime_Success tests that a manual entry is stored.
+func (s *TimeUsecaseTestSuite) TestLogTime_Success() {
+	ctx := as("user-1", domain.RoleMember)
+	s.updatableTask()
+	start, end := s.now.Add(-3*time.Hour), s.now.Add(-time.Hour)
+	want := domain.TimeEntry{TaskID: "task-123", UserID: "user-1", Username: "user-1", Start: start, End: end, Manual: true, CreatedAt: s.now}
+	s.mockTimeRepo.On("Create", ctx, want).Return(want, nil).Once()
+
+	entry, err := s.usecase.LogTime(ctx, "task-123", start, end, "")
+
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), 2*time.Hour, entry.Duration(s.now))
+}
+
+// TestLogTime_Fails_When_Invalid tests the rules manual entries must follow.
+func (s *TimeUsecaseTestSuite) TestLogTime_Fails_When_Invalid() {
+	cases := []struct {
+		name       string
+		start, end time.Time
+		note       string
+		wantDetail string
+	}{
+		{"end before start", s.now.Add(-time.Hour), s.now.Add(-2 * time.Hour), "", "end must be after start"},
+		{"empty", s.now.Add(-time.Hour), s.now.Add(-time.Hour), "", "end must be after start"},
+		{"in the future", s.now.Add(-time.Hour), s.now.Add(time.Minute), "", "time cannot be logged in the future"},
+		{"too long", s.now.Add(-25 * time.Hour), s.now, "", "a time entry cannot be longer than 24 hours"},
+		{"long note", s.now.Add(-time.Hour), s.now, strings.Repeat("x", MaxTimeEntryNoteLength+1), "note must be at most 500 characters"},
+	}
+	for _, tc := range cases {
+		s.Run(tc.name, func() {
+			s.updatableTask()
+
+			_, err := s.usecase.LogTime(as("user-1", domain.RoleMember), "task-123", tc.start, tc.end, tc.note)
+
+			assert.ErrorIs(s.T(), err, ErrInvalidTimeEntryRequest)
+			_, detail := Describe(err)
+			assert.Equal(s.T(), tc.wantDetail, detail)
+		})
+	}
+}
+
+// --- Test Cases for the TaskTime Method ---
+
+// TestTaskTime tests that the total counts running timers up to now.
+func (s *TimeUsecaseTestSuite) TestTaskTime() {
+	ctx := as("user-1", domain.RoleMember)
+	s.mockTasks.On("Get", ctx, "task-123").Return(domain.Task{ID: "task-123"}, nil)
+	entries := []domain.TimeEntry{
+		{ID: "done", Start: s.now.Add(-5 * time.Hour), End: s.now.Add(-4 * time.Hour)},
+		{ID: "running", Start: s.now.Add(-30 * time.Minute)},
+	}
+	s.mockTimeRepo.On("ListByTask", ctx, "task-123").Return(entries, nil)
+
+	result, err := s.usecase.TaskTime(ctx, "task-123")
+
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), entries, result.Entries)
+	assert.Equal(s.T(), 90*time.Minute, result.Total)
+}
+
+// TestTaskTime_Fails_When_TaskIsHidden tests that time on tasks the actor cannot read is not listed.
+func (s *TimeUsecaseTestSuite) TestTaskTime_Fails_When_TaskIsHidden() {
+	ctx := as("user-1", domain.RoleMember)
+	s.mockTasks.On("Get", ctx, "task-123").Return(domain.Task{}, ErrNotFound)
+
+	_, err := s.usecase.TaskTime(ctx, "task-123")
+
+	assert.ErrorIs(s.T(), err, ErrNotFound)
+}
+
+// --- Test Cases for the Timesheet Method ---
+
+// TestTimesheet tests that entries are clipped to the range and split at midnight in the actor's time zone, and that
+// running timers count up to now.
+func (s *TimeUsecaseTestSuite) TestTimesheet() {
+	// ARRANGE: Addis Ababa is UTC+3, so 1 and 2 January run from 21:00 UTC on 31 December to 21:00 UTC on 2 January.
+	ctx := as("user-1", domain.RoleMember)
+	s.mockUserRepo.On("FindByID", ctx, "user-1").Return(domain.User{ID: "user-1", TimeZone: "Africa/Addis_Ababa"}, nil)
+	loc, _ := time.LoadLocation("Africa/Addis_Ababa")
+	first := time.Date(2025, 1, 1, 0, 0, 0, 0, loc)
+	utc := func(day, hour int) time.Time { return time.Date(2025, 1, day, hour, 0, 0, 0, time.UTC) }
+	s.mockTimeRepo.On("ListByUser", ctx, "user-1", first, first.AddDate(0, 0, 2)).Return([]domain.TimeEntry{
+		{TaskID: "task-a", Start: time.Date(2024, 12, 31, 20, 0, 0, 0, time.UTC), End: utc(1, 0)},
+		{TaskID: "task-b", Start: utc(1, 20), End: utc(1, 22)},
+		{TaskID: "task-a", Start: utc(2, 10)},
+	}, nil)
+	s.mockTasks.On("Get", ctx, "task-a").Return(domain.Task{ID: "task-a", Title: "Beta"}, nil).Once()
+	s.mockTasks.On("Get", ctx, "task-b").Return(domain.Task{ID: "task-b", Title: "Alpha"}, nil).Once()
+
+	// ACT
+	sheet, err := s.usecase.Timesheet(ctx, "2025-01-01", "2025-01-02")
+
+	// ASSERT
+	s.Require().NoError(err)
+	assert.Equal(s.T(), first, sheet.From)
+	assert.Equal(s.T(), first.AddDate(0, 0, 1), sheet.To)
+	assert.Equal(s.T(), []domain.TimesheetDay{
+		{Date: first, Total: 4 * time.Hour, Tasks: []domain.TimesheetTask{
+			{TaskID: "task-b", Title: "Alpha", Duration: time.Hour},
+			{TaskID: "task-a", Title: "Beta", Duration: 3 * time.Hour},
+		}},
+		{Date: first.AddDate(0, 0, 1), Total: 3 * time.Hour, Tasks: []domain.TimesheetTask{
+			{TaskID: "task-b", Title: "Alpha", Duration: time.Hour},
+			{TaskID: "task-a", Title: "Beta", Duration: 2 * time.Hour},
+		}},
+	}, sheet.Days)
+	assert.Equal(s.T(), 7*time.Hour, sheet.Total)
+}
+
+// TestTimesheet_HidesTitlesOfUnreadableTasks tests that tasks the actor can no longer read keep their time but show
+// a placeholder instead of their title.
+func (s *TimeUsecaseTestSuite) TestTimesheet_HidesTitlesOfUnreadableTasks() {
+	// ARRANGE
+	ctx := as("user-1", domain.RoleMember)
+	day := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	s.mockUserRepo.On("FindByID", ctx, "user-1").Return(domain.User{ID: "user-1"}, nil)
+	s.mockTimeRepo.On("ListByUser", ctx, "user-1", day, day.AddDate(0, 0, 1)).Return([]domain.TimeEntry{
+		{TaskID: "task-a", Start: day.Add(9 * time.Hour), End: day.Add(10 * time.Hour)},
+		{TaskID: "task-b", Start: day.Add(11 * time.Hour), End: day.Add(13 * time.Hour)},
+	}, nil)
+	s.mockTasks.On("Get", ctx, "task-a").Return(domain.Task{ID: "task-a", Title: "Visible"}, nil)
+	s.mockTasks.On("Get", ctx, "task-b").Return(domain.Task{}, ErrNotFound)
+
+	// ACT
+	sheet, err := s.usecase.Timesheet(ctx, "2025-01-01", "2025-01-01")
+
+	// ASSERT
+	s.Require().NoError(err)
+	s.Require().Len(sheet.Days, 1)
+	assert.Equal(s.T(), []domain.TimesheetTask{
+		{TaskID: "task-b", Title: domain.HiddenTaskTitle, Duration: 2 * time.Hour},
+		{TaskID: "task-a", Title: "Visible", Duration: time.Hour},
+	}, sheet.Days[0].Tasks)
+	assert.Equal(s.T(), 3*time.Hour, sheet.Total)
+}
+
+// TestTimesheet_DefaultsToThisWeek tests that omitting the range reports the current week from Monday to Sunday.
+func (s *TimeUsecaseTestSuite) TestTimesheet_DefaultsToThisWeek() {
+	ctx := as("user-1", domain.RoleMember)
+	s.mockUserRepo.On("FindByID", ctx, "user-1").Return(domain.User{ID: "user-1"}, nil)
+	monday := time.Date(2024, 12, 30, 0, 0, 0, 0, time.UTC)
+	s.mockTimeRepo.On("ListByUser", ctx, "user-1", monday, monday.AddDate(0, 0, 7)).Return(nil, nil)
+
+	sheet, err := s.usecase.Timesheet(ctx, "", "")
+
+	s.Require().NoError(err)
+	assert.Equal(s.T(), monday, sheet.From)
+	assert.Equal(s.T(), monday.AddDate(0, 0, 6), sheet.To)
+	assert.Empty(s.T(), sheet.Days)
+}
+
+// TestTimesheet_Fails_When_RangeIsInvalid tests that malformed, reversed and overlong ranges are rejected.
+func (s *TimeUsecaseTestSuite) TestTimesheet_Fails_When_RangeIsInvalid() {
+	cases := []struct {
+		name, from, to string
+		wantDetail     string
+	}{
+		{"only from", "2025-01-01", "", "from and to must be given together"},
+		{"malformed", "01/01/2025", "2025-01-02", "from must be a date in YYYY-MM-DD format"},
+		{"reversed", "2025-01-02", "2025-01-01", "to must not be before from"},
+		{"too long", "2025-01-01", "2026-01-02", "a timesheet cannot cover more than 366 days"},
+	}
+	for _, tc := range cases {
+		s.Run(tc.name, func() {
+			ctx := as("user-1", domain.RoleMember)
+			s.mockUserRepo.On("FindByID", ctx, "user-1").Return(domain.User{ID: "user-1"}, nil)
+
+			_, err := s.usecase.Timesheet(ctx, tc.from, tc.to)
+
+			assert.ErrorIs(s.T(), err, ErrInvalidTimeEntryRequest)
+			_, detail := Describe(err)
+			assert.Equal(s.T(), tc.wantDetail, detail)
+		})
+	}
+}
+
+// TestTimesheet_Fails_When_ActorCannotReadOwnTasks tests that timesheets require task.read.own.
+func (s *TimeUsecaseTestSuite) TestTimesheet_Fails_When_ActorCannotReadOwnTasks() {
+	_, err := s.usecase.Timesheet(as("user-1", "auditor"), "", "")
+
+	assert.ErrorIs(s.T(), err, ErrForbidden)
+}
@@ -2,49 +2,309 @@ package usecase
 
 import (
 	"context"
+	"errors"
+	"sync"
 	"task_manager_test/internal/domain"
+	"time"
 )
 
+// unknownUserPassword is hashed for logins of unknown usernames to be compared against; it is no user's password.
+const unknownUserPassword = "unknown user"
+
 // UserUsecase defines the business logic operations related to user management.
 type UserUsecase interface {
 	Register(ctx context.Context, u domain.User) error
 	Login(ctx context.Context, username, password string) (string, error)
+	ValidateSession(ctx context.Context, userID string, tokenVersion int) error
+	// ChangeRole assigns a role to a user of the caller's organization. The caller must hold the user.manage
+	// permission, and be a super admin to grant or revoke the super-admin role.
+	ChangeRole(ctx context.Context, username string, role domain.Role) error
+	// Usernames resolves user IDs to usernames for display. IDs of users that no longer exist are left out.
+	Usernames(ctx context.Context, ids []string) (map[string]string, error)
+
+	// ListUsers returns every user of the caller's organization, without password hashes. The caller must hold the user.manage permission.
+	ListUsers(ctx context.Context) ([]domain.User, error)
+	// SetPassword replaces a user's password without knowing the current one. The caller must hold the user.manage
+	// permission, and be a super admin if the user is one.
+	SetPassword(ctx context.Context, username, password string) error
+	// SetDisabled disables or re-enables a user. The caller must hold the user.manage permission, and be a super admin
+	// if the user is one.
+	SetDisabled(ctx context.Context, username string, disabled bool) error
+
+	// Me returns the actor's own account, without its password hash.
+	Me(ctx context.Context) (domain.User, error)
+	// SetTimeZone sets the IANA time zone the actor's days are counted in; the empty name resets it to UTC.
+	SetTimeZone(ctx context.Context, name string) (domain.User, error)
 }
 
 // userUsecase is the concrete implementation of UserUsecase.
 type userUsecase struct {
 	repo       IUserRepository
+	orgs       IOrganizationRepository
 	pwdService IPasswordService
 	jwtService IJWTService
+	policy     PasswordPolicy
+	lockout    lockoutGuard
+	access     *AccessPolicy
+	now        func() time.Time
+
+	unknownUserOnce sync.Once
+	unknownUserHash string
 }
 
 // NewUserUsecase creates a new instance of userUsecase with dependencies injected.
-func NewUserUsecase(repo IUserRepository, pwd IPasswordService, jwtSvc IJWTService) UserUsecase {
-	return &userUsecase{repo, pwd, jwtSvc}
+func NewUserUsecase(repo IUserRepository, orgs IOrganizationRepository, pwd IPasswordService, jwtSvc IJWTService, policy PasswordPolicy, lockout LockoutPolicy, access *AccessPolicy) UserUsecase {
+	return &userUsecase{repo: repo, orgs: orgs, pwdService: pwd, jwtService: jwtSvc, policy: policy, lockout: lockoutGuard{users: repo, pwd: pwd, policy: lockout}, access: access, now: time.Now}
 }
 
 // Register registers a new user by hashing their password and saving them in the repository.
+// The password must satisfy the configured PasswordPolicy. Users get the policy's default role; requesting any
+// other role requires the user.manage permission, and the super-admin role can only be granted by a super admin.
+// The user joins the organization the context is confined to; anonymous users join the default organization.
 func (u *userUsecase) Register(ctx context.Context, user domain.User) error {
+	if user.Role == "" || user.Role == domain.RoleLegacyUser {
+		user.Role = u.access.DefaultRole()
+	}
+	if !u.access.HasRole(user.Role) {
+		return ErrUnknownRole
+	}
+	if user.Role != u.access.DefaultRole() {
+		if _, err := u.access.Authorize(ctx, domain.PermUserManage); err != nil {
+			return err
+		}
+	}
+	if user.Role == domain.RoleSuperAdmin && !isSuperAdmin(ctx) {
+		return ErrForbidden
+	}
+	orgID, err := u.registrationOrg(ctx)
+	if err != nil {
+		return err
+	}
+	user.OrgID = orgID
+	if err := u.policy.Validate(user.Username, user.Password); err != nil {
+		return err
+	}
 	hashed, err := u.pwdService.Hash(user.Password)
 	if err != nil {
 		return err
 	}
 	user.Password = hashed
-	if user.Role == "" {
-		user.Role = "user"
-	}
-	_, err = u.repo.Create(ctx, user)
+	_, err = u.repo.Create(WithTenant(ctx, orgID), user)
 	return err
 }
 
+// registrationOrg returns the organization a new user joins: the one ctx is confined to, or the default
+// organization when ctx is anonymous or spans every organization.
+func (u *userUsecase) registrationOrg(ctx context.Context) (string, error) {
+	if scope, ok := TenantFromContext(ctx); ok && !scope.All {
+		return scope.OrgID, nil
+	}
+	org, err := u.orgs.FindBySlug(ctx, domain.DefaultOrganizationSlug)
+	if err != nil {
+		return "", err
+	}
+	return org.ID, nil
+}
+
+// isSuperAdmin reports whether the actor of ctx is a super admin.
+func isSuperAdmin(ctx context.Context) bool {
+	actor, ok := ActorFromContext(ctx)
+	return ok && actor.Role == domain.RoleSuperAdmin
+}
+
 // Login validates user credentials and generates a JWT token if successful.
+// The password is compared even while the account is locked, and a locked account is reported like a wrong password,
+// so that logins cannot tell which accounts exist or are locked. Attempts on a locked account do not extend the lock.
+// Usernames are unique across organizations, so the user is looked up in all of them.
 func (u *userUsecase) Login(ctx context.Context, username, password string) (string, error) {
+	ctx = WithAllTenants(ctx)
 	usr, err := u.repo.FindByUsername(ctx, username)
+	if errors.Is(err, ErrNotFound) {
+		// Unknown usernames cost a comparison too, so that response times do not tell which accounts exist.
+		u.pwdService.Compare(u.unknownUserPasswordHash(), password)
+		return "", ErrInvalidCredentials
+	}
 	if err != nil {
 		return "", err
 	}
-	if !u.pwdService.Compare(usr.Password, password) {
+	// A locked account is reported like a wrong password, so that guessing cannot tell the two apart.
+	err = u.lockout.check(ctx, usr, password, u.now())
+	if errors.Is(err, ErrAccountLocked) || errors.Is(err, ErrIncorrectPassword) {
 		return "", ErrInvalidCredentials
 	}
-	return u.jwtService.GenerateToken(usr.Username, usr.Role)
+	if err != nil {
+		return "", err
+	}
+	// Checked after the password so that only the account holder learns the account is disabled.
+	if usr.Disabled {
+		return "", ErrAccountDisabled
+	}
+	return u.jwtService.GenerateToken(usr)
+}
+
+// unknownUserPasswordHash hashes unknownUserPassword on first use, with the configured work factor so that comparing
+// against it takes as long as comparing against a user's password. Should hashing fail, the comparison fails fast.
+func (u *userUsecase) unknownUserPasswordHash() string {
+	u.unknownUserOnce.Do(func() {
+		u.unknownUserHash, _ = u.pwdService.Hash(unknownUserPassword)
+	})
+	return u.unknownUserHash
+}
+
+// ValidateSession rejects tokens issued before the user's sessions were revoked, e.g. by a password change. The user
+// is looked up by ID rather than username, so that the tokens of a deleted account do not come back to life when
+// someone registers its username again. Malformed IDs are reported as ErrNotFound.
+func (u *userUsecase) ValidateSession(ctx context.Context, userID string, tokenVersion int) error {
+	usr, err := u.repo.FindByID(WithAllTenants(ctx), userID)
+	if errors.Is(err, ErrInvalidID) {
+		return ErrNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if usr.Disabled {
+		return ErrAccountDisabled
+	}
+	if usr.TokenVersion != tokenVersion {
+		return ErrSessionRevoked
+	}
+	return nil
+}
+
+// ChangeRole assigns a role to a user, revoking the user's existing sessions so the new role applies immediately.
+func (u *userUsecase) ChangeRole(ctx context.Context, username string, role domain.Role) error {
+	if _, err := u.access.Authorize(ctx, domain.PermUserManage); err != nil {
+		return err
+	}
+	if !u.access.HasRole(role) || role == domain.RoleLegacyUser {
+		return ErrUnknownRole
+	}
+	if role == domain.RoleSuperAdmin && !isSuperAdmin(ctx) {
+		return ErrForbidden
+	}
+	if err := u.guardSuperAdmin(ctx, username); err != nil {
+		return err
+	}
+	return u.repo.UpdateRole(ctx, username, role)
+}
+
+// Usernames looks up each distinct ID once. It only requires an authenticated actor, so callers must pass IDs the
+// actor is already allowed to see, such as the owners of tasks returned to it.
+func (u *userUsecase) Usernames(ctx context.Context, ids []string) (map[string]string, error) {
+	if _, ok := ActorFromContext(ctx); !ok {
+		return nil, ErrForbidden
+	}
+	names := make(map[string]string, len(ids))
+	seen := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		if id == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+		usr, err := u.repo.FindByID(ctx, id)
+		if errors.Is(err, ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		names[id] = usr.Username
+	}
+	return names, nil
+}
+
+// ListUsers returns every user with the password hashes cleared.
+func (u *userUsecase) ListUsers(ctx context.Context) ([]domain.User, error) {
+	if _, err := u.access.Authorize(ctx, domain.PermUserManage); err != nil {
+		return nil, err
+	}
+	users, err := u.repo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := range users {
+		users[i].Password = ""
+	}
+	return users, nil
+}
+
+// SetPassword stores a new password that satisfies the PasswordPolicy, revoking the user's sessions and clearing
+// any lockout.
+func (u *userUsecase) SetPassword(ctx context.Context, username, password string) error {
+	if _, err := u.access.Authorize(ctx, domain.PermUserManage); err != nil {
+		return err
+	}
+	usr, err := u.repo.FindByUsername(ctx, username)
+	if err != nil {
+		return err
+	}
+	if usr.Role == domain.RoleSuperAdmin && !isSuperAdmin(ctx) {
+		return ErrForbidden
+	}
+	if err := u.policy.Validate(usr.Username, password); err != nil {
+		return err
+	}
+	hashed, err := u.pwdService.Hash(password)
+	if err != nil {
+		return err
+	}
+	return u.repo.UpdatePassword(ctx, usr.ID, hashed)
+}
+
+// SetDisabled disables or re-enables a user. Disabling revokes the user's sessions; access tokens stop working while
+// the user is disabled and work again once it is re-enabled.
+func (u *userUsecase) SetDisabled(ctx context.Context, username string, disabled bool) error {
+	if _, err := u.access.Authorize(ctx, domain.PermUserManage); err != nil {
+		return err
+	}
+	if err := u.guardSuperAdmin(ctx, username); err != nil {
+		return err
+	}
+	return u.repo.SetDisabled(ctx, username, disabled)
+}
+
+// Me looks up the actor's account.
+func (u *userUsecase) Me(ctx context.Context) (domain.User, error) {
+	actor, ok := ActorFromContext(ctx)
+	if !ok {
+		return domain.User{}, ErrUnauthenticated
+	}
+	usr, err := u.repo.FindByID(ctx, actor.UserID)
+	if err != nil {
+		return domain.User{}, err
+	}
+	usr.Password = ""
+	return usr, nil
+}
+
+// SetTimeZone validates the zone name and stores it on the actor's account.
+func (u *userUsecase) SetTimeZone(ctx context.Context, name string) (domain.User, error) {
+	if _, err := LoadTimeZone(name); err != nil {
+		return domain.User{}, err
+	}
+	usr, err := u.Me(ctx)
+	if err != nil {
+		return domain.User{}, err
+	}
+	if err := u.repo.SetTimeZone(ctx, usr.ID, name); err != nil {
+		return domain.User{}, err
+	}
+	usr.TimeZone = name
+	return usr, nil
+}
+
+// guardSuperAdmin returns ErrForbidden if the user is a super admin and the actor is not, so that organization
+// admins cannot lock super admins out or demote them.
+func (u *userUsecase) guardSuperAdmin(ctx context.Context, username string) error {
+	if isSuperAdmin(ctx) {
+		return nil
+	}
+	usr, err := u.repo.FindByUsername(ctx, username)
+	if err != nil {
+		return err
+	}
+	if usr.Role == domain.RoleSuperAdmin {
+		return ErrForbidden
+	}
+	return nil
 }
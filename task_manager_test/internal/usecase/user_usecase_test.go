@@ -7,29 +7,54 @@ import (
 
 	"task_manager_test/internal/mocks"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 )
 
+// allTenants matches contexts spanning every organization.
+var allTenants = mock.MatchedBy(func(ctx context.Context) bool {
+	tenant, ok := TenantFromContext(ctx)
+	return ok && tenant.All
+})
+
+// inTenant matches contexts confined to the organization.
+func inTenant(orgID string) any {
+	return mock.MatchedBy(func(ctx context.Context) bool {
+		tenant, _ := TenantFromContext(ctx)
+		return tenant == TenantScope{OrgID: orgID}
+	})
+}
+
 // UserUsecaseTestSuite defines the test suite for the user use case.
 type UserUsecaseTestSuite struct {
 	suite.Suite
 	mockUserRepo *mocks.IUserRepository
+	mockOrgRepo  *mocks.IOrganizationRepository
 	mockPwdSvc   *mocks.IPasswordService
 	mockJwtSvc   *mocks.IJWTService
 	usecase      UserUsecase
+	now          time.Time
 }
 
 // SetupTest is a method from testify/suite. It runs before EACH test in the suite.
 func (s *UserUsecaseTestSuite) SetupTest() {
 	// Create new instances of our mocks for every single test.
 	s.mockUserRepo = mocks.NewIUserRepository(s.T())
+	s.mockOrgRepo = mocks.NewIOrganizationRepository(s.T())
+	s.mockOrgRepo.On("FindBySlug", mock.Anything, domain.DefaultOrganizationSlug).
+		Return(domain.Organization{ID: "org-default", Slug: domain.DefaultOrganizationSlug}, nil).Maybe()
 	s.mockPwdSvc = mocks.NewIPasswordService(s.T())
 	s.mockJwtSvc = mocks.NewIJWTService(s.T())
 
 	// Create a new instance of the use case we're testing, injecting our mock dependencies.
-	s.usecase = NewUserUsecase(s.mockUserRepo, s.mockPwdSvc, s.mockJwtSvc)
+	s.usecase = NewUserUsecase(s.mockUserRepo, s.mockOrgRepo, s.mockPwdSvc, s.mockJwtSvc, NewPasswordPolicy(8, []string{"password123"}), LockoutPolicy{MaxAttempts: 3, Duration: 15 * time.Minute}, DefaultAccessPolicy())
+
+	// Freeze the clock so lockout expiry times are deterministic.
+	s.now = time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	s.usecase.(*userUsecase).now = func() time.Time { return s.now }
 }
 
 // TestUserUsecaseTestSuite is the Go test runner's entry point for this suite.
@@ -49,8 +74,8 @@ func (s *UserUsecaseTestSuite) TestRegister_Success() {
 
 	s.mockPwdSvc.On("Hash", plainPassword).Return(hashedPassword, nil)
 
-	expectedUserInRepo := domain.User{Username: "newuser", Password: hashedPassword, Role: "user"}
-	s.mockUserRepo.On("Create", ctx, expectedUserInRepo).Return(expectedUserInRepo, nil)
+	expectedUserInRepo := domain.User{Username: "newuser", Password: hashedPassword, Role: domain.RoleMember, OrgID: "org-default"}
+	s.mockUserRepo.On("Create", inTenant("org-default"), expectedUserInRepo).Return(expectedUserInRepo, nil)
 
 	// ACT: Call the actual method we are testing.
 	err := s.usecase.Register(ctx, userToRegister)
@@ -67,8 +92,8 @@ func (s *UserUsecaseTestSuite) TestRegister_Fails_When_UserAlreadyExists() {
 
 	s.mockPwdSvc.On("Hash", "password").Return("hashed-password", nil)
 
-	expectedUserInRepo := domain.User{Username: "existinguser", Password: "hashed-password", Role: "user"}
-	s.mockUserRepo.On("Create", ctx, expectedUserInRepo).Return(domain.User{}, ErrUserAlreadyExists)
+	expectedUserInRepo := domain.User{Username: "existinguser", Password: "hashed-password", Role: domain.RoleMember, OrgID: "org-default"}
+	s.mockUserRepo.On("Create", inTenant("org-default"), expectedUserInRepo).Return(domain.User{}, ErrUserAlreadyExists)
 
 	// ACT
 	err := s.usecase.Register(ctx, userToRegister)
@@ -96,6 +121,65 @@ func (s *UserUsecaseTestSuite) TestRegister_Fails_When_HashingFails() {
 	s.mockUserRepo.AssertNotCalled(s.T(), "Create")
 }
 
+// TestRegister_Fails_When_PasswordViolatesPolicy tests that weak passwords are rejected before hashing.
+func (s *UserUsecaseTestSuite) TestRegister_Fails_When_PasswordViolatesPolicy() {
+	cases := map[string]string{
+		"too short":       "short",
+		"breached":        "Password123",
+		"same as account": "newuser-name",
+	}
+	for name, password := range cases {
+		s.Run(name, func() {
+			// ACT
+			err := s.usecase.Register(context.Background(), domain.User{Username: "newuser-name", Password: password})
+
+			// ASSERT
+			var policyErr *PasswordPolicyError
+			assert.ErrorAs(s.T(), err, &policyErr, "The error should explain the policy violation")
+			assert.ErrorIs(s.T(), err, ErrWeakPassword)
+		})
+	}
+	s.mockPwdSvc.AssertNotCalled(s.T(), "Hash")
+	s.mockUserRepo.AssertNotCalled(s.T(), "Create")
+}
+
+// TestRegister_RoleRules tests which roles may be requested at registration and by whom.
+func (s *UserUsecaseTestSuite) TestRegister_RoleRules() {
+	admin := WithActor(context.Background(), Actor{UserID: "admin-1", Username: "root", Role: domain.RoleAdmin, OrgID: "org-1"})
+
+	// Self-registration cannot pick a privileged role.
+	err := s.usecase.Register(context.Background(), domain.User{Username: "eve", Password: "plain-password", Role: domain.RoleAdmin})
+	assert.ErrorIs(s.T(), err, ErrForbidden)
+
+	// Roles outside the policy are rejected even for administrators.
+	err = s.usecase.Register(admin, domain.User{Username: "eve", Password: "plain-password", Role: "superuser"})
+	assert.ErrorIs(s.T(), err, ErrUnknownRole)
+
+	// Only super admins may create super admins.
+	err = s.usecase.Register(admin, domain.User{Username: "eve", Password: "plain-password", Role: domain.RoleSuperAdmin})
+	assert.ErrorIs(s.T(), err, ErrForbidden)
+
+	// A user with user.manage may register a manager, who joins the administrator's organization.
+	s.mockPwdSvc.On("Hash", "plain-password").Return("hashed-password", nil)
+	s.mockUserRepo.On("Create", inTenant("org-1"), domain.User{Username: "mia", Password: "hashed-password", Role: domain.RoleManager, OrgID: "org-1"}).
+		Return(domain.User{}, nil)
+	assert.NoError(s.T(), s.usecase.Register(admin, domain.User{Username: "mia", Password: "plain-password", Role: domain.RoleManager}))
+}
+
+// TestRegister_JoinsTenant tests that an explicit tenant decides the organization, even for a super admin of another.
+func (s *UserUsecaseTestSuite) TestRegister_JoinsTenant() {
+	super := WithActor(context.Background(), Actor{UserID: "root-1", Username: "root", Role: domain.RoleSuperAdmin, OrgID: "org-1"})
+	ctx := WithTenant(super, "org-2")
+	s.mockPwdSvc.On("Hash", "plain-password").Return("hashed-password", nil)
+	s.mockUserRepo.On("Create", inTenant("org-2"), domain.User{Username: "sam", Password: "hashed-password", Role: domain.RoleSuperAdmin, OrgID: "org-2"}).
+		Return(domain.User{}, nil)
+
+	err := s.usecase.Register(ctx, domain.User{Username: "sam", Password: "plain-password", Role: domain.RoleSuperAdmin})
+
+	assert.NoError(s.T(), err)
+	s.mockOrgRepo.AssertNotCalled(s.T(), "FindBySlug", mock.Anything, mock.Anything)
+}
+
 // --- Test Cases for the Login Method ---
 
 // TestLogin_Success tests the "happy path" for user login.
@@ -105,13 +189,13 @@ func (s *UserUsecaseTestSuite) TestLogin_Success() {
 	username := "testuser"
 	plainPassword := "correct-password"
 	hashedPassword := "hashed-password"
-	role := "admin"
+	role := domain.RoleAdmin
 	expectedToken := "a-valid-jwt-token"
 	userFromRepo := domain.User{ID: "user-123", Username: username, Password: hashedPassword, Role: role}
 
-	s.mockUserRepo.On("FindByUsername", ctx, username).Return(userFromRepo, nil)
+	s.mockUserRepo.On("FindByUsername", allTenants, username).Return(userFromRepo, nil)
 	s.mockPwdSvc.On("Compare", hashedPassword, plainPassword).Return(true)
-	s.mockJwtSvc.On("GenerateToken", username, role).Return(expectedToken, nil)
+	s.mockJwtSvc.On("GenerateToken", userFromRepo).Return(expectedToken, nil)
 
 	// ACT
 	token, err := s.usecase.Login(ctx, username, plainPassword)
@@ -121,23 +205,26 @@ func (s *UserUsecaseTestSuite) TestLogin_Success() {
 	assert.Equal(s.T(), expectedToken, token, "The returned token should match the expected token")
 }
 
-// TestLogin_Fails_When_UserNotFound tests the scenario where the username does not exist.
+// TestLogin_Fails_When_UserNotFound tests that an unknown username is reported like a wrong password, after the
+// password has been compared against a hash made once with the configured work factor.
 func (s *UserUsecaseTestSuite) TestLogin_Fails_When_UserNotFound() {
 	// ARRANGE
 	ctx := context.Background()
 	username := "non-existent-user"
 
-	s.mockUserRepo.On("FindByUsername", ctx, username).Return(domain.User{}, ErrNotFound)
+	s.mockUserRepo.On("FindByUsername", allTenants, username).Return(domain.User{}, ErrNotFound)
+	s.mockPwdSvc.On("Hash", unknownUserPassword).Return("unknown-user-hash", nil).Once()
+	s.mockPwdSvc.On("Compare", "unknown-user-hash", "any-password").Return(false).Twice()
 
 	// ACT
 	token, err := s.usecase.Login(ctx, username, "any-password")
+	_, again := s.usecase.Login(ctx, username, "any-password")
 
 	// ASSERT
-	assert.Error(s.T(), err, "Login should return an error when user is not found")
-	assert.ErrorIs(s.T(), err, ErrNotFound, "The error should be ErrNotFound")
+	assert.ErrorIs(s.T(), err, ErrInvalidCredentials, "Unknown users should be reported like wrong passwords")
+	assert.ErrorIs(s.T(), again, ErrInvalidCredentials)
 	assert.Empty(s.T(), token, "No token should be returned on failure")
-	// Assert that subsequent services were never called.
-	s.mockPwdSvc.AssertNotCalled(s.T(), "Compare")
+	s.mockUserRepo.AssertNotCalled(s.T(), "IncrementFailedLogins", mock.Anything, mock.Anything)
 	s.mockJwtSvc.AssertNotCalled(s.T(), "GenerateToken")
 }
 
@@ -150,8 +237,9 @@ func (s *UserUsecaseTestSuite) TestLogin_Fails_When_PasswordIsIncorrect() {
 	hashedPassword := "hashed-password"
 	userFromRepo := domain.User{ID: "user-123", Username: username, Password: hashedPassword}
 
-	s.mockUserRepo.On("FindByUsername", ctx, username).Return(userFromRepo, nil)
+	s.mockUserRepo.On("FindByUsername", allTenants, username).Return(userFromRepo, nil)
 	s.mockPwdSvc.On("Compare", hashedPassword, wrongPassword).Return(false)
+	s.mockUserRepo.On("IncrementFailedLogins", allTenants, username).Return(1, nil)
 
 	// ACT
 	token, err := s.usecase.Login(ctx, username, wrongPassword)
@@ -163,3 +251,312 @@ func (s *UserUsecaseTestSuite) TestLogin_Fails_When_PasswordIsIncorrect() {
 	// Assert that the JWT service was never called.
 	s.mockJwtSvc.AssertNotCalled(s.T(), "GenerateToken")
 }
+
+// --- Test Cases for Login Lockout ---
+
+// TestLogin_LocksAccount_When_ThresholdReached tests that the failed attempt reaching the threshold locks the account,
+// and is reported like any other wrong password.
+func (s *UserUsecaseTestSuite) TestLogin_LocksAccount_When_ThresholdReached() {
+	// ARRANGE
+	ctx := context.Background()
+	userFromRepo := domain.User{ID: "user-123", Username: "testuser", Password: "hashed-password", FailedLoginAttempts: 2}
+	expectedUntil := s.now.Add(15 * time.Minute)
+
+	s.mockUserRepo.On("FindByUsername", allTenants, "testuser").Return(userFromRepo, nil)
+	s.mockPwdSvc.On("Compare", "hashed-password", "wrong-password").Return(false)
+	s.mockUserRepo.On("IncrementFailedLogins", allTenants, "testuser").Return(3, nil)
+	s.mockUserRepo.On("Lock", allTenants, "testuser", expectedUntil).Return(nil)
+
+	// ACT
+	_, err := s.usecase.Login(ctx, "testuser", "wrong-password")
+
+	// ASSERT
+	assert.ErrorIs(s.T(), err, ErrInvalidCredentials, "Reaching the threshold should not reveal that the account exists")
+	assert.NotErrorIs(s.T(), err, ErrAccountLocked)
+	s.mockUserRepo.AssertExpectations(s.T())
+}
+
+// TestLogin_Fails_When_AccountIsLocked tests that a locked account is reported like a wrong password, whether or not
+// the password is correct, and that attempts on it are not counted.
+func (s *UserUsecaseTestSuite) TestLogin_Fails_When_AccountIsLocked() {
+	// ARRANGE
+	ctx := context.Background()
+	lockedUntil := s.now.Add(5 * time.Minute)
+	userFromRepo := domain.User{ID: "user-123", Username: "testuser", Password: "hashed-password", LockedUntil: lockedUntil}
+
+	s.mockUserRepo.On("FindByUsername", allTenants, "testuser").Return(userFromRepo, nil)
+	s.mockPwdSvc.On("Compare", "hashed-password", "correct-password").Return(true)
+	s.mockPwdSvc.On("Compare", "hashed-password", "wrong-password").Return(false)
+
+	for _, password := range []string{"correct-password", "wrong-password"} {
+		// ACT
+		token, err := s.usecase.Login(ctx, "testuser", password)
+
+		// ASSERT
+		assert.ErrorIs(s.T(), err, ErrInvalidCredentials)
+		assert.NotErrorIs(s.T(), err, ErrAccountLocked)
+		assert.Empty(s.T(), token)
+	}
+	s.mockUserRepo.AssertNotCalled(s.T(), "IncrementFailedLogins", mock.Anything, mock.Anything)
+	s.mockJwtSvc.AssertNotCalled(s.T(), "GenerateToken")
+}
+
+// TestLogin_ResetsFailedAttempts_On_Success tests that a successful login after an expired lock clears the counter.
+func (s *UserUsecaseTestSuite) TestLogin_ResetsFailedAttempts_On_Success() {
+	// ARRANGE
+	ctx := context.Background()
+	userFromRepo := domain.User{
+		ID: "user-123", Username: "testuser", Password: "hashed-password", Role: "user",
+		FailedLoginAttempts: 1, LockedUntil: s.now.Add(-time.Minute),
+	}
+
+	s.mockUserRepo.On("FindByUsername", allTenants, "testuser").Return(userFromRepo, nil)
+	s.mockPwdSvc.On("Compare", "hashed-password", "correct-password").Return(true)
+	s.mockUserRepo.On("ResetFailedLogins", allTenants, "testuser").Return(nil)
+	s.mockJwtSvc.On("GenerateToken", userFromRepo).Return("a-valid-jwt-token", nil)
+
+	// ACT
+	token, err := s.usecase.Login(ctx, "testuser", "correct-password")
+
+	// ASSERT
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), "a-valid-jwt-token", token)
+}
+
+// TestLogin_DoesNotTrackAttempts_When_LockoutDisabled tests that a zero threshold disables counting entirely.
+func (s *UserUsecaseTestSuite) TestLogin_DoesNotTrackAttempts_When_LockoutDisabled() {
+	// ARRANGE
+	ctx := context.Background()
+	uc := NewUserUsecase(s.mockUserRepo, s.mockOrgRepo, s.mockPwdSvc, s.mockJwtSvc, PasswordPolicy{}, LockoutPolicy{}, DefaultAccessPolicy())
+	userFromRepo := domain.User{Username: "testuser", Password: "hashed-password"}
+
+	s.mockUserRepo.On("FindByUsername", allTenants, "testuser").Return(userFromRepo, nil)
+	s.mockPwdSvc.On("Compare", "hashed-password", "wrong-password").Return(false)
+
+	// ACT
+	_, err := uc.Login(ctx, "testuser", "wrong-password")
+
+	// ASSERT
+	assert.ErrorIs(s.T(), err, ErrInvalidCredentials)
+	s.mockUserRepo.AssertNotCalled(s.T(), "IncrementFailedLogins")
+}
+
+// --- Test Cases for ValidateSession ---
+
+// TestValidateSession_Success tests that a token carrying the current version is accepted.
+func (s *UserUsecaseTestSuite) TestValidateSession_Success() {
+	ctx := context.Background()
+	s.mockUserRepo.On("FindByID", allTenants, "user-1").Return(domain.User{ID: "user-1", Username: "testuser", TokenVersion: 2}, nil)
+
+	err := s.usecase.ValidateSession(ctx, "user-1", 2)
+
+	assert.NoError(s.T(), err)
+}
+
+// TestValidateSession_Fails_When_VersionIsStale tests that tokens issued before a password change are revoked.
+func (s *UserUsecaseTestSuite) TestValidateSession_Fails_When_VersionIsStale() {
+	ctx := context.Background()
+	s.mockUserRepo.On("FindByID", allTenants, "user-1").Return(domain.User{ID: "user-1", Username: "testuser", TokenVersion: 3}, nil)
+
+	err := s.usecase.ValidateSession(ctx, "user-1", 2)
+
+	assert.ErrorIs(s.T(), err, ErrSessionRevoked)
+}
+
+// TestValidateSession_Fails_When_AccountIsDisabled tests that disabled accounts cannot use their tokens.
+func (s *UserUsecaseTestSuite) TestValidateSession_Fails_When_AccountIsDisabled() {
+	ctx := context.Background()
+	s.mockUserRepo.On("FindByID", allTenants, "user-1").Return(domain.User{ID: "user-1", Username: "testuser", TokenVersion: 2, Disabled: true}, nil)
+
+	err := s.usecase.ValidateSession(ctx, "user-1", 2)
+
+	assert.ErrorIs(s.T(), err, ErrAccountDisabled)
+}
+
+// TestValidateSession_Fails_When_UsernameWasRegisteredAgain tests that the tokens of a deleted account are refused
+// once someone registers its username again, even though the new account starts at the same token version.
+func (s *UserUsecaseTestSuite) TestValidateSession_Fails_When_UsernameWasRegisteredAgain() {
+	// ARRANGE: the old account, user-1, was deleted; its username now belongs to user-2.
+	ctx := context.Background()
+	s.mockPwdSvc.On("Hash", "another-password").Return("hashed-password", nil)
+	newcomer := domain.User{ID: "user-2", Username: "testuser", Password: "hashed-password", Role: domain.RoleMember, OrgID: "org-default"}
+	s.mockUserRepo.On("Create", inTenant("org-default"), mock.Anything).Return(newcomer, nil)
+	s.mockUserRepo.On("FindByUsername", allTenants, "testuser").Return(newcomer, nil).Maybe()
+	s.mockUserRepo.On("FindByID", allTenants, "user-1").Return(domain.User{}, ErrNotFound)
+	s.mockUserRepo.On("FindByID", allTenants, "user-2").Return(newcomer, nil)
+	s.Require().NoError(s.usecase.Register(ctx, domain.User{Username: "testuser", Password: "another-password"}))
+
+	// ACT
+	oldErr := s.usecase.ValidateSession(ctx, "user-1", 0)
+	newErr := s.usecase.ValidateSession(ctx, "user-2", 0)
+
+	// ASSERT
+	assert.ErrorIs(s.T(), oldErr, ErrNotFound, "The deleted account's token should be refused")
+	assert.NoError(s.T(), newErr)
+}
+
+// TestValidateSession_Fails_When_TokenHasNoUserID tests that tokens without a well-formed subject are refused like
+// tokens of a missing user.
+func (s *UserUsecaseTestSuite) TestValidateSession_Fails_When_TokenHasNoUserID() {
+	s.mockUserRepo.On("FindByID", allTenants, "").Return(domain.User{}, ErrInvalidID)
+
+	err := s.usecase.ValidateSession(context.Background(), "", 0)
+
+	assert.ErrorIs(s.T(), err, ErrNotFound)
+}
+
+// --- Test Cases for the ChangeRole Method ---
+
+// TestChangeRole_Success tests that a user with user.manage can assign a role.
+func (s *UserUsecaseTestSuite) TestChangeRole_Success() {
+	ctx := WithActor(context.Background(), Actor{UserID: "admin-1", Username: "root", Role: domain.RoleAdmin})
+	s.mockUserRepo.On("FindByUsername", ctx, "alice").Return(domain.User{Username: "alice", Role: domain.RoleMember}, nil)
+	s.mockUserRepo.On("UpdateRole", ctx, "alice", domain.RoleManager).Return(nil)
+
+	err := s.usecase.ChangeRole(ctx, "alice", domain.RoleManager)
+
+	assert.NoError(s.T(), err)
+}
+
+// TestChangeRole_Fails tests the authorization and validation rules of ChangeRole.
+func (s *UserUsecaseTestSuite) TestChangeRole_Fails() {
+	admin := WithActor(context.Background(), Actor{UserID: "admin-1", Username: "root", Role: domain.RoleAdmin})
+	manager := WithActor(context.Background(), Actor{UserID: "manager-1", Username: "mia", Role: domain.RoleManager})
+
+	assert.ErrorIs(s.T(), s.usecase.ChangeRole(manager, "alice", domain.RoleAdmin), ErrForbidden)
+	assert.ErrorIs(s.T(), s.usecase.ChangeRole(admin, "alice", "superuser"), ErrUnknownRole)
+	assert.ErrorIs(s.T(), s.usecase.ChangeRole(admin, "alice", domain.RoleLegacyUser), ErrUnknownRole)
+	assert.ErrorIs(s.T(), s.usecase.ChangeRole(admin, "alice", domain.RoleSuperAdmin), ErrForbidden)
+	s.mockUserRepo.On("FindByUsername", admin, "root").Return(domain.User{Username: "root", Role: domain.RoleSuperAdmin}, nil)
+	assert.ErrorIs(s.T(), s.usecase.ChangeRole(admin, "root", domain.RoleMember), ErrForbidden, "Org admins cannot demote super admins")
+	s.mockUserRepo.AssertNotCalled(s.T(), "UpdateRole")
+}
+
+// TestChangeRole_SuperAdmin tests that super admins may grant the super-admin role without looking the user up.
+func (s *UserUsecaseTestSuite) TestChangeRole_SuperAdmin() {
+	ctx := WithActor(context.Background(), Actor{UserID: "root-1", Username: "root", Role: domain.RoleSuperAdmin})
+	s.mockUserRepo.On("UpdateRole", ctx, "alice", domain.RoleSuperAdmin).Return(nil)
+
+	assert.NoError(s.T(), s.usecase.ChangeRole(ctx, "alice", domain.RoleSuperAdmin))
+}
+
+// --- Test Cases for the Usernames Method ---
+
+// TestUsernames_Success tests that each distinct ID is looked up once and missing users are left out.
+func (s *UserUsecaseTestSuite) TestUsernames_Success() {
+	ctx := WithActor(context.Background(), Actor{UserID: "user-1", Username: "alice", Role: domain.RoleMember})
+	s.mockUserRepo.On("FindByID", ctx, "user-1").Return(domain.User{ID: "user-1", Username: "alice"}, nil).Once()
+	s.mockUserRepo.On("FindByID", ctx, "gone").Return(domain.User{}, ErrNotFound).Once()
+
+	names, err := s.usecase.Usernames(ctx, []string{"user-1", "", "gone", "user-1"})
+
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), map[string]string{"user-1": "alice"}, names)
+}
+
+// TestUsernames_Fails tests that lookups require an actor and surface repository failures.
+func (s *UserUsecaseTestSuite) TestUsernames_Fails() {
+	_, err := s.usecase.Usernames(context.Background(), []string{"user-1"})
+	assert.ErrorIs(s.T(), err, ErrForbidden)
+
+	ctx := WithActor(context.Background(), Actor{UserID: "user-1", Username: "alice", Role: domain.RoleMember})
+	dbErr := errors.New("connection reset")
+	s.mockUserRepo.On("FindByID", ctx, "user-1").Return(domain.User{}, dbErr)
+	_, err = s.usecase.Usernames(ctx, []string{"user-1"})
+	assert.ErrorIs(s.T(), err, dbErr)
+}
+
+// --- Test Cases for the operator methods ---
+
+// TestLogin_Fails_When_AccountIsDisabled tests that disabled accounts cannot log in, and that this is only
+// revealed to callers who know the password.
+func (s *UserUsecaseTestSuite) TestLogin_Fails_When_AccountIsDisabled() {
+	ctx := context.Background()
+	user := domain.User{Username: "testuser", Password: "hashed", Disabled: true}
+	s.mockUserRepo.On("FindByUsername", allTenants, "testuser").Return(user, nil)
+	s.mockPwdSvc.On("Compare", "hashed", "right").Return(true).Once()
+	s.mockPwdSvc.On("Compare", "hashed", "wrong").Return(false).Once()
+	s.mockUserRepo.On("IncrementFailedLogins", allTenants, "testuser").Return(1, nil).Once()
+
+	_, err := s.usecase.Login(ctx, "testuser", "right")
+	assert.ErrorIs(s.T(), err, ErrAccountDisabled)
+
+	_, err = s.usecase.Login(ctx, "testuser", "wrong")
+	assert.ErrorIs(s.T(), err, ErrInvalidCredentials)
+	s.mockJwtSvc.AssertNotCalled(s.T(), "GenerateToken")
+}
+
+// TestListUsers tests that users are listed without their password hashes, to callers holding user.manage only.
+func (s *UserUsecaseTestSuite) TestListUsers() {
+	admin := WithActor(context.Background(), Actor{UserID: "admin-1", Username: "root", Role: domain.RoleAdmin})
+	s.mockUserRepo.On("List", admin).Return([]domain.User{{Username: "alice", Password: "hashed", Role: domain.RoleMember}}, nil)
+
+	users, err := s.usecase.ListUsers(admin)
+
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), []domain.User{{Username: "alice", Role: domain.RoleMember}}, users)
+
+	manager := WithActor(context.Background(), Actor{UserID: "manager-1", Username: "mia", Role: domain.RoleManager})
+	_, err = s.usecase.ListUsers(manager)
+	assert.ErrorIs(s.T(), err, ErrForbidden)
+}
+
+// TestSetPassword tests that operators can set a password that satisfies the policy.
+func (s *UserUsecaseTestSuite) TestSetPassword() {
+	admin := WithActor(context.Background(), Actor{UserID: "admin-1", Username: "root", Role: domain.RoleAdmin})
+	s.mockUserRepo.On("FindByUsername", admin, "alice").Return(domain.User{ID: "user-1", Username: "alice"}, nil)
+	s.mockPwdSvc.On("Hash", "a-new-passphrase").Return("hashed", nil)
+	s.mockUserRepo.On("UpdatePassword", admin, "user-1", "hashed").Return(nil).Once()
+
+	assert.NoError(s.T(), s.usecase.SetPassword(admin, "alice", "a-new-passphrase"))
+	assert.ErrorIs(s.T(), s.usecase.SetPassword(admin, "alice", "short"), ErrWeakPassword)
+
+	manager := WithActor(context.Background(), Actor{UserID: "manager-1", Username: "mia", Role: domain.RoleManager})
+	assert.ErrorIs(s.T(), s.usecase.SetPassword(manager, "alice", "a-new-passphrase"), ErrForbidden)
+
+	s.mockUserRepo.On("FindByUsername", admin, "root").Return(domain.User{ID: "root-1", Username: "root", Role: domain.RoleSuperAdmin}, nil)
+	assert.ErrorIs(s.T(), s.usecase.SetPassword(admin, "root", "a-new-passphrase"), ErrForbidden, "Org admins cannot take over super admins")
+}
+
+// TestSetDisabled tests that disabling requires user.manage.
+func (s *UserUsecaseTestSuite) TestSetDisabled() {
+	admin := WithActor(context.Background(), Actor{UserID: "admin-1", Username: "root", Role: domain.RoleAdmin})
+	s.mockUserRepo.On("FindByUsername", admin, "alice").Return(domain.User{Username: "alice", Role: domain.RoleMember}, nil)
+	s.mockUserRepo.On("SetDisabled", admin, "alice", true).Return(nil).Once()
+
+	assert.NoError(s.T(), s.usecase.SetDisabled(admin, "alice", true))
+
+	s.mockUserRepo.On("FindByUsername", admin, "boss").Return(domain.User{Username: "boss", Role: domain.RoleSuperAdmin}, nil)
+	assert.ErrorIs(s.T(), s.usecase.SetDisabled(admin, "boss", true), ErrForbidden)
+
+	member := WithActor(context.Background(), Actor{UserID: "user-1", Username: "alice", Role: domain.RoleMember})
+	assert.ErrorIs(s.T(), s.usecase.SetDisabled(member, "alice", false), ErrForbidden)
+}
+
+// TestMe tests that the actor's account is returned without its password hash.
+func (s *UserUsecaseTestSuite) TestMe() {
+	ctx := WithActor(context.Background(), Actor{UserID: "user-1", Username: "alice", Role: domain.RoleMember})
+	s.mockUserRepo.On("FindByID", ctx, "user-1").Return(domain.User{ID: "user-1", Username: "alice", Password: "hash", TimeZone: "Europe/Paris"}, nil)
+
+	usr, err := s.usecase.Me(ctx)
+
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), domain.User{ID: "user-1", Username: "alice", TimeZone: "Europe/Paris"}, usr)
+
+	_, err = s.usecase.Me(context.Background())
+	assert.ErrorIs(s.T(), err, ErrUnauthenticated)
+}
+
+// TestSetTimeZone tests that only IANA zone names are stored.
+func (s *UserUsecaseTestSuite) TestSetTimeZone() {
+	ctx := WithActor(context.Background(), Actor{UserID: "user-1", Username: "alice", Role: domain.RoleMember})
+	s.mockUserRepo.On("FindByID", ctx, "user-1").Return(domain.User{ID: "user-1", Username: "alice"}, nil)
+	s.mockUserRepo.On("SetTimeZone", ctx, "user-1", "America/Los_Angeles").Return(nil).Once()
+
+	usr, err := s.usecase.SetTimeZone(ctx, "America/Los_Angeles")
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), "America/Los_Angeles", usr.TimeZone)
+
+	_, err = s.usecase.SetTimeZone(ctx, "Pacific Time")
+	assert.ErrorIs(s.T(), err, ErrInvalidTimeZone)
+}